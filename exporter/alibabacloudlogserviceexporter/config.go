@@ -14,7 +14,19 @@
 
 package alibabacloudlogserviceexporter
 
-import "go.opentelemetry.io/collector/config/configmodels"
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	// defaultMaxBatchSize is the maximum number of logs of a PutLogs request
+	// accepted by LogService.
+	defaultMaxBatchSize  = 4096
+	defaultMaxRetries    = 3
+	defaultRetryInterval = time.Second
+)
 
 // Config defines configuration for AlibabaCloud Log Service exporter.
 type Config struct {
@@ -33,4 +45,11 @@ type Config struct {
 	AccessKeySecret string `mapstructure:"access_key_secret"`
 	// Set AlibabaCLoud ECS ram role if you are using ACK
 	ECSRamRole string `mapstructure:"ecs_ram_role"`
+	// Maximum number of logs sent to LogService in one request, the data of a push being split
+	// in several requests if needed. Defaults to 4096, the LogService limit.
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+	// Number of times a failed request is retried before the data is dropped. Defaults to 3.
+	MaxRetries int `mapstructure:"max_retries"`
+	// Wait before the first retry of a failed request, doubled at each retry. Defaults to 1s.
+	RetryInterval time.Duration `mapstructure:"retry_interval"`
 }
@@ -17,6 +17,7 @@ package alibabacloudlogserviceexporter
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,6 +61,9 @@ func TestLoadConfig(t *testing.T) {
 		AccessKeyID:     "test-id",
 		AccessKeySecret: "test-secret",
 		ECSRamRole:      "test-role",
+		MaxBatchSize:    1024,
+		MaxRetries:      5,
+		RetryInterval:   2 * time.Second,
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
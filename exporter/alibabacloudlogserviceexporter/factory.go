@@ -41,6 +41,9 @@ func (f *Factory) CreateDefaultConfig() configmodels.Exporter {
 			TypeVal: configmodels.Type(typeStr),
 			NameVal: typeStr,
 		},
+		MaxBatchSize:  defaultMaxBatchSize,
+		MaxRetries:    defaultMaxRetries,
+		RetryInterval: defaultRetryInterval,
 	}
 }
 
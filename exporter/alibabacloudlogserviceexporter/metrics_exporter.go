@@ -52,7 +52,7 @@ func (s *logServiceMetricsSender) pushMetricsData(
 ) (droppedTimeSeries int, err error) {
 	logs, droppedTimeSeries := metricsDataToLogServiceData(s.logger, td)
 	if len(logs) > 0 {
-		err = s.client.SendLogs(logs)
+		err = s.client.SendLogs(ctx, logs)
 	}
 	return droppedTimeSeries, err
 }
@@ -51,5 +51,5 @@ func (s *logServiceTraceSender) pushTraceData(
 	td consumerdata.TraceData,
 ) (droppedSpans int, err error) {
 	logs := traceDataToLogServiceData(td)
-	return 0, s.client.SendLogs(logs)
+	return 0, s.client.SendLogs(ctx, logs)
 }
@@ -15,9 +15,11 @@
 package alibabacloudlogserviceexporter
 
 import (
+	"context"
 	"errors"
 	"net"
 	"os"
+	"time"
 
 	sls "github.com/aliyun/aliyun-log-go-sdk"
 	"github.com/gogo/protobuf/proto"
@@ -27,7 +29,7 @@ import (
 // LogServiceClient log Service's client wrapper
 type LogServiceClient interface {
 	// SendLogs send message to LogService
-	SendLogs(logs []*sls.Log) error
+	SendLogs(ctx context.Context, logs []*sls.Log) error
 }
 
 type logServiceClientImpl struct {
@@ -36,6 +38,10 @@ type logServiceClientImpl struct {
 	logstore       string
 	topic          string
 	source         string
+	maxBatchSize   int
+	maxRetries     int
+	retryInterval  time.Duration
+	logger         *zap.Logger
 }
 
 func getIPAddress() (ipAddress string, err error) {
@@ -62,6 +68,19 @@ func NewLogServiceClient(config *Config, logger *zap.Logger) (LogServiceClient,
 		project:        config.Project,
 		logstore:       config.Logstore,
 		clientInstance: clientInterface,
+		maxBatchSize:   config.MaxBatchSize,
+		maxRetries:     config.MaxRetries,
+		retryInterval:  config.RetryInterval,
+		logger:         logger,
+	}
+	if c.maxBatchSize <= 0 || c.maxBatchSize > defaultMaxBatchSize {
+		c.maxBatchSize = defaultMaxBatchSize
+	}
+	if c.maxRetries < 0 {
+		c.maxRetries = 0
+	}
+	if c.retryInterval <= 0 {
+		c.retryInterval = defaultRetryInterval
 	}
 	// do not return error if get hostname or ip address fail
 	c.topic, _ = os.Hostname()
@@ -70,12 +89,40 @@ func NewLogServiceClient(config *Config, logger *zap.Logger) (LogServiceClient,
 	return c, nil
 }
 
-// SendLogs send message to LogService
-func (c *logServiceClientImpl) SendLogs(logs []*sls.Log) error {
-	logGroup := &sls.LogGroup{
-		Source: proto.String(c.source),
-		Topic:  proto.String(c.topic),
-		Logs:   logs,
+// SendLogs send message to LogService, in batches of at most maxBatchSize logs
+func (c *logServiceClientImpl) SendLogs(ctx context.Context, logs []*sls.Log) error {
+	for start := 0; start < len(logs); start += c.maxBatchSize {
+		end := start + c.maxBatchSize
+		if end > len(logs) {
+			end = len(logs)
+		}
+		logGroup := &sls.LogGroup{
+			Source: proto.String(c.source),
+			Topic:  proto.String(c.topic),
+			Logs:   logs[start:end],
+		}
+		if err := c.putLogs(ctx, logGroup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putLogs sends a log group, retrying up to maxRetries times with an exponential backoff
+func (c *logServiceClientImpl) putLogs(ctx context.Context, logGroup *sls.LogGroup) error {
+	interval := c.retryInterval
+	for retry := 0; ; retry++ {
+		err := c.clientInstance.PutLogs(c.project, c.logstore, logGroup)
+		if err == nil || retry >= c.maxRetries {
+			return err
+		}
+		c.logger.Debug("Retrying to send logs to LogService", zap.Int("retry", retry+1), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		interval *= 2
 	}
-	return c.clientInstance.PutLogs(c.project, c.logstore, logGroup)
 }
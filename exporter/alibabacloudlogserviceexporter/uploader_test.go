@@ -0,0 +1,90 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibabacloudlogserviceexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sls "github.com/aliyun/aliyun-log-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeClient records the log groups it is sent, failing the first failures
+// calls.
+type fakeClient struct {
+	sls.ClientInterface
+	failures  int
+	logGroups []*sls.LogGroup
+}
+
+func (c *fakeClient) PutLogs(project, logstore string, lg *sls.LogGroup) error {
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("unavailable")
+	}
+	c.logGroups = append(c.logGroups, lg)
+	return nil
+}
+
+func newTestClient(fake *fakeClient, maxBatchSize, maxRetries int) *logServiceClientImpl {
+	return &logServiceClientImpl{
+		clientInstance: fake,
+		project:        "demo-project",
+		logstore:       "demo-logstore",
+		maxBatchSize:   maxBatchSize,
+		maxRetries:     maxRetries,
+		retryInterval:  time.Millisecond,
+		logger:         zap.NewNop(),
+	}
+}
+
+func TestSendLogsBatches(t *testing.T) {
+	fake := &fakeClient{}
+	c := newTestClient(fake, 2, 0)
+
+	logs := make([]*sls.Log, 5)
+	for i := range logs {
+		logs[i] = &sls.Log{}
+	}
+	require.NoError(t, c.SendLogs(context.Background(), logs))
+
+	require.Len(t, fake.logGroups, 3)
+	assert.Len(t, fake.logGroups[0].Logs, 2)
+	assert.Len(t, fake.logGroups[1].Logs, 2)
+	assert.Len(t, fake.logGroups[2].Logs, 1)
+}
+
+func TestSendLogsRetries(t *testing.T) {
+	fake := &fakeClient{failures: 2}
+	c := newTestClient(fake, defaultMaxBatchSize, 2)
+	require.NoError(t, c.SendLogs(context.Background(), []*sls.Log{{}}))
+	assert.Len(t, fake.logGroups, 1)
+
+	fake = &fakeClient{failures: 3}
+	c = newTestClient(fake, defaultMaxBatchSize, 2)
+	assert.Error(t, c.SendLogs(context.Background(), []*sls.Log{{}}))
+	assert.Empty(t, fake.logGroups)
+
+	fake = &fakeClient{failures: 1}
+	c = newTestClient(fake, defaultMaxBatchSize, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, c.SendLogs(ctx, []*sls.Log{{}}))
+}
@@ -0,0 +1,58 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cwlogs"
+)
+
+// Config defines configuration for AWS CloudWatch Logs exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	// LogGroupName is the template of the name of the CloudWatch log group of
+	// the log records. The {attribute} placeholders are replaced by the values
+	// of the resource attributes, or "undefined" if they are missing.
+	LogGroupName string `mapstructure:"log_group_name"`
+	// LogStreamName is the template of the name of the CloudWatch log stream
+	// of the log records, with the same placeholders as LogGroupName.
+	LogStreamName string `mapstructure:"log_stream_name"`
+	// RawLog sends the body of the log records as the log event messages,
+	// instead of a JSON object holding the body, severity, trace context and
+	// attributes of the records.
+	RawLog bool `mapstructure:"raw_log"`
+	// AWSSessionSettings configures the AWS session sending the log events.
+	cwlogs.AWSSessionSettings `mapstructure:",squash"`
+}
+
+// validate checks the configuration and parses the name templates.
+func (cfg *Config) validate() (group, stream *nameTemplate, err error) {
+	if cfg.LogGroupName == "" {
+		return nil, nil, fmt.Errorf("%q config requires a non-empty \"log_group_name\"", cfg.Name())
+	}
+	if cfg.LogStreamName == "" {
+		return nil, nil, fmt.Errorf("%q config requires a non-empty \"log_stream_name\"", cfg.Name())
+	}
+	if group, err = parseNameTemplate(cfg.LogGroupName); err != nil {
+		return nil, nil, fmt.Errorf("%q config has an invalid \"log_group_name\": %w", cfg.Name(), err)
+	}
+	if stream, err = parseNameTemplate(cfg.LogStreamName); err != nil {
+		return nil, nil, fmt.Errorf("%q config has an invalid \"log_stream_name\": %w", cfg.Name(), err)
+	}
+	return group, stream, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cwlogs"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Exporters), 3)
+
+	r0 := cfg.Exporters["awscloudwatchlogs"]
+	assert.Equal(t, r0, factory.CreateDefaultConfig())
+
+	r1 := cfg.Exporters["awscloudwatchlogs/customname"].(*Config)
+	assert.Equal(t, r1,
+		&Config{
+			ExporterSettings: configmodels.ExporterSettings{TypeVal: configmodels.Type(typeStr), NameVal: "awscloudwatchlogs/customname"},
+			LogGroupName:     "/aws/{service.namespace}/{service.name}",
+			LogStreamName:    "{host.name}",
+			RawLog:           true,
+			AWSSessionSettings: cwlogs.AWSSessionSettings{
+				NumberOfWorkers:       8,
+				Endpoint:              "https://logs.eu-west-1.amazonaws.com",
+				RequestTimeoutSeconds: 10,
+				MaxRetries:            3,
+				NoVerifySSL:           false,
+				ProxyAddress:          "",
+				Region:                "eu-west-1",
+				RoleARN:               "arn:aws:iam::123456789:role/monitoring-EKS-NodeInstanceRole",
+			},
+		})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no log group",
+			modify: func(cfg *Config) { cfg.LogGroupName = "" },
+			err:    `"awscloudwatchlogs" config requires a non-empty "log_group_name"`,
+		},
+		{
+			name:   "no log stream",
+			modify: func(cfg *Config) { cfg.LogStreamName = "" },
+			err:    `"awscloudwatchlogs" config requires a non-empty "log_stream_name"`,
+		},
+		{
+			name:   "invalid log group template",
+			modify: func(cfg *Config) { cfg.LogGroupName = "/aws/{service.name" },
+			err:    `"awscloudwatchlogs" config has an invalid "log_group_name": unclosed placeholder at "{service.name"`,
+		},
+		{
+			name:   "invalid log stream template",
+			modify: func(cfg *Config) { cfg.LogStreamName = "{}" },
+			err:    `"awscloudwatchlogs" config has an invalid "log_stream_name": empty placeholder at "{}"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			_, _, err := cfg.validate()
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
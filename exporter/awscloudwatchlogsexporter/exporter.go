@@ -0,0 +1,119 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cwlogs"
+)
+
+// cwLogsExporter sends log records to the CloudWatch Logs log streams named
+// after their resource attributes.
+type cwLogsExporter struct {
+	config *Config
+	client cloudwatchlogsiface.CloudWatchLogsAPI
+	group  *nameTemplate
+	stream *nameTemplate
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	pushers map[logStream]*cwlogs.Pusher
+}
+
+var _ component.LogsExporter = (*cwLogsExporter)(nil)
+
+// newCwLogsExporter creates a logs exporter for the log streams of the
+// configured templates.
+func newCwLogsExporter(config *Config, logger *zap.Logger, cn cwlogs.ConnAttr) (*cwLogsExporter, error) {
+	group, stream, err := config.validate()
+	if err != nil {
+		return nil, err
+	}
+	awsConfig, session, err := cwlogs.GetAWSConfigSession(logger, cn, &config.AWSSessionSettings)
+	if err != nil {
+		return nil, err
+	}
+	return &cwLogsExporter{
+		config:  config,
+		client:  cloudwatchlogs.New(session, awsConfig),
+		group:   group,
+		stream:  stream,
+		logger:  logger,
+		pushers: make(map[logStream]*cwlogs.Pusher),
+	}, nil
+}
+
+func (e *cwLogsExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *cwLogsExporter) Shutdown(context.Context) error {
+	return e.logger.Sync()
+}
+
+func (e *cwLogsExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	ctx = obsreport.StartLogsExportOp(ctx, typeStr)
+
+	numDroppedLogs, err := e.pushLogData(ctx, ld)
+
+	obsreport.EndLogsExportOp(ctx, ld.LogRecordCount(), numDroppedLogs, err)
+	return err
+}
+
+// pushLogData sends the log records to their log streams, one stream at a
+// time. It returns the number of log records not sent.
+func (e *cwLogsExporter) pushLogData(ctx context.Context, ld pdata.Logs) (int, error) {
+	streams, dropped := logsToEvents(ld, e.group, e.stream, e.config.RawLog, time.Now())
+	if dropped > 0 {
+		e.logger.Debug("Dropped log records with an empty message", zap.Int("count", dropped))
+	}
+
+	var errs []error
+	for _, s := range streams {
+		if numDropped, err := e.pusher(s.logStream).Push(ctx, s.events); err != nil {
+			e.logger.Debug("Failed to push log events",
+				zap.String("log_group", s.group), zap.String("log_stream", s.stream),
+				zap.Int("events", numDropped), zap.Error(err))
+			dropped += numDropped
+			errs = append(errs, err)
+		}
+	}
+	return dropped, componenterror.CombineErrors(errs)
+}
+
+// pusher returns the pusher of the log stream, which holds its sequence
+// token.
+func (e *cwLogsExporter) pusher(ls logStream) *cwlogs.Pusher {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	p, ok := e.pushers[ls]
+	if !ok {
+		p = cwlogs.NewPusher(e.client, ls.group, ls.stream, e.logger)
+		e.pushers[ls] = p
+	}
+	return p
+}
@@ -0,0 +1,102 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cwlogs"
+)
+
+// fakeCloudWatchLogs holds existing log streams accepting the batches.
+type fakeCloudWatchLogs struct {
+	cloudwatchlogsiface.CloudWatchLogsAPI
+
+	putErr  error
+	batches [][]*cloudwatchlogs.InputLogEvent
+	streams []string
+}
+
+func (f *fakeCloudWatchLogs) PutLogEventsWithContext(_ aws.Context, input *cloudwatchlogs.PutLogEventsInput, _ ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	if f.putErr != nil {
+		return nil, f.putErr
+	}
+	f.batches = append(f.batches, input.LogEvents)
+	f.streams = append(f.streams, aws.StringValue(input.LogGroupName)+":"+aws.StringValue(input.LogStreamName))
+	return &cloudwatchlogs.PutLogEventsOutput{}, nil
+}
+
+func testExporter(t *testing.T, client *fakeCloudWatchLogs) *cwLogsExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LogGroupName = "/otel/{service.name}"
+	group, stream, err := cfg.validate()
+	require.NoError(t, err)
+	return &cwLogsExporter{
+		config:  cfg,
+		client:  client,
+		group:   group,
+		stream:  stream,
+		logger:  zap.NewNop(),
+		pushers: make(map[logStream]*cwlogs.Pusher),
+	}
+}
+
+func TestConsumeLogs(t *testing.T) {
+	client := &fakeCloudWatchLogs{}
+	exp := testExporter(t, client)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer exp.Shutdown(context.Background())
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testLogs()))
+	assert.Equal(t, []string{"/otel/cart:otel-stream", "/otel/undefined:otel-stream"}, client.streams)
+	require.Len(t, client.batches, 2)
+	assert.Len(t, client.batches[0], 3)
+	assert.Len(t, client.batches[1], 2)
+	assert.Len(t, exp.pushers, 2)
+
+	// The pushers of the log streams are reused.
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testLogs()))
+	assert.Len(t, client.batches, 4)
+	assert.Len(t, exp.pushers, 2)
+}
+
+func TestConsumeLogsError(t *testing.T) {
+	client := &fakeCloudWatchLogs{}
+	exp := testExporter(t, client)
+
+	client.putErr = &cloudwatchlogs.InvalidParameterException{}
+	err := exp.ConsumeLogs(context.Background(), testLogs())
+	assert.Error(t, err)
+
+	client.putErr = &cloudwatchlogs.ServiceUnavailableException{}
+	err = exp.ConsumeLogs(context.Background(), testLogs())
+	assert.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	numDropped, err := exp.pushLogData(context.Background(), testLogs())
+	assert.Error(t, err)
+	assert.Equal(t, testLogs().LogRecordCount(), numDropped)
+}
@@ -0,0 +1,14 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awscloudwatchlogsexporter
+
+go 1.14
+
+require (
+	github.com/aws/aws-sdk-go v1.34.5
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/collector v0.8.1-0.20200815205113-8e5c6065eb0e
+	go.uber.org/zap v1.15.0
+	google.golang.org/grpc/examples v0.0.0-20200728194956-1c32b02682df // indirect
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/common => ../../internal/common
@@ -0,0 +1,196 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// logStream identifies a CloudWatch log stream.
+type logStream struct {
+	group  string
+	stream string
+}
+
+// streamEvents holds the log events of a log stream.
+type streamEvents struct {
+	logStream
+	events []*cloudwatchlogs.InputLogEvent
+}
+
+// cwLogBody is the JSON message of a log event.
+type cwLogBody struct {
+	Name           string                 `json:"name,omitempty"`
+	Body           interface{}            `json:"body,omitempty"`
+	SeverityNumber int32                  `json:"severity_number,omitempty"`
+	SeverityText   string                 `json:"severity_text,omitempty"`
+	TraceID        string                 `json:"trace_id,omitempty"`
+	SpanID         string                 `json:"span_id,omitempty"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty"`
+	Resource       map[string]interface{} `json:"resource,omitempty"`
+}
+
+// logsToEvents converts the log records to log events grouped by the log
+// group and stream names resolved from their resource attributes. It returns
+// the number of records dropped because their message is empty, or cannot be
+// encoded.
+func logsToEvents(ld pdata.Logs, group, stream *nameTemplate, rawLog bool, now time.Time) ([]*streamEvents, int) {
+	var streams []*streamEvents
+	byStream := make(map[logStream]*streamEvents)
+	numDropped := 0
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		resourceAttrs := pdata.NewAttributeMap()
+		if !rl.Resource().IsNil() {
+			resourceAttrs = rl.Resource().Attributes()
+		}
+		key := logStream{group: group.execute(resourceAttrs), stream: stream.execute(resourceAttrs)}
+		var resource map[string]interface{}
+		if !rawLog && resourceAttrs.Len() > 0 {
+			resource = attributeMapToInterface(resourceAttrs)
+		}
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+				event, ok := logRecordToEvent(lr, resource, rawLog, now)
+				if !ok {
+					numDropped++
+					continue
+				}
+				s, exists := byStream[key]
+				if !exists {
+					s = &streamEvents{logStream: key}
+					byStream[key] = s
+					streams = append(streams, s)
+				}
+				s.events = append(s.events, event)
+			}
+		}
+	}
+	return streams, numDropped
+}
+
+func logRecordToEvent(lr pdata.LogRecord, resource map[string]interface{}, rawLog bool, now time.Time) (*cloudwatchlogs.InputLogEvent, bool) {
+	timestamp := now.UnixNano() / int64(time.Millisecond)
+	if lr.Timestamp() != 0 {
+		timestamp = int64(lr.Timestamp()) / int64(time.Millisecond)
+	}
+
+	var message string
+	if rawLog {
+		message = bodyToString(lr.Body())
+	} else {
+		body := &cwLogBody{
+			Name:           lr.Name(),
+			Body:           attributeValueToInterface(lr.Body()),
+			SeverityNumber: int32(lr.SeverityNumber()),
+			SeverityText:   lr.SeverityText(),
+			Resource:       resource,
+		}
+		if traceID := lr.TraceID(); len(traceID) != 0 {
+			body.TraceID = traceID.String()
+		}
+		if spanID := lr.SpanID(); len(spanID) != 0 {
+			body.SpanID = spanID.String()
+		}
+		if lr.Attributes().Len() > 0 {
+			body.Attributes = attributeMapToInterface(lr.Attributes())
+		}
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, false
+		}
+		message = string(b)
+	}
+	if message == "" {
+		return nil, false
+	}
+	return &cloudwatchlogs.InputLogEvent{
+		Message:   aws.String(message),
+		Timestamp: aws.Int64(timestamp),
+	}, true
+}
+
+func bodyToString(body pdata.AttributeValue) string {
+	if body.Type() == pdata.AttributeValueMAP {
+		b, err := json.Marshal(attributeMapToInterface(body.MapVal()))
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	return attributeValueToString(body)
+}
+
+func attributeValueToString(v pdata.AttributeValue) string {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return strconv.FormatInt(v.IntVal(), 10)
+	case pdata.AttributeValueDOUBLE:
+		return strconv.FormatFloat(v.DoubleVal(), 'g', -1, 64)
+	case pdata.AttributeValueBOOL:
+		return strconv.FormatBool(v.BoolVal())
+	}
+	return ""
+}
+
+func attributeValueToInterface(v pdata.AttributeValue) interface{} {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return v.IntVal()
+	case pdata.AttributeValueDOUBLE:
+		return v.DoubleVal()
+	case pdata.AttributeValueBOOL:
+		return v.BoolVal()
+	case pdata.AttributeValueMAP:
+		return attributeMapToInterface(v.MapVal())
+	}
+	return nil
+}
+
+func attributeMapToInterface(attrs pdata.AttributeMap) map[string]interface{} {
+	m := make(map[string]interface{}, attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		if value := attributeValueToInterface(v); value != nil {
+			m[k] = value
+		}
+	})
+	return m
+}
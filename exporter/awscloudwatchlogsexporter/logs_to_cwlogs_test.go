@@ -0,0 +1,120 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func testLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(3)
+
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().InitEmpty()
+	rl.Resource().Attributes().InsertString("service.name", "cart")
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(2)
+	logs.At(0).SetTimestamp(pdata.TimestampUnixNano(3 * time.Millisecond))
+	logs.At(0).SetName("checkout")
+	logs.At(0).SetSeverityNumber(pdata.SeverityNumberINFO)
+	logs.At(0).SetSeverityText("Info")
+	logs.At(0).SetTraceID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	logs.At(0).SetSpanID([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	logs.At(0).Body().SetStringVal("checked out")
+	logs.At(0).Attributes().InsertInt("items", 2)
+	logs.At(1).SetTimestamp(pdata.TimestampUnixNano(1 * time.Millisecond))
+	body := pdata.NewAttributeMap()
+	body.InsertString("msg", "hello")
+	logs.At(1).Body().SetMapVal(body)
+
+	// Same log stream as the first resource.
+	rl = ld.ResourceLogs().At(1)
+	rl.Resource().InitEmpty()
+	rl.Resource().Attributes().InsertString("service.name", "cart")
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs = rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(1)
+	logs.At(0).SetTimestamp(pdata.TimestampUnixNano(2 * time.Millisecond))
+	logs.At(0).Body().SetStringVal("second")
+
+	// Without any resource.
+	rl = ld.ResourceLogs().At(2)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs = rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(2)
+	logs.At(0).Body().SetStringVal("no resource")
+	return ld
+}
+
+func testTemplates(t *testing.T) (*nameTemplate, *nameTemplate) {
+	group, err := parseNameTemplate("/otel/{service.name}")
+	require.NoError(t, err)
+	stream, err := parseNameTemplate("stream")
+	require.NoError(t, err)
+	return group, stream
+}
+
+func TestLogsToEvents(t *testing.T) {
+	group, stream := testTemplates(t)
+	now := time.Unix(100, 0)
+	streams, numDropped := logsToEvents(testLogs(), group, stream, false, now)
+	assert.Zero(t, numDropped)
+	require.Len(t, streams, 2)
+
+	assert.Equal(t, logStream{group: "/otel/cart", stream: "stream"}, streams[0].logStream)
+	require.Len(t, streams[0].events, 3)
+	assert.Equal(t, int64(3), aws.Int64Value(streams[0].events[0].Timestamp))
+	assert.JSONEq(t, `{
+		"name": "checkout",
+		"body": "checked out",
+		"severity_number": 9,
+		"severity_text": "Info",
+		"trace_id": "0102030405060708090a0b0c0d0e0f10",
+		"span_id": "0102030405060708",
+		"attributes": {"items": 2},
+		"resource": {"service.name": "cart"}
+	}`, aws.StringValue(streams[0].events[0].Message))
+	assert.JSONEq(t, `{"body": {"msg": "hello"}, "resource": {"service.name": "cart"}}`,
+		aws.StringValue(streams[0].events[1].Message))
+	assert.Equal(t, int64(2), aws.Int64Value(streams[0].events[2].Timestamp))
+
+	assert.Equal(t, logStream{group: "/otel/undefined", stream: "stream"}, streams[1].logStream)
+	require.Len(t, streams[1].events, 2)
+	assert.Equal(t, int64(100000), aws.Int64Value(streams[1].events[0].Timestamp))
+	assert.JSONEq(t, `{"body": "no resource"}`, aws.StringValue(streams[1].events[0].Message))
+}
+
+func TestLogsToEventsRawLog(t *testing.T) {
+	group, stream := testTemplates(t)
+	streams, numDropped := logsToEvents(testLogs(), group, stream, true, time.Now())
+	// The record without a body.
+	assert.Equal(t, 1, numDropped)
+	require.Len(t, streams, 2)
+
+	require.Len(t, streams[0].events, 3)
+	assert.Equal(t, "checked out", aws.StringValue(streams[0].events[0].Message))
+	assert.Equal(t, `{"msg":"hello"}`, aws.StringValue(streams[0].events[1].Message))
+	assert.Equal(t, "second", aws.StringValue(streams[0].events[2].Message))
+	require.Len(t, streams[1].events, 1)
+	assert.Equal(t, "no resource", aws.StringValue(streams[1].events[0].Message))
+}
@@ -0,0 +1,80 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// undefinedValue replaces the placeholders of the missing attributes.
+const undefinedValue = "undefined"
+
+// nameTemplate is a log group or stream name with {attribute} placeholders
+// replaced by the values of resource attributes.
+type nameTemplate struct {
+	// parts alternates literal text and attribute names, starting with text.
+	parts []string
+}
+
+// parseNameTemplate parses a template, rejecting unbalanced braces and empty
+// placeholders.
+func parseNameTemplate(s string) (*nameTemplate, error) {
+	t := &nameTemplate{}
+	for {
+		open := strings.IndexAny(s, "{}")
+		if open < 0 {
+			t.parts = append(t.parts, s)
+			return t, nil
+		}
+		if s[open] == '}' {
+			return nil, fmt.Errorf("unexpected '}' at %q", s[open:])
+		}
+		end := strings.IndexAny(s[open+1:], "{}")
+		if end < 0 || s[open+1+end] == '{' {
+			return nil, fmt.Errorf("unclosed placeholder at %q", s[open:])
+		}
+		name := s[open+1 : open+1+end]
+		if name == "" {
+			return nil, fmt.Errorf("empty placeholder at %q", s[open:])
+		}
+		t.parts = append(t.parts, s[:open], name)
+		s = s[open+end+2:]
+	}
+}
+
+// execute returns the name for the resource attributes.
+func (t *nameTemplate) execute(attrs pdata.AttributeMap) string {
+	if len(t.parts) == 1 {
+		return t.parts[0]
+	}
+	var b strings.Builder
+	for i, part := range t.parts {
+		if i%2 == 0 {
+			b.WriteString(part)
+			continue
+		}
+		value := undefinedValue
+		if v, ok := attrs.Get(part); ok {
+			if s := attributeValueToString(v); s != "" {
+				value = s
+			}
+		}
+		b.WriteString(value)
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestNameTemplate(t *testing.T) {
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString("service.name", "cart")
+	attrs.InsertInt("pid", 42)
+	attrs.InsertString("empty", "")
+
+	tests := []struct {
+		template string
+		name     string
+	}{
+		{template: "/otel/logs", name: "/otel/logs"},
+		{template: "{service.name}", name: "cart"},
+		{template: "/aws/{service.name}/{pid}-stream", name: "/aws/cart/42-stream"},
+		{template: "/aws/{service.namespace}/{empty}", name: "/aws/undefined/undefined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			tmpl, err := parseNameTemplate(tt.template)
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, tmpl.execute(attrs))
+		})
+	}
+}
+
+func TestParseNameTemplateErrors(t *testing.T) {
+	tests := []struct {
+		template string
+		err      string
+	}{
+		{template: "/aws/{service.name", err: `unclosed placeholder at "{service.name"`},
+		{template: "/aws/{service{name}", err: `unclosed placeholder at "{service{name}"`},
+		{template: "/aws/service}", err: `unexpected '}' at "}"`},
+		{template: "/aws/{}", err: `empty placeholder at "{}"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			_, err := parseNameTemplate(tt.template)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cwlogs"
+)
+
+// Dimension rollup options.
+const (
+	// ZeroAndSingleDimensionRollup adds, to the dimension set of the labels,
+	// a dimension set without any dimension and a dimension set per label.
+	ZeroAndSingleDimensionRollup = "ZeroAndSingleDimensionRollup"
+	// SingleDimensionRollupOnly adds a dimension set per label.
+	SingleDimensionRollupOnly = "SingleDimensionRollupOnly"
+	// NoDimensionRollup only uses the dimension set of the labels.
+	NoDimensionRollup = "NoDimensionRollup"
+)
+
+// Config defines configuration for AWS EMF exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	// LogGroupName is the name of CloudWatch log group which defines group of log streams
+	// that share the same retention, monitoring, and access control settings.
+	LogGroupName string `mapstructure:"log_group_name"`
+	// LogStreamName is the name of CloudWatch log stream which is a sequence of log events
+	// that share the same source.
+	LogStreamName string `mapstructure:"log_stream_name"`
+	// Namespace is the CloudWatch namespace of the metrics. It defaults to the
+	// "service.namespace" and "service.name" resource labels, joined by a slash.
+	Namespace string `mapstructure:"namespace"`
+	// AWSSessionSettings configures the AWS session sending the log events.
+	cwlogs.AWSSessionSettings `mapstructure:",squash"`
+	// DimensionRollupOption is the rollup of the dimensions of the metrics not
+	// matched by a metric declaration: ZeroAndSingleDimensionRollup (the
+	// default), SingleDimensionRollupOnly or NoDimensionRollup.
+	DimensionRollupOption string `mapstructure:"dimension_rollup_option"`
+	// MetricDeclarations selects the metrics extracted as CloudWatch metrics
+	// from the log events, and their dimensions. If it is empty, all the
+	// metrics are extracted with the dimensions of the rollup option.
+	MetricDeclarations []*MetricDeclaration `mapstructure:"metric_declarations"`
+}
+
+// validate checks the configuration and compiles the metric declarations.
+func (cfg *Config) validate() error {
+	if cfg.LogGroupName == "" {
+		return fmt.Errorf("%q config requires a non-empty \"log_group_name\"", cfg.Name())
+	}
+	if cfg.LogStreamName == "" {
+		return fmt.Errorf("%q config requires a non-empty \"log_stream_name\"", cfg.Name())
+	}
+	switch cfg.DimensionRollupOption {
+	case ZeroAndSingleDimensionRollup, SingleDimensionRollupOnly, NoDimensionRollup:
+	default:
+		return fmt.Errorf("%q config has an invalid \"dimension_rollup_option\" %q", cfg.Name(), cfg.DimensionRollupOption)
+	}
+	for i, md := range cfg.MetricDeclarations {
+		if err := md.init(); err != nil {
+			return fmt.Errorf("%q config has an invalid metric declaration %d: %w", cfg.Name(), i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cwlogs"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Exporters), 3)
+
+	r0 := cfg.Exporters["awsemf"]
+	assert.Equal(t, r0, factory.CreateDefaultConfig())
+
+	r1 := cfg.Exporters["awsemf/customname"].(*Config)
+	assert.Equal(t, r1,
+		&Config{
+			ExporterSettings: configmodels.ExporterSettings{TypeVal: configmodels.Type(typeStr), NameVal: "awsemf/customname"},
+			LogGroupName:     "/aws/containerinsights/cluster/performance",
+			LogStreamName:    "node-1",
+			Namespace:        "ContainerInsights",
+			AWSSessionSettings: cwlogs.AWSSessionSettings{
+				NumberOfWorkers:       8,
+				Endpoint:              "",
+				RequestTimeoutSeconds: 30,
+				MaxRetries:            1,
+				NoVerifySSL:           false,
+				ProxyAddress:          "",
+				Region:                "eu-west-1",
+				RoleARN:               "arn:aws:iam::123456789:role/monitoring-EKS-NodeInstanceRole",
+			},
+			DimensionRollupOption: NoDimensionRollup,
+			MetricDeclarations: []*MetricDeclaration{{
+				Dimensions:          [][]string{{"ClusterName", "Namespace", "PodName"}, {"ClusterName"}},
+				MetricNameSelectors: []string{"^pod_cpu_utilization$", "^pod_memory_"},
+			}},
+		})
+	require.NoError(t, r1.validate())
+	assert.True(t, r1.MetricDeclarations[0].matches("pod_memory_working_set"))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no log group",
+			modify: func(cfg *Config) { cfg.LogGroupName = "" },
+			err:    `"awsemf" config requires a non-empty "log_group_name"`,
+		},
+		{
+			name:   "no log stream",
+			modify: func(cfg *Config) { cfg.LogStreamName = "" },
+			err:    `"awsemf" config requires a non-empty "log_stream_name"`,
+		},
+		{
+			name:   "invalid rollup option",
+			modify: func(cfg *Config) { cfg.DimensionRollupOption = "All" },
+			err:    `"awsemf" config has an invalid "dimension_rollup_option" "All"`,
+		},
+		{
+			name: "no metric name selectors",
+			modify: func(cfg *Config) {
+				cfg.MetricDeclarations = []*MetricDeclaration{{Dimensions: [][]string{{"a"}}}}
+			},
+			err: `"awsemf" config has an invalid metric declaration 0: "metric_name_selectors" must not be empty`,
+		},
+		{
+			name: "invalid metric name selector",
+			modify: func(cfg *Config) {
+				cfg.MetricDeclarations = []*MetricDeclaration{{MetricNameSelectors: []string{"("}}}
+			},
+			err: "\"awsemf\" config has an invalid metric declaration 0: invalid metric name selector \"(\": error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name: "too many dimensions",
+			modify: func(cfg *Config) {
+				cfg.MetricDeclarations = []*MetricDeclaration{{
+					MetricNameSelectors: []string{"a"},
+					Dimensions:          [][]string{{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}},
+				}}
+			},
+			err: `"awsemf" config has an invalid metric declaration 0: dimension set [1 2 3 4 5 6 7 8 9 10] has more than 9 dimensions`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cwlogs"
+)
+
+// emfExporter converts metrics to EMF log events and sends them to
+// CloudWatch Logs, which extracts the CloudWatch metrics.
+type emfExporter struct {
+	translator *metricTranslator
+	pusher     *cwlogs.Pusher
+	logger     *zap.Logger
+}
+
+// newEmfExporter creates a metrics exporter sending EMF log events to the
+// configured log group and stream.
+func newEmfExporter(config *Config, logger *zap.Logger, cn cwlogs.ConnAttr) (component.MetricsExporter, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	awsConfig, session, err := cwlogs.GetAWSConfigSession(logger, cn, &config.AWSSessionSettings)
+	if err != nil {
+		return nil, err
+	}
+	client := cloudwatchlogs.New(session, awsConfig)
+
+	exp := &emfExporter{
+		translator: newMetricTranslator(config),
+		pusher:     cwlogs.NewPusher(client, config.LogGroupName, config.LogStreamName, logger),
+		logger:     logger,
+	}
+	return exporterhelper.NewMetricsExporter(
+		config,
+		exp.pushMetrics,
+		exporterhelper.WithShutdown(func(context.Context) error {
+			return logger.Sync()
+		}),
+	)
+}
+
+func (e *emfExporter) pushMetrics(ctx context.Context, md pdata.Metrics) (int, error) {
+	_, numPoints := pdatautil.MetricAndDataPointCount(md)
+
+	var events []*cloudwatchlogs.InputLogEvent
+	dropped := 0
+	now := time.Now()
+	for _, data := range pdatautil.MetricsToMetricsData(md) {
+		dataEvents, numDropped, err := e.translator.translate(data, now)
+		if err != nil {
+			return numPoints, err
+		}
+		events = append(events, dataEvents...)
+		dropped += numDropped
+	}
+	if dropped > 0 {
+		e.logger.Debug("Dropped data points without a value or delta", zap.Int("count", dropped))
+	}
+	if len(events) == 0 {
+		return dropped, nil
+	}
+
+	if numDropped, err := e.pusher.Push(ctx, events); err != nil {
+		e.logger.Debug("Failed to push EMF log events", zap.Int("events", numDropped), zap.Error(err))
+		return numPoints, err
+	}
+	return dropped, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cwlogs"
+)
+
+// fakeCloudWatchLogs is an existing log stream accepting the batches.
+type fakeCloudWatchLogs struct {
+	cloudwatchlogsiface.CloudWatchLogsAPI
+
+	putErr  error
+	batches [][]*cloudwatchlogs.InputLogEvent
+}
+
+func (f *fakeCloudWatchLogs) PutLogEventsWithContext(_ aws.Context, input *cloudwatchlogs.PutLogEventsInput, _ ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	if f.putErr != nil {
+		return nil, f.putErr
+	}
+	f.batches = append(f.batches, input.LogEvents)
+	return &cloudwatchlogs.PutLogEventsOutput{}, nil
+}
+
+func TestPushMetrics(t *testing.T) {
+	client := &fakeCloudWatchLogs{}
+	exp := &emfExporter{
+		translator: testTranslator(t, nil),
+		pusher:     cwlogs.NewPusher(client, "group", "stream", zap.NewNop()),
+		logger:     zap.NewNop(),
+	}
+
+	md := pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{{
+		Metrics: []*metricspb.Metric{
+			testMetric("memory", metricspb.MetricDescriptor_GAUGE_INT64, int64Point(100, 1024)),
+			testMetric("requests", metricspb.MetricDescriptor_CUMULATIVE_INT64, int64Point(100, 10)),
+		},
+	}})
+	dropped, err := exp.pushMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+	require.Len(t, client.batches, 1)
+	require.Len(t, client.batches[0], 1)
+	assert.Contains(t, aws.StringValue(client.batches[0][0].Message), `"memory":1024`)
+
+	client.putErr = &cloudwatchlogs.ServiceUnavailableException{}
+	dropped, err = exp.pushMetrics(context.Background(), md)
+	assert.Error(t, err)
+	assert.Equal(t, 2, dropped)
+}
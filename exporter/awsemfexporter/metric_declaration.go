@@ -0,0 +1,126 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxDimensions is the maximum number of dimensions of an EMF dimension set.
+const maxDimensions = 9
+
+// MetricDeclaration selects metrics by name and defines their dimensions.
+type MetricDeclaration struct {
+	// Dimensions lists the dimension sets of the metrics. A dimension set is
+	// only used for the data points having all its labels.
+	Dimensions [][]string `mapstructure:"dimensions"`
+	// MetricNameSelectors lists the regular expressions matching the names of
+	// the metrics.
+	MetricNameSelectors []string `mapstructure:"metric_name_selectors"`
+
+	metricRegexList []*regexp.Regexp
+}
+
+// init checks the declaration and compiles its selectors.
+func (md *MetricDeclaration) init() error {
+	if len(md.MetricNameSelectors) == 0 {
+		return errors.New("\"metric_name_selectors\" must not be empty")
+	}
+	md.metricRegexList = make([]*regexp.Regexp, 0, len(md.MetricNameSelectors))
+	for _, selector := range md.MetricNameSelectors {
+		re, err := regexp.Compile(selector)
+		if err != nil {
+			return fmt.Errorf("invalid metric name selector %q: %w", selector, err)
+		}
+		md.metricRegexList = append(md.metricRegexList, re)
+	}
+	for _, dims := range md.Dimensions {
+		if len(dims) > maxDimensions {
+			return fmt.Errorf("dimension set %v has more than %d dimensions", dims, maxDimensions)
+		}
+	}
+	return nil
+}
+
+// matches reports whether the metric name is selected by the declaration.
+func (md *MetricDeclaration) matches(name string) bool {
+	for _, re := range md.metricRegexList {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// dimensions returns the declared dimension sets whose labels are all in
+// labels, sorted and without duplicates.
+func (md *MetricDeclaration) dimensions(labels map[string]string) [][]string {
+	var dimensions [][]string
+	for _, dims := range md.Dimensions {
+		present := true
+		for _, d := range dims {
+			if _, ok := labels[d]; !ok {
+				present = false
+				break
+			}
+		}
+		if present {
+			dimensions = appendDimensionSet(dimensions, dims)
+		}
+	}
+	return dimensions
+}
+
+// rollupDimensions returns the dimension set of all the labels, followed by
+// the dimension sets of the rollup option.
+func rollupDimensions(labels map[string]string, rollupOption string) [][]string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var dimensions [][]string
+	if len(keys) <= maxDimensions {
+		dimensions = appendDimensionSet(dimensions, keys)
+	}
+	if rollupOption == ZeroAndSingleDimensionRollup {
+		dimensions = appendDimensionSet(dimensions, []string{})
+	}
+	if rollupOption == ZeroAndSingleDimensionRollup || rollupOption == SingleDimensionRollupOnly {
+		for _, k := range keys {
+			dimensions = appendDimensionSet(dimensions, []string{k})
+		}
+	}
+	return dimensions
+}
+
+// appendDimensionSet appends a sorted copy of dims to dimensions, unless it
+// already holds the same set.
+func appendDimensionSet(dimensions [][]string, dims []string) [][]string {
+	set := append([]string{}, dims...)
+	sort.Strings(set)
+	key := strings.Join(set, "\x00")
+	for _, d := range dimensions {
+		if len(d) == len(set) && strings.Join(d, "\x00") == key {
+			return dimensions
+		}
+	}
+	return append(dimensions, set)
+}
@@ -0,0 +1,48 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupDimensions(t *testing.T) {
+	labels := map[string]string{"b": "2", "a": "1"}
+	assert.Equal(t, [][]string{{"a", "b"}, {}, {"a"}, {"b"}}, rollupDimensions(labels, ZeroAndSingleDimensionRollup))
+	assert.Equal(t, [][]string{{"a", "b"}, {"a"}, {"b"}}, rollupDimensions(labels, SingleDimensionRollupOnly))
+	assert.Equal(t, [][]string{{"a", "b"}}, rollupDimensions(labels, NoDimensionRollup))
+
+	// The single dimension set is the set of all the labels.
+	assert.Equal(t, [][]string{{"a"}, {}}, rollupDimensions(map[string]string{"a": "1"}, ZeroAndSingleDimensionRollup))
+	assert.Equal(t, [][]string{{}}, rollupDimensions(nil, ZeroAndSingleDimensionRollup))
+}
+
+func TestMetricDeclarationDimensions(t *testing.T) {
+	md := &MetricDeclaration{
+		Dimensions:          [][]string{{"PodName", "ClusterName"}, {"ClusterName"}, {"NodeName"}, {"ClusterName", "PodName"}},
+		MetricNameSelectors: []string{"^pod_"},
+	}
+	require.NoError(t, md.init())
+
+	assert.True(t, md.matches("pod_cpu"))
+	assert.False(t, md.matches("node_cpu"))
+	assert.Equal(t,
+		[][]string{{"ClusterName", "PodName"}, {"ClusterName"}},
+		md.dimensions(map[string]string{"ClusterName": "c", "PodName": "p"}))
+	assert.Nil(t, md.dimensions(map[string]string{"PodName": "p"}))
+}
@@ -0,0 +1,414 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	defaultNamespace = "default"
+
+	// deltaExpiry is the duration after which the previous value of a
+	// cumulative time series is forgotten.
+	deltaExpiry = 5 * time.Minute
+)
+
+// cwMetrics is the "_aws" metadata of an EMF log event, describing the
+// CloudWatch metrics extracted from its fields.
+//
+// See https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch_Embedded_Metric_Format_Specification.html.
+type cwMetrics struct {
+	Timestamp         int64            `json:"Timestamp"`
+	CloudWatchMetrics []*cwMeasurement `json:"CloudWatchMetrics"`
+}
+
+// cwMeasurement is a metric directive: the metrics sharing a namespace and
+// dimension sets.
+type cwMeasurement struct {
+	Namespace  string      `json:"Namespace"`
+	Dimensions [][]string  `json:"Dimensions"`
+	Metrics    []*cwMetric `json:"Metrics"`
+}
+
+// cwMetric is the definition of a metric of a directive.
+type cwMetric struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit,omitempty"`
+}
+
+// cwMetricStats is the value of distributions and summaries.
+type cwMetricStats struct {
+	Max   float64 `json:"Max"`
+	Min   float64 `json:"Min"`
+	Count uint64  `json:"Count"`
+	Sum   float64 `json:"Sum"`
+}
+
+// units maps the UCUM units of the metrics to CloudWatch units.
+var units = map[string]string{
+	"1":   "Count",
+	"s":   "Seconds",
+	"ms":  "Milliseconds",
+	"us":  "Microseconds",
+	"By":  "Bytes",
+	"bit": "Bits",
+	"%":   "Percent",
+}
+
+// event is an EMF log event under construction: the data points sharing a
+// timestamp and labels.
+type event struct {
+	timestamp    int64
+	fields       map[string]interface{}
+	measurements map[string]*cwMeasurement
+	order        []string
+}
+
+func (e *event) addMetric(namespace string, dimensions [][]string, metric *cwMetric) {
+	keys := make([]string, len(dimensions))
+	for i, dims := range dimensions {
+		keys[i] = strings.Join(dims, ",")
+	}
+	key := namespace + "\x00" + strings.Join(keys, ";")
+	m, ok := e.measurements[key]
+	if !ok {
+		m = &cwMeasurement{Namespace: namespace, Dimensions: dimensions}
+		e.measurements[key] = m
+		e.order = append(e.order, key)
+	}
+	m.Metrics = append(m.Metrics, metric)
+}
+
+func (e *event) toInputLogEvent() (*cloudwatchlogs.InputLogEvent, error) {
+	body := make(map[string]interface{}, len(e.fields)+1)
+	for k, v := range e.fields {
+		body[k] = v
+	}
+	metadata := &cwMetrics{Timestamp: e.timestamp}
+	for _, key := range e.order {
+		metadata.CloudWatchMetrics = append(metadata.CloudWatchMetrics, e.measurements[key])
+	}
+	body["_aws"] = metadata
+
+	message, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &cloudwatchlogs.InputLogEvent{
+		Message:   aws.String(string(message)),
+		Timestamp: aws.Int64(e.timestamp),
+	}, nil
+}
+
+// metricTranslator converts metrics to EMF log events.
+type metricTranslator struct {
+	config *Config
+	deltas *deltaCache
+}
+
+func newMetricTranslator(config *Config) *metricTranslator {
+	return &metricTranslator{config: config, deltas: newDeltaCache()}
+}
+
+// translate converts the metrics to EMF log events, an event per timestamp
+// and label set. The labels and values of the data points are the fields of
+// the events, and the metrics selected by the configuration are extracted
+// as CloudWatch metrics. It returns the number of data points dropped
+// because their value cannot be written, or because they are the first value
+// of a cumulative time series, whose CloudWatch metric is the delta between
+// successive values.
+func (t *metricTranslator) translate(md consumerdata.MetricsData, now time.Time) ([]*cloudwatchlogs.InputLogEvent, int, error) {
+	namespace := t.namespace(md.Resource.GetLabels())
+
+	var events []*event
+	byKey := make(map[string]*event)
+	dropped := 0
+	for _, metric := range md.Metrics {
+		if metric == nil || metric.MetricDescriptor == nil || metric.MetricDescriptor.Name == "" {
+			continue
+		}
+		desc := metric.MetricDescriptor
+		declarations := t.declarations(desc.Name)
+		if len(t.config.MetricDeclarations) > 0 && len(declarations) == 0 {
+			// Not a CloudWatch metric, so the data points are not sent.
+			continue
+		}
+		cumulative := desc.Type == metricspb.MetricDescriptor_CUMULATIVE_INT64 || desc.Type == metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
+
+		for _, ts := range metric.Timeseries {
+			if ts == nil {
+				continue
+			}
+			labels := make(map[string]string, len(desc.LabelKeys))
+			for i, key := range desc.LabelKeys {
+				if key == nil || i >= len(ts.LabelValues) || ts.LabelValues[i] == nil || !ts.LabelValues[i].HasValue {
+					continue
+				}
+				labels[key.Key] = ts.LabelValues[i].Value
+			}
+			labelsKey := labelsKey(labels)
+
+			for _, pt := range ts.Points {
+				if pt == nil {
+					continue
+				}
+				timestamp := now.UnixNano() / int64(time.Millisecond)
+				if pt.Timestamp != nil {
+					timestamp = pt.Timestamp.Seconds*1000 + int64(pt.Timestamp.Nanos)/int64(time.Millisecond)
+				}
+
+				value, ok := pointValue(pt)
+				if ok && cumulative {
+					value, ok = t.deltas.delta(namespace+"\x00"+desc.Name+"\x00"+labelsKey, value.(float64), now)
+				}
+				if !ok {
+					dropped++
+					continue
+				}
+
+				key := labelsKey + strconv.FormatInt(timestamp, 10)
+				e, exists := byKey[key]
+				if !exists {
+					e = &event{
+						timestamp:    timestamp,
+						fields:       make(map[string]interface{}, len(labels)+1),
+						measurements: make(map[string]*cwMeasurement),
+					}
+					for k, v := range labels {
+						e.fields[k] = v
+					}
+					byKey[key] = e
+					events = append(events, e)
+				}
+				e.fields[desc.Name] = value
+				if dimensions := t.dimensions(declarations, labels); len(dimensions) > 0 {
+					e.addMetric(namespace, dimensions, &cwMetric{Name: desc.Name, Unit: units[desc.Unit]})
+				}
+			}
+		}
+	}
+	t.deltas.expire(now)
+
+	logEvents := make([]*cloudwatchlogs.InputLogEvent, 0, len(events))
+	for _, e := range events {
+		logEvent, err := e.toInputLogEvent()
+		if err != nil {
+			return nil, dropped, err
+		}
+		logEvents = append(logEvents, logEvent)
+	}
+	return logEvents, dropped, nil
+}
+
+// namespace returns the configured namespace, or the one derived from the
+// resource labels.
+func (t *metricTranslator) namespace(resourceLabels map[string]string) string {
+	if t.config.Namespace != "" {
+		return t.config.Namespace
+	}
+	var parts []string
+	if ns := resourceLabels[conventions.AttributeServiceNamespace]; ns != "" {
+		parts = append(parts, ns)
+	}
+	if name := resourceLabels[conventions.AttributeServiceName]; name != "" {
+		parts = append(parts, name)
+	}
+	if len(parts) == 0 {
+		return defaultNamespace
+	}
+	return strings.Join(parts, "/")
+}
+
+// declarations returns the metric declarations matching the metric name.
+func (t *metricTranslator) declarations(name string) []*MetricDeclaration {
+	var declarations []*MetricDeclaration
+	for _, md := range t.config.MetricDeclarations {
+		if md.matches(name) {
+			declarations = append(declarations, md)
+		}
+	}
+	return declarations
+}
+
+// dimensions returns the dimension sets of a data point: the ones of the
+// matching declarations if any are configured, otherwise the ones of the
+// rollup option.
+func (t *metricTranslator) dimensions(declarations []*MetricDeclaration, labels map[string]string) [][]string {
+	if len(t.config.MetricDeclarations) == 0 {
+		return rollupDimensions(labels, t.config.DimensionRollupOption)
+	}
+	var dimensions [][]string
+	for _, md := range declarations {
+		for _, dims := range md.dimensions(labels) {
+			dimensions = appendDimensionSet(dimensions, dims)
+		}
+	}
+	return dimensions
+}
+
+// pointValue returns the value of a data point: a float64 for numbers, or
+// the statistics of distributions and summaries.
+func pointValue(pt *metricspb.Point) (interface{}, bool) {
+	switch v := pt.Value.(type) {
+	case *metricspb.Point_Int64Value:
+		return float64(v.Int64Value), true
+	case *metricspb.Point_DoubleValue:
+		if math.IsNaN(v.DoubleValue) || math.IsInf(v.DoubleValue, 0) {
+			return nil, false
+		}
+		return v.DoubleValue, true
+	case *metricspb.Point_DistributionValue:
+		return distributionStats(v.DistributionValue), true
+	case *metricspb.Point_SummaryValue:
+		return summaryStats(v.SummaryValue), true
+	}
+	return nil, false
+}
+
+// distributionStats returns the statistics of a distribution, its minimum
+// and maximum being approximated by the bounds of its first and last
+// non-empty buckets.
+func distributionStats(d *metricspb.DistributionValue) *cwMetricStats {
+	stats := &cwMetricStats{Count: uint64(d.Count), Sum: d.Sum}
+	if d.Count == 0 {
+		return stats
+	}
+	stats.Min = d.Sum / float64(d.Count)
+	stats.Max = stats.Min
+	bounds := d.GetBucketOptions().GetExplicit().GetBounds()
+	if len(bounds) == 0 || len(d.Buckets) != len(bounds)+1 {
+		return stats
+	}
+	first, last := -1, -1
+	for i, b := range d.Buckets {
+		if b.GetCount() > 0 {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return stats
+	}
+	if first > 0 {
+		stats.Min = bounds[first-1]
+	} else {
+		stats.Min = math.Min(stats.Min, bounds[0])
+	}
+	if last < len(bounds) {
+		stats.Max = bounds[last]
+	} else {
+		stats.Max = math.Max(stats.Max, bounds[len(bounds)-1])
+	}
+	return stats
+}
+
+// summaryStats returns the statistics of a summary, its minimum and maximum
+// being its 0th and 100th percentiles, or its average if they are missing.
+func summaryStats(s *metricspb.SummaryValue) *cwMetricStats {
+	stats := &cwMetricStats{Count: uint64(s.GetCount().GetValue()), Sum: s.GetSum().GetValue()}
+	if stats.Count > 0 {
+		stats.Min = stats.Sum / float64(stats.Count)
+		stats.Max = stats.Min
+	}
+	for _, pv := range s.GetSnapshot().GetPercentileValues() {
+		switch pv.GetPercentile() {
+		case 0:
+			stats.Min = pv.GetValue()
+		case 100:
+			stats.Max = pv.GetValue()
+		}
+	}
+	return stats
+}
+
+// labelsKey returns a key identifying a label set.
+func labelsKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(labels[k])
+		b.WriteByte('\x00')
+	}
+	return b.String()
+}
+
+// deltaCache holds the previous values of the cumulative time series.
+type deltaCache struct {
+	mu     sync.Mutex
+	values map[string]*previousValue
+}
+
+type previousValue struct {
+	value    float64
+	lastSeen time.Time
+}
+
+func newDeltaCache() *deltaCache {
+	return &deltaCache{values: make(map[string]*previousValue)}
+}
+
+// delta returns the difference between the value and the previous value of
+// the time series, or false for its first value or when the previous value
+// is older than deltaExpiry. A value lower than the
+// previous one means the time series was reset, so the value itself is the
+// delta.
+func (c *deltaCache) delta(key string, value float64, now time.Time) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev, ok := c.values[key]
+	c.values[key] = &previousValue{value: value, lastSeen: now}
+	if !ok || now.Sub(prev.lastSeen) > deltaExpiry {
+		return nil, false
+	}
+	if value < prev.value {
+		return value, true
+	}
+	return value - prev.value, true
+}
+
+// expire forgets the time series not seen for deltaExpiry.
+func (c *deltaCache) expire(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, prev := range c.values {
+		if now.Sub(prev.lastSeen) > deltaExpiry {
+			delete(c.values, key)
+		}
+	}
+}
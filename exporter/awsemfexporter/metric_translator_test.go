@@ -0,0 +1,222 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+func testMetric(name string, typ metricspb.MetricDescriptor_Type, value *metricspb.Point) *metricspb.Metric {
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      name,
+			Unit:      "By",
+			Type:      typ,
+			LabelKeys: []*metricspb.LabelKey{{Key: "ClusterName"}, {Key: "PodName"}},
+		},
+		Timeseries: []*metricspb.TimeSeries{{
+			LabelValues: []*metricspb.LabelValue{{Value: "cluster", HasValue: true}, {Value: "pod", HasValue: true}},
+			Points:      []*metricspb.Point{value},
+		}},
+	}
+}
+
+func int64Point(seconds int64, v int64) *metricspb.Point {
+	return &metricspb.Point{
+		Timestamp: &timestamp.Timestamp{Seconds: seconds},
+		Value:     &metricspb.Point_Int64Value{Int64Value: v},
+	}
+}
+
+func testTranslator(t *testing.T, modify func(*Config)) *metricTranslator {
+	cfg := createDefaultConfig().(*Config)
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.validate())
+	return newMetricTranslator(cfg)
+}
+
+func TestTranslateGauge(t *testing.T) {
+	translator := testTranslator(t, nil)
+	md := consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{Labels: map[string]string{"service.namespace": "shop", "service.name": "cart"}},
+		Metrics: []*metricspb.Metric{
+			testMetric("memory", metricspb.MetricDescriptor_GAUGE_INT64, int64Point(100, 1024)),
+			testMetric("cpu", metricspb.MetricDescriptor_GAUGE_DOUBLE, &metricspb.Point{
+				Timestamp: &timestamp.Timestamp{Seconds: 100},
+				Value:     &metricspb.Point_DoubleValue{DoubleValue: 0.5},
+			}),
+		},
+	}
+
+	events, dropped, err := translator.translate(md, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(100000), aws.Int64Value(events[0].Timestamp))
+	assert.JSONEq(t, `{
+		"ClusterName": "cluster",
+		"PodName": "pod",
+		"memory": 1024,
+		"cpu": 0.5,
+		"_aws": {
+			"Timestamp": 100000,
+			"CloudWatchMetrics": [{
+				"Namespace": "shop/cart",
+				"Dimensions": [["ClusterName", "PodName"], [], ["ClusterName"], ["PodName"]],
+				"Metrics": [{"Name": "memory", "Unit": "Bytes"}, {"Name": "cpu", "Unit": "Bytes"}]
+			}]
+		}
+	}`, aws.StringValue(events[0].Message))
+}
+
+func TestTranslateCumulative(t *testing.T) {
+	translator := testTranslator(t, func(cfg *Config) { cfg.DimensionRollupOption = NoDimensionRollup })
+	now := time.Now()
+	translate := func(seconds, value int64) ([]*eventMessage, int) {
+		md := consumerdata.MetricsData{
+			Metrics: []*metricspb.Metric{testMetric("requests", metricspb.MetricDescriptor_CUMULATIVE_INT64, int64Point(seconds, value))},
+		}
+		events, dropped, err := translator.translate(md, now)
+		require.NoError(t, err)
+		messages := make([]*eventMessage, len(events))
+		for i, e := range events {
+			messages[i] = &eventMessage{}
+			require.NoError(t, json.Unmarshal([]byte(aws.StringValue(e.Message)), messages[i]))
+		}
+		return messages, dropped
+	}
+
+	// The first value is only used to compute the next delta.
+	messages, dropped := translate(100, 10)
+	assert.Empty(t, messages)
+	assert.Equal(t, 1, dropped)
+
+	messages, dropped = translate(160, 25)
+	assert.Zero(t, dropped)
+	require.Len(t, messages, 1)
+	assert.Equal(t, float64(15), messages[0].Requests)
+	assert.Equal(t, "default", messages[0].AWS.CloudWatchMetrics[0].Namespace)
+	assert.Equal(t, [][]string{{"ClusterName", "PodName"}}, messages[0].AWS.CloudWatchMetrics[0].Dimensions)
+
+	// A reset of the time series.
+	messages, _ = translate(220, 5)
+	require.Len(t, messages, 1)
+	assert.Equal(t, float64(5), messages[0].Requests)
+
+	// The time series is forgotten after deltaExpiry.
+	now = now.Add(deltaExpiry + time.Second)
+	messages, dropped = translate(280, 10)
+	assert.Empty(t, messages)
+	assert.Equal(t, 1, dropped)
+}
+
+type eventMessage struct {
+	Requests float64   `json:"requests"`
+	AWS      cwMetrics `json:"_aws"`
+}
+
+func TestTranslateMetricDeclarations(t *testing.T) {
+	translator := testTranslator(t, func(cfg *Config) {
+		cfg.Namespace = "ContainerInsights"
+		cfg.MetricDeclarations = []*MetricDeclaration{
+			{Dimensions: [][]string{{"ClusterName", "PodName"}, {"Namespace"}}, MetricNameSelectors: []string{"^pod_"}},
+			{Dimensions: [][]string{{"ClusterName"}}, MetricNameSelectors: []string{"^pod_memory"}},
+		}
+	})
+	md := consumerdata.MetricsData{
+		Metrics: []*metricspb.Metric{
+			testMetric("pod_cpu", metricspb.MetricDescriptor_GAUGE_INT64, int64Point(100, 1)),
+			testMetric("pod_memory", metricspb.MetricDescriptor_GAUGE_INT64, int64Point(100, 2)),
+			testMetric("node_cpu", metricspb.MetricDescriptor_GAUGE_INT64, int64Point(100, 3)),
+		},
+	}
+
+	events, dropped, err := translator.translate(md, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+	require.Len(t, events, 1)
+	assert.JSONEq(t, `{
+		"ClusterName": "cluster",
+		"PodName": "pod",
+		"pod_cpu": 1,
+		"pod_memory": 2,
+		"_aws": {
+			"Timestamp": 100000,
+			"CloudWatchMetrics": [
+				{
+					"Namespace": "ContainerInsights",
+					"Dimensions": [["ClusterName", "PodName"]],
+					"Metrics": [{"Name": "pod_cpu", "Unit": "Bytes"}]
+				},
+				{
+					"Namespace": "ContainerInsights",
+					"Dimensions": [["ClusterName", "PodName"], ["ClusterName"]],
+					"Metrics": [{"Name": "pod_memory", "Unit": "Bytes"}]
+				}
+			]
+		}
+	}`, aws.StringValue(events[0].Message))
+}
+
+func TestDistributionStats(t *testing.T) {
+	d := &metricspb.DistributionValue{
+		Count: 4,
+		Sum:   70,
+		BucketOptions: &metricspb.DistributionValue_BucketOptions{
+			Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+				Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{Bounds: []float64{10, 20, 30}},
+			},
+		},
+		Buckets: []*metricspb.DistributionValue_Bucket{{Count: 0}, {Count: 3}, {Count: 1}, {Count: 0}},
+	}
+	assert.Equal(t, &cwMetricStats{Min: 10, Max: 30, Count: 4, Sum: 70}, distributionStats(d))
+
+	// Without bounds, the average is used.
+	d = &metricspb.DistributionValue{Count: 4, Sum: 70}
+	assert.Equal(t, &cwMetricStats{Min: 17.5, Max: 17.5, Count: 4, Sum: 70}, distributionStats(d))
+
+	assert.Equal(t, &cwMetricStats{}, distributionStats(&metricspb.DistributionValue{}))
+}
+
+func TestSummaryStats(t *testing.T) {
+	s := &metricspb.SummaryValue{
+		Count: &wrappers.Int64Value{Value: 3},
+		Sum:   &wrappers.DoubleValue{Value: 30},
+		Snapshot: &metricspb.SummaryValue_Snapshot{
+			PercentileValues: []*metricspb.SummaryValue_Snapshot_ValueAtPercentile{
+				{Percentile: 0, Value: 2},
+				{Percentile: 50, Value: 9},
+				{Percentile: 100, Value: 19},
+			},
+		},
+	}
+	assert.Equal(t, &cwMetricStats{Min: 2, Max: 19, Count: 3, Sum: 30}, summaryStats(s))
+
+	s.Snapshot = nil
+	assert.Equal(t, &cwMetricStats{Min: 10, Max: 10, Count: 3, Sum: 30}, summaryStats(s))
+}
@@ -43,6 +43,8 @@ func NewTraceExporter(config configmodels.Exporter, logger *zap.Logger, cn connA
 		return nil, err
 	}
 	xrayClient := NewXRay(logger, awsConfig, session)
+	cfg := config.(*Config)
+	logGroups := translator.MakeLogGroups(cfg.LogGroupNames, cfg.LogGroupARNs)
 	return exporterhelper.NewTraceExporter(
 		config,
 		func(ctx context.Context, td pdata.Traces) (totalDroppedSpans int, err error) {
@@ -69,7 +71,8 @@ func NewTraceExporter(config configmodels.Exporter, logger *zap.Logger, cn connA
 							continue
 						}
 
-						document, localErr := translator.MakeSegmentDocumentString(span, resource)
+						document, localErr := translator.MakeSegmentDocumentString(
+							span, resource, cfg.IndexedAttributes, cfg.IndexAllAttributes, logGroups)
 						if localErr != nil {
 							totalDroppedSpans++
 							continue
@@ -39,4 +39,13 @@ type Config struct {
 	ResourceARN string `mapstructure:"resource_arn"`
 	// IAM role to upload segments to a different account.
 	RoleARN string `mapstructure:"role_arn"`
+	// Span attributes converted to X-Ray annotations, which are indexed and searchable. The other span
+	// attributes are converted to X-Ray metadata.
+	IndexedAttributes []string `mapstructure:"indexed_attributes"`
+	// Convert all the span attributes to X-Ray annotations.
+	IndexAllAttributes bool `mapstructure:"index_all_attributes"`
+	// Names of the CloudWatch Logs log groups referenced by the segments.
+	LogGroupNames []string `mapstructure:"aws_log_groups"`
+	// ARNs of the CloudWatch Logs log groups referenced by the segments.
+	LogGroupARNs []string `mapstructure:"log_group_arns"`
 }
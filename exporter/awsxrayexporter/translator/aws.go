@@ -16,6 +16,7 @@ package translator
 
 import (
 	"strconv"
+	"strings"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	semconventions "go.opentelemetry.io/collector/translator/conventions"
@@ -48,6 +49,14 @@ type AWSData struct {
 	RequestID         string             `json:"request_id,omitempty"`
 	QueueURL          string             `json:"queue_url,omitempty"`
 	TableName         string             `json:"table_name,omitempty"`
+	CWLogs            []LogGroupMetadata `json:"cloudwatch_logs,omitempty"`
+}
+
+// LogGroupMetadata provides the shape for unmarshalling the CloudWatch Logs log group of a segment,
+// used by the X-Ray console to link the traces to their logs.
+type LogGroupMetadata struct {
+	LogGroup string `json:"log_group"`
+	Arn      string `json:"arn,omitempty"`
 }
 
 // EC2Metadata provides the shape for unmarshalling EC2 metadata.
@@ -70,7 +79,41 @@ type BeanstalkMetadata struct {
 	DeploymentID int64  `json:"deployment_id"`
 }
 
-func makeAws(attributes map[string]string, resource pdata.Resource) (map[string]string, *AWSData) {
+// MakeLogGroups returns the log groups metadata of the log groups identified by their names or ARNs.
+func MakeLogGroups(names []string, arns []string) []LogGroupMetadata {
+	var (
+		logGroups []LogGroupMetadata
+		seen      = make(map[string]bool)
+	)
+	for _, arn := range arns {
+		name := logGroupNameFromARN(arn)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		logGroups = append(logGroups, LogGroupMetadata{LogGroup: name, Arn: arn})
+	}
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		logGroups = append(logGroups, LogGroupMetadata{LogGroup: name})
+	}
+	return logGroups
+}
+
+// logGroupNameFromARN returns the name of the log group of a CloudWatch Logs ARN of the form
+// arn:partition:logs:region:account-id:log-group:name[:*], or an empty string if arn is not such an ARN.
+func logGroupNameFromARN(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 7 || parts[0] != "arn" || parts[2] != "logs" || parts[5] != "log-group" {
+		return ""
+	}
+	return parts[6]
+}
+
+func makeAws(attributes map[string]string, resource pdata.Resource, logGroups []LogGroupMetadata) (map[string]string, *AWSData) {
 	var (
 		cloud        string
 		account      string
@@ -151,6 +194,9 @@ func makeAws(attributes map[string]string, resource pdata.Resource) (map[string]
 		}
 	}
 	if cloud != "aws" && cloud != "" {
+		if len(logGroups) > 0 {
+			return filtered, &AWSData{CWLogs: logGroups}
+		}
 		return filtered, nil // not AWS so return nil
 	}
 	// progress from least specific to most specific origin so most specific ends up as origin
@@ -189,6 +235,7 @@ func makeAws(attributes map[string]string, resource pdata.Resource) (map[string]
 		RequestID:         requestID,
 		QueueURL:          queueURL,
 		TableName:         tableName,
+		CWLogs:            logGroups,
 	}
 	return filtered, awsData
 }
@@ -39,7 +39,7 @@ func TestAwsFromEc2Resource(t *testing.T) {
 
 	attributes := make(map[string]string)
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -77,7 +77,7 @@ func TestAwsFromEcsResource(t *testing.T) {
 
 	attributes := make(map[string]string)
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -105,7 +105,7 @@ func TestAwsFromBeanstalkResource(t *testing.T) {
 
 	attributes := make(map[string]string)
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -146,7 +146,7 @@ func TestAwsWithAwsSqsResources(t *testing.T) {
 	attributes[AWSQueueURLAttribute] = queueURL
 	attributes["employee.id"] = "XB477"
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -159,7 +159,7 @@ func TestAwsWithSqsAlternateAttribute(t *testing.T) {
 	attributes := make(map[string]string)
 	attributes[AWSQueueURLAttribute2] = queueURL
 
-	filtered, awsData := makeAws(attributes, pdata.NewResource())
+	filtered, awsData := makeAws(attributes, pdata.NewResource(), nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -191,7 +191,7 @@ func TestAwsWithAwsDynamoDbResources(t *testing.T) {
 	attributes[AWSRequestIDAttribute] = "75107C82-EC8A-4F75-883F-4440B491B0AB"
 	attributes[AWSTableNameAttribute] = tableName
 
-	filtered, awsData := makeAws(attributes, resource)
+	filtered, awsData := makeAws(attributes, resource, nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -205,7 +205,7 @@ func TestAwsWithDynamoDbAlternateAttribute(t *testing.T) {
 	attributes := make(map[string]string)
 	attributes[AWSTableNameAttribute2] = tableName
 
-	filtered, awsData := makeAws(attributes, pdata.NewResource())
+	filtered, awsData := makeAws(attributes, pdata.NewResource(), nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
@@ -217,9 +217,45 @@ func TestAwsWithRequestIdAlternateAttribute(t *testing.T) {
 	attributes := make(map[string]string)
 	attributes[AWSRequestIDAttribute2] = requestid
 
-	filtered, awsData := makeAws(attributes, pdata.NewResource())
+	filtered, awsData := makeAws(attributes, pdata.NewResource(), nil)
 
 	assert.NotNil(t, filtered)
 	assert.NotNil(t, awsData)
 	assert.Equal(t, requestid, awsData.RequestID)
 }
+
+func TestMakeLogGroups(t *testing.T) {
+	logGroups := MakeLogGroups(
+		[]string{"group1", "group2", ""},
+		[]string{
+			"arn:aws:logs:us-east-1:123456789:log-group:group2:*",
+			"arn:aws:logs:us-east-1:123456789:log-group:group3",
+			"arn:aws:s3:::bucket",
+		})
+
+	assert.Equal(t, []LogGroupMetadata{
+		{LogGroup: "group2", Arn: "arn:aws:logs:us-east-1:123456789:log-group:group2:*"},
+		{LogGroup: "group3", Arn: "arn:aws:logs:us-east-1:123456789:log-group:group3"},
+		{LogGroup: "group1"},
+	}, logGroups)
+}
+
+func TestAwsWithLogGroups(t *testing.T) {
+	logGroups := []LogGroupMetadata{{LogGroup: "group1"}}
+
+	attributes := make(map[string]string)
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString(semconventions.AttributeCloudProvider, "aws")
+	_, awsData := makeAws(attributes, resource, logGroups)
+	assert.NotNil(t, awsData)
+	assert.Equal(t, logGroups, awsData.CWLogs)
+
+	resource.Attributes().UpsertString(semconventions.AttributeCloudProvider, "gcp")
+	_, awsData = makeAws(attributes, resource, logGroups)
+	assert.NotNil(t, awsData)
+	assert.Equal(t, &AWSData{CWLogs: logGroups}, awsData)
+
+	_, awsData = makeAws(attributes, resource, nil)
+	assert.Nil(t, awsData)
+}
@@ -53,6 +53,8 @@ const (
 	defaultSegmentName = "span"
 	// maxSegmentNameLength the maximum length of a Segment name
 	maxSegmentNameLength = 200
+	// defaultMetadataNamespace is the namespace of the metadata holding the span attributes not indexed
+	defaultMetadataNamespace = "default"
 )
 
 const (
@@ -102,8 +104,9 @@ var (
 )
 
 // MakeSegmentDocumentString converts an OpenCensus Span to an X-Ray Segment and then serialzies to JSON
-func MakeSegmentDocumentString(span pdata.Span, resource pdata.Resource) (string, error) {
-	segment := MakeSegment(span, resource)
+func MakeSegmentDocumentString(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool,
+	logGroups []LogGroupMetadata) (string, error) {
+	segment := MakeSegment(span, resource, indexedAttrs, indexAllAttrs, logGroups)
 	w := writers.borrow()
 	if err := w.Encode(segment); err != nil {
 		return "", err
@@ -113,8 +116,11 @@ func MakeSegmentDocumentString(span pdata.Span, resource pdata.Resource) (string
 	return jsonStr, nil
 }
 
-// MakeSegment converts an OpenCensus Span to an X-Ray Segment
-func MakeSegment(span pdata.Span, resource pdata.Resource) Segment {
+// MakeSegment converts an OpenCensus Span to an X-Ray Segment. The span attributes listed in indexedAttrs, or all
+// of them if indexAllAttrs is set, become annotations, the other ones become metadata of the default namespace.
+// The logGroups are referenced in the aws object of the segment.
+func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool,
+	logGroups []LogGroupMetadata) Segment {
 	var (
 		traceID                                = convertToAmazonTraceID(span.TraceID())
 		startTime                              = timestampToFloatSeconds(span.StartTime())
@@ -123,10 +129,10 @@ func MakeSegment(span pdata.Span, resource pdata.Resource) Segment {
 		isError, isFault, causefiltered, cause = makeCause(span, httpfiltered, resource)
 		isThrottled                            = !span.Status().IsNil() && otlptrace.Status_StatusCode(span.Status().Code()) == otlptrace.Status_ResourceExhausted
 		origin                                 = determineAwsOrigin(resource)
-		awsfiltered, aws                       = makeAws(causefiltered, resource)
+		awsfiltered, aws                       = makeAws(causefiltered, resource, logGroups)
 		service                                = makeService(resource)
 		sqlfiltered, sql                       = makeSQL(awsfiltered)
-		user, annotations                      = makeAnnotations(sqlfiltered)
+		user, annotations, metadata            = makeXRayAttributes(sqlfiltered, indexedAttrs, indexAllAttrs)
 		name                                   string
 		namespace                              string
 		segmentType                            string
@@ -221,7 +227,7 @@ func MakeSegment(span pdata.Span, resource pdata.Resource) Segment {
 		Service:     service,
 		SQL:         sql,
 		Annotations: annotations,
-		Metadata:    nil,
+		Metadata:    metadata,
 		Type:        segmentType,
 	}
 }
@@ -326,17 +332,12 @@ func timestampToFloatSeconds(ts pdata.TimestampUnixNano) float64 {
 	return float64(ts) / float64(time.Second)
 }
 
-func sanitizeAndTransferAnnotations(dest map[string]interface{}, src map[string]string) {
-	for key, value := range src {
-		key = fixAnnotationKey(key)
-		dest[key] = value
-	}
-}
-
-func makeAnnotations(attributes map[string]string) (string, map[string]interface{}) {
+func makeXRayAttributes(attributes map[string]string, indexedAttrs []string, indexAllAttrs bool) (
+	string, map[string]interface{}, map[string]map[string]interface{}) {
 	var (
-		result = map[string]interface{}{}
-		user   string
+		annotations = map[string]interface{}{}
+		metadata    = map[string]interface{}{}
+		user        string
 	)
 	delete(attributes, semconventions.AttributeComponent)
 	userid, ok := attributes[semconventions.AttributeEnduserID]
@@ -344,12 +345,27 @@ func makeAnnotations(attributes map[string]string) (string, map[string]interface
 		user = userid
 		delete(attributes, semconventions.AttributeEnduserID)
 	}
-	sanitizeAndTransferAnnotations(result, attributes)
 
-	if len(result) == 0 {
-		return user, nil
+	indexed := make(map[string]bool, len(indexedAttrs))
+	for _, name := range indexedAttrs {
+		indexed[name] = true
+	}
+
+	for key, value := range attributes {
+		if indexAllAttrs || indexed[key] {
+			annotations[fixAnnotationKey(key)] = value
+		} else {
+			metadata[key] = value
+		}
+	}
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	if len(metadata) == 0 {
+		return user, annotations, nil
 	}
-	return user, result
+	return user, annotations, map[string]map[string]interface{}{defaultMetadataNamespace: metadata}
 }
 
 // fixSegmentName removes any invalid characters from the span name.  AWS X-Ray defines
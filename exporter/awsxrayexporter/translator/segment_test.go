@@ -49,12 +49,12 @@ func TestClientSpanWithAwsSdkClient(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment := MakeSegment(span, resource)
+	segment := MakeSegment(span, resource, nil, true, nil)
 	assert.Equal(t, "DynamoDB", segment.Name)
 	assert.Equal(t, "aws", segment.Namespace)
 	assert.Equal(t, "subsegment", segment.Type)
 
-	jsonStr, err := MakeSegmentDocumentString(span, resource)
+	jsonStr, err := MakeSegmentDocumentString(span, resource, nil, true, nil)
 
 	assert.NotNil(t, jsonStr)
 	assert.Nil(t, err)
@@ -80,7 +80,7 @@ func TestClientSpanWithPeerService(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment := MakeSegment(span, resource)
+	segment := MakeSegment(span, resource, nil, true, nil)
 	assert.Equal(t, "cats-table", segment.Name)
 }
 
@@ -104,7 +104,7 @@ func TestServerSpanWithInternalServerError(t *testing.T) {
 	timeEvents := constructTimedEventsWithSentMessageEvent(span.StartTime())
 	timeEvents.CopyTo(span.Events())
 
-	segment := MakeSegment(span, resource)
+	segment := MakeSegment(span, resource, nil, true, nil)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.Cause)
@@ -128,7 +128,7 @@ func TestServerSpanNoParentId(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructServerSpan(parentSpanID, spanName, 0, "OK", nil)
 
-	segment := MakeSegment(span, resource)
+	segment := MakeSegment(span, resource, nil, true, nil)
 
 	assert.Empty(t, segment.ParentID)
 }
@@ -143,7 +143,7 @@ func TestSpanWithNoStatus(t *testing.T) {
 	span.SetStartTime(pdata.TimestampUnixNano(time.Now().UnixNano()))
 	span.SetEndTime(pdata.TimestampUnixNano(time.Now().Add(10).UnixNano()))
 
-	segment := MakeSegment(span, pdata.NewResource())
+	segment := MakeSegment(span, pdata.NewResource(), nil, true, nil)
 	assert.NotNil(t, segment)
 }
 
@@ -163,7 +163,7 @@ func TestClientSpanWithDbComponent(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment := MakeSegment(span, resource)
+	segment := MakeSegment(span, resource, nil, true, nil)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.SQL)
@@ -187,6 +187,54 @@ func TestClientSpanWithDbComponent(t *testing.T) {
 	assert.True(t, strings.Contains(jsonStr, enterpriseAppID))
 }
 
+func TestSpanWithAttributesNotIndexed(t *testing.T) {
+	attributes := make(map[string]interface{})
+	attributes["attr1@1"] = "val1"
+	attributes["attr2@2"] = "val2"
+	resource := constructDefaultResource()
+	span := constructServerSpan(newSegmentID(), "api", 0, "OK", attributes)
+
+	segment := MakeSegment(span, resource, nil, false, nil)
+
+	assert.Nil(t, segment.Annotations)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"default": {
+			"attr1@1": "val1",
+			"attr2@2": "val2",
+		},
+	}, segment.Metadata)
+}
+
+func TestSpanWithAttributesPartlyIndexed(t *testing.T) {
+	attributes := make(map[string]interface{})
+	attributes["attr1@1"] = "val1"
+	attributes["attr2@2"] = "val2"
+	resource := constructDefaultResource()
+	span := constructServerSpan(newSegmentID(), "api", 0, "OK", attributes)
+
+	segment := MakeSegment(span, resource, []string{"attr1@1", "not_exist"}, false, nil)
+
+	assert.Equal(t, map[string]interface{}{"attr1_1": "val1"}, segment.Annotations)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"default": {
+			"attr2@2": "val2",
+		},
+	}, segment.Metadata)
+}
+
+func TestSpanWithAttributesAllIndexed(t *testing.T) {
+	attributes := make(map[string]interface{})
+	attributes["attr1@1"] = "val1"
+	attributes["attr2@2"] = "val2"
+	resource := constructDefaultResource()
+	span := constructServerSpan(newSegmentID(), "api", 0, "OK", attributes)
+
+	segment := MakeSegment(span, resource, []string{"attr1@1"}, true, nil)
+
+	assert.Equal(t, map[string]interface{}{"attr1_1": "val1", "attr2_2": "val2"}, segment.Annotations)
+	assert.Nil(t, segment.Metadata)
+}
+
 func TestClientSpanWithHttpHost(t *testing.T) {
 	spanName := "GET /"
 	parentSpanID := newSegmentID()
@@ -201,7 +249,7 @@ func TestClientSpanWithHttpHost(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment := MakeSegment(span, resource)
+	segment := MakeSegment(span, resource, nil, true, nil)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "foo.com", segment.Name)
@@ -220,7 +268,7 @@ func TestClientSpanWithoutHttpHost(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment := MakeSegment(span, resource)
+	segment := MakeSegment(span, resource, nil, true, nil)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "bar.com", segment.Name)
@@ -240,7 +288,7 @@ func TestClientSpanWithRpcHost(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment := MakeSegment(span, resource)
+	segment := MakeSegment(span, resource, nil, true, nil)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "com.foo.AnimalService", segment.Name)
@@ -263,7 +311,7 @@ func TestSpanWithInvalidTraceId(t *testing.T) {
 	traceID[0] = 0x11
 	span.SetTraceID(traceID)
 
-	jsonStr, err := MakeSegmentDocumentString(span, resource)
+	jsonStr, err := MakeSegmentDocumentString(span, resource, nil, true, nil)
 
 	assert.NotNil(t, jsonStr)
 	assert.Nil(t, err)
@@ -322,7 +370,7 @@ func TestServerSpanWithNilAttributes(t *testing.T) {
 	timeEvents.CopyTo(span.Events())
 	pdata.NewAttributeMap().CopyTo(span.Attributes())
 
-	segment := MakeSegment(span, resource)
+	segment := MakeSegment(span, resource, nil, true, nil)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.Cause)
@@ -38,6 +38,15 @@ type Config struct {
 	// data to the Carbon/Graphite backend.
 	// The default value is defined by the DefaultSendTimeout constant.
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// PathTemplate, if set, builds dot-separated metric paths instead of
+	// using the Carbon tagged format, e.g.: "servers.{host.name}.{metric}".
+	// The "{metric}" placeholder, which is required, is replaced by the metric
+	// name and any other placeholder by the value of the metric or resource
+	// label with that key. Labels that are not set are replaced by "unknown".
+	// The labels not referenced by the template are not sent, so the series
+	// differing only by these labels are written to the same path.
+	PathTemplate string `mapstructure:"path_template"`
 }
 
 // convenience function so the default can be created without instantiating the
@@ -60,6 +60,9 @@ func TestLoadConfig(t *testing.T) {
 	te, err := factory.CreateMetricsExporter(zap.NewNop(), e1)
 	require.NoError(t, err)
 	require.NotNil(t, te)
+
+	e2 := cfg.Exporters["carbon/template"].(*Config)
+	assert.Equal(t, "servers.{host.name}.{metric}", e2.PathTemplate)
 }
 
 func Test_setDefaults(t *testing.T) {
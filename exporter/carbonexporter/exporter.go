@@ -46,8 +46,21 @@ func New(config Config) (component.MetricsExporterOld, error) {
 			effectiveConfig.Name())
 	}
 
+	var template *pathTemplate
+	if effectiveConfig.PathTemplate != "" {
+		var err error
+		template, err = newPathTemplate(effectiveConfig.PathTemplate)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%q exporter has an invalid path template: %v",
+				effectiveConfig.Name(),
+				err)
+		}
+	}
+
 	sender := carbonSender{
 		connPool: newTCPConnPool(effectiveConfig.Endpoint, effectiveConfig.Timeout),
+		template: template,
 	}
 
 	return exporterhelper.NewMetricsExporterOld(
@@ -61,13 +74,14 @@ func New(config Config) (component.MetricsExporterOld, error) {
 // the exporter can leverage the helper and get consistent observability.
 type carbonSender struct {
 	connPool *connPool
+	template *pathTemplate
 }
 
 func (cs *carbonSender) pushMetricsData(
 	ctx context.Context,
 	md consumerdata.MetricsData,
 ) (int, error) {
-	lines, converted, dropped := metricDataToPlaintext(md)
+	lines, converted, dropped := metricDataToPlaintext(md, cs.template)
 
 	if _, err := cs.connPool.Write([]byte(lines)); err != nil {
 		// Use the sum of converted and dropped since the write failed for all.
@@ -54,6 +54,13 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid_path_template",
+			config: Config{
+				PathTemplate: "servers.{host.name}",
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid_timeout",
 			config: Config{
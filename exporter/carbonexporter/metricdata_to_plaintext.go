@@ -34,14 +34,12 @@ const (
 	tagValueNotSetPlaceholder = "<null>"
 
 	// Constants used when converting from distribution metrics to Carbon format.
-	distributionBucketSuffix             = ".bucket"
-	distributionUpperBoundTagKey         = "upper_bound"
-	distributionUpperBoundTagBeforeValue = tagPrefix + distributionUpperBoundTagKey + tagKeyValueSeparator
+	distributionBucketSuffix     = ".bucket"
+	distributionUpperBoundTagKey = "upper_bound"
 
 	// Constants used when converting from summary metrics to Carbon format.
-	summaryQuantileSuffix         = ".quantile"
-	summaryQuantileTagKey         = "quantile"
-	summaryQuantileTagBeforeValue = tagPrefix + summaryQuantileTagKey + tagKeyValueSeparator
+	summaryQuantileSuffix = ".quantile"
+	summaryQuantileTagKey = "quantile"
 
 	// Suffix to be added to original metric name for a Carbon metric representing
 	// a count metric for either distribution or summary metrics.
@@ -76,16 +74,24 @@ const (
 //
 // The <timestamp> is the Unix time text of when the measurement was made.
 //
+// If template is not nil the <path> is built from it instead, see pathTemplate,
+// and the tags are appended to it as dot-separated nodes.
+//
 // The returned values are:
 // 	- a string concatenating all generated "lines" (each single one representing
 // 	  a single Carbon metric.
 //  - number of time series successfully converted to carbon.
 // 	- number of time series that could not be converted to Carbon.
-func metricDataToPlaintext(md consumerdata.MetricsData) (string, int, int) {
+func metricDataToPlaintext(md consumerdata.MetricsData, template *pathTemplate) (string, int, int) {
 	if len(md.Metrics) == 0 {
 		return "", 0, 0
 	}
 
+	f := &pathFormatter{
+		template:       template,
+		resourceLabels: md.Resource.GetLabels(),
+	}
+
 	var sb strings.Builder
 	numTimeseriesDropped := 0
 	totalTimeseries := 0
@@ -122,18 +128,18 @@ func metricDataToPlaintext(md consumerdata.MetricsData) (string, int, int) {
 				switch pv := point.Value.(type) {
 
 				case *metricspb.Point_Int64Value:
-					path := buildPath(name, tagKeys, ts.LabelValues)
+					path := f.path(name, tagKeys, ts.LabelValues)
 					valueStr := formatInt64(pv.Int64Value)
 					sb.WriteString(buildLine(path, valueStr, timestampStr))
 
 				case *metricspb.Point_DoubleValue:
-					path := buildPath(name, tagKeys, ts.LabelValues)
+					path := f.path(name, tagKeys, ts.LabelValues)
 					valueStr := formatFloatForValue(pv.DoubleValue)
 					sb.WriteString(buildLine(path, valueStr, timestampStr))
 
 				case *metricspb.Point_DistributionValue:
 					err := buildDistributionIntoBuilder(
-						&sb, name, tagKeys, ts.LabelValues, timestampStr, pv.DistributionValue)
+						&sb, f, name, tagKeys, ts.LabelValues, timestampStr, pv.DistributionValue)
 					if err != nil {
 						// TODO: log error info
 						numTimeseriesDropped++
@@ -141,7 +147,7 @@ func metricDataToPlaintext(md consumerdata.MetricsData) (string, int, int) {
 
 				case *metricspb.Point_SummaryValue:
 					err := buildSummaryIntoBuilder(
-						&sb, name, tagKeys, ts.LabelValues, timestampStr, pv.SummaryValue)
+						&sb, f, name, tagKeys, ts.LabelValues, timestampStr, pv.SummaryValue)
 					if err != nil {
 						// TODO: log error info
 						numTimeseriesDropped++
@@ -170,6 +176,7 @@ func metricDataToPlaintext(md consumerdata.MetricsData) (string, int, int) {
 // less than or equal to the upper bound.
 func buildDistributionIntoBuilder(
 	sb *strings.Builder,
+	f *pathFormatter,
 	metricName string,
 	tagKeys []string,
 	labelValues []*metricspb.LabelValue,
@@ -178,6 +185,7 @@ func buildDistributionIntoBuilder(
 ) error {
 	buildCountAndSumIntoBuilder(
 		sb,
+		f,
 		metricName,
 		tagKeys,
 		labelValues,
@@ -199,10 +207,10 @@ func buildDistributionIntoBuilder(
 	}
 	carbonBounds[len(carbonBounds)-1] = infinityCarbonValue
 
-	bucketPath := buildPath(metricName+distributionBucketSuffix, tagKeys, labelValues)
+	bucketPath := f.path(metricName+distributionBucketSuffix, tagKeys, labelValues)
 	for i, bucket := range distributionValue.Buckets {
 		sb.WriteString(buildLine(
-			bucketPath+distributionUpperBoundTagBeforeValue+carbonBounds[i],
+			f.tag(bucketPath, distributionUpperBoundTagKey, carbonBounds[i]),
 			formatInt64(bucket.Count),
 			timestampStr))
 	}
@@ -224,6 +232,7 @@ func buildDistributionIntoBuilder(
 // and will include a tag key "quantile" that specifies the quantile value.
 func buildSummaryIntoBuilder(
 	sb *strings.Builder,
+	f *pathFormatter,
 	metricName string,
 	tagKeys []string,
 	labelValues []*metricspb.LabelValue,
@@ -232,6 +241,7 @@ func buildSummaryIntoBuilder(
 ) error {
 	buildCountAndSumIntoBuilder(
 		sb,
+		f,
 		metricName,
 		tagKeys,
 		labelValues,
@@ -246,10 +256,10 @@ func buildSummaryIntoBuilder(
 			metricName)
 	}
 
-	quantilePath := buildPath(metricName+summaryQuantileSuffix, tagKeys, labelValues)
+	quantilePath := f.path(metricName+summaryQuantileSuffix, tagKeys, labelValues)
 	for _, quantile := range percentiles {
 		sb.WriteString(buildLine(
-			quantilePath+summaryQuantileTagBeforeValue+formatFloatForLabel(quantile.GetPercentile()),
+			f.tag(quantilePath, summaryQuantileTagKey, formatFloatForLabel(quantile.GetPercentile())),
 			formatFloatForValue(quantile.GetValue()),
 			timestampStr))
 	}
@@ -267,6 +277,7 @@ func buildSummaryIntoBuilder(
 //
 func buildCountAndSumIntoBuilder(
 	sb *strings.Builder,
+	f *pathFormatter,
 	metricName string,
 	tagKeys []string,
 	labelValues []*metricspb.LabelValue,
@@ -275,11 +286,11 @@ func buildCountAndSumIntoBuilder(
 	timestampStr string,
 ) {
 	// Build count and sum metrics.
-	countPath := buildPath(metricName+countSuffix, tagKeys, labelValues)
+	countPath := f.path(metricName+countSuffix, tagKeys, labelValues)
 	valueStr := formatInt64(count)
 	sb.WriteString(buildLine(countPath, valueStr, timestampStr))
 
-	sumPath := buildPath(metricName, tagKeys, labelValues)
+	sumPath := f.path(metricName, tagKeys, labelValues)
 	valueStr = formatFloatForValue(sum)
 	sb.WriteString(buildLine(sumPath, valueStr, timestampStr))
 }
@@ -265,7 +265,7 @@ func Test_metricDataToPlaintext(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotLines, gotNunConvertedTimeseries, gotNumDroppedTimeseries := metricDataToPlaintext(tt.metricsDataFn())
+			gotLines, gotNunConvertedTimeseries, gotNumDroppedTimeseries := metricDataToPlaintext(tt.metricsDataFn(), nil)
 			assert.Equal(t, tt.wantNumConvertedTimeseries, gotNunConvertedTimeseries)
 			assert.Equal(t, tt.wantNumDroppedTimeseries, gotNumDroppedTimeseries)
 			got := strings.Split(gotLines, "\n")
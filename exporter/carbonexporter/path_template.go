@@ -0,0 +1,148 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+)
+
+const (
+	// metricPlaceholder is the template placeholder replaced by the metric name.
+	metricPlaceholder = "metric"
+
+	// templateValueNotSet replaces the template placeholders of labels that
+	// are not set.
+	templateValueNotSet = "unknown"
+)
+
+// pathTemplate builds dot-separated Carbon paths from a template such as
+// "servers.{host.name}.{metric}", whose placeholders are replaced by the
+// metric name, for "{metric}", or by the value of a metric or resource label.
+type pathTemplate struct {
+	// segments alternates literal text and placeholder keys, starting with
+	// literal text.
+	segments []string
+}
+
+func newPathTemplate(template string) (*pathTemplate, error) {
+	var (
+		segments  []string
+		hasMetric bool
+		rest      = template
+	)
+	for {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			if strings.IndexByte(rest, '}') >= 0 {
+				return nil, fmt.Errorf("unexpected '}' in path template %q", template)
+			}
+			segments = append(segments, rest)
+			break
+		}
+		if strings.IndexByte(rest[:open], '}') >= 0 {
+			return nil, fmt.Errorf("unexpected '}' in path template %q", template)
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed '{' in path template %q", template)
+		}
+		key := rest[open+1 : open+end]
+		if key == "" || strings.IndexByte(key, '{') >= 0 {
+			return nil, fmt.Errorf("invalid placeholder %q in path template %q", key, template)
+		}
+		hasMetric = hasMetric || key == metricPlaceholder
+		segments = append(segments, rest[:open], key)
+		rest = rest[open+end+1:]
+	}
+	if !hasMetric {
+		return nil, errors.New("path template must contain the {metric} placeholder")
+	}
+	return &pathTemplate{segments: segments}, nil
+}
+
+// execute returns the path of a metric, the label values being returned by
+// lookup.
+func (t *pathTemplate) execute(name string, lookup func(key string) (string, bool)) string {
+	var sb strings.Builder
+	for i, segment := range t.segments {
+		if i%2 == 0 {
+			sb.WriteString(segment)
+			continue
+		}
+		if segment == metricPlaceholder {
+			sb.WriteString(name)
+			continue
+		}
+		value, ok := lookup(segment)
+		if !ok || value == "" {
+			value = templateValueNotSet
+		}
+		sb.WriteString(sanitizePathNode(value))
+	}
+	return sb.String()
+}
+
+// sanitizePathNode replaces the characters of a value that are not letters,
+// digits, '-' or '_', so the value is a single node of a Carbon path.
+func sanitizePathNode(value string) string {
+	mapRune := func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return sanitizedRune
+	}
+
+	return strings.Map(mapRune, value)
+}
+
+// pathFormatter builds the paths of the metrics of a resource, either in the
+// Carbon tagged format or, if template is set, from the template.
+type pathFormatter struct {
+	template       *pathTemplate
+	resourceLabels map[string]string
+}
+
+// path returns the path of a metric. It assumes that len(tagKeys) is equal to
+// len(labelValues). With a template, the labels it doesn't reference are not
+// part of the path.
+func (f *pathFormatter) path(name string, tagKeys []string, labelValues []*metricspb.LabelValue) string {
+	if f.template == nil {
+		return buildPath(name, tagKeys, labelValues)
+	}
+
+	return f.template.execute(name, func(key string) (string, bool) {
+		for i, tagKey := range tagKeys {
+			if tagKey == key && labelValues[i].HasValue {
+				return labelValues[i].Value, true
+			}
+		}
+		value, ok := f.resourceLabels[key]
+		return value, ok
+	})
+}
+
+// tag adds a tag to a path built by the path method. With a template, the tag
+// is appended as two more path nodes.
+func (f *pathFormatter) tag(path, key, value string) string {
+	if f.template == nil {
+		return path + tagPrefix + key + tagKeyValueSeparator + value
+	}
+	return path + "." + key + "." + sanitizePathNode(value)
+}
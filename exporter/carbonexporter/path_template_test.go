@@ -0,0 +1,120 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/testutil/metricstestutil"
+)
+
+func Test_newPathTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{
+			name:     "metric_only",
+			template: "{metric}",
+		},
+		{
+			name:     "with_labels",
+			template: "servers.{host.name}.{metric}.{k0}",
+		},
+		{
+			name:     "missing_metric",
+			template: "servers.{host.name}",
+			wantErr:  true,
+		},
+		{
+			name:     "unclosed_placeholder",
+			template: "servers.{host.name.{metric}",
+			wantErr:  true,
+		},
+		{
+			name:     "unexpected_close",
+			template: "servers.host}.{metric}",
+			wantErr:  true,
+		},
+		{
+			name:     "empty_placeholder",
+			template: "servers.{}.{metric}",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newPathTemplate(tt.template)
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+				return
+			}
+			assert.NotNil(t, got)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_metricDataToPlaintext_pathTemplate(t *testing.T) {
+	template, err := newPathTemplate("servers.{host.name}.{metric}.{k0}.{k2}")
+	require.NoError(t, err)
+
+	tsUnix := time.Unix(1574092046, 0)
+	int64Pt := &metricspb.Point{
+		Timestamp: metricstestutil.Timestamp(tsUnix),
+		Value:     &metricspb.Point_Int64Value{Int64Value: 123},
+	}
+	distributionPt := metricstestutil.DistPt(tsUnix, []float64{1.5}, []int64{4, 2})
+	distributionSumStr := formatFloatForValue(distributionPt.GetDistributionValue().GetSum())
+
+	md := consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{
+			Labels: map[string]string{"host.name": "host 1"},
+		},
+		Metrics: []*metricspb.Metric{
+			metricstestutil.GaugeInt(
+				"gauge",
+				[]string{"k0", "k1"},
+				metricstestutil.Timeseries(tsUnix, []string{"v0", "v1"}, int64Pt)),
+			metricstestutil.GaugeDist(
+				"distrib",
+				[]string{"k0"},
+				metricstestutil.Timeseries(tsUnix, []string{"v.0"}, distributionPt)),
+		},
+	}
+
+	gotLines, gotNumConvertedTimeseries, gotNumDroppedTimeseries := metricDataToPlaintext(md, template)
+	assert.Equal(t, 2, gotNumConvertedTimeseries)
+	assert.Equal(t, 0, gotNumDroppedTimeseries)
+
+	got := strings.Split(gotLines, "\n")
+	got = got[:len(got)-1]
+	assert.Equal(t, []string{
+		"servers.host_1.gauge.v0.unknown 123 1574092046",
+		"servers.host_1.distrib.count.v_0.unknown 6 1574092046",
+		"servers.host_1.distrib.v_0.unknown " + distributionSumStr + " 1574092046",
+		"servers.host_1.distrib.bucket.v_0.unknown.upper_bound.1_5 4 1574092046",
+		"servers.host_1.distrib.bucket.v_0.unknown.upper_bound.inf 2 1574092046",
+	}, got)
+}
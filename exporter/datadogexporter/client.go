@@ -0,0 +1,80 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+const apiKeyHeader = "DD-Api-Key"
+
+// client sends gzipped payloads to the Datadog endpoints.
+type client struct {
+	httpClient *http.Client
+	apiKey     string
+}
+
+func newClient(cfg *Config) *client {
+	return &client{
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		apiKey:     cfg.API.Key,
+	}
+}
+
+// post sends body to url. The errors that retrying cannot fix are permanent.
+func (c *client) post(ctx context.Context, url string, contentType string, body []byte) error {
+	buf := new(bytes.Buffer)
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(body); err != nil {
+		return consumererror.Permanent(err)
+	}
+	if err := w.Close(); err != nil {
+		return consumererror.Permanent(err)
+	}
+
+	req, err := http.NewRequest("POST", url, buf)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set(apiKeyHeader, c.apiKey)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("HTTP %d %q from %s", resp.StatusCode, http.StatusText(resp.StatusCode), url)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return consumererror.Permanent(err)
+	}
+	return err
+}
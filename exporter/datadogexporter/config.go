@@ -0,0 +1,140 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	// DefaultSite is the Datadog site used when "site" is not set.
+	DefaultSite = "datadoghq.com"
+
+	defaultTimeout = 5 * time.Second
+)
+
+// APIConfig defines the Datadog API settings.
+type APIConfig struct {
+	// Key is the Datadog API key used to authenticate the requests.
+	Key string `mapstructure:"key"`
+
+	// Site is the Datadog site the data is sent to, e.g. datadoghq.com or
+	// datadoghq.eu. It is used to build the default endpoints.
+	Site string `mapstructure:"site"`
+}
+
+// TagsConfig defines the host and the tags of the exported data. The unified
+// service tags env, service and version are taken from the
+// deployment.environment, service.name and service.version resource
+// attributes, and from this configuration when a resource does not have them.
+type TagsConfig struct {
+	// Hostname is the host of the data without a host.name resource attribute.
+	// It defaults to the host name of the collector.
+	Hostname string `mapstructure:"hostname"`
+
+	// Env is the default env tag.
+	Env string `mapstructure:"env"`
+
+	// Service is the default service tag.
+	Service string `mapstructure:"service"`
+
+	// Version is the default version tag.
+	Version string `mapstructure:"version"`
+
+	// Tags are added to all the exported data, in the "key:value" format.
+	Tags []string `mapstructure:"tags"`
+}
+
+// MetricsConfig defines the metrics settings.
+type MetricsConfig struct {
+	// Endpoint is the Datadog API endpoint the metrics and the host metadata
+	// are sent to. It defaults to https://api.<site>.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Namespace is the prefix added to the metric names.
+	Namespace string `mapstructure:"namespace"`
+}
+
+// TracesConfig defines the traces settings.
+type TracesConfig struct {
+	// Endpoint is the Datadog APM intake endpoint the traces are sent to. It
+	// defaults to https://trace.agent.<site>.
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+// Config defines configuration for the Datadog exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	API APIConfig `mapstructure:"api"`
+
+	TagsConfig `mapstructure:",squash"`
+
+	Metrics MetricsConfig `mapstructure:"metrics"`
+
+	Traces TracesConfig `mapstructure:"traces"`
+
+	// Timeout is the timeout of the HTTP requests sent to Datadog. Defaults
+	// to 5 seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// sanitize validates the configuration and fills in the defaults derived from
+// the other settings.
+func (c *Config) sanitize() error {
+	c.API.Key = strings.TrimSpace(c.API.Key)
+	if c.API.Key == "" {
+		return errors.New("requires a non-empty \"api.key\"")
+	}
+	if c.Timeout < 0 {
+		return errors.New("cannot have a negative \"timeout\"")
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultTimeout
+	}
+
+	for _, tag := range c.Tags {
+		if !strings.Contains(tag, ":") {
+			return fmt.Errorf("invalid tag %q, tags must have the \"key:value\" format", tag)
+		}
+	}
+
+	if c.API.Site == "" {
+		c.API.Site = DefaultSite
+	}
+	if c.Metrics.Endpoint == "" {
+		c.Metrics.Endpoint = "https://api." + c.API.Site
+	}
+	if c.Traces.Endpoint == "" {
+		c.Traces.Endpoint = "https://trace.agent." + c.API.Site
+	}
+	c.Metrics.Endpoint = strings.TrimSuffix(c.Metrics.Endpoint, "/")
+	c.Traces.Endpoint = strings.TrimSuffix(c.Traces.Endpoint, "/")
+
+	if c.Hostname == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("cannot get the host name, \"hostname\" must be set: %w", err)
+		}
+		c.Hostname = hostname
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters["datadog"].(*Config)
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.API.Key = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	assert.Equal(t, defaultCfg, e0)
+
+	e1 := cfg.Exporters["datadog/2"].(*Config)
+	assert.Equal(t, &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "datadog/2",
+			TypeVal: "datadog",
+		},
+		API: APIConfig{
+			Key:  "abc",
+			Site: "datadoghq.eu",
+		},
+		TagsConfig: TagsConfig{
+			Hostname: "customhostname",
+			Env:      "prod",
+			Service:  "myservice",
+			Version:  "myversion",
+			Tags:     []string{"example:tag"},
+		},
+		Metrics: MetricsConfig{
+			Endpoint:  "https://api.datadoghq.test",
+			Namespace: "otel",
+		},
+		Traces: TracesConfig{
+			Endpoint: "https://trace.agent.datadoghq.test",
+		},
+		Timeout: 10 * time.Second,
+	}, e1)
+}
+
+func TestSanitize(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.API.Key = " abc "
+	cfg.API.Site = "datadoghq.eu"
+	cfg.Hostname = "host"
+	require.NoError(t, cfg.sanitize())
+	assert.Equal(t, "abc", cfg.API.Key)
+	assert.Equal(t, "https://api.datadoghq.eu", cfg.Metrics.Endpoint)
+	assert.Equal(t, "https://trace.agent.datadoghq.eu", cfg.Traces.Endpoint)
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Metrics.Endpoint = "http://localhost:8080/"
+	cfg.API.Key = "abc"
+	require.NoError(t, cfg.sanitize())
+	assert.Equal(t, "http://localhost:8080", cfg.Metrics.Endpoint)
+	assert.Equal(t, "https://trace.agent.datadoghq.com", cfg.Traces.Endpoint)
+	assert.NotEmpty(t, cfg.Hostname)
+}
+
+func TestSanitizeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no API key",
+			modify: func(cfg *Config) { cfg.API.Key = "" },
+			err:    `requires a non-empty "api.key"`,
+		},
+		{
+			name:   "negative timeout",
+			modify: func(cfg *Config) { cfg.Timeout = -time.Second },
+			err:    `cannot have a negative "timeout"`,
+		},
+		{
+			name:   "invalid tag",
+			modify: func(cfg *Config) { cfg.Tags = []string{"tag"} },
+			err:    `invalid tag "tag", tags must have the "key:value" format`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.API.Key = "abc"
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.sanitize(), tt.err)
+		})
+	}
+}
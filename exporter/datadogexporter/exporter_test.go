@@ -0,0 +1,174 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/exportable/pb"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+type request struct {
+	path   string
+	apiKey string
+	body   []byte
+}
+
+// fakeDatadog records the requests it receives and replies with status.
+type fakeDatadog struct {
+	*httptest.Server
+	status int
+
+	mu       sync.Mutex
+	requests []request
+}
+
+func newFakeDatadog(t *testing.T) *fakeDatadog {
+	f := &fakeDatadog{status: http.StatusAccepted}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		gz, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(gz)
+		require.NoError(t, err)
+
+		f.mu.Lock()
+		f.requests = append(f.requests, request{path: r.URL.Path, apiKey: r.Header.Get(apiKeyHeader), body: body})
+		f.mu.Unlock()
+		w.WriteHeader(f.status)
+	}))
+	return f
+}
+
+func testConfig(endpoint string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.API.Key = "key"
+	cfg.Hostname = "collector-host"
+	cfg.Metrics.Endpoint = endpoint
+	cfg.Traces.Endpoint = endpoint
+	return cfg
+}
+
+func TestPushMetrics(t *testing.T) {
+	server := newFakeDatadog(t)
+	defer server.Close()
+	cfg := testConfig(server.URL)
+	require.NoError(t, cfg.sanitize())
+	exp := newMetricsExporter(cfg, zap.NewNop())
+
+	md := pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{{
+		Metrics: []*metricspb.Metric{{
+			MetricDescriptor: &metricspb.MetricDescriptor{Name: "gauge", Type: metricspb.MetricDescriptor_GAUGE_INT64},
+			Timeseries: []*metricspb.TimeSeries{{
+				Points: []*metricspb.Point{{
+					Timestamp: &timestamp.Timestamp{Seconds: 100},
+					Value:     &metricspb.Point_Int64Value{Int64Value: 1},
+				}},
+			}},
+		}},
+	}})
+
+	dropped, err := exp.pushMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+
+	// The host metadata is sent once.
+	_, err = exp.pushMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	require.Len(t, server.requests, 3)
+	assert.Equal(t, "/intake", server.requests[0].path)
+	assert.Equal(t, "key", server.requests[0].apiKey)
+	var metadata hostMetadata
+	require.NoError(t, json.Unmarshal(server.requests[0].body, &metadata))
+	assert.Equal(t, "collector-host", metadata.InternalHostname)
+
+	assert.Equal(t, "/api/v1/series", server.requests[1].path)
+	assert.JSONEq(t,
+		`{"series":[{"metric":"gauge","points":[[100,1]],"type":"gauge","host":"collector-host"}]}`,
+		string(server.requests[1].body))
+	assert.Equal(t, "/api/v1/series", server.requests[2].path)
+}
+
+func TestPushMetricsError(t *testing.T) {
+	server := newFakeDatadog(t)
+	defer server.Close()
+	cfg := testConfig(server.URL)
+	require.NoError(t, cfg.sanitize())
+	exp := newMetricsExporter(cfg, zap.NewNop())
+
+	md := pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{{
+		Metrics: []*metricspb.Metric{{
+			MetricDescriptor: &metricspb.MetricDescriptor{Name: "gauge", Type: metricspb.MetricDescriptor_GAUGE_DOUBLE},
+			Timeseries: []*metricspb.TimeSeries{{
+				Points: []*metricspb.Point{{Value: &metricspb.Point_DoubleValue{DoubleValue: 1}}},
+			}},
+		}},
+	}})
+
+	server.status = http.StatusForbidden
+	dropped, err := exp.pushMetrics(context.Background(), md)
+	assert.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, dropped)
+
+	server.status = http.StatusServiceUnavailable
+	_, err = exp.pushMetrics(context.Background(), md)
+	assert.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestPushTraces(t *testing.T) {
+	server := newFakeDatadog(t)
+	defer server.Close()
+	cfg := testConfig(server.URL)
+	require.NoError(t, cfg.sanitize())
+	exp := newTracesExporter(cfg, zap.NewNop())
+
+	dropped, err := exp.pushTraces(context.Background(), testTraces())
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+
+	require.Len(t, server.requests, 1)
+	assert.Equal(t, "/api/v0.2/traces", server.requests[0].path)
+	assert.Equal(t, "key", server.requests[0].apiKey)
+	var payload pb.TracePayload
+	require.NoError(t, proto.Unmarshal(server.requests[0].body, &payload))
+	assert.Equal(t, "collector-host", payload.HostName)
+	assert.Equal(t, "prod", payload.Env)
+	require.Len(t, payload.Traces, 1)
+	assert.Len(t, payload.Traces[0].Spans, 2)
+
+	server.status = http.StatusBadRequest
+	dropped, err = exp.pushTraces(context.Background(), testTraces())
+	assert.Error(t, err)
+	assert.Equal(t, 2, dropped)
+}
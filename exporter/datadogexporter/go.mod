@@ -0,0 +1,14 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter
+
+go 1.14
+
+require (
+	github.com/DataDog/datadog-agent v0.0.0-20200417180928-f454c60bc16f
+	github.com/census-instrumentation/opencensus-proto v0.3.0
+	github.com/gogo/protobuf v1.3.1
+	github.com/golang/protobuf v1.4.2
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/collector v0.8.1-0.20200815205113-8e5c6065eb0e
+	go.uber.org/zap v1.15.0
+	google.golang.org/grpc/examples v0.0.0-20200728194956-1c32b02682df // indirect
+)
@@ -0,0 +1,91 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// hostMetadata is the payload of the Datadog intake registering a host and
+// its tags, making the hosts of the metrics known to the infrastructure list.
+type hostMetadata struct {
+	InternalHostname string   `json:"internalHostname"`
+	Flavor           string   `json:"agent-flavor"`
+	Meta             hostMeta `json:"meta"`
+	Tags             hostTags `json:"host-tags"`
+}
+
+type hostMeta struct {
+	Hostname string `json:"hostname"`
+}
+
+type hostTags struct {
+	System []string `json:"system,omitempty"`
+}
+
+// hostMetadataSender sends the metadata of each host once.
+type hostMetadataSender struct {
+	client *client
+	url    string
+	tags   []string
+
+	mu   sync.Mutex
+	sent map[string]bool
+}
+
+func newHostMetadataSender(c *client, cfg *Config) *hostMetadataSender {
+	return &hostMetadataSender{
+		client: c,
+		url:    cfg.Metrics.Endpoint + "/intake",
+		tags:   cfg.Tags,
+		sent:   make(map[string]bool),
+	}
+}
+
+// send sends the metadata of the hosts not sent yet. A host whose metadata
+// failed to be sent is retried the next time.
+func (s *hostMetadataSender) send(ctx context.Context, hosts []string) error {
+	var lastErr error
+	for _, host := range hosts {
+		s.mu.Lock()
+		sent := s.sent[host]
+		s.mu.Unlock()
+		if sent || host == "" {
+			continue
+		}
+
+		body, err := json.Marshal(&hostMetadata{
+			InternalHostname: host,
+			Flavor:           "opentelemetry-collector",
+			Meta:             hostMeta{Hostname: host},
+			Tags:             hostTags{System: s.tags},
+		})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if err := s.client.post(ctx, s.url, "application/json", body); err != nil {
+			lastErr = err
+			continue
+		}
+
+		s.mu.Lock()
+		s.sent[host] = true
+		s.mu.Unlock()
+	}
+	return lastErr
+}
@@ -0,0 +1,89 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+// deltaCacheTTL is how long the previous value of a cumulative time series is
+// kept without new points.
+const deltaCacheTTL = time.Hour
+
+type metricsExporter struct {
+	logger     *zap.Logger
+	client     *client
+	url        string
+	translator *metricsTranslator
+	metadata   *hostMetadataSender
+}
+
+func newMetricsExporter(cfg *Config, logger *zap.Logger) *metricsExporter {
+	c := newClient(cfg)
+	return &metricsExporter{
+		logger:     logger,
+		client:     c,
+		url:        cfg.Metrics.Endpoint + "/api/v1/series",
+		translator: newMetricsTranslator(cfg),
+		metadata:   newHostMetadataSender(c, cfg),
+	}
+}
+
+func (e *metricsExporter) pushMetrics(ctx context.Context, md pdata.Metrics) (int, error) {
+	_, numPoints := pdatautil.MetricAndDataPointCount(md)
+
+	now := time.Now()
+	var ss []series
+	for _, data := range pdatautil.MetricsToMetricsData(md) {
+		ss = append(ss, e.translator.translate(data, now)...)
+	}
+	e.translator.prevPts.prune(now.Add(-deltaCacheTTL))
+	if len(ss) == 0 {
+		return 0, nil
+	}
+
+	if err := e.metadata.send(ctx, seriesHosts(ss)); err != nil {
+		e.logger.Warn("Failed to send host metadata to Datadog", zap.Error(err))
+	}
+
+	body, err := json.Marshal(&seriesPayload{Series: ss})
+	if err != nil {
+		return numPoints, consumererror.Permanent(err)
+	}
+	if err := e.client.post(ctx, e.url, "application/json", body); err != nil {
+		return numPoints, err
+	}
+	return 0, nil
+}
+
+// seriesHosts returns the distinct hosts of the series.
+func seriesHosts(ss []series) []string {
+	seen := make(map[string]bool)
+	var hosts []string
+	for _, s := range ss {
+		if !seen[s.Host] {
+			seen[s.Host] = true
+			hosts = append(hosts, s.Host)
+		}
+	}
+	return hosts
+}
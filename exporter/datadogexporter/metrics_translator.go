@@ -0,0 +1,270 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+const (
+	gaugeType = "gauge"
+	countType = "count"
+)
+
+// series is a metric time series of the Datadog series API.
+type series struct {
+	Metric string       `json:"metric"`
+	Points [][2]float64 `json:"points"`
+	Type   string       `json:"type"`
+	Host   string       `json:"host,omitempty"`
+	Tags   []string     `json:"tags,omitempty"`
+}
+
+// seriesPayload is the body of a request to the Datadog series API.
+type seriesPayload struct {
+	Series []series `json:"series"`
+}
+
+// metricsTranslator translates OpenCensus metrics to Datadog series. As the
+// Datadog counts are deltas, the cumulative values are converted to their
+// difference with the previous value of their time series.
+type metricsTranslator struct {
+	tagsConfig *TagsConfig
+	namespace  string
+	prevPts    *deltaCache
+}
+
+func newMetricsTranslator(cfg *Config) *metricsTranslator {
+	return &metricsTranslator{
+		tagsConfig: &cfg.TagsConfig,
+		namespace:  cfg.Metrics.Namespace,
+		prevPts:    newDeltaCache(),
+	}
+}
+
+// translate returns the series of the metrics. The first point of a
+// cumulative time series is only used as the reference of the next delta.
+func (t *metricsTranslator) translate(md consumerdata.MetricsData, now time.Time) []series {
+	var labels map[string]string
+	if md.Resource != nil {
+		labels = md.Resource.Labels
+	}
+	st := t.tagsConfig.resolve(labelsGetter(labels))
+	resourceTags := t.tagsConfig.tags(st)
+
+	var out []series
+	for _, metric := range md.Metrics {
+		if metric == nil || metric.MetricDescriptor == nil {
+			continue
+		}
+		desc := metric.MetricDescriptor
+		name := t.metricName(desc.Name)
+		for _, ts := range metric.Timeseries {
+			if ts == nil {
+				continue
+			}
+			tags := append(append([]string(nil), resourceTags...), labelTags(desc.LabelKeys, ts.LabelValues)...)
+			startTime := timestampToNanos(ts.StartTimestamp)
+			for _, point := range ts.Points {
+				if point == nil {
+					continue
+				}
+				pt := pointContext{
+					name:      name,
+					host:      st.host,
+					tags:      tags,
+					startTime: startTime,
+					timestamp: timestampToSeconds(point.Timestamp, now),
+					now:       now,
+				}
+				out = t.translatePoint(out, desc.Type, pt, point)
+			}
+		}
+	}
+	return out
+}
+
+// pointContext holds what the series of a point share.
+type pointContext struct {
+	name      string
+	host      string
+	tags      []string
+	startTime int64
+	timestamp float64
+	now       time.Time
+}
+
+func (t *metricsTranslator) translatePoint(out []series, typ metricspb.MetricDescriptor_Type, pt pointContext, point *metricspb.Point) []series {
+	switch typ {
+	case metricspb.MetricDescriptor_GAUGE_INT64, metricspb.MetricDescriptor_GAUGE_DOUBLE:
+		if v, ok := numberValue(point); ok {
+			out = append(out, pt.gauge(pt.name, v, pt.tags))
+		}
+	case metricspb.MetricDescriptor_CUMULATIVE_INT64, metricspb.MetricDescriptor_CUMULATIVE_DOUBLE:
+		if v, ok := numberValue(point); ok {
+			out = t.appendDelta(out, pt, pt.name, v)
+		}
+	case metricspb.MetricDescriptor_GAUGE_DISTRIBUTION:
+		if d := point.GetDistributionValue(); d != nil {
+			out = append(out,
+				pt.gauge(pt.name+".count", float64(d.Count), pt.tags),
+				pt.gauge(pt.name+".sum", d.Sum, pt.tags))
+		}
+	case metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION:
+		if d := point.GetDistributionValue(); d != nil {
+			out = t.appendDelta(out, pt, pt.name+".count", float64(d.Count))
+			out = t.appendDelta(out, pt, pt.name+".sum", d.Sum)
+		}
+	case metricspb.MetricDescriptor_SUMMARY:
+		s := point.GetSummaryValue()
+		if s == nil {
+			break
+		}
+		if s.Count != nil {
+			out = t.appendDelta(out, pt, pt.name+".count", float64(s.Count.Value))
+		}
+		if s.Sum != nil {
+			out = t.appendDelta(out, pt, pt.name+".sum", s.Sum.Value)
+		}
+		if s.Snapshot != nil {
+			for _, pv := range s.Snapshot.PercentileValues {
+				if pv == nil {
+					continue
+				}
+				quantile := strconv.FormatFloat(pv.Percentile/100, 'g', -1, 64)
+				tags := append(append([]string(nil), pt.tags...), "quantile:"+quantile)
+				out = append(out, pt.gauge(pt.name+".quantile", pv.Value, tags))
+			}
+		}
+	}
+	return out
+}
+
+func (t *metricsTranslator) appendDelta(out []series, pt pointContext, name string, value float64) []series {
+	key := name + "|" + pt.host + "|" + strings.Join(pt.tags, ",")
+	delta, ok := t.prevPts.delta(key, pt.startTime, value, pt.now)
+	if !ok {
+		return out
+	}
+	return append(out, series{
+		Metric: name,
+		Points: [][2]float64{{pt.timestamp, delta}},
+		Type:   countType,
+		Host:   pt.host,
+		Tags:   pt.tags,
+	})
+}
+
+func (pt pointContext) gauge(name string, value float64, tags []string) series {
+	return series{
+		Metric: name,
+		Points: [][2]float64{{pt.timestamp, value}},
+		Type:   gaugeType,
+		Host:   pt.host,
+		Tags:   tags,
+	}
+}
+
+func (t *metricsTranslator) metricName(name string) string {
+	if t.namespace == "" {
+		return name
+	}
+	return t.namespace + "." + name
+}
+
+func labelTags(keys []*metricspb.LabelKey, values []*metricspb.LabelValue) []string {
+	tags := make([]string, 0, len(keys))
+	for i, key := range keys {
+		if key == nil || i >= len(values) || values[i] == nil || !values[i].HasValue {
+			continue
+		}
+		tags = append(tags, key.Key+":"+values[i].Value)
+	}
+	return tags
+}
+
+func numberValue(point *metricspb.Point) (float64, bool) {
+	switch v := point.Value.(type) {
+	case *metricspb.Point_Int64Value:
+		return float64(v.Int64Value), true
+	case *metricspb.Point_DoubleValue:
+		return v.DoubleValue, true
+	}
+	return 0, false
+}
+
+func timestampToNanos(ts *timestamp.Timestamp) int64 {
+	if ts == nil {
+		return 0
+	}
+	return ts.Seconds*int64(time.Second) + int64(ts.Nanos)
+}
+
+func timestampToSeconds(ts *timestamp.Timestamp, now time.Time) float64 {
+	if ts == nil {
+		return float64(now.Unix())
+	}
+	return float64(ts.Seconds)
+}
+
+// deltaCache holds the previous value of the cumulative time series.
+type deltaCache struct {
+	mu     sync.Mutex
+	points map[string]cachedPoint
+}
+
+type cachedPoint struct {
+	startTime int64
+	value     float64
+	seen      time.Time
+}
+
+func newDeltaCache() *deltaCache {
+	return &deltaCache{points: make(map[string]cachedPoint)}
+}
+
+// delta records value as the last value of the time series key, and returns
+// its difference with the previous one. It returns false when there is no
+// previous value, the time series having just started or been reset.
+func (c *deltaCache) delta(key string, startTime int64, value float64, now time.Time) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev, ok := c.points[key]
+	c.points[key] = cachedPoint{startTime: startTime, value: value, seen: now}
+	if !ok || prev.startTime != startTime || value < prev.value {
+		return 0, false
+	}
+	return value - prev.value, true
+}
+
+// prune removes the time series not seen since before.
+func (c *deltaCache) prune(before time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, p := range c.points {
+		if p.seen.Before(before) {
+			delete(c.points, key)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+func testTranslator() *metricsTranslator {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Hostname = "collector-host"
+	cfg.Env = "dev"
+	cfg.Tags = []string{"team:otel"}
+	cfg.Metrics.Namespace = "otel"
+	return newMetricsTranslator(cfg)
+}
+
+func testMetric(name string, typ metricspb.MetricDescriptor_Type, points ...*metricspb.Point) *metricspb.Metric {
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      name,
+			Type:      typ,
+			LabelKeys: []*metricspb.LabelKey{{Key: "k0"}, {Key: "k1"}},
+		},
+		Timeseries: []*metricspb.TimeSeries{{
+			StartTimestamp: &timestamp.Timestamp{Seconds: 100},
+			LabelValues:    []*metricspb.LabelValue{{Value: "v0", HasValue: true}, {}},
+			Points:         points,
+		}},
+	}
+}
+
+func TestTranslateGauges(t *testing.T) {
+	md := consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{Labels: map[string]string{
+			"host.name":    "resource-host",
+			"service.name": "svc",
+		}},
+		Metrics: []*metricspb.Metric{
+			testMetric("int_gauge", metricspb.MetricDescriptor_GAUGE_INT64, &metricspb.Point{
+				Timestamp: &timestamp.Timestamp{Seconds: 200},
+				Value:     &metricspb.Point_Int64Value{Int64Value: 3},
+			}),
+			testMetric("double_gauge", metricspb.MetricDescriptor_GAUGE_DOUBLE, &metricspb.Point{
+				Timestamp: &timestamp.Timestamp{Seconds: 200},
+				Value:     &metricspb.Point_DoubleValue{DoubleValue: 1.5},
+			}),
+		},
+	}
+
+	tags := []string{"team:otel", "env:dev", "service:svc", "k0:v0"}
+	assert.Equal(t, []series{
+		{Metric: "otel.int_gauge", Points: [][2]float64{{200, 3}}, Type: gaugeType, Host: "resource-host", Tags: tags},
+		{Metric: "otel.double_gauge", Points: [][2]float64{{200, 1.5}}, Type: gaugeType, Host: "resource-host", Tags: tags},
+	}, testTranslator().translate(md, time.Now()))
+}
+
+func TestTranslateCumulativeToDelta(t *testing.T) {
+	translator := testTranslator()
+	cumulative := func(value int64, ts int64) consumerdata.MetricsData {
+		return consumerdata.MetricsData{
+			Metrics: []*metricspb.Metric{
+				testMetric("requests", metricspb.MetricDescriptor_CUMULATIVE_INT64, &metricspb.Point{
+					Timestamp: &timestamp.Timestamp{Seconds: ts},
+					Value:     &metricspb.Point_Int64Value{Int64Value: value},
+				}),
+			},
+		}
+	}
+	now := time.Now()
+
+	// The first point is the reference of the next delta.
+	assert.Empty(t, translator.translate(cumulative(10, 200), now))
+
+	tags := []string{"team:otel", "env:dev", "k0:v0"}
+	assert.Equal(t, []series{
+		{Metric: "otel.requests", Points: [][2]float64{{210, 5}}, Type: countType, Host: "collector-host", Tags: tags},
+	}, translator.translate(cumulative(15, 210), now))
+
+	// A decreasing value is a reset.
+	assert.Empty(t, translator.translate(cumulative(2, 220), now))
+	assert.Equal(t, []series{
+		{Metric: "otel.requests", Points: [][2]float64{{230, 1}}, Type: countType, Host: "collector-host", Tags: tags},
+	}, translator.translate(cumulative(3, 230), now))
+
+	// The time series not seen recently are forgotten.
+	translator.prevPts.prune(now.Add(time.Second))
+	assert.Empty(t, translator.translate(cumulative(4, 240), now))
+}
+
+func TestTranslateSummary(t *testing.T) {
+	translator := testTranslator()
+	summary := func(count int64, sum float64) consumerdata.MetricsData {
+		return consumerdata.MetricsData{
+			Metrics: []*metricspb.Metric{
+				testMetric("latency", metricspb.MetricDescriptor_SUMMARY, &metricspb.Point{
+					Timestamp: &timestamp.Timestamp{Seconds: 200},
+					Value: &metricspb.Point_SummaryValue{SummaryValue: &metricspb.SummaryValue{
+						Count: &wrappers.Int64Value{Value: count},
+						Sum:   &wrappers.DoubleValue{Value: sum},
+						Snapshot: &metricspb.SummaryValue_Snapshot{
+							PercentileValues: []*metricspb.SummaryValue_Snapshot_ValueAtPercentile{
+								{Percentile: 99, Value: 7},
+							},
+						},
+					}},
+				}),
+			},
+		}
+	}
+	now := time.Now()
+
+	tags := []string{"team:otel", "env:dev", "k0:v0"}
+	quantileTags := []string{"team:otel", "env:dev", "k0:v0", "quantile:0.99"}
+	assert.Equal(t, []series{
+		{Metric: "otel.latency.quantile", Points: [][2]float64{{200, 7}}, Type: gaugeType, Host: "collector-host", Tags: quantileTags},
+	}, translator.translate(summary(2, 10), now))
+	assert.Equal(t, []series{
+		{Metric: "otel.latency.count", Points: [][2]float64{{200, 3}}, Type: countType, Host: "collector-host", Tags: tags},
+		{Metric: "otel.latency.sum", Points: [][2]float64{{200, 5}}, Type: countType, Host: "collector-host", Tags: tags},
+		{Metric: "otel.latency.quantile", Points: [][2]float64{{200, 7}}, Type: gaugeType, Host: "collector-host", Tags: quantileTags},
+	}, translator.translate(summary(5, 15), now))
+}
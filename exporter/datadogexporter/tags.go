@@ -0,0 +1,100 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// attributeDeploymentEnvironment is the resource attribute holding the env tag.
+const attributeDeploymentEnvironment = "deployment.environment"
+
+// serviceTags are the host and the unified service tags of a resource.
+type serviceTags struct {
+	host    string
+	env     string
+	service string
+	version string
+}
+
+// resolve returns the host and the unified service tags of a resource, whose
+// attributes are looked up with get, falling back to the configured ones.
+func (c *TagsConfig) resolve(get func(key string) string) serviceTags {
+	st := serviceTags{
+		host:    c.Hostname,
+		env:     c.Env,
+		service: c.Service,
+		version: c.Version,
+	}
+	if get == nil {
+		return st
+	}
+	if v := get(conventions.AttributeHostName); v != "" {
+		st.host = v
+	}
+	if v := get(attributeDeploymentEnvironment); v != "" {
+		st.env = v
+	}
+	if v := get(conventions.AttributeServiceName); v != "" {
+		st.service = v
+	}
+	if v := get(conventions.AttributeServiceVersion); v != "" {
+		st.version = v
+	}
+	return st
+}
+
+// tags returns the configured tags followed by the unified service tags.
+func (c *TagsConfig) tags(st serviceTags) []string {
+	tags := make([]string, 0, len(c.Tags)+3)
+	tags = append(tags, c.Tags...)
+	if st.env != "" {
+		tags = append(tags, "env:"+st.env)
+	}
+	if st.service != "" {
+		tags = append(tags, "service:"+st.service)
+	}
+	if st.version != "" {
+		tags = append(tags, "version:"+st.version)
+	}
+	return tags
+}
+
+// labelsGetter returns the lookup of the resolve method for OpenCensus
+// resource labels.
+func labelsGetter(labels map[string]string) func(key string) string {
+	if labels == nil {
+		return nil
+	}
+	return func(key string) string {
+		return labels[key]
+	}
+}
+
+// attributesGetter returns the lookup of the resolve method for the
+// attributes of a resource.
+func attributesGetter(resource pdata.Resource) func(key string) string {
+	if resource.IsNil() {
+		return nil
+	}
+	attrs := resource.Attributes()
+	return func(key string) string {
+		if v, ok := attrs.Get(key); ok && v.Type() == pdata.AttributeValueSTRING {
+			return v.StringVal()
+		}
+		return ""
+	}
+}
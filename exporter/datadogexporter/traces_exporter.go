@@ -0,0 +1,72 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"context"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/exportable/pb"
+	"github.com/gogo/protobuf/proto"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type tracesExporter struct {
+	logger     *zap.Logger
+	client     *client
+	url        string
+	translator *tracesTranslator
+}
+
+func newTracesExporter(cfg *Config, logger *zap.Logger) *tracesExporter {
+	return &tracesExporter{
+		logger:     logger,
+		client:     newClient(cfg),
+		url:        cfg.Traces.Endpoint + "/api/v0.2/traces",
+		translator: &tracesTranslator{tagsConfig: &cfg.TagsConfig},
+	}
+}
+
+func (e *tracesExporter) pushTraces(ctx context.Context, td pdata.Traces) (int, error) {
+	var (
+		numDropped int
+		errs       []error
+	)
+	for _, payload := range e.translator.translate(td) {
+		if err := e.pushPayload(ctx, payload); err != nil {
+			numDropped += payloadSpanCount(payload)
+			errs = append(errs, err)
+		}
+	}
+	return numDropped, componenterror.CombineErrors(errs)
+}
+
+func (e *tracesExporter) pushPayload(ctx context.Context, payload *pb.TracePayload) error {
+	body, err := proto.Marshal(payload)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	return e.client.post(ctx, e.url, "application/x-protobuf", body)
+}
+
+func payloadSpanCount(payload *pb.TracePayload) int {
+	n := 0
+	for _, trace := range payload.Traces {
+		n += len(trace.Spans)
+	}
+	return n
+}
@@ -0,0 +1,240 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/exportable/pb"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	// defaultServiceName is the service of the spans without service tag.
+	defaultServiceName = "unknown_service"
+	// defaultOperationPrefix is the prefix of the operation name of the spans
+	// without instrumentation library.
+	defaultOperationPrefix = "opentelemetry"
+
+	samplingPriorityKey = "_sampling_priority_v1"
+	topLevelKey         = "_top_level"
+	errorMsgKey         = "error.msg"
+)
+
+// tracesTranslator translates spans to the trace payloads of the Datadog APM
+// intake.
+type tracesTranslator struct {
+	tagsConfig *TagsConfig
+}
+
+// translate returns a payload per host and env of the spans, holding their
+// spans grouped by trace.
+func (t *tracesTranslator) translate(td pdata.Traces) []*pb.TracePayload {
+	type payloadKey struct{ host, env string }
+	var (
+		payloads []*pb.TracePayload
+		byKey    = make(map[payloadKey]*pb.TracePayload)
+		traces   = make(map[*pb.TracePayload]map[uint64]*pb.APITrace)
+	)
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		st := t.tagsConfig.resolve(attributesGetter(rs.Resource()))
+		if st.service == "" {
+			st.service = defaultServiceName
+		}
+		resourceMeta := t.resourceMeta(rs.Resource())
+
+		key := payloadKey{host: st.host, env: st.env}
+		payload, ok := byKey[key]
+		if !ok {
+			payload = &pb.TracePayload{HostName: st.host, Env: st.env}
+			byKey[key] = payload
+			traces[payload] = make(map[uint64]*pb.APITrace)
+			payloads = append(payloads, payload)
+		}
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			operationPrefix := defaultOperationPrefix
+			if lib := ils.InstrumentationLibrary(); !lib.IsNil() && lib.Name() != "" {
+				operationPrefix = lib.Name()
+			}
+
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				ddSpan := convertSpan(span, st, resourceMeta, operationPrefix)
+
+				trace, ok := traces[payload][ddSpan.TraceID]
+				if !ok {
+					trace = &pb.APITrace{
+						TraceID:   ddSpan.TraceID,
+						StartTime: ddSpan.Start,
+						EndTime:   ddSpan.Start + ddSpan.Duration,
+					}
+					traces[payload][ddSpan.TraceID] = trace
+					payload.Traces = append(payload.Traces, trace)
+				}
+				trace.Spans = append(trace.Spans, ddSpan)
+				if ddSpan.Start < trace.StartTime {
+					trace.StartTime = ddSpan.Start
+				}
+				if end := ddSpan.Start + ddSpan.Duration; end > trace.EndTime {
+					trace.EndTime = end
+				}
+			}
+		}
+	}
+	return payloads
+}
+
+// resourceMeta returns the resource attributes and the configured tags, as
+// span meta.
+func (t *tracesTranslator) resourceMeta(resource pdata.Resource) map[string]string {
+	meta := make(map[string]string)
+	for _, tag := range t.tagsConfig.Tags {
+		k, v := splitTag(tag)
+		meta[k] = v
+	}
+	if !resource.IsNil() {
+		resource.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+			meta[k] = attributeValueToString(v)
+		})
+	}
+	return meta
+}
+
+func convertSpan(span pdata.Span, st serviceTags, resourceMeta map[string]string, operationPrefix string) *pb.Span {
+	meta := make(map[string]string, len(resourceMeta)+span.Attributes().Len()+2)
+	for k, v := range resourceMeta {
+		meta[k] = v
+	}
+	span.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		meta[k] = attributeValueToString(v)
+	})
+	if st.env != "" {
+		meta["env"] = st.env
+	}
+	if st.version != "" {
+		meta["version"] = st.version
+	}
+
+	var duration int64
+	if span.EndTime() > span.StartTime() {
+		duration = int64(span.EndTime() - span.StartTime())
+	}
+
+	ddSpan := &pb.Span{
+		Service:  st.service,
+		Name:     operationPrefix + "." + spanKindName(span.Kind()),
+		Resource: span.Name(),
+		TraceID:  idToUint64(span.TraceID()),
+		SpanID:   idToUint64(span.SpanID()),
+		ParentID: idToUint64(span.ParentSpanID()),
+		Start:    int64(span.StartTime()),
+		Duration: duration,
+		Meta:     meta,
+		Metrics:  map[string]float64{samplingPriorityKey: 1},
+		Type:     spanType(span.Kind(), meta),
+	}
+	if ddSpan.ParentID == 0 {
+		ddSpan.Metrics[topLevelKey] = 1
+	}
+
+	if status := span.Status(); !status.IsNil() && status.Code() != pdata.StatusCode(0) {
+		ddSpan.Error = 1
+		if msg := status.Message(); msg != "" {
+			meta[errorMsgKey] = msg
+		}
+	}
+	return ddSpan
+}
+
+// idToUint64 returns the lower 64 bits of a trace or span ID, Datadog IDs
+// being 64 bits long.
+func idToUint64(id []byte) uint64 {
+	if len(id) < 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(id[len(id)-8:])
+}
+
+func spanKindName(kind pdata.SpanKind) string {
+	switch kind {
+	case pdata.SpanKindSERVER:
+		return "server"
+	case pdata.SpanKindCLIENT:
+		return "client"
+	case pdata.SpanKindPRODUCER:
+		return "producer"
+	case pdata.SpanKindCONSUMER:
+		return "consumer"
+	case pdata.SpanKindINTERNAL:
+		return "internal"
+	}
+	return "unspecified"
+}
+
+// spanType returns the Datadog type of a span, used to pick the icon and
+// the views of the span in the APM UI.
+func spanType(kind pdata.SpanKind, meta map[string]string) string {
+	switch kind {
+	case pdata.SpanKindSERVER:
+		return "web"
+	case pdata.SpanKindCLIENT:
+		if _, ok := meta[conventions.AttributeDBSystem]; ok {
+			return "db"
+		}
+		return "http"
+	}
+	return "custom"
+}
+
+func attributeValueToString(v pdata.AttributeValue) string {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return strconv.FormatInt(v.IntVal(), 10)
+	case pdata.AttributeValueDOUBLE:
+		return strconv.FormatFloat(v.DoubleVal(), 'g', -1, 64)
+	case pdata.AttributeValueBOOL:
+		return strconv.FormatBool(v.BoolVal())
+	}
+	return ""
+}
+
+func splitTag(tag string) (string, string) {
+	kv := strings.SplitN(tag, ":", 2)
+	if len(kv) < 2 {
+		return kv[0], ""
+	}
+	return kv[0], kv[1]
+}
@@ -0,0 +1,125 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/exportable/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().InitEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	rs.Resource().Attributes().InsertString("deployment.environment", "prod")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	ils.InstrumentationLibrary().InitEmpty()
+	ils.InstrumentationLibrary().SetName("grpc")
+	ils.Spans().Resize(2)
+
+	root := ils.Spans().At(0)
+	root.SetTraceID(pdata.NewTraceID([]byte{1, 1, 1, 1, 1, 1, 1, 1, 0, 0, 0, 0, 0, 0, 0, 1}))
+	root.SetSpanID(pdata.NewSpanID([]byte{0, 0, 0, 0, 0, 0, 0, 2}))
+	root.SetName("/checkout")
+	root.SetKind(pdata.SpanKindSERVER)
+	root.SetStartTime(1000)
+	root.SetEndTime(5000)
+	root.Attributes().InsertInt("http.status_code", 500)
+	root.Status().InitEmpty()
+	root.Status().SetCode(pdata.StatusCode(13))
+	root.Status().SetMessage("internal error")
+
+	child := ils.Spans().At(1)
+	child.SetTraceID(root.TraceID())
+	child.SetSpanID(pdata.NewSpanID([]byte{0, 0, 0, 0, 0, 0, 0, 3}))
+	child.SetParentSpanID(root.SpanID())
+	child.SetName("SELECT")
+	child.SetKind(pdata.SpanKindCLIENT)
+	child.SetStartTime(2000)
+	child.SetEndTime(6000)
+	child.Attributes().InsertString("db.system", "postgresql")
+	return td
+}
+
+func TestTranslateTraces(t *testing.T) {
+	translator := &tracesTranslator{tagsConfig: &TagsConfig{
+		Hostname: "collector-host",
+		Version:  "1.0",
+		Tags:     []string{"team:otel"},
+	}}
+
+	payloads := translator.translate(testTraces())
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "collector-host", payloads[0].HostName)
+	assert.Equal(t, "prod", payloads[0].Env)
+	require.Len(t, payloads[0].Traces, 1)
+
+	trace := payloads[0].Traces[0]
+	assert.Equal(t, uint64(1), trace.TraceID)
+	assert.Equal(t, int64(1000), trace.StartTime)
+	assert.Equal(t, int64(6000), trace.EndTime)
+	require.Len(t, trace.Spans, 2)
+
+	assert.Equal(t, &pb.Span{
+		Service:  "checkout",
+		Name:     "grpc.server",
+		Resource: "/checkout",
+		TraceID:  1,
+		SpanID:   2,
+		Start:    1000,
+		Duration: 4000,
+		Error:    1,
+		Meta: map[string]string{
+			"team":                   "otel",
+			"service.name":           "checkout",
+			"deployment.environment": "prod",
+			"http.status_code":       "500",
+			"env":                    "prod",
+			"version":                "1.0",
+			"error.msg":              "internal error",
+		},
+		Metrics: map[string]float64{samplingPriorityKey: 1, topLevelKey: 1},
+		Type:    "web",
+	}, trace.Spans[0])
+
+	child := trace.Spans[1]
+	assert.Equal(t, "grpc.client", child.Name)
+	assert.Equal(t, uint64(2), child.ParentID)
+	assert.Equal(t, "db", child.Type)
+	assert.Zero(t, child.Error)
+	assert.Equal(t, map[string]float64{samplingPriorityKey: 1}, child.Metrics)
+}
+
+func TestTranslateTracesDefaultService(t *testing.T) {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.InstrumentationLibrarySpans().Resize(1)
+	rs.InstrumentationLibrarySpans().At(0).Spans().Resize(1)
+
+	payloads := (&tracesTranslator{tagsConfig: &TagsConfig{Hostname: "h"}}).translate(td)
+	require.Len(t, payloads, 1)
+	span := payloads[0].Traces[0].Spans[0]
+	assert.Equal(t, defaultServiceName, span.Service)
+	assert.Equal(t, "opentelemetry.unspecified", span.Name)
+	assert.Equal(t, "custom", span.Type)
+}
@@ -0,0 +1,85 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynatraceexporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	// DefaultOneAgentEndpoint is the metrics ingest endpoint of the local
+	// OneAgent, used when "endpoint" is not set. It does not require an API
+	// token.
+	DefaultOneAgentEndpoint = "http://127.0.0.1:14499/metrics/ingest"
+
+	defaultTimeout = 5 * time.Second
+)
+
+// Config defines configuration for the Dynatrace exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Endpoint is the URL of the Dynatrace metrics ingest v2 API, e.g.
+	// https://{environment-id}.live.dynatrace.com/api/v2/metrics/ingest.
+	// It defaults to the endpoint of the local OneAgent.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// APIToken is the Dynatrace API token, with the "Ingest metrics" scope,
+	// used to authenticate the requests. It is required unless the metrics are
+	// sent to the local OneAgent.
+	APIToken string `mapstructure:"api_token"`
+
+	// Prefix is added to the metric keys, separated by a dot.
+	Prefix string `mapstructure:"prefix"`
+
+	// Tags are dimensions added to all the metrics, in the "key=value" format.
+	Tags []string `mapstructure:"tags"`
+
+	// Timeout is the timeout of the HTTP requests sent to Dynatrace. Defaults
+	// to 5 seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// sanitize validates the configuration and fills in the defaults.
+func (c *Config) sanitize() error {
+	c.APIToken = strings.TrimSpace(c.APIToken)
+	if c.Endpoint == "" {
+		c.Endpoint = DefaultOneAgentEndpoint
+	}
+	if c.APIToken == "" && c.Endpoint != DefaultOneAgentEndpoint {
+		return errors.New("requires a non-empty \"api_token\" unless \"endpoint\" is the local OneAgent")
+	}
+	if !strings.HasPrefix(c.Endpoint, "http://") && !strings.HasPrefix(c.Endpoint, "https://") {
+		return fmt.Errorf("invalid \"endpoint\" %q, it must be an HTTP or HTTPS URL", c.Endpoint)
+	}
+	if c.Timeout < 0 {
+		return errors.New("cannot have a negative \"timeout\"")
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultTimeout
+	}
+
+	for _, tag := range c.Tags {
+		if !strings.Contains(tag, "=") {
+			return fmt.Errorf("invalid tag %q, tags must have the \"key=value\" format", tag)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynatraceexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters["dynatrace"].(*Config)
+	assert.Equal(t, factory.CreateDefaultConfig(), e0)
+
+	e1 := cfg.Exporters["dynatrace/2"].(*Config)
+	assert.Equal(t, &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "dynatrace/2",
+			TypeVal: "dynatrace",
+		},
+		Endpoint: "https://abc12345.live.dynatrace.com/api/v2/metrics/ingest",
+		APIToken: "mytoken",
+		Prefix:   "otel",
+		Tags:     []string{"environment=prod"},
+		Timeout:  10 * time.Second,
+	}, e1)
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "oneagent_defaults",
+			want: Config{Endpoint: DefaultOneAgentEndpoint, Timeout: defaultTimeout},
+		},
+		{
+			name: "api_token_trimmed",
+			cfg:  Config{Endpoint: "https://abc.live.dynatrace.com/api/v2/metrics/ingest", APIToken: " token "},
+			want: Config{Endpoint: "https://abc.live.dynatrace.com/api/v2/metrics/ingest", APIToken: "token", Timeout: defaultTimeout},
+		},
+		{
+			name:    "missing_api_token",
+			cfg:     Config{Endpoint: "https://abc.live.dynatrace.com/api/v2/metrics/ingest"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_endpoint",
+			cfg:     Config{Endpoint: "abc.live.dynatrace.com", APIToken: "token"},
+			wantErr: true,
+		},
+		{
+			name:    "negative_timeout",
+			cfg:     Config{Timeout: -time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_tag",
+			cfg:     Config{Tags: []string{"environment"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.sanitize()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tt.cfg)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynatraceexporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxLinesPerRequest is the maximum number of lines accepted by the
+	// metrics ingest API in one request.
+	maxLinesPerRequest = 1000
+
+	// deltaCacheTTL is how long the previous value of a cumulative time
+	// series is kept without new points.
+	deltaCacheTTL = time.Hour
+)
+
+type metricsExporter struct {
+	logger     *zap.Logger
+	httpClient *http.Client
+	endpoint   string
+	apiToken   string
+	serializer *serializer
+}
+
+func newMetricsExporter(cfg *Config, logger *zap.Logger) *metricsExporter {
+	return &metricsExporter{
+		logger:     logger,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		endpoint:   cfg.Endpoint,
+		apiToken:   cfg.APIToken,
+		serializer: newSerializer(cfg),
+	}
+}
+
+func (e *metricsExporter) pushMetrics(ctx context.Context, md pdata.Metrics) (int, error) {
+	_, numPoints := pdatautil.MetricAndDataPointCount(md)
+
+	now := time.Now()
+	var lines []string
+	dropped := 0
+	for _, data := range pdatautil.MetricsToMetricsData(md) {
+		ls, numDropped := e.serializer.serialize(data, now)
+		lines = append(lines, ls...)
+		dropped += numDropped
+	}
+	e.serializer.prevPts.prune(now.Add(-deltaCacheTTL))
+	if dropped > 0 {
+		e.logger.Debug("Dropped time series with an invalid metric key", zap.Int("count", dropped))
+	}
+
+	for start := 0; start < len(lines); start += maxLinesPerRequest {
+		end := start + maxLinesPerRequest
+		if end > len(lines) {
+			end = len(lines)
+		}
+		if err := e.send(ctx, lines[start:end]); err != nil {
+			return numPoints, err
+		}
+	}
+	return dropped, nil
+}
+
+// send posts lines to the metrics ingest API. The errors that retrying cannot
+// fix are permanent.
+func (e *metricsExporter) send(ctx context.Context, lines []string) error {
+	body := strings.Join(lines, "\n")
+	req, err := http.NewRequest("POST", e.endpoint, bytes.NewBufferString(body))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if e.apiToken != "" {
+		req.Header.Set("Authorization", "Api-Token "+e.apiToken)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("HTTP %d %q from %s", resp.StatusCode, http.StatusText(resp.StatusCode), e.endpoint)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		// The response of a bad request reports the invalid lines.
+		e.logger.Warn("Dynatrace rejected the metrics", zap.ByteString("response", respBody))
+		return consumererror.Permanent(err)
+	}
+	return err
+}
+
+func (e *metricsExporter) shutdown(context.Context) error {
+	e.httpClient.CloseIdleConnections()
+	return nil
+}
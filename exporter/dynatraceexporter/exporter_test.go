@@ -0,0 +1,135 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynatraceexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+type request struct {
+	authorization string
+	lines         []string
+}
+
+// fakeDynatrace records the requests it receives and replies with status.
+type fakeDynatrace struct {
+	*httptest.Server
+	status int
+
+	mu       sync.Mutex
+	requests []request
+}
+
+func newFakeDynatrace(t *testing.T) *fakeDynatrace {
+	f := &fakeDynatrace{status: http.StatusAccepted}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/plain; charset=utf-8", r.Header.Get("Content-Type"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		f.mu.Lock()
+		f.requests = append(f.requests, request{
+			authorization: r.Header.Get("Authorization"),
+			lines:         strings.Split(string(body), "\n"),
+		})
+		f.mu.Unlock()
+		w.WriteHeader(f.status)
+	}))
+	return f
+}
+
+func testMetrics(numTimeseries int) pdata.Metrics {
+	timeseries := make([]*metricspb.TimeSeries, numTimeseries)
+	for i := range timeseries {
+		timeseries[i] = &metricspb.TimeSeries{
+			Points: []*metricspb.Point{{
+				Timestamp: &timestamp.Timestamp{Seconds: 100},
+				Value:     &metricspb.Point_Int64Value{Int64Value: int64(i)},
+			}},
+		}
+	}
+	return pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{{
+		Metrics: []*metricspb.Metric{{
+			MetricDescriptor: &metricspb.MetricDescriptor{Name: "gauge", Type: metricspb.MetricDescriptor_GAUGE_INT64},
+			Timeseries:       timeseries,
+		}},
+	}})
+}
+
+func TestPushMetrics(t *testing.T) {
+	server := newFakeDynatrace(t)
+	defer server.Close()
+	cfg := &Config{Endpoint: server.URL, APIToken: "token"}
+	require.NoError(t, cfg.sanitize())
+	exp := newMetricsExporter(cfg, zap.NewNop())
+
+	dropped, err := exp.pushMetrics(context.Background(), testMetrics(1))
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+
+	require.Len(t, server.requests, 1)
+	assert.Equal(t, "Api-Token token", server.requests[0].authorization)
+	assert.Equal(t, []string{"gauge gauge,0 100000"}, server.requests[0].lines)
+}
+
+func TestPushMetricsBatches(t *testing.T) {
+	server := newFakeDynatrace(t)
+	defer server.Close()
+	cfg := &Config{Endpoint: server.URL, APIToken: "token"}
+	require.NoError(t, cfg.sanitize())
+	exp := newMetricsExporter(cfg, zap.NewNop())
+
+	_, err := exp.pushMetrics(context.Background(), testMetrics(maxLinesPerRequest+1))
+	require.NoError(t, err)
+
+	require.Len(t, server.requests, 2)
+	assert.Len(t, server.requests[0].lines, maxLinesPerRequest)
+	assert.Len(t, server.requests[1].lines, 1)
+}
+
+func TestPushMetricsError(t *testing.T) {
+	server := newFakeDynatrace(t)
+	defer server.Close()
+	cfg := &Config{Endpoint: server.URL, APIToken: "token"}
+	require.NoError(t, cfg.sanitize())
+	exp := newMetricsExporter(cfg, zap.NewNop())
+
+	server.status = http.StatusBadRequest
+	dropped, err := exp.pushMetrics(context.Background(), testMetrics(1))
+	assert.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, dropped)
+
+	server.status = http.StatusServiceUnavailable
+	_, err = exp.pushMetrics(context.Background(), testMetrics(1))
+	assert.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
@@ -0,0 +1,53 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynatraceexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.uber.org/zap"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateExporter(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, typeStr, string(factory.Type()))
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "https://abc12345.live.dynatrace.com/api/v2/metrics/ingest"
+	me, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	assert.Error(t, err, "the API token is required")
+	assert.Nil(t, me)
+
+	cfg.APIToken = "token"
+	me, err = factory.CreateMetricsExporter(context.Background(), params, cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, me, "failed to create metrics exporter")
+
+	te, err := factory.CreateTraceExporter(context.Background(), params, cfg)
+	assert.Error(t, err)
+	assert.Nil(t, te)
+}
@@ -0,0 +1,402 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynatraceexporter
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+// Limits of the Dynatrace metrics ingest v2 line protocol, see
+// https://www.dynatrace.com/support/help/how-to-use-dynatrace/metrics/metric-ingestion/metric-ingestion-protocol/.
+const (
+	maxMetricKeyLength      = 250
+	maxDimensionKeyLength   = 100
+	maxDimensionValueLength = 250
+)
+
+// dimension is a key=value pair of a metric line, already normalized.
+type dimension struct {
+	key   string
+	value string
+}
+
+// serializer converts OpenCensus metrics to the lines of the Dynatrace
+// metrics ingest protocol:
+//
+//	<metric key>,<dimension>=<value>,... <payload> <timestamp>
+//
+// Gauges are sent with the "gauge,<value>" payload, while cumulative sums, and
+// the count and sum of cumulative distributions and summaries, are converted
+// to the delta since their previous point and sent with the
+// "count,delta=<value>" payload. The timestamps are in milliseconds.
+type serializer struct {
+	prefix     string
+	dimensions []dimension
+	prevPts    *deltaCache
+}
+
+func newSerializer(cfg *Config) *serializer {
+	dims := make([]dimension, 0, len(cfg.Tags))
+	for _, tag := range cfg.Tags {
+		kv := strings.SplitN(tag, "=", 2)
+		if dim, ok := newDimension(kv[0], kv[1]); ok {
+			dims = append(dims, dim)
+		}
+	}
+	return &serializer{
+		prefix:     cfg.Prefix,
+		dimensions: dims,
+		prevPts:    newDeltaCache(),
+	}
+}
+
+// serialize returns the lines of the metrics, and the number of time series
+// that could not be serialized. The first point of a cumulative time series is
+// only used as the reference of the next delta.
+func (s *serializer) serialize(md consumerdata.MetricsData, now time.Time) ([]string, int) {
+	var resourceDims []dimension
+	if md.Resource != nil {
+		resourceDims = labelDimensions(md.Resource.Labels)
+	}
+
+	var lines []string
+	dropped := 0
+	for _, metric := range md.Metrics {
+		if metric == nil || metric.MetricDescriptor == nil {
+			continue
+		}
+		desc := metric.MetricDescriptor
+		key, ok := normalizeMetricKey(s.metricKey(desc.Name))
+		if !ok {
+			dropped += len(metric.Timeseries)
+			continue
+		}
+		for _, ts := range metric.Timeseries {
+			if ts == nil {
+				continue
+			}
+			dims := mergeDimensions(s.dimensions, resourceDims, timeseriesDimensions(desc.LabelKeys, ts.LabelValues))
+			ls := lineSerializer{
+				dims:      serializeDimensions(dims),
+				startTime: timestampToNanos(ts.StartTimestamp),
+				now:       now,
+			}
+			for _, point := range ts.Points {
+				if point == nil {
+					continue
+				}
+				ls.timestamp = timestampToMillis(point.Timestamp, now)
+				lines = s.serializePoint(lines, desc.Type, key, &ls, point)
+			}
+		}
+	}
+	return lines, dropped
+}
+
+func (s *serializer) serializePoint(lines []string, typ metricspb.MetricDescriptor_Type, key string, ls *lineSerializer, point *metricspb.Point) []string {
+	switch typ {
+	case metricspb.MetricDescriptor_GAUGE_INT64, metricspb.MetricDescriptor_GAUGE_DOUBLE:
+		if v, ok := numberValue(point); ok {
+			lines = append(lines, ls.gauge(key, ls.dims, v))
+		}
+	case metricspb.MetricDescriptor_CUMULATIVE_INT64, metricspb.MetricDescriptor_CUMULATIVE_DOUBLE:
+		if v, ok := numberValue(point); ok {
+			lines = s.appendDelta(lines, ls, key, v)
+		}
+	case metricspb.MetricDescriptor_GAUGE_DISTRIBUTION:
+		if d := point.GetDistributionValue(); d != nil {
+			lines = append(lines,
+				ls.gauge(key+".count", ls.dims, float64(d.Count)),
+				ls.gauge(key+".sum", ls.dims, d.Sum))
+		}
+	case metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION:
+		if d := point.GetDistributionValue(); d != nil {
+			lines = s.appendDelta(lines, ls, key+".count", float64(d.Count))
+			lines = s.appendDelta(lines, ls, key+".sum", d.Sum)
+		}
+	case metricspb.MetricDescriptor_SUMMARY:
+		sv := point.GetSummaryValue()
+		if sv == nil {
+			break
+		}
+		if sv.Count != nil {
+			lines = s.appendDelta(lines, ls, key+".count", float64(sv.Count.Value))
+		}
+		if sv.Sum != nil {
+			lines = s.appendDelta(lines, ls, key+".sum", sv.Sum.Value)
+		}
+		if sv.Snapshot != nil {
+			for _, pv := range sv.Snapshot.PercentileValues {
+				if pv == nil {
+					continue
+				}
+				quantile := strconv.FormatFloat(pv.Percentile/100, 'g', -1, 64)
+				dims := ls.dims + ",quantile=" + quantile
+				lines = append(lines, ls.gauge(key+".quantile", dims, pv.Value))
+			}
+		}
+	}
+	return lines
+}
+
+func (s *serializer) appendDelta(lines []string, ls *lineSerializer, key string, value float64) []string {
+	delta, ok := s.prevPts.delta(key+ls.dims, ls.startTime, value, ls.now)
+	if !ok {
+		return lines
+	}
+	return append(lines, key+ls.dims+" count,delta="+formatFloat(delta)+" "+ls.timestampStr())
+}
+
+func (s *serializer) metricKey(name string) string {
+	if s.prefix == "" {
+		return name
+	}
+	return s.prefix + "." + name
+}
+
+// lineSerializer holds what the lines of the points of a time series share.
+type lineSerializer struct {
+	// dims is the serialized dimensions, starting with a comma.
+	dims      string
+	startTime int64
+	timestamp int64
+	now       time.Time
+}
+
+func (ls *lineSerializer) gauge(key, dims string, value float64) string {
+	return key + dims + " gauge," + formatFloat(value) + " " + ls.timestampStr()
+}
+
+func (ls *lineSerializer) timestampStr() string {
+	return strconv.FormatInt(ls.timestamp, 10)
+}
+
+// normalizeMetricKey returns a metric key made of dot-separated sections
+// starting with a letter and containing only letters, digits, '-' and '_'.
+// The invalid characters are replaced with '_' and the leading characters that
+// are not letters are removed. It returns false if nothing is left of the key.
+func normalizeMetricKey(key string) (string, bool) {
+	sections := strings.Split(key, ".")
+	normalized := make([]string, 0, len(sections))
+	for _, section := range sections {
+		section = strings.TrimLeftFunc(section, func(r rune) bool {
+			return !isASCIILetter(r)
+		})
+		if section == "" {
+			continue
+		}
+		normalized = append(normalized, strings.Map(func(r rune) rune {
+			if isASCIIAlphanumeric(r) || r == '-' || r == '_' {
+				return r
+			}
+			return '_'
+		}, section))
+	}
+	if len(normalized) == 0 {
+		return "", false
+	}
+	return truncate(strings.Join(normalized, "."), maxMetricKeyLength), true
+}
+
+// normalizeDimensionKey returns a lower case dimension key starting with a
+// letter and containing only letters, digits, '-', '_', '.' and ':'. It returns
+// false if nothing is left of the key.
+func normalizeDimensionKey(key string) (string, bool) {
+	key = strings.TrimLeftFunc(strings.ToLower(key), func(r rune) bool {
+		return !isASCIILetter(r)
+	})
+	if key == "" {
+		return "", false
+	}
+	key = strings.Map(func(r rune) rune {
+		if isASCIIAlphanumeric(r) || r == '-' || r == '_' || r == '.' || r == ':' {
+			return r
+		}
+		return '_'
+	}, key)
+	return truncate(key, maxDimensionKeyLength), true
+}
+
+// escapeDimensionValue escapes the characters with a meaning in the line
+// protocol.
+func escapeDimensionValue(value string) string {
+	value = truncate(value, maxDimensionValueLength)
+	var sb strings.Builder
+	for _, r := range value {
+		switch r {
+		case '\\', ',', '=', ' ', '"':
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func newDimension(key, value string) (dimension, bool) {
+	k, ok := normalizeDimensionKey(key)
+	if !ok || value == "" {
+		return dimension{}, false
+	}
+	return dimension{key: k, value: escapeDimensionValue(value)}, true
+}
+
+func labelDimensions(labels map[string]string) []dimension {
+	dims := make([]dimension, 0, len(labels))
+	for k, v := range labels {
+		if dim, ok := newDimension(k, v); ok {
+			dims = append(dims, dim)
+		}
+	}
+	return dims
+}
+
+func timeseriesDimensions(keys []*metricspb.LabelKey, values []*metricspb.LabelValue) []dimension {
+	dims := make([]dimension, 0, len(keys))
+	for i, key := range keys {
+		if key == nil || i >= len(values) || values[i] == nil || !values[i].HasValue {
+			continue
+		}
+		if dim, ok := newDimension(key.Key, values[i].Value); ok {
+			dims = append(dims, dim)
+		}
+	}
+	return dims
+}
+
+// mergeDimensions merges the dimensions, the ones of the later lists taking
+// precedence over the earlier ones with the same key, and sorts them by key.
+func mergeDimensions(lists ...[]dimension) []dimension {
+	byKey := make(map[string]string)
+	for _, dims := range lists {
+		for _, dim := range dims {
+			byKey[dim.key] = dim.value
+		}
+	}
+	merged := make([]dimension, 0, len(byKey))
+	for k, v := range byKey {
+		merged = append(merged, dimension{key: k, value: v})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].key < merged[j].key
+	})
+	return merged
+}
+
+func serializeDimensions(dims []dimension) string {
+	var sb strings.Builder
+	for _, dim := range dims {
+		sb.WriteString("," + dim.key + "=" + dim.value)
+	}
+	return sb.String()
+}
+
+func isASCIILetter(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+func isASCIIAlphanumeric(r rune) bool {
+	return isASCIILetter(r) || r >= '0' && r <= '9'
+}
+
+// truncate returns the first n bytes of s at most, without splitting a rune.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func numberValue(point *metricspb.Point) (float64, bool) {
+	switch v := point.Value.(type) {
+	case *metricspb.Point_Int64Value:
+		return float64(v.Int64Value), true
+	case *metricspb.Point_DoubleValue:
+		return v.DoubleValue, true
+	}
+	return 0, false
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func timestampToNanos(ts *timestamp.Timestamp) int64 {
+	if ts == nil {
+		return 0
+	}
+	return ts.Seconds*int64(time.Second) + int64(ts.Nanos)
+}
+
+func timestampToMillis(ts *timestamp.Timestamp, now time.Time) int64 {
+	if ts == nil {
+		return now.UnixNano() / int64(time.Millisecond)
+	}
+	return timestampToNanos(ts) / int64(time.Millisecond)
+}
+
+// deltaCache holds the previous value of the cumulative time series.
+type deltaCache struct {
+	mu     sync.Mutex
+	points map[string]cachedPoint
+}
+
+type cachedPoint struct {
+	startTime int64
+	value     float64
+	seen      time.Time
+}
+
+func newDeltaCache() *deltaCache {
+	return &deltaCache{points: make(map[string]cachedPoint)}
+}
+
+// delta records value as the last value of the time series key, and returns
+// its difference with the previous one. It returns false when there is no
+// previous value, the time series having just started or been reset.
+func (c *deltaCache) delta(key string, startTime int64, value float64, now time.Time) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev, ok := c.points[key]
+	c.points[key] = cachedPoint{startTime: startTime, value: value, seen: now}
+	if !ok || prev.startTime != startTime || value < prev.value {
+		return 0, false
+	}
+	return value - prev.value, true
+}
+
+// prune removes the time series not seen since before.
+func (c *deltaCache) prune(before time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, p := range c.points {
+		if p.seen.Before(before) {
+			delete(c.points, key)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynatraceexporter
+
+import (
+	"testing"
+	"time"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+func TestSerialize(t *testing.T) {
+	s := newSerializer(&Config{
+		Prefix: "otel",
+		Tags:   []string{"environment=prod"},
+	})
+	now := time.Unix(200, 0)
+	start := &timestamp.Timestamp{Seconds: 50}
+
+	md := func(value int64) consumerdata.MetricsData {
+		return consumerdata.MetricsData{
+			Node: &commonpb.Node{},
+			Resource: &resourcepb.Resource{
+				Labels: map[string]string{"host.name": "host-1"},
+			},
+			Metrics: []*metricspb.Metric{
+				{
+					MetricDescriptor: &metricspb.MetricDescriptor{
+						Name:      "queue.size",
+						Type:      metricspb.MetricDescriptor_GAUGE_DOUBLE,
+						LabelKeys: []*metricspb.LabelKey{{Key: "Queue Name"}},
+					},
+					Timeseries: []*metricspb.TimeSeries{{
+						LabelValues: []*metricspb.LabelValue{{Value: "high priority", HasValue: true}},
+						Points: []*metricspb.Point{{
+							Timestamp: &timestamp.Timestamp{Seconds: 100, Nanos: 5e6},
+							Value:     &metricspb.Point_DoubleValue{DoubleValue: 1.5},
+						}},
+					}},
+				},
+				{
+					MetricDescriptor: &metricspb.MetricDescriptor{
+						Name: "requests",
+						Type: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+					},
+					Timeseries: []*metricspb.TimeSeries{{
+						StartTimestamp: start,
+						Points: []*metricspb.Point{{
+							Timestamp: &timestamp.Timestamp{Seconds: 100},
+							Value:     &metricspb.Point_Int64Value{Int64Value: value},
+						}},
+					}},
+				},
+				{
+					MetricDescriptor: &metricspb.MetricDescriptor{
+						Name: "latency",
+						Type: metricspb.MetricDescriptor_SUMMARY,
+					},
+					Timeseries: []*metricspb.TimeSeries{{
+						StartTimestamp: start,
+						Points: []*metricspb.Point{{
+							Timestamp: &timestamp.Timestamp{Seconds: 100},
+							Value: &metricspb.Point_SummaryValue{SummaryValue: &metricspb.SummaryValue{
+								Count: &wrappers.Int64Value{Value: value},
+								Sum:   &wrappers.DoubleValue{Value: float64(value) * 2},
+								Snapshot: &metricspb.SummaryValue_Snapshot{
+									PercentileValues: []*metricspb.SummaryValue_Snapshot_ValueAtPercentile{
+										{Percentile: 99, Value: 7},
+									},
+								},
+							}},
+						}},
+					}},
+				},
+				{
+					MetricDescriptor: &metricspb.MetricDescriptor{
+						Name: "...",
+						Type: metricspb.MetricDescriptor_GAUGE_INT64,
+					},
+					Timeseries: []*metricspb.TimeSeries{{}},
+				},
+			},
+		}
+	}
+
+	lines, dropped := s.serialize(md(10), now)
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, []string{
+		`otel.queue.size,environment=prod,host.name=host-1,queue_name=high\ priority gauge,1.5 100005`,
+		`otel.latency.quantile,environment=prod,host.name=host-1,quantile=0.99 gauge,7 100000`,
+	}, lines)
+
+	// The deltas of the cumulative time series are sent from their second
+	// point on.
+	lines, _ = s.serialize(md(15), now)
+	assert.Equal(t, []string{
+		`otel.queue.size,environment=prod,host.name=host-1,queue_name=high\ priority gauge,1.5 100005`,
+		`otel.requests,environment=prod,host.name=host-1 count,delta=5 100000`,
+		`otel.latency.count,environment=prod,host.name=host-1 count,delta=5 100000`,
+		`otel.latency.sum,environment=prod,host.name=host-1 count,delta=10 100000`,
+		`otel.latency.quantile,environment=prod,host.name=host-1,quantile=0.99 gauge,7 100000`,
+	}, lines)
+}
+
+func TestNormalizeMetricKey(t *testing.T) {
+	tests := []struct {
+		key    string
+		want   string
+		wantOk bool
+	}{
+		{key: "system.cpu.time", want: "system.cpu.time", wantOk: true},
+		{key: "http/server duration", want: "http_server_duration", wantOk: true},
+		{key: "1xx.2requests", want: "xx.requests", wantOk: true},
+		{key: "a..b", want: "a.b", wantOk: true},
+		{key: "12.34"},
+		{key: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := normalizeMetricKey(tt.key)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeDimensionKey(t *testing.T) {
+	tests := []struct {
+		key    string
+		want   string
+		wantOk bool
+	}{
+		{key: "host.name", want: "host.name", wantOk: true},
+		{key: "K8s Pod/Name", want: "k8s_pod_name", wantOk: true},
+		{key: "_dt:entity", want: "dt:entity", wantOk: true},
+		{key: "123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := normalizeDimensionKey(tt.key)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEscapeDimensionValue(t *testing.T) {
+	assert.Equal(t, `a\ b\,c\=d\\e\"f`, escapeDimensionValue(`a b,c=d\e"f`))
+	assert.Equal(t, maxDimensionValueLength, len(escapeDimensionValue(string(make([]byte, 300)))))
+}
@@ -28,6 +28,22 @@ type Config struct {
 	// meaning no sampling. If you want to send one event out of every 250
 	// times Send() is called, you would specify 250 here.
 	SampleRate uint `mapstructure:"sample_rate"`
+	// SampleRateAttribute is the name of the span attribute holding the rate
+	// at which the span was sampled upstream, e.g. by a sampling processor of
+	// the collector. When a span has it, it overrides SampleRate, so Honeycomb
+	// weights the events of the span accordingly.
+	SampleRateAttribute string `mapstructure:"sample_rate_attribute"`
+	// DatasetRoutes send the events of some services to other datasets than
+	// Dataset.
+	DatasetRoutes []DatasetRoute `mapstructure:"dataset_routes"`
 	// Debug enables more verbose logging from the Honeycomb SDK. It defaults to false.
 	Debug bool `mapstructure:"debug"`
 }
+
+// DatasetRoute sends the events of a service to a dataset.
+type DatasetRoute struct {
+	// Service is the name of the service.
+	Service string `mapstructure:"service"`
+	// Dataset is the Honeycomb dataset the events of the service are sent to.
+	Dataset string `mapstructure:"dataset"`
+}
@@ -45,10 +45,14 @@ func TestLoadConfig(t *testing.T) {
 
 	r1 := cfg.Exporters["honeycomb/customname"].(*Config)
 	assert.Equal(t, r1, &Config{
-		ExporterSettings: configmodels.ExporterSettings{TypeVal: configmodels.Type(typeStr), NameVal: "honeycomb/customname"},
-		APIKey:           "test-apikey",
-		Dataset:          "test-dataset",
-		APIURL:           "https://api.testhost.io",
-		SampleRate:       1,
+		ExporterSettings:    configmodels.ExporterSettings{TypeVal: configmodels.Type(typeStr), NameVal: "honeycomb/customname"},
+		APIKey:              "test-apikey",
+		Dataset:             "test-dataset",
+		APIURL:              "https://api.testhost.io",
+		SampleRate:          1,
+		SampleRateAttribute: "sampling.rate",
+		DatasetRoutes: []DatasetRoute{
+			{Service: "routed-service", Dataset: "routed-dataset"},
+		},
 	})
 }
@@ -35,9 +35,11 @@ const (
 
 // honeycombExporter is the object that sends events to honeycomb.
 type honeycombExporter struct {
-	builder *libhoney.Builder
-	onError func(error)
-	logger  *zap.Logger
+	builder             *libhoney.Builder
+	onError             func(error)
+	logger              *zap.Logger
+	datasets            map[string]string
+	sampleRateAttribute string
 }
 
 // event represents a honeycomb event.
@@ -96,12 +98,18 @@ func newHoneycombTraceExporter(cfg *Config, logger *zap.Logger) (component.Trace
 		return nil, err
 	}
 	builder := libhoney.NewBuilder()
+	datasets := make(map[string]string, len(cfg.DatasetRoutes))
+	for _, route := range cfg.DatasetRoutes {
+		datasets[route.Service] = route.Dataset
+	}
 	exporter := &honeycombExporter{
 		builder: builder,
 		logger:  logger,
 		onError: func(err error) {
 			logger.Warn(err.Error())
 		},
+		datasets:            datasets,
+		sampleRateAttribute: cfg.SampleRateAttribute,
 	}
 
 	return exporterhelper.NewTraceExporterOld(
@@ -132,7 +140,8 @@ func (e *honeycombExporter) pushTraceData(ctx context.Context, td consumerdata.T
 	}
 
 	for _, span := range td.Spans {
-		ev := e.builder.NewEvent()
+		newEvent := e.eventFactory(td, span)
+		ev := newEvent()
 		addTraceLevelFields(ev)
 
 		// Treat resource labels as underlays, with any same-keyed span attributes taking
@@ -174,8 +183,8 @@ func (e *honeycombExporter) pushTraceData(ctx context.Context, td consumerdata.T
 			HasRemoteParent: hasRemoteParent(span),
 		})
 
-		e.sendMessageEvents(td, span, traceLevelFields)
-		e.sendSpanLinks(span)
+		e.sendMessageEvents(td, span, traceLevelFields, newEvent)
+		e.sendSpanLinks(span, newEvent)
 
 		ev.AddField("status.code", getStatusCode(span.Status))
 		ev.AddField("status.message", getStatusMessage(span.Status))
@@ -194,7 +203,7 @@ func (e *honeycombExporter) pushTraceData(ctx context.Context, td consumerdata.T
 
 // sendSpanLinks gets the list of links associated with this span and sends them as
 // separate events to Honeycomb, with a span type "link".
-func (e *honeycombExporter) sendSpanLinks(span *tracepb.Span) {
+func (e *honeycombExporter) sendSpanLinks(span *tracepb.Span, newEvent func() *libhoney.Event) {
 	links := span.GetLinks()
 
 	if links == nil {
@@ -202,7 +211,7 @@ func (e *honeycombExporter) sendSpanLinks(span *tracepb.Span) {
 	}
 
 	for _, l := range links.GetLink() {
-		ev := e.builder.NewEvent()
+		ev := newEvent()
 		ev.Add(link{
 			TraceID:     getHoneycombTraceID(span.GetTraceId()),
 			ParentID:    getHoneycombSpanID(span.GetSpanId()),
@@ -223,7 +232,7 @@ func (e *honeycombExporter) sendSpanLinks(span *tracepb.Span) {
 
 // sendMessageEvents gets the list of timeevents from the span and sends them as
 // separate events to Honeycomb, with a span type "span_event".
-func (e *honeycombExporter) sendMessageEvents(td consumerdata.TraceData, span *tracepb.Span, traceFields map[string]interface{}) {
+func (e *honeycombExporter) sendMessageEvents(td consumerdata.TraceData, span *tracepb.Span, traceFields map[string]interface{}, newEvent func() *libhoney.Event) {
 	timeEvents := span.GetTimeEvents()
 	if timeEvents == nil {
 		return
@@ -240,7 +249,7 @@ func (e *honeycombExporter) sendMessageEvents(td consumerdata.TraceData, span *t
 		attrs := spanAttributesToMap(annotation.GetAttributes())
 
 		// treat trace level fields as underlays with same keyed span attributes taking precedence.
-		ev := e.builder.NewEvent()
+		ev := newEvent()
 		for k, v := range traceFields {
 			ev.AddField(k, v)
 		}
@@ -277,6 +286,24 @@ func (e *honeycombExporter) sendMessageEvents(td consumerdata.TraceData, span *t
 	}
 }
 
+// eventFactory returns the constructor of the events of a span. The events are
+// sent to the dataset routed to the service of the span, and carry the sample
+// rate of the span.
+func (e *honeycombExporter) eventFactory(td consumerdata.TraceData, span *tracepb.Span) func() *libhoney.Event {
+	dataset := e.datasets[getServiceName(td, span)]
+	sampleRate := getSampleRate(span, e.sampleRateAttribute)
+	return func() *libhoney.Event {
+		ev := e.builder.NewEvent()
+		if dataset != "" {
+			ev.Dataset = dataset
+		}
+		if sampleRate > 0 {
+			ev.SampleRate = sampleRate
+		}
+		return ev
+	}
+}
+
 // Shutdown takes care of any cleanup tasks that need to be carried out. In
 // this case, we close the honeycomb sdk which flushes any events still in the
 // queue and closes any open channels between queues.
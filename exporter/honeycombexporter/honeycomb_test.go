@@ -305,3 +305,73 @@ func TestEmptyNode(t *testing.T) {
 		t.Errorf("otel span: (-want +got):\n%s", diff)
 	}
 }
+
+func TestDatasetRoutesAndSampleRate(t *testing.T) {
+	type batch struct {
+		path   string
+		events []struct {
+			Data       map[string]interface{} `json:"data"`
+			SampleRate uint                   `json:"samplerate"`
+		}
+	}
+	var got []batch
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		uncompressed, err := zstd.NewReader(req.Body)
+		require.NoError(t, err)
+		defer req.Body.Close()
+		b, err := ioutil.ReadAll(uncompressed)
+		require.NoError(t, err)
+
+		bt := batch{path: req.URL.Path}
+		require.NoError(t, json.Unmarshal(b, &bt.events))
+		got = append(got, bt)
+		rw.Write([]byte(`OK`))
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		APIKey:              "test",
+		Dataset:             "default",
+		APIURL:              server.URL,
+		SampleRate:          1,
+		SampleRateAttribute: "sampling.rate",
+		DatasetRoutes: []DatasetRoute{
+			{Service: "routed_service", Dataset: "routed"},
+		},
+	}
+	exporter, err := (&Factory{}).CreateTraceExporter(zap.NewNop(), &cfg)
+	require.NoError(t, err)
+
+	td := consumerdata.TraceData{
+		Node: &commonpb.Node{ServiceInfo: &commonpb.ServiceInfo{Name: "routed_service"}},
+		Spans: []*tracepb.Span{
+			{
+				TraceId: []byte{0x01},
+				SpanId:  []byte{0x02},
+				Name:    &tracepb.TruncatableString{Value: "root"},
+				Attributes: &tracepb.Span_Attributes{
+					AttributeMap: map[string]*tracepb.AttributeValue{
+						"sampling.rate": {Value: &tracepb.AttributeValue_IntValue{IntValue: 10}},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, exporter.ConsumeTraceData(context.Background(), td))
+
+	td.Node.ServiceInfo.Name = "other_service"
+	td.Spans[0].Attributes = nil
+	require.NoError(t, exporter.ConsumeTraceData(context.Background(), td))
+	exporter.Shutdown(context.Background())
+
+	datasets := make(map[string]uint)
+	for _, bt := range got {
+		for _, ev := range bt.events {
+			datasets[bt.path] = ev.SampleRate
+		}
+	}
+	require.Equal(t, map[string]uint{
+		"/1/batch/routed":  10,
+		"/1/batch/default": 1,
+	}, datasets)
+}
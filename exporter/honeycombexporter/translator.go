@@ -15,6 +15,8 @@
 package honeycombexporter
 
 import (
+	"math"
+	"strconv"
 	"time"
 
 	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
@@ -22,6 +24,7 @@ import (
 	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/translator/conventions"
 	"google.golang.org/grpc/codes"
 )
 
@@ -155,3 +158,45 @@ func truncatableStringAsString(s *tracepb.TruncatableString) string {
 	}
 	return ""
 }
+
+// getServiceName returns the service of a span, from the service.name label of
+// its resource, or from the node.
+func getServiceName(td consumerdata.TraceData, span *tracepb.Span) string {
+	if name := span.GetResource().GetLabels()[conventions.AttributeServiceName]; name != "" {
+		return name
+	}
+	if name := td.Resource.GetLabels()[conventions.AttributeServiceName]; name != "" {
+		return name
+	}
+	return td.Node.GetServiceInfo().GetName()
+}
+
+// getSampleRate returns the sample rate of a span held by the attribute, or 0
+// if the span does not have a valid one.
+func getSampleRate(span *tracepb.Span, attribute string) uint {
+	if attribute == "" {
+		return 0
+	}
+	attr, ok := span.GetAttributes().GetAttributeMap()[attribute]
+	if !ok {
+		return 0
+	}
+
+	var rate float64
+	switch v := attr.GetValue().(type) {
+	case *tracepb.AttributeValue_IntValue:
+		rate = float64(v.IntValue)
+	case *tracepb.AttributeValue_DoubleValue:
+		rate = v.DoubleValue
+	case *tracepb.AttributeValue_StringValue:
+		parsed, err := strconv.ParseFloat(truncatableStringAsString(v.StringValue), 64)
+		if err != nil {
+			return 0
+		}
+		rate = parsed
+	}
+	if rate < 1 {
+		return 0
+	}
+	return uint(math.Round(rate))
+}
@@ -18,8 +18,12 @@ import (
 	"testing"
 	"time"
 
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
 	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
 )
 
 func TestSpanAttributesToMap(t *testing.T) {
@@ -100,3 +104,87 @@ func TestTimestampToTime(t *testing.T) {
 		t.Errorf("Expected %+v, Got %+v\n", t2, nowTime)
 	}
 }
+
+func TestGetSampleRate(t *testing.T) {
+	spanWithAttr := func(value *tracepb.AttributeValue) *tracepb.Span {
+		return &tracepb.Span{
+			Attributes: &tracepb.Span_Attributes{
+				AttributeMap: map[string]*tracepb.AttributeValue{"sampling.rate": value},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		span      *tracepb.Span
+		attribute string
+		want      uint
+	}{
+		{
+			name:      "int",
+			span:      spanWithAttr(&tracepb.AttributeValue{Value: &tracepb.AttributeValue_IntValue{IntValue: 10}}),
+			attribute: "sampling.rate",
+			want:      10,
+		},
+		{
+			name:      "double",
+			span:      spanWithAttr(&tracepb.AttributeValue{Value: &tracepb.AttributeValue_DoubleValue{DoubleValue: 4.6}}),
+			attribute: "sampling.rate",
+			want:      5,
+		},
+		{
+			name: "string",
+			span: spanWithAttr(&tracepb.AttributeValue{Value: &tracepb.AttributeValue_StringValue{
+				StringValue: &tracepb.TruncatableString{Value: "20"},
+			}}),
+			attribute: "sampling.rate",
+			want:      20,
+		},
+		{
+			name: "invalid string",
+			span: spanWithAttr(&tracepb.AttributeValue{Value: &tracepb.AttributeValue_StringValue{
+				StringValue: &tracepb.TruncatableString{Value: "all"},
+			}}),
+			attribute: "sampling.rate",
+			want:      0,
+		},
+		{
+			name:      "less than one",
+			span:      spanWithAttr(&tracepb.AttributeValue{Value: &tracepb.AttributeValue_DoubleValue{DoubleValue: 0.5}}),
+			attribute: "sampling.rate",
+			want:      0,
+		},
+		{
+			name:      "missing attribute",
+			span:      &tracepb.Span{},
+			attribute: "sampling.rate",
+			want:      0,
+		},
+		{
+			name:      "no attribute configured",
+			span:      spanWithAttr(&tracepb.AttributeValue{Value: &tracepb.AttributeValue_IntValue{IntValue: 10}}),
+			attribute: "",
+			want:      0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getSampleRate(tt.span, tt.attribute))
+		})
+	}
+}
+
+func TestGetServiceName(t *testing.T) {
+	td := consumerdata.TraceData{
+		Node: &commonpb.Node{ServiceInfo: &commonpb.ServiceInfo{Name: "node_service"}},
+	}
+	assert.Equal(t, "node_service", getServiceName(td, &tracepb.Span{}))
+
+	td.Resource = &resourcepb.Resource{Labels: map[string]string{"service.name": "resource_service"}}
+	assert.Equal(t, "resource_service", getServiceName(td, &tracepb.Span{}))
+
+	span := &tracepb.Span{Resource: &resourcepb.Resource{Labels: map[string]string{"service.name": "span_service"}}}
+	assert.Equal(t, "span_service", getServiceName(td, span))
+
+	assert.Equal(t, "", getServiceName(consumerdata.TraceData{}, &tracepb.Span{}))
+}
@@ -0,0 +1,130 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humioexporter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	defaultTimeout = 5 * time.Second
+
+	structuredPath   = "/api/v1/ingest/humio-structured"
+	unstructuredPath = "/api/v1/ingest/humio-unstructured"
+)
+
+// Config defines configuration for the Humio exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"`
+	configtls.TLSClientSetting    `mapstructure:",squash"`
+
+	// Endpoint is the base URL of the Humio cluster, e.g.
+	// https://cloud.humio.com. The ingest API paths are appended to it.
+	//
+	// This is required.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// IngestToken is the ingest token of the Humio repository, sent as a
+	// bearer token.
+	//
+	// This is required.
+	IngestToken string `mapstructure:"ingest_token"`
+
+	// Headers are added to the ingest requests.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Timeout is the timeout of the ingest requests. Defaults to 5 seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// DisableCompression disables the gzip compression of the ingest requests.
+	DisableCompression bool `mapstructure:"disable_compression"`
+
+	// Tags lists the resource attributes converted to Humio tags. Each
+	// distinct tag set is a separate Humio datasource, so only attributes with
+	// few values should be listed.
+	Tags []TagConfig `mapstructure:"tags"`
+
+	// Logs configures how the log records are ingested.
+	Logs LogsConfig `mapstructure:"logs"`
+}
+
+// TagConfig maps a resource attribute to a Humio tag.
+type TagConfig struct {
+	// Attribute is the name of the resource attribute.
+	Attribute string `mapstructure:"attribute"`
+
+	// Tag is the name of the tag. It defaults to the attribute name.
+	Tag string `mapstructure:"tag"`
+}
+
+// LogsConfig configures how the log records are ingested.
+type LogsConfig struct {
+	// Unstructured sends the log records to the unstructured ingest API as
+	// messages parsed by Humio, instead of the structured ingest API.
+	Unstructured bool `mapstructure:"unstructured"`
+
+	// Parser is the name of the Humio parser of the unstructured messages. It
+	// defaults to the parser assigned to the ingest token.
+	Parser string `mapstructure:"parser"`
+}
+
+// validate checks the configuration and fills in the default tag names.
+func (cfg *Config) validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" must be specified")
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid \"endpoint\": %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid \"endpoint\" %q, it must be an absolute URL", cfg.Endpoint)
+	}
+	if cfg.IngestToken == "" {
+		return errors.New("\"ingest_token\" must be specified")
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("cannot have a negative \"timeout\"")
+	}
+	if cfg.Logs.Parser != "" && !cfg.Logs.Unstructured {
+		return errors.New("\"logs.parser\" requires \"logs.unstructured\"")
+	}
+	for i := range cfg.Tags {
+		tc := &cfg.Tags[i]
+		if tc.Attribute == "" {
+			return errors.New("tag \"attribute\" must be specified")
+		}
+		if tc.Tag == "" {
+			tc.Tag = tc.Attribute
+		}
+	}
+	return nil
+}
+
+// logsURL returns the URL of the ingest API of the log records.
+func (cfg *Config) logsURL() string {
+	path := structuredPath
+	if cfg.Logs.Unstructured {
+		path = unstructuredPath
+	}
+	return strings.TrimSuffix(cfg.Endpoint, "/") + path
+}
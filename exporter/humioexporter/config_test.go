@@ -0,0 +1,129 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humioexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters["humio"]
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "https://cloud.humio.com"
+	defaultCfg.IngestToken = "00000000-0000-0000-0000-000000000000"
+	assert.Equal(t, defaultCfg, e0)
+
+	e1 := cfg.Exporters["humio/allsettings"]
+	assert.Equal(t, &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "humio/allsettings",
+			TypeVal: "humio",
+		},
+		Endpoint:           "https://humio.example.com:8080/",
+		IngestToken:        "00000000-0000-0000-0000-000000000000",
+		Headers:            map[string]string{"x-custom-header": "value"},
+		Timeout:            10 * time.Second,
+		DisableCompression: true,
+		Tags: []TagConfig{
+			{Attribute: "service.name", Tag: "service"},
+			{Attribute: "k8s.namespace.name"},
+		},
+		Logs: LogsConfig{
+			Unstructured: true,
+			Parser:       "accesslog",
+		},
+	}, e1)
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Endpoint = "https://cloud.humio.com/"
+		cfg.IngestToken = "token"
+		cfg.Tags = []TagConfig{{Attribute: "k8s.namespace.name"}}
+		return cfg
+	}
+
+	cfg := valid()
+	require.NoError(t, cfg.validate())
+	assert.Equal(t, "k8s.namespace.name", cfg.Tags[0].Tag)
+	assert.Equal(t, "https://cloud.humio.com/api/v1/ingest/humio-structured", cfg.logsURL())
+	cfg.Logs.Unstructured = true
+	assert.Equal(t, "https://cloud.humio.com/api/v1/ingest/humio-unstructured", cfg.logsURL())
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    `"endpoint" must be specified`,
+		},
+		{
+			name:   "relative endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "cloud.humio.com" },
+			err:    `invalid "endpoint" "cloud.humio.com", it must be an absolute URL`,
+		},
+		{
+			name:   "no ingest token",
+			modify: func(cfg *Config) { cfg.IngestToken = "" },
+			err:    `"ingest_token" must be specified`,
+		},
+		{
+			name:   "negative timeout",
+			modify: func(cfg *Config) { cfg.Timeout = -time.Second },
+			err:    `cannot have a negative "timeout"`,
+		},
+		{
+			name:   "parser of structured logs",
+			modify: func(cfg *Config) { cfg.Logs.Parser = "json" },
+			err:    `"logs.parser" requires "logs.unstructured"`,
+		},
+		{
+			name:   "no attribute",
+			modify: func(cfg *Config) { cfg.Tags = []TagConfig{{Tag: "namespace"}} },
+			err:    `tag "attribute" must be specified`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.validate(), tt.err)
+		})
+	}
+}
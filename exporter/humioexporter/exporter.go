@@ -0,0 +1,168 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humioexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+type humioExporter struct {
+	config *Config
+	client *http.Client
+	logger *zap.Logger
+}
+
+var _ component.LogsExporter = (*humioExporter)(nil)
+
+func newExporter(config *Config, logger *zap.Logger) (*humioExporter, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := config.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	return &humioExporter{
+		config: config,
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		logger: logger,
+	}, nil
+}
+
+func (e *humioExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *humioExporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// ConsumeLogs sends the logs to the structured or unstructured ingest API, in
+// a single request holding the events of all the tag sets.
+func (e *humioExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	ctx = obsreport.StartLogsExportOp(ctx, typeStr)
+
+	numDroppedLogs, err := e.pushLogData(ctx, ld)
+
+	obsreport.EndLogsExportOp(ctx, ld.LogRecordCount(), numDroppedLogs, err)
+	return err
+}
+
+// pushLogData returns the number of log records not sent, those with an empty
+// body sent unstructured or all of them if the request fails.
+func (e *humioExporter) pushLogData(ctx context.Context, ld pdata.Logs) (int, error) {
+	var (
+		payload    interface{}
+		numDropped int
+	)
+	if e.config.Logs.Unstructured {
+		var events []*unstructuredEvents
+		events, numDropped = logsToUnstructured(ld, e.config.Tags, e.config.Logs.Parser)
+		if numDropped > 0 {
+			e.logger.Debug("Dropped log records with an empty body", zap.Int("count", numDropped))
+		}
+		if len(events) == 0 {
+			return numDropped, nil
+		}
+		payload = events
+	} else {
+		events := logsToStructured(ld, e.config.Tags, time.Now())
+		if len(events) == 0 {
+			return 0, nil
+		}
+		payload = events
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return ld.LogRecordCount(), consumererror.Permanent(err)
+	}
+	if err := e.push(ctx, e.config.logsURL(), body); err != nil {
+		return ld.LogRecordCount(), err
+	}
+	return numDropped, nil
+}
+
+func (e *humioExporter) push(ctx context.Context, url string, body []byte) error {
+	contentEncoding := ""
+	if !e.config.DisableCompression {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(body); err != nil {
+			return consumererror.Permanent(err)
+		}
+		if err := gz.Close(); err != nil {
+			return consumererror.Permanent(err)
+		}
+		body = buf.Bytes()
+		contentEncoding = "gzip"
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req = req.WithContext(ctx)
+	for k, v := range e.config.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+	req.Header.Set("Authorization", "Bearer "+e.config.IngestToken)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return consumererror.Permanent(err)
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humioexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func testTagConfigs() []TagConfig {
+	return []TagConfig{{Attribute: "service.name", Tag: "service"}}
+}
+
+func testLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(2)
+
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().InitEmpty()
+	rl.Resource().Attributes().InsertString("service.name", "api")
+	rl.Resource().Attributes().InsertString("host.name", "host1")
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(2)
+	logs.At(0).SetTimestamp(1000)
+	logs.At(0).SetName("request")
+	logs.At(0).SetSeverityText("info")
+	logs.At(0).Body().SetStringVal("GET /")
+	logs.At(0).Attributes().InsertInt("status", 200)
+	logs.At(1).SetTimestamp(2000)
+	body := pdata.NewAttributeMap()
+	body.InsertString("msg", "hello")
+	body.InsertInt("code", 3)
+	logs.At(1).Body().SetMapVal(body)
+
+	// Without any of the configured attributes.
+	rl = ld.ResourceLogs().At(1)
+	rl.Resource().InitEmpty()
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs = rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(2)
+	logs.At(0).SetTimestamp(3000)
+	logs.At(0).Body().SetStringVal("untagged")
+	// Without a body.
+	logs.At(1).SetTimestamp(4000)
+	return ld
+}
+
+func TestLogsToStructured(t *testing.T) {
+	now := time.Unix(10, 0)
+	events := logsToStructured(testLogs(), testTagConfigs(), now)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, map[string]string{"service": "api"}, events[0].Tags)
+	assert.Equal(t, []*structuredEvent{
+		{
+			Timestamp:  "1970-01-01T00:00:00.000001Z",
+			RawString:  "GET /",
+			Attributes: map[string]interface{}{"name": "request", "severity_text": "info", "status": int64(200)},
+		},
+		{
+			Timestamp:  "1970-01-01T00:00:00.000002Z",
+			Attributes: map[string]interface{}{"msg": "hello", "code": int64(3)},
+		},
+	}, events[0].Events)
+
+	assert.Nil(t, events[1].Tags)
+	assert.Equal(t, []*structuredEvent{
+		{Timestamp: "1970-01-01T00:00:00.000003Z", RawString: "untagged"},
+		{Timestamp: "1970-01-01T00:00:00.000004Z"},
+	}, events[1].Events)
+}
+
+func TestLogsToUnstructured(t *testing.T) {
+	events, numDropped := logsToUnstructured(testLogs(), testTagConfigs(), "accesslog")
+	assert.Equal(t, 1, numDropped)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, &unstructuredEvents{
+		Fields:   map[string]string{"service": "api"},
+		Type:     "accesslog",
+		Messages: []string{"GET /", `{"code":3,"msg":"hello"}`},
+	}, events[0])
+	assert.Equal(t, &unstructuredEvents{
+		Type:     "accesslog",
+		Messages: []string{"untagged"},
+	}, events[1])
+}
+
+func TestConsumeLogs(t *testing.T) {
+	var (
+		path     string
+		received []byte
+	)
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "value", r.Header.Get("X-Custom-Header"))
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = gz
+		}
+		var err error
+		received, err = ioutil.ReadAll(body)
+		require.NoError(t, err)
+		path = r.URL.Path
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.IngestToken = "token"
+	cfg.Headers = map[string]string{"x-custom-header": "value"}
+	cfg.Tags = testTagConfigs()
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer exp.Shutdown(context.Background())
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testLogs()))
+	assert.Equal(t, structuredPath, path)
+	var structured []structuredEvents
+	require.NoError(t, json.Unmarshal(received, &structured))
+	require.Len(t, structured, 2)
+	assert.Equal(t, map[string]string{"service": "api"}, structured[0].Tags)
+	assert.Len(t, structured[0].Events, 2)
+
+	cfg.DisableCompression = true
+	cfg.Logs.Unstructured = true
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testLogs()))
+	assert.Equal(t, unstructuredPath, path)
+	var unstructured []unstructuredEvents
+	require.NoError(t, json.Unmarshal(received, &unstructured))
+	require.Len(t, unstructured, 2)
+	assert.Equal(t, []string{"untagged"}, unstructured[1].Messages)
+
+	status = http.StatusUnauthorized
+	err = exp.ConsumeLogs(context.Background(), testLogs())
+	assert.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	status = http.StatusServiceUnavailable
+	err = exp.ConsumeLogs(context.Background(), testLogs())
+	assert.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	numDropped, err := exp.pushLogData(context.Background(), testLogs())
+	assert.Error(t, err)
+	assert.Equal(t, testLogs().LogRecordCount(), numDropped)
+}
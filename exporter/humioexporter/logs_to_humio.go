@@ -0,0 +1,260 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humioexporter
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Attribute keys of the structured events, holding the fields of the log
+// records which are not attributes.
+const (
+	nameAttributeKey           = "name"
+	severityTextAttributeKey   = "severity_text"
+	severityNumberAttributeKey = "severity_number"
+	traceIDAttributeKey        = "trace_id"
+	spanIDAttributeKey         = "span_id"
+)
+
+// structuredEvents is an element of the body of a request to the structured
+// ingest API: the events sharing a tag set.
+type structuredEvents struct {
+	Tags   map[string]string  `json:"tags,omitempty"`
+	Events []*structuredEvent `json:"events"`
+}
+
+// structuredEvent is an event of the structured ingest API. It has a raw
+// string, the attributes, or both.
+type structuredEvent struct {
+	Timestamp  string                 `json:"timestamp"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	RawString  string                 `json:"rawstring,omitempty"`
+}
+
+// unstructuredEvents is an element of the body of a request to the
+// unstructured ingest API: the messages sharing a set of fields, parsed by
+// the parser of the given type.
+type unstructuredEvents struct {
+	Fields   map[string]string `json:"fields,omitempty"`
+	Type     string            `json:"type,omitempty"`
+	Messages []string          `json:"messages"`
+}
+
+// logsToStructured converts the log records to structured events grouped by
+// their tags. The events of records with a scalar body have it as raw string,
+// while the fields of map bodies are added to the attributes, along with the
+// record attributes, name, severity and trace context.
+func logsToStructured(ld pdata.Logs, tagConfigs []TagConfig, now time.Time) []*structuredEvents {
+	var groups []*structuredEvents
+	byTags := make(map[string]*structuredEvents)
+	forEachLogRecord(ld, tagConfigs, func(tags map[string]string, lr pdata.LogRecord) {
+		key := tagsKey(tags)
+		g, ok := byTags[key]
+		if !ok {
+			g = &structuredEvents{Tags: tags}
+			byTags[key] = g
+			groups = append(groups, g)
+		}
+		g.Events = append(g.Events, logRecordToStructured(lr, now))
+	})
+	return groups
+}
+
+func logRecordToStructured(lr pdata.LogRecord, now time.Time) *structuredEvent {
+	ts := time.Unix(0, int64(lr.Timestamp())).UTC()
+	if lr.Timestamp() == 0 {
+		ts = now.UTC()
+	}
+	event := &structuredEvent{
+		Timestamp:  ts.Format(time.RFC3339Nano),
+		Attributes: make(map[string]interface{}),
+	}
+
+	body := lr.Body()
+	if body.Type() == pdata.AttributeValueMAP {
+		for k, v := range attributeMapToInterface(body.MapVal()) {
+			event.Attributes[k] = v
+		}
+	} else {
+		event.RawString = attributeValueToString(body)
+	}
+
+	if lr.Name() != "" {
+		event.Attributes[nameAttributeKey] = lr.Name()
+	}
+	if lr.SeverityText() != "" {
+		event.Attributes[severityTextAttributeKey] = lr.SeverityText()
+	}
+	if lr.SeverityNumber() != pdata.SeverityNumberUNDEFINED {
+		event.Attributes[severityNumberAttributeKey] = int64(lr.SeverityNumber())
+	}
+	if traceID := lr.TraceID(); len(traceID) != 0 {
+		event.Attributes[traceIDAttributeKey] = traceID.String()
+	}
+	if spanID := lr.SpanID(); len(spanID) != 0 {
+		event.Attributes[spanIDAttributeKey] = spanID.String()
+	}
+	for k, v := range attributeMapToInterface(lr.Attributes()) {
+		event.Attributes[k] = v
+	}
+	if len(event.Attributes) == 0 {
+		event.Attributes = nil
+	}
+	return event
+}
+
+// logsToUnstructured converts the log records to unstructured messages
+// grouped by their tags, sent as fields. The message is the body of a record,
+// map bodies being encoded as JSON. It returns the number of records dropped
+// because they have an empty body.
+func logsToUnstructured(ld pdata.Logs, tagConfigs []TagConfig, parser string) ([]*unstructuredEvents, int) {
+	var groups []*unstructuredEvents
+	byTags := make(map[string]*unstructuredEvents)
+	numDropped := 0
+	forEachLogRecord(ld, tagConfigs, func(tags map[string]string, lr pdata.LogRecord) {
+		message := bodyToString(lr.Body())
+		if message == "" {
+			numDropped++
+			return
+		}
+		key := tagsKey(tags)
+		g, ok := byTags[key]
+		if !ok {
+			g = &unstructuredEvents{Fields: tags, Type: parser}
+			byTags[key] = g
+			groups = append(groups, g)
+		}
+		g.Messages = append(g.Messages, message)
+	})
+	return groups, numDropped
+}
+
+// forEachLogRecord calls fn with each log record and the tags of its resource.
+func forEachLogRecord(ld pdata.Logs, tagConfigs []TagConfig, fn func(map[string]string, pdata.LogRecord)) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		var tags map[string]string
+		if !rl.Resource().IsNil() {
+			tags = resourceTags(rl.Resource().Attributes(), tagConfigs)
+		}
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+				fn(tags, lr)
+			}
+		}
+	}
+}
+
+// resourceTags returns the tags of the configured resource attributes, or nil
+// if the resource has none of them.
+func resourceTags(attrs pdata.AttributeMap, tagConfigs []TagConfig) map[string]string {
+	var tags map[string]string
+	for _, tc := range tagConfigs {
+		v, ok := attrs.Get(tc.Attribute)
+		if !ok {
+			continue
+		}
+		if s := attributeValueToString(v); s != "" {
+			if tags == nil {
+				tags = make(map[string]string, len(tagConfigs))
+			}
+			tags[tc.Tag] = s
+		}
+	}
+	return tags
+}
+
+// tagsKey returns a key identifying a tag set.
+func tagsKey(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(tags[k]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+func bodyToString(body pdata.AttributeValue) string {
+	if body.Type() == pdata.AttributeValueMAP {
+		b, err := json.Marshal(attributeMapToInterface(body.MapVal()))
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	return attributeValueToString(body)
+}
+
+func attributeValueToString(v pdata.AttributeValue) string {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return strconv.FormatInt(v.IntVal(), 10)
+	case pdata.AttributeValueDOUBLE:
+		return strconv.FormatFloat(v.DoubleVal(), 'g', -1, 64)
+	case pdata.AttributeValueBOOL:
+		return strconv.FormatBool(v.BoolVal())
+	}
+	return ""
+}
+
+func attributeMapToInterface(attrs pdata.AttributeMap) map[string]interface{} {
+	m := make(map[string]interface{}, attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		switch v.Type() {
+		case pdata.AttributeValueSTRING:
+			m[k] = v.StringVal()
+		case pdata.AttributeValueINT:
+			m[k] = v.IntVal()
+		case pdata.AttributeValueDOUBLE:
+			m[k] = v.DoubleVal()
+		case pdata.AttributeValueBOOL:
+			m[k] = v.BoolVal()
+		case pdata.AttributeValueMAP:
+			m[k] = attributeMapToInterface(v.MapVal())
+		}
+	})
+	return m
+}
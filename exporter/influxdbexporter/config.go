@@ -0,0 +1,154 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	defaultEndpoint = "http://localhost:8086"
+	defaultTimeout  = 5 * time.Second
+)
+
+// Config defines configuration for the InfluxDB exporter. The data is written
+// to an InfluxDB v1 database if Database is set, or to an InfluxDB v2 bucket
+// if Bucket is set.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	configtls.TLSClientSetting    `mapstructure:",squash"`
+
+	// Endpoint is the base URL of the InfluxDB HTTP API. Defaults to
+	// http://localhost:8086.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// V1 defines the settings of an InfluxDB v1 destination.
+	V1 V1Config `mapstructure:"v1"`
+
+	// V2 defines the settings of an InfluxDB v2 destination.
+	V2 V2Config `mapstructure:"v2"`
+
+	// ResourceTags lists the resource attributes converted to tags. If it is
+	// empty, all the resource attributes are converted to tags.
+	ResourceTags []TagConfig `mapstructure:"resource_tags"`
+
+	// Timeout is the timeout of the write requests. Defaults to 5 seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// V1Config defines the InfluxDB v1 destination.
+type V1Config struct {
+	// Database is the database the data is written to.
+	Database string `mapstructure:"database"`
+
+	// RetentionPolicy is the retention policy of the written data. Defaults
+	// to the default retention policy of the database.
+	RetentionPolicy string `mapstructure:"retention_policy"`
+
+	// Username and Password authenticate the requests, if set.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// V2Config defines the InfluxDB v2 destination.
+type V2Config struct {
+	// Org is the organization of the bucket.
+	Org string `mapstructure:"org"`
+
+	// Bucket is the bucket the data is written to.
+	Bucket string `mapstructure:"bucket"`
+
+	// Token is the API token authenticating the requests.
+	Token string `mapstructure:"token"`
+}
+
+// TagConfig defines a resource attribute converted to a tag.
+type TagConfig struct {
+	// Attribute is the name of the attribute.
+	Attribute string `mapstructure:"attribute"`
+
+	// Tag is the key of the tag. It defaults to the attribute name.
+	Tag string `mapstructure:"tag"`
+}
+
+func (cfg *Config) validate() error {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defaultEndpoint
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return fmt.Errorf("invalid \"endpoint\": %w", err)
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("cannot have a negative \"timeout\"")
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	switch {
+	case cfg.V1.Database != "" && cfg.V2.Bucket != "":
+		return errors.New("only one of \"v1.database\" and \"v2.bucket\" can be specified")
+	case cfg.V2.Bucket != "":
+		if cfg.V2.Org == "" {
+			return errors.New("\"v2.org\" must be specified with \"v2.bucket\"")
+		}
+		if cfg.V2.Token == "" {
+			return errors.New("\"v2.token\" must be specified with \"v2.bucket\"")
+		}
+	case cfg.V1.Database == "":
+		return errors.New("either \"v1.database\" or \"v2.bucket\" must be specified")
+	}
+
+	for i := range cfg.ResourceTags {
+		tc := &cfg.ResourceTags[i]
+		if tc.Attribute == "" {
+			return errors.New("tag \"attribute\" must be specified")
+		}
+		if tc.Tag == "" {
+			tc.Tag = tc.Attribute
+		}
+	}
+	return nil
+}
+
+// writeURL returns the URL of the write API of the destination, the
+// timestamps of the points being in nanoseconds.
+func (cfg *Config) writeURL() (string, error) {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return "", err
+	}
+	q := url.Values{"precision": []string{"ns"}}
+	if cfg.V2.Bucket != "" {
+		u.Path = path.Join(u.Path, "/api/v2/write")
+		q.Set("org", cfg.V2.Org)
+		q.Set("bucket", cfg.V2.Bucket)
+	} else {
+		u.Path = path.Join(u.Path, "/write")
+		q.Set("db", cfg.V1.Database)
+		if cfg.V1.RetentionPolicy != "" {
+			q.Set("rp", cfg.V1.RetentionPolicy)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters["influxdb"]
+	assert.Equal(t, factory.CreateDefaultConfig(), e0)
+
+	e1 := cfg.Exporters["influxdb/v1"].(*Config)
+	assert.Equal(t, &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "influxdb/v1",
+			TypeVal: "influxdb",
+		},
+		Endpoint: "http://influxdb:8086",
+		V1: V1Config{
+			Database:        "otel",
+			RetentionPolicy: "autogen",
+			Username:        "user",
+			Password:        "pass",
+		},
+		Timeout: 10 * time.Second,
+	}, e1)
+	require.NoError(t, e1.validate())
+	writeURL, err := e1.writeURL()
+	require.NoError(t, err)
+	assert.Equal(t, "http://influxdb:8086/write?db=otel&precision=ns&rp=autogen", writeURL)
+
+	e2 := cfg.Exporters["influxdb/v2"].(*Config)
+	assert.Equal(t, &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "influxdb/v2",
+			TypeVal: "influxdb",
+		},
+		Endpoint: "https://influxdb.example.com",
+		V2: V2Config{
+			Org:    "myorg",
+			Bucket: "mybucket",
+			Token:  "mytoken",
+		},
+		ResourceTags: []TagConfig{
+			{Attribute: "host.name", Tag: "host"},
+			{Attribute: "service.name"},
+		},
+		Timeout: defaultTimeout,
+	}, e2)
+	require.NoError(t, e2.validate())
+	assert.Equal(t, "service.name", e2.ResourceTags[1].Tag)
+	writeURL, err = e2.writeURL()
+	require.NoError(t, err)
+	assert.Equal(t, "https://influxdb.example.com/api/v2/write?bucket=mybucket&org=myorg&precision=ns", writeURL)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name: "v1",
+			cfg:  Config{V1: V1Config{Database: "otel"}},
+		},
+		{
+			name: "v2",
+			cfg:  Config{V2: V2Config{Org: "org", Bucket: "bucket", Token: "token"}},
+		},
+		{
+			name:    "no_destination",
+			wantErr: true,
+		},
+		{
+			name: "both_destinations",
+			cfg: Config{
+				V1: V1Config{Database: "otel"},
+				V2: V2Config{Org: "org", Bucket: "bucket", Token: "token"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "v2_without_org",
+			cfg:     Config{V2: V2Config{Bucket: "bucket", Token: "token"}},
+			wantErr: true,
+		},
+		{
+			name:    "v2_without_token",
+			cfg:     Config{V2: V2Config{Org: "org", Bucket: "bucket"}},
+			wantErr: true,
+		},
+		{
+			name:    "negative_timeout",
+			cfg:     Config{V1: V1Config{Database: "otel"}, Timeout: -time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "tag_without_attribute",
+			cfg:     Config{V1: V1Config{Database: "otel"}, ResourceTags: []TagConfig{{Tag: "host"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, defaultEndpoint, tt.cfg.Endpoint)
+			assert.Equal(t, defaultTimeout, tt.cfg.Timeout)
+		})
+	}
+}
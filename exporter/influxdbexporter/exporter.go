@@ -0,0 +1,168 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+// influxExporter writes metrics, traces and logs to the write API of InfluxDB.
+type influxExporter struct {
+	config   *Config
+	writeURL string
+	client   *http.Client
+	tagger   *resourceTagger
+	logger   *zap.Logger
+}
+
+var _ component.LogsExporter = (*influxExporter)(nil)
+
+func newExporter(config *Config, logger *zap.Logger) (*influxExporter, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	writeURL, err := config.writeURL()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := config.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &influxExporter{
+		config:   config,
+		writeURL: writeURL,
+		client: &http.Client{
+			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		tagger: &resourceTagger{tagConfigs: config.ResourceTags},
+		logger: logger,
+	}, nil
+}
+
+func (e *influxExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *influxExporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+func (e *influxExporter) pushMetrics(ctx context.Context, md pdata.Metrics) (int, error) {
+	_, numPoints := pdatautil.MetricAndDataPointCount(md)
+
+	var sb strings.Builder
+	dropped := 0
+	now := time.Now()
+	for _, data := range pdatautil.MetricsToMetricsData(md) {
+		dropped += metricsToLineProtocol(&sb, data, e.tagger, now)
+	}
+	if dropped > 0 {
+		e.logger.Debug("Dropped time series without name or values", zap.Int("count", dropped))
+	}
+
+	if err := e.write(ctx, sb.String()); err != nil {
+		return numPoints, err
+	}
+	return dropped, nil
+}
+
+func (e *influxExporter) pushTraces(ctx context.Context, td pdata.Traces) (int, error) {
+	var sb strings.Builder
+	numSpans := tracesToLineProtocol(&sb, td, e.tagger)
+
+	if err := e.write(ctx, sb.String()); err != nil {
+		return numSpans, err
+	}
+	return 0, nil
+}
+
+func (e *influxExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	ctx = obsreport.StartLogsExportOp(ctx, typeStr)
+
+	numDroppedLogs, err := e.pushLogs(ctx, ld)
+
+	obsreport.EndLogsExportOp(ctx, ld.LogRecordCount(), numDroppedLogs, err)
+	return err
+}
+
+func (e *influxExporter) pushLogs(ctx context.Context, ld pdata.Logs) (int, error) {
+	var sb strings.Builder
+	dropped := logsToLineProtocol(&sb, ld, e.tagger, time.Now())
+	if dropped > 0 {
+		e.logger.Debug("Dropped log records without fields", zap.Int("count", dropped))
+	}
+
+	if err := e.write(ctx, sb.String()); err != nil {
+		return ld.LogRecordCount(), err
+	}
+	return dropped, nil
+}
+
+// write posts the lines to the write API. The errors that retrying cannot fix
+// are permanent.
+func (e *influxExporter) write(ctx context.Context, lines string) error {
+	if lines == "" {
+		return nil
+	}
+
+	req, err := http.NewRequest("POST", e.writeURL, bytes.NewBufferString(lines))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if e.config.V2.Token != "" {
+		req.Header.Set("Authorization", "Token "+e.config.V2.Token)
+	} else if e.config.V1.Username != "" {
+		req.SetBasicAuth(e.config.V1.Username, e.config.V1.Password)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return consumererror.Permanent(err)
+	}
+	return err
+}
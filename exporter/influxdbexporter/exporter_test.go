@@ -0,0 +1,238 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+type request struct {
+	url           string
+	authorization string
+	body          string
+}
+
+// fakeInfluxDB records the requests it receives and replies with status.
+type fakeInfluxDB struct {
+	*httptest.Server
+	status int
+
+	mu       sync.Mutex
+	requests []request
+}
+
+func newFakeInfluxDB(t *testing.T) *fakeInfluxDB {
+	f := &fakeInfluxDB{status: http.StatusNoContent}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		f.mu.Lock()
+		f.requests = append(f.requests, request{
+			url:           r.URL.String(),
+			authorization: r.Header.Get("Authorization"),
+			body:          string(body),
+		})
+		f.mu.Unlock()
+		w.WriteHeader(f.status)
+	}))
+	return f
+}
+
+func newTestExporter(t *testing.T, endpoint string) *influxExporter {
+	exp, err := newExporter(&Config{
+		Endpoint:     endpoint,
+		V2:           V2Config{Org: "org", Bucket: "bucket", Token: "token"},
+		ResourceTags: []TagConfig{{Attribute: "host.name", Tag: "host"}},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	return exp
+}
+
+func TestPushMetrics(t *testing.T) {
+	server := newFakeInfluxDB(t)
+	defer server.Close()
+	exp := newTestExporter(t, server.URL)
+
+	ts := &timestamp.Timestamp{Seconds: 1, Nanos: 500}
+	md := pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{{
+		Resource: &resourcepb.Resource{Labels: map[string]string{"host.name": "host1", "other": "x"}},
+		Metrics: []*metricspb.Metric{
+			{
+				MetricDescriptor: &metricspb.MetricDescriptor{
+					Name:      "requests",
+					Type:      metricspb.MetricDescriptor_CUMULATIVE_INT64,
+					LabelKeys: []*metricspb.LabelKey{{Key: "method"}, {Key: "code"}},
+				},
+				Timeseries: []*metricspb.TimeSeries{{
+					LabelValues: []*metricspb.LabelValue{{Value: "GET", HasValue: true}, {}},
+					Points:      []*metricspb.Point{{Timestamp: ts, Value: &metricspb.Point_Int64Value{Int64Value: 7}}},
+				}},
+			},
+			{
+				MetricDescriptor: &metricspb.MetricDescriptor{
+					Name: "latency",
+					Type: metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION,
+				},
+				Timeseries: []*metricspb.TimeSeries{{
+					Points: []*metricspb.Point{{Timestamp: ts, Value: &metricspb.Point_DistributionValue{
+						DistributionValue: &metricspb.DistributionValue{
+							Count: 6,
+							Sum:   12.5,
+							BucketOptions: &metricspb.DistributionValue_BucketOptions{
+								Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+									Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{Bounds: []float64{1, 5}},
+								},
+							},
+							Buckets: []*metricspb.DistributionValue_Bucket{{Count: 1}, {Count: 2}, {Count: 3}},
+						},
+					}}},
+				}},
+			},
+			{
+				MetricDescriptor: &metricspb.MetricDescriptor{
+					Name: "size",
+					Type: metricspb.MetricDescriptor_SUMMARY,
+				},
+				Timeseries: []*metricspb.TimeSeries{{
+					Points: []*metricspb.Point{{Timestamp: ts, Value: &metricspb.Point_SummaryValue{
+						SummaryValue: &metricspb.SummaryValue{
+							Count: &wrappers.Int64Value{Value: 2},
+							Sum:   &wrappers.DoubleValue{Value: 3},
+							Snapshot: &metricspb.SummaryValue_Snapshot{
+								PercentileValues: []*metricspb.SummaryValue_Snapshot_ValueAtPercentile{{Percentile: 50, Value: 1.5}},
+							},
+						},
+					}}},
+				}},
+			},
+			{
+				MetricDescriptor: &metricspb.MetricDescriptor{Type: metricspb.MetricDescriptor_GAUGE_INT64},
+				Timeseries:       []*metricspb.TimeSeries{{}},
+			},
+		},
+	}})
+
+	dropped, err := exp.pushMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+
+	require.Len(t, server.requests, 1)
+	assert.Equal(t, "/api/v2/write?bucket=bucket&org=org&precision=ns", server.requests[0].url)
+	assert.Equal(t, "Token token", server.requests[0].authorization)
+	assert.Equal(t, strings.Join([]string{
+		"requests,host=host1,method=GET value=7i 1000000500",
+		"latency,host=host1 count=6i,sum=12.5,1=1i,5=3i,+Inf=6i 1000000500",
+		"size,host=host1 count=2i,sum=3,0.5=1.5 1000000500",
+		"",
+	}, "\n"), server.requests[0].body)
+}
+
+func TestPushTraces(t *testing.T) {
+	server := newFakeInfluxDB(t)
+	defer server.Close()
+	exp := newTestExporter(t, server.URL)
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().InitEmpty()
+	rs.Resource().Attributes().InsertString("host.name", "host1")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(1)
+	span := spans.At(0)
+	span.SetName("GET /users")
+	span.SetKind(pdata.SpanKindSERVER)
+	span.SetTraceID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	span.SetSpanID([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetStartTime(1000)
+	span.SetEndTime(1500)
+	span.Status().InitEmpty()
+	span.Attributes().InsertInt("http.status_code", 200)
+
+	dropped, err := exp.pushTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+
+	require.Len(t, server.requests, 1)
+	assert.Equal(t,
+		`spans,host=host1,span.kind=`+pdata.SpanKindSERVER.String()+`,span.name=GET\ /users `+
+			`trace_id="01020304050607080807060504030201",span_id="0102030405060708",duration_nano=500i,status_code=0i,http.status_code=200i 1000`+"\n",
+		server.requests[0].body)
+
+	server.status = http.StatusBadRequest
+	dropped, err = exp.pushTraces(context.Background(), td)
+	assert.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, dropped)
+}
+
+func TestConsumeLogs(t *testing.T) {
+	server := newFakeInfluxDB(t)
+	defer server.Close()
+	exp := newTestExporter(t, server.URL)
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().InitEmpty()
+	rl.Resource().Attributes().InsertString("host.name", "host1")
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(2)
+	logs.At(0).SetTimestamp(1000)
+	logs.At(0).Body().SetStringVal("user created")
+	logs.At(0).SetSeverityText("INFO")
+	logs.At(0).Attributes().InsertString("user", "alice")
+	// Without any field.
+	logs.At(1).SetTimestamp(2000)
+
+	dropped, err := exp.pushLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+
+	require.Len(t, server.requests, 1)
+	assert.Equal(t,
+		`logs,host=host1 body="user created",severity_text="INFO",user="alice" 1000`+"\n",
+		server.requests[0].body)
+
+	server.status = http.StatusServiceUnavailable
+	err = exp.ConsumeLogs(context.Background(), ld)
+	assert.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	dropped, err = exp.pushLogs(context.Background(), ld)
+	assert.Error(t, err)
+	assert.Equal(t, 2, dropped)
+}
@@ -0,0 +1,224 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// point is an InfluxDB point, written in the line protocol as:
+//
+//	<measurement>[,<tag key>=<tag value>...] <field key>=<field value>[,...] <timestamp>
+//
+// See https://docs.influxdata.com/influxdb/v1.8/write_protocols/line_protocol_reference/.
+type point struct {
+	measurement string
+	tags        map[string]string
+	fields      []field
+	// timestamp is in Unix nanoseconds.
+	timestamp int64
+}
+
+// field is a field of a point. Its value is an int64, a float64, a bool or a
+// string.
+type field struct {
+	key   string
+	value interface{}
+}
+
+func newPoint(measurement string, tags map[string]string, timestamp int64) *point {
+	return &point{measurement: measurement, tags: tags, timestamp: timestamp}
+}
+
+// addField adds a field to the point, unless it already has a field with the
+// same key or the value cannot be written, e.g. a NaN float.
+func (p *point) addField(key string, value interface{}) {
+	if key == "" {
+		return
+	}
+	if f, ok := value.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		return
+	}
+	for _, f := range p.fields {
+		if f.key == key {
+			return
+		}
+	}
+	p.fields = append(p.fields, field{key: key, value: value})
+}
+
+// addAttributeFields adds the attributes as fields.
+func (p *point) addAttributeFields(attrs pdata.AttributeMap) {
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		if value, ok := attributeValue(v); ok {
+			p.addField(k, value)
+		}
+	})
+}
+
+// writeTo writes the point as a line to sb. Points without fields are not
+// valid, so they are skipped and false is returned.
+func (p *point) writeTo(sb *strings.Builder) bool {
+	if len(p.fields) == 0 {
+		return false
+	}
+
+	sb.WriteString(measurementEscaper.Replace(p.measurement))
+
+	keys := make([]string, 0, len(p.tags))
+	for k, v := range p.tags {
+		// Tags with an empty value are not valid.
+		if k != "" && v != "" {
+			keys = append(keys, k)
+		}
+	}
+	// InfluxDB recommends sorting the tags by key for performance.
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteByte(',')
+		sb.WriteString(tagEscaper.Replace(k))
+		sb.WriteByte('=')
+		sb.WriteString(tagEscaper.Replace(p.tags[k]))
+	}
+
+	for i, f := range p.fields {
+		if i == 0 {
+			sb.WriteByte(' ')
+		} else {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(tagEscaper.Replace(f.key))
+		sb.WriteByte('=')
+		writeFieldValue(sb, f.value)
+	}
+
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.FormatInt(p.timestamp, 10))
+	sb.WriteByte('\n')
+	return true
+}
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	tagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	stringEscaper      = strings.NewReplacer(`"`, `\"`, `\`, `\\`)
+)
+
+func writeFieldValue(sb *strings.Builder, value interface{}) {
+	switch v := value.(type) {
+	case int64:
+		sb.WriteString(strconv.FormatInt(v, 10))
+		sb.WriteByte('i')
+	case float64:
+		sb.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	case bool:
+		sb.WriteString(strconv.FormatBool(v))
+	case string:
+		sb.WriteByte('"')
+		sb.WriteString(stringEscaper.Replace(v))
+		sb.WriteByte('"')
+	}
+}
+
+// attributeValue returns the value of a field from an attribute. Map
+// attributes are not supported.
+func attributeValue(v pdata.AttributeValue) (interface{}, bool) {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal(), true
+	case pdata.AttributeValueINT:
+		return v.IntVal(), true
+	case pdata.AttributeValueDOUBLE:
+		return v.DoubleVal(), true
+	case pdata.AttributeValueBOOL:
+		return v.BoolVal(), true
+	}
+	return nil, false
+}
+
+// attributeString returns the string form of an attribute, used as a tag
+// value.
+func attributeString(v pdata.AttributeValue) string {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return strconv.FormatInt(v.IntVal(), 10)
+	case pdata.AttributeValueDOUBLE:
+		return strconv.FormatFloat(v.DoubleVal(), 'g', -1, 64)
+	case pdata.AttributeValueBOOL:
+		return strconv.FormatBool(v.BoolVal())
+	}
+	return ""
+}
+
+// resourceTagger converts resource attributes to tags.
+type resourceTagger struct {
+	// tagConfigs lists the attributes converted to tags, all of them if it is
+	// empty.
+	tagConfigs []TagConfig
+}
+
+// tags returns the tags of a resource, in a new map the caller can add tags to.
+func (rt *resourceTagger) tags(resource pdata.Resource) map[string]string {
+	tags := make(map[string]string)
+	if resource.IsNil() {
+		return tags
+	}
+	attrs := resource.Attributes()
+	if len(rt.tagConfigs) == 0 {
+		attrs.ForEach(func(k string, v pdata.AttributeValue) {
+			tags[k] = attributeString(v)
+		})
+		return tags
+	}
+	for _, tc := range rt.tagConfigs {
+		if v, ok := attrs.Get(tc.Attribute); ok {
+			tags[tc.Tag] = attributeString(v)
+		}
+	}
+	return tags
+}
+
+// labelsTags returns the tags of resource labels, in a new map the caller can
+// add tags to.
+func (rt *resourceTagger) labelsTags(labels map[string]string) map[string]string {
+	tags := make(map[string]string)
+	if len(rt.tagConfigs) == 0 {
+		for k, v := range labels {
+			tags[k] = v
+		}
+		return tags
+	}
+	for _, tc := range rt.tagConfigs {
+		if v, ok := labels[tc.Attribute]; ok {
+			tags[tc.Tag] = v
+		}
+	}
+	return tags
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	c := make(map[string]string, len(tags))
+	for k, v := range tags {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,70 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestPointWriteTo(t *testing.T) {
+	p := newPoint("http requests,total", map[string]string{
+		"service": "api",
+		"path":    "/users, all",
+		"empty":   "",
+		"a=b":     "c",
+	}, 1000)
+	p.addField("value", int64(3))
+	p.addField("value", int64(4))
+	p.addField("rate", 1.5)
+	p.addField("nan", math.NaN())
+	p.addField("ok", true)
+	p.addField("message", `say "hi" \o/`)
+
+	var sb strings.Builder
+	assert.True(t, p.writeTo(&sb))
+	assert.Equal(t,
+		`http\ requests\,total,a\=b=c,path=/users\,\ all,service=api value=3i,rate=1.5,ok=true,message="say \"hi\" \\o/" 1000`+"\n",
+		sb.String())
+}
+
+func TestPointWithoutFields(t *testing.T) {
+	p := newPoint("measurement", nil, 1000)
+	p.addField("nan", math.Inf(1))
+
+	var sb strings.Builder
+	assert.False(t, p.writeTo(&sb))
+	assert.Empty(t, sb.String())
+}
+
+func TestResourceTagger(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString("host.name", "host1")
+	resource.Attributes().InsertInt("pid", 42)
+
+	all := &resourceTagger{}
+	assert.Equal(t, map[string]string{"host.name": "host1", "pid": "42"}, all.tags(resource))
+	assert.Equal(t, map[string]string{"host.name": "host1"}, all.labelsTags(map[string]string{"host.name": "host1"}))
+
+	configured := &resourceTagger{tagConfigs: []TagConfig{{Attribute: "host.name", Tag: "host"}}}
+	assert.Equal(t, map[string]string{"host": "host1"}, configured.tags(resource))
+	assert.Equal(t, map[string]string{"host": "host1"}, configured.labelsTags(map[string]string{"host.name": "host1", "pid": "42"}))
+	assert.Equal(t, map[string]string{}, configured.tags(pdata.NewResource()))
+}
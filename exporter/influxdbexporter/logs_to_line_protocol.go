@@ -0,0 +1,118 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Measurement and field keys of the log points.
+const (
+	logsMeasurement = "logs"
+
+	bodyFieldKey           = "body"
+	nameFieldKey           = "name"
+	severityTextFieldKey   = "severity_text"
+	severityNumberFieldKey = "severity_number"
+)
+
+// logsToLineProtocol writes a point per log record to sb, in the "logs"
+// measurement. The tags are the resource tags, and the fields are the body,
+// name, severity and trace context of the record, followed by its attributes.
+// It returns the number of records dropped because they have no field.
+func logsToLineProtocol(sb *strings.Builder, ld pdata.Logs, rt *resourceTagger, now time.Time) int {
+	numDropped := 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		resourceTags := rt.tags(rl.Resource())
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+
+				timestamp := int64(lr.Timestamp())
+				if timestamp == 0 {
+					timestamp = now.UnixNano()
+				}
+				p := newPoint(logsMeasurement, resourceTags, timestamp)
+				if body := bodyString(lr.Body()); body != "" {
+					p.addField(bodyFieldKey, body)
+				}
+				if lr.Name() != "" {
+					p.addField(nameFieldKey, lr.Name())
+				}
+				if lr.SeverityText() != "" {
+					p.addField(severityTextFieldKey, lr.SeverityText())
+				}
+				if lr.SeverityNumber() != pdata.SeverityNumberUNDEFINED {
+					p.addField(severityNumberFieldKey, int64(lr.SeverityNumber()))
+				}
+				if traceID := lr.TraceID(); len(traceID) != 0 {
+					p.addField(traceIDFieldKey, traceID.String())
+				}
+				if spanID := lr.SpanID(); len(spanID) != 0 {
+					p.addField(spanIDFieldKey, spanID.String())
+				}
+				p.addAttributeFields(lr.Attributes())
+				if !p.writeTo(sb) {
+					numDropped++
+				}
+			}
+		}
+	}
+	return numDropped
+}
+
+// bodyString returns the body of a log record as a string, maps being
+// converted to JSON.
+func bodyString(body pdata.AttributeValue) string {
+	if body.Type() == pdata.AttributeValueMAP {
+		b, err := json.Marshal(attributeMapToInterface(body.MapVal()))
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	return attributeString(body)
+}
+
+func attributeMapToInterface(attrs pdata.AttributeMap) map[string]interface{} {
+	m := make(map[string]interface{}, attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		if v.Type() == pdata.AttributeValueMAP {
+			m[k] = attributeMapToInterface(v.MapVal())
+		} else if value, ok := attributeValue(v); ok {
+			m[k] = value
+		}
+	})
+	return m
+}
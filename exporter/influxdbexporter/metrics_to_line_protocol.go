@@ -0,0 +1,123 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+// Field keys of the metric points.
+const (
+	valueFieldKey = "value"
+	countFieldKey = "count"
+	sumFieldKey   = "sum"
+	infFieldKey   = "+Inf"
+)
+
+// metricsToLineProtocol writes the points of the metrics to sb, the
+// measurement being the metric name and the tags the resource and metric
+// labels. It returns the number of time series that could not be converted.
+//
+// Numbers are written to the "value" field. Distributions and summaries are
+// written as one point with the "count" and "sum" fields, plus a field per
+// bucket, keyed by its upper bound and holding the cumulative count of the
+// bucket, or per quantile, keyed by the quantile.
+func metricsToLineProtocol(sb *strings.Builder, md consumerdata.MetricsData, rt *resourceTagger, now time.Time) int {
+	resourceTags := rt.labelsTags(md.Resource.GetLabels())
+
+	dropped := 0
+	for _, metric := range md.Metrics {
+		if metric == nil || metric.MetricDescriptor == nil {
+			continue
+		}
+		desc := metric.MetricDescriptor
+		if desc.Name == "" {
+			dropped += len(metric.Timeseries)
+			continue
+		}
+		for _, ts := range metric.Timeseries {
+			if ts == nil {
+				continue
+			}
+			tags := copyTags(resourceTags)
+			for i, key := range desc.LabelKeys {
+				if key == nil || i >= len(ts.LabelValues) || ts.LabelValues[i] == nil || !ts.LabelValues[i].HasValue {
+					continue
+				}
+				tags[key.Key] = ts.LabelValues[i].Value
+			}
+
+			written := false
+			for _, pt := range ts.Points {
+				if pt == nil {
+					continue
+				}
+				timestamp := now.UnixNano()
+				if pt.Timestamp != nil {
+					timestamp = pt.Timestamp.Seconds*int64(time.Second) + int64(pt.Timestamp.Nanos)
+				}
+				p := newPoint(desc.Name, tags, timestamp)
+				addPointFields(p, pt)
+				written = p.writeTo(sb) || written
+			}
+			if !written {
+				dropped++
+			}
+		}
+	}
+	return dropped
+}
+
+func addPointFields(p *point, pt *metricspb.Point) {
+	switch v := pt.Value.(type) {
+	case *metricspb.Point_Int64Value:
+		p.addField(valueFieldKey, v.Int64Value)
+	case *metricspb.Point_DoubleValue:
+		p.addField(valueFieldKey, v.DoubleValue)
+	case *metricspb.Point_DistributionValue:
+		d := v.DistributionValue
+		p.addField(countFieldKey, d.Count)
+		p.addField(sumFieldKey, d.Sum)
+		bounds := d.GetBucketOptions().GetExplicit().GetBounds()
+		if len(d.Buckets) != len(bounds)+1 {
+			break
+		}
+		var cumulative int64
+		for i, bound := range bounds {
+			cumulative += d.Buckets[i].GetCount()
+			p.addField(strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		p.addField(infFieldKey, d.Count)
+	case *metricspb.Point_SummaryValue:
+		s := v.SummaryValue
+		if s.Count != nil {
+			p.addField(countFieldKey, s.Count.Value)
+		}
+		if s.Sum != nil {
+			p.addField(sumFieldKey, s.Sum.Value)
+		}
+		for _, pv := range s.GetSnapshot().GetPercentileValues() {
+			if pv == nil {
+				continue
+			}
+			p.addField(strconv.FormatFloat(pv.Percentile/100, 'g', -1, 64), pv.Value)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Measurement, tag and field keys of the span points.
+const (
+	spansMeasurement = "spans"
+
+	spanNameTagKey = "span.name"
+	spanKindTagKey = "span.kind"
+
+	traceIDFieldKey       = "trace_id"
+	spanIDFieldKey        = "span_id"
+	parentSpanIDFieldKey  = "parent_span_id"
+	durationFieldKey      = "duration_nano"
+	statusCodeFieldKey    = "status_code"
+	statusMessageFieldKey = "status_message"
+)
+
+// tracesToLineProtocol writes a point per span to sb, in the "spans"
+// measurement and at the start time of the span. The tags are the resource
+// tags, the span name and kind, and the fields are the IDs, duration and status
+// of the span, followed by its attributes. It returns the number of spans.
+func tracesToLineProtocol(sb *strings.Builder, td pdata.Traces, rt *resourceTagger) int {
+	numSpans := 0
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		resourceTags := rt.tags(rs.Resource())
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				numSpans++
+
+				tags := copyTags(resourceTags)
+				tags[spanNameTagKey] = span.Name()
+				tags[spanKindTagKey] = span.Kind().String()
+
+				p := newPoint(spansMeasurement, tags, int64(span.StartTime()))
+				p.addField(traceIDFieldKey, span.TraceID().String())
+				p.addField(spanIDFieldKey, span.SpanID().String())
+				if parentSpanID := span.ParentSpanID(); len(parentSpanID) != 0 {
+					p.addField(parentSpanIDFieldKey, parentSpanID.String())
+				}
+				p.addField(durationFieldKey, int64(span.EndTime())-int64(span.StartTime()))
+				if status := span.Status(); !status.IsNil() {
+					p.addField(statusCodeFieldKey, int64(status.Code()))
+					if status.Message() != "" {
+						p.addField(statusMessageFieldKey, status.Message())
+					}
+				}
+				p.addAttributeFields(span.Attributes())
+				p.writeTo(sb)
+			}
+		}
+	}
+	return numSpans
+}
@@ -15,8 +15,7 @@
 package jaegerthrifthttpexporter
 
 import (
-	"time"
-
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 )
 
@@ -24,15 +23,18 @@ import (
 type Config struct {
 	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 
-	// URL is the URL to send the Jaeger trace data to (e.g.:
-	// http://some.url:14268/api/traces).
-	URL string `mapstructure:"url"`
+	// HTTPClientSettings configures the endpoint (e.g.:
+	// http://some.url:14268/api/traces), timeout, headers and TLS settings
+	// used to send the Jaeger trace data.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
 
-	// Timeout is the maximum timeout for HTTP request sending trace data. The
-	// default value is 5 seconds.
-	Timeout time.Duration `mapstructure:"timeout"`
+	// URL is deprecated, use Endpoint instead. It is only used when Endpoint
+	// is not set.
+	URL string `mapstructure:"url"`
 
-	// Headers are a set of headers to be added to the HTTP request sending
-	// trace data.
-	Headers map[string]string `mapstructure:"headers"`
+	// MaxPayloadSize is the maximum size in bytes of the serialized thrift
+	// batch sent in a single HTTP request. Larger batches are split into
+	// smaller ones. The default value of 0 means no limit; batches are then
+	// only split when the server rejects them as too large.
+	MaxPayloadSize int `mapstructure:"max_payload_size"`
 }
@@ -22,8 +22,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.uber.org/zap"
 )
 
@@ -40,9 +42,9 @@ func TestLoadConfig(t *testing.T) {
 
 	e0 := cfg.Exporters["jaeger_thrift"]
 
-	// URL doesn't have a default value so set it directly.
+	// Endpoint doesn't have a default value so set it directly.
 	defaultCfg := factory.CreateDefaultConfig().(*Config)
-	defaultCfg.URL = "http://some.location:14268/api/traces"
+	defaultCfg.Endpoint = "http://some.location:14268/api/traces"
 	assert.Equal(t, defaultCfg, e0)
 
 	expectedName := "jaeger_thrift/2"
@@ -53,16 +55,26 @@ func TestLoadConfig(t *testing.T) {
 			TypeVal: configmodels.Type(typeStr),
 			NameVal: expectedName,
 		},
-		URL: "http://some.other.location/api/traces",
-		Headers: map[string]string{
-			"added-entry": "added value",
-			"dot.test":    "test",
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: "https://some.other.location/api/traces",
+			Headers: map[string]string{
+				"added-entry": "added value",
+				"dot.test":    "test",
+			},
+			Timeout: 2 * time.Second,
+			TLSSetting: configtls.TLSClientSetting{
+				ServerName: "some.other.location",
+			},
 		},
-		Timeout: 2 * time.Second,
+		MaxPayloadSize: 1048576,
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
 	te, err := factory.CreateTraceExporter(zap.NewNop(), e1)
 	require.NoError(t, err)
 	require.NotNil(t, te)
+
+	e2 := cfg.Exporters["jaeger_thrift/deprecated_url"].(*Config)
+	assert.Equal(t, "http://some.location:14268/api/traces", e2.URL)
+	assert.Empty(t, e2.Endpoint)
 }
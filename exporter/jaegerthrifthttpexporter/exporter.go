@@ -24,7 +24,10 @@ import (
 	"time"
 
 	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.opentelemetry.io/collector/consumer/consumererror"
@@ -36,27 +39,33 @@ const defaultHTTPTimeout = time.Second * 5
 
 // New returns a new Jaeger Thrift over HTTP exporter.
 // The exporterName is the name to be used in the observability of the exporter.
-// The httpAddress should be the URL of the collector to handle POST requests,
-// typically something like: http://hostname:14268/api/traces.
-// The headers parameter is used to add entries to the POST message set to the
-// collector.
-// The timeout is used to set the timeout for the HTTP requests, if the
-// value is equal or smaller than zero the default of 5 seconds is used.
+// The httpSettings.Endpoint should be the URL of the collector to handle POST
+// requests, typically something like: http://hostname:14268/api/traces.
+// The httpSettings.Headers are added to the POST message sent to the
+// collector and httpSettings.TLSSetting configures the TLS connection.
+// The httpSettings.Timeout is used to set the timeout for the HTTP requests,
+// if the value is equal or smaller than zero the default of 5 seconds is used.
+// The maxPayloadSize is the maximum size in bytes of a serialized batch, larger
+// batches are split before being sent. A value of 0 means no limit.
 func New(
 	config configmodels.Exporter,
-	httpAddress string,
-	headers map[string]string,
-	timeout time.Duration,
+	httpSettings confighttp.HTTPClientSettings,
+	maxPayloadSize int,
 ) (component.TraceExporterOld, error) {
 
-	clientTimeout := defaultHTTPTimeout
-	if timeout != 0 {
-		clientTimeout = timeout
+	client, err := httpSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = defaultHTTPTimeout
+	if httpSettings.Timeout > 0 {
+		client.Timeout = httpSettings.Timeout
 	}
 	s := &jaegerThriftHTTPSender{
-		url:     httpAddress,
-		headers: headers,
-		client:  &http.Client{Timeout: clientTimeout},
+		url:            httpSettings.Endpoint,
+		headers:        httpSettings.Headers,
+		client:         client,
+		maxPayloadSize: maxPayloadSize,
 	}
 
 	return exporterhelper.NewTraceExporterOld(
@@ -67,9 +76,10 @@ func New(
 // jaegerThriftHTTPSender forwards spans encoded in the jaeger thrift
 // format to a http server.
 type jaegerThriftHTTPSender struct {
-	url     string
-	headers map[string]string
-	client  *http.Client
+	url            string
+	headers        map[string]string
+	client         *http.Client
+	maxPayloadSize int
 }
 
 func (s *jaegerThriftHTTPSender) pushTraceData(
@@ -82,14 +92,68 @@ func (s *jaegerThriftHTTPSender) pushTraceData(
 		return len(td.Spans), consumererror.Permanent(err)
 	}
 
-	body, err := serializeThrift(tBatch)
+	return s.send(ctx, tBatch)
+}
+
+// send serializes and posts the batch, splitting it in halves whenever it is
+// larger than maxPayloadSize or is rejected by the server as too large.
+func (s *jaegerThriftHTTPSender) send(ctx context.Context, batch *jaeger.Batch) (int, error) {
+	body, err := serializeThrift(batch)
 	if err != nil {
-		return len(td.Spans), err
+		return len(batch.Spans), err
+	}
+
+	if s.maxPayloadSize > 0 && body.Len() > s.maxPayloadSize {
+		if len(batch.Spans) <= 1 {
+			err = fmt.Errorf(
+				"span of %d bytes exceeds the max payload size of %d bytes",
+				body.Len(),
+				s.maxPayloadSize)
+			return len(batch.Spans), consumererror.Permanent(err)
+		}
+		return s.sendSplit(ctx, batch)
 	}
 
-	req, err := http.NewRequest("POST", s.url, body)
+	statusCode, err := s.post(ctx, body)
 	if err != nil {
-		return len(td.Spans), err
+		return len(batch.Spans), err
+	}
+
+	if statusCode == http.StatusRequestEntityTooLarge && len(batch.Spans) > 1 {
+		return s.sendSplit(ctx, batch)
+	}
+
+	if statusCode >= http.StatusBadRequest {
+		err = fmt.Errorf(
+			"HTTP %d %q",
+			statusCode,
+			http.StatusText(statusCode))
+		return len(batch.Spans), err
+	}
+
+	return 0, nil
+}
+
+// sendSplit sends each half of the batch separately, both halves sharing the
+// process of the original batch.
+func (s *jaegerThriftHTTPSender) sendSplit(ctx context.Context, batch *jaeger.Batch) (int, error) {
+	half := len(batch.Spans) / 2
+	var errs []error
+	dropped := 0
+	for _, spans := range [][]*jaeger.Span{batch.Spans[:half], batch.Spans[half:]} {
+		n, err := s.send(ctx, &jaeger.Batch{Process: batch.Process, Spans: spans})
+		dropped += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return dropped, componenterror.CombineErrors(errs)
+}
+
+func (s *jaegerThriftHTTPSender) post(ctx context.Context, body *bytes.Buffer) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", s.url, body)
+	if err != nil {
+		return 0, err
 	}
 
 	req.Header.Set("Content-Type", "application/x-thrift")
@@ -101,21 +165,13 @@ func (s *jaegerThriftHTTPSender) pushTraceData(
 
 	resp, err := s.client.Do(req)
 	if err != nil {
-		return len(td.Spans), err
+		return 0, err
 	}
 
 	io.Copy(ioutil.Discard, resp.Body)
 	resp.Body.Close()
 
-	if resp.StatusCode >= http.StatusBadRequest {
-		err = fmt.Errorf(
-			"HTTP %d %q",
-			resp.StatusCode,
-			http.StatusText(resp.StatusCode))
-		return len(td.Spans), err
-	}
-
-	return 0, nil
+	return resp.StatusCode, nil
 }
 
 func serializeThrift(obj thrift.TStruct) (*bytes.Buffer, error) {
@@ -16,33 +16,42 @@ package jaegerthrifthttpexporter
 
 import (
 	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 const testHTTPAddress = "http://a.test.dom:123/at/some/path"
 
 type args struct {
-	config      configmodels.Exporter
-	httpAddress string
-	headers     map[string]string
-	timeout     time.Duration
+	config         configmodels.Exporter
+	httpSettings   confighttp.HTTPClientSettings
+	maxPayloadSize int
 }
 
 func TestNew(t *testing.T) {
 	ar := args{
-		config:      &configmodels.ExporterSettings{},
-		httpAddress: testHTTPAddress,
-		headers:     map[string]string{"test": "test"},
-		timeout:     10 * time.Nanosecond,
+		config: &configmodels.ExporterSettings{},
+		httpSettings: confighttp.HTTPClientSettings{
+			Endpoint: testHTTPAddress,
+			Headers:  map[string]string{"test": "test"},
+			Timeout:  10 * time.Nanosecond,
+		},
 	}
 
-	got, err := New(ar.config, ar.httpAddress, ar.headers, ar.timeout)
+	got, err := New(ar.config, ar.httpSettings, ar.maxPayloadSize)
 	assert.NoError(t, err)
 	require.NotNil(t, got)
 
@@ -53,11 +62,148 @@ func TestNew(t *testing.T) {
 
 func TestNewFailsWithEmptyExporterName(t *testing.T) {
 	ar := args{
-		config:      nil,
-		httpAddress: testHTTPAddress,
+		config: nil,
+		httpSettings: confighttp.HTTPClientSettings{
+			Endpoint: testHTTPAddress,
+		},
 	}
 
-	got, err := New(ar.config, ar.httpAddress, ar.headers, ar.timeout)
+	got, err := New(ar.config, ar.httpSettings, ar.maxPayloadSize)
 	assert.EqualError(t, err, "nil config")
 	assert.Nil(t, got)
 }
+
+func TestNewFailsWithInvalidTLSSettings(t *testing.T) {
+	ar := args{
+		config: &configmodels.ExporterSettings{},
+		httpSettings: confighttp.HTTPClientSettings{
+			Endpoint: testHTTPAddress,
+			TLSSetting: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{
+					CAFile: "/nonexistent/ca.pem",
+				},
+			},
+		},
+	}
+
+	got, err := New(ar.config, ar.httpSettings, ar.maxPayloadSize)
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func testTraceData(numSpans int) consumerdata.TraceData {
+	td := consumerdata.TraceData{}
+	for i := 0; i < numSpans; i++ {
+		td.Spans = append(td.Spans, &tracepb.Span{
+			TraceId: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10},
+			SpanId:  []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, byte(i + 1)},
+			Name:    &tracepb.TruncatableString{Value: "operation"},
+		})
+	}
+	return td
+}
+
+// fakeCollector counts the requests it receives and rejects those whose body
+// is larger than maxBodySize.
+type fakeCollector struct {
+	*httptest.Server
+	maxBodySize int
+
+	mu       sync.Mutex
+	requests int
+	headers  http.Header
+}
+
+func newFakeCollector(t *testing.T, maxBodySize int) *fakeCollector {
+	c := &fakeCollector{maxBodySize: maxBodySize}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.headers = r.Header
+		if c.maxBodySize > 0 && len(body) > c.maxBodySize {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.requests++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	return c
+}
+
+func batchSize(t *testing.T, numSpans int) int {
+	batch, err := OCProtoToJaegerThrift(testTraceData(numSpans))
+	require.NoError(t, err)
+	body, err := serializeThrift(batch)
+	require.NoError(t, err)
+	return body.Len()
+}
+
+func TestPushTraceData(t *testing.T) {
+	collector := newFakeCollector(t, 0)
+	defer collector.Close()
+
+	s := &jaegerThriftHTTPSender{
+		url:     collector.URL,
+		headers: map[string]string{"x-custom-header": "value"},
+		client:  collector.Client(),
+	}
+	dropped, err := s.pushTraceData(context.Background(), testTraceData(4))
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+	assert.Equal(t, 1, collector.requests)
+	assert.Equal(t, "application/x-thrift", collector.headers.Get("Content-Type"))
+	assert.Equal(t, "value", collector.headers.Get("X-Custom-Header"))
+}
+
+func TestPushTraceDataSplitsOversizedBatches(t *testing.T) {
+	collector := newFakeCollector(t, 0)
+	defer collector.Close()
+
+	s := &jaegerThriftHTTPSender{
+		url:            collector.URL,
+		client:         collector.Client(),
+		maxPayloadSize: batchSize(t, 2),
+	}
+	dropped, err := s.pushTraceData(context.Background(), testTraceData(8))
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+	assert.Equal(t, 4, collector.requests)
+}
+
+func TestPushTraceDataSplitsRejectedBatches(t *testing.T) {
+	collector := newFakeCollector(t, batchSize(t, 1))
+	defer collector.Close()
+
+	s := &jaegerThriftHTTPSender{
+		url:    collector.URL,
+		client: collector.Client(),
+	}
+	dropped, err := s.pushTraceData(context.Background(), testTraceData(3))
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+	assert.Equal(t, 3, collector.requests)
+}
+
+func TestPushTraceDataSpanTooLarge(t *testing.T) {
+	collector := newFakeCollector(t, 0)
+	defer collector.Close()
+
+	s := &jaegerThriftHTTPSender{
+		url:            collector.URL,
+		client:         collector.Client(),
+		maxPayloadSize: 1,
+	}
+	dropped, err := s.pushTraceData(context.Background(), testTraceData(1))
+	assert.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, dropped)
+	assert.Zero(t, collector.requests)
+
+	dropped, err = s.pushTraceData(context.Background(), testTraceData(2))
+	assert.Error(t, err)
+	assert.Equal(t, 2, dropped)
+	assert.Zero(t, collector.requests)
+}
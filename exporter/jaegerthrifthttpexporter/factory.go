@@ -20,6 +20,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.uber.org/zap"
 )
@@ -45,7 +46,9 @@ func (f *Factory) CreateDefaultConfig() configmodels.Exporter {
 			TypeVal: configmodels.Type(typeStr),
 			NameVal: typeStr,
 		},
-		Timeout: defaultHTTPTimeout,
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Timeout: defaultHTTPTimeout,
+		},
 	}
 }
 
@@ -56,11 +59,15 @@ func (f *Factory) CreateTraceExporter(
 ) (component.TraceExporterOld, error) {
 
 	expCfg := config.(*Config)
-	_, err := url.ParseRequestURI(expCfg.URL)
+	httpSettings := expCfg.HTTPClientSettings
+	if httpSettings.Endpoint == "" {
+		httpSettings.Endpoint = expCfg.URL
+	}
+	_, err := url.ParseRequestURI(httpSettings.Endpoint)
 	if err != nil {
 		// TODO: Improve error message, see #215
 		err = fmt.Errorf(
-			"%q config requires a valid \"url\": %v",
+			"%q config requires a valid \"endpoint\": %v",
 			expCfg.Name(),
 			err)
 		return nil, err
@@ -73,7 +80,14 @@ func (f *Factory) CreateTraceExporter(
 		return nil, err
 	}
 
-	return New(config, expCfg.URL, expCfg.Headers, expCfg.Timeout)
+	if expCfg.MaxPayloadSize < 0 {
+		err := fmt.Errorf(
+			"%q config requires a non-negative value for \"max_payload_size\"",
+			expCfg.Name())
+		return nil, err
+	}
+
+	return New(config, httpSettings, expCfg.MaxPayloadSize)
 }
 
 // CreateMetricsExporter creates a metrics exporter based on this config.
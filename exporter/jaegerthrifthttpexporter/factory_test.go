@@ -21,6 +21,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.uber.org/zap"
 )
@@ -54,6 +55,17 @@ func TestCreateInstanceViaFactory(t *testing.T) {
 
 	// Endpoint doesn't have a default value so set it directly.
 	expCfg := cfg.(*Config)
+	expCfg.Endpoint = "http://some.target.org:12345/api/traces"
+	exp, err = factory.CreateTraceExporter(
+		zap.NewNop(),
+		cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, exp)
+
+	assert.NoError(t, exp.Shutdown(context.Background()))
+
+	// The deprecated URL is used when the endpoint is not set.
+	expCfg.Endpoint = ""
 	expCfg.URL = "http://some.target.org:12345/api/traces"
 	exp, err = factory.CreateTraceExporter(
 		zap.NewNop(),
@@ -71,12 +83,15 @@ func TestFactory_CreateTraceExporter(t *testing.T) {
 			TypeVal: configmodels.Type(typeStr),
 			NameVal: typeStr,
 		},
-		URL: "http://some.other.location/api/traces",
-		Headers: map[string]string{
-			"added-entry": "added value",
-			"dot.test":    "test",
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: "http://some.other.location/api/traces",
+			Headers: map[string]string{
+				"added-entry": "added value",
+				"dot.test":    "test",
+			},
+			Timeout: 2 * time.Second,
 		},
-		Timeout: 2 * time.Second,
+		MaxPayloadSize: 1024,
 	}
 
 	te, err := f.CreateTraceExporter(zap.NewNop(), config)
@@ -98,7 +113,7 @@ func TestFactory_CreateTraceExporterFails(t *testing.T) {
 					NameVal: typeStr,
 				},
 			},
-			errorMessage: "\"jaeger_thrift\" config requires a valid \"url\": parse \"\": empty url",
+			errorMessage: "\"jaeger_thrift\" config requires a valid \"endpoint\": parse \"\": empty url",
 		},
 		{
 			name: "invalid_url",
@@ -107,9 +122,11 @@ func TestFactory_CreateTraceExporterFails(t *testing.T) {
 					TypeVal: configmodels.Type(typeStr),
 					NameVal: typeStr,
 				},
-				URL: ".localhost:123",
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ".localhost:123",
+				},
 			},
-			errorMessage: "\"jaeger_thrift\" config requires a valid \"url\": parse \".localhost:123\": invalid URI for request",
+			errorMessage: "\"jaeger_thrift\" config requires a valid \"endpoint\": parse \".localhost:123\": invalid URI for request",
 		},
 		{
 			name: "negative_duration",
@@ -118,11 +135,28 @@ func TestFactory_CreateTraceExporterFails(t *testing.T) {
 					TypeVal: configmodels.Type(typeStr),
 					NameVal: typeStr,
 				},
-				URL:     "localhost:123",
-				Timeout: -2 * time.Second,
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "localhost:123",
+					Timeout:  -2 * time.Second,
+				},
 			},
 			errorMessage: "\"jaeger_thrift\" config requires a positive value for \"timeout\"",
 		},
+		{
+			name: "negative_max_payload_size",
+			config: &Config{
+				ExporterSettings: configmodels.ExporterSettings{
+					TypeVal: configmodels.Type(typeStr),
+					NameVal: typeStr,
+				},
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "localhost:123",
+					Timeout:  2 * time.Second,
+				},
+				MaxPayloadSize: -1,
+			},
+			errorMessage: "\"jaeger_thrift\" config requires a non-negative value for \"max_payload_size\"",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
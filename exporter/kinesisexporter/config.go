@@ -15,9 +15,19 @@
 package kinesisexporter
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 )
 
+const (
+	encodingOTLPProto = "otlp_proto"
+	encodingJSON      = "json"
+
+	compressionNone = "none"
+	compressionGzip = "gzip"
+)
+
 // AWSConfig contains AWS specific configuration such as kinesis stream, region, etc.
 type AWSConfig struct {
 	StreamName      string `mapstructure:"stream_name"`
@@ -52,4 +62,27 @@ type Config struct {
 	MaxBytesPerBatch     int `mapstructure:"max_bytes_per_batch"`
 	MaxBytesPerSpan      int `mapstructure:"max_bytes_per_span"`
 	FlushIntervalSeconds int `mapstructure:"flush_interval_seconds"`
+
+	// Encoding is the encoding of the metrics and logs records, "otlp_proto" or "json".
+	Encoding string `mapstructure:"encoding"`
+	// Compression is the compression of the metrics and logs records, "none" or "gzip".
+	Compression string `mapstructure:"compression"`
+	// PartitionKeyAttribute is the resource attribute whose value is the partition key
+	// of the metrics and logs records. A random partition key is used for the
+	// resources without it.
+	PartitionKeyAttribute string `mapstructure:"partition_key_attribute"`
+}
+
+func (c *Config) validate() error {
+	switch c.Encoding {
+	case encodingOTLPProto, encodingJSON:
+	default:
+		return fmt.Errorf("%q: unsupported encoding %q", c.Name(), c.Encoding)
+	}
+	switch c.Compression {
+	case compressionNone, compressionGzip:
+	default:
+		return fmt.Errorf("%q: unsupported compression %q", c.Name(), c.Compression)
+	}
+	return nil
 }
@@ -62,6 +62,9 @@ func TestDefaultConfig(t *testing.T) {
 			FlushIntervalSeconds: 5,
 			MaxBytesPerBatch:     100000,
 			MaxBytesPerSpan:      900000,
+
+			Encoding:    "otlp_proto",
+			Compression: "none",
 		},
 	)
 }
@@ -110,6 +113,10 @@ func TestConfig(t *testing.T) {
 			FlushIntervalSeconds: 3,
 			MaxBytesPerBatch:     4,
 			MaxBytesPerSpan:      5,
+
+			Encoding:              "json",
+			Compression:           "gzip",
+			PartitionKeyAttribute: "service.name",
 		},
 	)
 }
@@ -118,3 +125,15 @@ func TestConfigCheck(t *testing.T) {
 	cfg := (NewFactory()).CreateDefaultConfig()
 	assert.NoError(t, configcheck.ValidateConfig(cfg))
 }
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.validate())
+
+	cfg.Encoding = "zipkin"
+	assert.Error(t, cfg.validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Compression = "zstd"
+	assert.Error(t, cfg.validate())
+}
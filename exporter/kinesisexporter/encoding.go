@@ -0,0 +1,135 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kinesisexporter
+
+import (
+	"bytes"
+	"encoding/json"
+
+	agentmetricspb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/metrics/v1"
+	"github.com/golang/protobuf/jsonpb"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+)
+
+// encodeMetrics encodes the metrics as OTLP proto, or as one JSON line per
+// node and resource.
+func encodeMetrics(encoding string, mds []consumerdata.MetricsData) ([]byte, error) {
+	if encoding == encodingOTLPProto {
+		return pdatautil.MetricsFromMetricsData(mds).ToOtlpProtoBytes()
+	}
+
+	buf := new(bytes.Buffer)
+	marshaler := &jsonpb.Marshaler{}
+	for _, md := range mds {
+		req := &agentmetricspb.ExportMetricsServiceRequest{
+			Node:     md.Node,
+			Resource: md.Resource,
+			Metrics:  md.Metrics,
+		}
+		if err := marshaler.Marshal(buf, req); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// logRecord is the JSON encoding of a log record.
+type logRecord struct {
+	Timestamp      uint64                 `json:"timestamp,omitempty"`
+	SeverityNumber int32                  `json:"severity_number,omitempty"`
+	SeverityText   string                 `json:"severity_text,omitempty"`
+	Name           string                 `json:"name,omitempty"`
+	Body           interface{}            `json:"body,omitempty"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty"`
+	Resource       map[string]interface{} `json:"resource,omitempty"`
+}
+
+// encodeLogs encodes the logs as OTLP proto, or as one JSON line per log
+// record.
+func encodeLogs(encoding string, ld pdata.Logs) ([]byte, error) {
+	if encoding == encodingOTLPProto {
+		return ld.ToOtlpProtoBytes()
+	}
+
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		var resource map[string]interface{}
+		if !rl.Resource().IsNil() {
+			resource = attributeMapToInterface(rl.Resource().Attributes())
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+				if err := enc.Encode(&logRecord{
+					Timestamp:      uint64(lr.Timestamp()),
+					SeverityNumber: int32(lr.SeverityNumber()),
+					SeverityText:   lr.SeverityText(),
+					Name:           lr.Name(),
+					Body:           attributeValueToInterface(lr.Body()),
+					Attributes:     attributeMapToInterface(lr.Attributes()),
+					Resource:       resource,
+				}); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func attributeMapToInterface(attrs pdata.AttributeMap) map[string]interface{} {
+	if attrs.Len() == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = attributeValueToInterface(v)
+	})
+	return m
+}
+
+func attributeValueToInterface(v pdata.AttributeValue) interface{} {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return v.IntVal()
+	case pdata.AttributeValueDOUBLE:
+		return v.DoubleVal()
+	case pdata.AttributeValueBOOL:
+		return v.BoolVal()
+	case pdata.AttributeValueMAP:
+		return attributeMapToInterface(v.MapVal())
+	}
+	return nil
+}
@@ -16,11 +16,15 @@ package kinesisexporter
 
 import (
 	"context"
+	"fmt"
 
 	kinesis "github.com/signalfx/opencensus-go-exporter-kinesis"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
 	jaegertranslator "go.opentelemetry.io/collector/translator/trace/jaeger"
 	"go.uber.org/zap"
 )
@@ -70,3 +74,121 @@ func (e Exporter) ConsumeTraces(_ context.Context, td pdata.Traces) error {
 	}
 	return exportErr
 }
+
+// metricsExporter exports metrics to AWS Kinesis, aggregating the metrics of
+// the resources sharing a partition key into the same records.
+type metricsExporter struct {
+	producer              *producer
+	encoding              string
+	partitionKeyAttribute string
+	logger                *zap.Logger
+}
+
+var _ component.MetricsExporter = (*metricsExporter)(nil)
+
+func (e *metricsExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *metricsExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// ConsumeMetrics exports the metrics to AWS Kinesis.
+func (e *metricsExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	mds := pdatautil.MetricsToMetricsData(md)
+	keys := make([]string, len(mds))
+	if e.partitionKeyAttribute != "" {
+		for i, md := range mds {
+			if md.Resource != nil {
+				keys[i] = md.Resource.Labels[e.partitionKeyAttribute]
+			}
+		}
+	}
+
+	records, err := e.producer.aggregate(keys, func(indices []int) ([]byte, error) {
+		group := make([]consumerdata.MetricsData, len(indices))
+		for i, index := range indices {
+			group[i] = mds[index]
+		}
+		return encodeMetrics(e.encoding, group)
+	})
+	if err != nil {
+		e.logger.Error("error encoding metrics", zap.Error(err))
+		return consumererror.Permanent(err)
+	}
+
+	if _, err := e.producer.put(ctx, records); err != nil {
+		return fmt.Errorf("error exporting metrics to kinesis: %w", err)
+	}
+	return nil
+}
+
+// logsExporter exports logs to AWS Kinesis, aggregating the logs of the
+// resources sharing a partition key into the same records.
+type logsExporter struct {
+	producer              *producer
+	encoding              string
+	partitionKeyAttribute string
+	logger                *zap.Logger
+}
+
+var _ component.LogsExporter = (*logsExporter)(nil)
+
+func (e *logsExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *logsExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// ConsumeLogs exports the logs to AWS Kinesis.
+func (e *logsExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	ctx = obsreport.StartLogsExportOp(ctx, typeStr)
+
+	err := e.pushLogs(ctx, ld)
+
+	// The logs of a failed request can't be told apart once aggregated into
+	// records, all of them are reported as dropped.
+	numDroppedLogs := 0
+	if err != nil {
+		numDroppedLogs = ld.LogRecordCount()
+	}
+	obsreport.EndLogsExportOp(ctx, ld.LogRecordCount(), numDroppedLogs, err)
+	return err
+}
+
+func (e *logsExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	rls := ld.ResourceLogs()
+	keys := make([]string, rls.Len())
+	if e.partitionKeyAttribute != "" {
+		for i := 0; i < rls.Len(); i++ {
+			rl := rls.At(i)
+			if rl.IsNil() || rl.Resource().IsNil() {
+				continue
+			}
+			if v, ok := rl.Resource().Attributes().Get(e.partitionKeyAttribute); ok && v.Type() == pdata.AttributeValueSTRING {
+				keys[i] = v.StringVal()
+			}
+		}
+	}
+
+	records, err := e.producer.aggregate(keys, func(indices []int) ([]byte, error) {
+		group := pdata.NewLogs()
+		group.ResourceLogs().Resize(len(indices))
+		for i, index := range indices {
+			rls.At(index).CopyTo(group.ResourceLogs().At(i))
+		}
+		return encodeLogs(e.encoding, group)
+	})
+	if err != nil {
+		e.logger.Error("error encoding logs", zap.Error(err))
+		return consumererror.Permanent(err)
+	}
+
+	if _, err := e.producer.put(ctx, records); err != nil {
+		return fmt.Errorf("error exporting logs to kinesis: %w", err)
+	}
+	return nil
+}
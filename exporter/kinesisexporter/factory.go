@@ -34,7 +34,9 @@ func NewFactory() component.ExporterFactory {
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		exporterhelper.WithTraces(createTraceExporter))
+		exporterhelper.WithTraces(createTraceExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
 }
 
 func createDefaultConfig() configmodels.Exporter {
@@ -55,6 +57,9 @@ func createDefaultConfig() configmodels.Exporter {
 		FlushIntervalSeconds: 5,
 		MaxBytesPerBatch:     100000,
 		MaxBytesPerSpan:      900000,
+
+		Encoding:    encodingOTLPProto,
+		Compression: compressionNone,
 	}
 }
 
@@ -93,3 +98,45 @@ func createTraceExporter(
 	}
 	return Exporter{k, params.Logger}, nil
 }
+
+func createMetricsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	config configmodels.Exporter,
+) (component.MetricsExporter, error) {
+	c := config.(*Config)
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	p, err := newProducer(c, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsExporter{
+		producer:              p,
+		encoding:              c.Encoding,
+		partitionKeyAttribute: c.PartitionKeyAttribute,
+		logger:                params.Logger,
+	}, nil
+}
+
+func createLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	config configmodels.Exporter,
+) (component.LogsExporter, error) {
+	c := config.(*Config)
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	p, err := newProducer(c, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return &logsExporter{
+		producer:              p,
+		encoding:              c.Encoding,
+		partitionKeyAttribute: c.PartitionKeyAttribute,
+		logger:                params.Logger,
+	}, nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kinesisexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxRecordSize is the maximum size of the data of a Kinesis record.
+	maxRecordSize = 1024 * 1024
+	// maxRecordsPerPut is the maximum number of records of a PutRecords request.
+	maxRecordsPerPut = 500
+	// maxBytesPerPut is the maximum size of the records of a PutRecords request.
+	maxBytesPerPut = 5 * 1024 * 1024
+)
+
+// record is a Kinesis record ready to be sent.
+type record struct {
+	partitionKey string
+	data         []byte
+}
+
+// producer aggregates the encoded metrics and logs into Kinesis records and
+// sends them with PutRecords requests.
+type producer struct {
+	client      kinesisiface.KinesisAPI
+	streamName  string
+	compression string
+	logger      *zap.Logger
+}
+
+func newProducer(c *Config, logger *zap.Logger) (*producer, error) {
+	awsConfig := aws.NewConfig().WithRegion(c.AWS.Region)
+	if c.AWS.KinesisEndpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(c.AWS.KinesisEndpoint)
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if c.AWS.Role != "" {
+		awsConfig = awsConfig.WithCredentials(stscreds.NewCredentials(sess, c.AWS.Role))
+	}
+
+	return &producer{
+		client:      kinesis.New(sess, awsConfig),
+		streamName:  c.AWS.StreamName,
+		compression: c.Compression,
+		logger:      logger,
+	}, nil
+}
+
+// aggregate returns the records holding the items, whose partition keys are
+// keys. The items having the same partition key are encoded together by
+// encode, and split in halves while their compressed data is larger than a
+// record. The items without partition key share a random one. An item larger
+// than a record on its own is dropped.
+func (p *producer) aggregate(keys []string, encode func(indices []int) ([]byte, error)) ([]record, error) {
+	var (
+		groups    = make(map[string][]int)
+		order     []string
+		randomKey = uuid.New().String()
+		records   []record
+	)
+	for i, key := range keys {
+		if key == "" {
+			key = randomKey
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	for _, key := range order {
+		recs, err := p.makeRecords(key, groups[key], encode)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, recs...)
+	}
+	return records, nil
+}
+
+func (p *producer) makeRecords(key string, indices []int, encode func(indices []int) ([]byte, error)) ([]record, error) {
+	data, err := encode(indices)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = p.compress(data); err != nil {
+		return nil, err
+	}
+	if len(data) <= maxRecordSize {
+		return []record{{partitionKey: key, data: data}}, nil
+	}
+
+	if len(indices) == 1 {
+		p.logger.Warn("Dropping data larger than a Kinesis record",
+			zap.String("partition_key", key), zap.Int("size", len(data)))
+		return nil, nil
+	}
+
+	half := len(indices) / 2
+	left, err := p.makeRecords(key, indices[:half], encode)
+	if err != nil {
+		return nil, err
+	}
+	right, err := p.makeRecords(key, indices[half:], encode)
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
+
+func (p *producer) compress(data []byte) ([]byte, error) {
+	if p.compression != compressionGzip {
+		return data, nil
+	}
+	buf := new(bytes.Buffer)
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// put sends the records with as few PutRecords requests as possible. It
+// returns the number of records not sent.
+func (p *producer) put(ctx context.Context, records []record) (int, error) {
+	var (
+		numFailed int
+		lastErr   error
+		entries   []*kinesis.PutRecordsRequestEntry
+		size      int
+	)
+
+	flush := func() {
+		if len(entries) == 0 {
+			return
+		}
+		failed, err := p.putRecords(ctx, entries)
+		if err != nil {
+			lastErr = err
+		}
+		numFailed += failed
+		entries = nil
+		size = 0
+	}
+
+	for _, r := range records {
+		recordSize := len(r.data) + len(r.partitionKey)
+		if len(entries) == maxRecordsPerPut || size+recordSize > maxBytesPerPut {
+			flush()
+		}
+		entries = append(entries, &kinesis.PutRecordsRequestEntry{
+			Data:         r.data,
+			PartitionKey: aws.String(r.partitionKey),
+		})
+		size += recordSize
+	}
+	flush()
+
+	return numFailed, lastErr
+}
+
+func (p *producer) putRecords(ctx context.Context, entries []*kinesis.PutRecordsRequestEntry) (int, error) {
+	output, err := p.client.PutRecordsWithContext(ctx, &kinesis.PutRecordsInput{
+		StreamName: aws.String(p.streamName),
+		Records:    entries,
+	})
+	if err != nil {
+		return len(entries), err
+	}
+
+	failed := int(aws.Int64Value(output.FailedRecordCount))
+	if failed == 0 {
+		return 0, nil
+	}
+	for _, r := range output.Records {
+		if r.ErrorCode != nil {
+			return failed, fmt.Errorf("failed to put %d records to Kinesis: %s: %s",
+				failed, aws.StringValue(r.ErrorCode), aws.StringValue(r.ErrorMessage))
+		}
+	}
+	return failed, fmt.Errorf("failed to put %d records to Kinesis", failed)
+}
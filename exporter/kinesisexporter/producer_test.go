@@ -0,0 +1,179 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kinesisexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type fakeKinesis struct {
+	kinesisiface.KinesisAPI
+	inputs []*kinesis.PutRecordsInput
+	failed int64
+}
+
+func (f *fakeKinesis) PutRecordsWithContext(_ aws.Context, input *kinesis.PutRecordsInput, _ ...request.Option) (*kinesis.PutRecordsOutput, error) {
+	f.inputs = append(f.inputs, input)
+	output := &kinesis.PutRecordsOutput{FailedRecordCount: aws.Int64(f.failed)}
+	for i := range input.Records {
+		entry := &kinesis.PutRecordsResultEntry{}
+		if int64(i) < f.failed {
+			entry.ErrorCode = aws.String("ProvisionedThroughputExceededException")
+			entry.ErrorMessage = aws.String("Rate exceeded")
+		}
+		output.Records = append(output.Records, entry)
+	}
+	return output, nil
+}
+
+func newTestProducer(compression string) (*producer, *fakeKinesis) {
+	client := &fakeKinesis{}
+	return &producer{
+		client:      client,
+		streamName:  "test-stream",
+		compression: compression,
+		logger:      zap.NewNop(),
+	}, client
+}
+
+func testLogs(services ...string) pdata.Logs {
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(len(services))
+	for i, service := range services {
+		rl := rls.At(i)
+		rl.Resource().InitEmpty()
+		if service != "" {
+			rl.Resource().Attributes().InsertString("service.name", service)
+		}
+		rl.InstrumentationLibraryLogs().Resize(1)
+		logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+		logs.Resize(1)
+		logs.At(0).SetName("log-" + service)
+		logs.At(0).Body().SetStringVal("hello")
+	}
+	return ld
+}
+
+func TestAggregate(t *testing.T) {
+	p, _ := newTestProducer(compressionNone)
+	keys := []string{"a", "", "b", "a", ""}
+
+	var groups [][]int
+	records, err := p.aggregate(keys, func(indices []int) ([]byte, error) {
+		groups = append(groups, indices)
+		return []byte("data"), nil
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, [][]int{{0, 3}, {1, 4}, {2}}, groups)
+	assert.Equal(t, "a", records[0].partitionKey)
+	assert.NotEmpty(t, records[1].partitionKey)
+	assert.Equal(t, "b", records[2].partitionKey)
+}
+
+func TestAggregateSplitsLargeRecords(t *testing.T) {
+	p, _ := newTestProducer(compressionNone)
+	keys := []string{"a", "a", "a", "a"}
+	sizes := []int{maxRecordSize / 2, maxRecordSize / 2, maxRecordSize + 1, 10}
+
+	records, err := p.aggregate(keys, func(indices []int) ([]byte, error) {
+		size := 0
+		for _, i := range indices {
+			size += sizes[i]
+		}
+		return make([]byte, size), nil
+	})
+	require.NoError(t, err)
+	// The third item is dropped, the others fit in two records.
+	require.Len(t, records, 2)
+	assert.Len(t, records[0].data, maxRecordSize)
+	assert.Len(t, records[1].data, 10)
+}
+
+func TestAggregateGzip(t *testing.T) {
+	p, _ := newTestProducer(compressionGzip)
+	records, err := p.aggregate([]string{"a"}, func([]int) ([]byte, error) {
+		return []byte(strings.Repeat("data", 100)), nil
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	r, err := gzip.NewReader(bytes.NewReader(records[0].data))
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("data", 100), string(data))
+}
+
+func TestPutBatches(t *testing.T) {
+	p, client := newTestProducer(compressionNone)
+	records := make([]record, maxRecordsPerPut+1)
+	for i := range records {
+		records[i] = record{partitionKey: "a", data: []byte("data")}
+	}
+
+	numFailed, err := p.put(context.Background(), records)
+	require.NoError(t, err)
+	assert.Zero(t, numFailed)
+	require.Len(t, client.inputs, 2)
+	assert.Len(t, client.inputs[0].Records, maxRecordsPerPut)
+	assert.Len(t, client.inputs[1].Records, 1)
+	assert.Equal(t, "test-stream", aws.StringValue(client.inputs[0].StreamName))
+}
+
+func TestPutFailedRecords(t *testing.T) {
+	p, client := newTestProducer(compressionNone)
+	client.failed = 1
+
+	numFailed, err := p.put(context.Background(), []record{{partitionKey: "a", data: []byte("data")}})
+	assert.EqualError(t, err, "failed to put 1 records to Kinesis: ProvisionedThroughputExceededException: Rate exceeded")
+	assert.Equal(t, 1, numFailed)
+}
+
+func TestConsumeLogs(t *testing.T) {
+	p, client := newTestProducer(compressionNone)
+	e := &logsExporter{
+		producer:              p,
+		encoding:              encodingJSON,
+		partitionKeyAttribute: "service.name",
+		logger:                zap.NewNop(),
+	}
+
+	require.NoError(t, e.ConsumeLogs(context.Background(), testLogs("svc1", "svc2", "svc1")))
+	require.Len(t, client.inputs, 1)
+	records := client.inputs[0].Records
+	require.Len(t, records, 2)
+	assert.Equal(t, "svc1", aws.StringValue(records[0].PartitionKey))
+	assert.Equal(t,
+		`{"name":"log-svc1","body":"hello","resource":{"service.name":"svc1"}}`+"\n"+
+			`{"name":"log-svc1","body":"hello","resource":{"service.name":"svc1"}}`+"\n",
+		string(records[0].Data))
+	assert.Equal(t, "svc2", aws.StringValue(records[1].PartitionKey))
+}
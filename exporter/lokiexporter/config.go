@@ -0,0 +1,118 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// labelNameRegexp matches the valid Loki label names.
+var labelNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// invalidLabelCharRegexp matches the characters not allowed in Loki label names.
+var invalidLabelCharRegexp = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// Config defines configuration for the Loki exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"`
+	configtls.TLSClientSetting    `mapstructure:",squash"`
+
+	// Endpoint is the URL of the Loki push API, e.g.
+	// http://loki:3100/loki/api/v1/push.
+	//
+	// This is required.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// TenantID is sent in the X-Scope-OrgID header, identifying the tenant
+	// of the logs in a multi-tenant Loki.
+	TenantID string `mapstructure:"tenant_id"`
+
+	// Headers are added to the push requests.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Timeout is the timeout of the push requests. Defaults to 5 seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Labels lists the attributes converted to the labels of the Loki
+	// streams. Only these attributes become labels, keeping the number of
+	// streams under control.
+	Labels LabelsConfig `mapstructure:"labels"`
+}
+
+// LabelsConfig lists the attributes converted to Loki labels.
+type LabelsConfig struct {
+	// Resource lists the resource attributes converted to labels.
+	Resource []LabelConfig `mapstructure:"resource"`
+
+	// Record lists the log record attributes converted to labels. A record
+	// attribute takes precedence over a resource attribute with the same label.
+	Record []LabelConfig `mapstructure:"record"`
+}
+
+// LabelConfig maps an attribute to a Loki label.
+type LabelConfig struct {
+	// Attribute is the name of the attribute.
+	Attribute string `mapstructure:"attribute"`
+
+	// Label is the name of the label. It defaults to the attribute name, its
+	// characters not allowed in label names being replaced with underscores.
+	Label string `mapstructure:"label"`
+}
+
+// validate checks the configuration and fills in the default label names.
+func (cfg *Config) validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" must be specified")
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return fmt.Errorf("invalid \"endpoint\": %w", err)
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("cannot have a negative \"timeout\"")
+	}
+	if len(cfg.Labels.Resource) == 0 && len(cfg.Labels.Record) == 0 {
+		return errors.New("at least one resource or record attribute must be converted to a label")
+	}
+	for _, labels := range [][]LabelConfig{cfg.Labels.Resource, cfg.Labels.Record} {
+		for i := range labels {
+			if err := labels[i].validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (lc *LabelConfig) validate() error {
+	if lc.Attribute == "" {
+		return errors.New("label \"attribute\" must be specified")
+	}
+	if lc.Label == "" {
+		lc.Label = invalidLabelCharRegexp.ReplaceAllString(lc.Attribute, "_")
+	}
+	if !labelNameRegexp.MatchString(lc.Label) {
+		return fmt.Errorf("invalid label name %q", lc.Label)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters["loki"]
+	assert.Equal(t, factory.CreateDefaultConfig(), e0)
+
+	e1 := cfg.Exporters["loki/allsettings"]
+	assert.Equal(t, &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "loki/allsettings",
+			TypeVal: "loki",
+		},
+		Endpoint: "https://loki:3100/loki/api/v1/push",
+		TenantID: "tenant1",
+		Headers:  map[string]string{"x-custom-header": "value"},
+		Timeout:  10 * time.Second,
+		Labels: LabelsConfig{
+			Resource: []LabelConfig{
+				{Attribute: "service.name", Label: "job"},
+				{Attribute: "k8s.namespace.name"},
+			},
+			Record: []LabelConfig{
+				{Attribute: "severity"},
+			},
+		},
+	}, e1)
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Endpoint = "http://loki:3100/loki/api/v1/push"
+		cfg.Labels.Resource = []LabelConfig{{Attribute: "k8s.namespace.name"}}
+		return cfg
+	}
+
+	cfg := valid()
+	require.NoError(t, cfg.validate())
+	assert.Equal(t, "k8s_namespace_name", cfg.Labels.Resource[0].Label)
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    `"endpoint" must be specified`,
+		},
+		{
+			name:   "negative timeout",
+			modify: func(cfg *Config) { cfg.Timeout = -time.Second },
+			err:    `cannot have a negative "timeout"`,
+		},
+		{
+			name:   "no labels",
+			modify: func(cfg *Config) { cfg.Labels = LabelsConfig{} },
+			err:    "at least one resource or record attribute must be converted to a label",
+		},
+		{
+			name:   "no attribute",
+			modify: func(cfg *Config) { cfg.Labels.Record = []LabelConfig{{Label: "level"}} },
+			err:    `label "attribute" must be specified`,
+		},
+		{
+			name:   "invalid label",
+			modify: func(cfg *Config) { cfg.Labels.Record = []LabelConfig{{Attribute: "severity", Label: "0level"}} },
+			err:    `invalid label name "0level"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.validate(), tt.err)
+		})
+	}
+}
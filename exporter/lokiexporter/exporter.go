@@ -0,0 +1,139 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const tenantIDHeader = "X-Scope-OrgID"
+
+type lokiExporter struct {
+	config *Config
+	client *http.Client
+	logger *zap.Logger
+}
+
+var _ component.LogsExporter = (*lokiExporter)(nil)
+
+func newExporter(config *Config, logger *zap.Logger) (*lokiExporter, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := config.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	return &lokiExporter{
+		config: config,
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		logger: logger,
+	}, nil
+}
+
+func (e *lokiExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *lokiExporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// ConsumeLogs pushes the logs to Loki, in a single request holding a stream
+// per label set.
+func (e *lokiExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	ctx = obsreport.StartLogsExportOp(ctx, typeStr)
+
+	numDroppedLogs, err := e.pushLogData(ctx, ld)
+
+	obsreport.EndLogsExportOp(ctx, ld.LogRecordCount(), numDroppedLogs, err)
+	return err
+}
+
+// pushLogData returns the number of log records not pushed, those without
+// labels or all of them if the request fails.
+func (e *lokiExporter) pushLogData(ctx context.Context, ld pdata.Logs) (int, error) {
+	req, numDropped := logDataToLoki(ld, &e.config.Labels)
+	if numDropped > 0 {
+		e.logger.Debug("Dropped log records without labels", zap.Int("count", numDropped))
+	}
+	if len(req.Streams) == 0 {
+		return numDropped, nil
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return ld.LogRecordCount(), consumererror.Permanent(err)
+	}
+	if err := e.push(ctx, body); err != nil {
+		return ld.LogRecordCount(), err
+	}
+	return numDropped, nil
+}
+
+func (e *lokiExporter) push(ctx context.Context, body []byte) error {
+	req, err := http.NewRequest("POST", e.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req = req.WithContext(ctx)
+	for k, v := range e.config.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.config.TenantID != "" {
+		req.Header.Set(tenantIDHeader, e.config.TenantID)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return consumererror.Permanent(err)
+	}
+	return err
+}
@@ -0,0 +1,148 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func testLabelsConfig() *LabelsConfig {
+	return &LabelsConfig{
+		Resource: []LabelConfig{{Attribute: "service.name", Label: "job"}},
+		Record:   []LabelConfig{{Attribute: "severity", Label: "level"}},
+	}
+}
+
+func testLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(2)
+
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().InitEmpty()
+	rl.Resource().Attributes().InsertString("service.name", "api")
+	rl.Resource().Attributes().InsertString("host.name", "host1")
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(3)
+	logs.At(0).SetTimestamp(3000)
+	logs.At(0).Body().SetStringVal("third")
+	logs.At(0).Attributes().InsertString("severity", "info")
+	logs.At(1).SetTimestamp(1000)
+	logs.At(1).Body().SetStringVal("first")
+	logs.At(1).Attributes().InsertString("severity", "info")
+	logs.At(2).SetTimestamp(2000)
+	logs.At(2).Body().SetStringVal("error")
+	logs.At(2).Attributes().InsertString("severity", "error")
+
+	// Without any of the configured attributes.
+	rl = ld.ResourceLogs().At(1)
+	rl.Resource().InitEmpty()
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs = rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(1)
+	logs.At(0).SetTimestamp(1000)
+	logs.At(0).Body().SetStringVal("unlabeled")
+	return ld
+}
+
+func TestLogDataToLoki(t *testing.T) {
+	req, numDropped := logDataToLoki(testLogs(), testLabelsConfig())
+	assert.Equal(t, 1, numDropped)
+	require.Len(t, req.Streams, 2)
+
+	assert.Equal(t, map[string]string{"job": "api", "level": "info"}, req.Streams[0].Labels)
+	assert.Equal(t, [][2]string{{"1000", "first"}, {"3000", "third"}}, req.Streams[0].Entries)
+	assert.Equal(t, map[string]string{"job": "api", "level": "error"}, req.Streams[1].Labels)
+	assert.Equal(t, [][2]string{{"2000", "error"}}, req.Streams[1].Entries)
+}
+
+func TestLogDataToLokiMapBody(t *testing.T) {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(1)
+	logs.At(0).SetTimestamp(1000)
+	logs.At(0).Attributes().InsertString("severity", "warn")
+	body := pdata.NewAttributeMap()
+	body.InsertString("msg", "hello")
+	body.InsertInt("code", 3)
+	logs.At(0).Body().SetMapVal(body)
+
+	req, numDropped := logDataToLoki(ld, testLabelsConfig())
+	assert.Zero(t, numDropped)
+	require.Len(t, req.Streams, 1)
+	assert.Equal(t, map[string]string{"level": "warn"}, req.Streams[0].Labels)
+	assert.Equal(t, [][2]string{{"1000", `{"code":3,"msg":"hello"}`}}, req.Streams[0].Entries)
+}
+
+func TestConsumeLogs(t *testing.T) {
+	var received pushRequest
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tenant1", r.Header.Get(tenantIDHeader))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "value", r.Header.Get("X-Custom-Header"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.TenantID = "tenant1"
+	cfg.Headers = map[string]string{"x-custom-header": "value"}
+	cfg.Labels = *testLabelsConfig()
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer exp.Shutdown(context.Background())
+
+	numDropped, err := exp.pushLogData(context.Background(), testLogs())
+	require.NoError(t, err)
+	assert.Equal(t, 1, numDropped)
+	require.Len(t, received.Streams, 2)
+	assert.Equal(t, map[string]string{"job": "api", "level": "info"}, received.Streams[0].Labels)
+	assert.Equal(t, [][2]string{{"1000", "first"}, {"3000", "third"}}, received.Streams[0].Entries)
+
+	status = http.StatusBadRequest
+	err = exp.ConsumeLogs(context.Background(), testLogs())
+	assert.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	status = http.StatusServiceUnavailable
+	err = exp.ConsumeLogs(context.Background(), testLogs())
+	assert.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	numDropped, err = exp.pushLogData(context.Background(), testLogs())
+	assert.Error(t, err)
+	assert.Equal(t, testLogs().LogRecordCount(), numDropped)
+}
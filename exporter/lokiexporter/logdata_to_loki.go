@@ -0,0 +1,197 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// pushRequest is the body of a request to the Loki push API.
+type pushRequest struct {
+	Streams []*stream `json:"streams"`
+}
+
+// stream is a Loki stream: the entries sharing a label set. The entries are
+// pairs of a Unix nanoseconds timestamp and a log line.
+type stream struct {
+	Labels  map[string]string `json:"stream"`
+	Entries [][2]string       `json:"values"`
+
+	timestamps []int64
+}
+
+// logDataToLoki converts the logs to Loki streams, the labels of a record
+// being its configured resource and record attributes. It returns the number
+// of records dropped because they have none of them, Loki requiring labels.
+func logDataToLoki(ld pdata.Logs, labelsConfig *LabelsConfig) (*pushRequest, int) {
+	req := &pushRequest{}
+	streams := make(map[string]*stream)
+	numDropped := 0
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		var resourceLabels map[string]string
+		if !rl.Resource().IsNil() {
+			resourceLabels = attributesToLabels(rl.Resource().Attributes(), labelsConfig.Resource, nil)
+		}
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+				labels := attributesToLabels(lr.Attributes(), labelsConfig.Record, resourceLabels)
+				if len(labels) == 0 {
+					numDropped++
+					continue
+				}
+
+				key := streamKey(labels)
+				s, ok := streams[key]
+				if !ok {
+					s = &stream{Labels: labels}
+					streams[key] = s
+					req.Streams = append(req.Streams, s)
+				}
+				ts := int64(lr.Timestamp())
+				if ts == 0 {
+					ts = time.Now().UnixNano()
+				}
+				s.timestamps = append(s.timestamps, ts)
+				s.Entries = append(s.Entries, [2]string{strconv.FormatInt(ts, 10), bodyToLine(lr.Body())})
+			}
+		}
+	}
+
+	// Loki rejects the entries older than the last one of their stream.
+	for _, s := range req.Streams {
+		sort.Stable(byTimestamp{s})
+	}
+	return req, numDropped
+}
+
+// attributesToLabels returns the labels of the configured attributes, added
+// to a copy of base.
+func attributesToLabels(attrs pdata.AttributeMap, labelConfigs []LabelConfig, base map[string]string) map[string]string {
+	labels := make(map[string]string, len(base)+len(labelConfigs))
+	for k, v := range base {
+		labels[k] = v
+	}
+	for _, lc := range labelConfigs {
+		if v, ok := attrs.Get(lc.Attribute); ok {
+			if s := attributeValueToString(v); s != "" {
+				labels[lc.Label] = s
+			}
+		}
+	}
+	return labels
+}
+
+// streamKey returns a key identifying a label set.
+func streamKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[k]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+func bodyToLine(body pdata.AttributeValue) string {
+	if body.Type() == pdata.AttributeValueMAP {
+		line, err := json.Marshal(attributeMapToInterface(body.MapVal()))
+		if err != nil {
+			return ""
+		}
+		return string(line)
+	}
+	return attributeValueToString(body)
+}
+
+func attributeValueToString(v pdata.AttributeValue) string {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return strconv.FormatInt(v.IntVal(), 10)
+	case pdata.AttributeValueDOUBLE:
+		return strconv.FormatFloat(v.DoubleVal(), 'g', -1, 64)
+	case pdata.AttributeValueBOOL:
+		return strconv.FormatBool(v.BoolVal())
+	}
+	return ""
+}
+
+func attributeMapToInterface(attrs pdata.AttributeMap) map[string]interface{} {
+	m := make(map[string]interface{}, attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		switch v.Type() {
+		case pdata.AttributeValueSTRING:
+			m[k] = v.StringVal()
+		case pdata.AttributeValueINT:
+			m[k] = v.IntVal()
+		case pdata.AttributeValueDOUBLE:
+			m[k] = v.DoubleVal()
+		case pdata.AttributeValueBOOL:
+			m[k] = v.BoolVal()
+		case pdata.AttributeValueMAP:
+			m[k] = attributeMapToInterface(v.MapVal())
+		}
+	})
+	return m
+}
+
+// byTimestamp sorts the entries of a stream by timestamp.
+type byTimestamp struct {
+	*stream
+}
+
+func (b byTimestamp) Len() int {
+	return len(b.Entries)
+}
+
+func (b byTimestamp) Less(i, j int) bool {
+	return b.timestamps[i] < b.timestamps[j]
+}
+
+func (b byTimestamp) Swap(i, j int) {
+	b.Entries[i], b.Entries[j] = b.Entries[j], b.Entries[i]
+	b.timestamps[i], b.timestamps[j] = b.timestamps[j], b.timestamps[i]
+}
@@ -21,4 +21,7 @@ type Config struct {
 	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 	// DSN to report transaction to Sentry. If the DSN is not set, no trace will be sent to Sentry.
 	DSN string `mapstructure:"dsn"`
+	// Environment the transactions and errors are reported in, e.g. "production". If not set, Sentry
+	// reports them without an environment.
+	Environment string `mapstructure:"environment"`
 }
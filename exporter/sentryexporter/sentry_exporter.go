@@ -32,6 +32,13 @@ const (
 	sentryStatusUnknown       = "unknown"
 	otelSentryExporterVersion = "0.0.1"
 	otelSentryExporterName    = "sentry.opentelemetry"
+
+	// Span event name and attributes recording an exception, per the semantic conventions.
+	// See https://github.com/open-telemetry/opentelemetry-specification/blob/master/specification/trace/semantic_conventions/exceptions.md.
+	exceptionEventName           = "exception"
+	attributeExceptionType       = "exception.type"
+	attributeExceptionMessage    = "exception.message"
+	attributeExceptionStacktrace = "exception.stacktrace"
 )
 
 // canonicalCodes maps OpenTelemetry span codes to Sentry's span status.
@@ -58,7 +65,8 @@ var canonicalCodes = [...]string{
 
 // SentryExporter defines the Sentry Exporter.
 type SentryExporter struct {
-	transport transport
+	transport   transport
+	environment string
 }
 
 // pushTraceData takes an incoming OpenTelemetry trace, converts them into Sentry spans and transactions
@@ -77,6 +85,8 @@ func (s *SentryExporter) pushTraceData(_ context.Context, td pdata.Traces) (drop
 	idMap := make(map[string]string)
 	// Maps root span id to a transaction.
 	transactionMap := make(map[string]*sentry.Event)
+	// Errors from the exceptions recorded on the spans.
+	var exceptionEvents []*sentry.Event
 
 	for i := 0; i < resourceSpans.Len(); i++ {
 		rs := resourceSpans.At(i)
@@ -103,6 +113,7 @@ func (s *SentryExporter) pushTraceData(_ context.Context, td pdata.Traces) (drop
 				}
 
 				sentrySpan := convertToSentrySpan(otelSpan, library, resourceTags)
+				exceptionEvents = append(exceptionEvents, exceptionEventsFromSpan(otelSpan, sentrySpan)...)
 
 				// If a span is a root span, we consider it the start of a Sentry transaction.
 				// We should then create a new transaction for that root span, and keep track of it.
@@ -124,17 +135,24 @@ func (s *SentryExporter) pushTraceData(_ context.Context, td pdata.Traces) (drop
 		}
 	}
 
-	if len(transactionMap) == 0 {
-		return 0, nil
+	events := exceptionEvents
+	if len(transactionMap) != 0 {
+		// After the first pass through, we can't necessarily make the assumption we have not associated all
+		// the spans with a transaction. As such, we must classify the remaining spans as orphans or not.
+		orphanSpans := classifyAsOrphanSpans(maybeOrphanSpans, len(maybeOrphanSpans)+1, idMap, transactionMap)
+
+		events = append(generateTransactions(transactionMap, orphanSpans), events...)
 	}
 
-	// After the first pass through, we can't necessarily make the assumption we have not associated all
-	// the spans with a transaction. As such, we must classify the remaining spans as orphans or not.
-	orphanSpans := classifyAsOrphanSpans(maybeOrphanSpans, len(maybeOrphanSpans)+1, idMap, transactionMap)
+	if len(events) == 0 {
+		return 0, nil
+	}
 
-	transactions := generateTransactions(transactionMap, orphanSpans)
+	for _, event := range events {
+		event.Environment = s.environment
+	}
 
-	s.transport.SendTransactions(transactions)
+	s.transport.SendEvents(events)
 
 	return 0, nil
 }
@@ -368,6 +386,59 @@ func transactionFromSpan(span *sentry.Span) *sentry.Event {
 	return transaction
 }
 
+// exceptionEventsFromSpan converts the exceptions recorded as events of a span to Sentry errors
+// associated with the span. Events without the exception type nor message are skipped, as the
+// semantic conventions require at least one of them.
+func exceptionEventsFromSpan(span pdata.Span, sentrySpan *sentry.Span) []*sentry.Event {
+	spanEvents := span.Events()
+	var events []*sentry.Event
+
+	for i := 0; i < spanEvents.Len(); i++ {
+		spanEvent := spanEvents.At(i)
+		if spanEvent.IsNil() || spanEvent.Name() != exceptionEventName {
+			continue
+		}
+
+		attrs := spanEvent.Attributes()
+		exception := sentry.Exception{}
+		if exceptionType, ok := attrs.Get(attributeExceptionType); ok {
+			exception.Type = exceptionType.StringVal()
+		}
+		if message, ok := attrs.Get(attributeExceptionMessage); ok {
+			exception.Value = message.StringVal()
+		}
+		if exception.Type == "" && exception.Value == "" {
+			continue
+		}
+
+		event := sentry.NewEvent()
+
+		event.Level = sentry.LevelError
+		event.Exception = []sentry.Exception{exception}
+		if stacktrace, ok := attrs.Get(attributeExceptionStacktrace); ok {
+			event.Extra[attributeExceptionStacktrace] = stacktrace.StringVal()
+		}
+
+		event.Contexts["trace"] = sentry.TraceContext{
+			TraceID: sentrySpan.TraceID,
+			SpanID:  sentrySpan.SpanID,
+			Op:      sentrySpan.Op,
+			Status:  sentrySpan.Status,
+		}
+
+		event.Sdk.Name = otelSentryExporterName
+		event.Sdk.Version = otelSentryExporterVersion
+
+		event.Tags = sentrySpan.Tags
+		event.Timestamp = unixNanoToTime(spanEvent.Timestamp())
+		event.Transaction = sentrySpan.Description
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
 // CreateSentryExporter returns a new Sentry Exporter.
 func CreateSentryExporter(config *Config) (component.TraceExporter, error) {
 	transport := newSentryTransport()
@@ -376,7 +447,8 @@ func CreateSentryExporter(config *Config) (component.TraceExporter, error) {
 	})
 
 	s := &SentryExporter{
-		transport: transport,
+		transport:   transport,
+		environment: config.Environment,
 	}
 
 	return exporterhelper.NewTraceExporter(
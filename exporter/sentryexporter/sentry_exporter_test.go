@@ -504,12 +504,12 @@ func TestGenerateTransactions(t *testing.T) {
 }
 
 type mockTransport struct {
-	called       bool
-	transactions []*sentry.Event
+	called bool
+	events []*sentry.Event
 }
 
-func (t *mockTransport) SendTransactions(transactions []*sentry.Event) {
-	t.transactions = transactions
+func (t *mockTransport) SendEvents(events []*sentry.Event) {
+	t.events = events
 	t.called = true
 }
 
@@ -518,6 +518,45 @@ func (t *mockTransport) Flush(ctx context.Context) bool {
 	return true
 }
 
+func TestExceptionEventsFromSpan(t *testing.T) {
+	testSpan := pdata.NewSpan()
+	testSpan.InitEmpty()
+	testSpan.SetTraceID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	testSpan.SetSpanID([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	spanEvents := testSpan.Events()
+	spanEvents.Resize(3)
+
+	exceptionEvent := spanEvents.At(0)
+	exceptionEvent.SetName(exceptionEventName)
+	exceptionEvent.SetTimestamp(123)
+	exceptionEvent.Attributes().InsertString(attributeExceptionType, "ValueError")
+	exceptionEvent.Attributes().InsertString(attributeExceptionMessage, "invalid literal")
+	exceptionEvent.Attributes().InsertString(attributeExceptionStacktrace, "Traceback (most recent call last)")
+
+	emptyExceptionEvent := spanEvents.At(1)
+	emptyExceptionEvent.SetName(exceptionEventName)
+
+	otherEvent := spanEvents.At(2)
+	otherEvent.SetName("message")
+	otherEvent.Attributes().InsertString(attributeExceptionMessage, "not an exception")
+
+	sentrySpan := convertToSentrySpan(testSpan, pdata.NewInstrumentationLibrary(), map[string]string{})
+	events := exceptionEventsFromSpan(testSpan, sentrySpan)
+
+	assert.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, sentry.LevelError, event.Level)
+	assert.Equal(t, []sentry.Exception{{Type: "ValueError", Value: "invalid literal"}}, event.Exception)
+	assert.Equal(t, "Traceback (most recent call last)", event.Extra[attributeExceptionStacktrace])
+	assert.Equal(t, sentry.TraceContext{
+		TraceID: "01020304050607080807060504030201",
+		SpanID:  "0102030405060708",
+		Status:  sentrySpan.Status,
+	}, event.Contexts["trace"])
+	assert.Equal(t, unixNanoToTime(123), event.Timestamp)
+}
+
 type PushTraceDataTestCase struct {
 	testName string
 	// input
@@ -576,11 +615,15 @@ func TestPushTraceData(t *testing.T) {
 				called: false,
 			}
 			s := &SentryExporter{
-				transport: transport,
+				transport:   transport,
+				environment: "production",
 			}
 
 			s.pushTraceData(context.Background(), test.td)
 			assert.Equal(t, test.called, transport.called)
+			for _, event := range transport.events {
+				assert.Equal(t, "production", event.Environment)
+			}
 		})
 	}
 }
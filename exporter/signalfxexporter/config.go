@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -62,6 +63,10 @@ type Config struct {
 	// Whether to log dimension updates being sent to SignalFx.
 	LogDimensionUpdates bool `mapstructure:"log_dimension_updates"`
 
+	// DimensionClient configures the client sending the Kubernetes metadata
+	// received from the k8s_cluster receiver to the SignalFx dimensions API.
+	DimensionClient DimensionClientConfig `mapstructure:"dimension_client"`
+
 	splunk.AccessTokenPassthroughConfig `mapstructure:",squash"`
 
 	// SendCompatibleMetrics specifies if metrics must be sent in a format backward-compatible with
@@ -71,6 +76,29 @@ type Config struct {
 	// TranslationRules defines a set of rules how to translate metrics to a SignalFx compatible format
 	// If not provided explicitly, the rules defined in translations/config/default.yaml are used.
 	TranslationRules []translation.Rule `mapstructure:"translation_rules"`
+
+	// ExcludeMetrics defines the datapoints that must not be sent to SignalFx, matched after the translation.
+	// If not provided explicitly and SendCompatibleMetrics is set, the filters defined in
+	// translation.DefaultExcludeMetricsYaml are used.
+	ExcludeMetrics []translation.MetricFilter `mapstructure:"exclude_metrics"`
+
+	// IncludeMetrics defines the datapoints that must be sent to SignalFx even if they match ExcludeMetrics,
+	// intended to override the default exclusion filters.
+	IncludeMetrics []translation.MetricFilter `mapstructure:"include_metrics"`
+}
+
+// DimensionClientConfig defines how the dimension properties and tags
+// updates are sent to SignalFx.
+type DimensionClientConfig struct {
+	// SendDelay is the time to wait before sending a dimension update, the
+	// updates of the same dimension received meanwhile are merged and sent
+	// once. It is rounded down to the second. The default value is 10 seconds.
+	SendDelay time.Duration `mapstructure:"send_delay"`
+
+	// MaxBuffered is the maximum number of dimension updates waiting to be
+	// sent, the updates received once reached are dropped. The default value
+	// is 10000.
+	MaxBuffered int `mapstructure:"max_buffered"`
 }
 
 func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
@@ -83,6 +111,8 @@ func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
 		return nil, fmt.Errorf("invalid \"ingest_url\": %v", err)
 	}
 
+	eventURL := getEventURL(ingestURL)
+
 	apiURL, err := cfg.getAPIURL()
 	if err != nil {
 		return nil, fmt.Errorf("invalid \"api_url\": %v", err)
@@ -92,6 +122,14 @@ func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
 		cfg.Timeout = 5 * time.Second
 	}
 
+	if cfg.DimensionClient.SendDelay == 0 {
+		cfg.DimensionClient.SendDelay = defaultDimClientSendDelay
+	}
+
+	if cfg.DimensionClient.MaxBuffered == 0 {
+		cfg.DimensionClient.MaxBuffered = defaultDimClientMaxBuffered
+	}
+
 	var metricTranslator *translation.MetricTranslator
 	if cfg.SendCompatibleMetrics {
 		metricTranslator, err = translation.NewMetricTranslator(cfg.TranslationRules)
@@ -100,13 +138,23 @@ func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
 		}
 	}
 
+	dpFilter, err := translation.NewDataPointFilter(cfg.ExcludeMetrics, cfg.IncludeMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("invalid \"exclude_metrics\" or \"include_metrics\": %v", err)
+	}
+
 	return &exporterOptions{
 		ingestURL:        ingestURL,
+		eventURL:         eventURL,
 		apiURL:           apiURL,
 		httpTimeout:      cfg.Timeout,
 		token:            cfg.AccessToken,
 		logDimUpdate:     cfg.LogDimensionUpdates,
 		metricTranslator: metricTranslator,
+		dpFilter:         dpFilter,
+
+		dimClientSendDelay:   cfg.DimensionClient.SendDelay,
+		dimClientMaxBuffered: cfg.DimensionClient.MaxBuffered,
 	}, nil
 }
 
@@ -124,6 +172,14 @@ func (cfg *Config) validateConfig() error {
 		return errors.New("cannot have a negative \"timeout\"")
 	}
 
+	if cfg.DimensionClient.SendDelay < 0 {
+		return errors.New("cannot have a negative \"send_delay\" in \"dimension_client\"")
+	}
+
+	if cfg.DimensionClient.MaxBuffered < 0 {
+		return errors.New("cannot have a negative \"max_buffered\" in \"dimension_client\"")
+	}
+
 	return nil
 }
 
@@ -147,6 +203,17 @@ func (cfg *Config) getIngestURL() (out *url.URL, err error) {
 	return out, err
 }
 
+// getEventURL returns the URL of the events ingest endpoint, derived from the
+// datapoint one by replacing its "v2/datapoint" path suffix by "v2/event".
+func getEventURL(ingestURL *url.URL) *url.URL {
+	out := *ingestURL
+	out.Path = path.Join(strings.TrimSuffix(out.Path, "v2/datapoint"), "v2/event")
+	if !strings.HasPrefix(out.Path, "/") {
+		out.Path = "/" + out.Path
+	}
+	return &out
+}
+
 func (cfg *Config) getAPIURL() (*url.URL, error) {
 	if cfg.APIURL == "" {
 		return url.Parse(fmt.Sprintf("https://api.%s.signalfx.com", cfg.Realm))
@@ -77,6 +77,24 @@ func TestLoadConfig(t *testing.T) {
 					"k8s.cluster.name": "kubernetes_cluster",
 				},
 			},
+			{
+				Action:            translation.ActionDropDimensions,
+				MetricNames:       map[string]bool{"k8s.pod.network.io": true},
+				WithoutDimensions: []string{"interface"},
+			},
+		},
+		ExcludeMetrics: []translation.MetricFilter{
+			{
+				MetricNames: []string{"/^cpu\\..*/"},
+				Dimensions:  map[string]string{"host": "/^test-.*/"},
+			},
+		},
+		IncludeMetrics: []translation.MetricFilter{
+			{MetricNames: []string{"cpu.utilization"}},
+		},
+		DimensionClient: DimensionClientConfig{
+			SendDelay:   5 * time.Second,
+			MaxBuffered: 100,
 		},
 	}
 	assert.Equal(t, &expectedCfg, e1)
@@ -97,6 +115,8 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		Headers               map[string]string
 		SendCompatibleMetrics bool
 		TranslationRules      []translation.Rule
+		ExcludeMetrics        []translation.MetricFilter
+		DimensionClient       DimensionClientConfig
 	}
 	tests := []struct {
 		name    string
@@ -118,6 +138,11 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 					Host:   "ingest.us1.signalfx.com",
 					Path:   "/v2/datapoint",
 				},
+				eventURL: &url.URL{
+					Scheme: "https",
+					Host:   "ingest.us1.signalfx.com",
+					Path:   "/v2/event",
+				},
 				apiURL: &url.URL{
 					Scheme: "https",
 					Host:   "api.us1.signalfx.com",
@@ -125,6 +150,9 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 				},
 				httpTimeout: 5 * time.Second,
 				token:       "access_token",
+
+				dimClientSendDelay:   10 * time.Second,
+				dimClientMaxBuffered: 10000,
 			},
 			wantErr: false,
 		},
@@ -141,12 +169,20 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 					Host:   "ingest.us0.signalfx.com",
 					Path:   "/v2/datapoint",
 				},
+				eventURL: &url.URL{
+					Scheme: "https",
+					Host:   "ingest.us0.signalfx.com",
+					Path:   "/v2/event",
+				},
 				apiURL: &url.URL{
 					Scheme: "https",
 					Host:   "api.us0.signalfx.com",
 				},
 				httpTimeout: 10 * time.Second,
 				token:       "access_token",
+
+				dimClientSendDelay:   10 * time.Second,
+				dimClientMaxBuffered: 10000,
 			},
 			wantErr: false,
 		},
@@ -201,6 +237,28 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Test negative dimension client send delay",
+			fields: fields{
+				Realm:           "us0",
+				AccessToken:     "access_token",
+				DimensionClient: DimensionClientConfig{SendDelay: -time.Second},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test invalid exclude metrics",
+			fields: fields{
+				Realm:       "us0",
+				AccessToken: "access_token",
+				ExcludeMetrics: []translation.MetricFilter{
+					{MetricNames: []string{"/[/"}},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -214,6 +272,8 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 				Headers:               tt.fields.Headers,
 				SendCompatibleMetrics: tt.fields.SendCompatibleMetrics,
 				TranslationRules:      tt.fields.TranslationRules,
+				ExcludeMetrics:        tt.fields.ExcludeMetrics,
+				DimensionClient:       tt.fields.DimensionClient,
 			}
 			got, err := cfg.getOptionsFromConfig()
 			if (err != nil) != tt.wantErr {
@@ -224,3 +284,21 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEventURL(t *testing.T) {
+	tests := []struct {
+		ingestURL string
+		want      string
+	}{
+		{ingestURL: "https://ingest.us0.signalfx.com/v2/datapoint", want: "https://ingest.us0.signalfx.com/v2/event"},
+		{ingestURL: "http://localhost:9943", want: "http://localhost:9943/v2/event"},
+		{ingestURL: "http://localhost:9943/proxy/v2/datapoint", want: "http://localhost:9943/proxy/v2/event"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ingestURL, func(t *testing.T) {
+			ingestURL, err := url.Parse(tt.ingestURL)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, getEventURL(ingestURL).String())
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxexporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/splunk"
+)
+
+// sfxEventClient sends the log records carrying a SignalFx event type to the
+// SignalFx events ingest endpoint.
+type sfxEventClient struct {
+	eventURL               *url.URL
+	headers                map[string]string
+	client                 *http.Client
+	logger                 *zap.Logger
+	zippers                sync.Pool
+	accessTokenPassthrough bool
+}
+
+func (s *sfxEventClient) pushLogsData(ctx context.Context, ld pdata.Logs) (droppedLogRecords int, err error) {
+	var errs []error
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+
+		var events []*sfxpb.Event
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			evs, dropped := translation.LogSliceToSignalFxV2(rl.Resource(), ill.Logs())
+			events = append(events, evs...)
+			droppedLogRecords += dropped
+		}
+
+		if len(events) == 0 {
+			continue
+		}
+
+		if pErr := s.pushEvents(ctx, s.retrieveAccessToken(rl.Resource()), events); pErr != nil {
+			errs = append(errs, pErr)
+			droppedLogRecords += len(events)
+		}
+	}
+
+	return droppedLogRecords, componenterror.CombineErrors(errs)
+}
+
+func (s *sfxEventClient) pushEvents(ctx context.Context, accessToken string, events []*sfxpb.Event) error {
+	body, compressed, err := s.encodeBody(events)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+
+	req, err := http.NewRequest("POST", s.eventURL.String(), body)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req = req.WithContext(ctx)
+
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	if s.accessTokenPassthrough && accessToken != "" {
+		req.Header.Set(splunk.SFxAccessTokenHeader, accessToken)
+	}
+
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	// SignalFx accepts all 2XX codes.
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf(
+			"HTTP %d %q",
+			resp.StatusCode,
+			http.StatusText(resp.StatusCode))
+	}
+
+	return nil
+}
+
+func (s *sfxEventClient) encodeBody(events []*sfxpb.Event) (bodyReader io.Reader, compressed bool, err error) {
+	msg := sfxpb.EventUploadMessage{
+		Events: events,
+	}
+	body, err := msg.Marshal()
+	if err != nil {
+		return nil, false, err
+	}
+	return getReader(&s.zippers, body)
+}
+
+func (s *sfxEventClient) retrieveAccessToken(resource pdata.Resource) string {
+	if resource.IsNil() {
+		return ""
+	}
+	accessToken := ""
+	attrs := resource.Attributes()
+	if v, ok := attrs.Get(splunk.SFxAccessTokenLabel); ok {
+		accessToken = v.StringVal()
+		// Drop internally passed access token in all cases
+		attrs.Delete(splunk.SFxAccessTokenLabel)
+	}
+	return accessToken
+}
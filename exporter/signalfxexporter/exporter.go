@@ -40,15 +40,22 @@ type signalfxExporter struct {
 	logger                 *zap.Logger
 	pushMetricsData        func(ctx context.Context, md consumerdata.MetricsData) (droppedTimeSeries int, err error)
 	pushKubernetesMetadata func(metadata []*collection.KubernetesMetadataUpdate) error
+	// stopDimClient stops sending the dimension updates.
+	stopDimClient context.CancelFunc
 }
 
 type exporterOptions struct {
 	ingestURL        *url.URL
+	eventURL         *url.URL
 	apiURL           *url.URL
 	httpTimeout      time.Duration
 	token            string
 	logDimUpdate     bool
 	metricTranslator *translation.MetricTranslator
+	dpFilter         *translation.DataPointFilter
+
+	dimClientSendDelay   time.Duration
+	dimClientMaxBuffered int
 }
 
 // newSignalFxExporter returns a new SignalFx exporter.
@@ -86,22 +93,22 @@ func newSignalFxExporter(
 		}},
 		accessTokenPassthrough: config.AccessTokenPassthrough,
 		metricTranslator:       options.metricTranslator,
+		dpFilter:               options.dpFilter,
 	}
 
+	dimClientCtx, stopDimClient := context.WithCancel(context.Background())
 	dimClient := dimensions.NewDimensionClient(
-		context.Background(),
+		dimClientCtx,
 		dimensions.DimensionClientOptions{
 			Token:      options.token,
 			APIURL:     options.apiURL,
 			LogUpdates: options.logDimUpdate,
 			Logger:     logger,
-			// Duration to wait between property updates. This might be worth
-			// being made configurable.
-			SendDelay: 10,
+			// Duration to wait between property updates.
+			SendDelay: int(options.dimClientSendDelay / time.Second),
 			// In case of having issues sending dimension updates to SignalFx,
-			// buffer a fixed number of updates. Might also be a good candidate
-			// to make configurable.
-			PropertiesMaxBuffered: 10000,
+			// buffer a fixed number of updates.
+			PropertiesMaxBuffered: options.dimClientMaxBuffered,
 			MetricTranslator:      options.metricTranslator,
 		})
 	dimClient.Start()
@@ -110,6 +117,53 @@ func newSignalFxExporter(
 		logger:                 logger,
 		pushMetricsData:        dpClient.pushMetricsData,
 		pushKubernetesMetadata: dimClient.PushKubernetesMetadata,
+		stopDimClient:          stopDimClient,
+	}, nil
+}
+
+type signalfxEventExporter struct {
+	logger       *zap.Logger
+	pushLogsData func(ctx context.Context, ld pdata.Logs) (droppedLogRecords int, err error)
+}
+
+// newEventExporter returns a new SignalFx exporter sending the log records
+// carrying a SignalFx event type as SignalFx events.
+func newEventExporter(
+	config *Config,
+	logger *zap.Logger,
+) (component.LogsExporter, error) {
+
+	if config == nil {
+		return nil, errors.New("nil config")
+	}
+
+	options, err := config.getOptionsFromConfig()
+	if err != nil {
+		return nil,
+			fmt.Errorf("failed to process %q config: %v", config.Name(), err)
+	}
+
+	headers, err := buildHeaders(config)
+	if err != nil {
+		return nil, err
+	}
+
+	eventClient := &sfxEventClient{
+		eventURL: options.eventURL,
+		headers:  headers,
+		client: &http.Client{
+			Timeout: config.Timeout,
+		},
+		logger: logger,
+		zippers: sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(nil)
+		}},
+		accessTokenPassthrough: config.AccessTokenPassthrough,
+	}
+
+	return signalfxEventExporter{
+		logger:       logger,
+		pushLogsData: eventClient.pushLogsData,
 	}, nil
 }
 
@@ -118,6 +172,9 @@ func (se signalfxExporter) Start(context.Context, component.Host) error {
 }
 
 func (se signalfxExporter) Shutdown(context.Context) error {
+	if se.stopDimClient != nil {
+		se.stopDimClient()
+	}
 	return nil
 }
 
@@ -142,3 +199,24 @@ func (se signalfxExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics)
 func (se signalfxExporter) ConsumeKubernetesMetadata(metadata []*collection.KubernetesMetadataUpdate) error {
 	return se.pushKubernetesMetadata(metadata)
 }
+
+func (se signalfxEventExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (se signalfxEventExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (se signalfxEventExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	ctx = obsreport.StartLogsExportOp(ctx, typeStr)
+
+	droppedLogRecords, err := se.pushLogsData(ctx, ld)
+
+	obsreport.EndLogsExportOp(ctx, ld.LogRecordCount(), droppedLogRecords, err)
+	if droppedLogRecords > 0 {
+		se.logger.Debug("Dropped log records not sent as SignalFx events",
+			zap.Int("dropped_log_records", droppedLogRecords))
+	}
+	return err
+}
@@ -30,9 +30,11 @@ import (
 	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/pdatautil"
 	"go.opentelemetry.io/collector/testutil/metricstestutil"
 	"go.uber.org/zap"
@@ -59,6 +61,10 @@ func TestNew(t *testing.T) {
 	// This is expected to fail.
 	err = got.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{{}}))
 	assert.Error(t, err)
+
+	// Shutdown stops the dimension client.
+	assert.NotNil(t, got.(signalfxExporter).stopDimClient)
+	assert.NoError(t, got.Shutdown(context.Background()))
 }
 
 func TestConsumeMetricsData(t *testing.T) {
@@ -242,6 +248,63 @@ func TestConsumeMetricsDataWithAccessTokenPassthrough(t *testing.T) {
 	}
 }
 
+func TestConsumeLogsAsEvents(t *testing.T) {
+	fromHeaders := "AccessTokenFromClientHeaders"
+	fromAttributes := "AccessTokenFromAttribute"
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().InitEmpty()
+	rl.Resource().Attributes().InsertString("com.splunk.signalfx.access_token", fromAttributes)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(2)
+	logs.At(0).SetTimestamp(pdata.TimestampUnixNano(time.Now().UnixNano()))
+	logs.At(0).Attributes().InsertString("com.splunk.signalfx.event_type", "deploy")
+	logs.At(0).Attributes().InsertString("service", "test")
+	logs.At(1).SetName("not an event")
+
+	var received sfxpb.EventUploadMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/event", r.URL.Path)
+		assert.Equal(t, fromAttributes, r.Header.Get("x-sf-token"))
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, received.Unmarshal(body))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	eventClient := &sfxEventClient{
+		eventURL: getEventURL(serverURL),
+		headers: map[string]string{
+			"X-Sf-Token": fromHeaders,
+		},
+		client: &http.Client{
+			Timeout: 1 * time.Second,
+		},
+		logger: zap.NewNop(),
+		zippers: sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(nil)
+		}},
+		accessTokenPassthrough: true,
+	}
+
+	numDroppedLogRecords, err := eventClient.pushLogsData(context.Background(), ld)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numDroppedLogRecords)
+
+	require.Len(t, received.Events, 1)
+	assert.Equal(t, "deploy", received.Events[0].EventType)
+	assert.Equal(t, []*sfxpb.Dimension{{Key: "service", Value: "test"}}, received.Events[0].Dimensions)
+	_, ok := rl.Resource().Attributes().Get("com.splunk.signalfx.access_token")
+	assert.False(t, ok)
+}
+
 func generateLargeBatch(t *testing.T) *consumerdata.MetricsData {
 	md := &consumerdata.MetricsData{
 		Node: &commonpb.Node{
@@ -34,6 +34,9 @@ const (
 	typeStr = "signalfx"
 
 	defaultHTTPTimeout = time.Second * 5
+
+	defaultDimClientSendDelay   = time.Second * 10
+	defaultDimClientMaxBuffered = 10000
 )
 
 // NewFactory creates a factory for SignalFx exporter.
@@ -41,7 +44,8 @@ func NewFactory() component.ExporterFactory {
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		exporterhelper.WithMetrics(createMetricsExporter))
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
 }
 
 func createDefaultConfig() configmodels.Exporter {
@@ -56,6 +60,10 @@ func createDefaultConfig() configmodels.Exporter {
 		},
 		SendCompatibleMetrics: false,
 		TranslationRules:      nil,
+		DimensionClient: DimensionClientConfig{
+			SendDelay:   defaultDimClientSendDelay,
+			MaxBuffered: defaultDimClientMaxBuffered,
+		},
 	}
 }
 
@@ -73,6 +81,13 @@ func createMetricsExporter(
 		}
 	}
 
+	if expCfg.SendCompatibleMetrics && expCfg.ExcludeMetrics == nil {
+		expCfg.ExcludeMetrics, err = loadDefaultExcludeMetrics()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	exp, err = newSignalFxExporter(expCfg, params.Logger)
 
 	if err != nil {
@@ -82,6 +97,14 @@ func createMetricsExporter(
 	return exp, nil
 }
 
+func createLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	config configmodels.Exporter,
+) (component.LogsExporter, error) {
+	return newEventExporter(config.(*Config), params.Logger)
+}
+
 func loadDefaultTranslationRules() ([]translation.Rule, error) {
 	config := Config{}
 
@@ -95,3 +118,17 @@ func loadDefaultTranslationRules() ([]translation.Rule, error) {
 
 	return config.TranslationRules, nil
 }
+
+func loadDefaultExcludeMetrics() ([]translation.MetricFilter, error) {
+	config := Config{}
+
+	v := otelconfig.NewViper()
+	v.SetConfigType("yaml")
+	v.ReadConfig(strings.NewReader(translation.DefaultExcludeMetricsYaml))
+	err := v.UnmarshalExact(&config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load default exclude metrics: %v", err)
+	}
+
+	return config.ExcludeMetrics, nil
+}
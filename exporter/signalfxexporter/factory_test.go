@@ -49,6 +49,19 @@ func TestCreateMetricsExporter(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateLogsExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	c := cfg.(*Config)
+	c.AccessToken = "access_token"
+	c.Realm = "us0"
+
+	exp, err := factory.CreateLogsExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg)
+	assert.NoError(t, err)
+	require.NotNil(t, exp)
+	assert.NoError(t, exp.Shutdown(context.Background()))
+}
+
 func TestCreateInstanceViaFactory(t *testing.T) {
 	factory := NewFactory()
 
@@ -172,6 +185,32 @@ func TestCreateMetricsExporterWithDefaultTranslaitonRules(t *testing.T) {
 	assert.Equal(t, 27, len(config.TranslationRules))
 	assert.Equal(t, translation.ActionRenameDimensionKeys, config.TranslationRules[0].Action)
 	assert.Equal(t, 32, len(config.TranslationRules[0].Mapping))
+
+	// Validate that default exclude metrics are loaded
+	require.Equal(t, 1, len(config.ExcludeMetrics))
+	assert.Equal(t, 14, len(config.ExcludeMetrics[0].MetricNames))
+}
+
+func TestCreateMetricsExporterWithSpecifiedExcludeMetrics(t *testing.T) {
+	config := &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: configmodels.Type(typeStr),
+			NameVal: typeStr,
+		},
+		AccessToken:           "testToken",
+		Realm:                 "us1",
+		SendCompatibleMetrics: true,
+		ExcludeMetrics: []translation.MetricFilter{
+			{MetricNames: []string{"cpu.idle"}},
+		},
+	}
+
+	te, err := createMetricsExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, config)
+	assert.NoError(t, err)
+	assert.NotNil(t, te)
+
+	// Validate that specified exclude metrics are loaded instead of default ones
+	assert.Equal(t, []translation.MetricFilter{{MetricNames: []string{"cpu.idle"}}}, config.ExcludeMetrics)
 }
 
 func TestCreateMetricsExporterWithSpecifiedTranslaitonRules(t *testing.T) {
@@ -266,6 +305,48 @@ func TestDefaultTranslationRules(t *testing.T) {
 	require.True(t, ok, "container_memory_major_page_faults not found")
 }
 
+func TestDefaultExcludeMetrics(t *testing.T) {
+	rules, err := loadDefaultTranslationRules()
+	require.NoError(t, err)
+	tr, err := translation.NewMetricTranslator(rules)
+	require.NoError(t, err)
+	exclude, err := loadDefaultExcludeMetrics()
+	require.NoError(t, err)
+	filter, err := translation.NewDataPointFilter(exclude, nil)
+	require.NoError(t, err)
+
+	data := md()
+	// add a memory state left untranslated by the default rules
+	memory := data.Metrics[0]
+	memory.Timeseries = append(memory.Timeseries, &metricspb.TimeSeries{
+		LabelValues: []*metricspb.LabelValue{
+			{Value: "unknown", HasValue: true},
+			{Value: "host0", HasValue: true},
+			{Value: "node0", HasValue: true},
+			{Value: "cluster0", HasValue: true},
+		},
+		Points: []*metricspb.Point{{
+			Timestamp: &timestamp.Timestamp{Seconds: 1596000000},
+			Value:     &metricspb.Point_Int64Value{Int64Value: 1e9},
+		}},
+	})
+
+	translated, _ := translation.MetricDataToSignalFxV2(zap.NewNop(), tr, data)
+	var found bool
+	for _, pt := range translated {
+		found = found || pt.Metric == "system.memory.usage"
+	}
+	require.True(t, found, "untranslated system.memory.usage not found")
+
+	metrics := make(map[string]bool)
+	for _, pt := range filter.FilterDataPoints(translated) {
+		metrics[pt.Metric] = true
+	}
+	assert.False(t, metrics["system.memory.usage"], "untranslated system.memory.usage not excluded")
+	assert.True(t, metrics["memory.used"], "memory.used excluded")
+	assert.True(t, metrics["disk_ops.read"], "disk_ops.read excluded")
+}
+
 func md() consumerdata.MetricsData {
 	md := consumerdata.MetricsData{
 		Metrics: []*metricspb.Metric{
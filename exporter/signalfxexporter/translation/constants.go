@@ -311,4 +311,28 @@ translation_rules:
   scale_factors_float:
     memory.utilization: 100
 `
+
+	// DefaultExcludeMetricsYaml defines the default datapoints that are not sent to SignalFx if
+	// config.SendCompatibleMetrics set to true and config.ExcludeMetrics not specified explicitly.
+	// These are the datapoints of host and pod metrics left untranslated by DefaultTranslationRulesYaml,
+	// their data is sent under the SignalFx compatible names. Use config.IncludeMetrics to send them anyway.
+	DefaultExcludeMetricsYaml = `
+exclude_metrics:
+
+- metric_names:
+  - system.cpu.time
+  - system.disk.io
+  - system.disk.merged
+  - system.disk.ops
+  - system.disk.time
+  - system.filesystem.inodes.usage
+  - system.filesystem.usage
+  - system.memory.usage
+  - system.network.dropped_packets
+  - system.network.errors
+  - system.network.io
+  - system.network.packets
+  - k8s.pod.network.errors
+  - k8s.pod.network.io
+`
 )
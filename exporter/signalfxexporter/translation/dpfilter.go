@@ -0,0 +1,171 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+)
+
+// MetricFilter selects datapoints by metric name and dimensions.
+type MetricFilter struct {
+	// MetricNames are the names of the metrics to select. A name enclosed in slashes
+	// is a regular expression, e.g. /^system\..*/. All the metrics are selected if empty.
+	MetricNames []string `mapstructure:"metric_names"`
+
+	// Dimensions are the dimensions a datapoint must all have to be selected. A value
+	// enclosed in slashes is a regular expression.
+	Dimensions map[string]string `mapstructure:"dimensions"`
+}
+
+// DataPointFilter drops the datapoints selected by one of the exclude filters
+// unless they are also selected by one of the include filters.
+type DataPointFilter struct {
+	exclude []*dataPointMatcher
+	include []*dataPointMatcher
+}
+
+// NewDataPointFilter returns a filter for the given exclude and include filters,
+// nil if there is no exclude filter.
+func NewDataPointFilter(exclude []MetricFilter, include []MetricFilter) (*DataPointFilter, error) {
+	if len(exclude) == 0 {
+		return nil, nil
+	}
+	excludeMatchers, err := newDataPointMatchers(exclude)
+	if err != nil {
+		return nil, err
+	}
+	includeMatchers, err := newDataPointMatchers(include)
+	if err != nil {
+		return nil, err
+	}
+	return &DataPointFilter{exclude: excludeMatchers, include: includeMatchers}, nil
+}
+
+// FilterDataPoints returns the datapoints that are not excluded, reusing the given slice.
+func (f *DataPointFilter) FilterDataPoints(dps []*sfxpb.DataPoint) []*sfxpb.DataPoint {
+	if f == nil {
+		return dps
+	}
+	result := dps[:0]
+	for _, dp := range dps {
+		if f.excluded(dp) {
+			continue
+		}
+		result = append(result, dp)
+	}
+	return result
+}
+
+func (f *DataPointFilter) excluded(dp *sfxpb.DataPoint) bool {
+	if !anyMatches(f.exclude, dp) {
+		return false
+	}
+	return !anyMatches(f.include, dp)
+}
+
+func anyMatches(matchers []*dataPointMatcher, dp *sfxpb.DataPoint) bool {
+	for _, m := range matchers {
+		if m.matches(dp) {
+			return true
+		}
+	}
+	return false
+}
+
+type dataPointMatcher struct {
+	metricNames *stringMatcher
+	dimensions  map[string]*stringMatcher
+}
+
+func newDataPointMatchers(filters []MetricFilter) ([]*dataPointMatcher, error) {
+	matchers := make([]*dataPointMatcher, 0, len(filters))
+	for _, f := range filters {
+		if len(f.MetricNames) == 0 && len(f.Dimensions) == 0 {
+			return nil, errors.New("a metric filter requires \"metric_names\" or \"dimensions\"")
+		}
+		m := &dataPointMatcher{dimensions: make(map[string]*stringMatcher, len(f.Dimensions))}
+		var err error
+		if len(f.MetricNames) > 0 {
+			if m.metricNames, err = newStringMatcher(f.MetricNames); err != nil {
+				return nil, err
+			}
+		}
+		for k, v := range f.Dimensions {
+			if m.dimensions[k], err = newStringMatcher([]string{v}); err != nil {
+				return nil, err
+			}
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+func (m *dataPointMatcher) matches(dp *sfxpb.DataPoint) bool {
+	if m.metricNames != nil && !m.metricNames.matches(dp.Metric) {
+		return false
+	}
+	for k, vm := range m.dimensions {
+		var found bool
+		for _, d := range dp.Dimensions {
+			if d.Key == k && vm.matches(d.Value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// stringMatcher matches strings against a set of exact values and regular expressions.
+type stringMatcher struct {
+	values  map[string]bool
+	regexps []*regexp.Regexp
+}
+
+func newStringMatcher(patterns []string) (*stringMatcher, error) {
+	m := &stringMatcher{values: make(map[string]bool)}
+	for _, p := range patterns {
+		if len(p) > 1 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+			re, err := regexp.Compile(p[1 : len(p)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %v", p, err)
+			}
+			m.regexps = append(m.regexps, re)
+			continue
+		}
+		m.values[p] = true
+	}
+	return m, nil
+}
+
+func (m *stringMatcher) matches(s string) bool {
+	if m.values[s] {
+		return true
+	}
+	for _, re := range m.regexps {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
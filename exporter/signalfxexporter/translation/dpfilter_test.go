@@ -0,0 +1,114 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"testing"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFilterTestDataPoint(metric string, dims map[string]string) *sfxpb.DataPoint {
+	dp := &sfxpb.DataPoint{Metric: metric}
+	for k, v := range dims {
+		dp.Dimensions = append(dp.Dimensions, &sfxpb.Dimension{Key: k, Value: v})
+	}
+	return dp
+}
+
+func TestDataPointFilter(t *testing.T) {
+	filter, err := NewDataPointFilter(
+		[]MetricFilter{
+			{MetricNames: []string{"system.cpu.time", `/^k8s\.pod\..*/`}},
+			{Dimensions: map[string]string{"env": "/^(dev|test)$/", "team": "infra"}},
+		},
+		[]MetricFilter{
+			{MetricNames: []string{"k8s.pod.network.io"}, Dimensions: map[string]string{"direction": "receive"}},
+		},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		dp       *sfxpb.DataPoint
+		excluded bool
+	}{
+		{
+			name:     "exact_name",
+			dp:       newFilterTestDataPoint("system.cpu.time", nil),
+			excluded: true,
+		},
+		{
+			name:     "regexp_name",
+			dp:       newFilterTestDataPoint("k8s.pod.network.errors", nil),
+			excluded: true,
+		},
+		{
+			name:     "no_match",
+			dp:       newFilterTestDataPoint("cpu.utilization", map[string]string{"env": "dev"}),
+			excluded: false,
+		},
+		{
+			name:     "dimensions",
+			dp:       newFilterTestDataPoint("cpu.utilization", map[string]string{"env": "test", "team": "infra"}),
+			excluded: true,
+		},
+		{
+			name:     "included",
+			dp:       newFilterTestDataPoint("k8s.pod.network.io", map[string]string{"direction": "receive"}),
+			excluded: false,
+		},
+		{
+			name:     "not_included",
+			dp:       newFilterTestDataPoint("k8s.pod.network.io", map[string]string{"direction": "transmit"}),
+			excluded: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filter.FilterDataPoints([]*sfxpb.DataPoint{tt.dp})
+			if tt.excluded {
+				assert.Empty(t, got)
+			} else {
+				assert.Equal(t, []*sfxpb.DataPoint{tt.dp}, got)
+			}
+		})
+	}
+}
+
+func TestNilDataPointFilter(t *testing.T) {
+	filter, err := NewDataPointFilter(nil, []MetricFilter{{MetricNames: []string{"cpu.utilization"}}})
+	require.NoError(t, err)
+	require.Nil(t, filter)
+
+	dps := []*sfxpb.DataPoint{newFilterTestDataPoint("cpu.utilization", nil)}
+	assert.Equal(t, dps, filter.FilterDataPoints(dps))
+}
+
+func TestNewDataPointFilterErrors(t *testing.T) {
+	_, err := NewDataPointFilter([]MetricFilter{{}}, nil)
+	assert.EqualError(t, err, `a metric filter requires "metric_names" or "dimensions"`)
+
+	_, err = NewDataPointFilter([]MetricFilter{{MetricNames: []string{"/(/"}}}, nil)
+	assert.Error(t, err)
+
+	_, err = NewDataPointFilter(
+		[]MetricFilter{{MetricNames: []string{"cpu.utilization"}}},
+		[]MetricFilter{{Dimensions: map[string]string{"host": "/[/"}}},
+	)
+	assert.Error(t, err)
+}
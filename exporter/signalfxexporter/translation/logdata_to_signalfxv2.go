@@ -0,0 +1,107 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"strings"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/splunk"
+)
+
+// LogSliceToSignalFxV2 converts the log records carrying the SFxEventType
+// attribute to SignalFx events, the other records are dropped. The string
+// resource and record attributes become dimensions, the attributes prefixed
+// with SFxEventPropertyPrefix become properties.
+func LogSliceToSignalFxV2(
+	resource pdata.Resource,
+	logs pdata.LogSlice,
+) (events []*sfxpb.Event, numDroppedLogRecords int) {
+	var resourceDims []*sfxpb.Dimension
+	if !resource.IsNil() {
+		resource.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+			if k == splunk.SFxAccessTokenLabel || v.Type() != pdata.AttributeValueSTRING {
+				return
+			}
+			resourceDims = append(resourceDims, &sfxpb.Dimension{Key: k, Value: v.StringVal()})
+		})
+	}
+
+	events = make([]*sfxpb.Event, 0, logs.Len())
+	for i := 0; i < logs.Len(); i++ {
+		lr := logs.At(i)
+		if lr.IsNil() {
+			continue
+		}
+
+		eventType, ok := lr.Attributes().Get(splunk.SFxEventType)
+		if !ok || eventType.Type() != pdata.AttributeValueSTRING || eventType.StringVal() == "" {
+			numDroppedLogRecords++
+			continue
+		}
+
+		event := &sfxpb.Event{
+			EventType: eventType.StringVal(),
+			// SignalFx timestamps are in milliseconds.
+			Timestamp:  int64(lr.Timestamp()) / 1e6,
+			Dimensions: make([]*sfxpb.Dimension, 0, len(resourceDims)+lr.Attributes().Len()),
+		}
+		event.Dimensions = append(event.Dimensions, resourceDims...)
+
+		lr.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+			switch {
+			case k == splunk.SFxEventType:
+			case k == splunk.SFxEventCategoryKey:
+				if v.Type() == pdata.AttributeValueINT {
+					category := sfxpb.EventCategory(v.IntVal())
+					event.Category = &category
+				}
+			case strings.HasPrefix(k, splunk.SFxEventPropertyPrefix):
+				if value := attributeToPropertyValue(v); value != nil {
+					event.Properties = append(event.Properties, &sfxpb.Property{
+						Key:   strings.TrimPrefix(k, splunk.SFxEventPropertyPrefix),
+						Value: value,
+					})
+				}
+			case v.Type() == pdata.AttributeValueSTRING:
+				event.Dimensions = append(event.Dimensions, &sfxpb.Dimension{Key: k, Value: v.StringVal()})
+			}
+		})
+
+		events = append(events, event)
+	}
+
+	return events, numDroppedLogRecords
+}
+
+func attributeToPropertyValue(v pdata.AttributeValue) *sfxpb.PropertyValue {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		s := v.StringVal()
+		return &sfxpb.PropertyValue{StrValue: &s}
+	case pdata.AttributeValueINT:
+		i := v.IntVal()
+		return &sfxpb.PropertyValue{IntValue: &i}
+	case pdata.AttributeValueDOUBLE:
+		d := v.DoubleVal()
+		return &sfxpb.PropertyValue{DoubleValue: &d}
+	case pdata.AttributeValueBOOL:
+		b := v.BoolVal()
+		return &sfxpb.PropertyValue{BoolValue: &b}
+	}
+	return nil
+}
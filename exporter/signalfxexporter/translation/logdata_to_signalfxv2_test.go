@@ -0,0 +1,87 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"testing"
+	"time"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestLogSliceToSignalFxV2(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString("k8s.cluster.name", "test")
+	resource.Attributes().InsertString("com.splunk.signalfx.access_token", "token")
+	resource.Attributes().InsertInt("ignored", 1)
+
+	logs := pdata.NewLogSlice()
+	logs.Resize(3)
+
+	lr := logs.At(0)
+	lr.SetName("shutdown")
+	lr.SetTimestamp(pdata.TimestampUnixNano(now.UnixNano()))
+	lr.Attributes().InsertString("com.splunk.signalfx.event_type", "shutdown")
+	lr.Attributes().InsertInt("com.splunk.signalfx.event_category", int64(sfxpb.EventCategory_USER_DEFINED))
+	lr.Attributes().InsertString("host", "localhost")
+	lr.Attributes().InsertString("com.splunk.signalfx.event_properties.str", "s")
+	lr.Attributes().InsertInt("com.splunk.signalfx.event_properties.int", 13)
+	lr.Attributes().InsertDouble("com.splunk.signalfx.event_properties.double", 1.5)
+	lr.Attributes().InsertBool("com.splunk.signalfx.event_properties.bool", true)
+
+	// Not an event.
+	logs.At(1).SetName("log")
+	logs.At(1).Attributes().InsertString("host", "localhost")
+
+	lr = logs.At(2)
+	lr.SetTimestamp(pdata.TimestampUnixNano(now.UnixNano()))
+	lr.Attributes().InsertString("com.splunk.signalfx.event_type", "no_category")
+
+	events, dropped := LogSliceToSignalFxV2(resource, logs)
+	assert.Equal(t, 1, dropped)
+	require.Len(t, events, 2)
+
+	event := events[0]
+	assert.Equal(t, "shutdown", event.EventType)
+	assert.Equal(t, now.UnixNano()/1e6, event.Timestamp)
+	require.NotNil(t, event.Category)
+	assert.Equal(t, sfxpb.EventCategory_USER_DEFINED, *event.Category)
+	assert.ElementsMatch(t, []*sfxpb.Dimension{
+		{Key: "k8s.cluster.name", Value: "test"},
+		{Key: "host", Value: "localhost"},
+	}, event.Dimensions)
+
+	props := map[string]*sfxpb.PropertyValue{}
+	for _, p := range event.Properties {
+		props[p.Key] = p.Value
+	}
+	require.Len(t, props, 4)
+	assert.Equal(t, "s", *props["str"].StrValue)
+	assert.Equal(t, int64(13), *props["int"].IntValue)
+	assert.Equal(t, 1.5, *props["double"].DoubleValue)
+	assert.True(t, *props["bool"].BoolValue)
+
+	event = events[1]
+	assert.Equal(t, "no_category", event.EventType)
+	assert.Nil(t, event.Category)
+	assert.Empty(t, event.Properties)
+	assert.Equal(t, []*sfxpb.Dimension{{Key: "k8s.cluster.name", Value: "test"}}, event.Dimensions)
+}
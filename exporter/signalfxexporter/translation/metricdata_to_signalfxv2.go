@@ -67,8 +67,9 @@ var (
 	totalCountMetricType = &sfxMetricTypeCumulativeCounter
 
 	// Some standard dimension keys.
-	// upper bound dimension key for histogram buckets.
-	upperBoundDimensionKey = "upper_bound"
+	// upper bound dimension key for histogram buckets, named after the
+	// Prometheus "le" (less or equal) label.
+	upperBoundDimensionKey = "le"
 	// quantile dimension key for summary quantiles.
 	quantileDimensionKey = "quantile"
 
@@ -251,10 +252,13 @@ func appendDistributionValues(
 		&distributionValue.Sum)
 
 	// 3. Each histogram bucket is converted to a cumulative counter called
-	// <basename>_bucket and will include a dimension called upper_bound that
+	// <basename>_bucket and will include a dimension called le that
 	// specifies the maximum value in that bucket. This metric specifies the
 	// number of events with a value that is less than or equal to the upper
-	// bound.
+	// bound, i.e. the counts of the OpenCensus buckets, which don't include
+	// the events of the lower buckets, are accumulated.
+	// The min and max of the values are not part of the OpenCensus
+	// distributions so they can't be sent.
 	metricName := sfxBaseDataPoint.Metric + "_bucket"
 	explicitBuckets := distributionValue.BucketOptions.GetExplicit()
 	if explicitBuckets == nil {
@@ -263,15 +267,23 @@ func appendDistributionValues(
 			sfxBaseDataPoint.Metric)
 	}
 	bounds := explicitBuckets.Bounds
+	if len(distributionValue.Buckets) != len(bounds)+1 {
+		return sfxDataPoints, fmt.Errorf(
+			"%d buckets for %d bounds for metric %q",
+			len(distributionValue.Buckets),
+			len(bounds),
+			sfxBaseDataPoint.Metric)
+	}
 	sfxBounds := make([]string, len(bounds)+1)
 	for i := 0; i < len(bounds); i++ {
 		sfxBounds[i] = float64ToDimValue(bounds[i])
 	}
 	sfxBounds[len(sfxBounds)-1] = infinityBoundSFxDimValue
 
+	var cumulativeCount int64
 	for i, bucket := range distributionValue.Buckets {
 
-		// Adding the "upper_bound" dimension.
+		// Adding the "le" dimension.
 		bucketDimensions := make([]*sfxpb.Dimension, len(sfxBaseDataPoint.Dimensions)+1)
 		copy(bucketDimensions, sfxBaseDataPoint.Dimensions)
 
@@ -284,7 +296,8 @@ func appendDistributionValues(
 		bucketDP.Dimensions = bucketDimensions
 		bucketDP.Metric = metricName
 		bucketDP.MetricType = bucketMetricType
-		count := bucket.Count
+		cumulativeCount += bucket.Count
+		count := cumulativeCount
 		bucketDP.Value = sfxpb.Datum{IntValue: &count}
 
 		sfxDataPoints = append(sfxDataPoints, &bucketDP)
@@ -328,19 +328,24 @@ func expectedFromDistribution(
 		doubleSFxDataPoint(metricName, ts, &sfxMetricTypeCumulativeCounter, keys, values,
 			distributionValue.Sum))
 
+	// The bucket counts are cumulative: each bucket includes the counts of
+	// the lower ones.
 	explicitBuckets := distributionValue.BucketOptions.GetExplicit()
+	var count int64
 	for i := 0; i < len(explicitBuckets.Bounds); i++ {
+		count += distributionValue.Buckets[i].Count
 		dps = append(dps,
 			int64SFxDataPoint(metricName+"_bucket", ts, &sfxMetricTypeCumulativeCounter,
 				append(keys, upperBoundDimensionKey),
 				append(values, float64ToDimValue(explicitBuckets.Bounds[i])),
-				distributionValue.Buckets[i].Count))
+				count))
 	}
+	count += distributionValue.Buckets[len(distributionValue.Buckets)-1].Count
 	dps = append(dps,
 		int64SFxDataPoint(metricName+"_bucket", ts, &sfxMetricTypeCumulativeCounter,
 			append(keys, upperBoundDimensionKey),
 			append(values, float64ToDimValue(math.Inf(1))),
-			distributionValue.Buckets[len(distributionValue.Buckets)-1].Count))
+			count))
 	return dps
 }
 
@@ -408,6 +413,28 @@ func Test_InvalidDistribution_NoExplicitBuckets(t *testing.T) {
 	assert.Equal(t, 1, gotNumDroppedTimeSeries)
 }
 
+func Test_InvalidDistribution_BucketsMismatch(t *testing.T) {
+	logger := zap.NewNop()
+	unixSecs := int64(1574092046)
+	unixNSecs := int64(11 * time.Millisecond)
+	tsUnix := time.Unix(unixSecs, unixNSecs)
+	keys := []string{"k0", "k1"}
+	values := []string{"v0", "v1"}
+
+	// Three bounds require four buckets.
+	point := metricstestutil.DistPt(tsUnix, []float64{1, 2, 4}, []int64{4, 2, 3})
+	metricData := consumerdata.MetricsData{
+		Metrics: []*metricspb.Metric{
+			metricstestutil.CumulativeDist("cumulative_distrib", keys, metricstestutil.Timeseries(
+				tsUnix,
+				values,
+				point)),
+		},
+	}
+	_, gotNumDroppedTimeSeries := MetricDataToSignalFxV2(logger, nil, metricData)
+	assert.Equal(t, 1, gotNumDroppedTimeSeries)
+}
+
 func Test_InvalidSummary_NoPercentileValues(t *testing.T) {
 	logger := zap.NewNop()
 	unixSecs := int64(1574092046)
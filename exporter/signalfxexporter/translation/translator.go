@@ -109,6 +109,21 @@ const (
 	// new metric will also get any attributes of the 'memory.used' metric except for its value and metric name.
 	// Currently only integer inputs are handled and only division is supported.
 	ActionCalculateNewMetric Action = "calculate_new_metric"
+
+	// ActionDropDimensions removes the dimensions set in tr.WithoutDimensions from the datapoints.
+	// The rule can be applied only to particular metrics if tr.MetricNames is provided,
+	// otherwise applied to all metrics.
+	// For example, having the following translation rule:
+	// - action: drop_dimensions
+	//   metric_names:
+	//     k8s.pod.network.io: true
+	//   without_dimensions:
+	//   - interface
+	// The following translations will be performed:
+	// k8s.pod.network.io{direction="receive",interface="eth0"} -> k8s.pod.network.io{direction="receive"}
+	// Unlike aggregate_metric, the datapoints are not merged and the resulting datapoints must
+	// stay unique to be accepted by the backend.
+	ActionDropDimensions Action = "drop_dimensions"
 )
 
 type MetricOperator string
@@ -174,11 +189,11 @@ type Rule struct {
 	AggregationMethod AggregationMethod `mapstructure:"aggregation_method"`
 
 	// WithoutDimensions used by "aggregate_metric" translation rule to specify dimensions to be
-	// excluded by aggregation.
+	// excluded by aggregation, and by "drop_dimensions" to specify the dimensions to be removed.
 	WithoutDimensions []string `mapstructure:"without_dimensions"`
 
-	// MetricName is used by "split_metric" translation rule to specify a name
-	// of a metric that will be split.
+	// MetricNames is used by "rename_dimension_keys" and "drop_dimensions" translation rules
+	// to restrict the rule to the listed metrics.
 	MetricNames map[string]bool `mapstructure:"metric_names"`
 
 	Operand1Metric string         `mapstructure:"operand1_metric"`
@@ -281,6 +296,10 @@ func validateTranslationRules(rules []Rule) error {
 			if tr.Operator != MetricOperatorDivision {
 				return fmt.Errorf("invalid operator %q for %q translation rule", tr.Operator, tr.Action)
 			}
+		case ActionDropDimensions:
+			if len(tr.WithoutDimensions) == 0 {
+				return fmt.Errorf("field \"without_dimensions\" is required for %q translation rule", tr.Action)
+			}
 
 		default:
 			return fmt.Errorf("unknown \"action\" value: %q", tr.Action)
@@ -383,6 +402,14 @@ func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoint
 				processedDataPoints = append(processedDataPoints, newPt)
 			}
 
+		case ActionDropDimensions:
+			for _, dp := range processedDataPoints {
+				if len(tr.MetricNames) > 0 && !tr.MetricNames[dp.Metric] {
+					continue
+				}
+				dp.Dimensions = dropDimensions(dp.Dimensions, tr.WithoutDimensions)
+			}
+
 		case ActionAggregateMetric:
 			// NOTE: Based on the usage of TranslateDataPoints we can assume that the datapoints batch []*sfxpb.DataPoint
 			// represents only one metric and all the datapoints can be aggregated together.
@@ -596,6 +623,17 @@ func filterDimensions(dimensions []*sfxpb.Dimension, withoutDimensions []string)
 	return result
 }
 
+// dropDimensions returns a new list of dimensions without the dimensions whose key is in withoutDimensions.
+func dropDimensions(dimensions []*sfxpb.Dimension, withoutDimensions []string) []*sfxpb.Dimension {
+	result := make([]*sfxpb.Dimension, 0, len(dimensions))
+	for _, d := range dimensions {
+		if !dimensionIn(d, withoutDimensions) {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
 // dimensionIn checks if the dimension found in the dimensionsKeysFilter
 func dimensionIn(dimension *sfxpb.Dimension, dimensionsKeysFilter []string) bool {
 	for _, dk := range dimensionsKeysFilter {
@@ -439,6 +439,27 @@ func TestNewMetricTranslator(t *testing.T) {
 			wantError: `fields "metric_name", "operand1_metric", "operand2_metric", and "operator" ` +
 				`are required for "calculate_new_metric" translation rule`,
 		},
+		{
+			name: "drop_dimensions_valid",
+			trs: []Rule{
+				{
+					Action:            ActionDropDimensions,
+					WithoutDimensions: []string{"interface"},
+				},
+			},
+		},
+		{
+			name: "drop_dimensions_invalid",
+			trs: []Rule{
+				{
+					Action: ActionDropDimensions,
+					MetricNames: map[string]bool{
+						"metric": true,
+					},
+				},
+			},
+			wantError: `field "without_dimensions" is required for "drop_dimensions" translation rule`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -528,6 +549,82 @@ func TestTranslateDataPoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "drop_dimensions",
+			trs: []Rule{
+				{
+					Action: ActionDropDimensions,
+					MetricNames: map[string]bool{
+						"metric1": true,
+					},
+					WithoutDimensions: []string{"interface", "absent"},
+				},
+			},
+			dps: []*sfxpb.DataPoint{
+				{
+					Metric:    "metric1",
+					Timestamp: msec,
+					Value: sfxpb.Datum{
+						IntValue: generateIntPtr(13),
+					},
+					MetricType: &gaugeType,
+					Dimensions: []*sfxpb.Dimension{
+						{
+							Key:   "interface",
+							Value: "eth0",
+						},
+						{
+							Key:   "direction",
+							Value: "receive",
+						},
+					},
+				},
+				{
+					Metric:    "metric2",
+					Timestamp: msec,
+					Value: sfxpb.Datum{
+						IntValue: generateIntPtr(13),
+					},
+					MetricType: &gaugeType,
+					Dimensions: []*sfxpb.Dimension{
+						{
+							Key:   "interface",
+							Value: "eth0",
+						},
+					},
+				},
+			},
+			want: []*sfxpb.DataPoint{
+				{
+					Metric:    "metric1",
+					Timestamp: msec,
+					Value: sfxpb.Datum{
+						IntValue: generateIntPtr(13),
+					},
+					MetricType: &gaugeType,
+					Dimensions: []*sfxpb.Dimension{
+						{
+							Key:   "direction",
+							Value: "receive",
+						},
+					},
+				},
+				{
+					Metric:    "metric2",
+					Timestamp: msec,
+					Value: sfxpb.Datum{
+						IntValue: generateIntPtr(13),
+					},
+					MetricType: &gaugeType,
+					Dimensions: []*sfxpb.Dimension{
+						{
+							Key:   "interface",
+							Value: "eth0",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "rename_dimension_keys_filtered_metric",
 			trs: []Rule{
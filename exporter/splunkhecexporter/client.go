@@ -19,19 +19,27 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.uber.org/zap"
 )
 
+// splunkRequestChannelHeader is the header identifying the channel of the
+// requests when the indexer acknowledgement is enabled.
+const splunkRequestChannelHeader = "X-Splunk-Request-Channel"
+
 // client sends the data to the splunk backend.
 type client struct {
 	config  *Config
@@ -41,6 +49,28 @@ type client struct {
 	zippers sync.Pool
 	wg      sync.WaitGroup
 	headers map[string]string
+	// ackURL is the URL of the acknowledgement endpoint, nil if the indexer
+	// acknowledgement is disabled.
+	ackURL *url.URL
+	// channel identifies the client to the acknowledgement endpoint.
+	channel string
+}
+
+// hecResponse is the body of the responses of the HEC endpoint.
+type hecResponse struct {
+	Text  string `json:"text"`
+	Code  int    `json:"code"`
+	AckID *int64 `json:"ackId"`
+}
+
+// ackRequest is the body of the requests to the acknowledgement endpoint.
+type ackRequest struct {
+	Acks []int64 `json:"acks"`
+}
+
+// ackResponse is the body of the responses of the acknowledgement endpoint.
+type ackResponse struct {
+	Acks map[string]bool `json:"acks"`
 }
 
 func (c *client) pushMetricsData(
@@ -58,65 +88,125 @@ func (c *client) pushMetricsData(
 		return numDroppedTimeseries, nil
 	}
 
-	body, compressed, err := encodeBody(&c.zippers, splunkDataPoints, c.config.DisableCompression)
+	numDroppedDataPoints, err := c.pushInBatches(ctx, len(splunkDataPoints), func(i int) interface{} {
+		return splunkDataPoints[i]
+	}, c.config.MaxContentLength)
 	if err != nil {
-		return exporterhelper.NumTimeSeries(md), consumererror.Permanent(err)
+		return exporterhelper.NumTimeSeries(md), err
 	}
 
-	req, err := http.NewRequest("POST", c.url.String(), body)
-	if err != nil {
-		return exporterhelper.NumTimeSeries(md), consumererror.Permanent(err)
-	}
+	return numDroppedTimeseries + numDroppedDataPoints, nil
+}
 
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
-	}
+func (c *client) pushTraceData(
+	ctx context.Context,
+	td consumerdata.TraceData,
+) (droppedSpans int, err error) {
+	c.wg.Add(1)
+	defer c.wg.Done()
 
-	if compressed {
-		req.Header.Set("Content-Encoding", "gzip")
+	splunkEvents, numDroppedSpans := traceDataToSplunk(c.logger, td, c.config)
+	if len(splunkEvents) == 0 {
+		return numDroppedSpans, nil
 	}
 
-	resp, err := c.client.Do(req)
+	numNotSent, err := c.pushInBatches(ctx, len(splunkEvents), func(i int) interface{} {
+		return splunkEvents[i]
+	}, c.config.MaxContentLength)
 	if err != nil {
-		return exporterhelper.NumTimeSeries(md), err
-	}
-
-	io.Copy(ioutil.Discard, resp.Body)
-	resp.Body.Close()
-
-	// Splunk accepts all 2XX codes.
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		err = fmt.Errorf(
-			"HTTP %d %q",
-			resp.StatusCode,
-			http.StatusText(resp.StatusCode))
-		return exporterhelper.NumTimeSeries(md), err
+		return len(td.Spans), err
 	}
 
-	return numDroppedTimeseries, nil
+	return numDroppedSpans + numNotSent, nil
 }
 
-func (c *client) pushTraceData(
+func (c *client) pushLogData(
 	ctx context.Context,
-	td consumerdata.TraceData,
-) (droppedSpans int, err error) {
+	ld pdata.Logs,
+) (numDroppedLogs int, err error) {
 	c.wg.Add(1)
 	defer c.wg.Done()
 
-	splunkEvents, numDroppedSpans := traceDataToSplunk(c.logger, td, c.config)
+	splunkEvents, numDroppedLogs := logDataToSplunk(c.logger, ld, c.config)
 	if len(splunkEvents) == 0 {
-		return numDroppedSpans, nil
+		return numDroppedLogs, nil
 	}
 
-	body, compressed, err := encodeBodyEvents(&c.zippers, splunkEvents, c.config.DisableCompression)
+	numNotSent, err := c.pushInBatches(ctx, len(splunkEvents), func(i int) interface{} {
+		return splunkEvents[i]
+	}, c.config.MaxContentLengthLogs)
+	return numDroppedLogs + numNotSent, err
+}
+
+// pushInBatches encodes the n events returned by event and sends them in
+// requests whose uncompressed body is at most maxContentLength bytes, 0 means
+// no limit. The events larger than maxContentLength are dropped. It returns the
+// number of events not sent.
+func (c *client) pushInBatches(
+	ctx context.Context,
+	n int,
+	event func(i int) interface{},
+	maxContentLength uint,
+) (numNotSent int, err error) {
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	// batchStart is the index of the first event of the batch in buf.
+	batchStart := 0
+	for i := 0; i < n; i++ {
+		batchLen := buf.Len()
+		if err = encoder.Encode(event(i)); err != nil {
+			return numNotSent + n - batchStart, consumererror.Permanent(err)
+		}
+		buf.WriteString("\r\n\r\n")
+
+		if maxContentLength == 0 || uint(buf.Len()) <= maxContentLength {
+			continue
+		}
+
+		// The event doesn't fit in the batch, send the batch without it.
+		encoded := append([]byte(nil), buf.Bytes()[batchLen:]...)
+		buf.Truncate(batchLen)
+		if buf.Len() > 0 {
+			if err = c.postEvents(ctx, buf); err != nil {
+				return numNotSent + n - batchStart, err
+			}
+		}
+		buf.Reset()
+		batchStart = i
+
+		if uint(len(encoded)) > maxContentLength {
+			c.logger.Debug(
+				"Event dropped as it is larger than the max content length",
+				zap.Int("size", len(encoded)),
+				zap.Uint("max_content_length", maxContentLength))
+			numNotSent++
+			batchStart = i + 1
+			continue
+		}
+		buf.Write(encoded)
+	}
+
+	if buf.Len() > 0 {
+		if err = c.postEvents(ctx, buf); err != nil {
+			return numNotSent + n - batchStart, err
+		}
+	}
+
+	return numNotSent, nil
+}
+
+// postEvents sends the encoded events in buf to the HEC endpoint.
+func (c *client) postEvents(ctx context.Context, buf *bytes.Buffer) error {
+	body, compressed, err := getReader(&c.zippers, buf, c.config.DisableCompression)
 	if err != nil {
-		return len(td.Spans), consumererror.Permanent(err)
+		return consumererror.Permanent(err)
 	}
 
 	req, err := http.NewRequest("POST", c.url.String(), body)
 	if err != nil {
-		return len(td.Spans), consumererror.Permanent(err)
+		return consumererror.Permanent(err)
 	}
+	req = req.WithContext(ctx)
 
 	for k, v := range c.headers {
 		req.Header.Set(k, v)
@@ -126,50 +216,109 @@ func (c *client) pushTraceData(
 		req.Header.Set("Content-Encoding", "gzip")
 	}
 
+	if c.ackURL != nil {
+		req.Header.Set(splunkRequestChannelHeader, c.channel)
+	}
+
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return len(td.Spans), err
+		return err
 	}
 
-	io.Copy(ioutil.Discard, resp.Body)
-	resp.Body.Close()
+	if c.ackURL == nil {
+		io.Copy(ioutil.Discard, resp.Body)
+	}
+	defer resp.Body.Close()
 
 	// Splunk accepts all 2XX codes.
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		err = fmt.Errorf(
+		return fmt.Errorf(
 			"HTTP %d %q",
 			resp.StatusCode,
 			http.StatusText(resp.StatusCode))
-		return len(td.Spans), err
 	}
 
-	return numDroppedSpans, nil
+	if c.ackURL == nil {
+		return nil
+	}
+
+	var hecResp hecResponse
+	if err = json.NewDecoder(resp.Body).Decode(&hecResp); err != nil {
+		return fmt.Errorf("failed to decode the HEC response: %v", err)
+	}
+	if hecResp.AckID == nil {
+		return consumererror.Permanent(
+			errors.New("no ackId in the HEC response, indexer acknowledgement must be enabled on the token"))
+	}
+
+	return c.waitForAck(ctx, *hecResp.AckID)
 }
 
-func encodeBodyEvents(zippers *sync.Pool, evs []*splunkEvent, disableCompression bool) (bodyReader io.Reader, compressed bool, err error) {
-	buf := new(bytes.Buffer)
-	encoder := json.NewEncoder(buf)
-	for _, e := range evs {
-		err := encoder.Encode(e)
+// waitForAck polls the acknowledgement endpoint until the request identified
+// by ackID is acknowledged, or the ack timeout expires.
+func (c *client) waitForAck(ctx context.Context, ackID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Ack.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(c.config.Ack.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("ackId %d not acknowledged: %v", ackID, ctx.Err())
+		case <-ticker.C:
+		}
+
+		acked, err := c.pollAck(ctx, ackID)
 		if err != nil {
-			return nil, false, err
+			c.logger.Debug("Failed to poll the HEC acknowledgement endpoint",
+				zap.Int64("ack_id", ackID), zap.Error(err))
+			continue
+		}
+		if acked {
+			return nil
 		}
-		buf.WriteString("\r\n\r\n")
 	}
-	return getReader(zippers, buf, disableCompression)
 }
 
-func encodeBody(zippers *sync.Pool, dps []*splunkMetric, disableCompression bool) (bodyReader io.Reader, compressed bool, err error) {
-	buf := new(bytes.Buffer)
-	encoder := json.NewEncoder(buf)
-	for _, e := range dps {
-		err := encoder.Encode(e)
-		if err != nil {
-			return nil, false, err
-		}
-		buf.WriteString("\r\n\r\n")
+// pollAck returns whether the request identified by ackID is acknowledged.
+func (c *client) pollAck(ctx context.Context, ackID int64) (bool, error) {
+	body, err := json.Marshal(ackRequest{Acks: []int64{ackID}})
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest("POST", c.ackURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set(splunkRequestChannelHeader, c.channel)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		io.Copy(ioutil.Discard, resp.Body)
+		return false, fmt.Errorf(
+			"HTTP %d %q",
+			resp.StatusCode,
+			http.StatusText(resp.StatusCode))
+	}
+
+	var ackResp ackResponse
+	if err = json.NewDecoder(resp.Body).Decode(&ackResp); err != nil {
+		return false, err
 	}
-	return getReader(zippers, buf, disableCompression)
+	return ackResp.Acks[strconv.FormatInt(ackID, 10)], nil
 }
 
 // avoid attempting to compress things that fit into a single ethernet frame
@@ -21,6 +21,9 @@ import (
 	"math"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -31,7 +34,10 @@ import (
 	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/testutil/metricstestutil"
 	"go.uber.org/zap"
 )
@@ -292,15 +298,225 @@ func TestInvalidJson(t *testing.T) {
 	badEvent := badJSON{
 		Foo: math.Inf(1),
 	}
-	syncPool := sync.Pool{New: func() interface{} {
-		return gzip.NewWriter(nil)
-	}}
 	evs := []*splunkEvent{
 		{
 			Event: badEvent,
 		},
 		nil,
 	}
-	reader, _, err := encodeBodyEvents(&syncPool, evs, false)
-	assert.Error(t, err, reader)
+	c := &client{
+		config: &Config{},
+		logger: zap.NewNop(),
+		zippers: sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(nil)
+		}},
+	}
+	numNotSent, err := c.pushInBatches(context.Background(), len(evs), func(i int) interface{} {
+		return evs[i]
+	}, 0)
+	assert.Error(t, err)
+	assert.Equal(t, 2, numNotSent)
+}
+
+func TestPushMetricsDataMaxContentLength(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(body), 1000)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	factory := Factory{}
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Token = "1234-1234"
+	cfg.DisableCompression = true
+	cfg.MaxContentLength = 1000
+	options, err := cfg.getOptionsFromConfig()
+	assert.NoError(t, err)
+
+	c := buildClient(options, cfg, zap.NewNop())
+	numDroppedTimeSeries, err := c.pushMetricsData(context.Background(), createMetricsData(50))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, numDroppedTimeSeries)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Greater(t, len(bodies), 1)
+	numEvents := 0
+	for _, body := range bodies {
+		numEvents += strings.Count(body, "gauge_double_with_dims")
+	}
+	assert.Equal(t, 50, numEvents)
+}
+
+func createLogData(numberOfLogs int) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(numberOfLogs)
+	for i := 0; i < numberOfLogs; i++ {
+		logs.At(i).SetTimestamp(pdata.TimestampUnixNano(int64(i+1) * 1e9))
+		logs.At(i).Body().SetStringVal("mylog")
+	}
+	return ld
+}
+
+func TestPushLogDataMaxContentLength(t *testing.T) {
+	tests := []struct {
+		name                 string
+		maxContentLength     uint
+		numberOfLogs         int
+		wantRequests         int
+		wantNumDroppedLogs   int
+		wantEventsPerRequest int
+	}{
+		{
+			name:                 "no limit",
+			maxContentLength:     0,
+			numberOfLogs:         10,
+			wantRequests:         1,
+			wantEventsPerRequest: 10,
+		},
+		{
+			name:                 "two events per request",
+			maxContentLength:     100,
+			numberOfLogs:         10,
+			wantRequests:         5,
+			wantEventsPerRequest: 2,
+		},
+		{
+			name:               "event larger than limit",
+			maxContentLength:   10,
+			numberOfLogs:       3,
+			wantRequests:       0,
+			wantNumDroppedLogs: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				if tt.maxContentLength > 0 {
+					assert.LessOrEqual(t, len(body), int(tt.maxContentLength))
+				}
+				mu.Lock()
+				bodies = append(bodies, string(body))
+				mu.Unlock()
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			factory := Factory{}
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL
+			cfg.Token = "1234-1234"
+			cfg.DisableCompression = true
+			cfg.MaxContentLengthLogs = tt.maxContentLength
+			options, err := cfg.getOptionsFromConfig()
+			assert.NoError(t, err)
+
+			c := buildClient(options, cfg, zap.NewNop())
+			numDroppedLogs, err := c.pushLogData(context.Background(), createLogData(tt.numberOfLogs))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNumDroppedLogs, numDroppedLogs)
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Len(t, bodies, tt.wantRequests)
+			for _, body := range bodies {
+				assert.Equal(t, tt.wantEventsPerRequest, strings.Count(body, `"event":"mylog"`))
+			}
+		})
+	}
+}
+
+func TestPushLogDataWithAck(t *testing.T) {
+	tests := []struct {
+		name          string
+		ackID         string
+		ackedAfter    int
+		wantErr       string
+		wantPermanent bool
+	}{
+		{
+			name:       "acknowledged",
+			ackID:      `,"ackId":7`,
+			ackedAfter: 2,
+		},
+		{
+			name:       "not acknowledged",
+			ackID:      `,"ackId":7`,
+			ackedAfter: math.MaxInt32,
+			wantErr:    "ackId 7 not acknowledged: context deadline exceeded",
+		},
+		{
+			name:          "no ackId",
+			wantErr:       "no ackId in the HEC response, indexer acknowledgement must be enabled on the token",
+			wantPermanent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				channel := r.Header.Get("X-Splunk-Request-Channel")
+				assert.NotEmpty(t, channel)
+				switch r.URL.Path {
+				case "/services/collector":
+					w.Write([]byte(`{"text":"Success","code":0` + tt.ackID + `}`))
+				case "/services/collector/ack":
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{"acks":[7]}`, string(body))
+					mu.Lock()
+					polls++
+					acked := polls >= tt.ackedAfter
+					mu.Unlock()
+					w.Write([]byte(`{"acks":{"7":` + strconv.FormatBool(acked) + `}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			factory := Factory{}
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL + "/services/collector"
+			cfg.Token = "1234-1234"
+			cfg.Ack = AckConfig{
+				Enabled:      true,
+				PollInterval: 10 * time.Millisecond,
+				Timeout:      200 * time.Millisecond,
+			}
+			options, err := cfg.getOptionsFromConfig()
+			require.NoError(t, err)
+
+			c := buildClient(options, cfg, zap.NewNop())
+			numDroppedLogs, err := c.pushLogData(context.Background(), createLogData(2))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, numDroppedLogs)
+				mu.Lock()
+				assert.Equal(t, tt.ackedAfter, polls)
+				mu.Unlock()
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+			assert.Equal(t, 2, numDroppedLogs)
+		})
+	}
 }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -27,8 +28,38 @@ import (
 const (
 	// hecPath is the default HEC path on the Splunk instance.
 	hecPath = "services/collector"
+	// maxContentLengthLimit is the maximum value of MaxContentLength and MaxContentLengthLogs.
+	maxContentLengthLimit = 800 * 1024 * 1024
 )
 
+// AckConfig defines the indexer acknowledgement settings.
+type AckConfig struct {
+	// Enabled makes the exporter wait for the indexer acknowledgement of the requests before reporting them as sent.
+	// Indexer acknowledgement must be enabled on the HEC token. Defaults to false.
+	Enabled bool `mapstructure:"enabled"`
+
+	// PollInterval is the time between two polls of the acknowledgement endpoint. Defaults to 1 second.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// Timeout is the maximum time to wait for the acknowledgement of a request, the request is reported as failed
+	// if it is not acknowledged meanwhile. Defaults to 60 seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// HecToOtelAttrs defines the attributes holding the Splunk HEC metadata of
+// the log records. The attributes are looked up on the log record first, then
+// on its resource. An empty attribute name disables the lookup.
+type HecToOtelAttrs struct {
+	// Source is the attribute holding the Splunk source.
+	Source string `mapstructure:"source"`
+	// SourceType is the attribute holding the Splunk source type.
+	SourceType string `mapstructure:"sourcetype"`
+	// Index is the attribute holding the Splunk index.
+	Index string `mapstructure:"index"`
+	// Host is the attribute holding the host name.
+	Host string `mapstructure:"host"`
+}
+
 // Config defines configuration for Splunk exporter.
 type Config struct {
 	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
@@ -61,6 +92,21 @@ type Config struct {
 
 	// insecure_skip_verify skips checking the certificate of the HEC endpoint when sending data over HTTPS. Defaults to false.
 	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+
+	// MaxContentLength is the maximum size in bytes of the uncompressed body of a request sending metrics or traces,
+	// the data points and spans are split into several requests to respect it. Defaults to 2 MiB, 0 means no limit.
+	MaxContentLength uint `mapstructure:"max_content_length"`
+
+	// MaxContentLengthLogs is the maximum size in bytes of the uncompressed body of a request sending logs,
+	// the log records are split into several requests to respect it. Defaults to 2 MiB, 0 means no limit.
+	MaxContentLengthLogs uint `mapstructure:"max_content_length_logs"`
+
+	// HecToOtelAttrs defines the attributes holding the source, source type, index and host of the log records.
+	// The values of Source, SourceType, Index and unknownHostName are used for the records not having them.
+	HecToOtelAttrs HecToOtelAttrs `mapstructure:"hec_metadata_to_otel_attrs"`
+
+	// Ack defines the indexer acknowledgement settings.
+	Ack AckConfig `mapstructure:"ack"`
 }
 
 func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
@@ -73,9 +119,15 @@ func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
 		return nil, fmt.Errorf(`invalid "endpoint": %v`, err)
 	}
 
+	var ackURL *url.URL
+	if cfg.Ack.Enabled {
+		ackURL = getAckURL(url)
+	}
+
 	return &exporterOptions{
-		url:   url,
-		token: cfg.Token,
+		url:    url,
+		ackURL: ackURL,
+		token:  cfg.Token,
 	}, nil
 }
 
@@ -88,6 +140,22 @@ func (cfg *Config) validateConfig() error {
 		return errors.New(`requires a non-empty "token"`)
 	}
 
+	if cfg.Ack.Enabled && cfg.Ack.PollInterval <= 0 {
+		return errors.New(`requires a positive "poll_interval" in "ack"`)
+	}
+
+	if cfg.Ack.Enabled && cfg.Ack.Timeout <= 0 {
+		return errors.New(`requires a positive "timeout" in "ack"`)
+	}
+
+	if cfg.MaxContentLength > maxContentLengthLimit {
+		return fmt.Errorf(`requires "max_content_length" <= %d`, maxContentLengthLimit)
+	}
+
+	if cfg.MaxContentLengthLogs > maxContentLengthLimit {
+		return fmt.Errorf(`requires "max_content_length_logs" <= %d`, maxContentLengthLimit)
+	}
+
 	return nil
 }
 
@@ -103,3 +171,18 @@ func (cfg *Config) getURL() (out *url.URL, err error) {
 
 	return
 }
+
+// getAckURL returns the URL of the acknowledgement endpoint of the HEC endpoint. The ack path replaces the
+// trailing event or raw segment of the endpoint path, or is appended to it, so that the prefix of an endpoint
+// behind a proxy is kept.
+func getAckURL(hecURL *url.URL) *url.URL {
+	out := *hecURL
+	p := strings.TrimSuffix(out.Path, "/")
+	p = strings.TrimSuffix(p, "/1.0")
+	switch path.Base(p) {
+	case "event", "raw":
+		p = path.Dir(p)
+	}
+	out.Path = path.Join("/", p, "ack")
+	return &out
+}
@@ -55,13 +55,26 @@ func TestLoadConfig(t *testing.T) {
 			TypeVal: configmodels.Type(typeStr),
 			NameVal: expectedName,
 		},
-		Token:          "00000000-0000-0000-0000-0000000000000",
-		Endpoint:       "https://splunk:8088/services/collector",
-		Source:         "otel",
-		SourceType:     "otel",
-		Index:          "metrics",
-		MaxConnections: 100,
-		Timeout:        10 * time.Second,
+		Token:                "00000000-0000-0000-0000-0000000000000",
+		Endpoint:             "https://splunk:8088/services/collector",
+		Source:               "otel",
+		SourceType:           "otel",
+		Index:                "metrics",
+		MaxConnections:       100,
+		Timeout:              10 * time.Second,
+		MaxContentLength:     4096,
+		MaxContentLengthLogs: 1024,
+		HecToOtelAttrs: HecToOtelAttrs{
+			Source:     "mysource",
+			SourceType: "mysourcetype",
+			Index:      "myindex",
+			Host:       "myhost",
+		},
+		Ack: AckConfig{
+			Enabled:      true,
+			PollInterval: 2 * time.Second,
+			Timeout:      30 * time.Second,
+		},
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -72,12 +85,15 @@ func TestLoadConfig(t *testing.T) {
 
 func TestConfig_getOptionsFromConfig(t *testing.T) {
 	type fields struct {
-		ExporterSettings configmodels.ExporterSettings
-		Endpoint         string
-		Token            string
-		Source           string
-		SourceType       string
-		Index            string
+		ExporterSettings     configmodels.ExporterSettings
+		Endpoint             string
+		Token                string
+		Source               string
+		SourceType           string
+		Index                string
+		MaxContentLength     uint
+		MaxContentLengthLogs uint
+		Ack                  AckConfig
 	}
 	tests := []struct {
 		name    string
@@ -117,6 +133,80 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Test max content length logs greater than limit",
+			fields: fields{
+				Token:                "1234",
+				Endpoint:             "https://example.com:8000",
+				MaxContentLengthLogs: maxContentLengthLimit + 1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test max content length greater than limit",
+			fields: fields{
+				Token:            "1234",
+				Endpoint:         "https://example.com:8000",
+				MaxContentLength: maxContentLengthLimit + 1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test ack URL",
+			fields: fields{
+				Token:    "1234",
+				Endpoint: "https://example.com:8000/services/collector/event",
+				Ack:      AckConfig{Enabled: true, PollInterval: time.Second, Timeout: time.Second},
+			},
+			want: &exporterOptions{
+				token: "1234",
+				url: &url.URL{
+					Scheme: "https",
+					Host:   "example.com:8000",
+					Path:   "/services/collector/event",
+				},
+				ackURL: &url.URL{
+					Scheme: "https",
+					Host:   "example.com:8000",
+					Path:   "/services/collector/ack",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test ack URL with path prefix",
+			fields: fields{
+				Token:    "1234",
+				Endpoint: "https://proxy/splunk/services/collector",
+				Ack:      AckConfig{Enabled: true, PollInterval: time.Second, Timeout: time.Second},
+			},
+			want: &exporterOptions{
+				token: "1234",
+				url: &url.URL{
+					Scheme: "https",
+					Host:   "proxy",
+					Path:   "/splunk/services/collector",
+				},
+				ackURL: &url.URL{
+					Scheme: "https",
+					Host:   "proxy",
+					Path:   "/splunk/services/collector/ack",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test ack without poll interval",
+			fields: fields{
+				Token:    "1234",
+				Endpoint: "https://example.com:8000",
+				Ack:      AckConfig{Enabled: true, Timeout: time.Second},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "Test empty config",
 			want:    nil,
@@ -126,12 +216,15 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := &Config{
-				ExporterSettings: tt.fields.ExporterSettings,
-				Token:            tt.fields.Token,
-				Endpoint:         tt.fields.Endpoint,
-				Source:           tt.fields.Source,
-				SourceType:       tt.fields.SourceType,
-				Index:            tt.fields.Index,
+				ExporterSettings:     tt.fields.ExporterSettings,
+				Token:                tt.fields.Token,
+				Endpoint:             tt.fields.Endpoint,
+				Source:               tt.fields.Source,
+				SourceType:           tt.fields.SourceType,
+				Index:                tt.fields.Index,
+				MaxContentLength:     tt.fields.MaxContentLength,
+				MaxContentLengthLogs: tt.fields.MaxContentLengthLogs,
+				Ack:                  tt.fields.Ack,
 			}
 			got, err := cfg.getOptionsFromConfig()
 			if (err != nil) != tt.wantErr {
@@ -142,3 +235,25 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAckURL(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/services/collector", want: "/services/collector/ack"},
+		{path: "/services/collector/", want: "/services/collector/ack"},
+		{path: "/services/collector/event", want: "/services/collector/ack"},
+		{path: "/services/collector/raw", want: "/services/collector/ack"},
+		{path: "/services/collector/event/1.0", want: "/services/collector/ack"},
+		{path: "/splunk/services/collector/event", want: "/splunk/services/collector/ack"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			hecURL := &url.URL{Scheme: "https", Host: "example.com:8088", Path: tt.path}
+			got := getAckURL(hecURL)
+			assert.Equal(t, tt.want, got.Path)
+			assert.Equal(t, "example.com:8088", got.Host)
+		})
+	}
+}
@@ -26,9 +26,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/pdatautil"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.uber.org/zap"
@@ -51,12 +53,14 @@ type TraceAndMetricExporter interface {
 type splunkExporter struct {
 	pushMetricsData func(ctx context.Context, md consumerdata.MetricsData) (droppedTimeSeries int, err error)
 	pushTraceData   func(ctx context.Context, td consumerdata.TraceData) (numDroppedSpans int, err error)
+	pushLogData     func(ctx context.Context, ld pdata.Logs) (numDroppedLogs int, err error)
 	stop            func(ctx context.Context) (err error)
 }
 
 type exporterOptions struct {
-	url   *url.URL
-	token string
+	url    *url.URL
+	ackURL *url.URL
+	token  string
 }
 
 // createExporter returns a new Splunk exporter.
@@ -83,9 +87,34 @@ func createExporter(
 	}, nil
 }
 
+// createLogsExporter returns a new Splunk exporter sending logs.
+func createLogsExporter(
+	config *Config,
+	logger *zap.Logger,
+) (component.LogsExporter, error) {
+	if config == nil {
+		return nil, errors.New("nil config")
+	}
+
+	options, err := config.getOptionsFromConfig()
+	if err != nil {
+		return nil,
+			fmt.Errorf("failed to process %q config: %v", config.Name(), err)
+	}
+
+	client := buildClient(options, config, logger)
+
+	return splunkExporter{
+		pushLogData: client.pushLogData,
+		stop:        client.stop,
+	}, nil
+}
+
 func buildClient(options *exporterOptions, config *Config, logger *zap.Logger) *client {
 	return &client{
-		url: options.url,
+		url:     options.url,
+		ackURL:  options.ackURL,
+		channel: uuid.New().String(),
 		client: &http.Client{
 			Timeout: config.Timeout,
 			Transport: &http.Transport{
@@ -143,3 +172,12 @@ func (se splunkExporter) ConsumeTraceData(ctx context.Context, td consumerdata.T
 	obsreport.EndTraceDataExportOp(ctx, len(td.Spans), numDroppedSpans, err)
 	return err
 }
+
+func (se splunkExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	ctx = obsreport.StartLogsExportOp(ctx, typeStr)
+
+	numDroppedLogs, err := se.pushLogData(ctx, ld)
+
+	obsreport.EndLogsExportOp(ctx, ld.LogRecordCount(), numDroppedLogs, err)
+	return err
+}
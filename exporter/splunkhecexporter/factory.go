@@ -15,6 +15,7 @@
 package splunkhecexporter
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -30,6 +31,16 @@ const (
 	defaultNumWorkers  uint = 8
 	defaultMaxIdleCons      = 100
 	defaultHTTPTimeout      = 10 * time.Second
+	// 2 MiB
+	defaultMaxContentLength     uint = 2 * 1024 * 1024
+	defaultMaxContentLengthLogs uint = 2 * 1024 * 1024
+	// default attributes holding the HEC metadata of the log records
+	defaultSourceAttr     = "com.splunk.source"
+	defaultSourceTypeAttr = "com.splunk.sourcetype"
+	defaultIndexAttr      = "com.splunk.index"
+	// default indexer acknowledgement settings
+	defaultAckPollInterval = time.Second
+	defaultAckTimeout      = 60 * time.Second
 )
 
 // Factory is the factory for Splunk HEC exporter.
@@ -48,9 +59,21 @@ func (f *Factory) CreateDefaultConfig() configmodels.Exporter {
 			TypeVal: configmodels.Type(typeStr),
 			NameVal: typeStr,
 		},
-		Timeout:            defaultHTTPTimeout,
-		DisableCompression: false,
-		MaxConnections:     defaultMaxIdleCons,
+		Timeout:              defaultHTTPTimeout,
+		DisableCompression:   false,
+		MaxConnections:       defaultMaxIdleCons,
+		MaxContentLength:     defaultMaxContentLength,
+		MaxContentLengthLogs: defaultMaxContentLengthLogs,
+		HecToOtelAttrs: HecToOtelAttrs{
+			Source:     defaultSourceAttr,
+			SourceType: defaultSourceTypeAttr,
+			Index:      defaultIndexAttr,
+			Host:       hostnameLabel,
+		},
+		Ack: AckConfig{
+			PollInterval: defaultAckPollInterval,
+			Timeout:      defaultAckTimeout,
+		},
 	}
 }
 
@@ -91,3 +114,23 @@ func (f *Factory) CreateMetricsExporter(
 
 	return exp, nil
 }
+
+// CreateLogsExporter creates a logs exporter based on this config.
+func (f *Factory) CreateLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	config configmodels.Exporter,
+) (component.LogsExporter, error) {
+	if config == nil {
+		return nil, errors.New("nil config")
+	}
+	expCfg := config.(*Config)
+
+	exp, err := createLogsExporter(expCfg, params.Logger)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return exp, nil
+}
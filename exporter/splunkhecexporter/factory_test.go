@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.uber.org/zap"
@@ -74,6 +75,24 @@ func TestCreateTraceExporterInvalidEndpoint(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCreateLogsExporter(t *testing.T) {
+	factory := Factory{}
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "https://example.com:8088/services/collector"
+	cfg.Token = "1234-1234"
+
+	exp, err := factory.CreateLogsExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg)
+	assert.NoError(t, err)
+	require.NotNil(t, exp)
+	assert.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestCreateLogsExporterNoConfig(t *testing.T) {
+	factory := Factory{}
+	_, err := factory.CreateLogsExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, nil)
+	assert.Error(t, err)
+}
+
 func TestCreateInstanceViaFactory(t *testing.T) {
 	factory := Factory{}
 
@@ -0,0 +1,137 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func logDataToSplunk(logger *zap.Logger, ld pdata.Logs, config *Config) ([]*splunkEvent, int) {
+	numDroppedLogs := 0
+	splunkEvents := make([]*splunkEvent, 0)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+
+		resourceAttrs := pdata.NewAttributeMap()
+		if !rl.Resource().IsNil() {
+			resourceAttrs = rl.Resource().Attributes()
+		}
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+				se := mapLogRecordToSplunkEvent(lr, resourceAttrs, config)
+				if se == nil {
+					logger.Debug(
+						"Log record dropped as its body is not a scalar value",
+						zap.String("name", lr.Name()))
+					numDroppedLogs++
+					continue
+				}
+				splunkEvents = append(splunkEvents, se)
+			}
+		}
+	}
+
+	return splunkEvents, numDroppedLogs
+}
+
+// mapLogRecordToSplunkEvent returns the event holding the body of the log
+// record, nil if the body is not a scalar value. The attributes not mapped to
+// the HEC metadata become the event fields.
+func mapLogRecordToSplunkEvent(lr pdata.LogRecord, resourceAttrs pdata.AttributeMap, config *Config) *splunkEvent {
+	event := attributeValueToInterface(lr.Body())
+	if event == nil {
+		return nil
+	}
+
+	attrs := lr.Attributes()
+	se := &splunkEvent{
+		Time:       nanoTimestampToEpochMilliseconds(lr.Timestamp()),
+		Host:       lookupAttribute(config.HecToOtelAttrs.Host, attrs, resourceAttrs, unknownHostName),
+		Source:     lookupAttribute(config.HecToOtelAttrs.Source, attrs, resourceAttrs, config.Source),
+		SourceType: lookupAttribute(config.HecToOtelAttrs.SourceType, attrs, resourceAttrs, config.SourceType),
+		Index:      lookupAttribute(config.HecToOtelAttrs.Index, attrs, resourceAttrs, config.Index),
+		Event:      event,
+	}
+
+	fields := map[string]interface{}{}
+	addFields := func(k string, v pdata.AttributeValue) {
+		switch k {
+		case config.HecToOtelAttrs.Host, config.HecToOtelAttrs.Source,
+			config.HecToOtelAttrs.SourceType, config.HecToOtelAttrs.Index:
+			return
+		}
+		if value := attributeValueToInterface(v); value != nil {
+			fields[k] = value
+		}
+	}
+	resourceAttrs.ForEach(addFields)
+	attrs.ForEach(addFields)
+	if len(fields) > 0 {
+		se.Fields = fields
+	}
+
+	return se
+}
+
+// lookupAttribute returns the string value of the key attribute of the log
+// record, or else of its resource, or else defaultValue.
+func lookupAttribute(key string, attrs pdata.AttributeMap, resourceAttrs pdata.AttributeMap, defaultValue string) string {
+	if key == "" {
+		return defaultValue
+	}
+	if v, ok := attrs.Get(key); ok && v.Type() == pdata.AttributeValueSTRING {
+		return v.StringVal()
+	}
+	if v, ok := resourceAttrs.Get(key); ok && v.Type() == pdata.AttributeValueSTRING {
+		return v.StringVal()
+	}
+	return defaultValue
+}
+
+func attributeValueToInterface(v pdata.AttributeValue) interface{} {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return v.IntVal()
+	case pdata.AttributeValueDOUBLE:
+		return v.DoubleVal()
+	case pdata.AttributeValueBOOL:
+		return v.BoolVal()
+	}
+	return nil
+}
+
+func nanoTimestampToEpochMilliseconds(ts pdata.TimestampUnixNano) float64 {
+	return math.Round(float64(ts)/1e6) / 1e3
+}
@@ -0,0 +1,105 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestLogDataToSplunk(t *testing.T) {
+	factory := Factory{}
+	config := factory.CreateDefaultConfig().(*Config)
+	config.Source = "otel"
+	config.SourceType = "otel_type"
+	config.Index = "main"
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().InitEmpty()
+	rl.Resource().Attributes().InsertString("host.hostname", "myhost")
+	rl.Resource().Attributes().InsertString("com.splunk.index", "resource_index")
+	rl.Resource().Attributes().InsertString("service.name", "myservice")
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(3)
+
+	lr := logs.At(0)
+	lr.SetTimestamp(pdata.TimestampUnixNano(1500000000123456789))
+	lr.Body().SetStringVal("mylog")
+	lr.Attributes().InsertString("com.splunk.source", "record_source")
+	lr.Attributes().InsertInt("retries", 3)
+
+	lr = logs.At(1)
+	lr.Body().SetIntVal(42)
+	lr.Attributes().InsertString("com.splunk.index", "record_index")
+
+	// Dropped, the body is not set.
+	logs.At(2).SetName("empty")
+
+	events, numDroppedLogs := logDataToSplunk(zap.NewNop(), ld, config)
+	assert.Equal(t, 1, numDroppedLogs)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, &splunkEvent{
+		Time:       1500000000.123,
+		Host:       "myhost",
+		Source:     "record_source",
+		SourceType: "otel_type",
+		Index:      "resource_index",
+		Event:      "mylog",
+		Fields: map[string]interface{}{
+			"service.name": "myservice",
+			"retries":      int64(3),
+		},
+	}, events[0])
+
+	assert.Equal(t, &splunkEvent{
+		Host:       "myhost",
+		Source:     "otel",
+		SourceType: "otel_type",
+		Index:      "record_index",
+		Event:      int64(42),
+		Fields: map[string]interface{}{
+			"service.name": "myservice",
+		},
+	}, events[1])
+}
+
+func TestLogDataToSplunkDisabledMapping(t *testing.T) {
+	factory := Factory{}
+	config := factory.CreateDefaultConfig().(*Config)
+	config.HecToOtelAttrs = HecToOtelAttrs{}
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(1)
+	logs.At(0).Body().SetStringVal("mylog")
+	logs.At(0).Attributes().InsertString("host.hostname", "myhost")
+
+	events, numDroppedLogs := logDataToSplunk(zap.NewNop(), ld, config)
+	assert.Equal(t, 0, numDroppedLogs)
+	require.Len(t, events, 1)
+	assert.Equal(t, "unknown", events[0].Host)
+	assert.Equal(t, map[string]interface{}{"host.hostname": "myhost"}, events[0].Fields)
+}
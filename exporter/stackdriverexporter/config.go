@@ -29,20 +29,39 @@ type Config struct {
 	// Only has effect if Endpoint is not ""
 	UseInsecure      bool              `mapstructure:"use_insecure"`
 	ResourceMappings []ResourceMapping `mapstructure:"resource_mappings"`
+	// UseDefaultResourceMappings appends mappings of the OpenTelemetry resource attributes to
+	// the k8s_container, gce_instance and generic_task monitored resources to ResourceMappings.
+	UseDefaultResourceMappings bool `mapstructure:"use_default_resource_mappings"`
 }
 
 // ResourceMapping defines mapping of resources from source (OpenCensus) to target (Stackdriver).
+// The first mapping applying to a resource is used, so mappings can be listed from the most
+// to the least specific, falling back to the default mapping when none applies.
 type ResourceMapping struct {
+	// SourceType is the type of the resources the mapping applies to. When empty, the mapping
+	// applies to the resources of any type.
 	SourceType string `mapstructure:"source_type"`
 	TargetType string `mapstructure:"target_type"`
 
+	// MatchLabels are the labels the resources must have, with the given values, for the
+	// mapping to apply.
+	MatchLabels []LabelMatch `mapstructure:"match_labels"`
+
 	LabelMappings []LabelMapping `mapstructure:"label_mappings"`
 }
 
+// LabelMatch requires a resource label to have a value.
+type LabelMatch struct {
+	Key   string `mapstructure:"key"`
+	Value string `mapstructure:"value"`
+}
+
 type LabelMapping struct {
 	SourceKey string `mapstructure:"source_key"`
 	TargetKey string `mapstructure:"target_key"`
 	// Optional flag signals whether we can proceed with transformation if a label is missing in the resource.
 	// When required label is missing, we fallback to default resource mapping.
 	Optional bool `mapstructure:"optional"`
+	// Default is the value of the target label when the label is missing in the resource.
+	Default string `mapstructure:"default"`
 }
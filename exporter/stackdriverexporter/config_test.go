@@ -74,6 +74,23 @@ func TestLoadConfig(t *testing.T) {
 					SourceType: "source.resource2",
 					TargetType: "target-resource2",
 				},
+				{
+					TargetType: "target-resource3",
+					MatchLabels: []LabelMatch{
+						{
+							Key:   "cloud.provider",
+							Value: "gcp",
+						},
+					},
+					LabelMappings: []LabelMapping{
+						{
+							SourceKey: "cloud.zone",
+							TargetKey: "location",
+							Default:   "global",
+						},
+					},
+				},
 			},
+			UseDefaultResourceMappings: true,
 		})
 }
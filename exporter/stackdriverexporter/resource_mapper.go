@@ -17,6 +17,7 @@ package stackdriverexporter
 import (
 	"contrib.go.opencensus.io/exporter/stackdriver"
 	"go.opencensus.io/resource"
+	"go.opentelemetry.io/collector/translator/conventions"
 	monitoredrespb "google.golang.org/genproto/googleapis/api/monitoredres"
 )
 
@@ -26,7 +27,10 @@ type resourceMapper struct {
 
 func (mr *resourceMapper) mapResource(res *resource.Resource) *monitoredrespb.MonitoredResource {
 	for _, mapping := range mr.mappings {
-		if res.Type != mapping.SourceType {
+		if mapping.SourceType != "" && res.Type != mapping.SourceType {
+			continue
+		}
+		if !matchLabels(mapping.MatchLabels, res.Labels) {
 			continue
 		}
 
@@ -57,6 +61,8 @@ func transformLabels(labelMappings []LabelMapping, input map[string]string) (map
 	for _, labelMapping := range labelMappings {
 		if v, ok := input[labelMapping.SourceKey]; ok {
 			output[labelMapping.TargetKey] = v
+		} else if labelMapping.Default != "" {
+			output[labelMapping.TargetKey] = labelMapping.Default
 		} else if !labelMapping.Optional {
 			// Required label is missing
 			return nil, false
@@ -64,3 +70,53 @@ func transformLabels(labelMappings []LabelMapping, input map[string]string) (map
 	}
 	return output, true
 }
+
+// matchLabels returns true if the input has all the label values of matches.
+func matchLabels(matches []LabelMatch, input map[string]string) bool {
+	for _, match := range matches {
+		if v, ok := input[match.Key]; !ok || v != match.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// projectIDLabel is the resource label holding the project of the default resource mapping.
+const projectIDLabel = "contrib.opencensus.io/exporter/stackdriver/project_id"
+
+// defaultResourceMappings map the OpenTelemetry resource attributes to monitored resources,
+// from the most to the least specific.
+var defaultResourceMappings = []ResourceMapping{
+	{
+		TargetType: "k8s_container",
+		LabelMappings: []LabelMapping{
+			{SourceKey: projectIDLabel, TargetKey: "project_id", Optional: true},
+			{SourceKey: conventions.AttributeCloudZone, TargetKey: "location"},
+			{SourceKey: conventions.AttributeK8sCluster, TargetKey: "cluster_name"},
+			{SourceKey: conventions.AttributeK8sNamespace, TargetKey: "namespace_name"},
+			{SourceKey: conventions.AttributeK8sPod, TargetKey: "pod_name"},
+			{SourceKey: conventions.AttributeK8sContainer, TargetKey: "container_name"},
+		},
+	},
+	{
+		TargetType: "gce_instance",
+		MatchLabels: []LabelMatch{
+			{Key: conventions.AttributeCloudProvider, Value: conventions.AttributeCloudProviderGCP},
+		},
+		LabelMappings: []LabelMapping{
+			{SourceKey: projectIDLabel, TargetKey: "project_id", Optional: true},
+			{SourceKey: conventions.AttributeHostID, TargetKey: "instance_id"},
+			{SourceKey: conventions.AttributeCloudZone, TargetKey: "zone"},
+		},
+	},
+	{
+		TargetType: "generic_task",
+		LabelMappings: []LabelMapping{
+			{SourceKey: projectIDLabel, TargetKey: "project_id", Optional: true},
+			{SourceKey: conventions.AttributeCloudZone, TargetKey: "location", Default: "global"},
+			{SourceKey: conventions.AttributeServiceNamespace, TargetKey: "namespace", Optional: true},
+			{SourceKey: conventions.AttributeServiceName, TargetKey: "job"},
+			{SourceKey: conventions.AttributeServiceInstance, TargetKey: "task_id"},
+		},
+	},
+}
@@ -163,3 +163,160 @@ func TestResourceMapper(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceMapperMatchLabels(t *testing.T) {
+	rm := resourceMapper{
+		mappings: []ResourceMapping{
+			{
+				TargetType: "target_resource_1",
+				MatchLabels: []LabelMatch{
+					{Key: "source.kind", Value: "kind1"},
+				},
+				LabelMappings: []LabelMapping{
+					{SourceKey: "source.label", TargetKey: "target_label", Default: "default_value"},
+				},
+			},
+			{
+				TargetType: "target_resource_2",
+				LabelMappings: []LabelMapping{
+					{SourceKey: "source.label", TargetKey: "target_label"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		labels       map[string]string
+		wantResource *monitoredres.MonitoredResource
+	}{
+		{
+			name:   "Matching labels with missing label default",
+			labels: map[string]string{"source.kind": "kind1"},
+			wantResource: &monitoredres.MonitoredResource{
+				Type:   "target_resource_1",
+				Labels: map[string]string{"target_label": "default_value"},
+			},
+		},
+		{
+			name:   "Non matching label value falls back to the next mapping",
+			labels: map[string]string{"source.kind": "kind2", "source.label": "value1"},
+			wantResource: &monitoredres.MonitoredResource{
+				Type:   "target_resource_2",
+				Labels: map[string]string{"target_label": "value1"},
+			},
+		},
+		{
+			name:   "No mapping applies",
+			labels: map[string]string{"source.kind": "kind2", projectIDLabel: "123"},
+			wantResource: &monitoredres.MonitoredResource{
+				Type:   "global",
+				Labels: map[string]string{"project_id": "123"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := rm.mapResource(&resource.Resource{Type: "any", Labels: tt.labels})
+			require.NotNil(t, result)
+			assert.Equal(t, tt.wantResource.Type, result.Type)
+			assert.EqualValues(t, tt.wantResource.Labels, result.Labels)
+		})
+	}
+}
+
+func TestDefaultResourceMappings(t *testing.T) {
+	rm := resourceMapper{mappings: defaultResourceMappings}
+
+	tests := []struct {
+		name         string
+		labels       map[string]string
+		wantResource *monitoredres.MonitoredResource
+	}{
+		{
+			name: "k8s_container",
+			labels: map[string]string{
+				projectIDLabel:       "123",
+				"cloud.provider":     "gcp",
+				"cloud.zone":         "us-central1-a",
+				"host.id":            "1234567890",
+				"k8s.cluster.name":   "cluster1",
+				"k8s.namespace.name": "ns1",
+				"k8s.pod.name":       "pod1",
+				"k8s.container.name": "container1",
+			},
+			wantResource: &monitoredres.MonitoredResource{
+				Type: "k8s_container",
+				Labels: map[string]string{
+					"project_id":     "123",
+					"location":       "us-central1-a",
+					"cluster_name":   "cluster1",
+					"namespace_name": "ns1",
+					"pod_name":       "pod1",
+					"container_name": "container1",
+				},
+			},
+		},
+		{
+			name: "gce_instance",
+			labels: map[string]string{
+				"cloud.provider": "gcp",
+				"cloud.zone":     "us-central1-a",
+				"host.id":        "1234567890",
+				"service.name":   "service1",
+			},
+			wantResource: &monitoredres.MonitoredResource{
+				Type: "gce_instance",
+				Labels: map[string]string{
+					"instance_id": "1234567890",
+					"zone":        "us-central1-a",
+				},
+			},
+		},
+		{
+			name: "generic_task outside of GCP",
+			labels: map[string]string{
+				"cloud.provider":      "aws",
+				"cloud.zone":          "us-east-1a",
+				"host.id":             "i-1234567890",
+				"service.name":        "service1",
+				"service.instance.id": "instance1",
+			},
+			wantResource: &monitoredres.MonitoredResource{
+				Type: "generic_task",
+				Labels: map[string]string{
+					"location": "us-east-1a",
+					"job":      "service1",
+					"task_id":  "instance1",
+				},
+			},
+		},
+		{
+			name: "generic_task without zone",
+			labels: map[string]string{
+				"service.namespace":   "namespace1",
+				"service.name":        "service1",
+				"service.instance.id": "instance1",
+			},
+			wantResource: &monitoredres.MonitoredResource{
+				Type: "generic_task",
+				Labels: map[string]string{
+					"location":  "global",
+					"namespace": "namespace1",
+					"job":       "service1",
+					"task_id":   "instance1",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := rm.mapResource(&resource.Resource{Labels: tt.labels})
+			require.NotNil(t, result)
+			assert.Equal(t, tt.wantResource.Type, result.Type)
+			assert.EqualValues(t, tt.wantResource.Labels, result.Labels)
+		})
+	}
+}
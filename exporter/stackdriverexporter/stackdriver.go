@@ -129,9 +129,13 @@ func newStackdriverMetricsExporter(cfg *Config) (component.MetricsExporter, erro
 	if cfg.SkipCreateMetricDescriptor {
 		options.SkipCMD = true
 	}
-	if len(cfg.ResourceMappings) > 0 {
+	mappings := cfg.ResourceMappings
+	if cfg.UseDefaultResourceMappings {
+		mappings = append(append([]ResourceMapping(nil), mappings...), defaultResourceMappings...)
+	}
+	if len(mappings) > 0 {
 		rm := resourceMapper{
-			mappings: cfg.ResourceMappings,
+			mappings: mappings,
 		}
 		options.MapResource = rm.mapResource
 	}
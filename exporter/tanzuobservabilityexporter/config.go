@@ -0,0 +1,169 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	defaultTimeout     = 5 * time.Second
+	defaultProxyHost   = "localhost"
+	defaultMetricsPort = 2878
+	defaultTracesPort  = 30000
+	defaultMaxTags     = 20
+	defaultApplication = "defaultApp"
+	defaultService     = "defaultService"
+)
+
+// Config defines configuration for the Tanzu Observability (Wavefront)
+// exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"`
+
+	// Proxy configures the Wavefront proxy the data is sent to, unless the
+	// direct ingestion is configured.
+	Proxy ProxyConfig `mapstructure:"proxy"`
+
+	// DirectIngestion configures sending the data directly to a Wavefront
+	// cluster, without a proxy.
+	DirectIngestion DirectIngestionConfig `mapstructure:"direct_ingestion"`
+
+	// Source is the source of the points and spans whose resource has no
+	// "host.name" attribute. It defaults to the hostname of the collector.
+	Source string `mapstructure:"source"`
+
+	// ResourceAttributes configures the resource attributes converted to tags.
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+
+	// Traces configures the conversion of the spans.
+	Traces TracesConfig `mapstructure:"traces"`
+
+	// Timeout is the timeout of the connections and requests sending the
+	// data. Defaults to 5 seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// ProxyConfig defines the Wavefront proxy destination.
+type ProxyConfig struct {
+	// Host is the host of the proxy. Defaults to "localhost".
+	Host string `mapstructure:"host"`
+
+	// MetricsPort is the port of the proxy receiving the points in the
+	// Wavefront data format. Defaults to 2878.
+	MetricsPort int `mapstructure:"metrics_port"`
+
+	// TracesPort is the port of the proxy receiving the spans. Defaults to
+	// 30000.
+	TracesPort int `mapstructure:"traces_port"`
+}
+
+// DirectIngestionConfig defines the Wavefront cluster destination.
+type DirectIngestionConfig struct {
+	// Endpoint is the URL of the Wavefront cluster, e.g.
+	// https://example.wavefront.com.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Token is the API token used to authenticate with the cluster.
+	Token string `mapstructure:"token"`
+}
+
+// ResourceAttributesConfig defines the resource attributes converted to
+// tags. Each distinct tag set is a separate Wavefront time series, so the
+// number of tags and the attributes with many values are limited.
+type ResourceAttributesConfig struct {
+	// Include lists the resource attributes converted to tags. If it is
+	// empty, all the resource attributes are converted to tags, except the
+	// ones known to have a high cardinality, e.g. "process.pid".
+	Include []string `mapstructure:"include"`
+
+	// Exclude lists the resource attributes not converted to tags.
+	Exclude []string `mapstructure:"exclude"`
+
+	// MaxTags is the maximum number of tags converted from the resource
+	// attributes, the remaining ones being dropped in the order of their
+	// keys. Defaults to 20.
+	MaxTags int `mapstructure:"max_tags"`
+}
+
+// TracesConfig defines the conversion of the spans.
+type TracesConfig struct {
+	// Application is the application of the spans whose resource has no
+	// "application" attribute. Defaults to "defaultApp".
+	Application string `mapstructure:"application"`
+
+	// DisableREDMetrics disables the rate, errors and duration metrics
+	// derived from the spans.
+	DisableREDMetrics bool `mapstructure:"disable_red_metrics"`
+}
+
+// validate checks the configuration and fills in the source and application.
+func (cfg *Config) validate() error {
+	if cfg.DirectIngestion.Endpoint != "" {
+		u, err := url.Parse(cfg.DirectIngestion.Endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid \"direct_ingestion.endpoint\": %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid \"direct_ingestion.endpoint\" %q, it must be an absolute URL", cfg.DirectIngestion.Endpoint)
+		}
+		if cfg.DirectIngestion.Token == "" {
+			return errors.New("\"direct_ingestion.token\" must be specified")
+		}
+	} else {
+		if cfg.Proxy.Host == "" {
+			return errors.New("\"proxy.host\" must be specified")
+		}
+		if !validPort(cfg.Proxy.MetricsPort) {
+			return fmt.Errorf("invalid \"proxy.metrics_port\" %d", cfg.Proxy.MetricsPort)
+		}
+		if !validPort(cfg.Proxy.TracesPort) {
+			return fmt.Errorf("invalid \"proxy.traces_port\" %d", cfg.Proxy.TracesPort)
+		}
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("cannot have a negative \"timeout\"")
+	}
+	if cfg.ResourceAttributes.MaxTags < 0 {
+		return errors.New("cannot have a negative \"resource_attributes.max_tags\"")
+	}
+	if cfg.Traces.Application == "" {
+		cfg.Traces.Application = defaultApplication
+	}
+	if cfg.Source == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("cannot get the hostname for the default \"source\": %w", err)
+		}
+		cfg.Source = hostname
+	}
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// proxyAddress returns the address of the proxy port.
+func (cfg *Config) proxyAddress(port int) string {
+	return cfg.Proxy.Host + ":" + strconv.Itoa(port)
+}
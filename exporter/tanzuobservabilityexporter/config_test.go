@@ -0,0 +1,130 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters["tanzuobservability"]
+	assert.Equal(t, factory.CreateDefaultConfig(), e0)
+
+	e1 := cfg.Exporters["tanzuobservability/proxy"]
+	assert.Equal(t, &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "tanzuobservability/proxy",
+			TypeVal: "tanzuobservability",
+		},
+		Proxy: ProxyConfig{
+			Host:        "wavefront-proxy",
+			MetricsPort: 2879,
+			TracesPort:  30001,
+		},
+		Source: "collector",
+		ResourceAttributes: ResourceAttributesConfig{
+			Include: []string{"service.name", "k8s.namespace.name"},
+			MaxTags: 10,
+		},
+		Traces: TracesConfig{
+			Application:       "shop",
+			DisableREDMetrics: true,
+		},
+		Timeout: 10 * time.Second,
+	}, e1)
+
+	e2 := cfg.Exporters["tanzuobservability/direct"].(*Config)
+	assert.Equal(t, DirectIngestionConfig{
+		Endpoint: "https://example.wavefront.com",
+		Token:    "00000000-0000-0000-0000-000000000000",
+	}, e2.DirectIngestion)
+	assert.Equal(t, []string{"host.arch"}, e2.ResourceAttributes.Exclude)
+}
+
+func TestValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.validate())
+	assert.NotEmpty(t, cfg.Source)
+	assert.Equal(t, "localhost:2878", cfg.proxyAddress(cfg.Proxy.MetricsPort))
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no proxy host",
+			modify: func(cfg *Config) { cfg.Proxy.Host = "" },
+			err:    `"proxy.host" must be specified`,
+		},
+		{
+			name:   "invalid metrics port",
+			modify: func(cfg *Config) { cfg.Proxy.MetricsPort = 0 },
+			err:    `invalid "proxy.metrics_port" 0`,
+		},
+		{
+			name:   "invalid traces port",
+			modify: func(cfg *Config) { cfg.Proxy.TracesPort = 70000 },
+			err:    `invalid "proxy.traces_port" 70000`,
+		},
+		{
+			name:   "relative direct ingestion endpoint",
+			modify: func(cfg *Config) { cfg.DirectIngestion.Endpoint = "example.wavefront.com" },
+			err:    `invalid "direct_ingestion.endpoint" "example.wavefront.com", it must be an absolute URL`,
+		},
+		{
+			name:   "no direct ingestion token",
+			modify: func(cfg *Config) { cfg.DirectIngestion.Endpoint = "https://example.wavefront.com" },
+			err:    `"direct_ingestion.token" must be specified`,
+		},
+		{
+			name:   "negative timeout",
+			modify: func(cfg *Config) { cfg.Timeout = -time.Second },
+			err:    `cannot have a negative "timeout"`,
+		},
+		{
+			name:   "negative max tags",
+			modify: func(cfg *Config) { cfg.ResourceAttributes.MaxTags = -1 },
+			err:    `cannot have a negative "resource_attributes.max_tags"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.validate(), tt.err)
+		})
+	}
+}
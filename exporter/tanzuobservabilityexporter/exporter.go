@@ -0,0 +1,96 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+// wavefrontExporter sends metrics and traces to a Wavefront proxy or cluster.
+type wavefrontExporter struct {
+	config *Config
+	sender sender
+	tagger *resourceTagger
+	logger *zap.Logger
+}
+
+func newExporter(config *Config, logger *zap.Logger) (*wavefrontExporter, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return &wavefrontExporter{
+		config: config,
+		sender: newSender(config),
+		tagger: newResourceTagger(config),
+		logger: logger,
+	}, nil
+}
+
+func (e *wavefrontExporter) Shutdown(context.Context) error {
+	e.sender.close()
+	return nil
+}
+
+func (e *wavefrontExporter) pushMetrics(ctx context.Context, md pdata.Metrics) (int, error) {
+	_, numPoints := pdatautil.MetricAndDataPointCount(md)
+
+	var sb strings.Builder
+	dropped := 0
+	now := time.Now()
+	for _, data := range pdatautil.MetricsToMetricsData(md) {
+		dropped += metricsToWavefront(&sb, data, e.tagger, now)
+	}
+	if dropped > 0 {
+		e.logger.Debug("Dropped time series without name or values", zap.Int("count", dropped))
+	}
+
+	if err := e.sender.send(ctx, metricsFormat, sb.String()); err != nil {
+		return numPoints, err
+	}
+	return dropped, nil
+}
+
+// pushTraces sends the spans, then the RED metrics derived from them.
+func (e *wavefrontExporter) pushTraces(ctx context.Context, td pdata.Traces) (int, error) {
+	var red redMetrics
+	if !e.config.Traces.DisableREDMetrics {
+		red = make(redMetrics)
+	}
+
+	var sb strings.Builder
+	numSpans, dropped := tracesToWavefront(&sb, td, e.tagger, e.config.Traces.Application, red)
+	if dropped > 0 {
+		e.logger.Debug("Dropped spans with an invalid trace or span ID", zap.Int("count", dropped))
+	}
+	if err := e.sender.send(ctx, tracesFormat, sb.String()); err != nil {
+		return numSpans, err
+	}
+
+	if len(red) > 0 {
+		var redSb strings.Builder
+		red.writeTo(&redSb)
+		if err := e.sender.send(ctx, metricsFormat, redSb.String()); err != nil {
+			// The spans were sent, retrying would send them again.
+			e.logger.Warn("Failed to send the RED metrics derived from the spans", zap.Error(err))
+		}
+	}
+	return dropped, nil
+}
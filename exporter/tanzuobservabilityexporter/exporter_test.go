@@ -0,0 +1,251 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+func testConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Source = "collector"
+	return cfg
+}
+
+func testMetricsData() consumerdata.MetricsData {
+	return consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{Labels: map[string]string{"host.name": "host1", "service.name": "api"}},
+		Metrics: []*metricspb.Metric{
+			{
+				MetricDescriptor: &metricspb.MetricDescriptor{
+					Name:      "requests",
+					Type:      metricspb.MetricDescriptor_CUMULATIVE_INT64,
+					LabelKeys: []*metricspb.LabelKey{{Key: "code"}},
+				},
+				Timeseries: []*metricspb.TimeSeries{{
+					LabelValues: []*metricspb.LabelValue{{Value: "200", HasValue: true}},
+					Points: []*metricspb.Point{{
+						Timestamp: &timestamp.Timestamp{Seconds: 100},
+						Value:     &metricspb.Point_Int64Value{Int64Value: 3},
+					}},
+				}},
+			},
+			{
+				MetricDescriptor: &metricspb.MetricDescriptor{Name: "latency", Type: metricspb.MetricDescriptor_SUMMARY},
+				Timeseries: []*metricspb.TimeSeries{{
+					Points: []*metricspb.Point{{
+						Timestamp: &timestamp.Timestamp{Seconds: 100},
+						Value: &metricspb.Point_SummaryValue{SummaryValue: &metricspb.SummaryValue{
+							Snapshot: &metricspb.SummaryValue_Snapshot{
+								PercentileValues: []*metricspb.SummaryValue_Snapshot_ValueAtPercentile{{Percentile: 99, Value: 0.5}},
+							},
+						}},
+					}},
+				}},
+			},
+			{
+				MetricDescriptor: &metricspb.MetricDescriptor{Name: "", Type: metricspb.MetricDescriptor_GAUGE_DOUBLE},
+				Timeseries:       []*metricspb.TimeSeries{{}},
+			},
+		},
+	}
+}
+
+func TestMetricsToWavefront(t *testing.T) {
+	var sb strings.Builder
+	dropped := metricsToWavefront(&sb, testMetricsData(), newResourceTagger(testConfig()), time.Unix(200, 0))
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t,
+		`"requests" 3 100 source="host1" "code"="200" "service.name"="api"`+"\n"+
+			`"latency.p99" 0.5 100 source="host1" "service.name"="api"`+"\n",
+		sb.String())
+}
+
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().InitEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "api")
+	rs.Resource().Attributes().InsertString("k8s.namespace.name", "default")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(3)
+
+	span := spans.At(0)
+	span.SetName("GET /")
+	span.SetTraceID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetKind(pdata.SpanKindSERVER)
+	span.SetStartTime(pdata.TimestampUnixNano(2 * time.Second))
+	span.SetEndTime(pdata.TimestampUnixNano(2*time.Second + 30*time.Millisecond))
+	span.Attributes().InsertString("http.method", "GET")
+
+	span = spans.At(1)
+	span.SetName("GET /")
+	span.SetTraceID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([]byte{1, 2, 3, 4, 5, 6, 7, 9})
+	span.SetParentSpanID([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetStartTime(pdata.TimestampUnixNano(2 * time.Second))
+	span.SetEndTime(pdata.TimestampUnixNano(2*time.Second + 10*time.Millisecond))
+	span.Status().InitEmpty()
+	span.Status().SetCode(pdata.StatusCode(2))
+
+	// Without a trace ID.
+	spans.At(2).SetName("invalid")
+	return td
+}
+
+func TestTracesToWavefront(t *testing.T) {
+	var sb strings.Builder
+	red := make(redMetrics)
+	numSpans, dropped := tracesToWavefront(&sb, testTraces(), newResourceTagger(testConfig()), "shop", red)
+	assert.Equal(t, 3, numSpans)
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t,
+		`"GET--" source="collector" traceId=01020304-0506-0708-090a-0b0c0d0e0f10 spanId=00000000-0000-0000-0102-030405060708`+
+			` "application"="shop" "http.method"="GET" "k8s.namespace.name"="default" "service"="api" "span.kind"="server" 2000 30`+"\n"+
+			`"GET--" source="collector" traceId=01020304-0506-0708-090a-0b0c0d0e0f10 spanId=00000000-0000-0000-0102-030405060709`+
+			` parent=00000000-0000-0000-0102-030405060708 "application"="shop" "error"="true" "k8s.namespace.name"="default" "service"="api" 2000 10`+"\n",
+		sb.String())
+
+	sb.Reset()
+	red.writeTo(&sb)
+	tags := ` source="collector" "application"="shop" "operation"="GET /" "service"="api"`
+	assert.Equal(t,
+		`"∆tracing.derived.shop.api.GET--.invocation.count" 2`+tags+"\n"+
+			`"∆tracing.derived.shop.api.GET--.error.count" 1`+tags+"\n"+
+			`"∆tracing.derived.shop.api.GET--.total_time.millis.count" 40`+tags+"\n",
+		sb.String())
+}
+
+func TestToUUID(t *testing.T) {
+	_, ok := toUUID(nil)
+	assert.False(t, ok)
+	_, ok = toUUID(make([]byte, 16))
+	assert.False(t, ok)
+	id, ok := toUUID([]byte{0xab})
+	assert.True(t, ok)
+	assert.Equal(t, "00000000-0000-0000-0000-0000000000ab", id)
+}
+
+// listen accepts connections on a local port and sends the data received on
+// each of them to the returned channel.
+func listen(t *testing.T) (int, <-chan string) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			data, _ := ioutil.ReadAll(conn)
+			conn.Close()
+			received <- string(data)
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestPushTracesToProxy(t *testing.T) {
+	metricsPort, metrics := listen(t)
+	tracesPort, traces := listen(t)
+
+	cfg := testConfig()
+	cfg.Proxy.MetricsPort = metricsPort
+	cfg.Proxy.TracesPort = tracesPort
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	dropped, err := exp.pushTraces(context.Background(), testTraces())
+	require.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, 2, strings.Count(<-traces, "\n"))
+	assert.Contains(t, <-metrics, "invocation.count")
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	cfg.Traces.DisableREDMetrics = true
+	_, err = exp.pushTraces(context.Background(), testTraces())
+	require.NoError(t, err)
+	<-traces
+	select {
+	case <-metrics:
+		t.Fatal("the RED metrics are disabled")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestPushMetricsDirectly(t *testing.T) {
+	var received string
+	status := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/report", r.URL.Path)
+		assert.Equal(t, metricsFormat, r.URL.Query().Get("f"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		gz, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(gz)
+		require.NoError(t, err)
+		received = string(body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.DirectIngestion.Endpoint = server.URL
+	cfg.DirectIngestion.Token = "token"
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	defer exp.Shutdown(context.Background())
+
+	md := pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{testMetricsData()})
+	dropped, err := exp.pushMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+	assert.Contains(t, received, `"requests" 3 100 source="host1"`)
+
+	status = http.StatusUnauthorized
+	_, err = exp.pushMetrics(context.Background(), md)
+	assert.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	status = http.StatusServiceUnavailable
+	_, err = exp.pushMetrics(context.Background(), md)
+	assert.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
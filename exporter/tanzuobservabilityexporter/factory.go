@@ -0,0 +1,89 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "tanzuobservability"
+)
+
+// NewFactory creates a factory for Tanzu Observability (Wavefront) exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithTraces(createTraceExporter),
+	)
+}
+
+func createDefaultConfig() configmodels.Exporter {
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Proxy: ProxyConfig{
+			Host:        defaultProxyHost,
+			MetricsPort: defaultMetricsPort,
+			TracesPort:  defaultTracesPort,
+		},
+		ResourceAttributes: ResourceAttributesConfig{
+			MaxTags: defaultMaxTags,
+		},
+		Traces: TracesConfig{
+			Application: defaultApplication,
+		},
+		Timeout: defaultTimeout,
+	}
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	config configmodels.Exporter,
+) (component.MetricsExporter, error) {
+	exp, err := newExporter(config.(*Config), params.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(
+		config,
+		exp.pushMetrics,
+		exporterhelper.WithShutdown(exp.Shutdown))
+}
+
+func createTraceExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	config configmodels.Exporter,
+) (component.TraceExporter, error) {
+	exp, err := newExporter(config.(*Config), params.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewTraceExporter(
+		config,
+		exp.pushTraces,
+		exporterhelper.WithShutdown(exp.Shutdown))
+}
@@ -0,0 +1,147 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+// Suffixes of the metric names of the distributions and summaries.
+const (
+	countSuffix = ".count"
+	sumSuffix   = ".sum"
+)
+
+// metricsToWavefront writes the points of the metrics to sb in the Wavefront
+// data format, the tags being the resource tags and the metric labels. It
+// returns the number of time series that could not be converted.
+//
+// Numbers are written as a single point. Distributions and summaries are
+// written as the ".count" and ".sum" points, plus for summaries a point per
+// percentile, suffixed with ".p" and the percentile, e.g. ".p99".
+func metricsToWavefront(sb *strings.Builder, md consumerdata.MetricsData, rt *resourceTagger, now time.Time) int {
+	source, resourceTags := rt.labelsTags(md.Resource.GetLabels())
+
+	dropped := 0
+	for _, metric := range md.Metrics {
+		if metric == nil || metric.MetricDescriptor == nil {
+			continue
+		}
+		desc := metric.MetricDescriptor
+		if desc.Name == "" {
+			dropped += len(metric.Timeseries)
+			continue
+		}
+		for _, ts := range metric.Timeseries {
+			if ts == nil {
+				continue
+			}
+			tags := copyTags(resourceTags)
+			for i, key := range desc.LabelKeys {
+				if key == nil || i >= len(ts.LabelValues) || ts.LabelValues[i] == nil || !ts.LabelValues[i].HasValue {
+					continue
+				}
+				tags[key.Key] = ts.LabelValues[i].Value
+			}
+
+			written := false
+			for _, pt := range ts.Points {
+				if pt == nil {
+					continue
+				}
+				timestamp := now.Unix()
+				if pt.Timestamp != nil {
+					timestamp = pt.Timestamp.Seconds
+				}
+				written = writePointValues(sb, desc.Name, pt, timestamp, source, tags) || written
+			}
+			if !written {
+				dropped++
+			}
+		}
+	}
+	return dropped
+}
+
+// writePointValues writes the points of an OpenCensus point and reports
+// whether any was written.
+func writePointValues(sb *strings.Builder, name string, pt *metricspb.Point, timestamp int64, source string, tags map[string]string) bool {
+	written := false
+	write := func(name string, value float64) {
+		written = writePoint(sb, name, value, timestamp, source, tags) || written
+	}
+	switch v := pt.Value.(type) {
+	case *metricspb.Point_Int64Value:
+		write(name, float64(v.Int64Value))
+	case *metricspb.Point_DoubleValue:
+		write(name, v.DoubleValue)
+	case *metricspb.Point_DistributionValue:
+		write(name+countSuffix, float64(v.DistributionValue.Count))
+		write(name+sumSuffix, v.DistributionValue.Sum)
+	case *metricspb.Point_SummaryValue:
+		s := v.SummaryValue
+		if s.Count != nil {
+			write(name+countSuffix, float64(s.Count.Value))
+		}
+		if s.Sum != nil {
+			write(name+sumSuffix, s.Sum.Value)
+		}
+		for _, pv := range s.GetSnapshot().GetPercentileValues() {
+			if pv == nil {
+				continue
+			}
+			write(name+".p"+strconv.FormatFloat(pv.Percentile, 'g', -1, 64), pv.Value)
+		}
+	}
+	return written
+}
+
+// writePoint writes a point as a line to sb:
+//
+//	"<name>" <value> [<timestamp>] source="<source>" ["<tag key>"="<tag value>"...]
+//
+// The timestamp is in Unix seconds, and omitted if it is zero. Points whose
+// value cannot be written, e.g. a NaN float, are skipped and false is returned.
+//
+// See https://docs.wavefront.com/wavefront_data_format.html.
+func writePoint(sb *strings.Builder, name string, value float64, timestamp int64, source string, tags map[string]string) bool {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return false
+	}
+	writeName(sb, name)
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	if timestamp != 0 {
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.FormatInt(timestamp, 10))
+	}
+	writeTags(sb, source, tags)
+	sb.WriteByte('\n')
+	return true
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	c := make(map[string]string, len(tags))
+	for k, v := range tags {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,136 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// sender sends lines in one of the Wavefront formats.
+type sender interface {
+	send(ctx context.Context, format string, lines string) error
+	close()
+}
+
+func newSender(cfg *Config) sender {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	if cfg.DirectIngestion.Endpoint != "" {
+		return &directSender{
+			url:    strings.TrimSuffix(cfg.DirectIngestion.Endpoint, "/") + "/report",
+			token:  cfg.DirectIngestion.Token,
+			client: &http.Client{Timeout: timeout},
+		}
+	}
+	return &proxySender{
+		addresses: map[string]string{
+			metricsFormat: cfg.proxyAddress(cfg.Proxy.MetricsPort),
+			tracesFormat:  cfg.proxyAddress(cfg.Proxy.TracesPort),
+		},
+		timeout: timeout,
+	}
+}
+
+// proxySender sends the lines to the ports of a Wavefront proxy over TCP, a
+// connection per call.
+type proxySender struct {
+	addresses map[string]string
+	timeout   time.Duration
+}
+
+func (s *proxySender) send(ctx context.Context, format string, lines string) error {
+	if lines == "" {
+		return nil
+	}
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addresses[format])
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+	_, err = io.WriteString(conn, lines)
+	return err
+}
+
+func (s *proxySender) close() {}
+
+// directSender posts the lines to the direct ingestion API of a Wavefront
+// cluster.
+type directSender struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func (s *directSender) send(ctx context.Context, format string, lines string) error {
+	if lines == "" {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(gz, lines); err != nil {
+		return consumererror.Permanent(err)
+	}
+	if err := gz.Close(); err != nil {
+		return consumererror.Permanent(err)
+	}
+
+	req, err := http.NewRequest("POST", s.url+"?f="+format, &buf)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return consumererror.Permanent(err)
+	}
+	return err
+}
+
+func (s *directSender) close() {
+	s.client.CloseIdleConnections()
+}
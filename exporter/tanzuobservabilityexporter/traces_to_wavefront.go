@@ -0,0 +1,262 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// Tag keys of the spans and RED metrics.
+const (
+	applicationTagKey = "application"
+	serviceTagKey     = "service"
+	operationTagKey   = "operation"
+	spanKindTagKey    = "span.kind"
+	errorTagKey       = "error"
+)
+
+// redMetricPrefix prefixes the names of the RED metrics, the "∆" making them
+// delta counters summed by Wavefront.
+const redMetricPrefix = "∆tracing.derived."
+
+// tracesToWavefront writes a line per span to sb in the Wavefront span
+// format. The tags are the resource tags, the application and service, the
+// span kind and error, followed by the span attributes. The invocations,
+// errors and duration of the spans are added to red, unless it is nil. It
+// returns the number of spans, and the number of spans dropped because of an
+// invalid trace or span ID.
+func tracesToWavefront(sb *strings.Builder, td pdata.Traces, rt *resourceTagger, defaultApp string, red redMetrics) (int, int) {
+	numSpans, dropped := 0, 0
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		source, resourceTags := rt.tags(rs.Resource())
+		application, service := applicationAndService(rs.Resource(), defaultApp)
+		delete(resourceTags, applicationAttribute)
+		delete(resourceTags, conventions.AttributeServiceName)
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				numSpans++
+
+				traceID, ok := toUUID(span.TraceID())
+				if !ok {
+					dropped++
+					continue
+				}
+				spanID, ok := toUUID(span.SpanID())
+				if !ok {
+					dropped++
+					continue
+				}
+
+				tags := copyTags(resourceTags)
+				span.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+					if s := attributeValueToString(v); s != "" {
+						tags[k] = s
+					}
+				})
+				tags[applicationTagKey] = application
+				tags[serviceTagKey] = service
+				if kind := spanKind(span.Kind()); kind != "" {
+					tags[spanKindTagKey] = kind
+				}
+				isError := false
+				if status := span.Status(); !status.IsNil() && int32(status.Code()) != 0 {
+					isError = true
+					tags[errorTagKey] = "true"
+				}
+
+				start := int64(span.StartTime()) / int64(time.Millisecond)
+				duration := (int64(span.EndTime()) - int64(span.StartTime())) / int64(time.Millisecond)
+				if duration < 0 {
+					duration = 0
+				}
+
+				writeName(sb, span.Name())
+				sb.WriteString(" source=")
+				writeQuoted(sb, source)
+				sb.WriteString(" traceId=")
+				sb.WriteString(traceID)
+				sb.WriteString(" spanId=")
+				sb.WriteString(spanID)
+				if parentID, ok := toUUID(span.ParentSpanID()); ok {
+					sb.WriteString(" parent=")
+					sb.WriteString(parentID)
+				}
+				keys := make([]string, 0, len(tags))
+				for k := range tags {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				for _, k := range keys {
+					writeTag(sb, k, tags[k])
+				}
+				sb.WriteByte(' ')
+				sb.WriteString(strconv.FormatInt(start, 10))
+				sb.WriteByte(' ')
+				sb.WriteString(strconv.FormatInt(duration, 10))
+				sb.WriteByte('\n')
+
+				if red != nil {
+					red.add(redKey{source: source, application: application, service: service, operation: span.Name()}, isError, duration)
+				}
+			}
+		}
+	}
+	return numSpans, dropped
+}
+
+// applicationAndService returns the application and service of the spans of
+// a resource, which Wavefront requires.
+func applicationAndService(resource pdata.Resource, defaultApp string) (string, string) {
+	application, service := defaultApp, defaultService
+	if resource.IsNil() {
+		return application, service
+	}
+	if v, ok := resource.Attributes().Get(applicationAttribute); ok {
+		if s := attributeValueToString(v); s != "" {
+			application = s
+		}
+	}
+	if v, ok := resource.Attributes().Get(conventions.AttributeServiceName); ok {
+		if s := attributeValueToString(v); s != "" {
+			service = s
+		}
+	}
+	return application, service
+}
+
+func spanKind(kind pdata.SpanKind) string {
+	switch kind {
+	case pdata.SpanKindINTERNAL:
+		return "internal"
+	case pdata.SpanKindSERVER:
+		return "server"
+	case pdata.SpanKindCLIENT:
+		return "client"
+	case pdata.SpanKindPRODUCER:
+		return "producer"
+	case pdata.SpanKindCONSUMER:
+		return "consumer"
+	}
+	return ""
+}
+
+// toUUID formats a trace or span ID as the UUID Wavefront requires, span IDs
+// being padded with leading zeros. It returns false if the ID is empty, all
+// zeros or longer than 16 bytes.
+func toUUID(id []byte) (string, bool) {
+	if len(id) == 0 || len(id) > 16 {
+		return "", false
+	}
+	var b [16]byte
+	copy(b[16-len(id):], id)
+	if b == [16]byte{} {
+		return "", false
+	}
+	s := hex.EncodeToString(b[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32], true
+}
+
+// redKey identifies the RED metrics of an operation.
+type redKey struct {
+	source      string
+	application string
+	service     string
+	operation   string
+}
+
+// redValues are the RED metrics of an operation.
+type redValues struct {
+	invocations int64
+	errors      int64
+	totalMillis int64
+}
+
+// redMetrics accumulates the rate, errors and duration of the operations.
+type redMetrics map[redKey]*redValues
+
+func (r redMetrics) add(key redKey, isError bool, durationMillis int64) {
+	v, ok := r[key]
+	if !ok {
+		v = &redValues{}
+		r[key] = v
+	}
+	v.invocations++
+	if isError {
+		v.errors++
+	}
+	v.totalMillis += durationMillis
+}
+
+// writeTo writes the RED metrics to sb as delta counters named after the
+// application, service and operation, e.g.
+// "∆tracing.derived.app.svc.op.invocation.count". The error counter is only
+// written for operations with errors.
+func (r redMetrics) writeTo(sb *strings.Builder) {
+	keys := make([]redKey, 0, len(r))
+	for k := range r {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.source != b.source {
+			return a.source < b.source
+		}
+		if a.application != b.application {
+			return a.application < b.application
+		}
+		if a.service != b.service {
+			return a.service < b.service
+		}
+		return a.operation < b.operation
+	})
+
+	for _, k := range keys {
+		v := r[k]
+		prefix := redMetricPrefix + k.application + "." + k.service + "." + k.operation
+		tags := map[string]string{
+			applicationTagKey: k.application,
+			serviceTagKey:     k.service,
+			operationTagKey:   k.operation,
+		}
+		writePoint(sb, prefix+".invocation.count", float64(v.invocations), 0, k.source, tags)
+		if v.errors > 0 {
+			writePoint(sb, prefix+".error.count", float64(v.errors), 0, k.source, tags)
+		}
+		writePoint(sb, prefix+".total_time.millis.count", float64(v.totalMillis), 0, k.source, tags)
+	}
+}
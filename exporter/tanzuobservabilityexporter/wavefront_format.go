@@ -0,0 +1,225 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Formats of the Wavefront data, as named by the "f" parameter of the direct
+// ingestion API.
+const (
+	metricsFormat = "wavefront"
+	tracesFormat  = "trace"
+)
+
+// Resource attributes with a special meaning in Wavefront.
+const (
+	sourceAttribute      = "host.name"
+	applicationAttribute = "application"
+)
+
+// maxTagLength is the maximum length of the key and value of a tag.
+const maxTagLength = 254
+
+// highCardinalityAttributes are the resource attributes not converted to tags,
+// unless explicitly included, because of their many values.
+var highCardinalityAttributes = map[string]bool{
+	"container.id":         true,
+	"host.id":              true,
+	"k8s.pod.uid":          true,
+	"process.command_line": true,
+	"process.pid":          true,
+	"service.instance.id":  true,
+}
+
+// resourceTagger converts the resource attributes to the source and tags of
+// the points and spans.
+type resourceTagger struct {
+	source  string
+	include map[string]bool
+	exclude map[string]bool
+	maxTags int
+}
+
+func newResourceTagger(cfg *Config) *resourceTagger {
+	rt := &resourceTagger{
+		source:  cfg.Source,
+		exclude: make(map[string]bool, len(cfg.ResourceAttributes.Exclude)),
+		maxTags: cfg.ResourceAttributes.MaxTags,
+	}
+	if len(cfg.ResourceAttributes.Include) > 0 {
+		rt.include = make(map[string]bool, len(cfg.ResourceAttributes.Include))
+		for _, attr := range cfg.ResourceAttributes.Include {
+			rt.include[attr] = true
+		}
+	}
+	for _, attr := range cfg.ResourceAttributes.Exclude {
+		rt.exclude[attr] = true
+	}
+	return rt
+}
+
+// tags returns the source and tags of a resource.
+func (rt *resourceTagger) tags(resource pdata.Resource) (string, map[string]string) {
+	labels := make(map[string]string)
+	if !resource.IsNil() {
+		resource.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+			if s := attributeValueToString(v); s != "" {
+				labels[k] = s
+			}
+		})
+	}
+	return rt.labelsTags(labels)
+}
+
+// labelsTags returns the source and tags of the resource labels. The source
+// is the "host.name" label, which is not a tag.
+func (rt *resourceTagger) labelsTags(labels map[string]string) (string, map[string]string) {
+	source := rt.source
+	if s := labels[sourceAttribute]; s != "" {
+		source = s
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k, v := range labels {
+		if v == "" || k == sourceAttribute {
+			continue
+		}
+		if rt.include != nil {
+			if !rt.include[k] {
+				continue
+			}
+		} else if highCardinalityAttributes[k] {
+			continue
+		}
+		if rt.exclude[k] {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if rt.maxTags > 0 && len(keys) > rt.maxTags {
+		keys = keys[:rt.maxTags]
+	}
+
+	tags := make(map[string]string, len(keys))
+	for _, k := range keys {
+		tags[k] = labels[k]
+	}
+	return source, tags
+}
+
+// writeName writes a metric or span name, quoted.
+func writeName(sb *strings.Builder, name string) {
+	sb.WriteByte('"')
+	sb.WriteString(sanitizeName(name))
+	sb.WriteByte('"')
+}
+
+// writeTags writes the source and tags, sorted by key, each preceded by a
+// space.
+func writeTags(sb *strings.Builder, source string, tags map[string]string) {
+	sb.WriteString(` source=`)
+	writeQuoted(sb, source)
+
+	keys := make([]string, 0, len(tags))
+	for k, v := range tags {
+		if k != "" && v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeTag(sb, k, tags[k])
+	}
+}
+
+// writeTag writes a tag preceded by a space, its value being truncated so
+// that the tag does not exceed the maximum length.
+func writeTag(sb *strings.Builder, key, value string) {
+	key = sanitizeTagKey(key)
+	value = truncate(value, maxTagLength-len(key))
+	sb.WriteByte(' ')
+	writeQuoted(sb, key)
+	sb.WriteByte('=')
+	writeQuoted(sb, value)
+}
+
+func writeQuoted(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	sb.WriteString(valueEscaper.Replace(s))
+	sb.WriteByte('"')
+}
+
+var valueEscaper = strings.NewReplacer(`"`, `\"`, "\n", `\n`)
+
+// sanitizeName replaces the characters not allowed in metric and span names
+// with hyphens.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if isAlphanumeric(r) || r == '.' || r == '_' || r == '-' || r == '~' || r == '∆' || r == 'Δ' {
+			return r
+		}
+		return '-'
+	}, name)
+}
+
+// sanitizeTagKey replaces the characters not allowed in tag keys with hyphens.
+func sanitizeTagKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if isAlphanumeric(r) || r == '.' || r == '_' || r == '-' {
+			return r
+		}
+		return '-'
+	}, key)
+}
+
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// truncate truncates s to at most n bytes, without splitting a character.
+func truncate(s string, n int) string {
+	if n < 0 {
+		n = 0
+	}
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func attributeValueToString(v pdata.AttributeValue) string {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return strconv.FormatInt(v.IntVal(), 10)
+	case pdata.AttributeValueDOUBLE:
+		return strconv.FormatFloat(v.DoubleVal(), 'g', -1, 64)
+	case pdata.AttributeValueBOOL:
+		return strconv.FormatBool(v.BoolVal())
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceTagger(t *testing.T) {
+	labels := map[string]string{
+		"host.name":          "host1",
+		"service.name":       "api",
+		"k8s.namespace.name": "default",
+		"process.pid":        "1234",
+		"host.arch":          "amd64",
+		"empty":              "",
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Source = "collector"
+	rt := newResourceTagger(cfg)
+	source, tags := rt.labelsTags(labels)
+	assert.Equal(t, "host1", source)
+	assert.Equal(t, map[string]string{
+		"service.name":       "api",
+		"k8s.namespace.name": "default",
+		"host.arch":          "amd64",
+	}, tags)
+
+	source, _ = rt.labelsTags(nil)
+	assert.Equal(t, "collector", source)
+
+	cfg.ResourceAttributes.Exclude = []string{"host.arch"}
+	cfg.ResourceAttributes.MaxTags = 1
+	_, tags = newResourceTagger(cfg).labelsTags(labels)
+	assert.Equal(t, map[string]string{"k8s.namespace.name": "default"}, tags)
+
+	cfg.ResourceAttributes.Include = []string{"service.name", "process.pid"}
+	cfg.ResourceAttributes.MaxTags = 0
+	_, tags = newResourceTagger(cfg).labelsTags(labels)
+	assert.Equal(t, map[string]string{"service.name": "api", "process.pid": "1234"}, tags)
+}
+
+func TestWritePoint(t *testing.T) {
+	var sb strings.Builder
+	assert.True(t, writePoint(&sb, "http requests/total", 1.5, 100, "host1", map[string]string{
+		"path":      `/a "b"`,
+		"tag key!":  "v",
+		"empty":     "",
+		"multiline": "a\nb",
+	}))
+	assert.False(t, writePoint(&sb, "nan", math.NaN(), 100, "host1", nil))
+	assert.True(t, writePoint(&sb, "∆counter", 2, 0, "host1", nil))
+	assert.Equal(t,
+		`"http-requests-total" 1.5 100 source="host1" "multiline"="a\nb" "path"="/a \"b\"" "tag-key-"="v"`+"\n"+
+			`"∆counter" 2 source="host1"`+"\n",
+		sb.String())
+}
+
+func TestWriteTagTruncates(t *testing.T) {
+	var sb strings.Builder
+	writeTag(&sb, "key", strings.Repeat("é", 200))
+	value := strings.TrimSuffix(strings.TrimPrefix(sb.String(), ` "key"="`), `"`)
+	assert.LessOrEqual(t, len("key")+len(value), maxTagLength)
+	assert.Equal(t, strings.Repeat("é", 125), value)
+}
@@ -62,6 +62,9 @@ type HostPort struct {
 	Name string
 	// Command used to invoke the process using the Endpoint.
 	Command string
+	// Username of the owner of the process using the Endpoint. Empty if
+	// it could not be determined.
+	Username string
 	// Port number of the endpoint.
 	Port uint16
 	// Transport is the transport protocol used by the Endpoint. (TCP or UDP).
@@ -110,6 +113,7 @@ func EndpointToEnv(endpoint Endpoint) (EndpointEnv, error) {
 			"endpoint":  endpoint.Target,
 			"name":      o.Name,
 			"command":   o.Command,
+			"username":  o.Username,
 			"is_ipv6":   o.IsIPv6,
 			"port":      o.Port,
 			"transport": o.Transport,
@@ -106,6 +106,7 @@ func TestEndpointToEnv(t *testing.T) {
 				Details: HostPort{
 					Name:      "process_name",
 					Command:   "./cmd --config config.yaml",
+					Username:  "otel",
 					Port:      2379,
 					Transport: ProtocolUDP,
 					IsIPv6:    true,
@@ -119,6 +120,7 @@ func TestEndpointToEnv(t *testing.T) {
 				"endpoint":  "127.0.0.1",
 				"name":      "process_name",
 				"command":   "./cmd --config config.yaml",
+				"username":  "otel",
 				"is_ipv6":   true,
 				"port":      uint16(2379),
 				"transport": ProtocolUDP,
@@ -161,6 +161,7 @@ func (e endpointsLister) collectEndpoints(conns []net.ConnectionStat) []observer
 				Details: observer.HostPort{
 					Name:      pd.name,
 					Command:   pd.args,
+					Username:  pd.username,
 					Port:      cd.port,
 					Transport: cd.transport,
 					// TODO: Move this field to observer.Endpoint and
@@ -212,8 +213,9 @@ func collectConnectionDetails(c *net.ConnectionStat) connectionDetails {
 }
 
 type processDetails struct {
-	name string
-	args string
+	name     string
+	args     string
+	username string
 }
 
 func collectProcessDetails(proc *process.Process) (*processDetails, error) {
@@ -227,9 +229,17 @@ func collectProcessDetails(proc *process.Process) (*processDetails, error) {
 		return nil, fmt.Errorf("could not get process args: %v", err)
 	}
 
+	// The owning user might not be resolvable (e.g. the uid has no passwd entry
+	// inside a container) so don't skip the process if it's missing.
+	username, err := getProcessUsername(proc)
+	if err != nil {
+		username = ""
+	}
+
 	return &processDetails{
-		name: name,
-		args: args,
+		name:     name,
+		args:     args,
+		username: username,
 	}, nil
 }
 
@@ -241,6 +251,10 @@ var getProcessArgs = func(proc *process.Process) (string, error) {
 	return proc.Cmdline()
 }
 
+var getProcessUsername = func(proc *process.Process) (string, error) {
+	return proc.Username()
+}
+
 func portTypeToProtocol(t uint32) observer.Transport {
 	switch t {
 	case syscall.SOCK_STREAM:
@@ -18,6 +18,7 @@ package hostobserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -28,6 +29,7 @@ import (
 	"time"
 
 	psnet "github.com/shirou/gopsutil/net"
+	"github.com/shirou/gopsutil/process"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -371,6 +373,85 @@ func TestCollectEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Listening TCP socket with process info",
+			conns: []psnet.ConnectionStat{
+				{
+					Family: syscall.AF_INET,
+					Type:   syscall.SOCK_STREAM,
+					Laddr: psnet.Addr{
+						IP:   "123.345.567.789",
+						Port: 80,
+					},
+					Status: "LISTEN",
+					Pid:    int32(selfPid),
+				},
+			},
+			overrideProcessInfoMethods: func() {
+				getProcessName = func(proc *process.Process) (string, error) {
+					return "redis-server", nil
+				}
+				getProcessArgs = func(proc *process.Process) (string, error) {
+					return "redis-server *:6379", nil
+				}
+				getProcessUsername = func(proc *process.Process) (string, error) {
+					return "redis", nil
+				}
+			},
+			want: []observer.Endpoint{
+				{
+					ID:     observer.EndpointID(fmt.Sprintf("()123.345.567.789-80-TCP-%d", selfPid)),
+					Target: "123.345.567.789:80",
+					Details: observer.HostPort{
+						Name:      "redis-server",
+						Command:   "redis-server *:6379",
+						Username:  "redis",
+						Port:      80,
+						Transport: observer.ProtocolTCP,
+						IsIPv6:    false,
+					},
+				},
+			},
+		},
+		{
+			name: "Unresolvable process username",
+			conns: []psnet.ConnectionStat{
+				{
+					Family: syscall.AF_INET,
+					Type:   syscall.SOCK_STREAM,
+					Laddr: psnet.Addr{
+						IP:   "123.345.567.789",
+						Port: 80,
+					},
+					Status: "LISTEN",
+					Pid:    int32(selfPid),
+				},
+			},
+			overrideProcessInfoMethods: func() {
+				getProcessName = func(proc *process.Process) (string, error) {
+					return "redis-server", nil
+				}
+				getProcessArgs = func(proc *process.Process) (string, error) {
+					return "redis-server *:6379", nil
+				}
+				getProcessUsername = func(proc *process.Process) (string, error) {
+					return "", errors.New("unknown userid 1000")
+				}
+			},
+			want: []observer.Endpoint{
+				{
+					ID:     observer.EndpointID(fmt.Sprintf("()123.345.567.789-80-TCP-%d", selfPid)),
+					Target: "123.345.567.789:80",
+					Details: observer.HostPort{
+						Name:      "redis-server",
+						Command:   "redis-server *:6379",
+						Port:      80,
+						Transport: observer.ProtocolTCP,
+						IsIPv6:    false,
+					},
+				},
+			},
+		},
 		{
 			name: "TCP socket that's not listening",
 			conns: []psnet.ConnectionStat{
@@ -406,6 +487,13 @@ func TestCollectEndpoints(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.overrideProcessInfoMethods != nil {
+				origName, origArgs, origUsername := getProcessName, getProcessArgs, getProcessUsername
+				tt.overrideProcessInfoMethods()
+				defer func() {
+					getProcessName, getProcessArgs, getProcessUsername = origName, origArgs, origUsername
+				}()
+			}
 			e := endpointsLister{
 				logger: zap.NewNop(),
 			}
@@ -0,0 +1,34 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwlogs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewHTTPClientNoVerifySSL(t *testing.T) {
+	client, err := newHTTPClient(zap.NewNop(), 1, 1, false, "")
+	require.NoError(t, err)
+	assert.False(t, client.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+
+	client, err = newHTTPClient(zap.NewNop(), 1, 1, true, "")
+	require.NoError(t, err)
+	assert.True(t, client.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+}
@@ -0,0 +1,197 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cwlogs sends log events to CloudWatch Logs, it holds the AWS session
+// and log stream handling shared by the exporters sending to CloudWatch Logs.
+package cwlogs
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+// Limits of the PutLogEvents API.
+const (
+	maxEventsPerBatch = 10000
+	maxBatchSize      = 1048576
+	// eventOverhead is the number of bytes counted for each event in addition
+	// to the size of its message.
+	eventOverhead = 26
+	maxEventSize  = 256 * 1024
+	maxBatchSpan  = 24 * time.Hour
+	// maxSequenceTokenRetries is the number of times a batch is sent again
+	// with the expected sequence token.
+	maxSequenceTokenRetries = 3
+)
+
+var errEventTooLarge = errors.New("the log event exceeds the maximum event size")
+
+// Pusher sends log events to a CloudWatch Logs log stream, creating the
+// log group and stream if they do not exist. The events are sent in batches
+// under the limits of the PutLogEvents API, one at a time as each batch
+// requires the sequence token returned for the previous one.
+type Pusher struct {
+	client    cloudwatchlogsiface.CloudWatchLogsAPI
+	logGroup  string
+	logStream string
+	logger    *zap.Logger
+
+	mu            sync.Mutex
+	sequenceToken *string
+}
+
+// NewPusher returns a pusher of the log events of logStream in logGroup.
+func NewPusher(client cloudwatchlogsiface.CloudWatchLogsAPI, logGroup, logStream string, logger *zap.Logger) *Pusher {
+	return &Pusher{
+		client:    client,
+		logGroup:  logGroup,
+		logStream: logStream,
+		logger:    logger,
+	}
+}
+
+// Push sends the events in chronological order. The events larger than the
+// maximum event size are dropped, and returned in the number of dropped
+// events along with the events of the batches that could not be sent.
+func (p *Pusher) Push(ctx context.Context, events []*cloudwatchlogs.InputLogEvent) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return aws.Int64Value(events[i].Timestamp) < aws.Int64Value(events[j].Timestamp)
+	})
+
+	dropped := 0
+	var errs []error
+	var batch []*cloudwatchlogs.InputLogEvent
+	batchSize := 0
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := p.putLogEvents(ctx, batch); err != nil {
+			dropped += len(batch)
+			errs = append(errs, err)
+		}
+		batch = nil
+		batchSize = 0
+	}
+	for _, e := range events {
+		size := len(aws.StringValue(e.Message)) + eventOverhead
+		if size > maxEventSize {
+			dropped++
+			errs = append(errs, consumererror.Permanent(errEventTooLarge))
+			continue
+		}
+		if len(batch) == maxEventsPerBatch || batchSize+size > maxBatchSize ||
+			(len(batch) > 0 && time.Duration(aws.Int64Value(e.Timestamp)-aws.Int64Value(batch[0].Timestamp))*time.Millisecond > maxBatchSpan) {
+			flush()
+		}
+		batch = append(batch, e)
+		batchSize += size
+	}
+	flush()
+
+	if len(errs) > 0 {
+		return dropped, errs[0]
+	}
+	return 0, nil
+}
+
+// putLogEvents sends a batch, creating the log group and stream when they do
+// not exist, and retrying with the expected sequence token when it is invalid.
+func (p *Pusher) putLogEvents(ctx context.Context, batch []*cloudwatchlogs.InputLogEvent) error {
+	input := &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String(p.logGroup),
+		LogStreamName: aws.String(p.logStream),
+		LogEvents:     batch,
+	}
+	created := false
+	for retries := 0; ; retries++ {
+		input.SequenceToken = p.sequenceToken
+		output, err := p.client.PutLogEventsWithContext(ctx, input)
+		if err == nil {
+			p.sequenceToken = output.NextSequenceToken
+			if info := output.RejectedLogEventsInfo; info != nil {
+				p.logger.Warn("Some log events were rejected", zap.String("info", info.String()))
+			}
+			return nil
+		}
+
+		switch e := err.(type) {
+		case *cloudwatchlogs.InvalidSequenceTokenException:
+			p.sequenceToken = e.ExpectedSequenceToken
+			if retries < maxSequenceTokenRetries {
+				continue
+			}
+		case *cloudwatchlogs.DataAlreadyAcceptedException:
+			p.sequenceToken = e.ExpectedSequenceToken
+			return nil
+		case *cloudwatchlogs.ResourceNotFoundException:
+			if !created {
+				created = true
+				if err := p.createLogStream(ctx); err != nil {
+					return err
+				}
+				p.sequenceToken = nil
+				continue
+			}
+		case *cloudwatchlogs.InvalidParameterException:
+			return consumererror.Permanent(err)
+		}
+		return err
+	}
+}
+
+// createLogStream creates the log stream, and the log group if it does not
+// exist.
+func (p *Pusher) createLogStream(ctx context.Context) error {
+	_, err := p.client.CreateLogStreamWithContext(ctx, &cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  aws.String(p.logGroup),
+		LogStreamName: aws.String(p.logStream),
+	})
+	if _, ok := err.(*cloudwatchlogs.ResourceNotFoundException); ok {
+		_, err = p.client.CreateLogGroupWithContext(ctx, &cloudwatchlogs.CreateLogGroupInput{
+			LogGroupName: aws.String(p.logGroup),
+		})
+		if err != nil && !isAlreadyExists(err) {
+			return err
+		}
+		_, err = p.client.CreateLogStreamWithContext(ctx, &cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(p.logGroup),
+			LogStreamName: aws.String(p.logStream),
+		})
+	}
+	if err != nil && !isAlreadyExists(err) {
+		return err
+	}
+	p.logger.Info("Created the CloudWatch Logs log stream",
+		zap.String("log_group", p.logGroup), zap.String("log_stream", p.logStream))
+	return nil
+}
+
+func isAlreadyExists(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == cloudwatchlogs.ErrCodeResourceAlreadyExistsException
+}
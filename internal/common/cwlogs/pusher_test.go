@@ -0,0 +1,177 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwlogs
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+// fakeCloudWatchLogs is a log stream checking the sequence tokens of the
+// PutLogEvents calls.
+type fakeCloudWatchLogs struct {
+	cloudwatchlogsiface.CloudWatchLogsAPI
+
+	groupExists   bool
+	streamExists  bool
+	sequenceToken int
+	putErr        error
+	batches       [][]*cloudwatchlogs.InputLogEvent
+	created       []string
+}
+
+func (f *fakeCloudWatchLogs) PutLogEventsWithContext(_ aws.Context, input *cloudwatchlogs.PutLogEventsInput, _ ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	if f.putErr != nil {
+		return nil, f.putErr
+	}
+	if !f.streamExists {
+		return nil, &cloudwatchlogs.ResourceNotFoundException{}
+	}
+	expected := f.token()
+	if aws.StringValue(input.SequenceToken) != aws.StringValue(expected) {
+		return nil, &cloudwatchlogs.InvalidSequenceTokenException{ExpectedSequenceToken: expected}
+	}
+	f.batches = append(f.batches, input.LogEvents)
+	f.sequenceToken++
+	return &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: f.token()}, nil
+}
+
+func (f *fakeCloudWatchLogs) CreateLogStreamWithContext(_ aws.Context, input *cloudwatchlogs.CreateLogStreamInput, _ ...request.Option) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	if !f.groupExists {
+		return nil, &cloudwatchlogs.ResourceNotFoundException{}
+	}
+	f.streamExists = true
+	f.created = append(f.created, aws.StringValue(input.LogStreamName))
+	return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+}
+
+func (f *fakeCloudWatchLogs) CreateLogGroupWithContext(_ aws.Context, input *cloudwatchlogs.CreateLogGroupInput, _ ...request.Option) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	f.groupExists = true
+	f.created = append(f.created, aws.StringValue(input.LogGroupName))
+	return &cloudwatchlogs.CreateLogGroupOutput{}, nil
+}
+
+// token returns the sequence token expected by the stream, nil before the
+// first batch.
+func (f *fakeCloudWatchLogs) token() *string {
+	if f.sequenceToken == 0 {
+		return nil
+	}
+	return aws.String(strconv.Itoa(f.sequenceToken))
+}
+
+func testEvents(n int, timestamp int64, message string) []*cloudwatchlogs.InputLogEvent {
+	events := make([]*cloudwatchlogs.InputLogEvent, n)
+	for i := range events {
+		events[i] = &cloudwatchlogs.InputLogEvent{
+			Message:   aws.String(message),
+			Timestamp: aws.Int64(timestamp + int64(i)),
+		}
+	}
+	return events
+}
+
+func TestPushCreatesLogStream(t *testing.T) {
+	client := &fakeCloudWatchLogs{}
+	pusher := NewPusher(client, "group", "stream", zap.NewNop())
+
+	dropped, err := pusher.Push(context.Background(), testEvents(2, 1000, "{}"))
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+	assert.Equal(t, []string{"group", "stream"}, client.created)
+	require.Len(t, client.batches, 1)
+	assert.Len(t, client.batches[0], 2)
+
+	// The next batch uses the sequence token returned for the first one.
+	_, err = pusher.Push(context.Background(), testEvents(1, 2000, "{}"))
+	require.NoError(t, err)
+	assert.Len(t, client.batches, 2)
+	assert.Equal(t, "2", aws.StringValue(pusher.sequenceToken))
+}
+
+func TestPushInvalidSequenceToken(t *testing.T) {
+	client := &fakeCloudWatchLogs{groupExists: true, streamExists: true, sequenceToken: 5}
+	pusher := NewPusher(client, "group", "stream", zap.NewNop())
+
+	_, err := pusher.Push(context.Background(), testEvents(1, 1000, "{}"))
+	require.NoError(t, err)
+	assert.Len(t, client.batches, 1)
+	assert.Equal(t, "6", aws.StringValue(pusher.sequenceToken))
+}
+
+func TestPushBatches(t *testing.T) {
+	client := &fakeCloudWatchLogs{groupExists: true, streamExists: true}
+	pusher := NewPusher(client, "group", "stream", zap.NewNop())
+
+	// Split by number of events.
+	_, err := pusher.Push(context.Background(), testEvents(maxEventsPerBatch+1, 1000, "{}"))
+	require.NoError(t, err)
+	require.Len(t, client.batches, 2)
+	assert.Len(t, client.batches[0], maxEventsPerBatch)
+	assert.Len(t, client.batches[1], 1)
+
+	// Split by size.
+	client.batches = nil
+	message := strings.Repeat("a", 200*1024)
+	_, err = pusher.Push(context.Background(), testEvents(6, 1000, message))
+	require.NoError(t, err)
+	require.Len(t, client.batches, 2)
+	assert.Len(t, client.batches[0], 5)
+	assert.Len(t, client.batches[1], 1)
+
+	// Split by time span, the events being sorted.
+	client.batches = nil
+	events := []*cloudwatchlogs.InputLogEvent{
+		{Message: aws.String("late"), Timestamp: aws.Int64(maxBatchSpan.Milliseconds() + 2000)},
+		{Message: aws.String("early"), Timestamp: aws.Int64(1000)},
+	}
+	_, err = pusher.Push(context.Background(), events)
+	require.NoError(t, err)
+	require.Len(t, client.batches, 2)
+	assert.Equal(t, "early", aws.StringValue(client.batches[0][0].Message))
+	assert.Equal(t, "late", aws.StringValue(client.batches[1][0].Message))
+}
+
+func TestPushErrors(t *testing.T) {
+	client := &fakeCloudWatchLogs{groupExists: true, streamExists: true}
+	pusher := NewPusher(client, "group", "stream", zap.NewNop())
+
+	events := append(testEvents(1, 1000, strings.Repeat("a", maxEventSize)), testEvents(1, 2000, "{}")...)
+	dropped, err := pusher.Push(context.Background(), events)
+	assert.Equal(t, 1, dropped)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Len(t, client.batches, 1)
+
+	client.putErr = &cloudwatchlogs.InvalidParameterException{}
+	dropped, err = pusher.Push(context.Background(), testEvents(3, 1000, "{}"))
+	assert.Equal(t, 3, dropped)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	client.putErr = &cloudwatchlogs.ServiceUnavailableException{}
+	_, err = pusher.Push(context.Background(), testEvents(1, 1000, "{}"))
+	assert.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
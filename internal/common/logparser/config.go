@@ -0,0 +1,133 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logparser decodes and parses the raw log lines received by the log
+// receivers, with a pipeline of stanza-style operators shared by all of
+// them.
+package logparser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+)
+
+const (
+	// ParserJSON parses the lines as JSON objects.
+	ParserJSON = "json"
+	// ParserRegex parses the lines with a regular expression.
+	ParserRegex = "regex"
+)
+
+// Config defines how the log lines are decoded and parsed.
+type Config struct {
+	// Encoding is the encoding of the lines: utf-8, utf-16le, utf-16be or
+	// nop to keep the raw bytes. utf-8 by default.
+	Encoding string `mapstructure:"encoding"`
+
+	// Parser is the parser of the lines, json or regex. The lines are not
+	// parsed by default.
+	Parser string `mapstructure:"parser"`
+
+	// Regex is the regular expression of the regex parser. Its named
+	// capture groups become the attributes of the logs.
+	Regex string `mapstructure:"regex"`
+
+	// Operators are applied in order to the lines, after the parser.
+	Operators []OperatorConfig `mapstructure:"operators"`
+}
+
+// Validate checks that the configuration is valid.
+func (c *Config) Validate() error {
+	if _, err := lookupEncoding(c.Encoding); err != nil {
+		return err
+	}
+	if _, err := c.operators(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// operators returns the operators of the pipeline: the parser, if any,
+// followed by the configured operators.
+func (c *Config) operators() ([]operator, error) {
+	var ops []operator
+	parserCfg, err := c.parserOperator()
+	if err != nil {
+		return nil, err
+	}
+	if parserCfg != nil {
+		op, err := newOperator(*parserCfg)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	for i, cfg := range c.Operators {
+		op, err := newOperator(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("operators[%d]: %w", i, err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// parserOperator returns the configuration of the operator of the parser,
+// nil if the lines are not parsed.
+func (c *Config) parserOperator() (*OperatorConfig, error) {
+	if c.Parser != ParserRegex && c.Regex != "" {
+		return nil, fmt.Errorf("regex is only supported by the %s parser", ParserRegex)
+	}
+	switch c.Parser {
+	case "":
+		return nil, nil
+	case ParserJSON:
+		return &OperatorConfig{Type: OperatorJSONParser}, nil
+	case ParserRegex:
+		if c.Regex == "" {
+			return nil, fmt.Errorf("regex must be specified for the %s parser", ParserRegex)
+		}
+		return &OperatorConfig{Type: OperatorRegexParser, Regex: c.Regex}, nil
+	default:
+		return nil, fmt.Errorf("unsupported parser %q", c.Parser)
+	}
+}
+
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	switch strings.ToLower(name) {
+	case "", "utf-8", "utf8":
+		return unicode.UTF8, nil
+	case "utf-16le":
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), nil
+	case "utf-16be":
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), nil
+	case "nop":
+		return encoding.Nop, nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", name)
+	}
+}
+
+func hasNamedGroup(re *regexp.Regexp) bool {
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,389 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The types of the operators.
+const (
+	// OperatorRegexParser parses a value with a regular expression whose
+	// named capture groups become attributes.
+	OperatorRegexParser = "regex_parser"
+	// OperatorJSONParser parses a value as a JSON object whose fields
+	// become attributes.
+	OperatorJSONParser = "json_parser"
+	// OperatorSeverityParser sets the severity of the entry from a value.
+	OperatorSeverityParser = "severity_parser"
+	// OperatorTimeParser sets the timestamp of the entry from a value.
+	OperatorTimeParser = "time_parser"
+	// OperatorMove moves a value to another attribute or to the body.
+	OperatorMove = "move"
+	// OperatorCopy copies a value to another attribute or to the body.
+	OperatorCopy = "copy"
+)
+
+// The epoch layouts of the time parser, for the number of seconds,
+// milliseconds, microseconds or nanoseconds since the Unix epoch.
+const (
+	LayoutEpochSeconds      = "epoch_s"
+	LayoutEpochMilliseconds = "epoch_ms"
+	LayoutEpochMicroseconds = "epoch_us"
+	LayoutEpochNanoseconds  = "epoch_ns"
+)
+
+// OperatorConfig defines an operator of the parsing pipeline.
+type OperatorConfig struct {
+	// Type is the type of the operator: regex_parser, json_parser,
+	// severity_parser, time_parser, move or copy.
+	Type string `mapstructure:"type"`
+
+	// ParseFrom is the attribute parsed by the operator, the body of the
+	// entry if empty.
+	ParseFrom string `mapstructure:"parse_from"`
+
+	// Regex is the regular expression of the regex_parser.
+	Regex string `mapstructure:"regex"`
+
+	// Mapping maps severities to the values parsed by the severity_parser,
+	// in addition to the default mapping of the severity names, e.g.
+	// error: [E, err]. The severities are trace, debug, info, warn, error
+	// and fatal. Values are matched case insensitively.
+	Mapping map[string][]string `mapstructure:"mapping"`
+
+	// Layout is the layout of the time_parser, a Go time layout or
+	// epoch_s, epoch_ms, epoch_us or epoch_ns. RFC 3339 by default.
+	Layout string `mapstructure:"layout"`
+
+	// From and To are the attributes the move and copy operators read the
+	// value from and write it to, the body of the entry if empty. Only
+	// strings can be written to the body.
+	From string `mapstructure:"from"`
+	To   string `mapstructure:"to"`
+}
+
+// Severity is the severity number of an entry, as defined by the
+// OpenTelemetry log data model.
+type Severity int32
+
+// The severities of the severity_parser.
+const (
+	SeverityUndefined Severity = 0
+	SeverityTrace     Severity = 1
+	SeverityDebug     Severity = 5
+	SeverityInfo      Severity = 9
+	SeverityWarn      Severity = 13
+	SeverityError     Severity = 17
+	SeverityFatal     Severity = 21
+)
+
+var severityNames = map[string]Severity{
+	"trace": SeverityTrace,
+	"debug": SeverityDebug,
+	"info":  SeverityInfo,
+	"warn":  SeverityWarn,
+	"error": SeverityError,
+	"fatal": SeverityFatal,
+}
+
+// defaultSeverityMapping maps the usual severity names to severities.
+var defaultSeverityMapping = map[string]Severity{
+	"trace":       SeverityTrace,
+	"debug":       SeverityDebug,
+	"info":        SeverityInfo,
+	"information": SeverityInfo,
+	"notice":      SeverityInfo,
+	"warn":        SeverityWarn,
+	"warning":     SeverityWarn,
+	"error":       SeverityError,
+	"err":         SeverityError,
+	"critical":    SeverityFatal,
+	"crit":        SeverityFatal,
+	"alert":       SeverityFatal,
+	"emergency":   SeverityFatal,
+	"emerg":       SeverityFatal,
+	"fatal":       SeverityFatal,
+	"panic":       SeverityFatal,
+}
+
+// operator processes the entries of the parsing pipeline.
+type operator interface {
+	process(entry *Entry) error
+}
+
+// newOperator checks the configuration of an operator and creates it.
+func newOperator(cfg OperatorConfig) (operator, error) {
+	switch cfg.Type {
+	case OperatorRegexParser:
+		if cfg.Regex == "" {
+			return nil, errors.New("regex must be specified")
+		}
+		re, err := regexp.Compile(cfg.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %q: %w", cfg.Regex, err)
+		}
+		if !hasNamedGroup(re) {
+			return nil, fmt.Errorf("regex %q must have at least one named capture group", cfg.Regex)
+		}
+		return &regexParser{parseFrom: cfg.ParseFrom, regex: re}, nil
+	case OperatorJSONParser:
+		return &jsonParser{parseFrom: cfg.ParseFrom}, nil
+	case OperatorSeverityParser:
+		mapping := make(map[string]Severity, len(defaultSeverityMapping))
+		for value, severity := range defaultSeverityMapping {
+			mapping[value] = severity
+		}
+		for name, values := range cfg.Mapping {
+			severity, ok := severityNames[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("unsupported severity %q", name)
+			}
+			for _, value := range values {
+				mapping[strings.ToLower(value)] = severity
+			}
+		}
+		return &severityParser{parseFrom: cfg.ParseFrom, mapping: mapping}, nil
+	case OperatorTimeParser:
+		layout := cfg.Layout
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return &timeParser{parseFrom: cfg.ParseFrom, layout: layout}, nil
+	case OperatorMove, OperatorCopy:
+		if cfg.From == cfg.To {
+			return nil, errors.New("from and to must be different")
+		}
+		return &moveOperator{from: cfg.From, to: cfg.To, keep: cfg.Type == OperatorCopy}, nil
+	default:
+		return nil, fmt.Errorf("unsupported operator type %q", cfg.Type)
+	}
+}
+
+// value returns the value parsed by an operator, the body of the entry or
+// one of its attributes.
+func value(entry *Entry, parseFrom string) (interface{}, error) {
+	if parseFrom == "" {
+		return entry.Body, nil
+	}
+	v, ok := entry.Attributes[parseFrom]
+	if !ok {
+		return nil, fmt.Errorf("attribute %q not found", parseFrom)
+	}
+	return v, nil
+}
+
+func stringValue(entry *Entry, parseFrom string) (string, error) {
+	v, err := value(entry, parseFrom)
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("attribute %q is not a string", parseFrom)
+	}
+	return s, nil
+}
+
+// addAttributes adds attributes to the entry, overwriting the existing
+// ones.
+func addAttributes(entry *Entry, attrs map[string]interface{}) {
+	if entry.Attributes == nil {
+		entry.Attributes = make(map[string]interface{}, len(attrs))
+	}
+	for key, value := range attrs {
+		entry.Attributes[key] = value
+	}
+}
+
+type regexParser struct {
+	parseFrom string
+	regex     *regexp.Regexp
+}
+
+func (p *regexParser) process(entry *Entry) error {
+	s, err := stringValue(entry, p.parseFrom)
+	if err != nil {
+		return err
+	}
+	match := p.regex.FindStringSubmatch(s)
+	if match == nil {
+		return errors.New("log does not match the regex")
+	}
+	attrs := make(map[string]interface{})
+	for i, name := range p.regex.SubexpNames() {
+		if name != "" && i < len(match) {
+			attrs[name] = match[i]
+		}
+	}
+	addAttributes(entry, attrs)
+	return nil
+}
+
+type jsonParser struct {
+	parseFrom string
+}
+
+func (p *jsonParser) process(entry *Entry) error {
+	s, err := stringValue(entry, p.parseFrom)
+	if err != nil {
+		return err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &fields); err != nil {
+		return fmt.Errorf("failed to parse JSON log: %w", err)
+	}
+	attrs := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		switch v := value.(type) {
+		case nil:
+		case string, float64, bool:
+			attrs[key] = v
+		default:
+			// Objects and arrays are kept as JSON.
+			raw, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			attrs[key] = string(raw)
+		}
+	}
+	addAttributes(entry, attrs)
+	return nil
+}
+
+type severityParser struct {
+	parseFrom string
+	mapping   map[string]Severity
+}
+
+func (p *severityParser) process(entry *Entry) error {
+	s, err := stringValue(entry, p.parseFrom)
+	if err != nil {
+		return err
+	}
+	severity, ok := p.mapping[strings.ToLower(s)]
+	if !ok {
+		return fmt.Errorf("unknown severity %q", s)
+	}
+	entry.Severity = severity
+	entry.SeverityText = s
+	return nil
+}
+
+type timeParser struct {
+	parseFrom string
+	layout    string
+}
+
+func (p *timeParser) process(entry *Entry) error {
+	v, err := value(entry, p.parseFrom)
+	if err != nil {
+		return err
+	}
+
+	var scale float64
+	switch p.layout {
+	case LayoutEpochSeconds:
+		scale = 1e9
+	case LayoutEpochMilliseconds:
+		scale = 1e6
+	case LayoutEpochMicroseconds:
+		scale = 1e3
+	case LayoutEpochNanoseconds:
+		scale = 1
+	default:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("attribute %q is not a string", p.parseFrom)
+		}
+		ts, err := time.Parse(p.layout, s)
+		if err != nil {
+			return fmt.Errorf("failed to parse timestamp: %w", err)
+		}
+		entry.Timestamp = ts
+		return nil
+	}
+
+	switch v := v.(type) {
+	case float64:
+		entry.Timestamp = epochTime(v, scale)
+	case int64:
+		entry.Timestamp = time.Unix(0, v*int64(scale)).UTC()
+	case string:
+		// Integers are parsed exactly, nanoseconds exceeding the precision
+		// of float64.
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			entry.Timestamp = time.Unix(0, n*int64(scale)).UTC()
+			return nil
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse timestamp: %w", err)
+		}
+		entry.Timestamp = epochTime(f, scale)
+	default:
+		return fmt.Errorf("attribute %q is not a number", p.parseFrom)
+	}
+	return nil
+}
+
+// epochTime returns the time of an epoch in a unit of scale nanoseconds.
+func epochTime(epoch, scale float64) time.Time {
+	unitsPerSecond := 1e9 / scale
+	sec := math.Floor(epoch / unitsPerSecond)
+	nsec := math.Round((epoch - sec*unitsPerSecond) * scale)
+	return time.Unix(int64(sec), int64(nsec)).UTC()
+}
+
+// moveOperator moves, or copies if keep is set, a value of the entry.
+type moveOperator struct {
+	from string
+	to   string
+	keep bool
+}
+
+func (o *moveOperator) process(entry *Entry) error {
+	v, err := value(entry, o.from)
+	if err != nil {
+		return err
+	}
+
+	if o.to == "" {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("attribute %q is not a string", o.from)
+		}
+		entry.Body = s
+	} else {
+		addAttributes(entry, map[string]interface{}{o.to: v})
+	}
+
+	if !o.keep {
+		if o.from == "" {
+			entry.Body = ""
+		} else {
+			delete(entry.Attributes, o.from)
+		}
+	}
+	return nil
+}
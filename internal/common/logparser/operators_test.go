@@ -0,0 +1,164 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorsValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []OperatorConfig
+		err  string
+	}{
+		{name: "unsupported type", ops: []OperatorConfig{{Type: "xml_parser"}}, err: `operators[0]: unsupported operator type "xml_parser"`},
+		{name: "missing regex", ops: []OperatorConfig{{Type: OperatorJSONParser}, {Type: OperatorRegexParser}}, err: "operators[1]: regex must be specified"},
+		{name: "unnamed regex", ops: []OperatorConfig{{Type: OperatorRegexParser, Regex: `\w+`}}, err: "must have at least one named capture group"},
+		{name: "unsupported severity", ops: []OperatorConfig{{Type: OperatorSeverityParser, Mapping: map[string][]string{"notice": {"N"}}}}, err: `unsupported severity "notice"`},
+		{name: "move to itself", ops: []OperatorConfig{{Type: OperatorMove, From: "msg", To: "msg"}}, err: "from and to must be different"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Operators: tt.ops}
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	p, err := New(Config{
+		Parser: ParserRegex,
+		Regex:  `^(?P<time>\S+) (?P<level>\w+) (?P<payload>.*)$`,
+		Operators: []OperatorConfig{
+			{Type: OperatorJSONParser, ParseFrom: "payload"},
+			{Type: OperatorSeverityParser, ParseFrom: "level", Mapping: map[string][]string{"warn": {"W"}}},
+			{Type: OperatorTimeParser, ParseFrom: "time"},
+		},
+	})
+	require.NoError(t, err)
+
+	line := `2020-09-01T10:00:00.5Z W {"user": "alice", "retries": 3}`
+	entry, err := p.Process(line)
+	require.NoError(t, err)
+	assert.Equal(t, &Entry{
+		Body: line,
+		Attributes: map[string]interface{}{
+			"time":    "2020-09-01T10:00:00.5Z",
+			"level":   "W",
+			"payload": `{"user": "alice", "retries": 3}`,
+			"user":    "alice",
+			"retries": 3.0,
+		},
+		Timestamp:    time.Date(2020, 9, 1, 10, 0, 0, 5e8, time.UTC),
+		Severity:     SeverityWarn,
+		SeverityText: "W",
+	}, entry)
+
+	// The operators following a failing one are skipped.
+	entry, err = p.Process(`2020-09-01T10:00:00Z ERROR not json`)
+	require.Error(t, err)
+	assert.Equal(t, "ERROR", entry.Attributes["level"])
+	assert.Equal(t, SeverityUndefined, entry.Severity)
+	assert.True(t, entry.Timestamp.IsZero())
+}
+
+func TestSeverityParser(t *testing.T) {
+	p, err := New(Config{Operators: []OperatorConfig{{Type: OperatorSeverityParser}}})
+	require.NoError(t, err)
+
+	for value, want := range map[string]Severity{
+		"TRACE":   SeverityTrace,
+		"debug":   SeverityDebug,
+		"Info":    SeverityInfo,
+		"warning": SeverityWarn,
+		"err":     SeverityError,
+		"crit":    SeverityFatal,
+	} {
+		entry, err := p.Process(value)
+		require.NoError(t, err)
+		assert.Equal(t, want, entry.Severity, value)
+		assert.Equal(t, value, entry.SeverityText)
+	}
+
+	_, err = p.Process("verbose")
+	assert.EqualError(t, err, `unknown severity "verbose"`)
+}
+
+func TestTimeParser(t *testing.T) {
+	tests := []struct {
+		layout string
+		value  interface{}
+		want   time.Time
+	}{
+		{layout: "02/Jan/2006:15:04:05 -0700", value: "01/Sep/2020:12:00:00 +0200", want: time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC)},
+		{layout: LayoutEpochSeconds, value: 1598954400.25, want: time.Date(2020, 9, 1, 10, 0, 0, 25e7, time.UTC)},
+		{layout: LayoutEpochSeconds, value: "1598954400", want: time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC)},
+		{layout: LayoutEpochMilliseconds, value: 1598954400123.0, want: time.Date(2020, 9, 1, 10, 0, 0, 123e6, time.UTC)},
+		{layout: LayoutEpochMicroseconds, value: "1598954400123456", want: time.Date(2020, 9, 1, 10, 0, 0, 123456e3, time.UTC)},
+		{layout: LayoutEpochNanoseconds, value: "1598954400123456789", want: time.Date(2020, 9, 1, 10, 0, 0, 123456789, time.UTC)},
+		{layout: LayoutEpochMilliseconds, value: int64(1598954400123), want: time.Date(2020, 9, 1, 10, 0, 0, 123e6, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			p, err := New(Config{Operators: []OperatorConfig{{Type: OperatorTimeParser, ParseFrom: "ts", Layout: tt.layout}}})
+			require.NoError(t, err)
+			entry := &Entry{Attributes: map[string]interface{}{"ts": tt.value}}
+			require.NoError(t, p.operators[0].process(entry))
+			assert.True(t, tt.want.Equal(entry.Timestamp), entry.Timestamp)
+		})
+	}
+}
+
+func TestOperatorMissingAttribute(t *testing.T) {
+	p, err := New(Config{Operators: []OperatorConfig{{Type: OperatorTimeParser, ParseFrom: "ts"}}})
+	require.NoError(t, err)
+	_, err = p.Process("no timestamp")
+	assert.EqualError(t, err, `attribute "ts" not found`)
+}
+
+func TestMoveAndCopy(t *testing.T) {
+	p, err := New(Config{Operators: []OperatorConfig{
+		{Type: OperatorCopy, To: "message"},
+		{Type: OperatorMove, From: "user", To: "user.name"},
+		{Type: OperatorMove, From: "summary"},
+	}})
+	require.NoError(t, err)
+
+	entry := &Entry{
+		Body:       "login failed",
+		Attributes: map[string]interface{}{"user": "alice", "summary": "failed login of alice"},
+	}
+	require.NoError(t, p.ProcessEntry(entry))
+	assert.Equal(t, &Entry{
+		Body: "failed login of alice",
+		Attributes: map[string]interface{}{
+			"message":   "login failed",
+			"user.name": "alice",
+		},
+	}, entry)
+
+	// Only strings can be written to the body.
+	p, err = New(Config{Operators: []OperatorConfig{{Type: OperatorCopy, From: "retries"}}})
+	require.NoError(t, err)
+	entry = &Entry{Attributes: map[string]interface{}{"retries": 3.0}}
+	assert.EqualError(t, p.ProcessEntry(entry), `attribute "retries" is not a string`)
+}
@@ -0,0 +1,138 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparser
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding"
+)
+
+// Entry is a log line and what its operators parsed from it.
+type Entry struct {
+	Body string
+	// Attributes are the parsed attributes, strings, float64 or bool. The
+	// attributes of existing logs may also be int64.
+	Attributes map[string]interface{}
+	// Timestamp is the parsed timestamp, zero if none.
+	Timestamp time.Time
+	// Severity and SeverityText are the parsed severity and the value it
+	// was parsed from, undefined if none.
+	Severity     Severity
+	SeverityText string
+}
+
+// Parser decodes and parses log lines.
+type Parser struct {
+	decoder   *encoding.Decoder
+	newline   []byte
+	operators []operator
+}
+
+// New creates a Parser from a valid configuration.
+func New(cfg Config) (*Parser, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	enc, _ := lookupEncoding(cfg.Encoding)
+	newline, err := enc.NewEncoder().Bytes([]byte("\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	ops, _ := cfg.operators()
+	return &Parser{
+		decoder:   enc.NewDecoder(),
+		newline:   newline,
+		operators: ops,
+	}, nil
+}
+
+// SplitLines is a bufio.SplitFunc splitting encoded data into lines, without
+// their newline.
+func (p *Parser) SplitLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	// Newlines of multi-byte encodings are searched at character boundaries.
+	for i := 0; i+len(p.newline) <= len(data); i += len(p.newline) {
+		if bytes.Equal(data[i:i+len(p.newline)], p.newline) {
+			return i + len(p.newline), data[:i], nil
+		}
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// Lines returns the lines of data, e.g. of a datagram holding several lines.
+func (p *Parser) Lines(data []byte) [][]byte {
+	var lines [][]byte
+	for len(data) > 0 {
+		advance, line, _ := p.SplitLines(data, true)
+		lines = append(lines, line)
+		data = data[advance:]
+	}
+	return lines
+}
+
+// Decode decodes a line to a string, without its trailing carriage return.
+// A Parser must not be used to decode lines concurrently.
+func (p *Parser) Decode(line []byte) (string, error) {
+	decoded, err := p.decoder.Bytes(line)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(decoded), "\r"), nil
+}
+
+// Process applies the operators to a decoded line. If an operator fails,
+// the following ones are skipped and the entry is returned with the error.
+func (p *Parser) Process(line string) (*Entry, error) {
+	entry := &Entry{Body: line}
+	return entry, p.ProcessEntry(entry)
+}
+
+// ProcessEntry applies the operators to an entry, e.g. built from an existing
+// log. If an operator fails, the following ones are skipped.
+func (p *Parser) ProcessEntry(entry *Entry) error {
+	for _, op := range p.operators {
+		if err := op.process(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parse returns the attributes parsed from a decoded line, nil if the lines
+// are not parsed. The attribute values are strings, float64 or bool.
+func (p *Parser) Parse(line string) (map[string]interface{}, error) {
+	entry, err := p.Process(line)
+	return entry.Attributes, err
+}
+
+// NewScanner returns a scanner of the lines of r, with lines up to maxSize
+// bytes.
+func (p *Parser) NewScanner(r io.Reader, maxSize int) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxSize)
+	scanner.Split(p.SplitLines)
+	return scanner
+}
@@ -0,0 +1,123 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{name: "default", cfg: Config{}},
+		{name: "json", cfg: Config{Encoding: "utf-16le", Parser: ParserJSON}},
+		{name: "regex", cfg: Config{Parser: ParserRegex, Regex: `^(?P<level>\w+) `}},
+		{name: "unsupported encoding", cfg: Config{Encoding: "latin1"}, err: `unsupported encoding "latin1"`},
+		{name: "unsupported parser", cfg: Config{Parser: "xml"}, err: `unsupported parser "xml"`},
+		{name: "missing regex", cfg: Config{Parser: ParserRegex}, err: "regex must be specified for the regex parser"},
+		{name: "regex without parser", cfg: Config{Regex: `(?P<level>\w+)`}, err: "regex is only supported by the regex parser"},
+		{name: "invalid regex", cfg: Config{Parser: ParserRegex, Regex: `(?P<level>`}, err: "invalid regex"},
+		{name: "unnamed regex", cfg: Config{Parser: ParserRegex, Regex: `(\w+)`}, err: "must have at least one named capture group"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
+
+func TestScanLines(t *testing.T) {
+	p, err := New(Config{})
+	require.NoError(t, err)
+
+	scanner := p.NewScanner(strings.NewReader("first\r\nsecond\n\nlast"), 1024)
+	var lines []string
+	for scanner.Scan() {
+		line, err := p.Decode(scanner.Bytes())
+		require.NoError(t, err)
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []string{"first", "second", "", "last"}, lines)
+}
+
+func TestScanLinesUTF16(t *testing.T) {
+	p, err := New(Config{Encoding: "utf-16le"})
+	require.NoError(t, err)
+
+	// "\u0a41\u0100" is encoded as 41 0a 00 01 in little endian, its bytes
+	// 0a 00 must not be mistaken for a newline.
+	data := []byte{0x41, 0x0a, 0x00, 0x01, '\n', 0, 'b', 0, '\n', 0}
+	lines := p.Lines(data)
+	require.Len(t, lines, 2)
+
+	line, err := p.Decode(lines[0])
+	require.NoError(t, err)
+	assert.Equal(t, "\u0a41\u0100", line)
+	line, err = p.Decode(lines[1])
+	require.NoError(t, err)
+	assert.Equal(t, "b", line)
+}
+
+func TestParseJSON(t *testing.T) {
+	p, err := New(Config{Parser: ParserJSON})
+	require.NoError(t, err)
+
+	attrs, err := p.Parse(`{"level": "info", "status": 200, "ok": true, "tags": ["a"], "user": null}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"level":  "info",
+		"status": 200.0,
+		"ok":     true,
+		"tags":   `["a"]`,
+	}, attrs)
+
+	_, err = p.Parse("not json")
+	assert.Error(t, err)
+}
+
+func TestParseRegex(t *testing.T) {
+	p, err := New(Config{Parser: ParserRegex, Regex: `^(?P<level>\w+) (?P<message>.*)$`})
+	require.NoError(t, err)
+
+	attrs, err := p.Parse("ERROR disk full")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"level": "ERROR", "message": "disk full"}, attrs)
+
+	_, err = p.Parse("")
+	assert.Error(t, err)
+}
+
+func TestParseWithoutParser(t *testing.T) {
+	p, err := New(Config{})
+	require.NoError(t, err)
+
+	attrs, err := p.Parse("anything")
+	require.NoError(t, err)
+	assert.Nil(t, attrs)
+}
@@ -0,0 +1,26 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pdh is a thin wrapper of the Windows Performance Data Helper (PDH)
+// API used to collect performance counters.
+package pdh
+
+// CounterValue is the formatted value of a performance counter for one of
+// its instances.
+type CounterValue struct {
+	// InstanceName is the name of the instance, empty for counters of
+	// objects without instances.
+	InstanceName string
+	Value        float64
+}
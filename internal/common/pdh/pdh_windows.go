@@ -0,0 +1,159 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build windows
+
+package pdh
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	errorSuccess = 0
+	pdhMoreData  = 0x800007D2
+
+	pdhCStatusValidData = 0x00000000
+	pdhCStatusNewData   = 0x00000001
+
+	pdhFmtDouble   = 0x00000200
+	pdhFmtNoCap100 = 0x00008000
+)
+
+var (
+	pdhDLL = windows.NewLazySystemDLL("pdh.dll")
+
+	procPdhOpenQueryW                = pdhDLL.NewProc("PdhOpenQueryW")
+	procPdhAddEnglishCounterW        = pdhDLL.NewProc("PdhAddEnglishCounterW")
+	procPdhCollectQueryData          = pdhDLL.NewProc("PdhCollectQueryData")
+	procPdhGetFormattedCounterArrayW = pdhDLL.NewProc("PdhGetFormattedCounterArrayW")
+	procPdhCloseQuery                = pdhDLL.NewProc("PdhCloseQuery")
+)
+
+// fmtCounterValueItemDouble mirrors PDH_FMT_COUNTERVALUE_ITEM_DOUBLE on
+// 64-bit Windows, where the double of the value union is 8-byte aligned.
+type fmtCounterValueItemDouble struct {
+	SzName      *uint16
+	CStatus     uint32
+	_           uint32
+	DoubleValue float64
+}
+
+// Query is a PDH query collecting a set of counters at once.
+type Query struct {
+	handle uintptr
+}
+
+// Counter is a counter added to a Query.
+type Counter struct {
+	path   string
+	handle uintptr
+}
+
+// OpenQuery opens a new query on the local computer.
+func OpenQuery() (*Query, error) {
+	q := &Query{}
+	ret, _, _ := procPdhOpenQueryW.Call(0, 0, uintptr(unsafe.Pointer(&q.handle)))
+	if ret != errorSuccess {
+		return nil, pdhError("PdhOpenQueryW", ret)
+	}
+	return q, nil
+}
+
+// AddCounter adds the counter with the given English path, e.g.
+// `\Processor(_Total)\% Processor Time`, to the query.
+func (q *Query) AddCounter(path string) (*Counter, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Counter{path: path}
+	ret, _, _ := procPdhAddEnglishCounterW.Call(q.handle, uintptr(unsafe.Pointer(p)), 0, uintptr(unsafe.Pointer(&c.handle)))
+	if ret != errorSuccess {
+		return nil, fmt.Errorf("counter %q: %w", path, pdhError("PdhAddEnglishCounterW", ret))
+	}
+	return c, nil
+}
+
+// Collect collects the current raw values of all the counters of the query.
+func (q *Query) Collect() error {
+	ret, _, _ := procPdhCollectQueryData.Call(q.handle)
+	if ret != errorSuccess {
+		return pdhError("PdhCollectQueryData", ret)
+	}
+	return nil
+}
+
+// Close closes the query and all its counters.
+func (q *Query) Close() error {
+	ret, _, _ := procPdhCloseQuery.Call(q.handle)
+	if ret != errorSuccess {
+		return pdhError("PdhCloseQuery", ret)
+	}
+	return nil
+}
+
+// Values returns the values of the counter, one per instance, computed from
+// the data of the last collections of its query. Instances with invalid data
+// are skipped.
+func (c *Counter) Values() ([]CounterValue, error) {
+	var bufSize, itemCount uint32
+	ret, _, _ := procPdhGetFormattedCounterArrayW.Call(c.handle, pdhFmtDouble|pdhFmtNoCap100,
+		uintptr(unsafe.Pointer(&bufSize)), uintptr(unsafe.Pointer(&itemCount)), 0)
+	if ret != pdhMoreData {
+		return nil, fmt.Errorf("counter %q: %w", c.path, pdhError("PdhGetFormattedCounterArrayW", ret))
+	}
+	if itemCount == 0 {
+		return nil, nil
+	}
+
+	buf := make([]byte, bufSize)
+	ret, _, _ = procPdhGetFormattedCounterArrayW.Call(c.handle, pdhFmtDouble|pdhFmtNoCap100,
+		uintptr(unsafe.Pointer(&bufSize)), uintptr(unsafe.Pointer(&itemCount)), uintptr(unsafe.Pointer(&buf[0])))
+	if ret != errorSuccess {
+		return nil, fmt.Errorf("counter %q: %w", c.path, pdhError("PdhGetFormattedCounterArrayW", ret))
+	}
+
+	items := (*[1 << 20]fmtCounterValueItemDouble)(unsafe.Pointer(&buf[0]))[:itemCount:itemCount]
+	values := make([]CounterValue, 0, itemCount)
+	for _, item := range items {
+		if item.CStatus != pdhCStatusValidData && item.CStatus != pdhCStatusNewData {
+			continue
+		}
+		values = append(values, CounterValue{
+			InstanceName: utf16PtrToString(item.SzName),
+			Value:        item.DoubleValue,
+		})
+	}
+	return values, nil
+}
+
+// utf16PtrToString converts a NUL terminated UTF-16 string to a string.
+func utf16PtrToString(p *uint16) string {
+	if p == nil {
+		return ""
+	}
+	n := 0
+	for ptr := unsafe.Pointer(p); *(*uint16)(ptr) != 0; n++ {
+		ptr = unsafe.Pointer(uintptr(ptr) + unsafe.Sizeof(*p))
+	}
+	return windows.UTF16ToString((*[1 << 29]uint16)(unsafe.Pointer(p))[:n:n])
+}
+
+func pdhError(fn string, status uintptr) error {
+	return fmt.Errorf("%s failed with status 0x%08X", fn, uint32(status))
+}
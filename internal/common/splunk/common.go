@@ -17,6 +17,13 @@ package splunk
 const (
 	SFxAccessTokenHeader = "X-Sf-Token"
 	SFxAccessTokenLabel  = "com.splunk.signalfx.access_token"
+
+	// SFxEventType is the log record attribute holding the SignalFx event type.
+	SFxEventType = "com.splunk.signalfx.event_type"
+	// SFxEventCategoryKey is the log record attribute holding the SignalFx event category.
+	SFxEventCategoryKey = "com.splunk.signalfx.event_category"
+	// SFxEventPropertyPrefix prefixes the log record attributes holding SignalFx event properties.
+	SFxEventPropertyPrefix = "com.splunk.signalfx.event_properties."
 )
 
 type AccessTokenPassthroughConfig struct {
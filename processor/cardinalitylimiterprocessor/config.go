@@ -0,0 +1,50 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimiterprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Action is the action applied to the time series over the limit.
+type Action string
+
+const (
+	// Drop drops the time series over the limit.
+	Drop Action = "drop"
+
+	// Aggregate merges the time series over the limit into a single time series
+	// labelled with the overflow label.
+	Aggregate Action = "aggregate"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Limit is the maximum number of active time series per metric. Defaults
+	// to 1000.
+	Limit int `mapstructure:"limit"`
+
+	// Action is the action applied to the time series received once the limit
+	// is reached, either drop or aggregate. Defaults to drop.
+	Action Action `mapstructure:"action"`
+
+	// Expiration is the amount of time after which a time series that has not
+	// been received is no longer active. Defaults to 5m.
+	Expiration time.Duration `mapstructure:"expiration"`
+}
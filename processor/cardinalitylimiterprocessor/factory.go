@@ -0,0 +1,88 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimiterprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "cardinalitylimiter"
+
+	defaultLimit      = 1000
+	defaultExpiration = 5 * time.Minute
+)
+
+var (
+	errInvalidLimit      = errors.New("\"limit\" must be positive")
+	errInvalidExpiration = errors.New("\"expiration\" must be positive")
+)
+
+// NewFactory returns a new factory for the cardinality limiter processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithMetrics(createMetricsProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: configmodels.Type(typeStr),
+			NameVal: typeStr,
+		},
+		Limit:      defaultLimit,
+		Action:     Drop,
+		Expiration: defaultExpiration,
+	}
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextMetricsConsumer consumer.MetricsConsumer,
+) (component.MetricsProcessor, error) {
+	oCfg := cfg.(*Config)
+	if err := validateConfig(oCfg); err != nil {
+		return nil, err
+	}
+	return newCardinalityLimiterProcessor(oCfg, params.Logger, nextMetricsConsumer), nil
+}
+
+func validateConfig(cfg *Config) error {
+	if cfg.Limit <= 0 {
+		return errInvalidLimit
+	}
+	if cfg.Expiration <= 0 {
+		return errInvalidExpiration
+	}
+	switch cfg.Action {
+	case Drop, Aggregate:
+	default:
+		return fmt.Errorf("unsupported action %q, must be %q or %q", cfg.Action, Drop, Aggregate)
+	}
+	return nil
+}
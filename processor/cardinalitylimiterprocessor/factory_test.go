@@ -0,0 +1,95 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimiterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestType(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, configmodels.Type(typeStr), factory.Type())
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: typeStr,
+			TypeVal: typeStr,
+		},
+		Limit:      defaultLimit,
+		Action:     Drop,
+		Expiration: defaultExpiration,
+	}, cfg)
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, &exportertest.SinkMetricsExporter{})
+	assert.NoError(t, err)
+	assert.NotNil(t, mp)
+
+	tp, err := factory.CreateTraceProcessor(context.Background(), params, cfg, &exportertest.SinkTraceExporter{})
+	assert.Error(t, err)
+	assert.Nil(t, tp)
+}
+
+func TestCreateProcessorInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "limit",
+			modify: func(cfg *Config) { cfg.Limit = 0 },
+			err:    errInvalidLimit.Error(),
+		},
+		{
+			name:   "expiration",
+			modify: func(cfg *Config) { cfg.Expiration = -1 },
+			err:    errInvalidExpiration.Error(),
+		},
+		{
+			name:   "action",
+			modify: func(cfg *Config) { cfg.Action = "sample" },
+			err:    `unsupported action "sample", must be "drop" or "aggregate"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			tt.modify(cfg)
+			mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, &exportertest.SinkMetricsExporter{})
+			assert.EqualError(t, err, tt.err)
+			assert.Nil(t, mp)
+		})
+	}
+}
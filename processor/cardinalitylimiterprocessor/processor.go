@@ -0,0 +1,293 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimiterprocessor
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+// overflowLabel is the label set on the time series aggregating the time
+// series over the limit.
+const overflowLabel = "otel.metric.overflow"
+
+type cardinalityLimiterProcessor struct {
+	logger     *zap.Logger
+	next       consumer.MetricsConsumer
+	limit      int
+	action     Action
+	expiration time.Duration
+	now        func() time.Time
+
+	mu sync.Mutex
+	// active maps the metric names to the active time series of the metric
+	// and the last time they were received at.
+	active     map[string]map[string]time.Time
+	lastPurged time.Time
+}
+
+var _ component.MetricsProcessor = (*cardinalityLimiterProcessor)(nil)
+
+func newCardinalityLimiterProcessor(cfg *Config, logger *zap.Logger, next consumer.MetricsConsumer) *cardinalityLimiterProcessor {
+	return &cardinalityLimiterProcessor{
+		logger:     logger,
+		next:       next,
+		limit:      cfg.Limit,
+		action:     cfg.Action,
+		expiration: cfg.Expiration,
+		now:        time.Now,
+		active:     make(map[string]map[string]time.Time),
+	}
+}
+
+// GetCapabilities returns the Capabilities associated with the processor.
+func (clp *cardinalityLimiterProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: true}
+}
+
+// Start is invoked during service startup.
+func (*cardinalityLimiterProcessor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown is invoked during service shutdown.
+func (*cardinalityLimiterProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ConsumeMetrics applies the configured action to the time series received
+// once their metric has reached the limit of active time series. The metrics
+// left without time series are removed.
+func (clp *cardinalityLimiterProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	clp.mu.Lock()
+	now := clp.now()
+	clp.purge(now)
+
+	var overflow int
+	mds := pdatautil.MetricsToMetricsData(md)
+	for i := range mds {
+		data := &mds[i]
+		srcKey := resourceKey(data.Node, data.Resource)
+		metrics := data.Metrics[:0]
+		for _, metric := range data.Metrics {
+			key := srcKey
+			if metric.Resource != nil {
+				key += resourceKey(nil, metric.Resource)
+			}
+			n := clp.limitMetric(now, key, metric)
+			overflow += n
+			if n > 0 && len(metric.Timeseries) == 0 {
+				continue
+			}
+			metrics = append(metrics, metric)
+		}
+		data.Metrics = metrics
+	}
+	clp.mu.Unlock()
+
+	if overflow > 0 {
+		clp.logger.Debug("Time series over the cardinality limit",
+			zap.Int("count", overflow),
+			zap.String("action", string(clp.action)))
+	}
+	return clp.next.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData(mds))
+}
+
+// limitMetric applies the action to the time series of the metric over the
+// limit and returns their number.
+func (clp *cardinalityLimiterProcessor) limitMetric(now time.Time, srcKey string, metric *metricspb.Metric) int {
+	name := metric.GetMetricDescriptor().GetName()
+	series, ok := clp.active[name]
+	if !ok {
+		series = make(map[string]time.Time)
+		clp.active[name] = series
+	}
+
+	var overflow []*metricspb.TimeSeries
+	timeseries := metric.Timeseries[:0]
+	for _, ts := range metric.Timeseries {
+		key := srcKey + seriesKey(ts.GetLabelValues())
+		if _, ok := series[key]; ok || len(series) < clp.limit {
+			series[key] = now
+			timeseries = append(timeseries, ts)
+			continue
+		}
+		overflow = append(overflow, ts)
+	}
+	metric.Timeseries = timeseries
+
+	if clp.action == Aggregate && len(overflow) > 0 {
+		aggregateOverflow(metric, overflow)
+	}
+	return len(overflow)
+}
+
+// purge forgets the time series not received for longer than the
+// expiration, at most once per expiration.
+func (clp *cardinalityLimiterProcessor) purge(now time.Time) {
+	if now.Sub(clp.lastPurged) < clp.expiration {
+		return
+	}
+	for name, series := range clp.active {
+		for key, seenAt := range series {
+			if now.Sub(seenAt) >= clp.expiration {
+				delete(series, key)
+			}
+		}
+		if len(series) == 0 {
+			delete(clp.active, name)
+		}
+	}
+	clp.lastPurged = now
+}
+
+// aggregateOverflow adds to the metric a time series labelled with the
+// overflow label only, summing the values of the points of the overflow time
+// series with the same timestamp. Only the int64 and double points can be
+// summed, the other points are dropped.
+func aggregateOverflow(metric *metricspb.Metric, overflow []*metricspb.TimeSeries) {
+	desc := metric.MetricDescriptor
+	if desc == nil {
+		return
+	}
+	agg := &metricspb.TimeSeries{}
+	points := make(map[string]*metricspb.Point)
+	for _, ts := range overflow {
+		if earlier(ts.StartTimestamp, agg.StartTimestamp) {
+			agg.StartTimestamp = ts.StartTimestamp
+		}
+		for _, p := range ts.Points {
+			key := timestampKey(p.Timestamp)
+			sum, ok := points[key]
+			if !ok {
+				switch p.Value.(type) {
+				case *metricspb.Point_Int64Value:
+					sum = &metricspb.Point{Timestamp: p.Timestamp, Value: &metricspb.Point_Int64Value{}}
+				case *metricspb.Point_DoubleValue:
+					sum = &metricspb.Point{Timestamp: p.Timestamp, Value: &metricspb.Point_DoubleValue{}}
+				default:
+					continue
+				}
+				points[key] = sum
+				agg.Points = append(agg.Points, sum)
+			}
+			switch v := p.Value.(type) {
+			case *metricspb.Point_Int64Value:
+				if s, ok := sum.Value.(*metricspb.Point_Int64Value); ok {
+					s.Int64Value += v.Int64Value
+				}
+			case *metricspb.Point_DoubleValue:
+				if s, ok := sum.Value.(*metricspb.Point_DoubleValue); ok {
+					s.DoubleValue += v.DoubleValue
+				}
+			}
+		}
+	}
+	if len(agg.Points) == 0 {
+		return
+	}
+
+	idx := -1
+	for i, k := range desc.LabelKeys {
+		if k.GetKey() == overflowLabel {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		idx = len(desc.LabelKeys)
+		desc.LabelKeys = append(desc.LabelKeys, &metricspb.LabelKey{Key: overflowLabel})
+		for _, ts := range metric.Timeseries {
+			ts.LabelValues = append(ts.LabelValues, &metricspb.LabelValue{})
+		}
+	}
+	agg.LabelValues = make([]*metricspb.LabelValue, len(desc.LabelKeys))
+	for i := range agg.LabelValues {
+		agg.LabelValues[i] = &metricspb.LabelValue{}
+	}
+	agg.LabelValues[idx] = &metricspb.LabelValue{Value: "true", HasValue: true}
+	metric.Timeseries = append(metric.Timeseries, agg)
+}
+
+// earlier returns whether the timestamp ts is set and before the timestamp
+// than, an unset timestamp being after any timestamp.
+func earlier(ts, than *timestamp.Timestamp) bool {
+	if ts == nil {
+		return false
+	}
+	if than == nil {
+		return true
+	}
+	return ts.Seconds < than.Seconds || (ts.Seconds == than.Seconds && ts.Nanos < than.Nanos)
+}
+
+func timestampKey(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return strconv.FormatInt(ts.Seconds, 10) + "." + strconv.FormatInt(int64(ts.Nanos), 10)
+}
+
+// resourceKey returns a key identifying the source of the metrics.
+func resourceKey(node *commonpb.Node, resource *resourcepb.Resource) string {
+	var sb strings.Builder
+	sb.WriteString(node.GetServiceInfo().GetName())
+	sb.WriteString("\x00")
+	sb.WriteString(node.GetIdentifier().GetHostName())
+	sb.WriteString("\x00")
+	sb.WriteString(strconv.FormatUint(uint64(node.GetIdentifier().GetPid()), 10))
+	sb.WriteString("\x00")
+	sb.WriteString(resource.GetType())
+
+	keys := make([]string, 0, len(resource.GetLabels()))
+	for k := range resource.GetLabels() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString("\x00")
+		sb.WriteString(k)
+		sb.WriteString("=")
+		sb.WriteString(resource.Labels[k])
+	}
+	sb.WriteString("\x01")
+	return sb.String()
+}
+
+func seriesKey(labelValues []*metricspb.LabelValue) string {
+	var sb strings.Builder
+	for _, v := range labelValues {
+		sb.WriteString("\x00")
+		if v.GetHasValue() {
+			sb.WriteString("=")
+			sb.WriteString(v.GetValue())
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,159 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinalitylimiterprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func newMetric(name string, values map[string]int64) *metricspb.Metric {
+	metric := &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      name,
+			Type:      metricspb.MetricDescriptor_CUMULATIVE_INT64,
+			LabelKeys: []*metricspb.LabelKey{{Key: "user"}},
+		},
+	}
+	for _, user := range []string{"a", "b", "c", "d"} {
+		v, ok := values[user]
+		if !ok {
+			continue
+		}
+		metric.Timeseries = append(metric.Timeseries, &metricspb.TimeSeries{
+			StartTimestamp: &timestamp.Timestamp{Seconds: 100},
+			LabelValues:    []*metricspb.LabelValue{{Value: user, HasValue: true}},
+			Points: []*metricspb.Point{{
+				Timestamp: &timestamp.Timestamp{Seconds: 200},
+				Value:     &metricspb.Point_Int64Value{Int64Value: v},
+			}},
+		})
+	}
+	return metric
+}
+
+// consume sends the metrics to the processor and returns the metrics it
+// forwarded.
+func consume(t *testing.T, clp *cardinalityLimiterProcessor, next *exportertest.SinkMetricsExporter, metrics ...*metricspb.Metric) []*metricspb.Metric {
+	next.Reset()
+	md := consumerdata.MetricsData{
+		Node:    &commonpb.Node{Identifier: &commonpb.ProcessIdentifier{HostName: "host"}},
+		Metrics: metrics,
+	}
+	require.NoError(t, clp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md})))
+	require.Len(t, next.AllMetrics(), 1)
+	mds := pdatautil.MetricsToMetricsData(next.AllMetrics()[0])
+	require.Len(t, mds, 1)
+	return mds[0].Metrics
+}
+
+// values returns the values of the int64 points by the label values of their
+// time series.
+func values(metric *metricspb.Metric) map[string]int64 {
+	got := make(map[string]int64)
+	for _, ts := range metric.Timeseries {
+		var key string
+		for i, v := range ts.LabelValues {
+			if v.HasValue {
+				key += metric.MetricDescriptor.LabelKeys[i].Key + "=" + v.Value
+			}
+		}
+		for _, p := range ts.Points {
+			got[key] += p.GetInt64Value()
+		}
+	}
+	return got
+}
+
+func TestDrop(t *testing.T) {
+	now := time.Unix(1000, 0)
+	next := &exportertest.SinkMetricsExporter{}
+	clp := newCardinalityLimiterProcessor(&Config{Limit: 2, Action: Drop, Expiration: time.Minute}, zap.NewNop(), next)
+	clp.now = func() time.Time { return now }
+
+	got := consume(t, clp, next,
+		newMetric("requests", map[string]int64{"a": 1, "b": 2, "c": 3}),
+		newMetric("errors", map[string]int64{"c": 1}))
+	require.Len(t, got, 2)
+	assert.Equal(t, map[string]int64{"user=a": 1, "user=b": 2}, values(got[0]))
+	assert.Equal(t, map[string]int64{"user=c": 1}, values(got[1]))
+
+	// the active time series are still accepted
+	now = now.Add(30 * time.Second)
+	got = consume(t, clp, next,
+		newMetric("requests", map[string]int64{"c": 3, "d": 4}),
+		newMetric("errors", map[string]int64{"c": 2}))
+	require.Len(t, got, 1)
+	assert.Equal(t, "errors", got[0].MetricDescriptor.Name)
+
+	// the time series not received anymore expire
+	now = now.Add(time.Minute)
+	got = consume(t, clp, next, newMetric("requests", map[string]int64{"c": 3, "d": 4}))
+	require.Len(t, got, 1)
+	assert.Equal(t, map[string]int64{"user=c": 3, "user=d": 4}, values(got[0]))
+	assert.Len(t, clp.active, 1)
+}
+
+func TestAggregate(t *testing.T) {
+	next := &exportertest.SinkMetricsExporter{}
+	clp := newCardinalityLimiterProcessor(&Config{Limit: 1, Action: Aggregate, Expiration: time.Minute}, zap.NewNop(), next)
+
+	got := consume(t, clp, next, newMetric("requests", map[string]int64{"a": 1, "b": 2, "c": 3}))
+	require.Len(t, got, 1)
+	metric := got[0]
+	assert.Equal(t, []*metricspb.LabelKey{{Key: "user"}, {Key: overflowLabel}}, metric.MetricDescriptor.LabelKeys)
+	assert.Equal(t, map[string]int64{"user=a": 1, overflowLabel + "=true": 5}, values(metric))
+	require.Len(t, metric.Timeseries, 2)
+	assert.Equal(t, &timestamp.Timestamp{Seconds: 100}, metric.Timeseries[1].StartTimestamp)
+	require.Len(t, metric.Timeseries[1].Points, 1)
+	assert.Equal(t, &timestamp.Timestamp{Seconds: 200}, metric.Timeseries[1].Points[0].Timestamp)
+
+	// the time series of another resource count towards the same limit
+	next.Reset()
+	md := consumerdata.MetricsData{
+		Node:    &commonpb.Node{Identifier: &commonpb.ProcessIdentifier{HostName: "other"}},
+		Metrics: []*metricspb.Metric{newMetric("requests", map[string]int64{"a": 4})},
+	}
+	require.NoError(t, clp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md})))
+	mds := pdatautil.MetricsToMetricsData(next.AllMetrics()[0])
+	require.Len(t, mds[0].Metrics, 1)
+	assert.Equal(t, map[string]int64{overflowLabel + "=true": 4}, values(mds[0].Metrics[0]))
+}
+
+func TestAggregateUnsupportedPoints(t *testing.T) {
+	next := &exportertest.SinkMetricsExporter{}
+	clp := newCardinalityLimiterProcessor(&Config{Limit: 1, Action: Aggregate, Expiration: time.Minute}, zap.NewNop(), next)
+
+	metric := newMetric("latency", map[string]int64{"a": 1, "b": 2})
+	for _, ts := range metric.Timeseries {
+		ts.Points[0].Value = &metricspb.Point_DistributionValue{DistributionValue: &metricspb.DistributionValue{Count: 1}}
+	}
+	got := consume(t, clp, next, metric)
+	require.Len(t, got, 1)
+	require.Len(t, got[0].Timeseries, 1)
+	assert.Equal(t, "a", got[0].Timeseries[0].LabelValues[0].Value)
+	assert.Len(t, got[0].MetricDescriptor.LabelKeys, 1)
+}
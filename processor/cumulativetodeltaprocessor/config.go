@@ -0,0 +1,36 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Metrics is the list of the names of the cumulative metrics to convert to
+	// delta. All the cumulative metrics are converted when it is empty.
+	Metrics []string `mapstructure:"metrics"`
+
+	// MaxStaleness is the amount of time after which the state kept for a
+	// series that has not received any point is discarded. The next point of
+	// the series then only initializes a new state. No state is discarded when
+	// it is 0. Defaults to 5m.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+}
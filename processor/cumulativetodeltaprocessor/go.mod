@@ -0,0 +1,13 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor
+
+go 1.14
+
+require (
+	github.com/census-instrumentation/opencensus-proto v0.3.0
+	github.com/golang/protobuf v1.4.2
+	github.com/google/go-cmp v0.5.1
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/collector v0.8.1-0.20200815205113-8e5c6065eb0e
+	go.uber.org/zap v1.15.0
+	google.golang.org/protobuf v1.25.0
+)
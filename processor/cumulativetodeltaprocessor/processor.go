@@ -0,0 +1,193 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+// deltaTypes maps the cumulative metric types to the types of the converted
+// metrics. OpenCensus has no delta type, the deltas are reported as gauges
+// whose start timestamp is the timestamp of the previous cumulative point.
+var deltaTypes = map[metricspb.MetricDescriptor_Type]metricspb.MetricDescriptor_Type{
+	metricspb.MetricDescriptor_CUMULATIVE_INT64:        metricspb.MetricDescriptor_GAUGE_INT64,
+	metricspb.MetricDescriptor_CUMULATIVE_DOUBLE:       metricspb.MetricDescriptor_GAUGE_DOUBLE,
+	metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION: metricspb.MetricDescriptor_GAUGE_DISTRIBUTION,
+}
+
+type cumulativeToDeltaProcessor struct {
+	logger  *zap.Logger
+	next    consumer.MetricsConsumer
+	metrics map[string]bool
+	tracker *deltaTracker
+}
+
+var _ component.MetricsProcessor = (*cumulativeToDeltaProcessor)(nil)
+
+func newCumulativeToDeltaProcessor(cfg *Config, logger *zap.Logger, next consumer.MetricsConsumer) *cumulativeToDeltaProcessor {
+	var metrics map[string]bool
+	if len(cfg.Metrics) > 0 {
+		metrics = make(map[string]bool, len(cfg.Metrics))
+		for _, name := range cfg.Metrics {
+			metrics[name] = true
+		}
+	}
+	return &cumulativeToDeltaProcessor{
+		logger:  logger,
+		next:    next,
+		metrics: metrics,
+		tracker: newDeltaTracker(cfg.MaxStaleness),
+	}
+}
+
+// GetCapabilities returns the Capabilities associated with the processor.
+func (ctdp *cumulativeToDeltaProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: true}
+}
+
+// Start is invoked during service startup.
+func (*cumulativeToDeltaProcessor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown is invoked during service shutdown.
+func (*cumulativeToDeltaProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ConsumeMetrics implements the MetricsProcessor interface.
+func (ctdp *cumulativeToDeltaProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	ctdp.tracker.removeStale()
+
+	mds := pdatautil.MetricsToMetricsData(md)
+	for i := range mds {
+		data := &mds[i]
+		srcKey := resourceKey(data.Node, data.Resource)
+		metrics := data.Metrics[:0]
+		for _, metric := range data.Metrics {
+			if ctdp.shouldConvert(metric) {
+				key := srcKey
+				if metric.Resource != nil {
+					key += resourceKey(nil, metric.Resource)
+				}
+				ctdp.convertMetric(key, metric)
+				if len(metric.Timeseries) == 0 {
+					continue
+				}
+			}
+			metrics = append(metrics, metric)
+		}
+		data.Metrics = metrics
+	}
+
+	return ctdp.next.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData(mds))
+}
+
+func (ctdp *cumulativeToDeltaProcessor) shouldConvert(metric *metricspb.Metric) bool {
+	desc := metric.GetMetricDescriptor()
+	if _, ok := deltaTypes[desc.GetType()]; !ok {
+		return false
+	}
+	return ctdp.metrics == nil || ctdp.metrics[desc.GetName()]
+}
+
+// convertMetric replaces the cumulative points of the metric by the deltas
+// with the previous points of their series. The series without any delta,
+// e.g. the ones seen for the first time, are removed.
+func (ctdp *cumulativeToDeltaProcessor) convertMetric(srcKey string, metric *metricspb.Metric) {
+	desc := metric.MetricDescriptor
+	var sb strings.Builder
+	sb.WriteString(srcKey)
+	sb.WriteString(desc.Name)
+	for _, k := range desc.LabelKeys {
+		sb.WriteString("\x00")
+		sb.WriteString(k.Key)
+	}
+	metricKey := sb.String()
+
+	timeseries := metric.Timeseries[:0]
+	for _, ts := range metric.Timeseries {
+		key := metricKey + seriesKey(ts.LabelValues)
+		points := make([]*metricspb.Point, 0, len(ts.Points))
+		for _, p := range ts.Points {
+			delta, start := ctdp.tracker.convert(key, ts.StartTimestamp, p)
+			if delta == nil {
+				continue
+			}
+			if len(points) == 0 {
+				ts.StartTimestamp = start
+			}
+			points = append(points, delta)
+		}
+		if len(points) == 0 {
+			continue
+		}
+		ts.Points = points
+		timeseries = append(timeseries, ts)
+	}
+
+	metric.Timeseries = timeseries
+	desc.Type = deltaTypes[desc.Type]
+}
+
+// resourceKey returns a key identifying the source of the metrics.
+func resourceKey(node *commonpb.Node, resource *resourcepb.Resource) string {
+	var sb strings.Builder
+	sb.WriteString(node.GetServiceInfo().GetName())
+	sb.WriteString("\x00")
+	sb.WriteString(node.GetIdentifier().GetHostName())
+	sb.WriteString("\x00")
+	sb.WriteString(strconv.FormatUint(uint64(node.GetIdentifier().GetPid()), 10))
+	sb.WriteString("\x00")
+	sb.WriteString(resource.GetType())
+
+	keys := make([]string, 0, len(resource.GetLabels()))
+	for k := range resource.GetLabels() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString("\x00")
+		sb.WriteString(k)
+		sb.WriteString("=")
+		sb.WriteString(resource.Labels[k])
+	}
+	sb.WriteString("\x01")
+	return sb.String()
+}
+
+func seriesKey(labelValues []*metricspb.LabelValue) string {
+	var sb strings.Builder
+	for _, v := range labelValues {
+		sb.WriteString("\x00")
+		if v.GetHasValue() {
+			sb.WriteString("=")
+			sb.WriteString(v.GetValue())
+		}
+	}
+	return sb.String()
+}
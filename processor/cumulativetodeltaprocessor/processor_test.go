@@ -0,0 +1,105 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"testing"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func newMetric(name string, metricType metricspb.MetricDescriptor_Type, points ...*metricspb.Point) *metricspb.Metric {
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      name,
+			Type:      metricType,
+			LabelKeys: []*metricspb.LabelKey{{Key: "host"}},
+		},
+		Timeseries: []*metricspb.TimeSeries{{
+			StartTimestamp: ts(1),
+			LabelValues:    []*metricspb.LabelValue{{Value: "a", HasValue: true}},
+			Points:         points,
+		}},
+	}
+}
+
+func consume(t *testing.T, p *cumulativeToDeltaProcessor, next *exportertest.SinkMetricsExporter, metrics ...*metricspb.Metric) []*metricspb.Metric {
+	next.Reset()
+	md := consumerdata.MetricsData{
+		Node:    &commonpb.Node{Identifier: &commonpb.ProcessIdentifier{HostName: "host"}},
+		Metrics: metrics,
+	}
+	require.NoError(t, p.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md})))
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	mds := pdatautil.MetricsToMetricsData(got[0])
+	require.Len(t, mds, 1)
+	return mds[0].Metrics
+}
+
+func TestCumulativeToDeltaProcessor(t *testing.T) {
+	next := &exportertest.SinkMetricsExporter{}
+	p := newCumulativeToDeltaProcessor(&Config{Metrics: []string{"requests", "latency"}}, zap.NewNop(), next)
+	assert.True(t, p.GetCapabilities().MutatesConsumedData)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, p.Shutdown(context.Background())) }()
+
+	gauge := newMetric("temperature", metricspb.MetricDescriptor_GAUGE_DOUBLE, doublePoint(10, 20))
+	other := newMetric("bytes", metricspb.MetricDescriptor_CUMULATIVE_INT64, int64Point(10, 1))
+
+	// the first points only initialize the series
+	got := consume(t, p, next,
+		newMetric("requests", metricspb.MetricDescriptor_CUMULATIVE_INT64, int64Point(10, 5)),
+		newMetric("latency", metricspb.MetricDescriptor_CUMULATIVE_DOUBLE, doublePoint(10, 1.5)),
+		gauge,
+		other,
+	)
+	assert.Empty(t, cmp.Diff([]*metricspb.Metric{gauge, other}, got, protocmp.Transform()))
+
+	got = consume(t, p, next,
+		newMetric("requests", metricspb.MetricDescriptor_CUMULATIVE_INT64, int64Point(20, 8), int64Point(30, 10)),
+		newMetric("latency", metricspb.MetricDescriptor_CUMULATIVE_DOUBLE, doublePoint(20, 2)),
+	)
+	want := []*metricspb.Metric{
+		newMetric("requests", metricspb.MetricDescriptor_GAUGE_INT64, int64Point(20, 3), int64Point(30, 2)),
+		newMetric("latency", metricspb.MetricDescriptor_GAUGE_DOUBLE, doublePoint(20, 0.5)),
+	}
+	want[0].Timeseries[0].StartTimestamp = ts(10)
+	want[1].Timeseries[0].StartTimestamp = ts(10)
+	assert.Empty(t, cmp.Diff(want, got, protocmp.Transform()))
+}
+
+func TestCumulativeToDeltaProcessorAllMetrics(t *testing.T) {
+	next := &exportertest.SinkMetricsExporter{}
+	p := newCumulativeToDeltaProcessor(createDefaultConfig().(*Config), zap.NewNop(), next)
+
+	consume(t, p, next, newMetric("bytes", metricspb.MetricDescriptor_CUMULATIVE_INT64, int64Point(10, 1)))
+	got := consume(t, p, next, newMetric("bytes", metricspb.MetricDescriptor_CUMULATIVE_INT64, int64Point(20, 4)))
+
+	want := newMetric("bytes", metricspb.MetricDescriptor_GAUGE_INT64, int64Point(20, 3))
+	want.Timeseries[0].StartTimestamp = ts(10)
+	assert.Empty(t, cmp.Diff([]*metricspb.Metric{want}, got, protocmp.Transform()))
+}
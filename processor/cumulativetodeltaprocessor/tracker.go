@@ -0,0 +1,176 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"sync"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/protobuf/proto"
+)
+
+// seriesState is the last cumulative point received for a series.
+type seriesState struct {
+	startTimestamp *timestamp.Timestamp
+	point          *metricspb.Point
+	lastSeen       time.Time
+}
+
+// deltaTracker keeps the last cumulative point of each series, identified by
+// a key, to compute the delta with the next point of the series.
+type deltaTracker struct {
+	mu           sync.Mutex
+	states       map[string]*seriesState
+	maxStaleness time.Duration
+	now          func() time.Time
+}
+
+func newDeltaTracker(maxStaleness time.Duration) *deltaTracker {
+	return &deltaTracker{
+		states:       make(map[string]*seriesState),
+		maxStaleness: maxStaleness,
+		now:          time.Now,
+	}
+}
+
+// convert returns the delta between the point and the previous point of the
+// series with the given key, and the timestamp of the previous point as start
+// of the delta. It returns a nil point when there is no previous point to
+// compute the delta from, or when the point is out of order.
+func (t *deltaTracker) convert(key string, startTimestamp *timestamp.Timestamp, point *metricspb.Point) (*metricspb.Point, *timestamp.Timestamp) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The points are passed to the next consumers, which may modify them.
+	point = proto.Clone(point).(*metricspb.Point)
+
+	state, ok := t.states[key]
+	if !ok {
+		t.states[key] = &seriesState{startTimestamp: startTimestamp, point: point, lastSeen: t.now()}
+		return nil, nil
+	}
+	state.lastSeen = t.now()
+
+	if !isBefore(state.point.Timestamp, point.Timestamp) {
+		return nil, nil
+	}
+
+	prev := state.point
+	restarted := !timestampsEqual(state.startTimestamp, startTimestamp)
+	state.startTimestamp = startTimestamp
+	state.point = point
+
+	delta, reset := subtract(point, prev)
+	if restarted || reset {
+		// The cumulative value restarted from its new start timestamp, the
+		// whole value is the delta since then.
+		return proto.Clone(point).(*metricspb.Point), startTimestamp
+	}
+	return delta, prev.Timestamp
+}
+
+// removeStale removes the states of the series which haven't received a
+// point for longer than the max staleness.
+func (t *deltaTracker) removeStale() {
+	if t.maxStaleness <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	expiry := t.now().Add(-t.maxStaleness)
+	for key, state := range t.states {
+		if state.lastSeen.Before(expiry) {
+			delete(t.states, key)
+		}
+	}
+}
+
+// subtract returns the point whose value is the value of cur minus the value
+// of prev, and whether cur is lower than prev, meaning the cumulative value
+// was reset.
+func subtract(cur, prev *metricspb.Point) (*metricspb.Point, bool) {
+	delta := &metricspb.Point{Timestamp: cur.Timestamp}
+	switch v := cur.Value.(type) {
+	case *metricspb.Point_Int64Value:
+		d := v.Int64Value - prev.GetInt64Value()
+		delta.Value = &metricspb.Point_Int64Value{Int64Value: d}
+		return delta, d < 0
+	case *metricspb.Point_DoubleValue:
+		d := v.DoubleValue - prev.GetDoubleValue()
+		delta.Value = &metricspb.Point_DoubleValue{DoubleValue: d}
+		return delta, d < 0
+	case *metricspb.Point_DistributionValue:
+		d, ok := subtractDistributions(v.DistributionValue, prev.GetDistributionValue())
+		if !ok {
+			return cur, true
+		}
+		delta.Value = &metricspb.Point_DistributionValue{DistributionValue: d}
+		return delta, false
+	default:
+		return cur, true
+	}
+}
+
+// subtractDistributions returns the distribution of the values recorded
+// between prev and cur. It returns false when the distributions don't have
+// the same buckets or when cur has fewer values than prev.
+func subtractDistributions(cur, prev *metricspb.DistributionValue) (*metricspb.DistributionValue, bool) {
+	if prev == nil || cur.Count < prev.Count || len(cur.Buckets) != len(prev.Buckets) ||
+		!boundsEqual(cur.GetBucketOptions().GetExplicit().GetBounds(), prev.GetBucketOptions().GetExplicit().GetBounds()) {
+		return nil, false
+	}
+
+	buckets := make([]*metricspb.DistributionValue_Bucket, len(cur.Buckets))
+	for i, b := range cur.Buckets {
+		count := b.Count - prev.Buckets[i].Count
+		if count < 0 {
+			return nil, false
+		}
+		buckets[i] = &metricspb.DistributionValue_Bucket{Count: count, Exemplar: b.Exemplar}
+	}
+
+	return &metricspb.DistributionValue{
+		Count:         cur.Count - prev.Count,
+		Sum:           cur.Sum - prev.Sum,
+		BucketOptions: cur.BucketOptions,
+		Buckets:       buckets,
+		// The sum of squared deviation of the values recorded in the interval
+		// can't be derived from the cumulative ones.
+	}, true
+}
+
+func boundsEqual(b1, b2 []float64) bool {
+	if len(b1) != len(b2) {
+		return false
+	}
+	for i := range b1 {
+		if b1[i] != b2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isBefore(t1, t2 *timestamp.Timestamp) bool {
+	return t1.GetSeconds() < t2.GetSeconds() || (t1.GetSeconds() == t2.GetSeconds() && t1.GetNanos() < t2.GetNanos())
+}
+
+func timestampsEqual(t1, t2 *timestamp.Timestamp) bool {
+	return t1.GetSeconds() == t2.GetSeconds() && t1.GetNanos() == t2.GetNanos()
+}
@@ -0,0 +1,147 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ts(sec int64) *timestamp.Timestamp {
+	return &timestamp.Timestamp{Seconds: sec}
+}
+
+func int64Point(sec int64, val int64) *metricspb.Point {
+	return &metricspb.Point{Timestamp: ts(sec), Value: &metricspb.Point_Int64Value{Int64Value: val}}
+}
+
+func doublePoint(sec int64, val float64) *metricspb.Point {
+	return &metricspb.Point{Timestamp: ts(sec), Value: &metricspb.Point_DoubleValue{DoubleValue: val}}
+}
+
+func distributionPoint(sec int64, count int64, sum float64, bounds []float64, buckets []int64) *metricspb.Point {
+	bs := make([]*metricspb.DistributionValue_Bucket, len(buckets))
+	for i, c := range buckets {
+		bs[i] = &metricspb.DistributionValue_Bucket{Count: c}
+	}
+	return &metricspb.Point{
+		Timestamp: ts(sec),
+		Value: &metricspb.Point_DistributionValue{
+			DistributionValue: &metricspb.DistributionValue{
+				Count: count,
+				Sum:   sum,
+				BucketOptions: &metricspb.DistributionValue_BucketOptions{
+					Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+						Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{Bounds: bounds},
+					},
+				},
+				Buckets: bs,
+			},
+		},
+	}
+}
+
+func TestConvertInt64(t *testing.T) {
+	tracker := newDeltaTracker(0)
+
+	delta, start := tracker.convert("a", ts(1), int64Point(10, 5))
+	assert.Nil(t, delta)
+	assert.Nil(t, start)
+
+	delta, start = tracker.convert("a", ts(1), int64Point(20, 8))
+	require.NotNil(t, delta)
+	assert.Equal(t, int64(3), delta.GetInt64Value())
+	assert.Equal(t, int64(20), delta.Timestamp.Seconds)
+	assert.Equal(t, int64(10), start.Seconds)
+
+	// out of order points are dropped
+	delta, _ = tracker.convert("a", ts(1), int64Point(15, 7))
+	assert.Nil(t, delta)
+
+	// a lower value is a reset of the cumulative value
+	delta, start = tracker.convert("a", ts(1), int64Point(30, 2))
+	require.NotNil(t, delta)
+	assert.Equal(t, int64(2), delta.GetInt64Value())
+	assert.Equal(t, int64(1), start.Seconds)
+
+	// a new start timestamp is a restart of the cumulative value
+	delta, start = tracker.convert("a", ts(35), int64Point(40, 4))
+	require.NotNil(t, delta)
+	assert.Equal(t, int64(4), delta.GetInt64Value())
+	assert.Equal(t, int64(35), start.Seconds)
+
+	// series are tracked independently
+	delta, _ = tracker.convert("b", ts(1), int64Point(40, 100))
+	assert.Nil(t, delta)
+}
+
+func TestConvertDouble(t *testing.T) {
+	tracker := newDeltaTracker(0)
+
+	delta, _ := tracker.convert("a", ts(1), doublePoint(10, 1.5))
+	assert.Nil(t, delta)
+
+	delta, start := tracker.convert("a", ts(1), doublePoint(20, 4))
+	require.NotNil(t, delta)
+	assert.Equal(t, 2.5, delta.GetDoubleValue())
+	assert.Equal(t, int64(10), start.Seconds)
+}
+
+func TestConvertDistribution(t *testing.T) {
+	tracker := newDeltaTracker(0)
+	bounds := []float64{1, 2}
+
+	delta, _ := tracker.convert("a", ts(1), distributionPoint(10, 3, 4, bounds, []int64{1, 1, 1}))
+	assert.Nil(t, delta)
+
+	delta, start := tracker.convert("a", ts(1), distributionPoint(20, 7, 10, bounds, []int64{2, 3, 2}))
+	require.NotNil(t, delta)
+	assert.Equal(t, int64(10), start.Seconds)
+	dist := delta.GetDistributionValue()
+	assert.Equal(t, int64(4), dist.Count)
+	assert.Equal(t, 6.0, dist.Sum)
+	assert.Equal(t, bounds, dist.BucketOptions.GetExplicit().Bounds)
+	assert.Equal(t, []*metricspb.DistributionValue_Bucket{{Count: 1}, {Count: 2}, {Count: 1}}, dist.Buckets)
+
+	// changed buckets restart the distribution
+	delta, start = tracker.convert("a", ts(1), distributionPoint(30, 8, 11, []float64{1, 5}, []int64{2, 4, 2}))
+	require.NotNil(t, delta)
+	assert.Equal(t, int64(1), start.Seconds)
+	assert.Equal(t, int64(8), delta.GetDistributionValue().Count)
+}
+
+func TestRemoveStale(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := newDeltaTracker(time.Minute)
+	tracker.now = func() time.Time { return now }
+
+	tracker.convert("a", ts(1), int64Point(10, 5))
+	now = now.Add(30 * time.Second)
+	tracker.convert("b", ts(1), int64Point(10, 5))
+
+	now = now.Add(45 * time.Second)
+	tracker.removeStale()
+	assert.Len(t, tracker.states, 1)
+	assert.Contains(t, tracker.states, "b")
+
+	// the next point of a removed series only initializes a new state
+	delta, _ := tracker.convert("a", ts(1), int64Point(20, 8))
+	assert.Nil(t, delta)
+}
@@ -0,0 +1,36 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import "go.opentelemetry.io/collector/config/configmodels"
+
+// Config defines the configuration for the processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Database is the path of the MaxMind City or Country database, e.g.
+	// GeoLite2-City.mmdb.
+	// REQUIRED
+	Database string `mapstructure:"database"`
+
+	// ASNDatabase is the optional path of the MaxMind ASN database, e.g.
+	// GeoLite2-ASN.mmdb. The ASN attributes are only added when it is set.
+	ASNDatabase string `mapstructure:"asn_database"`
+
+	// Attributes are the attributes holding the client IP address, the first
+	// one holding a valid IP address is looked up. Defaults to client.address
+	// and net.peer.ip.
+	Attributes []string `mapstructure:"attributes"`
+}
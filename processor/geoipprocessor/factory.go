@@ -0,0 +1,101 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "geoip"
+)
+
+var (
+	defaultAttributes = []string{"client.address", "net.peer.ip"}
+
+	errMissingDatabase  = errors.New("\"database\" is required")
+	errMissingAttribute = errors.New("\"attributes\" must not be empty")
+	errEmptyAttribute   = errors.New("\"attributes\" must not contain empty names")
+)
+
+// NewFactory returns a new factory for the geoip processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: configmodels.Type(typeStr),
+			NameVal: typeStr,
+		},
+		Attributes: defaultAttributes,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextTraceConsumer consumer.TraceConsumer,
+) (component.TraceProcessor, error) {
+	gp, err := newGeoIPProcessor(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	gp.nextTraceConsumer = nextTraceConsumer
+	return gp, nil
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextLogsConsumer consumer.LogsConsumer,
+) (component.LogsProcessor, error) {
+	gp, err := newGeoIPProcessor(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	gp.nextLogsConsumer = nextLogsConsumer
+	return gp, nil
+}
+
+// validateConfig validates the input configuration has all of the required fields for the processor.
+func validateConfig(cfg *Config) error {
+	if cfg.Database == "" {
+		return errMissingDatabase
+	}
+	if len(cfg.Attributes) == 0 {
+		return errMissingAttribute
+	}
+	for _, attr := range cfg.Attributes {
+		if attr == "" {
+			return errEmptyAttribute
+		}
+	}
+	return nil
+}
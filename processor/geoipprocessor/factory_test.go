@@ -0,0 +1,69 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestType(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, configmodels.Type(typeStr), factory.Type())
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: typeStr,
+			TypeVal: typeStr,
+		},
+		Attributes: []string{"client.address", "net.peer.ip"},
+	}, cfg)
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	// the database is required
+	tp, err := factory.CreateTraceProcessor(context.Background(), params, cfg, &exportertest.SinkTraceExporter{})
+	assert.Equal(t, errMissingDatabase, err)
+	assert.Nil(t, tp)
+
+	cfg.(*Config).Database = "GeoLite2-City.mmdb"
+	tp, err = factory.CreateTraceProcessor(context.Background(), params, cfg, &exportertest.SinkTraceExporter{})
+	assert.NoError(t, err)
+	assert.NotNil(t, tp)
+
+	lp, err := factory.(component.LogsProcessorFactory).CreateLogsProcessor(context.Background(), params, cfg, &exportertest.SinkLogsExporter{})
+	assert.NoError(t, err)
+	assert.NotNil(t, lp)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, &exportertest.SinkMetricsExporter{})
+	assert.Error(t, err)
+	assert.Nil(t, mp)
+}
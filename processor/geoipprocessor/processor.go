@@ -0,0 +1,195 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"context"
+	"net"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	// geoCountry is the attribute holding the ISO 3166-1 code of the country.
+	geoCountry = "geo.country"
+	// geoCity is the attribute holding the English name of the city.
+	geoCity = "geo.city"
+	// geoASN is the attribute holding the autonomous system number.
+	geoASN = "geo.asn"
+	// geoASOrganization is the attribute holding the organization owning the
+	// autonomous system.
+	geoASOrganization = "geo.asn.organization"
+)
+
+type geoIPProcessor struct {
+	logger            *zap.Logger
+	database          string
+	asnDatabase       string
+	attributes        []string
+	newProvider       func(database, asnDatabase string) (provider, error)
+	provider          provider
+	nextTraceConsumer consumer.TraceConsumer
+	nextLogsConsumer  consumer.LogsConsumer
+}
+
+var _ (component.TraceProcessor) = (*geoIPProcessor)(nil)
+var _ (component.LogsProcessor) = (*geoIPProcessor)(nil)
+
+func newGeoIPProcessor(logger *zap.Logger, cfg *Config) (*geoIPProcessor, error) {
+	if err := validateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	return &geoIPProcessor{
+		logger:      logger,
+		database:    cfg.Database,
+		asnDatabase: cfg.ASNDatabase,
+		attributes:  cfg.Attributes,
+		newProvider: func(database, asnDatabase string) (provider, error) {
+			return newMaxMindProvider(database, asnDatabase)
+		},
+	}, nil
+}
+
+func (gp *geoIPProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: true}
+}
+
+// Start opens the databases.
+func (gp *geoIPProcessor) Start(context.Context, component.Host) error {
+	p, err := gp.newProvider(gp.database, gp.asnDatabase)
+	if err != nil {
+		return err
+	}
+	gp.provider = p
+	return nil
+}
+
+// Shutdown closes the databases.
+func (gp *geoIPProcessor) Shutdown(context.Context) error {
+	if gp.provider == nil {
+		return nil
+	}
+	return gp.provider.Close()
+}
+
+// ConsumeTraces adds the location of the client IP address to the resources
+// and the spans holding one.
+func (gp *geoIPProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		if resource := rs.Resource(); !resource.IsNil() {
+			gp.processAttributes(resource.Attributes())
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				gp.processAttributes(span.Attributes())
+			}
+		}
+	}
+
+	return gp.nextTraceConsumer.ConsumeTraces(ctx, td)
+}
+
+// ConsumeLogs adds the location of the client IP address to the logs the same
+// way as for spans.
+func (gp *geoIPProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		if resource := rl.Resource(); !resource.IsNil() {
+			gp.processAttributes(resource.Attributes())
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+				gp.processAttributes(lr.Attributes())
+			}
+		}
+	}
+
+	return gp.nextLogsConsumer.ConsumeLogs(ctx, ld)
+}
+
+// processAttributes looks up the client IP address of the attributes and
+// adds its location to them. The existing attributes are not overwritten.
+func (gp *geoIPProcessor) processAttributes(attrs pdata.AttributeMap) {
+	ip := gp.clientIP(attrs)
+	if ip == nil {
+		return
+	}
+	loc, err := gp.provider.Location(ip)
+	if err != nil {
+		gp.logger.Debug("Failed to look up the IP address location", zap.Stringer("ip", ip), zap.Error(err))
+		return
+	}
+
+	if loc.CountryISOCode != "" {
+		attrs.InsertString(geoCountry, loc.CountryISOCode)
+	}
+	if loc.CityName != "" {
+		attrs.InsertString(geoCity, loc.CityName)
+	}
+	if loc.ASN != 0 {
+		attrs.InsertInt(geoASN, int64(loc.ASN))
+	}
+	if loc.ASOrganization != "" {
+		attrs.InsertString(geoASOrganization, loc.ASOrganization)
+	}
+}
+
+// clientIP returns the first valid IP address held by the configured
+// attributes, nil if none.
+func (gp *geoIPProcessor) clientIP(attrs pdata.AttributeMap) net.IP {
+	for _, name := range gp.attributes {
+		v, ok := attrs.Get(name)
+		if !ok || v.Type() != pdata.AttributeValueSTRING {
+			continue
+		}
+		if ip := net.ParseIP(v.StringVal()); ip != nil {
+			return ip
+		}
+	}
+	return nil
+}
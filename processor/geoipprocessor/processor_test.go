@@ -0,0 +1,220 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+type fakeProvider struct {
+	locations map[string]location
+	closed    bool
+}
+
+func (fp *fakeProvider) Location(ip net.IP) (location, error) {
+	loc, ok := fp.locations[ip.String()]
+	if !ok {
+		return location{}, errors.New("lookup failed")
+	}
+	return loc, nil
+}
+
+func (fp *fakeProvider) Close() error {
+	fp.closed = true
+	return nil
+}
+
+func newTestProcessor(t *testing.T, cfg *Config) (*geoIPProcessor, *fakeProvider) {
+	gp, err := newGeoIPProcessor(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	fp := &fakeProvider{locations: map[string]location{
+		"203.0.113.5": {CountryISOCode: "US", CityName: "Boston", ASN: 64496, ASOrganization: "Example Org"},
+		"2001:db8::1": {CountryISOCode: "FR"},
+		"10.0.0.1":    {},
+	}}
+	gp.newProvider = func(database, asnDatabase string) (provider, error) {
+		assert.Equal(t, cfg.Database, database)
+		assert.Equal(t, cfg.ASNDatabase, asnDatabase)
+		return fp, nil
+	}
+	require.NoError(t, gp.Start(context.Background(), nil))
+	return gp, fp
+}
+
+func TestProcessAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		attrs    map[string]pdata.AttributeValue
+		expected map[string]pdata.AttributeValue
+	}{
+		{
+			name: "city and asn",
+			attrs: map[string]pdata.AttributeValue{
+				"client.address": pdata.NewAttributeValueString("203.0.113.5"),
+			},
+			expected: map[string]pdata.AttributeValue{
+				"client.address":  pdata.NewAttributeValueString("203.0.113.5"),
+				geoCountry:        pdata.NewAttributeValueString("US"),
+				geoCity:           pdata.NewAttributeValueString("Boston"),
+				geoASN:            pdata.NewAttributeValueInt(64496),
+				geoASOrganization: pdata.NewAttributeValueString("Example Org"),
+			},
+		},
+		{
+			name: "first valid attribute",
+			attrs: map[string]pdata.AttributeValue{
+				"client.address": pdata.NewAttributeValueString("unknown"),
+				"net.peer.ip":    pdata.NewAttributeValueString("2001:db8::1"),
+			},
+			expected: map[string]pdata.AttributeValue{
+				"client.address": pdata.NewAttributeValueString("unknown"),
+				"net.peer.ip":    pdata.NewAttributeValueString("2001:db8::1"),
+				geoCountry:       pdata.NewAttributeValueString("FR"),
+			},
+		},
+		{
+			name: "existing attributes",
+			attrs: map[string]pdata.AttributeValue{
+				"net.peer.ip": pdata.NewAttributeValueString("2001:db8::1"),
+				geoCountry:    pdata.NewAttributeValueString("BE"),
+			},
+			expected: map[string]pdata.AttributeValue{
+				"net.peer.ip": pdata.NewAttributeValueString("2001:db8::1"),
+				geoCountry:    pdata.NewAttributeValueString("BE"),
+			},
+		},
+		{
+			name: "unknown location",
+			attrs: map[string]pdata.AttributeValue{
+				"client.address": pdata.NewAttributeValueString("10.0.0.1"),
+			},
+			expected: map[string]pdata.AttributeValue{
+				"client.address": pdata.NewAttributeValueString("10.0.0.1"),
+			},
+		},
+		{
+			name: "lookup error",
+			attrs: map[string]pdata.AttributeValue{
+				"client.address": pdata.NewAttributeValueString("192.0.2.1"),
+			},
+			expected: map[string]pdata.AttributeValue{
+				"client.address": pdata.NewAttributeValueString("192.0.2.1"),
+			},
+		},
+		{
+			name: "not a string",
+			attrs: map[string]pdata.AttributeValue{
+				"client.address": pdata.NewAttributeValueInt(1),
+			},
+			expected: map[string]pdata.AttributeValue{
+				"client.address": pdata.NewAttributeValueInt(1),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gp, _ := newTestProcessor(t, &Config{Database: "city.mmdb", Attributes: defaultAttributes})
+
+			attrs := pdata.NewAttributeMap().InitFromMap(tt.attrs)
+			gp.processAttributes(attrs)
+
+			assert.Equal(t, len(tt.expected), attrs.Len())
+			for k, expected := range tt.expected {
+				v, ok := attrs.Get(k)
+				require.True(t, ok, "missing attribute %q", k)
+				assert.Equal(t, expected, v, "attribute %q", k)
+			}
+		})
+	}
+}
+
+func TestConsumeTraces(t *testing.T) {
+	next := &exportertest.SinkTraceExporter{}
+	gp, fp := newTestProcessor(t, &Config{Database: "city.mmdb", ASNDatabase: "asn.mmdb", Attributes: []string{"client.address"}})
+	gp.nextTraceConsumer = next
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().InitEmpty()
+	rs.Resource().Attributes().InsertString("client.address", "2001:db8::1")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	rs.InstrumentationLibrarySpans().At(0).Spans().Resize(1)
+	span := rs.InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.Attributes().InsertString("client.address", "203.0.113.5")
+
+	require.NoError(t, gp.ConsumeTraces(context.Background(), td))
+	require.Len(t, next.AllTraces(), 1)
+	rs = next.AllTraces()[0].ResourceSpans().At(0)
+	country, ok := rs.Resource().Attributes().Get(geoCountry)
+	require.True(t, ok)
+	assert.Equal(t, "FR", country.StringVal())
+	city, ok := rs.InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes().Get(geoCity)
+	require.True(t, ok)
+	assert.Equal(t, "Boston", city.StringVal())
+
+	require.NoError(t, gp.Shutdown(context.Background()))
+	assert.True(t, fp.closed)
+}
+
+func TestConsumeLogs(t *testing.T) {
+	next := &exportertest.SinkLogsExporter{}
+	gp, _ := newTestProcessor(t, &Config{Database: "city.mmdb", Attributes: []string{"net.peer.ip"}})
+	gp.nextLogsConsumer = next
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	rl.InstrumentationLibraryLogs().At(0).Logs().Resize(1)
+	log := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	log.Attributes().InsertString("net.peer.ip", "203.0.113.5")
+
+	require.NoError(t, gp.ConsumeLogs(context.Background(), ld))
+	require.Len(t, next.AllLogs(), 1)
+	attrs := next.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()
+	assert.Equal(t, 5, attrs.Len())
+	asn, ok := attrs.Get(geoASN)
+	require.True(t, ok)
+	assert.Equal(t, int64(64496), asn.IntVal())
+}
+
+func TestStartMissingDatabase(t *testing.T) {
+	gp, err := newGeoIPProcessor(zap.NewNop(), &Config{Database: "testdata/missing.mmdb", Attributes: defaultAttributes})
+	require.NoError(t, err)
+	assert.Error(t, gp.Start(context.Background(), nil))
+	assert.NoError(t, gp.Shutdown(context.Background()))
+}
+
+func TestInvalidConfig(t *testing.T) {
+	_, err := newGeoIPProcessor(zap.NewNop(), &Config{Attributes: defaultAttributes})
+	assert.Equal(t, errMissingDatabase, err)
+
+	_, err = newGeoIPProcessor(zap.NewNop(), &Config{Database: "city.mmdb"})
+	assert.Equal(t, errMissingAttribute, err)
+
+	_, err = newGeoIPProcessor(zap.NewNop(), &Config{Database: "city.mmdb", Attributes: []string{""}})
+	assert.Equal(t, errEmptyAttribute, err)
+}
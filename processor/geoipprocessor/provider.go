@@ -0,0 +1,110 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoipprocessor
+
+import (
+	"net"
+	"strings"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+// location is the geographical information of an IP address. The fields are
+// empty when unknown.
+type location struct {
+	// CountryISOCode is the ISO 3166-1 code of the country, e.g. US.
+	CountryISOCode string
+	// CityName is the English name of the city.
+	CityName string
+	// ASN is the autonomous system number.
+	ASN uint
+	// ASOrganization is the organization owning the autonomous system.
+	ASOrganization string
+}
+
+// provider looks up the location of IP addresses.
+type provider interface {
+	Location(ip net.IP) (location, error)
+	Close() error
+}
+
+// maxmindProvider looks up the locations in MaxMind databases.
+type maxmindProvider struct {
+	geo     *geoip2.Reader
+	hasCity bool
+	asn     *geoip2.Reader
+}
+
+var _ provider = (*maxmindProvider)(nil)
+
+// newMaxMindProvider opens the City or Country database and the optional ASN
+// database.
+func newMaxMindProvider(database, asnDatabase string) (*maxmindProvider, error) {
+	geo, err := geoip2.Open(database)
+	if err != nil {
+		return nil, err
+	}
+	mp := &maxmindProvider{
+		geo:     geo,
+		hasCity: strings.Contains(geo.Metadata().DatabaseType, "City"),
+	}
+	if asnDatabase != "" {
+		if mp.asn, err = geoip2.Open(asnDatabase); err != nil {
+			geo.Close()
+			return nil, err
+		}
+	}
+	return mp, nil
+}
+
+// Location returns the location of the IP address.
+func (mp *maxmindProvider) Location(ip net.IP) (location, error) {
+	var loc location
+	if mp.hasCity {
+		city, err := mp.geo.City(ip)
+		if err != nil {
+			return loc, err
+		}
+		loc.CountryISOCode = city.Country.IsoCode
+		loc.CityName = city.City.Names["en"]
+	} else {
+		country, err := mp.geo.Country(ip)
+		if err != nil {
+			return loc, err
+		}
+		loc.CountryISOCode = country.Country.IsoCode
+	}
+
+	if mp.asn != nil {
+		asn, err := mp.asn.ASN(ip)
+		if err != nil {
+			return loc, err
+		}
+		loc.ASN = asn.AutonomousSystemNumber
+		loc.ASOrganization = asn.AutonomousSystemOrganization
+	}
+	return loc, nil
+}
+
+// Close closes the databases.
+func (mp *maxmindProvider) Close() error {
+	err := mp.geo.Close()
+	if mp.asn != nil {
+		if asnErr := mp.asn.Close(); err == nil {
+			err = asnErr
+		}
+	}
+	return err
+}
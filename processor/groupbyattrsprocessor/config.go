@@ -0,0 +1,30 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbyattrsprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// GroupByKeys is the list of the attributes (labels for metrics) which are
+	// moved from the spans, metrics and logs to their resource. The records are
+	// then grouped by resource. When it is empty, the records are only grouped
+	// under their identical resources.
+	GroupByKeys []string `mapstructure:"keys"`
+}
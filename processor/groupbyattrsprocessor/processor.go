@@ -0,0 +1,425 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbyattrsprocessor
+
+import (
+	"context"
+	"fmt"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/proto"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+type groupByAttrsProcessor struct {
+	logger              *zap.Logger
+	groupByKeys         []string
+	nextTraceConsumer   consumer.TraceConsumer
+	nextMetricsConsumer consumer.MetricsConsumer
+	nextLogsConsumer    consumer.LogsConsumer
+}
+
+var _ (component.TraceProcessor) = (*groupByAttrsProcessor)(nil)
+var _ (component.MetricsProcessor) = (*groupByAttrsProcessor)(nil)
+var _ (component.LogsProcessor) = (*groupByAttrsProcessor)(nil)
+
+func newGroupByAttrsProcessor(logger *zap.Logger, cfg *Config) *groupByAttrsProcessor {
+	return &groupByAttrsProcessor{
+		logger:      logger,
+		groupByKeys: cfg.GroupByKeys,
+	}
+}
+
+func (gap *groupByAttrsProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: true}
+}
+
+func (gap *groupByAttrsProcessor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (gap *groupByAttrsProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ConsumeTraces moves the grouping attributes of the spans to their resource
+// and regroups the spans by resource and instrumentation library.
+func (gap *groupByAttrsProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	groups := newTraceGroups()
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				resource := gap.groupedResource(rs.Resource(), span.Attributes())
+				groups.instrumentationLibrarySpans(resource, ils.InstrumentationLibrary()).Spans().Append(&span)
+			}
+		}
+	}
+
+	return gap.nextTraceConsumer.ConsumeTraces(ctx, groups.traces)
+}
+
+// ConsumeLogs moves the grouping attributes of the logs to their resource and
+// regroups the logs by resource and instrumentation library.
+func (gap *groupByAttrsProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	groups := newLogGroups()
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				log := logs.At(k)
+				if log.IsNil() {
+					continue
+				}
+				resource := gap.groupedResource(rl.Resource(), log.Attributes())
+				groups.instrumentationLibraryLogs(resource, ill.InstrumentationLibrary()).Logs().Append(&log)
+			}
+		}
+	}
+
+	return gap.nextLogsConsumer.ConsumeLogs(ctx, groups.logs)
+}
+
+// groupedResource returns a copy of the resource to which the grouping
+// attributes are moved from the given record attributes.
+func (gap *groupByAttrsProcessor) groupedResource(resource pdata.Resource, attrs pdata.AttributeMap) pdata.Resource {
+	grouped := pdata.NewResource()
+	grouped.InitEmpty()
+	if !resource.IsNil() {
+		resource.CopyTo(grouped)
+	}
+
+	groupedAttrs := grouped.Attributes()
+	for _, key := range gap.groupByKeys {
+		if v, ok := attrs.Get(key); ok {
+			groupedAttrs.Upsert(key, v)
+			attrs.Delete(key)
+		}
+	}
+	return grouped
+}
+
+// ConsumeMetrics moves the grouping labels of the time series to their
+// resource and regroups the metrics by node and resource.
+// TODO: Move to internal data model once it's available in contrib.
+func (gap *groupByAttrsProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	groups := newMetricsGroups()
+
+	for _, data := range pdatautil.MetricsToMetricsData(md) {
+		for _, metric := range data.Metrics {
+			if metric == nil {
+				continue
+			}
+			for _, split := range gap.splitMetric(metric) {
+				groups.add(data.Node, data.Resource, split.labels, split.metric)
+			}
+		}
+	}
+
+	return gap.nextMetricsConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData(groups.mds))
+}
+
+// metricSplit is the part of a metric whose time series have the same values
+// for the grouping labels.
+type metricSplit struct {
+	labels map[string]string
+	metric *metricspb.Metric
+}
+
+// splitMetric removes the grouping labels from the metric and splits its time
+// series by the values they had for these labels.
+func (gap *groupByAttrsProcessor) splitMetric(metric *metricspb.Metric) []metricSplit {
+	desc := metric.MetricDescriptor
+	var indices []int
+	for i, key := range desc.GetLabelKeys() {
+		if gap.isGroupByKey(key.GetKey()) {
+			indices = append(indices, i)
+		}
+	}
+	if len(indices) == 0 || len(metric.Timeseries) == 0 {
+		return []metricSplit{{metric: metric}}
+	}
+
+	splitDesc := &metricspb.MetricDescriptor{
+		Name:        desc.Name,
+		Description: desc.Description,
+		Unit:        desc.Unit,
+		Type:        desc.Type,
+		LabelKeys:   removeLabelKeys(desc.LabelKeys, indices),
+	}
+
+	var splits []metricSplit
+	splitIndices := make(map[string]int)
+	for _, ts := range metric.Timeseries {
+		labels := make(map[string]string, len(indices))
+		for _, i := range indices {
+			if i < len(ts.LabelValues) && ts.LabelValues[i].GetHasValue() {
+				labels[desc.LabelKeys[i].Key] = ts.LabelValues[i].Value
+			}
+		}
+		ts.LabelValues = removeLabelValues(ts.LabelValues, indices)
+
+		key := fmt.Sprintf("%#v", labels)
+		idx, ok := splitIndices[key]
+		if !ok {
+			idx = len(splits)
+			splitIndices[key] = idx
+			splits = append(splits, metricSplit{
+				labels: labels,
+				metric: &metricspb.Metric{
+					MetricDescriptor: proto.Clone(splitDesc).(*metricspb.MetricDescriptor),
+					Resource:         metric.Resource,
+				},
+			})
+		}
+		splits[idx].metric.Timeseries = append(splits[idx].metric.Timeseries, ts)
+	}
+	return splits
+}
+
+func (gap *groupByAttrsProcessor) isGroupByKey(key string) bool {
+	for _, k := range gap.groupByKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// removeLabelKeys returns a copy of the label keys without the ones at the
+// given sorted indices.
+func removeLabelKeys(keys []*metricspb.LabelKey, indices []int) []*metricspb.LabelKey {
+	result := make([]*metricspb.LabelKey, 0, len(keys))
+	for i, k := range keys {
+		if len(indices) > 0 && indices[0] == i {
+			indices = indices[1:]
+			continue
+		}
+		result = append(result, k)
+	}
+	return result
+}
+
+// removeLabelValues returns a copy of the label values without the ones at
+// the given sorted indices.
+func removeLabelValues(values []*metricspb.LabelValue, indices []int) []*metricspb.LabelValue {
+	result := make([]*metricspb.LabelValue, 0, len(values))
+	for i, v := range values {
+		if len(indices) > 0 && indices[0] == i {
+			indices = indices[1:]
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+// traceGroups accumulates the spans by resource and instrumentation library.
+type traceGroups struct {
+	traces    pdata.Traces
+	resources map[string]pdata.ResourceSpans
+	libraries map[string]pdata.InstrumentationLibrarySpans
+}
+
+func newTraceGroups() *traceGroups {
+	return &traceGroups{
+		traces:    pdata.NewTraces(),
+		resources: make(map[string]pdata.ResourceSpans),
+		libraries: make(map[string]pdata.InstrumentationLibrarySpans),
+	}
+}
+
+func (tg *traceGroups) instrumentationLibrarySpans(resource pdata.Resource, library pdata.InstrumentationLibrary) pdata.InstrumentationLibrarySpans {
+	resourceKey := attributesKey(resource.Attributes())
+	libraryKey := resourceKey + instrumentationLibraryKey(library)
+	if ils, ok := tg.libraries[libraryKey]; ok {
+		return ils
+	}
+
+	rs, ok := tg.resources[resourceKey]
+	if !ok {
+		rss := tg.traces.ResourceSpans()
+		rss.Resize(rss.Len() + 1)
+		rs = rss.At(rss.Len() - 1)
+		resource.CopyTo(rs.Resource())
+		tg.resources[resourceKey] = rs
+	}
+
+	ilss := rs.InstrumentationLibrarySpans()
+	ilss.Resize(ilss.Len() + 1)
+	ils := ilss.At(ilss.Len() - 1)
+	if !library.IsNil() {
+		library.CopyTo(ils.InstrumentationLibrary())
+	}
+	tg.libraries[libraryKey] = ils
+	return ils
+}
+
+// logGroups accumulates the logs by resource and instrumentation library.
+type logGroups struct {
+	logs      pdata.Logs
+	resources map[string]pdata.ResourceLogs
+	libraries map[string]pdata.InstrumentationLibraryLogs
+}
+
+func newLogGroups() *logGroups {
+	return &logGroups{
+		logs:      pdata.NewLogs(),
+		resources: make(map[string]pdata.ResourceLogs),
+		libraries: make(map[string]pdata.InstrumentationLibraryLogs),
+	}
+}
+
+func (lg *logGroups) instrumentationLibraryLogs(resource pdata.Resource, library pdata.InstrumentationLibrary) pdata.InstrumentationLibraryLogs {
+	resourceKey := attributesKey(resource.Attributes())
+	libraryKey := resourceKey + instrumentationLibraryKey(library)
+	if ill, ok := lg.libraries[libraryKey]; ok {
+		return ill
+	}
+
+	rl, ok := lg.resources[resourceKey]
+	if !ok {
+		rls := lg.logs.ResourceLogs()
+		rls.Resize(rls.Len() + 1)
+		rl = rls.At(rls.Len() - 1)
+		resource.CopyTo(rl.Resource())
+		lg.resources[resourceKey] = rl
+	}
+
+	ills := rl.InstrumentationLibraryLogs()
+	ills.Resize(ills.Len() + 1)
+	ill := ills.At(ills.Len() - 1)
+	if !library.IsNil() {
+		library.CopyTo(ill.InstrumentationLibrary())
+	}
+	lg.libraries[libraryKey] = ill
+	return ill
+}
+
+// metricsGroups accumulates the metrics by node and resource.
+type metricsGroups struct {
+	mds     []consumerdata.MetricsData
+	indices map[string]int
+}
+
+func newMetricsGroups() *metricsGroups {
+	return &metricsGroups{indices: make(map[string]int)}
+}
+
+// add adds the metric to the group of the node and of the resource to which
+// the labels are added.
+func (mg *metricsGroups) add(node *commonpb.Node, resource *resourcepb.Resource, labels map[string]string, metric *metricspb.Metric) {
+	if len(labels) > 0 {
+		grouped := &resourcepb.Resource{
+			Type:   resource.GetType(),
+			Labels: make(map[string]string, len(resource.GetLabels())+len(labels)),
+		}
+		for k, v := range resource.GetLabels() {
+			grouped.Labels[k] = v
+		}
+		for k, v := range labels {
+			grouped.Labels[k] = v
+		}
+		resource = grouped
+	}
+
+	key := nodeKey(node) + "\x00" + resource.GetType() + fmt.Sprintf("%#v", resource.GetLabels())
+	idx, ok := mg.indices[key]
+	if !ok {
+		idx = len(mg.mds)
+		mg.indices[key] = idx
+		mg.mds = append(mg.mds, consumerdata.MetricsData{Node: node, Resource: resource})
+	}
+	mg.mds[idx].Metrics = append(mg.mds[idx].Metrics, metric)
+}
+
+// attributesKey returns a key identifying the attributes, independently of
+// their order.
+func attributesKey(attrs pdata.AttributeMap) string {
+	return fmt.Sprintf("%#v", attributesToMap(attrs))
+}
+
+func attributesToMap(am pdata.AttributeMap) map[string]interface{} {
+	mp := make(map[string]interface{}, am.Len())
+	am.ForEach(func(k string, v pdata.AttributeValue) {
+		switch v.Type() {
+		case pdata.AttributeValueBOOL:
+			mp[k] = v.BoolVal()
+		case pdata.AttributeValueINT:
+			mp[k] = v.IntVal()
+		case pdata.AttributeValueDOUBLE:
+			mp[k] = v.DoubleVal()
+		case pdata.AttributeValueSTRING:
+			mp[k] = v.StringVal()
+		case pdata.AttributeValueMAP:
+			mp[k] = attributesToMap(v.MapVal())
+		}
+	})
+	return mp
+}
+
+func instrumentationLibraryKey(library pdata.InstrumentationLibrary) string {
+	if library.IsNil() {
+		return ""
+	}
+	return "\x00" + library.Name() + "\x00" + library.Version()
+}
+
+// nodeKey returns a key identifying the node. Nodes that can't be serialized
+// are only identified by their address.
+func nodeKey(node *commonpb.Node) string {
+	if node == nil {
+		return ""
+	}
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(node); err != nil {
+		return fmt.Sprintf("%p", node)
+	}
+	return string(buf.Bytes())
+}
@@ -0,0 +1,239 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbyattrsprocessor
+
+import (
+	"context"
+	"testing"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func newProcessor(keys ...string) *groupByAttrsProcessor {
+	return newGroupByAttrsProcessor(zap.NewNop(), &Config{GroupByKeys: keys})
+}
+
+// appendResourceSpans appends resource spans with the given resource
+// attributes and one span per host attribute value, an empty value meaning a
+// span without host attribute.
+func appendResourceSpans(td pdata.Traces, resourceAttrs map[string]string, hosts ...string) {
+	rss := td.ResourceSpans()
+	rss.Resize(rss.Len() + 1)
+	rs := rss.At(rss.Len() - 1)
+	rs.Resource().InitEmpty()
+	for k, v := range resourceAttrs {
+		rs.Resource().Attributes().InsertString(k, v)
+	}
+	rs.InstrumentationLibrarySpans().Resize(1)
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	ils.InstrumentationLibrary().InitEmpty()
+	ils.InstrumentationLibrary().SetName("lib")
+	spans := ils.Spans()
+	spans.Resize(len(hosts))
+	for i, host := range hosts {
+		spans.At(i).SetName("span-" + host)
+		if host != "" {
+			spans.At(i).Attributes().InsertString("host", host)
+		}
+		spans.At(i).Attributes().InsertString("other", "value")
+	}
+}
+
+func TestConsumeTraces(t *testing.T) {
+	next := &exportertest.SinkTraceExporter{}
+	gap := newProcessor("host")
+	gap.nextTraceConsumer = next
+
+	td := pdata.NewTraces()
+	appendResourceSpans(td, map[string]string{"service.name": "svc"}, "a", "b", "")
+	appendResourceSpans(td, map[string]string{"service.name": "svc"}, "b")
+	require.NoError(t, gap.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, next.AllTraces(), 1)
+	rss := next.AllTraces()[0].ResourceSpans()
+	require.Equal(t, 3, rss.Len())
+
+	expected := []struct {
+		host  string
+		spans []string
+	}{
+		{host: "a", spans: []string{"span-a"}},
+		{host: "b", spans: []string{"span-b", "span-b"}},
+		{host: "", spans: []string{"span-"}},
+	}
+	for i, e := range expected {
+		rs := rss.At(i)
+		attrs := rs.Resource().Attributes()
+		assert.Equal(t, pdata.NewAttributeValueString("svc"), getAttribute(t, attrs, "service.name"))
+		host, ok := attrs.Get("host")
+		if e.host == "" {
+			assert.False(t, ok)
+		} else {
+			assert.Equal(t, e.host, host.StringVal())
+		}
+
+		require.Equal(t, 1, rs.InstrumentationLibrarySpans().Len())
+		ils := rs.InstrumentationLibrarySpans().At(0)
+		assert.Equal(t, "lib", ils.InstrumentationLibrary().Name())
+		spans := ils.Spans()
+		require.Equal(t, len(e.spans), spans.Len())
+		for j, name := range e.spans {
+			assert.Equal(t, name, spans.At(j).Name())
+			_, ok := spans.At(j).Attributes().Get("host")
+			assert.False(t, ok)
+			assert.Equal(t, pdata.NewAttributeValueString("value"), getAttribute(t, spans.At(j).Attributes(), "other"))
+		}
+	}
+}
+
+func TestConsumeTracesCompaction(t *testing.T) {
+	next := &exportertest.SinkTraceExporter{}
+	gap := newProcessor()
+	gap.nextTraceConsumer = next
+
+	td := pdata.NewTraces()
+	appendResourceSpans(td, map[string]string{"service.name": "svc"}, "a")
+	appendResourceSpans(td, map[string]string{"service.name": "other"}, "b")
+	appendResourceSpans(td, map[string]string{"service.name": "svc"}, "c")
+	require.NoError(t, gap.ConsumeTraces(context.Background(), td))
+
+	rss := next.AllTraces()[0].ResourceSpans()
+	require.Equal(t, 2, rss.Len())
+	assert.Equal(t, 2, rss.At(0).InstrumentationLibrarySpans().At(0).Spans().Len())
+	assert.Equal(t, 1, rss.At(1).InstrumentationLibrarySpans().At(0).Spans().Len())
+	_, ok := rss.At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes().Get("host")
+	assert.True(t, ok)
+}
+
+func TestConsumeLogs(t *testing.T) {
+	next := &exportertest.SinkLogsExporter{}
+	gap := newProcessor("host")
+	gap.nextLogsConsumer = next
+
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rls.At(0).InitEmpty()
+	rls.At(0).InstrumentationLibraryLogs().Resize(1)
+	logs := rls.At(0).InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(3)
+	for i, host := range []string{"a", "b", "a"} {
+		logs.At(i).Body().SetStringVal(host)
+		logs.At(i).Attributes().InsertString("host", host)
+	}
+	require.NoError(t, gap.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, next.AllLogs(), 1)
+	rls = next.AllLogs()[0].ResourceLogs()
+	require.Equal(t, 2, rls.Len())
+	for i, e := range []struct {
+		host  string
+		count int
+	}{{"a", 2}, {"b", 1}} {
+		assert.Equal(t, pdata.NewAttributeValueString(e.host), getAttribute(t, rls.At(i).Resource().Attributes(), "host"))
+		logs := rls.At(i).InstrumentationLibraryLogs().At(0).Logs()
+		require.Equal(t, e.count, logs.Len())
+		for j := 0; j < logs.Len(); j++ {
+			assert.Equal(t, e.host, logs.At(j).Body().StringVal())
+			assert.Equal(t, 0, logs.At(j).Attributes().Len())
+		}
+	}
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	next := &exportertest.SinkMetricsExporter{}
+	gap := newProcessor("host")
+	gap.nextMetricsConsumer = next
+
+	node := &commonpb.Node{Identifier: &commonpb.ProcessIdentifier{HostName: "collector"}}
+	series := func(host, cpu string, value int64) *metricspb.TimeSeries {
+		return &metricspb.TimeSeries{
+			LabelValues: []*metricspb.LabelValue{
+				{Value: host, HasValue: host != ""},
+				{Value: cpu, HasValue: true},
+			},
+			Points: []*metricspb.Point{{Value: &metricspb.Point_Int64Value{Int64Value: value}}},
+		}
+	}
+	mds := []consumerdata.MetricsData{
+		{
+			Node:     node,
+			Resource: &resourcepb.Resource{Labels: map[string]string{"service.name": "svc"}},
+			Metrics: []*metricspb.Metric{
+				{
+					MetricDescriptor: &metricspb.MetricDescriptor{
+						Name:      "cpu",
+						Type:      metricspb.MetricDescriptor_GAUGE_INT64,
+						LabelKeys: []*metricspb.LabelKey{{Key: "host"}, {Key: "cpu"}},
+					},
+					Timeseries: []*metricspb.TimeSeries{series("a", "0", 1), series("b", "0", 2), series("a", "1", 3), series("", "0", 4)},
+				},
+				{
+					MetricDescriptor: &metricspb.MetricDescriptor{Name: "uptime", Type: metricspb.MetricDescriptor_GAUGE_INT64},
+					Timeseries:       []*metricspb.TimeSeries{{Points: []*metricspb.Point{{Value: &metricspb.Point_Int64Value{Int64Value: 5}}}}},
+				},
+			},
+		},
+	}
+	require.NoError(t, gap.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData(mds)))
+
+	require.Len(t, next.AllMetrics(), 1)
+	got := pdatautil.MetricsToMetricsData(next.AllMetrics()[0])
+	require.Len(t, got, 3)
+
+	expected := []struct {
+		labels  map[string]string
+		metrics []string
+		values  [][]int64
+	}{
+		{labels: map[string]string{"service.name": "svc", "host": "a"}, metrics: []string{"cpu"}, values: [][]int64{{1, 3}}},
+		{labels: map[string]string{"service.name": "svc", "host": "b"}, metrics: []string{"cpu"}, values: [][]int64{{2}}},
+		{labels: map[string]string{"service.name": "svc"}, metrics: []string{"cpu", "uptime"}, values: [][]int64{{4}, {5}}},
+	}
+	for i, e := range expected {
+		assert.Equal(t, node, got[i].Node)
+		assert.Equal(t, e.labels, got[i].Resource.Labels)
+		require.Len(t, got[i].Metrics, len(e.metrics))
+		for j, name := range e.metrics {
+			metric := got[i].Metrics[j]
+			assert.Equal(t, name, metric.MetricDescriptor.Name)
+			require.Len(t, metric.Timeseries, len(e.values[j]))
+			for k, v := range e.values[j] {
+				assert.Equal(t, v, metric.Timeseries[k].Points[0].GetInt64Value())
+			}
+			if name == "cpu" {
+				assert.Equal(t, []*metricspb.LabelKey{{Key: "cpu"}}, metric.MetricDescriptor.LabelKeys)
+				for _, ts := range metric.Timeseries {
+					assert.Len(t, ts.LabelValues, 1)
+				}
+			}
+		}
+	}
+}
+
+func getAttribute(t *testing.T, attrs pdata.AttributeMap, key string) pdata.AttributeValue {
+	v, ok := attrs.Get(key)
+	require.True(t, ok, "missing attribute %q", key)
+	return v
+}
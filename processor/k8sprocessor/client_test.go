@@ -28,7 +28,7 @@ import (
 
 // fakeClient is used as a replacement for WatchClient in test cases.
 type fakeClient struct {
-	Pods     map[string]*kube.Pod
+	Pods     map[kube.PodIdentifier]*kube.Pod
 	Rules    kube.ExtractionRules
 	Filters  kube.Filters
 	Informer cache.SharedInformer
@@ -49,7 +49,7 @@ func newFakeClient(_ *zap.Logger, apiCfg k8sconfig.APIConfig, rules kube.Extract
 
 	ls, fs := selectors()
 	return &fakeClient{
-		Pods:     map[string]*kube.Pod{},
+		Pods:     map[kube.PodIdentifier]*kube.Pod{},
 		Rules:    rules,
 		Filters:  filters,
 		Informer: kube.NewFakeInformer(cs, "", ls, fs),
@@ -57,9 +57,9 @@ func newFakeClient(_ *zap.Logger, apiCfg k8sconfig.APIConfig, rules kube.Extract
 	}, nil
 }
 
-// GetPodByIP looks up FakeClient.Pods map by the provided string.
-func (f *fakeClient) GetPodByIP(ip string) (*kube.Pod, bool) {
-	p, ok := f.Pods[ip]
+// GetPod looks up FakeClient.Pods map by the provided identifier.
+func (f *fakeClient) GetPod(identifier kube.PodIdentifier) (*kube.Pod, bool) {
+	p, ok := f.Pods[identifier]
 	return p, ok
 }
 
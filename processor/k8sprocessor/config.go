@@ -39,6 +39,26 @@ type Config struct {
 	// Filter section allows specifying filters to filter
 	// pods by labels, fields, namespaces, nodes, etc.
 	Filter FilterConfig `mapstructure:"filter"`
+
+	// Association section allows specifying the sources, evaluated in order,
+	// of the identifier used to associate spans, metrics and logs with pods.
+	// Spans and logs are associated with the k8s.pod.ip resource attribute,
+	// the ip resource attribute or the connection IP by default, metrics with
+	// the host name.
+	Association []PodAssociationConfig `mapstructure:"pod_association"`
+}
+
+// PodAssociationConfig specifies a source of the identifier, the IP address
+// or the UID, of the pod data comes from.
+type PodAssociationConfig struct {
+	// From is the source of the identifier, one of
+	//   connection: the IP address of the client the data was received from
+	//   resource_attribute: the value of the resource attribute named Name,
+	//     e.g. k8s.pod.ip or k8s.pod.uid
+	From string `mapstructure:"from"`
+
+	// Name is the name of the resource attribute holding the identifier.
+	Name string `mapstructure:"name"`
 }
 
 // ExtractConfig section allows specifying extraction rules to extract
@@ -48,7 +68,13 @@ type ExtractConfig struct {
 	// The field accepts a list of strings.
 	//
 	// Metadata fields supported right now are,
-	//   namespace, podName, podUID, deployment, cluster, node and startTime
+	//   namespace, podName, podUID, deployment, statefulSet, daemonSet, job,
+	//   cronJob, cluster, node and startTime
+	//
+	// The deployment, stateful set, daemon set, job and cron job names are
+	// found by walking the owner references of the pods. The cron job name
+	// is found from the job owning a pod, which requires the "list" and
+	// "watch" permissions on jobs.
 	//
 	// Specifying anything other than these values will result in an error.
 	// By default all of the fields are extracted and added to spans and metrics.
@@ -69,8 +95,8 @@ type ExtractConfig struct {
 
 // FieldExtractConfig allows specifying an extraction rule to extract a value from exactly one field.
 //
-// The field accepts a list FilterExtractConfig map. The map accepts three keys
-//     tag-name, key and regex
+// The field accepts a list FilterExtractConfig map. The map accepts four keys
+//     tag-name, key, key_regex and regex
 //
 // - tag-name represents the name of the tag that will be added to the span.
 //   When not specified a default tag name will be used of the format:
@@ -80,6 +106,19 @@ type ExtractConfig struct {
 //
 // - key represents the annotation name. This must exactly match an annotation name.
 //
+// - key_regex can be used instead of key to extract every annotation whose name
+//   fully matches the regular expression. The tag-name of such a rule may refer to
+//   the submatches of key_regex with $1, ${name}, etc. to rename the extracted fields.
+//   When tag-name is not specified, the default tag name is k8s.<annotation>.$0, $0
+//   being the whole annotation name. For example, the following rule adds every
+//   app.kubernetes.io/<x> label as the app.<x> tag:
+//
+//   processors:
+//     k8s-tagger:
+//       labels:
+//         - tag_name: app.$1
+//           key_regex: app\.kubernetes\.io/(.+)
+//
 // - regex is an optional field used to extract a sub-string from a complex field value.
 //   The supplied regular expression must contain one named parameter with the string "value"
 //   as the name. For example, if your pod spec contains the following annotation,
@@ -89,7 +128,7 @@ type ExtractConfig struct {
 //   and you'd like to extract the GIT_SHA and the CI_BUILD values as tags, then you must
 //   specify the following two extraction rules:
 //
-//   procesors:
+//   processors:
 //     k8s-tagger:
 //       annotations:
 //         - name: git.sha
@@ -101,9 +140,10 @@ type ExtractConfig struct {
 //
 //   this will add the `git.sha` and `ci.build` tags to the spans or metrics.
 type FieldExtractConfig struct {
-	TagName string `mapstructure:"tag_name"`
-	Key     string `mapstructure:"key"`
-	Regex   string `mapstructure:"regex"`
+	TagName  string `mapstructure:"tag_name"`
+	Key      string `mapstructure:"key"`
+	KeyRegex string `mapstructure:"key_regex"`
+	Regex    string `mapstructure:"regex"`
 }
 
 // FilterConfig section allows specifying filters to filter
@@ -150,7 +190,7 @@ type FilterConfig struct {
 	//    - equals
 	//    - not-equals
 	//    - exists
-	//    - not-exists
+	//    - does-not-exist
 	//
 	// Check FieldFilterConfig for more details.
 	Labels []FieldFilterConfig `mapstructure:"labels"`
@@ -74,6 +74,7 @@ func TestLoadConfig(t *testing.T) {
 				Labels: []FieldExtractConfig{
 					{TagName: "l1", Key: "label1"},
 					{TagName: "l2", Key: "label2", Regex: "field=(?P<value>.+)"},
+					{TagName: "app.$1", KeyRegex: `app\.kubernetes\.io/(.+)`},
 				},
 			},
 			Filter: FilterConfig{
@@ -89,5 +90,9 @@ func TestLoadConfig(t *testing.T) {
 					{Key: "key2", Value: "value2", Op: "not-equals"},
 				},
 			},
+			Association: []PodAssociationConfig{
+				{From: "resource_attribute", Name: "k8s.pod.uid"},
+				{From: "connection"},
+			},
 		})
 }
@@ -22,6 +22,11 @@
 // resource attribute which is set by prometheus receiver and some metrics instrumentation libraries.
 // If a match is found, the cached metadata is added to the spans and metrics as resource attributes.
 //
+// The pod data comes from can also be identified by its UID or its IP address set as resource attributes,
+// with the pod_association config section listing the sources of the pod identifier to try in order.
+// This is needed when the connection IP address is not the one of the pod, e.g. behind a gateway
+// collector or for pods in host network.
+//
 // RBAC
 //
 // TODO: mention the required RBAC rules.
@@ -97,7 +102,9 @@
 //
 // This approach is also relevant for metrics data since it's not guaranteed that all the metric formats
 // that used to send data from agent to collector preserve "host.hostname" attribute. We need to rely on an additional
-// attribute keeping a k8s pod IP value in the passthrough mode.
+// attribute keeping a k8s pod IP value in the passthrough mode. The collector uses the "k8s.pod.ip" resource label
+// set by the agents to identify the pod when no pod association matches, so only the collector needs to watch
+// the k8s API and the agents don't run any informers.
 //
 // Caveats
 //
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.uber.org/zap"
 )
 
@@ -45,6 +46,10 @@ func TestCreateProcessor(t *testing.T) {
 	assert.NotNil(t, mp)
 	assert.NoError(t, err)
 
+	lp, err := factory.(component.LogsProcessorFactory).CreateLogsProcessor(context.Background(), params, cfg, &exportertest.SinkLogsExporter{})
+	assert.NotNil(t, lp)
+	assert.NoError(t, err)
+
 	oCfg := cfg.(*Config)
 	oCfg.Passthrough = true
 
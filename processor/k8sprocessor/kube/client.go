@@ -23,6 +23,7 @@ import (
 
 	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -41,11 +42,12 @@ type WatchClient struct {
 	logger          *zap.Logger
 	kc              kubernetes.Interface
 	informer        cache.SharedInformer
+	jobInformer     cache.SharedInformer
 	deploymentRegex *regexp.Regexp
 	deleteQueue     []deleteRequest
 	stopCh          chan struct{}
 
-	Pods    map[string]*Pod
+	Pods    map[PodIdentifier]*Pod
 	Rules   ExtractionRules
 	Filters Filters
 }
@@ -59,7 +61,7 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 	c := &WatchClient{logger: logger, Rules: rules, Filters: filters, deploymentRegex: dRegex, stopCh: make(chan struct{})}
 	go c.deleteLoop(time.Second*30, defaultPodDeleteGracePeriod)
 
-	c.Pods = map[string]*Pod{}
+	c.Pods = map[PodIdentifier]*Pod{}
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -84,11 +86,22 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 	}
 
 	c.informer = newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
+	if c.Rules.CronJob {
+		c.jobInformer = newJobSharedInformer(c.kc, c.Filters.Namespace)
+	}
 	return c, err
 }
 
 // Start registers pod event handlers and starts watching the kubernetes cluster for pod changes.
 func (c *WatchClient) Start() {
+	if c.jobInformer != nil {
+		go c.jobInformer.Run(c.stopCh)
+		// Wait for the jobs to be listed so that the pods listed below get
+		// their cron job names.
+		if !cache.WaitForCacheSync(c.stopCh, c.jobInformer.HasSynced) {
+			c.logger.Warn("Failed to sync the jobs cache, k8s.cronjob.name may be missing")
+		}
+	}
 	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handlePodAdd,
 		UpdateFunc: c.handlePodUpdate,
@@ -152,11 +165,11 @@ func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Durati
 
 			c.m.Lock()
 			for _, d := range toDelete {
-				if p, ok := c.Pods[d.ip]; ok {
+				if p, ok := c.Pods[d.id]; ok {
 					// Sanity check: make sure we are deleting the same pod
-					// and the underlying state (ip<>pod mapping) has not changed.
+					// and the underlying state (id<>pod mapping) has not changed.
 					if p.Name == d.name {
-						delete(c.Pods, d.ip)
+						delete(c.Pods, d.id)
 					}
 				}
 			}
@@ -168,10 +181,11 @@ func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Durati
 	}
 }
 
-// GetPodByIP takes an IP address and returns the pod the IP address is associated with.
-func (c *WatchClient) GetPodByIP(ip string) (*Pod, bool) {
+// GetPod takes a pod identifier, an IP address or a pod UID, and returns the pod
+// the identifier is associated with.
+func (c *WatchClient) GetPod(identifier PodIdentifier) (*Pod, bool) {
 	c.m.RLock()
-	pod, ok := c.Pods[ip]
+	pod, ok := c.Pods[identifier]
 	c.m.RUnlock()
 	if ok {
 		if pod.Ignore {
@@ -205,13 +219,7 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 		tags[conventions.AttributeK8sPodUID] = string(uid)
 	}
 
-	if c.Rules.Deployment {
-		// format: [deployment-name]-[Random-String-For-ReplicaSet]-[Random-String-For-Pod]
-		parts := c.deploymentRegex.FindStringSubmatch(pod.Name)
-		if len(parts) == 2 {
-			tags[conventions.AttributeK8sDeployment] = parts[1]
-		}
-	}
+	c.extractOwnerAttributes(tags, pod)
 
 	if c.Rules.Node {
 		tags[tagNodeName] = pod.Spec.NodeName
@@ -225,17 +233,102 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 	}
 
 	for _, r := range c.Rules.Labels {
-		if v, ok := pod.Labels[r.Key]; ok {
-			tags[r.Name] = c.extractField(v, r)
-		}
+		c.extractFields(tags, pod.Labels, r)
 	}
 
 	for _, r := range c.Rules.Annotations {
-		if v, ok := pod.Annotations[r.Key]; ok {
+		c.extractFields(tags, pod.Annotations, r)
+	}
+	return tags
+}
+
+// extractFields adds the tags extracted by the rule from the given pod fields,
+// labels or annotations.
+func (c *WatchClient) extractFields(tags map[string]string, fields map[string]string, r FieldExtractionRule) {
+	if r.KeyRegex == nil {
+		if v, ok := fields[r.Key]; ok {
 			tags[r.Name] = c.extractField(v, r)
 		}
+		return
+	}
+
+	for k, v := range fields {
+		match := r.KeyRegex.FindStringSubmatchIndex(k)
+		if match == nil {
+			continue
+		}
+		name := string(r.KeyRegex.ExpandString(nil, r.Name, k, match))
+		tags[name] = c.extractField(v, r)
 	}
-	return tags
+}
+
+// extractOwnerAttributes adds the names of the workloads owning the pod by
+// walking its owner references. The deployment of a replica set is derived
+// from the name the controller gives to the replica set, while the cron job of
+// a job is found by getting the job and walking its owner references.
+func (c *WatchClient) extractOwnerAttributes(tags map[string]string, pod *api_v1.Pod) {
+	if len(pod.OwnerReferences) == 0 {
+		if c.Rules.Deployment {
+			// format: [deployment-name]-[Random-String-For-ReplicaSet]-[Random-String-For-Pod]
+			parts := c.deploymentRegex.FindStringSubmatch(pod.Name)
+			if len(parts) == 2 {
+				tags[conventions.AttributeK8sDeployment] = parts[1]
+			}
+		}
+		return
+	}
+
+	for _, ref := range pod.OwnerReferences {
+		switch ref.Kind {
+		case kindReplicaSet:
+			// format: [deployment-name]-[pod-template-hash]
+			hash := pod.Labels[podTemplateHashLabel]
+			if c.Rules.Deployment && hash != "" && strings.HasSuffix(ref.Name, "-"+hash) {
+				tags[conventions.AttributeK8sDeployment] = strings.TrimSuffix(ref.Name, "-"+hash)
+			}
+		case kindStatefulSet:
+			if c.Rules.StatefulSet {
+				tags[conventions.AttributeK8sStatefulSet] = ref.Name
+			}
+		case kindDaemonSet:
+			if c.Rules.DaemonSet {
+				tags[conventions.AttributeK8sDaemonSet] = ref.Name
+			}
+		case kindJob:
+			if c.Rules.Job {
+				tags[conventions.AttributeK8sJob] = ref.Name
+			}
+			if c.Rules.CronJob {
+				if name := c.jobCronJob(pod.Namespace, ref.Name); name != "" {
+					tags[conventions.AttributeK8sCronJob] = name
+				}
+			}
+		}
+	}
+}
+
+// jobCronJob returns the name of the cron job owning the job, or an empty
+// string if the job is not owned by a cron job or is not in the jobs cache.
+func (c *WatchClient) jobCronJob(namespace, name string) string {
+	if c.jobInformer == nil {
+		return ""
+	}
+	obj, exists, err := c.jobInformer.GetStore().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		c.logger.Debug("Unable to find the job owning a pod",
+			zap.String("namespace", namespace), zap.String("job", name), zap.Error(err))
+		return ""
+	}
+	job, ok := obj.(*batch_v1.Job)
+	if !ok {
+		return ""
+	}
+	for _, ref := range job.OwnerReferences {
+		if ref.Kind == kindCronJob {
+			return ref.Name
+		}
+	}
+	return ""
 }
 
 func (c *WatchClient) extractField(v string, r FieldExtractionRule) string {
@@ -253,64 +346,90 @@ func (c *WatchClient) extractField(v string, r FieldExtractionRule) string {
 }
 
 func (c *WatchClient) addOrUpdatePod(pod *api_v1.Pod) {
-	if pod.Status.PodIP == "" {
+	if pod.Status.PodIP == "" && pod.UID == "" {
 		return
 	}
 
+	newPod := &Pod{
+		Name:      pod.Name,
+		Address:   pod.Status.PodIP,
+		StartTime: pod.Status.StartTime,
+	}
+
+	// The attributes are extracted before locking as it may query the API.
+	if c.isExcludedPod(pod) {
+		newPod.Ignore = true
+	} else {
+		newPod.Attributes = c.extractPodAttributes(pod)
+	}
+
 	c.m.Lock()
 	defer c.m.Unlock()
+
+	if pod.UID != "" {
+		c.Pods[PodIdentifier(pod.UID)] = newPod
+	}
+
+	if pod.Status.PodIP == "" {
+		return
+	}
 	// compare initial scheduled timestamp for existing pod and new pod with same IP
 	// and only replace old pod if scheduled time of new pod is newer? This should fix
 	// the case where scheduler has assigned the same IP to a new pod but update event for
 	// the old pod came in later
-	if p, ok := c.Pods[pod.Status.PodIP]; ok {
+	if p, ok := c.Pods[PodIdentifier(pod.Status.PodIP)]; ok {
 		if p.StartTime != nil && pod.Status.StartTime.Before(p.StartTime) {
 			return
 		}
 	}
-	newPod := &Pod{
-		Name:      pod.Name,
-		Address:   pod.Status.PodIP,
-		StartTime: pod.Status.StartTime,
-	}
 
-	if c.shouldIgnorePod(pod) {
-		newPod.Ignore = true
-	} else {
-		newPod.Attributes = c.extractPodAttributes(pod)
+	if c.shouldIgnorePod(pod) && !newPod.Ignore {
+		newPod = &Pod{
+			Name:      pod.Name,
+			Address:   pod.Status.PodIP,
+			StartTime: pod.Status.StartTime,
+			Ignore:    true,
+		}
 	}
-	c.Pods[pod.Status.PodIP] = newPod
+	c.Pods[PodIdentifier(pod.Status.PodIP)] = newPod
 }
 
 func (c *WatchClient) forgetPod(pod *api_v1.Pod) {
-	if pod.Status.PodIP == "" {
-		return
-	}
-	c.m.RLock()
-	p, ok := c.GetPodByIP(pod.Status.PodIP)
-	c.m.RUnlock()
+	for _, id := range []PodIdentifier{PodIdentifier(pod.Status.PodIP), PodIdentifier(pod.UID)} {
+		if id == "" {
+			continue
+		}
+		c.m.RLock()
+		p, ok := c.GetPod(id)
+		c.m.RUnlock()
 
-	if ok && p.Name == pod.Name {
-		c.deleteMut.Lock()
-		c.deleteQueue = append(c.deleteQueue, deleteRequest{
-			ip:   pod.Status.PodIP,
-			name: pod.Name,
-			ts:   time.Now(),
-		})
-		c.deleteMut.Unlock()
+		if ok && p.Name == pod.Name {
+			c.deleteMut.Lock()
+			c.deleteQueue = append(c.deleteQueue, deleteRequest{
+				id:   id,
+				name: pod.Name,
+				ts:   time.Now(),
+			})
+			c.deleteMut.Unlock()
+		}
 	}
 }
 
 func (c *WatchClient) shouldIgnorePod(pod *api_v1.Pod) bool {
-	// Host network mode is not supported right now with IP based
-	// tagging as all pods in host network get same IP addresses.
-	// Such pods are very rare and usually are used to monitor or control
-	// host traffic (e.g, linkerd, flannel) instead of service business needs.
-	// We plan to support host network pods in future.
+	// Host network mode is not supported with IP based tagging as all pods
+	// in host network get same IP addresses. Such pods are very rare and
+	// usually are used to monitor or control host traffic (e.g, linkerd,
+	// flannel) instead of service business needs. They can still be
+	// associated by UID.
 	if pod.Spec.HostNetwork {
 		return true
 	}
+	return c.isExcludedPod(pod)
+}
 
+// isExcludedPod returns whether the pod must be ignored whatever it is
+// associated by.
+func (c *WatchClient) isExcludedPod(pod *api_v1.Pod) bool {
 	// Check if user requested the pod to be ignored through annotations
 	if v, ok := pod.Annotations[ignoreAnnotation]; ok {
 		if strings.ToLower(strings.TrimSpace(v)) == "true" {
@@ -331,7 +450,12 @@ func (c *WatchClient) shouldIgnorePod(pod *api_v1.Pod) bool {
 func selectorsFromFilters(filters Filters) (labels.Selector, fields.Selector, error) {
 	labelSelector := labels.Everything()
 	for _, f := range filters.Labels {
-		r, err := labels.NewRequirement(f.Key, f.Op, []string{f.Value})
+		var vals []string
+		// Existence requirements must not have any value.
+		if f.Op != selection.Exists && f.Op != selection.DoesNotExist {
+			vals = []string{f.Value}
+		}
+		r, err := labels.NewRequirement(f.Key, f.Op, vals)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -26,6 +26,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -151,6 +152,36 @@ func TestPodHostNetwork(t *testing.T) {
 	assert.True(t, got.Ignore)
 }
 
+func TestPodUID(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{PodName: true}, Filters{})
+
+	// pod without IP
+	pod := &api_v1.Pod{}
+	pod.Name = "podA"
+	pod.UID = "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"
+	c.handlePodAdd(pod)
+	assert.Equal(t, len(c.Pods), 1)
+	got, ok := c.GetPod("aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee")
+	require.True(t, ok)
+	assert.Equal(t, got.Name, "podA")
+	assert.Equal(t, got.Attributes, map[string]string{"k8s.pod.name": "podA"})
+
+	// host network pods are only associated by UID
+	pod.Status.PodIP = "1.1.1.1"
+	pod.Spec.HostNetwork = true
+	c.handlePodUpdate(&api_v1.Pod{}, pod)
+	assert.Equal(t, len(c.Pods), 2)
+	_, ok = c.GetPod("1.1.1.1")
+	assert.False(t, ok)
+	got, ok = c.GetPod("aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee")
+	require.True(t, ok)
+	assert.Equal(t, got.Address, "1.1.1.1")
+
+	c.handlePodDelete(pod)
+	require.Equal(t, len(c.deleteQueue), 1)
+	assert.Equal(t, c.deleteQueue[0].id, PodIdentifier("aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"))
+}
+
 func TestPodAddOutOfSync(t *testing.T) {
 	c, _ := newTestClient(t)
 	assert.Equal(t, len(c.Pods), 0)
@@ -222,7 +253,7 @@ func TestPodDelete(t *testing.T) {
 	assert.Equal(t, len(c.Pods), 1)
 	assert.Equal(t, len(c.deleteQueue), 1)
 	deleteRequest := c.deleteQueue[0]
-	assert.Equal(t, deleteRequest.ip, "1.1.1.1")
+	assert.Equal(t, deleteRequest.id, PodIdentifier("1.1.1.1"))
 	assert.Equal(t, deleteRequest.name, "podB")
 	assert.True(t, deleteRequest.ts.After(tsBeforeDelete))
 	assert.True(t, deleteRequest.ts.Before(time.Now()))
@@ -285,8 +316,8 @@ func TestGetIgnoredPod(t *testing.T) {
 	pod := &api_v1.Pod{}
 	pod.Status.PodIP = "1.1.1.1"
 	c.handlePodAdd(pod)
-	c.Pods[pod.Status.PodIP].Ignore = true
-	got, ok := c.GetPodByIP(pod.Status.PodIP)
+	c.Pods[PodIdentifier(pod.Status.PodIP)].Ignore = true
+	got, ok := c.GetPod(PodIdentifier(pod.Status.PodIP))
 	assert.Nil(t, got)
 	assert.False(t, ok)
 }
@@ -316,6 +347,9 @@ func TestExtractionRules(t *testing.T) {
 			Labels: map[string]string{
 				"label1": "lv1",
 				"label2": "k1=v1 k5=v5 extra!",
+
+				"app.kubernetes.io/name":    "auth",
+				"app.kubernetes.io/version": "1.2.0",
 			},
 			Annotations: map[string]string{
 				"annotation1": "av1",
@@ -388,13 +422,37 @@ func TestExtractionRules(t *testing.T) {
 			"l2": "v5",
 			"a1": "av1",
 		},
+	}, {
+		name: "key-regex",
+		rules: ExtractionRules{
+			Annotations: []FieldExtractionRule{{
+				Name:     "k8s.annotation.$0",
+				KeyRegex: regexp.MustCompile(`^(?:annotation.*)$`),
+			},
+			},
+			Labels: []FieldExtractionRule{{
+				Name:     "app.$1",
+				KeyRegex: regexp.MustCompile(`^(?:app\.kubernetes\.io/(.+))$`),
+			}, {
+				Name:     "k5",
+				KeyRegex: regexp.MustCompile(`^(?:label[2-9])$`),
+				Regex:    regexp.MustCompile(`k5=(?P<value>[^\s]+)`),
+			},
+			},
+		},
+		attributes: map[string]string{
+			"k8s.annotation.annotation1": "av1",
+			"app.name":                   "auth",
+			"app.version":                "1.2.0",
+			"k5":                         "v5",
+		},
 	},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			c.Rules = tc.rules
 			c.handlePodAdd(pod)
-			p, ok := c.GetPodByIP(pod.Status.PodIP)
+			p, ok := c.GetPod(PodIdentifier(pod.Status.PodIP))
 			require.True(t, ok)
 
 			assert.Equal(t, len(tc.attributes), len(p.Attributes))
@@ -407,6 +465,111 @@ func TestExtractionRules(t *testing.T) {
 	}
 }
 
+func TestExtractOwnerAttributes(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{
+		Deployment:  true,
+		StatefulSet: true,
+		DaemonSet:   true,
+		Job:         true,
+		CronJob:     true,
+	}, Filters{})
+	for _, job := range []*batch_v1.Job{{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            "report-1599000000",
+			Namespace:       "default",
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "CronJob", Name: "report"}},
+		},
+	}, {
+		ObjectMeta: meta_v1.ObjectMeta{Name: "migrate-2", Namespace: "default"},
+	}} {
+		require.NoError(t, c.jobInformer.GetStore().Add(job))
+	}
+
+	testCases := []struct {
+		name       string
+		podName    string
+		labels     map[string]string
+		owners     []meta_v1.OwnerReference
+		attributes map[string]string
+	}{{
+		name:    "replicaset",
+		podName: "auth-service-7d4b9c6f58-xyz3",
+		labels:  map[string]string{"pod-template-hash": "7d4b9c6f58"},
+		owners:  []meta_v1.OwnerReference{{Kind: "ReplicaSet", Name: "auth-service-7d4b9c6f58"}},
+		attributes: map[string]string{
+			"k8s.deployment.name": "auth-service",
+		},
+	}, {
+		name:       "replicaset-without-deployment",
+		podName:    "auth-service-abc12",
+		owners:     []meta_v1.OwnerReference{{Kind: "ReplicaSet", Name: "auth-service"}},
+		attributes: map[string]string{},
+	}, {
+		name:    "statefulset",
+		podName: "db-0",
+		owners:  []meta_v1.OwnerReference{{Kind: "StatefulSet", Name: "db"}},
+		attributes: map[string]string{
+			"k8s.statefulset.name": "db",
+		},
+	}, {
+		name:    "daemonset",
+		podName: "agent-x7k2p",
+		owners:  []meta_v1.OwnerReference{{Kind: "DaemonSet", Name: "agent"}},
+		attributes: map[string]string{
+			"k8s.daemonset.name": "agent",
+		},
+	}, {
+		name:    "cronjob",
+		podName: "report-1599000000-abcde",
+		owners:  []meta_v1.OwnerReference{{Kind: "Job", Name: "report-1599000000"}},
+		attributes: map[string]string{
+			"k8s.job.name":     "report-1599000000",
+			"k8s.cronjob.name": "report",
+		},
+	}, {
+		name:    "job-not-owned-by-cronjob",
+		podName: "migrate-2-abcde",
+		owners:  []meta_v1.OwnerReference{{Kind: "Job", Name: "migrate-2"}},
+		attributes: map[string]string{
+			"k8s.job.name": "migrate-2",
+		},
+	}, {
+		name:    "unknown-job",
+		podName: "db-backup-20200101-abcde",
+		owners:  []meta_v1.OwnerReference{{Kind: "Job", Name: "db-backup-20200101"}},
+		attributes: map[string]string{
+			"k8s.job.name": "db-backup-20200101",
+		},
+	}, {
+		name:    "job",
+		podName: "migrate-abcde",
+		owners:  []meta_v1.OwnerReference{{Kind: "Job", Name: "migrate"}},
+		attributes: map[string]string{
+			"k8s.job.name": "migrate",
+		},
+	}, {
+		name:    "no-owner",
+		podName: "auth-service-abc12-xyz3",
+		attributes: map[string]string{
+			"k8s.deployment.name": "auth-service",
+		},
+	},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &api_v1.Pod{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:            tc.podName,
+					Namespace:       "default",
+					Labels:          tc.labels,
+					OwnerReferences: tc.owners,
+				},
+			}
+			assert.Equal(t, tc.attributes, c.extractPodAttributes(pod))
+		})
+	}
+}
+
 func TestFilters(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -457,6 +620,21 @@ func TestFilters(t *testing.T) {
 		},
 		labels: "k1=v1,k2!=v2",
 		fields: "k1=v1,k2!=v2",
+	}, {
+		name: "label-existence",
+		filters: Filters{
+			Labels: []FieldFilter{
+				{
+					Key: "k3",
+					Op:  selection.Exists,
+				},
+				{
+					Key: "k4",
+					Op:  selection.DoesNotExist,
+				},
+			},
+		},
+		labels: "k3,!k4",
 	},
 	}
 
@@ -17,6 +17,7 @@ package kube
 import (
 	"context"
 
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -53,6 +54,24 @@ func newSharedInformer(
 	return informer
 }
 
+// newJobSharedInformer returns an informer watching the jobs of the namespace,
+// used to find the cron jobs owning pods without querying the API server for
+// every pod.
+func newJobSharedInformer(client kubernetes.Interface, namespace string) cache.SharedInformer {
+	return cache.NewSharedInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.BatchV1().Jobs(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.BatchV1().Jobs(namespace).Watch(context.Background(), opts)
+			},
+		},
+		&batch_v1.Job{},
+		watchSyncPeriod,
+	)
+}
+
 func informerListFuncWithSelectors(client kubernetes.Interface, namespace string, ls labels.Selector, fs fields.Selector) cache.ListFunc {
 	return func(opts metav1.ListOptions) (runtime.Object, error) {
 		opts.LabelSelector = ls.String()
@@ -15,11 +15,13 @@
 package kube
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
@@ -37,6 +39,24 @@ func Test_newSharedInformer(t *testing.T) {
 	assert.NotNil(t, informer)
 }
 
+func Test_newJobSharedInformer(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	job := &batch_v1.Job{ObjectMeta: metav1.ObjectMeta{Name: "report-1599000000", Namespace: "testns"}}
+	_, err = client.BatchV1().Jobs("testns").Create(context.Background(), job, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	informer := newJobSharedInformer(client, "testns")
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+	require.True(t, cache.WaitForCacheSync(stopCh, informer.HasSynced))
+
+	_, exists, err := informer.GetStore().GetByKey("testns/report-1599000000")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
 func Test_informerListFuncWithSelectors(t *testing.T) {
 	ls, fs, err := selectorsFromFilters(Filters{
 		Fields: []FieldFilter{
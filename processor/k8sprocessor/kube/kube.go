@@ -32,6 +32,16 @@ const (
 
 	tagNodeName  = "k8s.node.name"
 	tagStartTime = "k8s.pod.startTime"
+
+	// podTemplateHashLabel is set by the deployment controller on its pods,
+	// the name of their replica set is [deployment-name]-[pod-template-hash].
+	podTemplateHashLabel = "pod-template-hash"
+
+	kindReplicaSet  = "ReplicaSet"
+	kindStatefulSet = "StatefulSet"
+	kindDaemonSet   = "DaemonSet"
+	kindJob         = "Job"
+	kindCronJob     = "CronJob"
 )
 
 var (
@@ -44,9 +54,12 @@ var (
 	watchSyncPeriod             = time.Minute * 5
 )
 
+// PodIdentifier is the key pods are looked up by, either their IP address or their UID.
+type PodIdentifier string
+
 // Client defines the main interface that allows querying pods by metadata.
 type Client interface {
-	GetPodByIP(string) (*Pod, bool)
+	GetPod(PodIdentifier) (*Pod, bool)
 	Start()
 	Stop()
 }
@@ -70,7 +83,7 @@ type Pod struct {
 }
 
 type deleteRequest struct {
-	ip   string
+	id   PodIdentifier
 	name string
 	ts   time.Time
 }
@@ -93,22 +106,27 @@ type FieldFilter struct {
 	// Value matches the field value.
 	Value string
 	// Op determines the matching operation.
-	// Currently only two operations are supported,
+	// Currently only two operations are supported for fields,
 	//  - Equals
 	//  - NotEquals
+	// Labels also support Exists and DoesNotExist, Value is ignored then.
 	Op selection.Operator
 }
 
 // ExtractionRules is used to specify the information that needs to be extracted
 // from pods and added to the spans as tags.
 type ExtractionRules struct {
-	Deployment bool
-	Namespace  bool
-	PodName    bool
-	PodUID     bool
-	Node       bool
-	Cluster    bool
-	StartTime  bool
+	Deployment  bool
+	StatefulSet bool
+	DaemonSet   bool
+	Job         bool
+	CronJob     bool
+	Namespace   bool
+	PodName     bool
+	PodUID      bool
+	Node        bool
+	Cluster     bool
+	StartTime   bool
 
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
@@ -117,10 +135,14 @@ type ExtractionRules struct {
 // FieldExtractionRule is used to specify which fields to extract from pod fields
 // and inject into spans as attributes.
 type FieldExtractionRule struct {
-	// Name is used to as the Span tag name.
+	// Name is used to as the Span tag name. When KeyRegex is set, it is a
+	// template that may refer to the submatches of KeyRegex, such as $1.
 	Name string
 	// Key is used to lookup k8s pod fields.
 	Key string
+	// KeyRegex is used instead of Key to lookup every k8s pod field whose key
+	// matches the regular expression.
+	KeyRegex *regexp.Regexp
 	// Regex is a regular expression used to extract a sub-part of a field value.
 	// Full value is extracted when no regexp is provided.
 	Regex *regexp.Regexp
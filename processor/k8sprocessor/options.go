@@ -31,13 +31,20 @@ const (
 	filterOPExists       = "exists"
 	filterOPDoesNotExist = "does-not-exist"
 
-	metdataNamespace   = "namespace"
-	metadataPodName    = "podName"
-	metadataPodUID     = "podUID"
-	metadataStartTime  = "startTime"
-	metadataDeployment = "deployment"
-	metadataCluster    = "cluster"
-	metadataNode       = "node"
+	associationSourceConnection        = "connection"
+	associationSourceResourceAttribute = "resource_attribute"
+
+	metdataNamespace    = "namespace"
+	metadataPodName     = "podName"
+	metadataPodUID      = "podUID"
+	metadataStartTime   = "startTime"
+	metadataDeployment  = "deployment"
+	metadataStatefulSet = "statefulSet"
+	metadataDaemonSet   = "daemonSet"
+	metadataJob         = "job"
+	metadataCronJob     = "cronJob"
+	metadataCluster     = "cluster"
+	metadataNode        = "node"
 )
 
 // Option represents a configuration option that can be passes.
@@ -73,6 +80,10 @@ func WithExtractMetadata(fields ...string) Option {
 				metadataPodUID,
 				metadataStartTime,
 				metadataDeployment,
+				metadataStatefulSet,
+				metadataDaemonSet,
+				metadataJob,
+				metadataCronJob,
 				metadataCluster,
 				metadataNode,
 			}
@@ -89,6 +100,14 @@ func WithExtractMetadata(fields ...string) Option {
 				p.rules.StartTime = true
 			case metadataDeployment:
 				p.rules.Deployment = true
+			case metadataStatefulSet:
+				p.rules.StatefulSet = true
+			case metadataDaemonSet:
+				p.rules.DaemonSet = true
+			case metadataJob:
+				p.rules.Job = true
+			case metadataCronJob:
+				p.rules.CronJob = true
 			case metadataCluster:
 				p.rules.Cluster = true
 			case metadataNode:
@@ -128,9 +147,26 @@ func WithExtractAnnotations(annotations ...FieldExtractConfig) Option {
 func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.FieldExtractionRule, error) {
 	rules := []kube.FieldExtractionRule{}
 	for _, a := range fields {
+		if (a.Key == "") == (a.KeyRegex == "") {
+			return rules, fmt.Errorf("exactly one of key or key_regex must be specified for %s extraction rules", fieldType)
+		}
+
+		var keyRegex *regexp.Regexp
+		if a.KeyRegex != "" {
+			var err error
+			keyRegex, err = regexp.Compile("^(?:" + a.KeyRegex + ")$")
+			if err != nil {
+				return rules, err
+			}
+		}
+
 		name := a.TagName
 		if name == "" {
-			name = fmt.Sprintf("k8s.%s.%s", fieldType, a.Key)
+			key := a.Key
+			if keyRegex != nil {
+				key = "$0"
+			}
+			name = fmt.Sprintf("k8s.%s.%s", fieldType, key)
 		}
 
 		var r *regexp.Regexp
@@ -147,7 +183,7 @@ func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.F
 		}
 
 		rules = append(rules, kube.FieldExtractionRule{
-			Name: name, Key: a.Key, Regex: r,
+			Name: name, Key: a.Key, KeyRegex: keyRegex, Regex: r,
 		})
 	}
 	return rules, nil
@@ -234,3 +270,23 @@ func WithFilterFields(filters ...FieldFilterConfig) Option {
 		return nil
 	}
 }
+
+// WithPodAssociations allows specifying the sources, evaluated in order, of
+// the identifier used to associate data with pods.
+func WithPodAssociations(podAssociations ...PodAssociationConfig) Option {
+	return func(p *kubernetesprocessor) error {
+		for _, a := range podAssociations {
+			switch a.From {
+			case associationSourceConnection:
+			case associationSourceResourceAttribute:
+				if a.Name == "" {
+					return fmt.Errorf("name must be specified for the %s pod association source", a.From)
+				}
+			default:
+				return fmt.Errorf("\"%s\" is not a supported pod association source", a.From)
+			}
+		}
+		p.podAssociations = podAssociations
+		return nil
+	}
+}
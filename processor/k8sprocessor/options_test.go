@@ -203,6 +203,10 @@ func TestWithExtractMetadata(t *testing.T) {
 	assert.True(t, p.rules.PodUID)
 	assert.True(t, p.rules.StartTime)
 	assert.True(t, p.rules.Deployment)
+	assert.True(t, p.rules.StatefulSet)
+	assert.True(t, p.rules.DaemonSet)
+	assert.True(t, p.rules.Job)
+	assert.True(t, p.rules.CronJob)
 	assert.True(t, p.rules.Cluster)
 	assert.True(t, p.rules.Node)
 
@@ -219,6 +223,14 @@ func TestWithExtractMetadata(t *testing.T) {
 	assert.False(t, p.rules.StartTime)
 	assert.False(t, p.rules.Deployment)
 	assert.False(t, p.rules.Node)
+
+	p = &kubernetesprocessor{}
+	assert.NoError(t, WithExtractMetadata("statefulSet", "daemonSet", "job", "cronJob")(p))
+	assert.True(t, p.rules.StatefulSet)
+	assert.True(t, p.rules.DaemonSet)
+	assert.True(t, p.rules.Job)
+	assert.True(t, p.rules.CronJob)
+	assert.False(t, p.rules.Deployment)
 }
 
 func TestWithFilterLabels(t *testing.T) {
@@ -484,6 +496,28 @@ func TestWithFilterFields(t *testing.T) {
 	}
 }
 
+func TestWithPodAssociations(t *testing.T) {
+	p := &kubernetesprocessor{}
+	associations := []PodAssociationConfig{
+		{From: "resource_attribute", Name: "k8s.pod.uid"},
+		{From: "connection"},
+	}
+	assert.NoError(t, WithPodAssociations(associations...)(p))
+	assert.Equal(t, associations, p.podAssociations)
+
+	p = &kubernetesprocessor{}
+	assert.NoError(t, WithPodAssociations()(p))
+	assert.Empty(t, p.podAssociations)
+
+	err := WithPodAssociations(PodAssociationConfig{From: "resource_attribute"})(p)
+	assert.Error(t, err)
+	assert.Equal(t, "name must be specified for the resource_attribute pod association source", err.Error())
+
+	err = WithPodAssociations(PodAssociationConfig{From: "label"})(p)
+	assert.Error(t, err)
+	assert.Equal(t, `"label" is not a supported pod association source`, err.Error())
+}
+
 func Test_extractFieldRules(t *testing.T) {
 	type args struct {
 		fieldType string
@@ -550,6 +584,60 @@ func Test_extractFieldRules(t *testing.T) {
 			[]kube.FieldExtractionRule{},
 			true,
 		},
+		{
+			"keyregex",
+			args{"field", []FieldExtractConfig{
+				{
+					TagName:  "app.$1",
+					KeyRegex: `app\.kubernetes\.io/(.+)`,
+				},
+				{
+					KeyRegex: "team.*",
+				},
+			}},
+			[]kube.FieldExtractionRule{
+				{
+					Name:     "app.$1",
+					KeyRegex: regexp.MustCompile(`^(?:app\.kubernetes\.io/(.+))$`),
+				},
+				{
+					Name:     "k8s.field.$0",
+					KeyRegex: regexp.MustCompile(`^(?:team.*)$`),
+				},
+			},
+			false,
+		},
+		{
+			"bad-keyregex",
+			args{"field", []FieldExtractConfig{
+				{
+					KeyRegex: "[",
+				},
+			}},
+			[]kube.FieldExtractionRule{},
+			true,
+		},
+		{
+			"key-and-keyregex",
+			args{"field", []FieldExtractConfig{
+				{
+					Key:      "key",
+					KeyRegex: "key.*",
+				},
+			}},
+			[]kube.FieldExtractionRule{},
+			true,
+		},
+		{
+			"no-key",
+			args{"field", []FieldExtractConfig{
+				{
+					TagName: "name",
+				},
+			}},
+			[]kube.FieldExtractionRule{},
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
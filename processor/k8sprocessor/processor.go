@@ -35,6 +35,14 @@ const (
 	clientIPLabelName string = "ip"
 )
 
+// defaultPodAssociations are used to associate spans and logs with pods when
+// no pod association is configured.
+var defaultPodAssociations = []PodAssociationConfig{
+	{From: associationSourceResourceAttribute, Name: k8sIPLabelName},
+	{From: associationSourceResourceAttribute, Name: clientIPLabelName},
+	{From: associationSourceConnection},
+}
+
 type kubernetesprocessor struct {
 	logger              *zap.Logger
 	apiConfig           k8sconfig.APIConfig
@@ -42,12 +50,15 @@ type kubernetesprocessor struct {
 	passthroughMode     bool
 	rules               kube.ExtractionRules
 	filters             kube.Filters
+	podAssociations     []PodAssociationConfig
 	nextTraceConsumer   consumer.TraceConsumer
 	nextMetricsConsumer consumer.MetricsConsumer
+	nextLogsConsumer    consumer.LogsConsumer
 }
 
 var _ (component.TraceProcessor) = (*kubernetesprocessor)(nil)
 var _ (component.MetricsProcessor) = (*kubernetesprocessor)(nil)
+var _ (component.LogsProcessor) = (*kubernetesprocessor)(nil)
 
 // newTraceProcessor returns a component.TraceProcessor that adds the WithAttributeMap(attributes) to all spans
 // passed to it.
@@ -90,6 +101,26 @@ func newMetricsProcessor(
 	return kp, nil
 }
 
+// newLogsProcessor returns a component.LogsProcessor that adds the k8s attributes to logs passed to it.
+func newLogsProcessor(
+	logger *zap.Logger,
+	nextLogsConsumer consumer.LogsConsumer,
+	kubeClient kube.ClientProvider,
+	options ...Option,
+) (component.LogsProcessor, error) {
+	kp := &kubernetesprocessor{logger: logger, nextLogsConsumer: nextLogsConsumer}
+	for _, opt := range options {
+		if err := opt(kp); err != nil {
+			return nil, err
+		}
+	}
+	err := kp.initKubeClient(logger, kubeClient)
+	if err != nil {
+		return nil, err
+	}
+	return kp, nil
+}
+
 func (kp *kubernetesprocessor) initKubeClient(logger *zap.Logger, kubeClient kube.ClientProvider) error {
 	if kubeClient == nil {
 		kubeClient = kube.New
@@ -129,53 +160,66 @@ func (kp *kubernetesprocessor) ConsumeTraces(ctx context.Context, td pdata.Trace
 		if rs.IsNil() {
 			continue
 		}
+		kp.processResource(ctx, rs.Resource())
+	}
 
-		var podIP string
-		resource := rs.Resource()
-
-		// check if the application, a collector/agent or a prior processor has already
-		// annotated the batch with IP.
-		if !resource.IsNil() {
-			podIP = kp.k8sIPFromAttributes(resource.Attributes())
-		}
-
-		// Check if the receiver detected client IP.
-		if podIP == "" {
-			if c, ok := client.FromContext(ctx); ok {
-				podIP = c.IP
-			}
-		}
-
-		if podIP != "" {
-			if resource.IsNil() {
-				resource.InitEmpty()
-			}
-			resource.Attributes().InsertString(k8sIPLabelName, podIP)
-		}
+	return kp.nextTraceConsumer.ConsumeTraces(ctx, td)
+}
 
-		// Don't invoke any k8s client functionality in passthrough mode.
-		// Just tag the IP and forward the batch.
-		if kp.passthroughMode {
+// ConsumeLogs process logs and add k8s metadata to their resources the same way as for spans.
+func (kp *kubernetesprocessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
 			continue
 		}
+		kp.processResource(ctx, rl.Resource())
+	}
 
-		// add k8s tags to resource
-		attrsToAdd := kp.getAttributesForPodIP(podIP)
-		if len(attrsToAdd) == 0 {
-			continue
+	return kp.nextLogsConsumer.ConsumeLogs(ctx, ld)
+}
+
+// processResource adds the pod IP and the k8s attributes of the pod to the resource.
+func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pdata.Resource) {
+	associations := kp.podAssociations
+	if len(associations) == 0 {
+		associations = defaultPodAssociations
+	}
+	podID := podIdentifier(ctx, associations, func(name string) string {
+		if resource.IsNil() {
+			return ""
 		}
+		return stringAttributeFromMap(resource.Attributes(), name)
+	})
 
+	if net.ParseIP(string(podID)) != nil {
 		if resource.IsNil() {
 			resource.InitEmpty()
 		}
+		resource.Attributes().InsertString(k8sIPLabelName, string(podID))
+	}
 
-		attrs := resource.Attributes()
-		for k, v := range attrsToAdd {
-			attrs.InsertString(k, v)
-		}
+	// Don't invoke any k8s client functionality in passthrough mode.
+	// Just tag the IP and forward the batch.
+	if kp.passthroughMode {
+		return
 	}
 
-	return kp.nextTraceConsumer.ConsumeTraces(ctx, td)
+	// add k8s tags to resource
+	attrsToAdd := kp.getAttributesForPod(podID)
+	if len(attrsToAdd) == 0 {
+		return
+	}
+
+	if resource.IsNil() {
+		resource.InitEmpty()
+	}
+
+	attrs := resource.Attributes()
+	for k, v := range attrsToAdd {
+		attrs.InsertString(k, v)
+	}
 }
 
 // ConsumeMetrics process metrics and add k8s metadata using resource hostname as pod origin.
@@ -186,37 +230,52 @@ func (kp *kubernetesprocessor) ConsumeMetrics(ctx context.Context, metrics pdata
 	for i := range mds {
 		md := &mds[i]
 		var presetPodIP string
-		var podIP string
+		var podID kube.PodIdentifier
 
 		// Check if a collector/agent or a prior processor has already annotated the metrics with IP.
 		if md.Resource.GetLabels() != nil {
 			presetPodIP = md.Resource.Labels[k8sIPLabelName]
 		}
 
+		// Metrics are only associated with the configured pod association sources
+		// as the connection may come from an agent scraping the pods.
+		if len(kp.podAssociations) > 0 {
+			podID = podIdentifier(ctx, kp.podAssociations, func(name string) string {
+				return md.Resource.GetLabels()[name]
+			})
+		}
+
+		// The pod IP tagged by an agent running in passthrough mode identifies the
+		// pod even if the metrics format used between the agent and the collector
+		// doesn't preserve the hostname.
+		if podID == "" && net.ParseIP(presetPodIP) != nil {
+			podID = kube.PodIdentifier(presetPodIP)
+		}
+
 		// Most of the metric receivers uses "host.hostname" resource label (which is represented as
 		// Node.Identifier.HostName in OpenCensus format) to identify metrics origin.
 		// In k8s environment, it's set to a pod IP address. If the value doesn't represent
 		// an IP address, we skip it.
-		if podIP == "" && md.Node.GetIdentifier().GetHostName() != "" {
+		if podID == "" && md.Node.GetIdentifier().GetHostName() != "" {
 			hostname := md.Node.Identifier.HostName
 			if net.ParseIP(hostname) != nil {
-				podIP = hostname
+				podID = kube.PodIdentifier(hostname)
 			}
 		}
 
-		if presetPodIP == "" && podIP != "" {
-			if md.Resource == nil {
-				md.Resource = &resourcepb.Resource{}
-			}
-			if md.Resource.Labels == nil {
-				md.Resource.Labels = map[string]string{}
-			}
-			md.Resource.Labels[k8sIPLabelName] = podIP
+		// Ignore metrics if cannot infer the origin pod.
+		if podID == "" {
+			continue
 		}
 
-		// Ignore metrics if cannot infer IP address of the origin pod.
-		if podIP == "" {
-			continue
+		if md.Resource == nil {
+			md.Resource = &resourcepb.Resource{}
+		}
+		if md.Resource.Labels == nil {
+			md.Resource.Labels = map[string]string{}
+		}
+		if presetPodIP == "" && net.ParseIP(string(podID)) != nil {
+			md.Resource.Labels[k8sIPLabelName] = string(podID)
 		}
 
 		// Don't invoke any k8s client functionality in passthrough mode.
@@ -226,7 +285,7 @@ func (kp *kubernetesprocessor) ConsumeMetrics(ctx context.Context, metrics pdata
 		}
 
 		// Add k8s tags to resource.
-		attrsToAdd := kp.getAttributesForPodIP(podIP)
+		attrsToAdd := kp.getAttributesForPod(podID)
 		if len(attrsToAdd) == 0 {
 			continue
 		}
@@ -239,20 +298,33 @@ func (kp *kubernetesprocessor) ConsumeMetrics(ctx context.Context, metrics pdata
 	return kp.nextMetricsConsumer.ConsumeMetrics(ctx, metrics)
 }
 
-func (kp *kubernetesprocessor) getAttributesForPodIP(ip string) map[string]string {
-	pod, ok := kp.kc.GetPodByIP(ip)
+func (kp *kubernetesprocessor) getAttributesForPod(podID kube.PodIdentifier) map[string]string {
+	pod, ok := kp.kc.GetPod(podID)
 	if !ok {
 		return nil
 	}
 	return pod.Attributes
 }
 
-func (kp *kubernetesprocessor) k8sIPFromAttributes(attrs pdata.AttributeMap) string {
-	ip := stringAttributeFromMap(attrs, k8sIPLabelName)
-	if ip == "" {
-		ip = stringAttributeFromMap(attrs, clientIPLabelName)
+// podIdentifier returns the identifier of the pod the data comes from by
+// evaluating the pod associations in order. The attribute func returns the
+// value of a resource attribute.
+func podIdentifier(ctx context.Context, associations []PodAssociationConfig, attribute func(string) string) kube.PodIdentifier {
+	for _, a := range associations {
+		var id string
+		switch a.From {
+		case associationSourceConnection:
+			if c, ok := client.FromContext(ctx); ok {
+				id = c.IP
+			}
+		case associationSourceResourceAttribute:
+			id = attribute(a.Name)
+		}
+		if id != "" {
+			return kube.PodIdentifier(id)
+		}
 	}
-	return ip
+	return ""
 }
 
 func stringAttributeFromMap(attrs pdata.AttributeMap, key string) string {
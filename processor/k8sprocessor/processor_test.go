@@ -22,6 +22,7 @@ import (
 
 	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/client"
@@ -276,7 +277,7 @@ func TestTraceProcessorAddLabels(t *testing.T) {
 		"2": {},
 	}
 	for ip, attrs := range tests {
-		kc.Pods[ip] = &kube.Pod{Attributes: attrs}
+		kc.Pods[kube.PodIdentifier(ip)] = &kube.Pod{Attributes: attrs}
 	}
 
 	var i int
@@ -299,6 +300,80 @@ func TestTraceProcessorAddLabels(t *testing.T) {
 	}
 }
 
+func TestPodAssociations(t *testing.T) {
+	next := &exportertest.SinkTraceExporter{}
+	p, err := newTraceProcessor(
+		zap.NewNop(),
+		next,
+		newFakeClient,
+		WithPodAssociations(
+			PodAssociationConfig{From: "resource_attribute", Name: "k8s.pod.uid"},
+			PodAssociationConfig{From: "connection"},
+		),
+	)
+	require.NoError(t, err)
+
+	kp := p.(*kubernetesprocessor)
+	kc := kp.kc.(*fakeClient)
+	kc.Pods["aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"] = &kube.Pod{Attributes: map[string]string{"k8s.pod.name": "PodA"}}
+	kc.Pods["1.1.1.1"] = &kube.Pod{Attributes: map[string]string{"k8s.pod.name": "PodB"}}
+	ctx := client.NewContext(context.Background(), &client.Client{IP: "1.1.1.1"})
+
+	// associated by UID
+	traces := generateTraces()
+	resource := traces.ResourceSpans().At(0).Resource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString("k8s.pod.uid", "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee")
+	require.NoError(t, p.ConsumeTraces(ctx, traces))
+	r := next.AllTraces()[0].ResourceSpans().At(0).Resource()
+	assertResourceHasStringAttribute(t, r, "k8s.pod.name", "PodA")
+	_, ok := r.Attributes().Get("k8s.pod.ip")
+	assert.False(t, ok)
+
+	// associated by connection IP
+	require.NoError(t, p.ConsumeTraces(ctx, generateTraces()))
+	r = next.AllTraces()[1].ResourceSpans().At(0).Resource()
+	assertResourceHasStringAttribute(t, r, "k8s.pod.name", "PodB")
+	assertResourceHasStringAttribute(t, r, "k8s.pod.ip", "1.1.1.1")
+
+	// the ip resource attribute is not an association source anymore
+	traces = generateTraces()
+	resource = traces.ResourceSpans().At(0).Resource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString("ip", "2.2.2.2")
+	require.NoError(t, p.ConsumeTraces(ctx, traces))
+	r = next.AllTraces()[2].ResourceSpans().At(0).Resource()
+	assertResourceHasStringAttribute(t, r, "k8s.pod.ip", "1.1.1.1")
+}
+
+func TestMetricsPodAssociations(t *testing.T) {
+	next := &exportertest.SinkMetricsExporter{}
+	p, err := newMetricsProcessor(
+		zap.NewNop(),
+		next,
+		newFakeClient,
+		WithPodAssociations(PodAssociationConfig{From: "resource_attribute", Name: "k8s.pod.uid"}),
+	)
+	require.NoError(t, err)
+
+	kp := p.(*kubernetesprocessor)
+	kc := kp.kc.(*fakeClient)
+	kc.Pods["aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"] = &kube.Pod{Attributes: map[string]string{"k8s.pod.name": "PodA"}}
+
+	metrics := generateMetrics()
+	md := pdatautil.MetricsToMetricsData(metrics)[0]
+	md.Resource = &resourcepb.Resource{Labels: map[string]string{"k8s.pod.uid": "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"}}
+	metrics = pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md})
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), metrics))
+	require.Len(t, next.AllMetrics(), 1)
+	md = pdatautil.MetricsToMetricsData(next.AllMetrics()[0])[0]
+	assert.Equal(t, map[string]string{
+		"k8s.pod.uid":  "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+		"k8s.pod.name": "PodA",
+	}, md.Resource.Labels)
+}
+
 func TestPassthroughStart(t *testing.T) {
 	next := &exportertest.SinkTraceExporter{}
 	opts := []Option{WithPassthrough()}
@@ -513,7 +588,7 @@ func TestMetricsProcessorAddLabels(t *testing.T) {
 		},
 	}
 	for ip, attrs := range tests {
-		kc.Pods[ip] = &kube.Pod{Attributes: attrs}
+		kc.Pods[kube.PodIdentifier(ip)] = &kube.Pod{Attributes: attrs}
 	}
 
 	var i int
@@ -542,6 +617,102 @@ func TestMetricsProcessorAddLabels(t *testing.T) {
 	}
 }
 
+func TestMetricsAgentToGateway(t *testing.T) {
+	gatewayNext := &exportertest.SinkMetricsExporter{}
+	gateway, err := newMetricsProcessor(
+		zap.NewNop(),
+		gatewayNext,
+		newFakeClient,
+	)
+	require.NoError(t, err)
+	kc := gateway.(*kubernetesprocessor).kc.(*fakeClient)
+	kc.Pods["1.1.1.1"] = &kube.Pod{Attributes: map[string]string{"k8s.pod.name": "PodA"}}
+
+	agentNext := &exportertest.SinkMetricsExporter{}
+	agent, err := newMetricsProcessor(
+		zap.NewNop(),
+		agentNext,
+		newFakeClient,
+		WithPassthrough(),
+	)
+	require.NoError(t, err)
+	assert.Nil(t, agent.(*kubernetesprocessor).kc)
+
+	// the agent only tags the pod IP
+	require.NoError(t, agent.ConsumeMetrics(context.Background(), generateMetrics()))
+	require.Len(t, agentNext.AllMetrics(), 1)
+	mds := pdatautil.MetricsToMetricsData(agentNext.AllMetrics()[0])
+	require.Len(t, mds, 1)
+	assert.Equal(t, map[string]string{"k8s.pod.ip": "1.1.1.1"}, mds[0].Resource.Labels)
+
+	// the hostname is not preserved on the way to the gateway
+	mds[0].Node = nil
+	require.NoError(t, gateway.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData(mds)))
+	require.Len(t, gatewayNext.AllMetrics(), 1)
+	mds = pdatautil.MetricsToMetricsData(gatewayNext.AllMetrics()[0])
+	require.Len(t, mds, 1)
+	assert.Equal(t, map[string]string{
+		"k8s.pod.ip":   "1.1.1.1",
+		"k8s.pod.name": "PodA",
+	}, mds[0].Resource.Labels)
+}
+
+func TestNewLogsProcessor(t *testing.T) {
+	_, err := newLogsProcessor(
+		zap.NewNop(),
+		&exportertest.SinkLogsExporter{},
+		newFakeClient,
+	)
+	require.NoError(t, err)
+}
+
+func TestLogsProcessorAddLabels(t *testing.T) {
+	next := &exportertest.SinkLogsExporter{}
+	p, err := newLogsProcessor(
+		zap.NewNop(),
+		next,
+		newFakeClient,
+	)
+	require.NoError(t, err)
+
+	kp, ok := p.(*kubernetesprocessor)
+	assert.True(t, ok)
+	kc, ok := kp.kc.(*fakeClient)
+	assert.True(t, ok)
+
+	attrs := map[string]string{
+		"k8s.pod.name":       "test-2323",
+		"k8s.namespace.name": "default",
+	}
+	kc.Pods["1.1.1.1"] = &kube.Pod{Attributes: attrs}
+
+	ctx := client.NewContext(context.Background(), &client.Client{IP: "1.1.1.1"})
+	err = p.ConsumeLogs(ctx, generateLogs())
+	require.NoError(t, err)
+
+	require.Len(t, next.AllLogs(), 1)
+	rls := next.AllLogs()[0].ResourceLogs()
+	require.Equal(t, rls.Len(), 1)
+	r := rls.At(0).Resource()
+	require.False(t, r.IsNil())
+	assertResourceHasStringAttribute(t, r, "k8s.pod.ip", "1.1.1.1")
+	for k, v := range attrs {
+		assertResourceHasStringAttribute(t, r, k, v)
+	}
+}
+
+func generateLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rls.At(0).InitEmpty()
+	rls.At(0).InstrumentationLibraryLogs().Resize(1)
+	rls.At(0).InstrumentationLibraryLogs().At(0).Logs().Resize(1)
+	lr := rls.At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	lr.Body().SetStringVal("foobar")
+	return ld
+}
+
 func generateMetrics() pdata.Metrics {
 	md := consumerdata.MetricsData{
 		Node: &commonpb.Node{
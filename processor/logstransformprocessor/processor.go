@@ -0,0 +1,166 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logstransformprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+type logsTransformProcessor struct {
+	logger *zap.Logger
+	parser *logparser.Parser
+	next   consumer.LogsConsumer
+}
+
+var _ (component.LogsProcessor) = (*logsTransformProcessor)(nil)
+
+func newLogsTransformProcessor(logger *zap.Logger, cfg *Config, next consumer.LogsConsumer) (*logsTransformProcessor, error) {
+	parser, err := logparser.New(logparser.Config{Operators: cfg.Operators})
+	if err != nil {
+		return nil, err
+	}
+	return &logsTransformProcessor{
+		logger: logger,
+		parser: parser,
+		next:   next,
+	}, nil
+}
+
+func (ltp *logsTransformProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: true}
+}
+
+func (ltp *logsTransformProcessor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (ltp *logsTransformProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ConsumeLogs applies the operators to each log. The logs which fail an
+// operator keep the changes of the previous operators.
+func (ltp *logsTransformProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+				ltp.processLog(lr)
+			}
+		}
+	}
+
+	return ltp.next.ConsumeLogs(ctx, ld)
+}
+
+func (ltp *logsTransformProcessor) processLog(lr pdata.LogRecord) {
+	entry, keys := toEntry(lr)
+	if err := ltp.parser.ProcessEntry(entry); err != nil {
+		ltp.logger.Debug("Failed to transform log", zap.Error(err))
+	}
+	fromEntry(entry, keys, lr)
+}
+
+// toEntry converts the log to an entry of the operators. It also returns the
+// keys of the attributes of the entry, the other attributes of the log, e.g.
+// maps, are not available to the operators.
+func toEntry(lr pdata.LogRecord) (*logparser.Entry, []string) {
+	entry := &logparser.Entry{
+		Attributes:   make(map[string]interface{}, lr.Attributes().Len()),
+		Severity:     logparser.Severity(lr.SeverityNumber()),
+		SeverityText: lr.SeverityText(),
+	}
+	if lr.Timestamp() != 0 {
+		entry.Timestamp = time.Unix(0, int64(lr.Timestamp())).UTC()
+	}
+	if body := lr.Body(); body.Type() == pdata.AttributeValueSTRING {
+		entry.Body = body.StringVal()
+	}
+
+	var keys []string
+	lr.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		switch v.Type() {
+		case pdata.AttributeValueSTRING:
+			entry.Attributes[k] = v.StringVal()
+		case pdata.AttributeValueDOUBLE:
+			entry.Attributes[k] = v.DoubleVal()
+		case pdata.AttributeValueBOOL:
+			entry.Attributes[k] = v.BoolVal()
+		case pdata.AttributeValueINT:
+			entry.Attributes[k] = v.IntVal()
+		default:
+			return
+		}
+		keys = append(keys, k)
+	})
+	return entry, keys
+}
+
+// fromEntry updates the log with the entry processed by the operators. The
+// attributes with the given keys which are no longer in the entry are
+// removed from the log.
+func fromEntry(entry *logparser.Entry, keys []string, lr pdata.LogRecord) {
+	if !entry.Timestamp.IsZero() {
+		lr.SetTimestamp(pdata.TimestampUnixNano(uint64(entry.Timestamp.UnixNano())))
+	}
+	if entry.Severity != logparser.SeverityUndefined {
+		lr.SetSeverityNumber(pdata.SeverityNumber(entry.Severity))
+		lr.SetSeverityText(entry.SeverityText)
+	}
+	if body := lr.Body(); body.Type() == pdata.AttributeValueSTRING || entry.Body != "" {
+		body.SetStringVal(entry.Body)
+	}
+
+	attrs := lr.Attributes()
+	for _, k := range keys {
+		if _, ok := entry.Attributes[k]; !ok {
+			attrs.Delete(k)
+		}
+	}
+	for k, value := range entry.Attributes {
+		switch v := value.(type) {
+		case string:
+			attrs.UpsertString(k, v)
+		case float64:
+			attrs.UpsertDouble(k, v)
+		case bool:
+			attrs.UpsertBool(k, v)
+		case int64:
+			attrs.UpsertInt(k, v)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logstransformprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+func newLogs(bodies ...string) pdata.Logs {
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rls.At(0).InstrumentationLibraryLogs().Resize(1)
+	lrs := rls.At(0).InstrumentationLibraryLogs().At(0).Logs()
+	lrs.Resize(len(bodies))
+	for i, body := range bodies {
+		lrs.At(i).Body().SetStringVal(body)
+		lrs.At(i).Attributes().InsertInt("retries", 2)
+	}
+	return ld
+}
+
+func TestConsumeLogs(t *testing.T) {
+	next := &exportertest.SinkLogsExporter{}
+	ltp, err := newLogsTransformProcessor(zap.NewNop(), &Config{
+		Operators: []logparser.OperatorConfig{
+			{Type: logparser.OperatorRegexParser, Regex: `^(?P<time>\S+) (?P<level>\w+) (?P<msg>.*)$`},
+			{Type: logparser.OperatorSeverityParser, ParseFrom: "level"},
+			{Type: logparser.OperatorTimeParser, ParseFrom: "time"},
+			{Type: logparser.OperatorMove, From: "msg"},
+			{Type: logparser.OperatorCopy, From: "retries", To: "attempts"},
+		},
+	}, next)
+	require.NoError(t, err)
+
+	ld := newLogs("2020-09-01T10:00:00Z WARN disk almost full", "unparsable")
+	require.NoError(t, ltp.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, next.AllLogs(), 1)
+	lrs := next.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 2, lrs.Len())
+
+	lr := lrs.At(0)
+	assert.Equal(t, "disk almost full", lr.Body().StringVal())
+	assert.Equal(t, pdata.SeverityNumber(logparser.SeverityWarn), lr.SeverityNumber())
+	assert.Equal(t, "WARN", lr.SeverityText())
+	assert.Equal(t, pdata.TimestampUnixNano(time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC).UnixNano()), lr.Timestamp())
+	attrs := lr.Attributes()
+	assert.Equal(t, 4, attrs.Len())
+	_, ok := attrs.Get("msg")
+	assert.False(t, ok)
+	for k, want := range map[string]pdata.AttributeValue{
+		"time":     pdata.NewAttributeValueString("2020-09-01T10:00:00Z"),
+		"level":    pdata.NewAttributeValueString("WARN"),
+		"retries":  pdata.NewAttributeValueInt(2),
+		"attempts": pdata.NewAttributeValueInt(2),
+	} {
+		v, ok := attrs.Get(k)
+		require.True(t, ok, "missing attribute %q", k)
+		assert.Equal(t, want, v, "attribute %q", k)
+	}
+
+	// the log failing the first operator is left unchanged
+	lr = lrs.At(1)
+	assert.Equal(t, "unparsable", lr.Body().StringVal())
+	assert.Equal(t, pdata.SeverityNumber(0), lr.SeverityNumber())
+	assert.Equal(t, 1, lr.Attributes().Len())
+}
+
+func TestInvalidOperators(t *testing.T) {
+	_, err := newLogsTransformProcessor(zap.NewNop(), &Config{
+		Operators: []logparser.OperatorConfig{{Type: "xml_parser"}},
+	}, &exportertest.SinkLogsExporter{})
+	assert.EqualError(t, err, `operators[0]: unsupported operator type "xml_parser"`)
+}
@@ -0,0 +1,200 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsdedupprocessor
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+type metricsDedupProcessor struct {
+	logger *zap.Logger
+	next   consumer.MetricsConsumer
+	window time.Duration
+	now    func() time.Time
+
+	mu sync.Mutex
+	// seen maps the identities of the points to the time they were first
+	// received at.
+	seen       map[string]time.Time
+	lastPurged time.Time
+}
+
+var _ component.MetricsProcessor = (*metricsDedupProcessor)(nil)
+
+func newMetricsDedupProcessor(cfg *Config, logger *zap.Logger, next consumer.MetricsConsumer) *metricsDedupProcessor {
+	return &metricsDedupProcessor{
+		logger: logger,
+		next:   next,
+		window: cfg.Window,
+		now:    time.Now,
+		seen:   make(map[string]time.Time),
+	}
+}
+
+// GetCapabilities returns the Capabilities associated with the processor.
+func (mdp *metricsDedupProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: true}
+}
+
+// Start is invoked during service startup.
+func (*metricsDedupProcessor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown is invoked during service shutdown.
+func (*metricsDedupProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ConsumeMetrics drops the points already received within the window. The
+// time series and metrics left without points by the deduplication are
+// removed.
+func (mdp *metricsDedupProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	mdp.mu.Lock()
+	now := mdp.now()
+	mdp.purge(now)
+
+	var dropped int
+	mds := pdatautil.MetricsToMetricsData(md)
+	for i := range mds {
+		data := &mds[i]
+		srcKey := resourceKey(data.Node, data.Resource)
+		metrics := data.Metrics[:0]
+		for _, metric := range data.Metrics {
+			key := srcKey
+			if metric.Resource != nil {
+				key += resourceKey(nil, metric.Resource)
+			}
+			n := mdp.dedupMetric(now, key, metric)
+			dropped += n
+			if n > 0 && len(metric.Timeseries) == 0 {
+				continue
+			}
+			metrics = append(metrics, metric)
+		}
+		data.Metrics = metrics
+	}
+	mdp.mu.Unlock()
+
+	if dropped > 0 {
+		mdp.logger.Debug("Dropped duplicate points", zap.Int("count", dropped))
+	}
+	return mdp.next.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData(mds))
+}
+
+// dedupMetric removes the duplicate points of the metric and returns their
+// number.
+func (mdp *metricsDedupProcessor) dedupMetric(now time.Time, srcKey string, metric *metricspb.Metric) int {
+	desc := metric.GetMetricDescriptor()
+	var sb strings.Builder
+	sb.WriteString(srcKey)
+	sb.WriteString(desc.GetName())
+	for _, k := range desc.GetLabelKeys() {
+		sb.WriteString("\x00")
+		sb.WriteString(k.GetKey())
+	}
+	metricKey := sb.String()
+
+	var dropped int
+	timeseries := metric.Timeseries[:0]
+	for _, ts := range metric.Timeseries {
+		key := metricKey + seriesKey(ts.GetLabelValues()) + "\x01"
+		points := ts.Points[:0]
+		for _, p := range ts.Points {
+			if p.GetTimestamp() != nil {
+				pointKey := key + strconv.FormatInt(p.Timestamp.Seconds, 10) + "." + strconv.FormatInt(int64(p.Timestamp.Nanos), 10)
+				if seenAt, ok := mdp.seen[pointKey]; ok && now.Sub(seenAt) < mdp.window {
+					dropped++
+					continue
+				}
+				mdp.seen[pointKey] = now
+			}
+			points = append(points, p)
+		}
+		if len(points) == 0 {
+			continue
+		}
+		ts.Points = points
+		timeseries = append(timeseries, ts)
+	}
+	metric.Timeseries = timeseries
+	return dropped
+}
+
+// purge forgets the points received before the window, at most once per
+// window.
+func (mdp *metricsDedupProcessor) purge(now time.Time) {
+	if now.Sub(mdp.lastPurged) < mdp.window {
+		return
+	}
+	for key, seenAt := range mdp.seen {
+		if now.Sub(seenAt) >= mdp.window {
+			delete(mdp.seen, key)
+		}
+	}
+	mdp.lastPurged = now
+}
+
+// resourceKey returns a key identifying the source of the metrics.
+func resourceKey(node *commonpb.Node, resource *resourcepb.Resource) string {
+	var sb strings.Builder
+	sb.WriteString(node.GetServiceInfo().GetName())
+	sb.WriteString("\x00")
+	sb.WriteString(node.GetIdentifier().GetHostName())
+	sb.WriteString("\x00")
+	sb.WriteString(strconv.FormatUint(uint64(node.GetIdentifier().GetPid()), 10))
+	sb.WriteString("\x00")
+	sb.WriteString(resource.GetType())
+
+	keys := make([]string, 0, len(resource.GetLabels()))
+	for k := range resource.GetLabels() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString("\x00")
+		sb.WriteString(k)
+		sb.WriteString("=")
+		sb.WriteString(resource.Labels[k])
+	}
+	sb.WriteString("\x01")
+	return sb.String()
+}
+
+func seriesKey(labelValues []*metricspb.LabelValue) string {
+	var sb strings.Builder
+	for _, v := range labelValues {
+		sb.WriteString("\x00")
+		if v.GetHasValue() {
+			sb.WriteString("=")
+			sb.WriteString(v.GetValue())
+		}
+	}
+	return sb.String()
+}
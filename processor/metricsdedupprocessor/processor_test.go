@@ -0,0 +1,116 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsdedupprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func newMetric(name, host string, timestamps ...int64) *metricspb.Metric {
+	points := make([]*metricspb.Point, len(timestamps))
+	for i, ts := range timestamps {
+		points[i] = &metricspb.Point{
+			Timestamp: &timestamp.Timestamp{Seconds: ts},
+			Value:     &metricspb.Point_Int64Value{Int64Value: ts},
+		}
+	}
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      name,
+			Type:      metricspb.MetricDescriptor_GAUGE_INT64,
+			LabelKeys: []*metricspb.LabelKey{{Key: "host"}},
+		},
+		Timeseries: []*metricspb.TimeSeries{{
+			LabelValues: []*metricspb.LabelValue{{Value: host, HasValue: true}},
+			Points:      points,
+		}},
+	}
+}
+
+// consume sends the metrics to the processor and returns the timestamps of
+// the points it forwarded, by metric name and host.
+func consume(t *testing.T, mdp *metricsDedupProcessor, next *exportertest.SinkMetricsExporter, metrics ...*metricspb.Metric) map[string][]int64 {
+	next.Reset()
+	md := consumerdata.MetricsData{
+		Node:    &commonpb.Node{Identifier: &commonpb.ProcessIdentifier{HostName: "scraper"}},
+		Metrics: metrics,
+	}
+	require.NoError(t, mdp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md})))
+	require.Len(t, next.AllMetrics(), 1)
+
+	got := make(map[string][]int64)
+	for _, data := range pdatautil.MetricsToMetricsData(next.AllMetrics()[0]) {
+		for _, metric := range data.Metrics {
+			for _, ts := range metric.Timeseries {
+				key := metric.MetricDescriptor.Name + "/" + ts.LabelValues[0].Value
+				for _, p := range ts.Points {
+					got[key] = append(got[key], p.Timestamp.Seconds)
+				}
+			}
+		}
+	}
+	return got
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	now := time.Unix(1000, 0)
+	next := &exportertest.SinkMetricsExporter{}
+	mdp := newMetricsDedupProcessor(&Config{Window: time.Minute}, zap.NewNop(), next)
+	mdp.now = func() time.Time { return now }
+
+	got := consume(t, mdp, next, newMetric("cpu", "a", 10, 20), newMetric("cpu", "b", 10))
+	assert.Equal(t, map[string][]int64{"cpu/a": {10, 20}, "cpu/b": {10}}, got)
+
+	// the same points sent by another collector are dropped
+	now = now.Add(30 * time.Second)
+	got = consume(t, mdp, next, newMetric("cpu", "a", 20, 30), newMetric("cpu", "b", 10), newMetric("memory", "a", 10))
+	assert.Equal(t, map[string][]int64{"cpu/a": {30}, "memory/a": {10}}, got)
+
+	// the points are forgotten after the window
+	now = now.Add(45 * time.Second)
+	got = consume(t, mdp, next, newMetric("cpu", "a", 10, 30))
+	assert.Equal(t, map[string][]int64{"cpu/a": {10}}, got)
+	assert.Len(t, mdp.seen, 3)
+}
+
+func TestConsumeMetricsWithoutTimestamp(t *testing.T) {
+	next := &exportertest.SinkMetricsExporter{}
+	mdp := newMetricsDedupProcessor(&Config{Window: time.Minute}, zap.NewNop(), next)
+
+	metric := func() *metricspb.Metric {
+		m := newMetric("cpu", "a", 10)
+		m.Timeseries[0].Points[0].Timestamp = nil
+		return m
+	}
+	for i := 0; i < 2; i++ {
+		next.Reset()
+		require.NoError(t, mdp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{{Metrics: []*metricspb.Metric{metric()}}})))
+		got := pdatautil.MetricsToMetricsData(next.AllMetrics()[0])
+		require.Len(t, got[0].Metrics, 1)
+		assert.Len(t, got[0].Metrics[0].Timeseries[0].Points, 1)
+	}
+}
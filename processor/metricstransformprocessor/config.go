@@ -20,6 +20,9 @@ const (
 	// MetricNameFieldName is the mapstructure field name for MetricName field
 	MetricNameFieldName = "metric_name"
 
+	// MatchTypeFieldName is the mapstructure field name for MatchType field
+	MatchTypeFieldName = "match_type"
+
 	// ActionFieldName is the mapstructure field name for Action field
 	ActionFieldName = "action"
 
@@ -34,6 +37,12 @@ const (
 
 	// NewValueFieldName is the mapstructure field name for NewValue field
 	NewValueFieldName = "new_value"
+
+	// AggregationTypeFieldName is the mapstructure field name for AggregationType field
+	AggregationTypeFieldName = "aggregation_type"
+
+	// ScaleFieldName is the mapstructure field name for Scale field
+	ScaleFieldName = "experimental_scale"
 )
 
 // Config defines configuration for Resource processor.
@@ -50,11 +59,17 @@ type Transform struct {
 	// REQUIRED
 	MetricName string `mapstructure:"metric_name"`
 
+	// MatchType determines how MetricName is matched against the metric names,
+	// either strictly or as a regular expression. Defaults to strict.
+	MatchType MatchType `mapstructure:"match_type"`
+
 	// Action specifies the action performed on the matched metric.
 	// REQUIRED
 	Action ConfigAction `mapstructure:"action"`
 
 	// NewName specifies the name of the new metric when inserting or updating.
+	// When MatchType is regexp, it can reference the capture groups of MetricName,
+	// e.g. $1 or ${name}.
 	// REQUIRED only if Action is INSERT.
 	NewName string `mapstructure:"new_name"`
 
@@ -84,6 +99,7 @@ type Operation struct {
 	AggregatedValues []string `mapstructure:"aggregated_values"`
 
 	// NewValue is used to set a new label value either when the operation is `AggregatedValues` or `AddLabel`.
+	// When the transform's MatchType is regexp, it can reference the capture groups of its MetricName.
 	NewValue string `mapstructure:"new_value"`
 
 	// ValueActions is a list of renaming actions for label values.
@@ -91,6 +107,12 @@ type Operation struct {
 
 	// LabelValue identifies the exact label value to operate on
 	LabelValue string `mapstructure:"label_value"`
+
+	// Scale is the factor the values are multiplied by when the operation is `ScaleValue`.
+	Scale float64 `mapstructure:"experimental_scale"`
+
+	// NewUnit is used to set the unit of the metric when the operation is `ScaleValue`.
+	NewUnit string `mapstructure:"new_unit"`
 }
 
 // ValueAction renames label values.
@@ -99,19 +121,29 @@ type ValueAction struct {
 	Value string `mapstructure:"value"`
 
 	// NewValue specifies the label value to rename to.
+	// When the transform's MatchType is regexp, it can reference the capture groups of its MetricName.
 	NewValue string `mapstructure:"new_value"`
 }
 
+// MatchType is the enum to capture the two types of matching of the metric names.
+type MatchType string
+
 // ConfigAction is the enum to capture the two types of actions to perform on a metric.
 type ConfigAction string
 
 // OperationAction is the enum to capture the thress types of actions to perform for an operation.
 type OperationAction string
 
-// AggregationType os the enum to capture the three types of aggregation for the aggregation operation.
+// AggregationType is the enum to capture the types of aggregation for the aggregation operation.
 type AggregationType string
 
 const (
+	// StrictMatchType matches the metrics whose name is exactly MetricName.
+	StrictMatchType MatchType = "strict"
+
+	// RegexpMatchType matches the metrics whose name matches the regular expression MetricName.
+	RegexpMatchType MatchType = "regexp"
+
 	// Insert adds a new metric to the batch with a new name.
 	Insert ConfigAction = "insert"
 
@@ -138,6 +170,10 @@ const (
 	// DeleteLabelValue deletes a label value by also removing all the points associated with this label value
 	DeleteLabelValue OperationAction = "delete_label_value"
 
+	// ScaleValue multiplies the values of the metric by Operation.Scale, e.g. to convert
+	// its unit, and sets its unit to Operation.NewUnit if provided.
+	ScaleValue OperationAction = "experimental_scale_value"
+
 	// Mean indicates taking the mean of the aggregated data.
 	Mean AggregationType = "mean"
 
@@ -149,4 +185,7 @@ const (
 
 	// Min indicates taking the minimum of the aggregated data.
 	Min AggregationType = "min"
+
+	// Count indicates taking the number of the aggregated data points.
+	Count AggregationType = "count"
 )
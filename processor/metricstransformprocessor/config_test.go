@@ -79,6 +79,23 @@ var (
 				},
 			},
 		},
+		{
+			filterName: "metricstransform/regexp",
+			expCfg: &Config{
+				ProcessorSettings: configmodels.ProcessorSettings{
+					NameVal: "metricstransform/regexp",
+					TypeVal: typeStr,
+				},
+				Transforms: []Transform{
+					{
+						MetricName: `^k8s\.pod\.(cpu|memory)$`,
+						MatchType:  RegexpMatchType,
+						Action:     Insert,
+						NewName:    "kubernetes.pod.usage",
+					},
+				},
+			},
+		},
 		{
 			filterName: "metricstransform/addlabel",
 			expCfg: &Config{
@@ -126,6 +126,8 @@ func (mtp *metricsTransformProcessor) mergeInt64(points []*metricspb.Point, aggr
 	}
 	if aggrType == Mean {
 		intVal /= int64(len(points))
+	} else if aggrType == Count {
+		intVal = int64(len(points))
 	}
 	return &metricspb.Point_Int64Value{Int64Value: intVal}
 }
@@ -148,6 +150,8 @@ func (mtp *metricsTransformProcessor) mergeDouble(points []*metricspb.Point, agg
 	}
 	if aggrType == Mean {
 		doubleVal /= float64(len(points))
+	} else if aggrType == Count {
+		doubleVal = float64(len(points))
 	}
 	return &metricspb.Point_DoubleValue{DoubleValue: doubleVal}
 }
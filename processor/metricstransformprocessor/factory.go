@@ -17,6 +17,7 @@ package metricstransformprocessor
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configerror"
@@ -81,6 +82,16 @@ func validateConfiguration(config *Config) error {
 			return fmt.Errorf("missing required field %q", MetricNameFieldName)
 		}
 
+		if transform.MatchType != "" && transform.MatchType != StrictMatchType && transform.MatchType != RegexpMatchType {
+			return fmt.Errorf("unsupported %q: %v, the supported match types are %q and %q", MatchTypeFieldName, transform.MatchType, StrictMatchType, RegexpMatchType)
+		}
+
+		if transform.MatchType == RegexpMatchType {
+			if _, err := regexp.Compile(transform.MetricName); err != nil {
+				return fmt.Errorf("%q, %v, is not a valid regexp: %v", MetricNameFieldName, transform.MetricName, err)
+			}
+		}
+
 		if transform.Action != Update && transform.Action != Insert {
 			return fmt.Errorf("unsupported %q: %v, the supported actions are %q and %q", ActionFieldName, transform.Action, Insert, Update)
 		}
@@ -99,11 +110,26 @@ func validateConfiguration(config *Config) error {
 			if op.Action == AddLabel && op.NewValue == "" {
 				return fmt.Errorf("missing required field %q while %q is %v in the %vth operation", NewValueFieldName, ActionFieldName, AddLabel, i)
 			}
+			if op.Action == AggregateLabelValues && op.Label == "" {
+				return fmt.Errorf("missing required field %q while %q is %v in the %vth operation", LabelFieldName, ActionFieldName, AggregateLabelValues, i)
+			}
+			if op.Action == AggregateLabelValues && op.NewValue == "" {
+				return fmt.Errorf("missing required field %q while %q is %v in the %vth operation", NewValueFieldName, ActionFieldName, AggregateLabelValues, i)
+			}
+			if op.Action == ScaleValue && op.Scale == 0 {
+				return fmt.Errorf("missing required field %q while %q is %v in the %vth operation", ScaleFieldName, ActionFieldName, ScaleValue, i)
+			}
+			if (op.Action == AggregateLabels || op.Action == AggregateLabelValues) && !validAggregationTypes[op.AggregationType] {
+				return fmt.Errorf("unsupported %q: %v in the %vth operation, the supported types are %q, %q, %q, %q and %q",
+					AggregationTypeFieldName, op.AggregationType, i, Sum, Mean, Max, Min, Count)
+			}
 		}
 	}
 	return nil
 }
 
+var validAggregationTypes = map[AggregationType]bool{Sum: true, Mean: true, Max: true, Min: true, Count: true}
+
 // buildHelperConfig constructs the maps that will be useful for the operations
 func buildHelperConfig(config *Config) []internalTransform {
 	helperDataTransforms := make([]internalTransform, len(config.Transforms))
@@ -114,6 +140,9 @@ func buildHelperConfig(config *Config) []internalTransform {
 			NewName:    t.NewName,
 			Operations: make([]internalOperation, len(t.Operations)),
 		}
+		if t.MatchType == RegexpMatchType {
+			helperT.MetricNameRegexp = regexp.MustCompile(t.MetricName)
+		}
 		for j, op := range t.Operations {
 			mtpOp := internalOperation{
 				configOperation: op,
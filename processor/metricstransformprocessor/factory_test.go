@@ -149,6 +149,88 @@ func TestFactory_validateConfiguration(t *testing.T) {
 
 	err = validateConfiguration(&v2)
 	assert.Equal(t, "missing required field \"new_value\" while \"action\" is add_label in the 0th operation", err.Error())
+
+	v3 := Config{
+		Transforms: []Transform{
+			{
+				MetricName: "mymetric",
+				Action:     Update,
+				Operations: []Operation{
+					{
+						Action:   AggregateLabels,
+						LabelSet: []string{"foo"},
+					},
+				},
+			},
+		},
+	}
+
+	err = validateConfiguration(&v3)
+	assert.Equal(t, "unsupported \"aggregation_type\":  in the 0th operation, the supported types are \"sum\", \"mean\", \"max\", \"min\" and \"count\"", err.Error())
+
+	v4 := Config{
+		Transforms: []Transform{
+			{
+				MetricName: "mymetric",
+				Action:     Update,
+				Operations: []Operation{
+					{
+						Action:           AggregateLabelValues,
+						AggregatedValues: []string{"bar"},
+						NewValue:         "baz",
+						AggregationType:  Count,
+					},
+				},
+			},
+		},
+	}
+
+	err = validateConfiguration(&v4)
+	assert.Equal(t, "missing required field \"label\" while \"action\" is aggregate_label_values in the 0th operation", err.Error())
+
+	v5 := Config{
+		Transforms: []Transform{
+			{
+				MetricName: "mymetric",
+				Action:     Update,
+				Operations: []Operation{
+					{
+						Action:  ScaleValue,
+						NewUnit: "ms",
+					},
+				},
+			},
+		},
+	}
+
+	err = validateConfiguration(&v5)
+	assert.Equal(t, "missing required field \"experimental_scale\" while \"action\" is experimental_scale_value in the 0th operation", err.Error())
+
+	v6 := Config{
+		Transforms: []Transform{
+			{
+				MetricName: "mymetric",
+				MatchType:  "invalid",
+				Action:     Update,
+			},
+		},
+	}
+
+	err = validateConfiguration(&v6)
+	assert.Equal(t, "unsupported \"match_type\": invalid, the supported match types are \"strict\" and \"regexp\"", err.Error())
+
+	v7 := Config{
+		Transforms: []Transform{
+			{
+				MetricName: "(mymetric",
+				MatchType:  RegexpMatchType,
+				Action:     Update,
+			},
+		},
+	}
+
+	err = validateConfiguration(&v7)
+	assert.EqualError(t, err, "\"metric_name\", (mymetric, is not a valid regexp: error parsing regexp: missing closing ): `(mymetric`")
 }
 
 func TestCreateProcessorsFilledData(t *testing.T) {
@@ -238,6 +320,7 @@ func TestCreateProcessorsFilledData(t *testing.T) {
 	}
 
 	internalTransforms := buildHelperConfig(oCfg)
+	assert.Nil(t, internalTransforms[0].MetricNameRegexp)
 
 	for i, expTr := range expData {
 		mtpT := internalTransforms[i]
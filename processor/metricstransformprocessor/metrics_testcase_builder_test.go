@@ -39,6 +39,12 @@ func (b builder) setName(name string) builder {
 	return b
 }
 
+// setUnit sets the unit of the metric
+func (b builder) setUnit(unit string) builder {
+	b.metric.MetricDescriptor.Unit = unit
+	return b
+}
+
 // setLabels sets the labels for the metric
 func (b builder) setLabels(labels []string) builder {
 	labelKeys := make([]*metricspb.LabelKey, len(labels))
@@ -16,11 +16,13 @@ package metricstransformprocessor
 
 import (
 	"context"
+	"regexp"
 
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/pdatautil"
 	"go.uber.org/zap"
@@ -29,9 +31,11 @@ import (
 
 type internalTransform struct {
 	MetricName string
-	Action     ConfigAction
-	NewName    string
-	Operations []internalOperation
+	// MetricNameRegexp is set when the metric name is matched as a regexp.
+	MetricNameRegexp *regexp.Regexp
+	Action           ConfigAction
+	NewName          string
+	Operations       []internalOperation
 }
 
 type internalOperation struct {
@@ -89,28 +93,75 @@ func (mtp *metricsTransformProcessor) transform(md pdata.Metrics) pdata.Metrics
 		}
 
 		for _, transform := range mtp.transforms {
+			if transform.MetricNameRegexp != nil {
+				// The metrics inserted by this transform are not matched again, range
+				// only iterates over the metrics present before it.
+				for _, metric := range data.Metrics {
+					name := metric.MetricDescriptor.Name
+					match := transform.MetricNameRegexp.FindStringSubmatchIndex(name)
+					if match == nil {
+						continue
+					}
+					mtp.transformMetric(data, nameToMetricMapping, metric, expandTransform(transform, name, match))
+				}
+				continue
+			}
+
 			metric, ok := nameToMetricMapping[transform.MetricName]
 			if !ok {
 				continue
 			}
+			mtp.transformMetric(data, nameToMetricMapping, metric, transform)
+		}
+	}
 
-			if transform.Action == Insert {
-				metric = proto.Clone(metric).(*metricspb.Metric)
-				data.Metrics = append(data.Metrics, metric)
-			}
+	return pdatautil.MetricsFromMetricsData(mds)
+}
 
-			mtp.update(metric, transform)
+// transformMetric applies the transform to the metric, or to a copy of it inserted in data.
+func (mtp *metricsTransformProcessor) transformMetric(data *consumerdata.MetricsData, nameToMetricMapping map[string]*metricspb.Metric, metric *metricspb.Metric, transform internalTransform) {
+	oldName := metric.MetricDescriptor.Name
+	if transform.Action == Insert {
+		metric = proto.Clone(metric).(*metricspb.Metric)
+		data.Metrics = append(data.Metrics, metric)
+	}
 
-			if transform.NewName != "" {
-				if transform.Action == Update {
-					delete(nameToMetricMapping, transform.MetricName)
-				}
-				nameToMetricMapping[transform.NewName] = metric
-			}
+	mtp.update(metric, transform)
+
+	if transform.NewName != "" {
+		if transform.Action == Update {
+			delete(nameToMetricMapping, oldName)
 		}
+		nameToMetricMapping[transform.NewName] = metric
 	}
+}
 
-	return pdatautil.MetricsFromMetricsData(mds)
+// expandTransform returns a copy of the transform whose new metric name and new label values
+// have the references to the capture groups of the metric name regexp replaced by the values
+// matched in name.
+func expandTransform(transform internalTransform, name string, match []int) internalTransform {
+	expand := func(template string) string {
+		if template == "" {
+			return template
+		}
+		return string(transform.MetricNameRegexp.ExpandString(nil, template, name, match))
+	}
+
+	expanded := transform
+	expanded.NewName = expand(transform.NewName)
+	expanded.Operations = make([]internalOperation, len(transform.Operations))
+	for i, op := range transform.Operations {
+		op.configOperation.NewValue = expand(op.configOperation.NewValue)
+		if op.valueActionsMapping != nil {
+			mapping := make(map[string]string, len(op.valueActionsMapping))
+			for value, newValue := range op.valueActionsMapping {
+				mapping[value] = expand(newValue)
+			}
+			op.valueActionsMapping = mapping
+		}
+		expanded.Operations[i] = op
+	}
+	return expanded
 }
 
 // update updates the metric content based on operations indicated in transform.
@@ -133,6 +184,8 @@ func (mtp *metricsTransformProcessor) update(metric *metricspb.Metric, transform
 			mtp.addLabelOp(metric, op)
 		case DeleteLabelValue:
 			mtp.deleteLabelValueOp(metric, op)
+		case ScaleValue:
+			mtp.scaleValueOp(metric, op)
 		}
 	}
 }
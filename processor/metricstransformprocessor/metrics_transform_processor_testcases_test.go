@@ -15,6 +15,8 @@
 package metricstransformprocessor
 
 import (
+	"regexp"
+
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 )
 
@@ -84,6 +86,67 @@ var (
 				metricBuilder().setName("metric1").build(),
 			},
 		},
+		{
+			name: "metric_name_update_regexp",
+			transforms: []internalTransform{
+				{
+					MetricName:       `^k8s\.(.*)$`,
+					MetricNameRegexp: regexp.MustCompile(`^k8s\.(.*)$`),
+					Action:           Update,
+					NewName:          "kubernetes.$1",
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("k8s.pod.cpu").build(),
+				metricBuilder().setName("container.cpu").build(),
+				metricBuilder().setName("k8s.node.memory").build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("kubernetes.pod.cpu").build(),
+				metricBuilder().setName("container.cpu").build(),
+				metricBuilder().setName("kubernetes.node.memory").build(),
+			},
+		},
+		{
+			name: "metric_label_values_update_regexp",
+			transforms: []internalTransform{
+				{
+					MetricName:       `^system\.(?P<resource>[^.]+)\.usage$`,
+					MetricNameRegexp: regexp.MustCompile(`^system\.(?P<resource>[^.]+)\.usage$`),
+					Action:           Update,
+					NewName:          "usage",
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:   AddLabel,
+								NewLabel: "resource",
+								NewValue: "${resource}",
+							},
+						},
+						{
+							configOperation: Operation{
+								Action: UpdateLabel,
+								Label:  "state",
+								ValueActions: []ValueAction{
+									{Value: "used", NewValue: "${resource}_used"},
+								},
+							},
+							valueActionsMapping: map[string]string{"used": "${resource}_used"},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("system.memory.usage").setLabels([]string{"state"}).
+					addTimeseries(1, []string{"used"}).addTimeseries(1, []string{"free"}).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("usage").setLabels([]string{"state", "resource"}).
+					addTimeseries(1, []string{"memory_used", "memory"}).addTimeseries(1, []string{"free", "memory"}).
+					build(),
+			},
+		},
 		{
 			name: "metric_label_update",
 			transforms: []internalTransform{
@@ -264,6 +327,38 @@ var (
 					build(),
 			},
 		},
+		{
+			name: "metric_label_aggregation_count_int_update",
+			transforms: []internalTransform{
+				{
+					MetricName: "metric1",
+					Action:     Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:          AggregateLabels,
+								AggregationType: Count,
+								LabelSet:        []string{"label1"},
+							},
+							labelSetMap: map[string]bool{"label1": true},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"label1", "label2"}).setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"label1-value1", "label2-value1"}).addTimeseries(1, []string{"label1-value1", "label2-value2"}).
+					addTimeseries(1, []string{"label1-value1", "label2-value3"}).
+					addInt64Point(0, 3, 2).addInt64Point(1, 1, 2).addInt64Point(2, 3, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"label1"}).setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"label1-value1"}).
+					addInt64Point(0, 3, 2).
+					build(),
+			},
+		},
 		{
 			name: "metric_label_aggregation_sum_double_update",
 			transforms: []internalTransform{
@@ -389,6 +484,37 @@ var (
 					build(),
 			},
 		},
+		{
+			name: "metric_label_aggregation_count_double_update",
+			transforms: []internalTransform{
+				{
+					MetricName: "metric1",
+					Action:     Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:          AggregateLabels,
+								AggregationType: Count,
+								LabelSet:        []string{"label1"},
+							},
+							labelSetMap: map[string]bool{"label1": true},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"label1", "label2"}).setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).
+					addTimeseries(1, []string{"label1-value1", "label2-value1"}).addTimeseries(1, []string{"label1-value1", "label2-value2"}).
+					addDoublePoint(0, 3, 2).addDoublePoint(1, 1, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"label1"}).setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).
+					addTimeseries(1, []string{"label1-value1"}).
+					addDoublePoint(0, 2, 2).
+					build(),
+			},
+		},
 		{
 			name: "metric_label_values_aggregation_sum_int_update",
 			transforms: []internalTransform{
@@ -531,6 +657,29 @@ var (
 				metricBuilder().setName("new/metric2").build(),
 			},
 		},
+		{
+			name: "metric_name_insert_regexp",
+			transforms: []internalTransform{
+				{
+					MetricName:       `^(.*)/usage$`,
+					MetricNameRegexp: regexp.MustCompile(`^(.*)/usage$`),
+					Action:           Insert,
+					NewName:          "$1/utilization",
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("cpu/usage").build(),
+				metricBuilder().setName("memory/usage").build(),
+				metricBuilder().setName("disk/io").build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("cpu/usage").build(),
+				metricBuilder().setName("memory/usage").build(),
+				metricBuilder().setName("disk/io").build(),
+				metricBuilder().setName("cpu/utilization").build(),
+				metricBuilder().setName("memory/utilization").build(),
+			},
+		},
 		{
 			name: "metric_label_update_with_metric_insert",
 			transforms: []internalTransform{
@@ -792,6 +941,92 @@ var (
 			},
 		},
 		// Add Label to a metric
+		{
+			name: "metric_scale_value_int64_with_new_unit",
+			transforms: []internalTransform{
+				{
+					MetricName: "metric1",
+					Action:     Update,
+					NewName:    "metric1_kib",
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:  ScaleValue,
+								Scale:   1.0 / 1024,
+								NewUnit: "KiBy",
+							},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setUnit("By").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, nil).addInt64Point(0, 4096, 2).addInt64Point(0, 1500, 3).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1_kib").setUnit("KiBy").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, nil).addInt64Point(0, 4, 2).addInt64Point(0, 1, 3).
+					build(),
+			},
+		},
+		{
+			name: "metric_scale_value_double",
+			transforms: []internalTransform{
+				{
+					MetricName: "metric1",
+					Action:     Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action: ScaleValue,
+								Scale:  1000,
+							},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setUnit("s").setDataType(metricspb.MetricDescriptor_CUMULATIVE_DOUBLE).
+					addTimeseries(1, nil).addDoublePoint(0, 1.5, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setUnit("s").setDataType(metricspb.MetricDescriptor_CUMULATIVE_DOUBLE).
+					addTimeseries(1, nil).addDoublePoint(0, 1500, 2).
+					build(),
+			},
+		},
+		{
+			name: "metric_scale_value_distribution",
+			transforms: []internalTransform{
+				{
+					MetricName: "metric1",
+					Action:     Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:  ScaleValue,
+								Scale:   1000,
+								NewUnit: "ms",
+							},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setUnit("s").setDataType(metricspb.MetricDescriptor_GAUGE_DISTRIBUTION).
+					addTimeseries(1, nil).
+					addDistributionPoints(0, 1, 3, 6, []float64{1, 2, 3}, []int64{0, 1, 1, 1}, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setUnit("ms").setDataType(metricspb.MetricDescriptor_GAUGE_DISTRIBUTION).
+					addTimeseries(1, nil).
+					addDistributionPoints(0, 1, 3, 6000, []float64{1000, 2000, 3000}, []int64{0, 1, 1, 1}, 2000000).
+					build(),
+			},
+		},
 		{
 			name: "update existing metric by adding a new label when there are no labels",
 			transforms: []internalTransform{
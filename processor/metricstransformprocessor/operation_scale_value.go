@@ -0,0 +1,68 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+
+// scaleValueOp multiplies the values of the points of the metric by the scale of the operation,
+// and updates the unit of the metric accordingly
+func (mtp *metricsTransformProcessor) scaleValueOp(metric *metricspb.Metric, mtpOp internalOperation) {
+	op := mtpOp.configOperation
+	isDistribution := metric.MetricDescriptor.Type == metricspb.MetricDescriptor_GAUGE_DISTRIBUTION ||
+		metric.MetricDescriptor.Type == metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION
+	if isDistribution && op.Scale < 0 {
+		mtp.logger.Warn("Distribution data can only be scaled by a positive factor")
+		return
+	}
+
+	for _, ts := range metric.Timeseries {
+		for _, dp := range ts.Points {
+			switch metric.MetricDescriptor.Type {
+			case metricspb.MetricDescriptor_GAUGE_INT64, metricspb.MetricDescriptor_CUMULATIVE_INT64:
+				dp.Value = &metricspb.Point_Int64Value{Int64Value: int64(float64(dp.GetInt64Value()) * op.Scale)}
+			case metricspb.MetricDescriptor_GAUGE_DOUBLE, metricspb.MetricDescriptor_CUMULATIVE_DOUBLE:
+				dp.Value = &metricspb.Point_DoubleValue{DoubleValue: dp.GetDoubleValue() * op.Scale}
+			case metricspb.MetricDescriptor_GAUGE_DISTRIBUTION, metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION:
+				mtp.scaleDistribution(dp.GetDistributionValue(), op.Scale)
+			}
+		}
+	}
+
+	if op.NewUnit != "" {
+		metric.MetricDescriptor.Unit = op.NewUnit
+	}
+}
+
+// scaleDistribution scales the sum, the bucket bounds and the exemplars of the distribution,
+// the counts are left unchanged
+func (mtp *metricsTransformProcessor) scaleDistribution(dist *metricspb.DistributionValue, scale float64) {
+	if dist == nil {
+		return
+	}
+	dist.Sum *= scale
+	dist.SumOfSquaredDeviation *= scale * scale
+	if explicit := dist.GetBucketOptions().GetExplicit(); explicit != nil {
+		bounds := make([]float64, len(explicit.Bounds))
+		for i, b := range explicit.Bounds {
+			bounds[i] = b * scale
+		}
+		explicit.Bounds = bounds
+	}
+	for _, bucket := range dist.Buckets {
+		if bucket.Exemplar != nil {
+			bucket.Exemplar.Value *= scale
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// SummaryVerbosity is the level of detail of the attributes summarizing what
+// was redacted.
+type SummaryVerbosity string
+
+const (
+	// DebugSummary lists the redacted and masked keys, and their counts.
+	DebugSummary = SummaryVerbosity("debug")
+
+	// InfoSummary only adds the counts of redacted and masked keys.
+	InfoSummary = SummaryVerbosity("info")
+
+	// SilentSummary doesn't add any summary attribute.
+	SilentSummary = SummaryVerbosity("silent")
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// AllowAllKeys disables the allow-list, no attribute is removed.
+	AllowAllKeys bool `mapstructure:"allow_all_keys"`
+
+	// AllowedKeys is the list of the attributes kept on the spans and logs,
+	// the other attributes are removed.
+	AllowedKeys []string `mapstructure:"allowed_keys"`
+
+	// BlockedValues is the list of the regular expressions matching sensitive
+	// values. The matching parts of the string attribute values are masked.
+	BlockedValues []string `mapstructure:"blocked_values"`
+
+	// Summary controls the attributes added to summarize what was redacted,
+	// either "debug", "info" or "silent". Defaults to "info".
+	Summary SummaryVerbosity `mapstructure:"summary"`
+}
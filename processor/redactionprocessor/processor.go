@@ -0,0 +1,191 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	// redactedKeys is the attribute listing the removed attributes.
+	redactedKeys = "redaction.redacted.keys"
+	// redactedKeyCount is the attribute counting the removed attributes.
+	redactedKeyCount = "redaction.redacted.count"
+	// maskedValues is the attribute listing the attributes whose value was masked.
+	maskedValues = "redaction.masked.keys"
+	// maskedValueCount is the attribute counting the attributes whose value was masked.
+	maskedValueCount = "redaction.masked.count"
+
+	// mask replaces the parts of the values matching a blocked pattern.
+	mask = "****"
+)
+
+type redactionProcessor struct {
+	logger            *zap.Logger
+	allowAllKeys      bool
+	allowList         map[string]bool
+	blockRegexList    []*regexp.Regexp
+	summary           SummaryVerbosity
+	nextTraceConsumer consumer.TraceConsumer
+	nextLogsConsumer  consumer.LogsConsumer
+}
+
+var _ (component.TraceProcessor) = (*redactionProcessor)(nil)
+var _ (component.LogsProcessor) = (*redactionProcessor)(nil)
+
+func newRedactionProcessor(logger *zap.Logger, cfg *Config) (*redactionProcessor, error) {
+	if err := validateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	allowList := make(map[string]bool, len(cfg.AllowedKeys))
+	for _, key := range cfg.AllowedKeys {
+		allowList[key] = true
+	}
+
+	blockRegexList := make([]*regexp.Regexp, 0, len(cfg.BlockedValues))
+	for _, pattern := range cfg.BlockedValues {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%q, %v, is not a valid regexp: %v", "blocked_values", pattern, err)
+		}
+		blockRegexList = append(blockRegexList, re)
+	}
+
+	return &redactionProcessor{
+		logger:         logger,
+		allowAllKeys:   cfg.AllowAllKeys,
+		allowList:      allowList,
+		blockRegexList: blockRegexList,
+		summary:        cfg.Summary,
+	}, nil
+}
+
+func (rp *redactionProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: true}
+}
+
+func (rp *redactionProcessor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (rp *redactionProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ConsumeTraces redacts the attributes of the spans.
+func (rp *redactionProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				rp.processAttributes(span.Attributes())
+			}
+		}
+	}
+
+	return rp.nextTraceConsumer.ConsumeTraces(ctx, td)
+}
+
+// ConsumeLogs redacts the attributes of the logs the same way as for spans.
+func (rp *redactionProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				log := logs.At(k)
+				if log.IsNil() {
+					continue
+				}
+				rp.processAttributes(log.Attributes())
+			}
+		}
+	}
+
+	return rp.nextLogsConsumer.ConsumeLogs(ctx, ld)
+}
+
+// processAttributes removes the attributes which aren't allowed, masks the
+// sensitive parts of the string values and adds the summary attributes.
+func (rp *redactionProcessor) processAttributes(attributes pdata.AttributeMap) {
+	var redacted, masked []string
+	attributes.ForEach(func(k string, v pdata.AttributeValue) {
+		if !rp.allowAllKeys && !rp.allowList[k] {
+			redacted = append(redacted, k)
+			return
+		}
+		if v.Type() != pdata.AttributeValueSTRING {
+			return
+		}
+		value := v.StringVal()
+		for _, re := range rp.blockRegexList {
+			value = re.ReplaceAllString(value, mask)
+		}
+		if value != v.StringVal() {
+			v.SetStringVal(value)
+			masked = append(masked, k)
+		}
+	})
+	for _, k := range redacted {
+		attributes.Delete(k)
+	}
+
+	rp.addSummary(attributes, redactedKeys, redactedKeyCount, redacted)
+	rp.addSummary(attributes, maskedValues, maskedValueCount, masked)
+}
+
+func (rp *redactionProcessor) addSummary(attributes pdata.AttributeMap, keysAttr, countAttr string, keys []string) {
+	if rp.summary == SilentSummary || len(keys) == 0 {
+		return
+	}
+	if rp.summary == DebugSummary {
+		sort.Strings(keys)
+		attributes.UpsertString(keysAttr, strings.Join(keys, ","))
+	}
+	attributes.UpsertInt(countAttr, int64(len(keys)))
+}
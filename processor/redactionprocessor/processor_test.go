@@ -0,0 +1,159 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func newTestAttributes() pdata.AttributeMap {
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString("http.method", "GET")
+	attrs.InsertString("user.email", "jdoe@example.com")
+	attrs.InsertString("payment.card", "card 4111 1111 1111 1111 charged")
+	attrs.InsertString("auth.header", "Bearer abc123")
+	attrs.InsertInt("http.status_code", 200)
+	return attrs
+}
+
+func TestProcessAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *Config
+		expected map[string]pdata.AttributeValue
+	}{
+		{
+			name: "allow list with debug summary",
+			config: &Config{
+				AllowedKeys:   []string{"http.method", "payment.card", "auth.header", "http.status_code"},
+				BlockedValues: []string{`\d{4} \d{4} \d{4} \d{4}`, `Bearer \S+`},
+				Summary:       DebugSummary,
+			},
+			expected: map[string]pdata.AttributeValue{
+				"http.method":      pdata.NewAttributeValueString("GET"),
+				"payment.card":     pdata.NewAttributeValueString("card **** charged"),
+				"auth.header":      pdata.NewAttributeValueString("****"),
+				"http.status_code": pdata.NewAttributeValueInt(200),
+				redactedKeys:       pdata.NewAttributeValueString("user.email"),
+				redactedKeyCount:   pdata.NewAttributeValueInt(1),
+				maskedValues:       pdata.NewAttributeValueString("auth.header,payment.card"),
+				maskedValueCount:   pdata.NewAttributeValueInt(2),
+			},
+		},
+		{
+			name: "allow all keys with info summary",
+			config: &Config{
+				AllowAllKeys:  true,
+				BlockedValues: []string{`[a-z]+@example\.com`},
+				Summary:       InfoSummary,
+			},
+			expected: map[string]pdata.AttributeValue{
+				"http.method":      pdata.NewAttributeValueString("GET"),
+				"user.email":       pdata.NewAttributeValueString("****"),
+				"payment.card":     pdata.NewAttributeValueString("card 4111 1111 1111 1111 charged"),
+				"auth.header":      pdata.NewAttributeValueString("Bearer abc123"),
+				"http.status_code": pdata.NewAttributeValueInt(200),
+				maskedValueCount:   pdata.NewAttributeValueInt(1),
+			},
+		},
+		{
+			name: "empty allow list with silent summary",
+			config: &Config{
+				Summary: SilentSummary,
+			},
+			expected: map[string]pdata.AttributeValue{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, err := newRedactionProcessor(zap.NewNop(), tt.config)
+			require.NoError(t, err)
+
+			attrs := newTestAttributes()
+			rp.processAttributes(attrs)
+
+			assert.Equal(t, len(tt.expected), attrs.Len())
+			for k, expected := range tt.expected {
+				v, ok := attrs.Get(k)
+				require.True(t, ok, "missing attribute %q", k)
+				assert.Equal(t, expected, v, "attribute %q", k)
+			}
+		})
+	}
+}
+
+func TestConsumeTraces(t *testing.T) {
+	next := &exportertest.SinkTraceExporter{}
+	rp, err := newRedactionProcessor(zap.NewNop(), &Config{AllowedKeys: []string{"http.method"}, Summary: InfoSummary})
+	require.NoError(t, err)
+	rp.nextTraceConsumer = next
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.InstrumentationLibrarySpans().Resize(1)
+	rs.InstrumentationLibrarySpans().At(0).Spans().Resize(1)
+	span := rs.InstrumentationLibrarySpans().At(0).Spans().At(0)
+	newTestAttributes().CopyTo(span.Attributes())
+
+	require.NoError(t, rp.ConsumeTraces(context.Background(), td))
+	require.Len(t, next.AllTraces(), 1)
+	attrs := next.AllTraces()[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+	assert.Equal(t, 2, attrs.Len())
+	_, ok := attrs.Get("http.method")
+	assert.True(t, ok)
+	count, ok := attrs.Get(redactedKeyCount)
+	require.True(t, ok)
+	assert.Equal(t, int64(4), count.IntVal())
+}
+
+func TestConsumeLogs(t *testing.T) {
+	next := &exportertest.SinkLogsExporter{}
+	rp, err := newRedactionProcessor(zap.NewNop(), &Config{AllowAllKeys: true, BlockedValues: []string{"abc123"}, Summary: SilentSummary})
+	require.NoError(t, err)
+	rp.nextLogsConsumer = next
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	rl.InstrumentationLibraryLogs().At(0).Logs().Resize(1)
+	log := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	newTestAttributes().CopyTo(log.Attributes())
+
+	require.NoError(t, rp.ConsumeLogs(context.Background(), ld))
+	require.Len(t, next.AllLogs(), 1)
+	attrs := next.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()
+	assert.Equal(t, 5, attrs.Len())
+	v, ok := attrs.Get("auth.header")
+	require.True(t, ok)
+	assert.Equal(t, "Bearer ****", v.StringVal())
+}
+
+func TestInvalidConfig(t *testing.T) {
+	_, err := newRedactionProcessor(zap.NewNop(), &Config{BlockedValues: []string{"("}, Summary: InfoSummary})
+	assert.Error(t, err)
+
+	_, err = newRedactionProcessor(zap.NewNop(), &Config{Summary: "verbose"})
+	assert.EqualError(t, err, `unsupported "summary": verbose, the supported verbosities are "debug", "info" and "silent"`)
+}
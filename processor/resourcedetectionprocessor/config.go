@@ -18,6 +18,11 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
 // Config defines configuration for Resource processor.
@@ -29,7 +34,41 @@ type Config struct {
 	// Timeout specifies the maximum amount of time that we will wait
 	// before assuming a detector has failed. Defaults to 5s.
 	Timeout time.Duration `mapstructure:"timeout"`
+	// DetectorTimeouts specifies, by detector, the maximum amount of time that
+	// we will wait for this detector. A detector that times out is skipped, so
+	// that a slow metadata endpoint doesn't block the pipeline startup.
+	DetectorTimeouts map[string]time.Duration `mapstructure:"detector_timeouts"`
 	// Override indicates whether any existing resource attributes
 	// should be overridden or preserved. Defaults to true.
 	Override bool `mapstructure:"override"`
+	// Attributes is an allow-list of the detected attributes to add to the
+	// resources. All the detected attributes are added when it is empty.
+	Attributes []string `mapstructure:"attributes"`
+	// DetectorConfig holds the settings specific to the detectors.
+	DetectorConfig `mapstructure:",squash"`
+}
+
+// DetectorConfig contains the user-specified configurations of the individual detectors.
+type DetectorConfig struct {
+	// EC2Config contains the user-specified configuration of the EC2 detector.
+	EC2Config ec2.Config `mapstructure:"ec2"`
+	// ConsulConfig contains the user-specified configuration of the Consul detector.
+	ConsulConfig consul.Config `mapstructure:"consul"`
+	// SystemConfig contains the user-specified configuration of the system detector.
+	SystemConfig system.Config `mapstructure:"system"`
+}
+
+// GetConfigFromType returns the configuration of the detector of the given type,
+// nil if the detector doesn't have one.
+func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) internal.DetectorConfig {
+	switch detectorType {
+	case ec2.TypeStr:
+		return d.EC2Config
+	case consul.TypeStr:
+		return d.ConsulConfig
+	case system.TypeStr:
+		return d.SystemConfig
+	default:
+		return nil
+	}
 }
@@ -23,6 +23,10 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -59,5 +63,63 @@ func TestLoadConfig(t *testing.T) {
 		Detectors: []string{"env", "ec2"},
 		Timeout:   2 * time.Second,
 		Override:  false,
+		DetectorConfig: DetectorConfig{
+			EC2Config: ec2.Config{
+				Tags: []string{"^team$", `^app\..*`},
+			},
+		},
 	})
+
+	p4 := cfg.Processors["resourcedetection/system"]
+	assert.Equal(t, p4, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "resourcedetection",
+			NameVal: "resourcedetection/system",
+		},
+		Detectors: []string{"env", "system"},
+		Timeout:   2 * time.Second,
+		Override:  false,
+		DetectorConfig: DetectorConfig{
+			SystemConfig: system.Config{
+				HostnameSources: []string{"os"},
+			},
+		},
+	})
+
+	p6 := cfg.Processors["resourcedetection/timeouts"]
+	assert.Equal(t, p6, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "resourcedetection",
+			NameVal: "resourcedetection/timeouts",
+		},
+		Detectors:        []string{"env", "ec2"},
+		Timeout:          5 * time.Second,
+		DetectorTimeouts: map[string]time.Duration{"ec2": 500 * time.Millisecond},
+		Override:         true,
+		Attributes:       []string{"cloud.region", "host.id"},
+	})
+
+	p5 := cfg.Processors["resourcedetection/consul"]
+	assert.Equal(t, p5, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "resourcedetection",
+			NameVal: "resourcedetection/consul",
+		},
+		Detectors: []string{"env", "consul"},
+		Timeout:   2 * time.Second,
+		Override:  false,
+		DetectorConfig: DetectorConfig{
+			ConsulConfig: consul.Config{
+				Address: "localhost:8500",
+				Meta:    []string{"^env$"},
+			},
+		},
+	})
+}
+
+func TestGetConfigFromType(t *testing.T) {
+	cfg := DetectorConfig{EC2Config: ec2.Config{Tags: []string{"^team$"}}}
+	assert.Equal(t, ec2.Config{Tags: []string{"^team$"}}, cfg.GetConfigFromType(ec2.TypeStr))
+	assert.Equal(t, system.Config{}, cfg.GetConfigFromType(system.TypeStr))
+	assert.Nil(t, cfg.GetConfigFromType("env"))
 }
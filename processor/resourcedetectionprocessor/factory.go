@@ -27,8 +27,15 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ecs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/eks"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure/aks"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/env"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/gce"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
 const (
@@ -49,9 +56,16 @@ type Factory struct {
 // NewFactory creates a new factory for resourcedetection processor.
 func NewFactory() *Factory {
 	resourceProviderFactory := internal.NewProviderFactory(map[internal.DetectorType]internal.DetectorFactory{
-		env.TypeStr: env.NewDetector,
-		gce.TypeStr: gce.NewDetector,
-		ec2.TypeStr: ec2.NewDetector,
+		env.TypeStr:    env.NewDetector,
+		gce.TypeStr:    gce.NewDetector,
+		gcp.TypeStr:    gcp.NewDetector,
+		ec2.TypeStr:    ec2.NewDetector,
+		ecs.TypeStr:    ecs.NewDetector,
+		eks.TypeStr:    eks.NewDetector,
+		azure.TypeStr:  azure.NewDetector,
+		aks.TypeStr:    aks.NewDetector,
+		system.TypeStr: system.NewDetector,
+		consul.TypeStr: consul.NewDetector,
 	})
 
 	return &Factory{
@@ -87,7 +101,7 @@ func (f *Factory) CreateTraceProcessor(
 ) (component.TraceProcessor, error) {
 	oCfg := cfg.(*Config)
 
-	provider, err := f.getResourceProvider(ctx, params.Logger, cfg.Name(), oCfg.Timeout, oCfg.Detectors)
+	provider, err := f.getResourceProvider(ctx, params.Logger, cfg.Name(), oCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +118,7 @@ func (f *Factory) CreateMetricsProcessor(
 ) (component.MetricsProcessor, error) {
 	oCfg := cfg.(*Config)
 
-	provider, err := f.getResourceProvider(ctx, params.Logger, cfg.Name(), oCfg.Timeout, oCfg.Detectors)
+	provider, err := f.getResourceProvider(ctx, params.Logger, cfg.Name(), oCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -116,8 +130,7 @@ func (f *Factory) getResourceProvider(
 	ctx context.Context,
 	logger *zap.Logger,
 	processorName string,
-	timeout time.Duration,
-	configuredDetectors []string,
+	cfg *Config,
 ) (*internal.ResourceProvider, error) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -126,12 +139,18 @@ func (f *Factory) getResourceProvider(
 		return provider, nil
 	}
 
-	detectorTypes := make([]internal.DetectorType, 0, len(configuredDetectors))
-	for _, key := range configuredDetectors {
+	detectorTypes := make([]internal.DetectorType, 0, len(cfg.Detectors))
+	for _, key := range cfg.Detectors {
 		detectorTypes = append(detectorTypes, internal.DetectorType(strings.TrimSpace(key)))
 	}
 
-	provider, err := f.resourceProviderFactory.CreateResourceProvider(logger, timeout, detectorTypes...)
+	detectorTimeouts := make(map[internal.DetectorType]time.Duration, len(cfg.DetectorTimeouts))
+	for key, timeout := range cfg.DetectorTimeouts {
+		detectorTimeouts[internal.DetectorType(strings.TrimSpace(key))] = timeout
+	}
+
+	provider, err := f.resourceProviderFactory.CreateResourceProvider(
+		logger, cfg.Timeout, detectorTimeouts, cfg.Attributes, &cfg.DetectorConfig, detectorTypes...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ec2
+
+// Config defines the user-specified configuration of the EC2 detector.
+type Config struct {
+	// Tags is a list of regular expressions matching the keys of the EC2
+	// instance tags to add as resource attributes, named ec2.tag.<key>.
+	// Fetching the tags requires the ec2:DescribeTags permission.
+	Tags []string `mapstructure:"tags"`
+}
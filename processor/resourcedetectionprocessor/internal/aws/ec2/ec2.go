@@ -16,6 +16,8 @@ package ec2
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -27,20 +29,40 @@ import (
 const (
 	TypeStr          = "ec2"
 	cloudProviderAWS = "aws"
+	tagPrefix        = "ec2.tag."
 )
 
 var _ internal.Detector = (*Detector)(nil)
 
 type Detector struct {
-	provider ec2MetadataProvider
+	provider     ec2MetadataProvider
+	tagsProvider ec2TagsProvider
+	tagKeys      []*regexp.Regexp
 }
 
-func NewDetector() (internal.Detector, error) {
+// NewDetector returns a detector reading the EC2 instance metadata. The
+// metadata is read with IMDSv2 session tokens, falling back to IMDSv1 when
+// tokens are not supported.
+func NewDetector(dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg, _ := dcfg.(Config)
+	tagKeys := make([]*regexp.Regexp, 0, len(cfg.Tags))
+	for _, expr := range cfg.Tags {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag key regex %q: %w", expr, err)
+		}
+		tagKeys = append(tagKeys, re)
+	}
+
 	sess, err := session.NewSession()
 	if err != nil {
 		return nil, err
 	}
-	return &Detector{provider: &ec2MetadataImpl{sess: sess}}, nil
+	return &Detector{
+		provider:     &ec2MetadataImpl{sess: sess},
+		tagsProvider: &ec2TagsImpl{sess: sess},
+		tagKeys:      tagKeys,
+	}, nil
 }
 
 func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
@@ -65,5 +87,34 @@ func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
 	attr.InsertString(conventions.AttributeHostImageID, meta.ImageID)
 	attr.InsertString(conventions.AttributeHostType, meta.InstanceType)
 
+	hostname, err := d.provider.hostname(ctx)
+	if err != nil {
+		return res, err
+	}
+	attr.InsertString(conventions.AttributeHostName, hostname)
+
+	if len(d.tagKeys) == 0 {
+		return res, nil
+	}
+
+	tags, err := d.tagsProvider.tags(ctx, meta.Region, meta.InstanceID)
+	if err != nil {
+		return res, fmt.Errorf("failed fetching ec2 instance tags: %w", err)
+	}
+	for key, value := range tags {
+		if d.matchesTagKey(key) {
+			attr.InsertString(tagPrefix+key, value)
+		}
+	}
+
 	return res, nil
 }
+
+func (d *Detector) matchesTagKey(key string) bool {
+	for _, re := range d.tagKeys {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
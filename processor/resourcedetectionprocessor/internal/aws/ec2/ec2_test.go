@@ -17,6 +17,7 @@ package ec2
 import (
 	"context"
 	"errors"
+	"regexp"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
@@ -33,6 +34,20 @@ type mockMetadata struct {
 	isAvailable bool
 }
 
+type mockTags struct {
+	ret       map[string]string
+	returnErr error
+}
+
+var _ ec2TagsProvider = (*mockTags)(nil)
+
+func (mt mockTags) tags(ctx context.Context, region, instanceID string) (map[string]string, error) {
+	if mt.returnErr != nil {
+		return nil, mt.returnErr
+	}
+	return mt.ret, nil
+}
+
 var _ ec2MetadataProvider = (*mockMetadata)(nil)
 
 func (mm mockMetadata) available(ctx context.Context) bool {
@@ -46,15 +61,32 @@ func (mm mockMetadata) get(ctx context.Context) (ec2metadata.EC2InstanceIdentity
 	return mm.ret, nil
 }
 
+func (mm mockMetadata) hostname(ctx context.Context) (string, error) {
+	if mm.returnErr != nil {
+		return "", mm.returnErr
+	}
+	return "example-hostname", nil
+}
+
 func TestNewDetector(t *testing.T) {
-	detector, err := NewDetector()
+	detector, err := NewDetector(nil)
 	assert.NotNil(t, detector)
 	assert.NoError(t, err)
+
+	detector, err = NewDetector(Config{Tags: []string{"^team$", "^app\\..*"}})
+	assert.NotNil(t, detector)
+	assert.NoError(t, err)
+	assert.Len(t, detector.(*Detector).tagKeys, 2)
+
+	_, err = NewDetector(Config{Tags: []string{"["}})
+	assert.Error(t, err)
 }
 
 func TestDetector_Detect(t *testing.T) {
 	type fields struct {
-		provider ec2MetadataProvider
+		provider     ec2MetadataProvider
+		tagsProvider ec2TagsProvider
+		tagKeys      []*regexp.Regexp
 	}
 	type args struct {
 		ctx context.Context
@@ -89,8 +121,51 @@ func TestDetector_Detect(t *testing.T) {
 				attr.InsertString("host.id", "i-abcd1234")
 				attr.InsertString("host.image.id", "abcdef")
 				attr.InsertString("host.type", "c4.xlarge")
+				attr.InsertString("host.name", "example-hostname")
 				return res
 			}()},
+		{
+			name: "success with tags",
+			fields: fields{
+				provider: &mockMetadata{ret: ec2metadata.EC2InstanceIdentityDocument{
+					Region:     "us-west-2",
+					InstanceID: "i-abcd1234",
+				},
+					isAvailable: true},
+				tagsProvider: &mockTags{ret: map[string]string{
+					"team":       "payments",
+					"teammate":   "bob",
+					"app.name":   "checkout",
+					"aws:region": "us-west-2",
+				}},
+				tagKeys: []*regexp.Regexp{regexp.MustCompile("^team$"), regexp.MustCompile(`^app\..*`)},
+			},
+			args: args{ctx: context.Background()},
+			want: func() pdata.Resource {
+				res := pdata.NewResource()
+				res.InitEmpty()
+				attr := res.Attributes()
+				attr.InsertString("cloud.account.id", "")
+				attr.InsertString("cloud.provider", "aws")
+				attr.InsertString("cloud.region", "us-west-2")
+				attr.InsertString("cloud.zone", "")
+				attr.InsertString("host.id", "i-abcd1234")
+				attr.InsertString("host.image.id", "")
+				attr.InsertString("host.type", "")
+				attr.InsertString("host.name", "example-hostname")
+				attr.InsertString("ec2.tag.team", "payments")
+				attr.InsertString("ec2.tag.app.name", "checkout")
+				return res
+			}()},
+		{
+			name: "tags fail",
+			fields: fields{
+				provider:     &mockMetadata{isAvailable: true},
+				tagsProvider: &mockTags{returnErr: errors.New("access denied")},
+				tagKeys:      []*regexp.Regexp{regexp.MustCompile(".*")},
+			},
+			args:    args{ctx: context.Background()},
+			wantErr: true},
 		{
 			name: "endpoint not available",
 			fields: fields{provider: &mockMetadata{
@@ -123,7 +198,9 @@ func TestDetector_Detect(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &Detector{
-				provider: tt.fields.provider,
+				provider:     tt.fields.provider,
+				tagsProvider: tt.fields.tagsProvider,
+				tagKeys:      tt.fields.tagKeys,
 			}
 			got, err := d.Detect(tt.args.ctx)
 
@@ -22,5 +22,11 @@ import (
 
 type ec2MetadataProvider interface {
 	get(ctx context.Context) (ec2metadata.EC2InstanceIdentityDocument, error)
+	hostname(ctx context.Context) (string, error)
 	available(ctx context.Context) bool
 }
+
+type ec2TagsProvider interface {
+	// tags returns the tags of the instance by key.
+	tags(ctx context.Context, region, instanceID string) (map[string]string, error)
+}
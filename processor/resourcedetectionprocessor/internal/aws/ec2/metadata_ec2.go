@@ -17,8 +17,10 @@ package ec2
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
 )
 
 type ec2MetadataImpl struct {
@@ -36,3 +38,33 @@ func (md *ec2MetadataImpl) get(ctx context.Context) (ec2metadata.EC2InstanceIden
 	meta := ec2metadata.New(md.sess)
 	return meta.GetInstanceIdentityDocumentWithContext(ctx)
 }
+
+func (md *ec2MetadataImpl) hostname(ctx context.Context) (string, error) {
+	meta := ec2metadata.New(md.sess)
+	return meta.GetMetadataWithContext(ctx, "hostname")
+}
+
+type ec2TagsImpl struct {
+	sess *session.Session
+}
+
+var _ ec2TagsProvider = (*ec2TagsImpl)(nil)
+
+func (t *ec2TagsImpl) tags(ctx context.Context, region, instanceID string) (map[string]string, error) {
+	svc := awsec2.New(t.sess, aws.NewConfig().WithRegion(region))
+	input := &awsec2.DescribeTagsInput{
+		Filters: []*awsec2.Filter{{
+			Name:   aws.String("resource-id"),
+			Values: aws.StringSlice([]string{instanceID}),
+		}},
+	}
+
+	tags := map[string]string{}
+	err := svc.DescribeTagsPagesWithContext(ctx, input, func(out *awsec2.DescribeTagsOutput, _ bool) bool {
+		for _, tag := range out.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		return true
+	})
+	return tags, err
+}
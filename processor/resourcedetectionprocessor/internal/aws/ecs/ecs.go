@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr          = "ecs"
+	cloudProviderAWS = "aws"
+	cloudPlatformECS = "aws_ecs"
+
+	// The task metadata endpoints v4 and v3 are injected in the containers
+	// by the ECS agent with these environment variables.
+	metadataURIV4Env = "ECS_CONTAINER_METADATA_URI_V4"
+	metadataURIV3Env = "ECS_CONTAINER_METADATA_URI"
+
+	attributeCloudPlatform   = "cloud.platform"
+	attributeECSClusterARN   = "aws.ecs.cluster.arn"
+	attributeECSTaskARN      = "aws.ecs.task.arn"
+	attributeECSTaskFamily   = "aws.ecs.task.family"
+	attributeECSTaskRevision = "aws.ecs.task.revision"
+	attributeECSLaunchType   = "aws.ecs.launchtype"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	provider ecsMetadataProvider
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{provider: &ecsMetadataImpl{client: &http.Client{}}}, nil
+}
+
+// Detect reads the task metadata from the task metadata endpoint. It returns
+// an empty resource when not running in an ECS task.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	endpoint := os.Getenv(metadataURIV4Env)
+	if endpoint == "" {
+		endpoint = os.Getenv(metadataURIV3Env)
+	}
+	if endpoint == "" {
+		return res, nil
+	}
+
+	meta, err := d.provider.fetchTaskMetadata(ctx, endpoint)
+	if err != nil {
+		return res, err
+	}
+
+	// Task ARN format: arn:aws:ecs:<region>:<account>:task/<cluster>/<id>
+	arnParts := strings.Split(meta.TaskARN, ":")
+	if len(arnParts) < 6 {
+		return res, fmt.Errorf("invalid ecs task arn %q", meta.TaskARN)
+	}
+	region, account := arnParts[3], arnParts[4]
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, cloudProviderAWS)
+	attr.InsertString(attributeCloudPlatform, cloudPlatformECS)
+	attr.InsertString(conventions.AttributeCloudRegion, region)
+	attr.InsertString(conventions.AttributeCloudAccount, account)
+	if meta.AvailabilityZone != "" {
+		attr.InsertString(conventions.AttributeCloudZone, meta.AvailabilityZone)
+	}
+
+	// The cluster is its name with the v3 endpoint and its ARN with the v4 one.
+	clusterARN := meta.Cluster
+	if !strings.HasPrefix(clusterARN, "arn:") {
+		clusterARN = fmt.Sprintf("%s:cluster/%s", strings.Join(arnParts[:5], ":"), meta.Cluster)
+	}
+	attr.InsertString(attributeECSClusterARN, clusterARN)
+	attr.InsertString(attributeECSTaskARN, meta.TaskARN)
+	attr.InsertString(attributeECSTaskFamily, meta.Family)
+	attr.InsertString(attributeECSTaskRevision, meta.Revision)
+	if meta.LaunchType != "" {
+		attr.InsertString(attributeECSLaunchType, strings.ToLower(meta.LaunchType))
+	}
+
+	return res, nil
+}
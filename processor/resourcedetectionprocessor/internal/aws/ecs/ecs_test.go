@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockMetadata struct {
+	endpoint  string
+	ret       *taskMetadata
+	returnErr error
+}
+
+var _ ecsMetadataProvider = (*mockMetadata)(nil)
+
+func (mm *mockMetadata) fetchTaskMetadata(ctx context.Context, endpoint string) (*taskMetadata, error) {
+	mm.endpoint = endpoint
+	return mm.ret, mm.returnErr
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(nil)
+	assert.NotNil(t, d)
+	assert.NoError(t, err)
+}
+
+func TestDetectV4(t *testing.T) {
+	os.Setenv(metadataURIV4Env, "http://169.254.170.2/v4/abc")
+	os.Setenv(metadataURIV3Env, "http://169.254.170.2/v3/abc")
+	defer os.Unsetenv(metadataURIV4Env)
+	defer os.Unsetenv(metadataURIV3Env)
+
+	md := &mockMetadata{ret: &taskMetadata{
+		Cluster:          "arn:aws:ecs:us-west-2:123456789012:cluster/default",
+		TaskARN:          "arn:aws:ecs:us-west-2:123456789012:task/default/158d1c8083dd49d6b527399fd6414f5c",
+		Family:           "curltest",
+		Revision:         "26",
+		AvailabilityZone: "us-west-2d",
+		LaunchType:       "FARGATE",
+	}}
+	d := &Detector{provider: md}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://169.254.170.2/v4/abc", md.endpoint)
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":        "aws",
+		"cloud.platform":        "aws_ecs",
+		"cloud.region":          "us-west-2",
+		"cloud.account.id":      "123456789012",
+		"cloud.zone":            "us-west-2d",
+		"aws.ecs.cluster.arn":   "arn:aws:ecs:us-west-2:123456789012:cluster/default",
+		"aws.ecs.task.arn":      "arn:aws:ecs:us-west-2:123456789012:task/default/158d1c8083dd49d6b527399fd6414f5c",
+		"aws.ecs.task.family":   "curltest",
+		"aws.ecs.task.revision": "26",
+		"aws.ecs.launchtype":    "fargate",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectV3(t *testing.T) {
+	os.Setenv(metadataURIV3Env, "http://169.254.170.2/v3/abc")
+	defer os.Unsetenv(metadataURIV3Env)
+
+	md := &mockMetadata{ret: &taskMetadata{
+		Cluster:  "default",
+		TaskARN:  "arn:aws:ecs:us-west-2:123456789012:task/9781c248-0edd-4cdb-9a93-f63cb662a5d3",
+		Family:   "nginx",
+		Revision: "5",
+	}}
+	d := &Detector{provider: md}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://169.254.170.2/v3/abc", md.endpoint)
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":        "aws",
+		"cloud.platform":        "aws_ecs",
+		"cloud.region":          "us-west-2",
+		"cloud.account.id":      "123456789012",
+		"aws.ecs.cluster.arn":   "arn:aws:ecs:us-west-2:123456789012:cluster/default",
+		"aws.ecs.task.arn":      "arn:aws:ecs:us-west-2:123456789012:task/9781c248-0edd-4cdb-9a93-f63cb662a5d3",
+		"aws.ecs.task.family":   "nginx",
+		"aws.ecs.task.revision": "5",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectNotOnECS(t *testing.T) {
+	os.Unsetenv(metadataURIV4Env)
+	os.Unsetenv(metadataURIV3Env)
+
+	d := &Detector{provider: &mockMetadata{returnErr: errors.New("should not be called")}}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDetectError(t *testing.T) {
+	os.Setenv(metadataURIV4Env, "http://169.254.170.2/v4/abc")
+	defer os.Unsetenv(metadataURIV4Env)
+
+	d := &Detector{provider: &mockMetadata{returnErr: errors.New("connection refused")}}
+	_, err := d.Detect(context.Background())
+	assert.EqualError(t, err, "connection refused")
+
+	d = &Detector{provider: &mockMetadata{ret: &taskMetadata{TaskARN: "invalid"}}}
+	_, err = d.Detect(context.Background())
+	assert.EqualError(t, err, `invalid ecs task arn "invalid"`)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// taskMetadata is the part of the response of the task metadata endpoint
+// the detector uses.
+type taskMetadata struct {
+	Cluster          string `json:"Cluster"`
+	TaskARN          string `json:"TaskARN"`
+	Family           string `json:"Family"`
+	Revision         string `json:"Revision"`
+	AvailabilityZone string `json:"AvailabilityZone"`
+	LaunchType       string `json:"LaunchType"`
+}
+
+type ecsMetadataProvider interface {
+	fetchTaskMetadata(ctx context.Context, endpoint string) (*taskMetadata, error)
+}
+
+type ecsMetadataImpl struct {
+	client *http.Client
+}
+
+var _ ecsMetadataProvider = (*ecsMetadataImpl)(nil)
+
+func (md *ecsMetadataImpl) fetchTaskMetadata(ctx context.Context, endpoint string) (*taskMetadata, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint+"/task", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := md.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ecs task metadata endpoint returned %d", resp.StatusCode)
+	}
+
+	meta := &taskMetadata{}
+	if err := json.NewDecoder(resp.Body).Decode(meta); err != nil {
+		return nil, fmt.Errorf("failed decoding ecs task metadata: %w", err)
+	}
+	return meta, nil
+}
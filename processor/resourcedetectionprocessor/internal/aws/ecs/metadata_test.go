@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchTaskMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/abc/task":
+			w.Write([]byte(`{
+				"Cluster": "default",
+				"TaskARN": "arn:aws:ecs:us-west-2:123456789012:task/default/158d1c8083dd49d6b527399fd6414f5c",
+				"Family": "curltest",
+				"Revision": "26",
+				"DesiredStatus": "RUNNING",
+				"AvailabilityZone": "us-west-2d",
+				"LaunchType": "EC2"
+			}`))
+		case "/v4/invalid/task":
+			w.Write([]byte(`{`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	md := &ecsMetadataImpl{client: srv.Client()}
+	meta, err := md.fetchTaskMetadata(context.Background(), srv.URL+"/v4/abc")
+	require.NoError(t, err)
+	assert.Equal(t, &taskMetadata{
+		Cluster:          "default",
+		TaskARN:          "arn:aws:ecs:us-west-2:123456789012:task/default/158d1c8083dd49d6b527399fd6414f5c",
+		Family:           "curltest",
+		Revision:         "26",
+		AvailabilityZone: "us-west-2d",
+		LaunchType:       "EC2",
+	}, meta)
+
+	_, err = md.fetchTaskMetadata(context.Background(), srv.URL+"/v4/invalid")
+	assert.Error(t, err)
+
+	_, err = md.fetchTaskMetadata(context.Background(), srv.URL+"/v4/missing")
+	assert.EqualError(t, err, "ecs task metadata endpoint returned 404")
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr          = "eks"
+	cloudProviderAWS = "aws"
+	cloudPlatformEKS = "aws_eks"
+
+	attributeCloudPlatform = "cloud.platform"
+
+	k8sServiceHostEnv = "KUBERNETES_SERVICE_HOST"
+
+	// The aws-auth ConfigMap maps IAM roles to Kubernetes users, it only
+	// exists in EKS clusters.
+	authConfigMapNamespace = "kube-system"
+	authConfigMapName      = "aws-auth"
+
+	// The cluster-info ConfigMap is created when installing Container
+	// Insights, it holds the name of the cluster.
+	clusterInfoConfigMapNamespace = "amazon-cloudwatch"
+	clusterInfoConfigMapName      = "cluster-info"
+	clusterNameKey                = "cluster.name"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	utils detectorUtils
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{utils: newDetectorUtils()}, nil
+}
+
+// Detect returns a resource with the cloud platform and the name of the
+// cluster when running in an EKS cluster. The cluster name is read from the
+// cluster-info ConfigMap, or from the tags of the EC2 instance hosting the
+// pod when the ConfigMap doesn't exist.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	if os.Getenv(k8sServiceHostEnv) == "" {
+		return res, nil
+	}
+
+	authConfigMap, err := d.utils.getConfigMap(ctx, authConfigMapNamespace, authConfigMapName)
+	if err != nil {
+		return res, err
+	}
+	if authConfigMap == nil {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, cloudProviderAWS)
+	attr.InsertString(attributeCloudPlatform, cloudPlatformEKS)
+
+	clusterInfo, err := d.utils.getConfigMap(ctx, clusterInfoConfigMapNamespace, clusterInfoConfigMapName)
+	if err != nil {
+		return res, err
+	}
+	clusterName := clusterInfo[clusterNameKey]
+	if clusterName == "" {
+		clusterName, err = d.utils.getClusterNameFromEC2Tags(ctx)
+		if err != nil {
+			return res, err
+		}
+	}
+	if clusterName != "" {
+		attr.InsertString(conventions.AttributeK8sCluster, clusterName)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockUtils struct {
+	configMaps  map[string]map[string]string
+	clusterName string
+	returnErr   error
+}
+
+var _ detectorUtils = (*mockUtils)(nil)
+
+func (mu *mockUtils) getConfigMap(_ context.Context, namespace, name string) (map[string]string, error) {
+	if mu.returnErr != nil {
+		return nil, mu.returnErr
+	}
+	return mu.configMaps[namespace+"/"+name], nil
+}
+
+func (mu *mockUtils) getClusterNameFromEC2Tags(context.Context) (string, error) {
+	return mu.clusterName, nil
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(nil)
+	assert.NotNil(t, d)
+	assert.NoError(t, err)
+}
+
+func TestDetect(t *testing.T) {
+	os.Setenv(k8sServiceHostEnv, "10.100.0.1")
+	defer os.Unsetenv(k8sServiceHostEnv)
+
+	tests := []struct {
+		name  string
+		utils *mockUtils
+		want  map[string]interface{}
+	}{
+		{
+			name: "cluster name from ConfigMap",
+			utils: &mockUtils{
+				configMaps: map[string]map[string]string{
+					"kube-system/aws-auth":           {},
+					"amazon-cloudwatch/cluster-info": {"cluster.name": "my-cluster"},
+				},
+				clusterName: "other-cluster",
+			},
+			want: map[string]interface{}{
+				"cloud.provider":   "aws",
+				"cloud.platform":   "aws_eks",
+				"k8s.cluster.name": "my-cluster",
+			},
+		},
+		{
+			name: "cluster name from EC2 tags",
+			utils: &mockUtils{
+				configMaps: map[string]map[string]string{
+					"kube-system/aws-auth": {},
+				},
+				clusterName: "other-cluster",
+			},
+			want: map[string]interface{}{
+				"cloud.provider":   "aws",
+				"cloud.platform":   "aws_eks",
+				"k8s.cluster.name": "other-cluster",
+			},
+		},
+		{
+			name: "unknown cluster name",
+			utils: &mockUtils{
+				configMaps: map[string]map[string]string{
+					"kube-system/aws-auth": {},
+				},
+			},
+			want: map[string]interface{}{
+				"cloud.provider": "aws",
+				"cloud.platform": "aws_eks",
+			},
+		},
+		{
+			name:  "not EKS",
+			utils: &mockUtils{},
+			want:  map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Detector{utils: tt.utils}
+			res, err := d.Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, internal.AttributesToMap(res.Attributes()))
+		})
+	}
+}
+
+func TestDetectNotOnKubernetes(t *testing.T) {
+	os.Unsetenv(k8sServiceHostEnv)
+
+	d := &Detector{utils: &mockUtils{returnErr: errors.New("should not be called")}}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDetectError(t *testing.T) {
+	os.Setenv(k8sServiceHostEnv, "10.100.0.1")
+	defer os.Unsetenv(k8sServiceHostEnv)
+
+	d := &Detector{utils: &mockUtils{returnErr: errors.New("forbidden")}}
+	_, err := d.Detect(context.Background())
+	assert.EqualError(t, err, "forbidden")
+}
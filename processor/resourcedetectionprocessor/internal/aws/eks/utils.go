@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	k8sServicePortEnv = "KUBERNETES_SERVICE_PORT"
+
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCAPath    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+
+	// EKS managed node groups are tagged with the name of their cluster,
+	// self-managed nodes with the kubernetes.io/cluster/<name> tag.
+	eksClusterNameTag = "eks:cluster-name"
+	clusterTagPrefix  = "kubernetes.io/cluster/"
+)
+
+type detectorUtils interface {
+	// getConfigMap returns the data of the ConfigMap, nil if it doesn't exist.
+	getConfigMap(ctx context.Context, namespace, name string) (map[string]string, error)
+	getClusterNameFromEC2Tags(ctx context.Context) (string, error)
+}
+
+type eksDetectorUtils struct {
+	tokenPath string
+	caPath    string
+}
+
+var _ detectorUtils = (*eksDetectorUtils)(nil)
+
+func newDetectorUtils() *eksDetectorUtils {
+	return &eksDetectorUtils{
+		tokenPath: serviceAccountTokenPath,
+		caPath:    serviceAccountCAPath,
+	}
+}
+
+// getConfigMap reads the ConfigMap from the Kubernetes API with the service
+// account of the pod.
+func (u *eksDetectorUtils) getConfigMap(ctx context.Context, namespace, name string) (map[string]string, error) {
+	token, err := ioutil.ReadFile(u.tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading service account token: %w", err)
+	}
+	ca, err := ioutil.ReadFile(u.caPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading service account CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("invalid service account CA")
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+
+	host := net.JoinHostPort(os.Getenv(k8sServiceHostEnv), os.Getenv(k8sServicePortEnv))
+	url := fmt.Sprintf("https://%s/api/v1/namespaces/%s/configmaps/%s", host, namespace, name)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed getting ConfigMap %s/%s: status %d", namespace, name, resp.StatusCode)
+	}
+
+	var configMap struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&configMap); err != nil {
+		return nil, fmt.Errorf("failed decoding ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	return configMap.Data, nil
+}
+
+// getClusterNameFromEC2Tags reads the cluster name from the tags of the
+// EC2 instance found with the instance metadata API.
+func (u *eksDetectorUtils) getClusterNameFromEC2Tags(ctx context.Context) (string, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return "", err
+	}
+	doc, err := ec2metadata.New(sess).GetInstanceIdentityDocumentWithContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	svc := awsec2.New(sess, aws.NewConfig().WithRegion(doc.Region))
+	input := &awsec2.DescribeTagsInput{
+		Filters: []*awsec2.Filter{{
+			Name:   aws.String("resource-id"),
+			Values: aws.StringSlice([]string{doc.InstanceID}),
+		}},
+	}
+	var clusterName string
+	err = svc.DescribeTagsPagesWithContext(ctx, input, func(out *awsec2.DescribeTagsOutput, _ bool) bool {
+		for _, tag := range out.Tags {
+			if name := clusterNameFromTag(aws.StringValue(tag.Key), aws.StringValue(tag.Value)); name != "" {
+				clusterName = name
+				return false
+			}
+		}
+		return true
+	})
+	return clusterName, err
+}
+
+func clusterNameFromTag(key, value string) string {
+	switch {
+	case key == eksClusterNameTag:
+		return value
+	case strings.HasPrefix(key, clusterTagPrefix):
+		return strings.TrimPrefix(key, clusterTagPrefix)
+	default:
+		return ""
+	}
+}
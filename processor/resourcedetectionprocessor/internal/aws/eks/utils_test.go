@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConfigMap(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/namespaces/amazon-cloudwatch/configmaps/cluster-info":
+			w.Write([]byte(`{"kind": "ConfigMap", "data": {"cluster.name": "my-cluster"}}`))
+		case "/api/v1/namespaces/kube-system/configmaps/empty":
+			w.Write([]byte(`{"kind": "ConfigMap"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "eks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	u := &eksDetectorUtils{
+		tokenPath: filepath.Join(dir, "token"),
+		caPath:    filepath.Join(dir, "ca.crt"),
+	}
+	require.NoError(t, ioutil.WriteFile(u.tokenPath, []byte("token\n"), 0600))
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(u.caPath, ca, 0600))
+
+	host, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+	os.Setenv(k8sServiceHostEnv, host)
+	os.Setenv(k8sServicePortEnv, port)
+	defer os.Unsetenv(k8sServiceHostEnv)
+	defer os.Unsetenv(k8sServicePortEnv)
+
+	data, err := u.getConfigMap(context.Background(), "amazon-cloudwatch", "cluster-info")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"cluster.name": "my-cluster"}, data)
+
+	data, err = u.getConfigMap(context.Background(), "kube-system", "empty")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{}, data)
+
+	data, err = u.getConfigMap(context.Background(), "kube-system", "aws-auth")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, ioutil.WriteFile(u.tokenPath, []byte("invalid"), 0600))
+	_, err = u.getConfigMap(context.Background(), "kube-system", "aws-auth")
+	assert.EqualError(t, err, "failed getting ConfigMap kube-system/aws-auth: status 401")
+
+	u.caPath = filepath.Join(dir, "missing")
+	_, err = u.getConfigMap(context.Background(), "kube-system", "aws-auth")
+	assert.Error(t, err)
+}
+
+func TestClusterNameFromTag(t *testing.T) {
+	assert.Equal(t, "my-cluster", clusterNameFromTag("eks:cluster-name", "my-cluster"))
+	assert.Equal(t, "my-cluster", clusterNameFromTag("kubernetes.io/cluster/my-cluster", "owned"))
+	assert.Equal(t, "", clusterNameFromTag("Name", "node-1"))
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
+)
+
+const (
+	TypeStr          = "aks"
+	cloudPlatformAKS = "azure_aks"
+
+	k8sServiceHostEnv = "KUBERNETES_SERVICE_HOST"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	provider azure.Provider
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{provider: azure.NewProvider()}, nil
+}
+
+// Detect returns a resource with the AKS cloud platform when running in a
+// Kubernetes cluster on Azure VMs.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	if os.Getenv(k8sServiceHostEnv) == "" {
+		return res, nil
+	}
+
+	// The metadata service is only available on Azure VMs.
+	if _, err := d.provider.Metadata(ctx); err != nil {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, azure.CloudProviderAzure)
+	attr.InsertString(azure.AttributeCloudPlatform, cloudPlatformAKS)
+
+	return res, nil
+}
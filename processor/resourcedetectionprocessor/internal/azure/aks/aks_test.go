@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
+)
+
+type mockProvider struct {
+	returnErr error
+}
+
+var _ azure.Provider = (*mockProvider)(nil)
+
+func (mp *mockProvider) Metadata(context.Context) (*azure.ComputeMetadata, error) {
+	if mp.returnErr != nil {
+		return nil, mp.returnErr
+	}
+	return &azure.ComputeMetadata{}, nil
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(nil)
+	assert.NotNil(t, d)
+	assert.NoError(t, err)
+}
+
+func TestDetect(t *testing.T) {
+	os.Setenv(k8sServiceHostEnv, "10.0.0.1")
+	defer os.Unsetenv(k8sServiceHostEnv)
+
+	d := &Detector{provider: &mockProvider{}}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider": "azure",
+		"cloud.platform": "azure_aks",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectNotOnKubernetes(t *testing.T) {
+	os.Unsetenv(k8sServiceHostEnv)
+
+	d := &Detector{provider: &mockProvider{}}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDetectNotOnAzure(t *testing.T) {
+	os.Setenv(k8sServiceHostEnv, "10.0.0.1")
+	defer os.Unsetenv(k8sServiceHostEnv)
+
+	d := &Detector{provider: &mockProvider{returnErr: errors.New("connection refused")}}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr            = "azure"
+	CloudProviderAzure = "azure"
+	cloudPlatformVM    = "azure_vm"
+
+	// AttributeCloudPlatform is the cloud platform resource attribute.
+	AttributeCloudPlatform = "cloud.platform"
+
+	attributeAzureVMName            = "azure.vm.name"
+	attributeAzureVMSize            = "azure.vm.size"
+	attributeAzureVMScaleSetName    = "azure.vm.scaleset.name"
+	attributeAzureResourceGroupName = "azure.resourcegroup.name"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	provider Provider
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{provider: NewProvider()}, nil
+}
+
+// Detect reads the compute metadata of the VM. It returns an empty resource
+// when the metadata service is not available, e.g. when not running on Azure.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	meta, err := d.provider.Metadata(ctx)
+	if err != nil {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, CloudProviderAzure)
+	attr.InsertString(AttributeCloudPlatform, cloudPlatformVM)
+	attr.InsertString(conventions.AttributeCloudRegion, meta.Location)
+	attr.InsertString(conventions.AttributeCloudAccount, meta.SubscriptionID)
+	attr.InsertString(conventions.AttributeHostID, meta.VMID)
+	attr.InsertString(conventions.AttributeHostName, meta.Name)
+	attr.InsertString(conventions.AttributeHostType, meta.VMSize)
+	attr.InsertString(attributeAzureVMName, meta.Name)
+	attr.InsertString(attributeAzureVMSize, meta.VMSize)
+	attr.InsertString(attributeAzureResourceGroupName, meta.ResourceGroupName)
+	if meta.VMScaleSetName != "" {
+		attr.InsertString(attributeAzureVMScaleSetName, meta.VMScaleSetName)
+	}
+
+	return res, nil
+}
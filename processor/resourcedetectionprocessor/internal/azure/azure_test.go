@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockProvider struct {
+	ret       *ComputeMetadata
+	returnErr error
+}
+
+var _ Provider = (*mockProvider)(nil)
+
+func (mp *mockProvider) Metadata(context.Context) (*ComputeMetadata, error) {
+	return mp.ret, mp.returnErr
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(nil)
+	assert.NotNil(t, d)
+	assert.NoError(t, err)
+}
+
+func TestDetect(t *testing.T) {
+	d := &Detector{provider: &mockProvider{ret: &ComputeMetadata{
+		Location:          "westeurope",
+		Name:              "vm-1",
+		VMID:              "13f56399-bd52-4150-9748-7190aae1ff21",
+		VMSize:            "Standard_D2s_v3",
+		SubscriptionID:    "8d8a7d4f-5b6c-4a0c-9a0e-2b5e6c5b3f1a",
+		ResourceGroupName: "my-group",
+		VMScaleSetName:    "my-scaleset",
+	}}}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":           "azure",
+		"cloud.platform":           "azure_vm",
+		"cloud.region":             "westeurope",
+		"cloud.account.id":         "8d8a7d4f-5b6c-4a0c-9a0e-2b5e6c5b3f1a",
+		"host.id":                  "13f56399-bd52-4150-9748-7190aae1ff21",
+		"host.name":                "vm-1",
+		"host.type":                "Standard_D2s_v3",
+		"azure.vm.name":            "vm-1",
+		"azure.vm.size":            "Standard_D2s_v3",
+		"azure.vm.scaleset.name":   "my-scaleset",
+		"azure.resourcegroup.name": "my-group",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectNotOnAzure(t *testing.T) {
+	d := &Detector{provider: &mockProvider{returnErr: errors.New("connection refused")}}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
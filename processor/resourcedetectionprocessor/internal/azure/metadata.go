@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// The Azure Instance Metadata Service endpoint returning the compute
+	// metadata of the VM.
+	metadataEndpoint = "http://169.254.169.254/metadata/instance/compute"
+	apiVersion       = "2020-09-01"
+)
+
+// ComputeMetadata is the part of the compute metadata of a VM the detectors use.
+type ComputeMetadata struct {
+	Location          string `json:"location"`
+	Name              string `json:"name"`
+	VMID              string `json:"vmId"`
+	VMSize            string `json:"vmSize"`
+	SubscriptionID    string `json:"subscriptionId"`
+	ResourceGroupName string `json:"resourceGroupName"`
+	VMScaleSetName    string `json:"vmScaleSetName"`
+}
+
+// Provider gets the compute metadata of the VM from the Azure Instance Metadata Service.
+type Provider interface {
+	Metadata(ctx context.Context) (*ComputeMetadata, error)
+}
+
+type azureProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+var _ Provider = (*azureProviderImpl)(nil)
+
+// NewProvider creates a new metadata provider.
+func NewProvider() Provider {
+	return &azureProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   &http.Client{},
+	}
+}
+
+func (p *azureProviderImpl) Metadata(ctx context.Context) (*ComputeMetadata, error) {
+	req, err := http.NewRequest(http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+	q := req.URL.Query()
+	q.Set("api-version", apiVersion)
+	q.Set("format", "json")
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("azure metadata endpoint returned %d", resp.StatusCode)
+	}
+
+	meta := &ComputeMetadata{}
+	if err := json.NewDecoder(resp.Body).Decode(meta); err != nil {
+		return nil, fmt.Errorf("failed decoding azure metadata: %w", err)
+	}
+	return meta, nil
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("api-version") != apiVersion {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{
+			"location": "westeurope",
+			"name": "vm-1",
+			"vmId": "13f56399-bd52-4150-9748-7190aae1ff21",
+			"vmSize": "Standard_D2s_v3",
+			"subscriptionId": "8d8a7d4f-5b6c-4a0c-9a0e-2b5e6c5b3f1a",
+			"resourceGroupName": "my-group",
+			"vmScaleSetName": "",
+			"osType": "Linux"
+		}`))
+	}))
+	defer srv.Close()
+
+	p := &azureProviderImpl{endpoint: srv.URL, client: srv.Client()}
+	meta, err := p.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &ComputeMetadata{
+		Location:          "westeurope",
+		Name:              "vm-1",
+		VMID:              "13f56399-bd52-4150-9748-7190aae1ff21",
+		VMSize:            "Standard_D2s_v3",
+		SubscriptionID:    "8d8a7d4f-5b6c-4a0c-9a0e-2b5e6c5b3f1a",
+		ResourceGroupName: "my-group",
+	}, meta)
+}
+
+func TestMetadataError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/invalid":
+			w.Write([]byte(`{`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	p := &azureProviderImpl{endpoint: srv.URL + "/missing", client: srv.Client()}
+	_, err := p.Metadata(context.Background())
+	assert.EqualError(t, err, "azure metadata endpoint returned 404")
+
+	p = &azureProviderImpl{endpoint: srv.URL + "/invalid", client: srv.Client()}
+	_, err = p.Metadata(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+// Config defines the user-specified configuration of the Consul detector.
+type Config struct {
+	// Address is the address of the Consul agent, as host:port or as an
+	// http(s) URL. Defaults to the CONSUL_HTTP_ADDR environment variable,
+	// then to localhost:8500.
+	Address string `mapstructure:"address"`
+	// Token is the ACL token used to query the agent. Defaults to the
+	// CONSUL_HTTP_TOKEN environment variable.
+	Token string `mapstructure:"token"`
+	// Meta is a list of regular expressions matching the keys of the node
+	// metadata to add as resource attributes, named consul.meta.<key>.
+	Meta []string `mapstructure:"meta"`
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package consul provides a detector that loads resource information from
+// the local Consul agent.
+package consul
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "consul"
+
+	defaultAddress = "localhost:8500"
+	addressEnv     = "CONSUL_HTTP_ADDR"
+	tokenEnv       = "CONSUL_HTTP_TOKEN"
+
+	attributeConsulMetaPrefix = "consul.meta."
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	provider consulMetadataProvider
+	metaKeys []*regexp.Regexp
+}
+
+// NewDetector returns a detector querying the Consul agent configured in
+// dcfg, or in the environment of the collector.
+func NewDetector(dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg, _ := dcfg.(Config)
+	metaKeys := make([]*regexp.Regexp, 0, len(cfg.Meta))
+	for _, expr := range cfg.Meta {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid meta key regex %q: %w", expr, err)
+		}
+		metaKeys = append(metaKeys, re)
+	}
+
+	address := cfg.Address
+	if address == "" {
+		address = os.Getenv(addressEnv)
+	}
+	if address == "" {
+		address = defaultAddress
+	}
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = "http://" + address
+	}
+
+	token := cfg.Token
+	if token == "" {
+		token = os.Getenv(tokenEnv)
+	}
+
+	return &Detector{
+		provider: &consulMetadataImpl{
+			address: strings.TrimSuffix(address, "/"),
+			token:   token,
+			client:  &http.Client{},
+		},
+		metaKeys: metaKeys,
+	}, nil
+}
+
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	meta, err := d.provider.fetchAgentMetadata(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed getting consul agent metadata: %w", err)
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeHostName, meta.NodeName)
+	if meta.NodeID != "" {
+		attr.InsertString(conventions.AttributeHostID, meta.NodeID)
+	}
+	attr.InsertString(conventions.AttributeCloudRegion, meta.Datacenter)
+
+	for key, val := range meta.Meta {
+		for _, re := range d.metaKeys {
+			if re.MatchString(key) {
+				attr.InsertString(attributeConsulMetaPrefix+key, val)
+				break
+			}
+		}
+	}
+
+	return res, nil
+}
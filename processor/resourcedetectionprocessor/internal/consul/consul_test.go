@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockMetadata struct {
+	ret       *agentMetadata
+	returnErr error
+}
+
+var _ consulMetadataProvider = (*mockMetadata)(nil)
+
+func (mm *mockMetadata) fetchAgentMetadata(context.Context) (*agentMetadata, error) {
+	return mm.ret, mm.returnErr
+}
+
+func TestNewDetector(t *testing.T) {
+	os.Unsetenv(addressEnv)
+	os.Unsetenv(tokenEnv)
+
+	d, err := NewDetector(nil)
+	require.NoError(t, err)
+	md := d.(*Detector).provider.(*consulMetadataImpl)
+	assert.Equal(t, "http://localhost:8500", md.address)
+	assert.Equal(t, "", md.token)
+
+	os.Setenv(addressEnv, "https://consul.example.com:8501/")
+	os.Setenv(tokenEnv, "secret")
+	defer os.Unsetenv(addressEnv)
+	defer os.Unsetenv(tokenEnv)
+
+	d, err = NewDetector(Config{})
+	require.NoError(t, err)
+	md = d.(*Detector).provider.(*consulMetadataImpl)
+	assert.Equal(t, "https://consul.example.com:8501", md.address)
+	assert.Equal(t, "secret", md.token)
+
+	d, err = NewDetector(Config{Address: "10.0.0.1:8500", Token: "other"})
+	require.NoError(t, err)
+	md = d.(*Detector).provider.(*consulMetadataImpl)
+	assert.Equal(t, "http://10.0.0.1:8500", md.address)
+	assert.Equal(t, "other", md.token)
+
+	_, err = NewDetector(Config{Meta: []string{"("}})
+	assert.Error(t, err)
+}
+
+func TestDetect(t *testing.T) {
+	d, err := NewDetector(Config{Meta: []string{"^env$", "^team"}})
+	require.NoError(t, err)
+	d.(*Detector).provider = &mockMetadata{ret: &agentMetadata{
+		NodeName:   "node-1",
+		NodeID:     "9d754690-a5c4-4a5e-8dbb-ae7d1e1a6f0a",
+		Datacenter: "dc1",
+		Meta:       map[string]string{"env": "prod", "team-name": "core", "rack": "r1"},
+	}}
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"host.name":             "node-1",
+		"host.id":               "9d754690-a5c4-4a5e-8dbb-ae7d1e1a6f0a",
+		"cloud.region":          "dc1",
+		"consul.meta.env":       "prod",
+		"consul.meta.team-name": "core",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectError(t *testing.T) {
+	d := &Detector{provider: &mockMetadata{returnErr: errors.New("connection refused")}}
+	res, err := d.Detect(context.Background())
+	assert.EqualError(t, err, "failed getting consul agent metadata: connection refused")
+	assert.True(t, internal.IsEmptyResource(res))
+}
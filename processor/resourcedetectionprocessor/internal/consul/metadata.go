@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type agentMetadata struct {
+	NodeName   string
+	NodeID     string
+	Datacenter string
+	Meta       map[string]string
+}
+
+type consulMetadataProvider interface {
+	fetchAgentMetadata(ctx context.Context) (*agentMetadata, error)
+}
+
+type consulMetadataImpl struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+var _ consulMetadataProvider = (*consulMetadataImpl)(nil)
+
+// agentSelf is the part of the response of the /v1/agent/self endpoint the
+// detector uses.
+type agentSelf struct {
+	Config struct {
+		NodeName   string
+		NodeID     string
+		Datacenter string
+	}
+	Meta map[string]string
+}
+
+func (md *consulMetadataImpl) fetchAgentMetadata(ctx context.Context) (*agentMetadata, error) {
+	req, err := http.NewRequest(http.MethodGet, md.address+"/v1/agent/self", nil)
+	if err != nil {
+		return nil, err
+	}
+	if md.token != "" {
+		req.Header.Set("X-Consul-Token", md.token)
+	}
+
+	resp, err := md.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("consul agent returned %d", resp.StatusCode)
+	}
+
+	self := &agentSelf{}
+	if err := json.NewDecoder(resp.Body).Decode(self); err != nil {
+		return nil, fmt.Errorf("failed decoding consul agent metadata: %w", err)
+	}
+	return &agentMetadata{
+		NodeName:   self.Config.NodeName,
+		NodeID:     self.Config.NodeID,
+		Datacenter: self.Config.Datacenter,
+		Meta:       self.Meta,
+	}, nil
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchAgentMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/agent/self" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("X-Consul-Token") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{
+			"Config": {
+				"Datacenter": "dc1",
+				"NodeName": "node-1",
+				"NodeID": "9d754690-a5c4-4a5e-8dbb-ae7d1e1a6f0a",
+				"Server": false
+			},
+			"Meta": {
+				"env": "prod",
+				"consul-network-segment": ""
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	md := &consulMetadataImpl{address: srv.URL, token: "secret", client: srv.Client()}
+	meta, err := md.fetchAgentMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &agentMetadata{
+		NodeName:   "node-1",
+		NodeID:     "9d754690-a5c4-4a5e-8dbb-ae7d1e1a6f0a",
+		Datacenter: "dc1",
+		Meta:       map[string]string{"env": "prod", "consul-network-segment": ""},
+	}, meta)
+
+	md = &consulMetadataImpl{address: srv.URL, client: srv.Client()}
+	_, err = md.fetchAgentMetadata(context.Background())
+	assert.EqualError(t, err, "consul agent returned 403")
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gcp provides a detector that loads resource information from the
+// GCP metadata server and the environment of GCE, GKE, Cloud Run and Cloud
+// Functions.
+package gcp
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr          = "gcp"
+	cloudProviderGCP = "gcp"
+
+	cloudPlatformGCE            = "gcp_compute_engine"
+	cloudPlatformGKE            = "gcp_kubernetes_engine"
+	cloudPlatformCloudRun       = "gcp_cloud_run"
+	cloudPlatformCloudFunctions = "gcp_cloud_functions"
+
+	attributeCloudPlatform = "cloud.platform"
+	attributeFaaSName      = "faas.name"
+	attributeFaaSVersion   = "faas.version"
+	attributeFaaSID        = "faas.id"
+
+	// Cloud Run and Cloud Functions set K_SERVICE and K_REVISION, Cloud
+	// Functions also sets FUNCTION_TARGET. Older Cloud Functions runtimes
+	// only set FUNCTION_NAME.
+	kServiceEnv       = "K_SERVICE"
+	kRevisionEnv      = "K_REVISION"
+	kConfigurationEnv = "K_CONFIGURATION"
+	functionTargetEnv = "FUNCTION_TARGET"
+	functionNameEnv   = "FUNCTION_NAME"
+	k8sServiceHostEnv = "KUBERNETES_SERVICE_HOST"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	metadata gcpMetadata
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{metadata: &gcpMetadataImpl{}}, nil
+}
+
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	if !d.metadata.OnGCE() {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, cloudProviderGCP)
+
+	var errors []error
+	if projectID, err := d.metadata.ProjectID(); err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeCloudAccount, projectID)
+	}
+
+	switch {
+	case os.Getenv(functionTargetEnv) != "" || os.Getenv(functionNameEnv) != "":
+		attr.InsertString(attributeCloudPlatform, cloudPlatformCloudFunctions)
+		errors = append(errors, d.initializeFaaSAttributes(attr)...)
+	case os.Getenv(kConfigurationEnv) != "":
+		attr.InsertString(attributeCloudPlatform, cloudPlatformCloudRun)
+		errors = append(errors, d.initializeFaaSAttributes(attr)...)
+	case os.Getenv(k8sServiceHostEnv) != "":
+		attr.InsertString(attributeCloudPlatform, cloudPlatformGKE)
+		errors = append(errors, d.initializeGKEAttributes(attr)...)
+	default:
+		attr.InsertString(attributeCloudPlatform, cloudPlatformGCE)
+		errors = append(errors, d.initializeZoneAttributes(attr)...)
+		errors = append(errors, d.initializeHostAttributes(attr)...)
+	}
+
+	return res, componenterror.CombineErrors(errors)
+}
+
+// initializeFaaSAttributes sets the attributes of Cloud Run services and
+// Cloud Functions, which run in a region and have no host of their own.
+func (d *Detector) initializeFaaSAttributes(attr pdata.AttributeMap) []error {
+	var errors []error
+
+	name := os.Getenv(kServiceEnv)
+	if name == "" {
+		name = os.Getenv(functionNameEnv)
+	}
+	if name != "" {
+		attr.InsertString(attributeFaaSName, name)
+	}
+	if revision := os.Getenv(kRevisionEnv); revision != "" {
+		attr.InsertString(attributeFaaSVersion, revision)
+	}
+
+	// The region is returned as projects/<project number>/regions/<region>.
+	region, err := d.metadata.Get("instance/region")
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeCloudRegion, region[strings.LastIndex(region, "/")+1:])
+	}
+
+	instanceID, err := d.metadata.InstanceID()
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(attributeFaaSID, instanceID)
+	}
+
+	return errors
+}
+
+func (d *Detector) initializeGKEAttributes(attr pdata.AttributeMap) []error {
+	var errors []error
+
+	clusterName, err := d.metadata.InstanceAttributeValue("cluster-name")
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeK8sCluster, clusterName)
+	}
+
+	// The location of a cluster is a zone for zonal clusters and a region for
+	// regional clusters.
+	location, err := d.metadata.InstanceAttributeValue("cluster-location")
+	if err != nil {
+		errors = append(errors, err)
+	} else if strings.Count(location, "-") > 1 {
+		attr.InsertString(conventions.AttributeCloudZone, location)
+		attr.InsertString(conventions.AttributeCloudRegion, location[:strings.LastIndex(location, "-")])
+	} else {
+		attr.InsertString(conventions.AttributeCloudRegion, location)
+	}
+
+	errors = append(errors, d.initializeHostAttributes(attr)...)
+	return errors
+}
+
+func (d *Detector) initializeZoneAttributes(attr pdata.AttributeMap) []error {
+	zone, err := d.metadata.Zone()
+	if err != nil {
+		return []error{err}
+	}
+	attr.InsertString(conventions.AttributeCloudZone, zone)
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		attr.InsertString(conventions.AttributeCloudRegion, zone[:i])
+	}
+	return nil
+}
+
+func (d *Detector) initializeHostAttributes(attr pdata.AttributeMap) []error {
+	var errors []error
+
+	hostname, err := d.metadata.Hostname()
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeHostHostname, hostname)
+	}
+
+	instanceID, err := d.metadata.InstanceID()
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeHostID, instanceID)
+	}
+
+	name, err := d.metadata.InstanceName()
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeHostName, name)
+	}
+
+	hostType, err := d.metadata.Get("instance/machine-type")
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeHostType, hostType)
+	}
+
+	return errors
+}
@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockMetadata struct {
+	mock.Mock
+}
+
+func (m *mockMetadata) OnGCE() bool {
+	return m.MethodCalled("OnGCE").Bool(0)
+}
+
+func (m *mockMetadata) ProjectID() (string, error) {
+	args := m.MethodCalled("ProjectID")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) Zone() (string, error) {
+	args := m.MethodCalled("Zone")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) Hostname() (string, error) {
+	args := m.MethodCalled("Hostname")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) InstanceID() (string, error) {
+	args := m.MethodCalled("InstanceID")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) InstanceName() (string, error) {
+	args := m.MethodCalled("InstanceName")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) InstanceAttributeValue(attr string) (string, error) {
+	args := m.MethodCalled("InstanceAttributeValue", attr)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) Get(suffix string) (string, error) {
+	args := m.MethodCalled("Get", suffix)
+	return args.String(0), args.Error(1)
+}
+
+func newHostMetadata() *mockMetadata {
+	md := &mockMetadata{}
+	md.On("OnGCE").Return(true)
+	md.On("ProjectID").Return("my-project", nil)
+	md.On("Zone").Return("us-central1-a", nil)
+	md.On("Hostname").Return("hostname", nil)
+	md.On("InstanceID").Return("2", nil)
+	md.On("InstanceName").Return("name", nil)
+	md.On("Get", "instance/machine-type").Return("machine-type", nil)
+	md.On("Get", "instance/region").Return("projects/123/regions/us-central1", nil)
+	return md
+}
+
+func withEnv(t *testing.T, env map[string]string) func() {
+	for k, v := range env {
+		require.NoError(t, os.Setenv(k, v))
+	}
+	return func() {
+		for k := range env {
+			os.Unsetenv(k)
+		}
+	}
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(nil)
+	assert.NotNil(t, d)
+	assert.NoError(t, err)
+}
+
+func TestDetectGCE(t *testing.T) {
+	detector := &Detector{metadata: newHostMetadata()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":   "gcp",
+		"cloud.platform":   "gcp_compute_engine",
+		"cloud.account.id": "my-project",
+		"cloud.zone":       "us-central1-a",
+		"cloud.region":     "us-central1",
+		"host.hostname":    "hostname",
+		"host.id":          "2",
+		"host.name":        "name",
+		"host.type":        "machine-type",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectGKE(t *testing.T) {
+	defer withEnv(t, map[string]string{k8sServiceHostEnv: "10.0.0.1"})()
+
+	tests := []struct {
+		name     string
+		location string
+		want     map[string]interface{}
+	}{
+		{
+			name:     "zonal cluster",
+			location: "us-central1-a",
+			want: map[string]interface{}{
+				"cloud.zone":   "us-central1-a",
+				"cloud.region": "us-central1",
+			},
+		},
+		{
+			name:     "regional cluster",
+			location: "us-central1",
+			want: map[string]interface{}{
+				"cloud.region": "us-central1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := newHostMetadata()
+			md.On("InstanceAttributeValue", "cluster-name").Return("my-cluster", nil)
+			md.On("InstanceAttributeValue", "cluster-location").Return(tt.location, nil)
+
+			detector := &Detector{metadata: md}
+			res, err := detector.Detect(context.Background())
+			require.NoError(t, err)
+
+			want := map[string]interface{}{
+				"cloud.provider":   "gcp",
+				"cloud.platform":   "gcp_kubernetes_engine",
+				"cloud.account.id": "my-project",
+				"k8s.cluster.name": "my-cluster",
+				"host.hostname":    "hostname",
+				"host.id":          "2",
+				"host.name":        "name",
+				"host.type":        "machine-type",
+			}
+			for k, v := range tt.want {
+				want[k] = v
+			}
+			assert.Equal(t, want, internal.AttributesToMap(res.Attributes()))
+		})
+	}
+}
+
+func TestDetectCloudRun(t *testing.T) {
+	defer withEnv(t, map[string]string{
+		kServiceEnv:       "my-service",
+		kRevisionEnv:      "my-service-00001-abc",
+		kConfigurationEnv: "my-service",
+	})()
+
+	detector := &Detector{metadata: newHostMetadata()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":   "gcp",
+		"cloud.platform":   "gcp_cloud_run",
+		"cloud.account.id": "my-project",
+		"cloud.region":     "us-central1",
+		"faas.name":        "my-service",
+		"faas.version":     "my-service-00001-abc",
+		"faas.id":          "2",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectCloudFunctions(t *testing.T) {
+	defer withEnv(t, map[string]string{
+		kServiceEnv:       "my-function",
+		kRevisionEnv:      "3",
+		functionTargetEnv: "HelloWorld",
+	})()
+
+	detector := &Detector{metadata: newHostMetadata()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":   "gcp",
+		"cloud.platform":   "gcp_cloud_functions",
+		"cloud.account.id": "my-project",
+		"cloud.region":     "us-central1",
+		"faas.name":        "my-function",
+		"faas.version":     "3",
+		"faas.id":          "2",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectFalse(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("OnGCE").Return(false)
+
+	detector := &Detector{metadata: md}
+	res, err := detector.Detect(context.Background())
+
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDetectError(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("OnGCE").Return(true)
+	md.On("ProjectID").Return("", errors.New("err1"))
+	md.On("Zone").Return("", errors.New("err2"))
+	md.On("Hostname").Return("", errors.New("err3"))
+	md.On("InstanceID").Return("", errors.New("err4"))
+	md.On("InstanceName").Return("", errors.New("err5"))
+	md.On("Get", "instance/machine-type").Return("", errors.New("err6"))
+
+	detector := &Detector{metadata: md}
+	res, err := detector.Detect(context.Background())
+
+	assert.EqualError(t, err, "[err1; err2; err3; err4; err5; err6]")
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider": "gcp",
+		"cloud.platform": "gcp_compute_engine",
+	}, internal.AttributesToMap(res.Attributes()))
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import "cloud.google.com/go/compute/metadata"
+
+type gcpMetadata interface {
+	OnGCE() bool
+	ProjectID() (string, error)
+	Zone() (string, error)
+	Hostname() (string, error)
+	InstanceID() (string, error)
+	InstanceName() (string, error)
+	InstanceAttributeValue(attr string) (string, error)
+	Get(suffix string) (string, error)
+}
+
+type gcpMetadataImpl struct{}
+
+func (m *gcpMetadataImpl) OnGCE() bool {
+	return metadata.OnGCE()
+}
+
+func (m *gcpMetadataImpl) ProjectID() (string, error) {
+	return metadata.ProjectID()
+}
+
+func (m *gcpMetadataImpl) Zone() (string, error) {
+	return metadata.Zone()
+}
+
+func (m *gcpMetadataImpl) Hostname() (string, error) {
+	return metadata.Hostname()
+}
+
+func (m *gcpMetadataImpl) InstanceID() (string, error) {
+	return metadata.InstanceID()
+}
+
+func (m *gcpMetadataImpl) InstanceName() (string, error) {
+	return metadata.InstanceName()
+}
+
+func (m *gcpMetadataImpl) InstanceAttributeValue(attr string) (string, error) {
+	return metadata.InstanceAttributeValue(attr)
+}
+
+func (m *gcpMetadataImpl) Get(suffix string) (string, error) {
+	return metadata.Get(suffix)
+}
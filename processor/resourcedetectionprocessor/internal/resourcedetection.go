@@ -18,6 +18,7 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -32,7 +33,15 @@ type Detector interface {
 	Detect(ctx context.Context) (pdata.Resource, error)
 }
 
-type DetectorFactory func() (Detector, error)
+// DetectorConfig is the configuration of a detector, specific to its type.
+type DetectorConfig interface{}
+
+// ResourceDetectorConfig provides the configuration of the detectors by type.
+type ResourceDetectorConfig interface {
+	GetConfigFromType(DetectorType) DetectorConfig
+}
+
+type DetectorFactory func(DetectorConfig) (Detector, error)
 
 type ResourceProviderFactory struct {
 	// detectors holds all possible detector types.
@@ -43,17 +52,33 @@ func NewProviderFactory(detectors map[DetectorType]DetectorFactory) *ResourcePro
 	return &ResourceProviderFactory{detectors: detectors}
 }
 
-func (f *ResourceProviderFactory) CreateResourceProvider(logger *zap.Logger, timeout time.Duration, detectorTypes ...DetectorType) (*ResourceProvider, error) {
-	detectors, err := f.getDetectors(detectorTypes)
+// CreateResourceProvider creates a provider running the detectors of the given
+// types. The detectors with an entry in detectorTimeouts are given at most
+// that time to detect their resource. Only the attributes listed in
+// attributesToKeep are kept from the detected resource, all of them when it
+// is empty.
+func (f *ResourceProviderFactory) CreateResourceProvider(
+	logger *zap.Logger,
+	timeout time.Duration,
+	detectorTimeouts map[DetectorType]time.Duration,
+	attributesToKeep []string,
+	detectorsConfig ResourceDetectorConfig,
+	detectorTypes ...DetectorType,
+) (*ResourceProvider, error) {
+	detectors, err := f.getDetectors(detectorsConfig, detectorTimeouts, detectorTypes)
 	if err != nil {
 		return nil, err
 	}
 
-	provider := NewResourceProvider(logger, timeout, detectors...)
+	provider := NewResourceProvider(logger, timeout, attributesToKeep, detectors...)
 	return provider, nil
 }
 
-func (f *ResourceProviderFactory) getDetectors(detectorTypes []DetectorType) ([]Detector, error) {
+func (f *ResourceProviderFactory) getDetectors(
+	detectorsConfig ResourceDetectorConfig,
+	detectorTimeouts map[DetectorType]time.Duration,
+	detectorTypes []DetectorType,
+) ([]Detector, error) {
 	detectors := make([]Detector, 0, len(detectorTypes))
 	for _, detectorType := range detectorTypes {
 		detectorFactory, ok := f.detectors[detectorType]
@@ -61,20 +86,76 @@ func (f *ResourceProviderFactory) getDetectors(detectorTypes []DetectorType) ([]
 			return nil, fmt.Errorf("invalid detector key: %v", detectorType)
 		}
 
-		detector, err := detectorFactory()
+		var detectorConfig DetectorConfig
+		if detectorsConfig != nil {
+			detectorConfig = detectorsConfig.GetConfigFromType(detectorType)
+		}
+
+		detector, err := detectorFactory(detectorConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed creating detector type %q: %w", detectorType, err)
 		}
 
-		detectors = append(detectors, detector)
+		detectors = append(detectors, &timeoutDetector{
+			detectorType: detectorType,
+			detector:     detector,
+			timeout:      detectorTimeouts[detectorType],
+		})
 	}
 
 	return detectors, nil
 }
 
+// errDetectorTimeout is returned by a timeoutDetector when the timeout of its
+// detector expired.
+var errDetectorTimeout = errors.New("resource detector timed out")
+
+// timeoutDetector stops waiting for the wrapped detector when its own timeout,
+// if any, or the deadline of the detection expires, so that detectors which
+// don't honor their context cannot block the detection. Only the expiry of its
+// own timeout is reported with errDetectorTimeout.
+type timeoutDetector struct {
+	detectorType DetectorType
+	detector     Detector
+	timeout      time.Duration
+}
+
+func (d *timeoutDetector) Detect(ctx context.Context) (pdata.Resource, error) {
+	detectCtx := ctx
+	if d.timeout > 0 {
+		var cancel context.CancelFunc
+		detectCtx, cancel = context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+	}
+
+	ch := make(chan resourceResult, 1)
+	go func() {
+		res, err := d.detector.Detect(detectCtx)
+		ch <- resourceResult{resource: res, err: err}
+	}()
+
+	var r resourceResult
+	select {
+	case r = <-ch:
+	case <-detectCtx.Done():
+		r = resourceResult{
+			resource: pdata.NewResource(),
+			err:      fmt.Errorf("detector %q timed out: %w", d.detectorType, detectCtx.Err()),
+		}
+	}
+
+	// The detector failed because its own timeout expired, not the deadline
+	// of the detection.
+	if r.err != nil && d.timeout > 0 && detectCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		return pdata.NewResource(), fmt.Errorf("%w: %q after %v", errDetectorTimeout, d.detectorType, d.timeout)
+	}
+	return r.resource, r.err
+}
+
 type ResourceProvider struct {
 	logger           *zap.Logger
 	timeout          time.Duration
+	attributesToKeep map[string]struct{}
 	detectors        []Detector
 	detectedResource *resourceResult
 	once             sync.Once
@@ -85,11 +166,20 @@ type resourceResult struct {
 	err      error
 }
 
-func NewResourceProvider(logger *zap.Logger, timeout time.Duration, detectors ...Detector) *ResourceProvider {
+func NewResourceProvider(logger *zap.Logger, timeout time.Duration, attributesToKeep []string, detectors ...Detector) *ResourceProvider {
+	var keep map[string]struct{}
+	if len(attributesToKeep) > 0 {
+		keep = make(map[string]struct{}, len(attributesToKeep))
+		for _, k := range attributesToKeep {
+			keep[k] = struct{}{}
+		}
+	}
+
 	return &ResourceProvider{
-		logger:    logger,
-		timeout:   timeout,
-		detectors: detectors,
+		logger:           logger,
+		timeout:          timeout,
+		attributesToKeep: keep,
+		detectors:        detectors,
 	}
 }
 
@@ -114,6 +204,13 @@ func (p *ResourceProvider) detectResource(ctx context.Context) {
 
 	for _, detector := range p.detectors {
 		r, err := detector.Detect(ctx)
+		if errors.Is(err, errDetectorTimeout) {
+			// A slow metadata endpoint must not prevent the pipeline from
+			// starting when a timeout is set for its detector, the resource
+			// is detected without this detector.
+			p.logger.Warn("skipped resource detector", zap.Error(err))
+			continue
+		}
 		if err != nil {
 			p.detectedResource.err = err
 			return
@@ -122,11 +219,27 @@ func (p *ResourceProvider) detectResource(ctx context.Context) {
 		MergeResource(res, r, false)
 	}
 
+	if p.attributesToKeep != nil {
+		filterAttributes(res.Attributes(), p.attributesToKeep)
+	}
+
 	p.logger.Info("detected resource information", zap.Any("resource", AttributesToMap(res.Attributes())))
 
 	p.detectedResource.resource = res
 }
 
+func filterAttributes(am pdata.AttributeMap, attributesToKeep map[string]struct{}) {
+	var toDelete []string
+	am.ForEach(func(k string, _ pdata.AttributeValue) {
+		if _, ok := attributesToKeep[k]; !ok {
+			toDelete = append(toDelete, k)
+		}
+	})
+	for _, k := range toDelete {
+		am.Delete(k)
+	}
+}
+
 func AttributesToMap(am pdata.AttributeMap) map[string]interface{} {
 	mp := make(map[string]interface{}, am.Len())
 	am.ForEach(func(k string, v pdata.AttributeValue) {
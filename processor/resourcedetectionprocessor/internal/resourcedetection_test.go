@@ -81,14 +81,14 @@ func TestDetect(t *testing.T) {
 				md.On("Detect").Return(res, nil)
 
 				mockDetectorType := DetectorType(fmt.Sprintf("mockdetector%v", i))
-				mockDetectors[mockDetectorType] = func() (Detector, error) {
+				mockDetectors[mockDetectorType] = func(DetectorConfig) (Detector, error) {
 					return md, nil
 				}
 				mockDetectorTypes = append(mockDetectorTypes, mockDetectorType)
 			}
 
 			f := NewProviderFactory(mockDetectors)
-			p, err := f.CreateResourceProvider(zap.NewNop(), time.Second, mockDetectorTypes...)
+			p, err := f.CreateResourceProvider(zap.NewNop(), time.Second, nil, nil, nil, mockDetectorTypes...)
 			require.NoError(t, err)
 
 			got, err := p.Get(context.Background())
@@ -104,18 +104,18 @@ func TestDetect(t *testing.T) {
 func TestDetectResource_InvalidDetectorType(t *testing.T) {
 	mockDetectorKey := DetectorType("mock")
 	p := NewProviderFactory(map[DetectorType]DetectorFactory{})
-	_, err := p.CreateResourceProvider(zap.NewNop(), time.Second, mockDetectorKey)
+	_, err := p.CreateResourceProvider(zap.NewNop(), time.Second, nil, nil, nil, mockDetectorKey)
 	require.EqualError(t, err, fmt.Sprintf("invalid detector key: %v", mockDetectorKey))
 }
 
 func TestDetectResource_DetectoryFactoryError(t *testing.T) {
 	mockDetectorKey := DetectorType("mock")
 	p := NewProviderFactory(map[DetectorType]DetectorFactory{
-		mockDetectorKey: func() (Detector, error) {
+		mockDetectorKey: func(DetectorConfig) (Detector, error) {
 			return nil, errors.New("creation failed")
 		},
 	})
-	_, err := p.CreateResourceProvider(zap.NewNop(), time.Second, mockDetectorKey)
+	_, err := p.CreateResourceProvider(zap.NewNop(), time.Second, nil, nil, nil, mockDetectorKey)
 	require.EqualError(t, err, fmt.Sprintf("failed creating detector type %q: %v", mockDetectorKey, "creation failed"))
 }
 
@@ -126,11 +126,87 @@ func TestDetectResource_Error(t *testing.T) {
 	md2 := &MockDetector{}
 	md2.On("Detect").Return(pdata.NewResource(), errors.New("err1"))
 
-	p := NewResourceProvider(zap.NewNop(), time.Second, md1, md2)
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2)
 	_, err := p.Get(context.Background())
 	require.EqualError(t, err, "err1")
 }
 
+func TestDetectResource_Timeout(t *testing.T) {
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(NewResource(map[string]interface{}{"a": "1", "b": "2"}), nil)
+
+	// The slow detector ignores its context.
+	md2 := NewMockParallelDetector()
+	md2.On("Detect").Return(NewResource(map[string]interface{}{"c": "3"}), nil)
+	defer close(md2.ch)
+
+	md3 := &MockDetector{}
+	md3.On("Detect").Return(NewResource(map[string]interface{}{"d": "4"}), nil)
+
+	f := NewProviderFactory(map[DetectorType]DetectorFactory{
+		"md1":  func(DetectorConfig) (Detector, error) { return md1, nil },
+		"slow": func(DetectorConfig) (Detector, error) { return md2, nil },
+		"md3":  func(DetectorConfig) (Detector, error) { return md3, nil },
+	})
+	p, err := f.CreateResourceProvider(zap.NewNop(), time.Minute, map[DetectorType]time.Duration{"slow": 10 * time.Millisecond}, nil, nil, "md1", "slow", "md3")
+	require.NoError(t, err)
+
+	got, err := p.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "1", "b": "2", "d": "4"}, AttributesToMap(got.Attributes()))
+}
+
+func TestDetectResource_DeadlineExceededWithoutTimeout(t *testing.T) {
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(NewResource(map[string]interface{}{"a": "1"}), nil)
+
+	// The detector's own HTTP client deadline expired.
+	md2 := &MockDetector{}
+	md2.On("Detect").Return(pdata.NewResource(), fmt.Errorf("metadata request failed: %w", context.DeadlineExceeded))
+
+	f := NewProviderFactory(map[DetectorType]DetectorFactory{
+		"md1": func(DetectorConfig) (Detector, error) { return md1, nil },
+		"md2": func(DetectorConfig) (Detector, error) { return md2, nil },
+	})
+	p, err := f.CreateResourceProvider(zap.NewNop(), time.Minute, nil, nil, nil, "md1", "md2")
+	require.NoError(t, err)
+
+	_, err = p.Get(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestDetectResource_DetectionTimeout(t *testing.T) {
+	// The slow detector ignores its context, the deadline of the whole
+	// detection expires before its own timeout.
+	md := NewMockParallelDetector()
+	md.On("Detect").Return(NewResource(map[string]interface{}{"c": "3"}), nil)
+	defer close(md.ch)
+
+	f := NewProviderFactory(map[DetectorType]DetectorFactory{
+		"slow": func(DetectorConfig) (Detector, error) { return md, nil },
+	})
+	p, err := f.CreateResourceProvider(zap.NewNop(), 10*time.Millisecond, map[DetectorType]time.Duration{"slow": time.Minute}, nil, nil, "slow")
+	require.NoError(t, err)
+
+	_, err = p.Get(context.Background())
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, errDetectorTimeout))
+}
+
+func TestDetectResource_AttributesToKeep(t *testing.T) {
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(NewResource(map[string]interface{}{"a": "1", "b": "2"}), nil)
+
+	md2 := &MockDetector{}
+	md2.On("Detect").Return(NewResource(map[string]interface{}{"c": "3"}), nil)
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, []string{"a", "c", "d"}, md1, md2)
+	got, err := p.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "1", "c": "3"}, AttributesToMap(got.Attributes()))
+}
+
 func TestMergeResource(t *testing.T) {
 	for _, tt := range []struct {
 		name       string
@@ -193,7 +269,7 @@ func TestDetectResource_Parallel(t *testing.T) {
 	expectedResource := NewResource(map[string]interface{}{"a": "1", "b": "2", "c": "3"})
 	expectedResource.Attributes().Sort()
 
-	p := NewResourceProvider(zap.NewNop(), time.Second, md1, md2)
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2)
 
 	// call p.Get multiple times
 	wg := &sync.WaitGroup{}
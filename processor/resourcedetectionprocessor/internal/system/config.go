@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+// Config defines the user-specified configuration of the system detector.
+type Config struct {
+	// HostnameSources is a priority list of sources from which the hostname
+	// is fetched: "dns" for the fully qualified domain name, "lookup" for a
+	// reverse DNS lookup of the host IP addresses and "os" for the hostname
+	// reported by the kernel. The first source returning a hostname is used.
+	// Defaults to ["dns", "os"].
+	HostnameSources []string `mapstructure:"hostname_sources"`
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// machineIDPaths are the files holding the unique machine ID on Linux.
+var machineIDPaths = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+
+type systemMetadata interface {
+	// Hostname returns the hostname reported by the kernel.
+	Hostname() (string, error)
+	// FQDN returns the fully qualified domain name of the host.
+	FQDN() (string, error)
+	// LookupHostname returns the name of the host found by a reverse DNS
+	// lookup of its IP addresses.
+	LookupHostname() (string, error)
+	// HostID returns the unique machine ID of the host.
+	HostID() (string, error)
+	// OSType returns the operating system type.
+	OSType() string
+	// HostArch returns the CPU architecture of the host.
+	HostArch() string
+}
+
+type systemMetadataImpl struct{}
+
+var _ systemMetadata = (*systemMetadataImpl)(nil)
+
+func (*systemMetadataImpl) Hostname() (string, error) {
+	return os.Hostname()
+}
+
+func (*systemMetadataImpl) FQDN() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	cname, err := net.LookupCNAME(hostname)
+	if err != nil {
+		return "", fmt.Errorf("failed looking up the fqdn of %q: %w", hostname, err)
+	}
+	return strings.TrimSuffix(cname, "."), nil
+}
+
+func (*systemMetadataImpl) LookupHostname() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	ips, err := net.LookupIP(hostname)
+	if err != nil {
+		return "", fmt.Errorf("failed looking up the addresses of %q: %w", hostname, err)
+	}
+	for _, ip := range ips {
+		if ip.To4() == nil {
+			continue
+		}
+		names, err := net.LookupAddr(ip.String())
+		if err != nil || len(names) == 0 {
+			continue
+		}
+		return strings.TrimSuffix(names[0], "."), nil
+	}
+	return "", fmt.Errorf("no reverse dns record found for %q", hostname)
+}
+
+func (*systemMetadataImpl) HostID() (string, error) {
+	var err error
+	for _, path := range machineIDPaths {
+		var b []byte
+		if b, err = ioutil.ReadFile(path); err == nil {
+			if id := strings.TrimSpace(string(b)); id != "" {
+				return id, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("failed reading the machine id: %v", err)
+}
+
+func (*systemMetadataImpl) OSType() string {
+	return runtime.GOOS
+}
+
+// HostArch returns the architecture using the values of the semantic
+// conventions, which match GOARCH except for 32-bit x86.
+func (*systemMetadataImpl) HostArch() string {
+	if runtime.GOARCH == "386" {
+		return "x86"
+	}
+	return runtime.GOARCH
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package system provides a detector that loads resource information from
+// the host the collector runs on.
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "system"
+
+	attributeOSType   = "os.type"
+	attributeHostArch = "host.arch"
+)
+
+var defaultHostnameSources = []string{"dns", "os"}
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	provider        systemMetadata
+	hostnameSources []string
+}
+
+// NewDetector returns a detector reading the host information. It fails
+// when the configuration holds an unknown hostname source.
+func NewDetector(dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg, _ := dcfg.(Config)
+	sources := cfg.HostnameSources
+	if len(sources) == 0 {
+		sources = defaultHostnameSources
+	}
+	for _, source := range sources {
+		if _, ok := hostnameSourceFuncs[source]; !ok {
+			return nil, fmt.Errorf("invalid hostname source %q, valid sources are \"dns\", \"lookup\" and \"os\"", source)
+		}
+	}
+	return &Detector{provider: &systemMetadataImpl{}, hostnameSources: sources}, nil
+}
+
+var hostnameSourceFuncs = map[string]func(systemMetadata) (string, error){
+	"dns":    systemMetadata.FQDN,
+	"lookup": systemMetadata.LookupHostname,
+	"os":     systemMetadata.Hostname,
+}
+
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	hostname, err := d.hostname()
+	if err != nil {
+		return res, err
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeHostName, hostname)
+	attr.InsertString(attributeOSType, d.provider.OSType())
+	attr.InsertString(attributeHostArch, d.provider.HostArch())
+	// The machine ID is not available on every platform.
+	if hostID, err := d.provider.HostID(); err == nil {
+		attr.InsertString(conventions.AttributeHostID, hostID)
+	}
+
+	return res, nil
+}
+
+// hostname returns the hostname from the first source that succeeds, or the
+// error of the last one.
+func (d *Detector) hostname() (string, error) {
+	var err error
+	for _, source := range d.hostnameSources {
+		var hostname string
+		if hostname, err = hostnameSourceFuncs[source](d.provider); err == nil && hostname != "" {
+			return hostname, nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("no hostname found from sources %v", d.hostnameSources)
+	}
+	return "", fmt.Errorf("failed getting the hostname: %w", err)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockMetadata struct {
+	hostname       string
+	fqdn           string
+	lookupHostname string
+	hostID         string
+}
+
+var _ systemMetadata = (*mockMetadata)(nil)
+
+func orError(s string) (string, error) {
+	if s == "" {
+		return "", errors.New("not found")
+	}
+	return s, nil
+}
+
+func (m *mockMetadata) Hostname() (string, error)       { return orError(m.hostname) }
+func (m *mockMetadata) FQDN() (string, error)           { return orError(m.fqdn) }
+func (m *mockMetadata) LookupHostname() (string, error) { return orError(m.lookupHostname) }
+func (m *mockMetadata) HostID() (string, error)         { return orError(m.hostID) }
+func (m *mockMetadata) OSType() string                  { return "linux" }
+func (m *mockMetadata) HostArch() string                { return "amd64" }
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dns", "os"}, d.(*Detector).hostnameSources)
+
+	d, err = NewDetector(Config{HostnameSources: []string{"lookup", "os"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lookup", "os"}, d.(*Detector).hostnameSources)
+
+	_, err = NewDetector(Config{HostnameSources: []string{"dns", "invalid"}})
+	assert.EqualError(t, err, `invalid hostname source "invalid", valid sources are "dns", "lookup" and "os"`)
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  []string
+		metadata *mockMetadata
+		want     map[string]interface{}
+	}{
+		{
+			name:     "fqdn",
+			sources:  []string{"dns", "os"},
+			metadata: &mockMetadata{hostname: "host", fqdn: "host.example.com", hostID: "abc"},
+			want: map[string]interface{}{
+				"host.name": "host.example.com",
+				"host.id":   "abc",
+				"os.type":   "linux",
+				"host.arch": "amd64",
+			},
+		},
+		{
+			name:     "fallback to os",
+			sources:  []string{"dns", "os"},
+			metadata: &mockMetadata{hostname: "host", hostID: "abc"},
+			want: map[string]interface{}{
+				"host.name": "host",
+				"host.id":   "abc",
+				"os.type":   "linux",
+				"host.arch": "amd64",
+			},
+		},
+		{
+			name:     "lookup without host id",
+			sources:  []string{"lookup", "dns", "os"},
+			metadata: &mockMetadata{hostname: "host", fqdn: "host.example.com", lookupHostname: "ip-10-0-0-1.example.com"},
+			want: map[string]interface{}{
+				"host.name": "ip-10-0-0-1.example.com",
+				"os.type":   "linux",
+				"host.arch": "amd64",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Detector{provider: tt.metadata, hostnameSources: tt.sources}
+			res, err := d.Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, internal.AttributesToMap(res.Attributes()))
+		})
+	}
+}
+
+func TestDetectError(t *testing.T) {
+	d := &Detector{provider: &mockMetadata{hostname: "host"}, hostnameSources: []string{"dns", "lookup"}}
+	res, err := d.Detect(context.Background())
+	assert.EqualError(t, err, "failed getting the hostname: not found")
+	assert.True(t, internal.IsEmptyResource(res))
+}
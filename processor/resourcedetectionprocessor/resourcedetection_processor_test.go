@@ -51,6 +51,7 @@ func TestResourceProcessor(t *testing.T) {
 		name               string
 		detectorKeys       []string
 		override           bool
+		attributes         []string
 		sourceResource     pdata.Resource
 		detectedResource   pdata.Resource
 		detectedError      error
@@ -106,6 +107,25 @@ func TestResourceProcessor(t *testing.T) {
 				"host.name":        "k8s-node",
 			}),
 		},
+		{
+			name:       "Only allowed attributes are added",
+			override:   true,
+			attributes: []string{"cloud.zone", "host.name"},
+			sourceResource: internal.NewResource(map[string]interface{}{
+				"type":       "original-type",
+				"cloud.zone": "will-be-overridden",
+			}),
+			detectedResource: internal.NewResource(map[string]interface{}{
+				"cloud.zone":       "zone-1",
+				"k8s.cluster.name": "k8s-cluster",
+				"host.name":        "k8s-node",
+			}),
+			expectedResource: internal.NewResource(map[string]interface{}{
+				"type":       "original-type",
+				"cloud.zone": "zone-1",
+				"host.name":  "k8s-node",
+			}),
+		},
 		{
 			name: "Empty detected resource",
 			sourceResource: internal.NewResource(map[string]interface{}{
@@ -162,7 +182,7 @@ func TestResourceProcessor(t *testing.T) {
 			md1 := &MockDetector{}
 			md1.On("Detect").Return(tt.detectedResource, tt.detectedError)
 			factory.resourceProviderFactory = internal.NewProviderFactory(
-				map[internal.DetectorType]internal.DetectorFactory{"mock": func() (internal.Detector, error) {
+				map[internal.DetectorType]internal.DetectorFactory{"mock": func(internal.DetectorConfig) (internal.Detector, error) {
 					return md1, nil
 				}})
 
@@ -170,7 +190,7 @@ func TestResourceProcessor(t *testing.T) {
 				tt.detectorKeys = []string{"mock"}
 			}
 
-			cfg := &Config{Override: tt.override, Detectors: tt.detectorKeys, Timeout: time.Second}
+			cfg := &Config{Override: tt.override, Attributes: tt.attributes, Detectors: tt.detectorKeys, Timeout: time.Second}
 
 			// Test trace consuner
 			ttn := &exportertest.SinkTraceExporter{}
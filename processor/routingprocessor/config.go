@@ -0,0 +1,62 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// AttributeSource is the source of the attribute used to route the data.
+type AttributeSource string
+
+const (
+	// ContextAttributeSource routes the data based on a gRPC metadata entry,
+	// or an HTTP header, of the request which received it.
+	ContextAttributeSource = AttributeSource("context")
+
+	// ResourceAttributeSource routes the data based on an attribute of its
+	// resource.
+	ResourceAttributeSource = AttributeSource("resource")
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// DefaultExporters is the list of the exporters to which the data is sent
+	// when the routing attribute is missing or doesn't match any route.
+	DefaultExporters []string `mapstructure:"default_exporters"`
+
+	// AttributeSource defines where the routing attribute is read from, either
+	// "context" or "resource". Defaults to "context".
+	AttributeSource AttributeSource `mapstructure:"attribute_source"`
+
+	// FromAttribute is the name of the header or resource attribute whose
+	// value determines the route.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// Table is the list of the routes.
+	Table []RoutingTableItem `mapstructure:"table"`
+}
+
+// RoutingTableItem specifies the exporters to which the data is sent when the
+// routing attribute has a given value.
+type RoutingTableItem struct {
+	// Value is the value of the routing attribute selecting this route.
+	Value string `mapstructure:"value"`
+
+	// Exporters is the list of the exporters of this route.
+	Exporters []string `mapstructure:"exporters"`
+}
@@ -0,0 +1,127 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "routing"
+)
+
+var (
+	errNoExporters            = errors.New("no exporters defined for the route")
+	errNoTableItems           = errors.New("the routing table is empty")
+	errMissingFromAttribute   = errors.New("missing required field \"from_attribute\"")
+	errInvalidAttributeSource = fmt.Errorf("unsupported \"attribute_source\", the supported sources are %q and %q", ContextAttributeSource, ResourceAttributeSource)
+	errEmptyRoutingValue      = errors.New("missing required field \"value\" in the routing table")
+	errDuplicatedRoute        = errors.New("duplicated \"value\" in the routing table")
+)
+
+// NewFactory returns a new factory for the routing processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: configmodels.Type(typeStr),
+			NameVal: typeStr,
+		},
+		AttributeSource: ContextAttributeSource,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	_ consumer.TraceConsumer,
+) (component.TraceProcessor, error) {
+	oCfg := cfg.(*Config)
+	if err := validateConfig(oCfg); err != nil {
+		return nil, err
+	}
+	return newRoutingProcessor(params.Logger, oCfg, configmodels.TracesDataType), nil
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	_ consumer.MetricsConsumer,
+) (component.MetricsProcessor, error) {
+	oCfg := cfg.(*Config)
+	if err := validateConfig(oCfg); err != nil {
+		return nil, err
+	}
+	return newRoutingProcessor(params.Logger, oCfg, configmodels.MetricsDataType), nil
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	_ consumer.LogsConsumer,
+) (component.LogsProcessor, error) {
+	oCfg := cfg.(*Config)
+	if err := validateConfig(oCfg); err != nil {
+		return nil, err
+	}
+	return newRoutingProcessor(params.Logger, oCfg, configmodels.LogsDataType), nil
+}
+
+// validateConfig validates the input configuration has all of the required fields for the processor.
+func validateConfig(cfg *Config) error {
+	if cfg.FromAttribute == "" {
+		return errMissingFromAttribute
+	}
+	if cfg.AttributeSource != ContextAttributeSource && cfg.AttributeSource != ResourceAttributeSource {
+		return errInvalidAttributeSource
+	}
+	if len(cfg.Table) == 0 {
+		return errNoTableItems
+	}
+
+	values := make(map[string]bool, len(cfg.Table))
+	for _, item := range cfg.Table {
+		if item.Value == "" {
+			return errEmptyRoutingValue
+		}
+		if values[item.Value] {
+			return errDuplicatedRoute
+		}
+		values[item.Value] = true
+		if len(item.Exporters) == 0 {
+			return errNoExporters
+		}
+	}
+	return nil
+}
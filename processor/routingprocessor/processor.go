@@ -0,0 +1,268 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+// routingProcessor sends the data to the exporters of the route selected by
+// the routing attribute instead of the next consumer of the pipeline.
+type routingProcessor struct {
+	logger   *zap.Logger
+	config   *Config
+	dataType configmodels.DataType
+
+	// The exporters are only available when the processor starts.
+	defaultExporters []component.Exporter
+	routes           map[string][]component.Exporter
+}
+
+var _ (component.TraceProcessor) = (*routingProcessor)(nil)
+var _ (component.MetricsProcessor) = (*routingProcessor)(nil)
+var _ (component.LogsProcessor) = (*routingProcessor)(nil)
+
+func newRoutingProcessor(logger *zap.Logger, cfg *Config, dataType configmodels.DataType) *routingProcessor {
+	return &routingProcessor{
+		logger:   logger,
+		config:   cfg,
+		dataType: dataType,
+		routes:   make(map[string][]component.Exporter, len(cfg.Table)),
+	}
+}
+
+func (rp *routingProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: false}
+}
+
+// Start looks up the exporters of the routes among the exporters of the
+// processor's data type.
+func (rp *routingProcessor) Start(_ context.Context, host component.Host) error {
+	available := make(map[string]component.Exporter)
+	for cfg, exp := range host.GetExporters()[rp.dataType] {
+		available[cfg.Name()] = exp
+	}
+
+	var err error
+	if rp.defaultExporters, err = rp.selectExporters(available, rp.config.DefaultExporters); err != nil {
+		return err
+	}
+	for _, item := range rp.config.Table {
+		exporters, err := rp.selectExporters(available, item.Exporters)
+		if err != nil {
+			return err
+		}
+		rp.routes[item.Value] = exporters
+	}
+	return nil
+}
+
+func (rp *routingProcessor) selectExporters(available map[string]component.Exporter, names []string) ([]component.Exporter, error) {
+	exporters := make([]component.Exporter, 0, len(names))
+	for _, name := range names {
+		exp, ok := available[name]
+		if !ok {
+			return nil, fmt.Errorf("%s exporter is not in the %s pipelines", name, rp.dataType)
+		}
+
+		var supported bool
+		switch rp.dataType {
+		case configmodels.TracesDataType:
+			_, supported = exp.(component.TraceExporter)
+		case configmodels.MetricsDataType:
+			_, supported = exp.(component.MetricsExporter)
+		case configmodels.LogsDataType:
+			_, supported = exp.(component.LogsExporter)
+		}
+		if !supported {
+			return nil, fmt.Errorf("%s exporter doesn't support routing %s", name, rp.dataType)
+		}
+		exporters = append(exporters, exp)
+	}
+	return exporters, nil
+}
+
+func (rp *routingProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// exporters returns the exporters of the route selected by the value of the
+// routing attribute.
+func (rp *routingProcessor) exporters(value string) []component.Exporter {
+	if exporters, ok := rp.routes[value]; ok {
+		return exporters
+	}
+	return rp.defaultExporters
+}
+
+// ConsumeTraces sends the spans to the exporters of their route. The batch is
+// split by resource when routing on a resource attribute.
+func (rp *routingProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if rp.config.AttributeSource == ContextAttributeSource {
+		return rp.exportTraces(ctx, rp.exporters(valueFromContext(ctx, rp.config.FromAttribute)), td)
+	}
+
+	var values []string
+	batches := make(map[string]pdata.Traces)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		value := valueFromResource(rs.Resource(), rp.config.FromAttribute)
+		batch, ok := batches[value]
+		if !ok {
+			batch = pdata.NewTraces()
+			batches[value] = batch
+			values = append(values, value)
+		}
+		batch.ResourceSpans().Append(&rs)
+	}
+
+	var errs []error
+	for _, value := range values {
+		if err := rp.exportTraces(ctx, rp.exporters(value), batches[value]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+func (rp *routingProcessor) exportTraces(ctx context.Context, exporters []component.Exporter, td pdata.Traces) error {
+	var errs []error
+	for _, exp := range exporters {
+		if err := exp.(component.TraceExporter).ConsumeTraces(ctx, td); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// ConsumeLogs sends the logs to the exporters of their route the same way as
+// for spans.
+func (rp *routingProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if rp.config.AttributeSource == ContextAttributeSource {
+		return rp.exportLogs(ctx, rp.exporters(valueFromContext(ctx, rp.config.FromAttribute)), ld)
+	}
+
+	var values []string
+	batches := make(map[string]pdata.Logs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		value := valueFromResource(rl.Resource(), rp.config.FromAttribute)
+		batch, ok := batches[value]
+		if !ok {
+			batch = pdata.NewLogs()
+			batches[value] = batch
+			values = append(values, value)
+		}
+		batch.ResourceLogs().Append(&rl)
+	}
+
+	var errs []error
+	for _, value := range values {
+		if err := rp.exportLogs(ctx, rp.exporters(value), batches[value]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+func (rp *routingProcessor) exportLogs(ctx context.Context, exporters []component.Exporter, ld pdata.Logs) error {
+	var errs []error
+	for _, exp := range exporters {
+		if err := exp.(component.LogsExporter).ConsumeLogs(ctx, ld); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// ConsumeMetrics sends the metrics to the exporters of their route, using the
+// resource labels when routing on a resource attribute.
+// TODO: Move to internal data model once it's available in contrib.
+func (rp *routingProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if rp.config.AttributeSource == ContextAttributeSource {
+		return rp.exportMetrics(ctx, rp.exporters(valueFromContext(ctx, rp.config.FromAttribute)), md)
+	}
+
+	var values []string
+	batches := make(map[string][]consumerdata.MetricsData)
+	for _, data := range pdatautil.MetricsToMetricsData(md) {
+		value := data.Resource.GetLabels()[rp.config.FromAttribute]
+		if _, ok := batches[value]; !ok {
+			values = append(values, value)
+		}
+		batches[value] = append(batches[value], data)
+	}
+
+	var errs []error
+	for _, value := range values {
+		if err := rp.exportMetrics(ctx, rp.exporters(value), pdatautil.MetricsFromMetricsData(batches[value])); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+func (rp *routingProcessor) exportMetrics(ctx context.Context, exporters []component.Exporter, md pdata.Metrics) error {
+	var errs []error
+	for _, exp := range exporters {
+		if err := exp.(component.MetricsExporter).ConsumeMetrics(ctx, md); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// valueFromContext returns the first value of the gRPC metadata entry of the
+// request. The HTTP headers are available as gRPC metadata for the receivers
+// serving both protocols.
+func valueFromContext(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func valueFromResource(resource pdata.Resource, key string) string {
+	if resource.IsNil() {
+		return ""
+	}
+	if val, ok := resource.Attributes().Get(key); ok && val.Type() == pdata.AttributeValueSTRING {
+		return val.StringVal()
+	}
+	return ""
+}
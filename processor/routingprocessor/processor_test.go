@@ -0,0 +1,205 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+type mockHost struct {
+	component.Host
+	exporters map[configmodels.DataType]map[configmodels.Exporter]component.Exporter
+}
+
+func newMockHost(dataType configmodels.DataType, exporters map[string]component.Exporter) *mockHost {
+	byConfig := make(map[configmodels.Exporter]component.Exporter, len(exporters))
+	for name, exp := range exporters {
+		byConfig[&configmodels.ExporterSettings{TypeVal: "otlp", NameVal: name}] = exp
+	}
+	return &mockHost{
+		Host:      componenttest.NewNopHost(),
+		exporters: map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{dataType: byConfig},
+	}
+}
+
+func (m *mockHost) GetExporters() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+	return m.exporters
+}
+
+func newConfig(source AttributeSource) *Config {
+	return &Config{
+		DefaultExporters: []string{"otlp"},
+		AttributeSource:  source,
+		FromAttribute:    "X-Tenant",
+		Table: []RoutingTableItem{
+			{Value: "acme", Exporters: []string{"otlp/acme"}},
+			{Value: "globex", Exporters: []string{"otlp/globex", "otlp"}},
+		},
+	}
+}
+
+func newTraces(tenants ...string) pdata.Traces {
+	td := pdata.NewTraces()
+	rss := td.ResourceSpans()
+	rss.Resize(len(tenants))
+	for i, tenant := range tenants {
+		rss.At(i).Resource().InitEmpty()
+		if tenant != "" {
+			rss.At(i).Resource().Attributes().InsertString("X-Tenant", tenant)
+		}
+	}
+	return td
+}
+
+func TestTracesRoutingFromContext(t *testing.T) {
+	defaultExp := &exportertest.SinkTraceExporter{}
+	acmeExp := &exportertest.SinkTraceExporter{}
+	globexExp := &exportertest.SinkTraceExporter{}
+
+	rp := newRoutingProcessor(zap.NewNop(), newConfig(ContextAttributeSource), configmodels.TracesDataType)
+	require.NoError(t, rp.Start(context.Background(), newMockHost(configmodels.TracesDataType, map[string]component.Exporter{
+		"otlp":        defaultExp,
+		"otlp/acme":   acmeExp,
+		"otlp/globex": globexExp,
+	})))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "acme"))
+	require.NoError(t, rp.ConsumeTraces(ctx, newTraces("")))
+	assert.Len(t, acmeExp.AllTraces(), 1)
+	assert.Len(t, globexExp.AllTraces(), 0)
+	assert.Len(t, defaultExp.AllTraces(), 0)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "globex"))
+	require.NoError(t, rp.ConsumeTraces(ctx, newTraces("")))
+	assert.Len(t, acmeExp.AllTraces(), 1)
+	assert.Len(t, globexExp.AllTraces(), 1)
+	assert.Len(t, defaultExp.AllTraces(), 1)
+
+	// unknown tenant and missing header are sent to the default exporters
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "initech"))
+	require.NoError(t, rp.ConsumeTraces(ctx, newTraces("")))
+	require.NoError(t, rp.ConsumeTraces(context.Background(), newTraces("")))
+	assert.Len(t, acmeExp.AllTraces(), 1)
+	assert.Len(t, globexExp.AllTraces(), 1)
+	assert.Len(t, defaultExp.AllTraces(), 3)
+}
+
+func TestTracesRoutingFromResource(t *testing.T) {
+	defaultExp := &exportertest.SinkTraceExporter{}
+	acmeExp := &exportertest.SinkTraceExporter{}
+	globexExp := &exportertest.SinkTraceExporter{}
+
+	rp := newRoutingProcessor(zap.NewNop(), newConfig(ResourceAttributeSource), configmodels.TracesDataType)
+	require.NoError(t, rp.Start(context.Background(), newMockHost(configmodels.TracesDataType, map[string]component.Exporter{
+		"otlp":        defaultExp,
+		"otlp/acme":   acmeExp,
+		"otlp/globex": globexExp,
+	})))
+
+	require.NoError(t, rp.ConsumeTraces(context.Background(), newTraces("acme", "", "acme", "globex")))
+	require.Len(t, acmeExp.AllTraces(), 1)
+	assert.Equal(t, 2, acmeExp.AllTraces()[0].ResourceSpans().Len())
+	require.Len(t, globexExp.AllTraces(), 1)
+	assert.Equal(t, 1, globexExp.AllTraces()[0].ResourceSpans().Len())
+	assert.Len(t, defaultExp.AllTraces(), 2)
+}
+
+func TestLogsRoutingFromResource(t *testing.T) {
+	defaultExp := &exportertest.SinkLogsExporter{}
+	acmeExp := &exportertest.SinkLogsExporter{}
+	globexExp := &exportertest.SinkLogsExporter{}
+
+	rp := newRoutingProcessor(zap.NewNop(), newConfig(ResourceAttributeSource), configmodels.LogsDataType)
+	require.NoError(t, rp.Start(context.Background(), newMockHost(configmodels.LogsDataType, map[string]component.Exporter{
+		"otlp":        defaultExp,
+		"otlp/acme":   acmeExp,
+		"otlp/globex": globexExp,
+	})))
+
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(2)
+	rls.At(0).Resource().InitEmpty()
+	rls.At(0).Resource().Attributes().InsertString("X-Tenant", "acme")
+	require.NoError(t, rp.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, acmeExp.AllLogs(), 1)
+	assert.Equal(t, 1, acmeExp.AllLogs()[0].ResourceLogs().Len())
+	assert.Len(t, globexExp.AllLogs(), 0)
+	assert.Len(t, defaultExp.AllLogs(), 1)
+}
+
+func TestMetricsRoutingFromResource(t *testing.T) {
+	defaultExp := &exportertest.SinkMetricsExporter{}
+	acmeExp := &exportertest.SinkMetricsExporter{}
+	globexExp := &exportertest.SinkMetricsExporter{}
+
+	rp := newRoutingProcessor(zap.NewNop(), newConfig(ResourceAttributeSource), configmodels.MetricsDataType)
+	require.NoError(t, rp.Start(context.Background(), newMockHost(configmodels.MetricsDataType, map[string]component.Exporter{
+		"otlp":        defaultExp,
+		"otlp/acme":   acmeExp,
+		"otlp/globex": globexExp,
+	})))
+
+	metric := &metricspb.Metric{MetricDescriptor: &metricspb.MetricDescriptor{Name: "requests"}}
+	mds := []consumerdata.MetricsData{
+		{Resource: &resourcepb.Resource{Labels: map[string]string{"X-Tenant": "globex"}}, Metrics: []*metricspb.Metric{metric}},
+		{Resource: &resourcepb.Resource{Labels: map[string]string{"X-Tenant": "acme"}}, Metrics: []*metricspb.Metric{metric}},
+		{Metrics: []*metricspb.Metric{metric}},
+		{Resource: &resourcepb.Resource{Labels: map[string]string{"X-Tenant": "globex"}}, Metrics: []*metricspb.Metric{metric}},
+	}
+	require.NoError(t, rp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData(mds)))
+
+	require.Len(t, globexExp.AllMetrics(), 1)
+	assert.Len(t, pdatautil.MetricsToMetricsData(globexExp.AllMetrics()[0]), 2)
+	require.Len(t, acmeExp.AllMetrics(), 1)
+	assert.Len(t, pdatautil.MetricsToMetricsData(acmeExp.AllMetrics()[0]), 1)
+	// default exporter receives the batch of the globex route and the one without tenant
+	assert.Len(t, defaultExp.AllMetrics(), 2)
+}
+
+func TestStartMissingExporter(t *testing.T) {
+	rp := newRoutingProcessor(zap.NewNop(), newConfig(ContextAttributeSource), configmodels.TracesDataType)
+	err := rp.Start(context.Background(), newMockHost(configmodels.TracesDataType, map[string]component.Exporter{
+		"otlp":      &exportertest.SinkTraceExporter{},
+		"otlp/acme": &exportertest.SinkTraceExporter{},
+	}))
+	assert.EqualError(t, err, "otlp/globex exporter is not in the traces pipelines")
+}
+
+func TestStartUnsupportedExporter(t *testing.T) {
+	rp := newRoutingProcessor(zap.NewNop(), newConfig(ContextAttributeSource), configmodels.TracesDataType)
+	err := rp.Start(context.Background(), newMockHost(configmodels.TracesDataType, map[string]component.Exporter{
+		"otlp":        &exportertest.SinkMetricsExporter{},
+		"otlp/acme":   &exportertest.SinkTraceExporter{},
+		"otlp/globex": &exportertest.SinkTraceExporter{},
+	}))
+	assert.EqualError(t, err, "otlp exporter doesn't support routing traces")
+}
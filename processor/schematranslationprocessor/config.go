@@ -0,0 +1,41 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schematranslationprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// SchemaFiles is the list of the files declaring the changes introduced by
+	// each version of the semantic conventions.
+	SchemaFiles []string `mapstructure:"schema_files"`
+
+	// TargetVersion is the version the telemetry is translated to.
+	TargetVersion string `mapstructure:"target_version"`
+
+	// VersionAttribute is the resource attribute holding the version the
+	// telemetry was produced with. It is set to the target version once the
+	// telemetry is translated. Defaults to telemetry.schema.version.
+	VersionAttribute string `mapstructure:"version_attribute"`
+
+	// DefaultVersion is the version of the telemetry without version
+	// attribute. The telemetry without version attribute isn't translated
+	// when it is empty.
+	DefaultVersion string `mapstructure:"default_version"`
+}
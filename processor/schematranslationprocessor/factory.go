@@ -0,0 +1,114 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schematranslationprocessor
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "schematranslation"
+
+	defaultVersionAttribute = "telemetry.schema.version"
+)
+
+var (
+	errNoSchemaFiles    = errors.New("missing required field \"schema_files\"")
+	errNoTargetVersion  = errors.New("missing required field \"target_version\"")
+	errNoVersionAttrKey = errors.New("missing required field \"version_attribute\"")
+)
+
+// NewFactory returns a new factory for the schema translation processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: configmodels.Type(typeStr),
+			NameVal: typeStr,
+		},
+		VersionAttribute: defaultVersionAttribute,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextTraceConsumer consumer.TraceConsumer,
+) (component.TraceProcessor, error) {
+	stp, err := newSchemaTranslationProcessor(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	stp.nextTraceConsumer = nextTraceConsumer
+	return stp, nil
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextMetricsConsumer consumer.MetricsConsumer,
+) (component.MetricsProcessor, error) {
+	stp, err := newSchemaTranslationProcessor(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	stp.nextMetricsConsumer = nextMetricsConsumer
+	return stp, nil
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextLogsConsumer consumer.LogsConsumer,
+) (component.LogsProcessor, error) {
+	stp, err := newSchemaTranslationProcessor(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	stp.nextLogsConsumer = nextLogsConsumer
+	return stp, nil
+}
+
+// validateConfig validates the input configuration has all of the required fields for the processor.
+func validateConfig(cfg *Config) error {
+	if len(cfg.SchemaFiles) == 0 {
+		return errNoSchemaFiles
+	}
+	if cfg.TargetVersion == "" {
+		return errNoTargetVersion
+	}
+	if cfg.VersionAttribute == "" {
+		return errNoVersionAttrKey
+	}
+	return nil
+}
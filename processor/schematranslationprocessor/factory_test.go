@@ -0,0 +1,114 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schematranslationprocessor
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestType(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, configmodels.Type(typeStr), factory.Type())
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: typeStr,
+			TypeVal: typeStr,
+		},
+		VersionAttribute: defaultVersionAttribute,
+	}, cfg)
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SchemaFiles = []string{path.Join(".", "testdata", "schema.yaml")}
+	cfg.TargetVersion = "1.2.0"
+	params := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	tp, err := factory.CreateTraceProcessor(context.Background(), params, cfg, &exportertest.SinkTraceExporter{})
+	assert.NoError(t, err)
+	assert.NotNil(t, tp)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, &exportertest.SinkMetricsExporter{})
+	assert.NoError(t, err)
+	assert.NotNil(t, mp)
+
+	lp, err := factory.(component.LogsProcessorFactory).CreateLogsProcessor(context.Background(), params, cfg, &exportertest.SinkLogsExporter{})
+	assert.NoError(t, err)
+	assert.NotNil(t, lp)
+}
+
+func TestCreateProcessorInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "missing schema files",
+			modify: func(cfg *Config) { cfg.SchemaFiles = nil },
+			err:    errNoSchemaFiles.Error(),
+		},
+		{
+			name:   "missing target version",
+			modify: func(cfg *Config) { cfg.TargetVersion = "" },
+			err:    errNoTargetVersion.Error(),
+		},
+		{
+			name:   "missing version attribute",
+			modify: func(cfg *Config) { cfg.VersionAttribute = "" },
+			err:    errNoVersionAttrKey.Error(),
+		},
+		{
+			name:   "invalid target version",
+			modify: func(cfg *Config) { cfg.TargetVersion = "latest" },
+			err:    `invalid version "latest"`,
+		},
+		{
+			name:   "invalid default version",
+			modify: func(cfg *Config) { cfg.DefaultVersion = "v1" },
+			err:    `invalid version "v1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.SchemaFiles = []string{path.Join(".", "testdata", "schema.yaml")}
+			cfg.TargetVersion = "1.2.0"
+			tt.modify(cfg)
+
+			tp, err := factory.CreateTraceProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, &exportertest.SinkTraceExporter{})
+			assert.EqualError(t, err, tt.err)
+			assert.Nil(t, tp)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/processor/schematranslationprocessor
+
+go 1.14
+
+require (
+	github.com/census-instrumentation/opencensus-proto v0.3.0
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/collector v0.8.1-0.20200815205113-8e5c6065eb0e
+	go.uber.org/zap v1.15.0
+	gopkg.in/yaml.v2 v2.3.0
+)
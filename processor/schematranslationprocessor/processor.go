@@ -0,0 +1,302 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schematranslationprocessor
+
+import (
+	"context"
+	"sync"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.uber.org/zap"
+)
+
+type schemaTranslationProcessor struct {
+	logger           *zap.Logger
+	schema           *schema
+	target           versionNumber
+	targetVersion    string
+	versionAttribute string
+	defaultVersion   string
+
+	// translations caches the translations by source version, nil if the
+	// telemetry of the version isn't translated.
+	mu           sync.Mutex
+	translations map[string][]renames
+
+	nextTraceConsumer   consumer.TraceConsumer
+	nextMetricsConsumer consumer.MetricsConsumer
+	nextLogsConsumer    consumer.LogsConsumer
+}
+
+var _ (component.TraceProcessor) = (*schemaTranslationProcessor)(nil)
+var _ (component.MetricsProcessor) = (*schemaTranslationProcessor)(nil)
+var _ (component.LogsProcessor) = (*schemaTranslationProcessor)(nil)
+
+func newSchemaTranslationProcessor(logger *zap.Logger, cfg *Config) (*schemaTranslationProcessor, error) {
+	if err := validateConfig(cfg); err != nil {
+		return nil, err
+	}
+	target, err := parseVersion(cfg.TargetVersion)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.DefaultVersion != "" {
+		if _, err := parseVersion(cfg.DefaultVersion); err != nil {
+			return nil, err
+		}
+	}
+	s, err := loadSchema(cfg.SchemaFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemaTranslationProcessor{
+		logger:           logger,
+		schema:           s,
+		target:           target,
+		targetVersion:    cfg.TargetVersion,
+		versionAttribute: cfg.VersionAttribute,
+		defaultVersion:   cfg.DefaultVersion,
+		translations:     make(map[string][]renames),
+	}, nil
+}
+
+func (stp *schemaTranslationProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: true}
+}
+
+func (stp *schemaTranslationProcessor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (stp *schemaTranslationProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// translation returns the renames translating the telemetry of the version to
+// the target version, and whether the telemetry is translated.
+func (stp *schemaTranslationProcessor) translation(version string) ([]renames, bool) {
+	if version == "" {
+		version = stp.defaultVersion
+	}
+	if version == "" {
+		return nil, false
+	}
+
+	stp.mu.Lock()
+	defer stp.mu.Unlock()
+	if steps, ok := stp.translations[version]; ok {
+		return steps, steps != nil
+	}
+
+	number, err := parseVersion(version)
+	if err != nil {
+		stp.logger.Debug("Telemetry with an invalid version is not translated", zap.Error(err))
+		stp.translations[version] = nil
+		return nil, false
+	}
+	steps := stp.schema.translation(number, stp.target)
+	if steps == nil {
+		// The telemetry is still labeled with the target version.
+		steps = []renames{}
+	}
+	stp.translations[version] = steps
+	return steps, true
+}
+
+// ConsumeTraces translates the attributes of the resources and spans.
+func (stp *schemaTranslationProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		steps, ok := stp.translateResource(rs.Resource())
+		if !ok {
+			continue
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				renameAttributes(span.Attributes(), steps)
+			}
+		}
+	}
+
+	return stp.nextTraceConsumer.ConsumeTraces(ctx, td)
+}
+
+// ConsumeLogs translates the attributes of the resources and logs.
+func (stp *schemaTranslationProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		steps, ok := stp.translateResource(rl.Resource())
+		if !ok {
+			continue
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				log := logs.At(k)
+				if log.IsNil() {
+					continue
+				}
+				renameAttributes(log.Attributes(), steps)
+			}
+		}
+	}
+
+	return stp.nextLogsConsumer.ConsumeLogs(ctx, ld)
+}
+
+// translateResource translates the attributes of the resource and sets its
+// version to the target version. It returns the renames of the records of
+// the resource, and whether they are translated.
+func (stp *schemaTranslationProcessor) translateResource(resource pdata.Resource) ([]renames, bool) {
+	var version string
+	if !resource.IsNil() {
+		if v, ok := resource.Attributes().Get(stp.versionAttribute); ok && v.Type() == pdata.AttributeValueSTRING {
+			version = v.StringVal()
+		}
+	}
+	steps, ok := stp.translation(version)
+	if !ok {
+		return nil, false
+	}
+
+	if resource.IsNil() {
+		resource.InitEmpty()
+	}
+	attrs := resource.Attributes()
+	renameAttributes(attrs, steps)
+	attrs.UpsertString(stp.versionAttribute, stp.targetVersion)
+	return steps, true
+}
+
+// renameAttributes applies the successive renames to the attributes. If the
+// new name is already used, the attribute with the old name is removed.
+func renameAttributes(attrs pdata.AttributeMap, steps []renames) {
+	for _, step := range steps {
+		var found []string
+		attrs.ForEach(func(k string, _ pdata.AttributeValue) {
+			if _, ok := step.Attributes[k]; ok {
+				found = append(found, k)
+			}
+		})
+		for _, k := range found {
+			v, _ := attrs.Get(k)
+			attrs.Insert(step.Attributes[k], v)
+			attrs.Delete(k)
+		}
+	}
+}
+
+// ConsumeMetrics translates the labels of the resources and metrics, and the
+// names of the metrics.
+// TODO: Move to internal data model once it's available in contrib.
+func (stp *schemaTranslationProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	mds := pdatautil.MetricsToMetricsData(md)
+	for i := range mds {
+		data := &mds[i]
+		steps, ok := stp.translation(data.Resource.GetLabels()[stp.versionAttribute])
+		if !ok {
+			continue
+		}
+
+		if data.Resource == nil {
+			data.Resource = &resourcepb.Resource{}
+		}
+		data.Resource.Labels = renameLabels(data.Resource.Labels, steps)
+		data.Resource.Labels[stp.versionAttribute] = stp.targetVersion
+
+		for _, metric := range data.Metrics {
+			renameMetric(metric, steps)
+		}
+	}
+
+	return stp.nextMetricsConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData(mds))
+}
+
+// renameLabels applies the successive renames to the labels the same way as
+// renameAttributes.
+func renameLabels(labels map[string]string, steps []renames) map[string]string {
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	for _, step := range steps {
+		var found []string
+		for k := range labels {
+			if _, ok := step.Attributes[k]; ok {
+				found = append(found, k)
+			}
+		}
+		for _, k := range found {
+			if _, ok := labels[step.Attributes[k]]; !ok {
+				labels[step.Attributes[k]] = labels[k]
+			}
+			delete(labels, k)
+		}
+	}
+	return labels
+}
+
+// renameMetric applies the successive renames to the name and label keys of
+// the metric. A label key isn't renamed if the new name is already used.
+func renameMetric(metric *metricspb.Metric, steps []renames) {
+	desc := metric.GetMetricDescriptor()
+	if desc == nil {
+		return
+	}
+	for _, step := range steps {
+		if name, ok := step.Metrics[desc.Name]; ok {
+			desc.Name = name
+		}
+		keys := make(map[string]bool, len(desc.LabelKeys))
+		for _, key := range desc.LabelKeys {
+			keys[key.Key] = true
+		}
+		for _, key := range desc.LabelKeys {
+			if name, ok := step.Attributes[key.Key]; ok && !keys[name] {
+				delete(keys, key.Key)
+				keys[name] = true
+				key.Key = name
+			}
+		}
+	}
+}
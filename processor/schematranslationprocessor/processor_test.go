@@ -0,0 +1,166 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schematranslationprocessor
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func newProcessor(t *testing.T, target, defaultVersion string) *schemaTranslationProcessor {
+	stp, err := newSchemaTranslationProcessor(zap.NewNop(), &Config{
+		SchemaFiles:      []string{path.Join(".", "testdata", "schema.yaml")},
+		TargetVersion:    target,
+		VersionAttribute: defaultVersionAttribute,
+		DefaultVersion:   defaultVersion,
+	})
+	require.NoError(t, err)
+	return stp
+}
+
+func appendResourceSpans(td pdata.Traces, version string, spanAttrs map[string]string) {
+	rss := td.ResourceSpans()
+	rss.Resize(rss.Len() + 1)
+	rs := rss.At(rss.Len() - 1)
+	rs.Resource().InitEmpty()
+	rs.Resource().Attributes().InsertString("net.peer.ip", "10.0.0.1")
+	if version != "" {
+		rs.Resource().Attributes().InsertString(defaultVersionAttribute, version)
+	}
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(1)
+	for k, v := range spanAttrs {
+		spans.At(0).Attributes().InsertString(k, v)
+	}
+}
+
+func attributesToMap(attrs pdata.AttributeMap) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = v.StringVal()
+	})
+	return m
+}
+
+func TestConsumeTracesUpgrade(t *testing.T) {
+	next := &exportertest.SinkTraceExporter{}
+	stp := newProcessor(t, "1.2.0", "1.0.0")
+	stp.nextTraceConsumer = next
+
+	td := pdata.NewTraces()
+	appendResourceSpans(td, "", map[string]string{"http.url": "http://example.com", "http.method": "GET"})
+	appendResourceSpans(td, "1.1.0", map[string]string{"http.url": "http://example.com", "url.full": "http://example.com/a"})
+	appendResourceSpans(td, "invalid", map[string]string{"http.url": "http://example.com"})
+	require.NoError(t, stp.ConsumeTraces(context.Background(), td))
+
+	rss := next.AllTraces()[0].ResourceSpans()
+	expected := []struct {
+		resource map[string]string
+		span     map[string]string
+	}{
+		{
+			resource: map[string]string{"client.address": "10.0.0.1", defaultVersionAttribute: "1.2.0"},
+			span:     map[string]string{"url.full": "http://example.com", "http.request.method": "GET"},
+		},
+		{
+			// http.url was already renamed in 1.1.0
+			resource: map[string]string{"client.address": "10.0.0.1", defaultVersionAttribute: "1.2.0"},
+			span:     map[string]string{"http.url": "http://example.com", "url.full": "http://example.com/a"},
+		},
+		{
+			resource: map[string]string{"net.peer.ip": "10.0.0.1", defaultVersionAttribute: "invalid"},
+			span:     map[string]string{"http.url": "http://example.com"},
+		},
+	}
+	require.Equal(t, len(expected), rss.Len())
+	for i, e := range expected {
+		assert.Equal(t, e.resource, attributesToMap(rss.At(i).Resource().Attributes()))
+		assert.Equal(t, e.span, attributesToMap(rss.At(i).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()))
+	}
+}
+
+func TestConsumeLogsDowngrade(t *testing.T) {
+	next := &exportertest.SinkLogsExporter{}
+	stp := newProcessor(t, "1.0.0", "")
+	stp.nextLogsConsumer = next
+
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(2)
+	for i := 0; i < rls.Len(); i++ {
+		rls.At(i).Resource().InitEmpty()
+		rls.At(i).InstrumentationLibraryLogs().Resize(1)
+		rls.At(i).InstrumentationLibraryLogs().At(0).Logs().Resize(1)
+		rls.At(i).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes().InsertString("url.full", "http://example.com")
+	}
+	rls.At(0).Resource().Attributes().InsertString(defaultVersionAttribute, "1.2.0")
+	require.NoError(t, stp.ConsumeLogs(context.Background(), ld))
+
+	rls = next.AllLogs()[0].ResourceLogs()
+	assert.Equal(t, map[string]string{defaultVersionAttribute: "1.0.0"}, attributesToMap(rls.At(0).Resource().Attributes()))
+	assert.Equal(t, map[string]string{"http.url": "http://example.com"}, attributesToMap(rls.At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()))
+	// no version and no default version
+	assert.Equal(t, map[string]string{}, attributesToMap(rls.At(1).Resource().Attributes()))
+	assert.Equal(t, map[string]string{"url.full": "http://example.com"}, attributesToMap(rls.At(1).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()))
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	next := &exportertest.SinkMetricsExporter{}
+	stp := newProcessor(t, "1.2.0", "1.0.0")
+	stp.nextMetricsConsumer = next
+
+	mds := []consumerdata.MetricsData{
+		{
+			Metrics: []*metricspb.Metric{
+				{
+					MetricDescriptor: &metricspb.MetricDescriptor{
+						Name:      "http.server.duration",
+						LabelKeys: []*metricspb.LabelKey{{Key: "http.method"}, {Key: "net.peer.ip"}, {Key: "client.address"}},
+					},
+				},
+			},
+		},
+		{
+			Resource: &resourcepb.Resource{Labels: map[string]string{defaultVersionAttribute: "1.2.0", "net.peer.ip": "10.0.0.1"}},
+			Metrics: []*metricspb.Metric{
+				{MetricDescriptor: &metricspb.MetricDescriptor{Name: "http.server.duration"}},
+			},
+		},
+	}
+	require.NoError(t, stp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData(mds)))
+
+	got := pdatautil.MetricsToMetricsData(next.AllMetrics()[0])
+	require.Len(t, got, 2)
+	assert.Equal(t, map[string]string{defaultVersionAttribute: "1.2.0"}, got[0].Resource.Labels)
+	desc := got[0].Metrics[0].MetricDescriptor
+	assert.Equal(t, "http.server.request.duration", desc.Name)
+	// net.peer.ip isn't renamed as client.address is already a label key
+	assert.Equal(t, []*metricspb.LabelKey{{Key: "http.request.method"}, {Key: "net.peer.ip"}, {Key: "client.address"}}, desc.LabelKeys)
+
+	assert.Equal(t, map[string]string{defaultVersionAttribute: "1.2.0", "net.peer.ip": "10.0.0.1"}, got[1].Resource.Labels)
+	assert.Equal(t, "http.server.duration", got[1].Metrics[0].MetricDescriptor.Name)
+}
@@ -0,0 +1,176 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schematranslationprocessor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// versionNumber is a semantic version: major, minor and patch numbers.
+type versionNumber [3]int
+
+// parseVersion parses a version such as 1.2.0, the omitted minor and patch
+// numbers being 0.
+func parseVersion(s string) (versionNumber, error) {
+	var v versionNumber
+	parts := strings.Split(s, ".")
+	if len(parts) > len(v) {
+		return v, fmt.Errorf("invalid version %q", s)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid version %q", s)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func (v versionNumber) less(other versionNumber) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+	return false
+}
+
+// schemaFile is the content of a schema file, e.g.
+//
+//	versions:
+//	  "1.1.0":
+//	    rename_attributes:
+//	      http.url: url.full
+//	    rename_metrics:
+//	      http.server.duration: http.server.request.duration
+type schemaFile struct {
+	Versions map[string]renames `yaml:"versions"`
+}
+
+// renames are the attributes and metrics renamed by a version.
+type renames struct {
+	Attributes map[string]string `yaml:"rename_attributes"`
+	Metrics    map[string]string `yaml:"rename_metrics"`
+}
+
+func (r renames) inverted() renames {
+	return renames{Attributes: invert(r.Attributes), Metrics: invert(r.Metrics)}
+}
+
+func invert(m map[string]string) map[string]string {
+	inverted := make(map[string]string, len(m))
+	for k, v := range m {
+		inverted[v] = k
+	}
+	return inverted
+}
+
+// schemaVersion is a version of the schema, with the renames upgrading the
+// telemetry from the previous version and the ones downgrading it back.
+type schemaVersion struct {
+	number    versionNumber
+	upgrade   renames
+	downgrade renames
+}
+
+// schema is the list of the versions of the schema, in ascending order.
+type schema struct {
+	versions []schemaVersion
+}
+
+// loadSchema reads and merges the schema files.
+func loadSchema(files []string) (*schema, error) {
+	merged := make(map[versionNumber]renames)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read schema file: %w", err)
+		}
+		var sf schemaFile
+		if err := yaml.UnmarshalStrict(data, &sf); err != nil {
+			return nil, fmt.Errorf("failed to parse schema file %s: %w", file, err)
+		}
+
+		for version, r := range sf.Versions {
+			number, err := parseVersion(version)
+			if err != nil {
+				return nil, fmt.Errorf("schema file %s: %w", file, err)
+			}
+			m, ok := merged[number]
+			if !ok {
+				m = renames{Attributes: map[string]string{}, Metrics: map[string]string{}}
+				merged[number] = m
+			}
+			if err := mergeRenames(m.Attributes, r.Attributes); err != nil {
+				return nil, fmt.Errorf("schema file %s, version %s: %w", file, version, err)
+			}
+			if err := mergeRenames(m.Metrics, r.Metrics); err != nil {
+				return nil, fmt.Errorf("schema file %s, version %s: %w", file, version, err)
+			}
+		}
+	}
+
+	s := &schema{versions: make([]schemaVersion, 0, len(merged))}
+	for number, r := range merged {
+		if len(invert(r.Attributes)) != len(r.Attributes) || len(invert(r.Metrics)) != len(r.Metrics) {
+			return nil, fmt.Errorf("several names are renamed to the same name in version %d.%d.%d", number[0], number[1], number[2])
+		}
+		s.versions = append(s.versions, schemaVersion{number: number, upgrade: r, downgrade: r.inverted()})
+	}
+	sort.Slice(s.versions, func(i, j int) bool {
+		return s.versions[i].number.less(s.versions[j].number)
+	})
+	return s, nil
+}
+
+func mergeRenames(to, from map[string]string) error {
+	for k, v := range from {
+		if prev, ok := to[k]; ok && prev != v {
+			return fmt.Errorf("%s is renamed to both %s and %s", k, prev, v)
+		}
+		to[k] = v
+	}
+	return nil
+}
+
+// translation returns the successive renames translating the telemetry from a
+// version to another. Upgrading applies the renames of the versions after the
+// source version up to the target version, downgrading reverts the renames of
+// the versions after the target version up to the source version.
+func (s *schema) translation(from, to versionNumber) []renames {
+	var steps []renames
+	if from.less(to) {
+		for _, v := range s.versions {
+			if from.less(v.number) && !to.less(v.number) {
+				steps = append(steps, v.upgrade)
+			}
+		}
+	} else if to.less(from) {
+		for i := len(s.versions) - 1; i >= 0; i-- {
+			v := s.versions[i]
+			if to.less(v.number) && !from.less(v.number) {
+				steps = append(steps, v.downgrade)
+			}
+		}
+	}
+	return steps
+}
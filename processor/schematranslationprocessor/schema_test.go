@@ -0,0 +1,67 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schematranslationprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := parseVersion("1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, versionNumber{1, 2, 3}, v)
+
+	v, err = parseVersion("2")
+	require.NoError(t, err)
+	assert.Equal(t, versionNumber{2, 0, 0}, v)
+
+	for _, invalid := range []string{"", "1.2.3.4", "1.x", "-1"} {
+		_, err := parseVersion(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestTranslation(t *testing.T) {
+	s, err := loadSchema([]string{path.Join(".", "testdata", "schema.yaml")})
+	require.NoError(t, err)
+	require.Len(t, s.versions, 2)
+
+	v1 := versionNumber{1, 0, 0}
+	v11 := versionNumber{1, 1, 0}
+	v12 := versionNumber{1, 2, 0}
+
+	assert.Empty(t, s.translation(v12, v12))
+
+	assert.Equal(t, []renames{s.versions[0].upgrade, s.versions[1].upgrade}, s.translation(v1, v12))
+	assert.Equal(t, []renames{s.versions[1].upgrade}, s.translation(v11, v12))
+
+	steps := s.translation(v12, v1)
+	require.Len(t, steps, 2)
+	assert.Equal(t, map[string]string{"client.address": "net.peer.ip"}, steps[0].Attributes)
+	assert.Equal(t, map[string]string{"http.server.request.duration": "http.server.duration"}, steps[0].Metrics)
+	assert.Equal(t, map[string]string{"url.full": "http.url", "http.request.method": "http.method"}, steps[1].Attributes)
+}
+
+func TestLoadSchemaErrors(t *testing.T) {
+	_, err := loadSchema([]string{path.Join(".", "testdata", "missing.yaml")})
+	assert.Error(t, err)
+
+	_, err = loadSchema([]string{path.Join(".", "testdata", "schema.yaml"), path.Join(".", "testdata", "schema_conflict.yaml")})
+	assert.EqualError(t, err, "schema file testdata/schema_conflict.yaml, version 1.1.0: http.url is renamed to both url.full and http.target")
+}
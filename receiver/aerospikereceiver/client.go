@@ -0,0 +1,140 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospikereceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// infoVersion and infoType identify the info messages of the Aerospike
+	// wire protocol, whose 8 bytes header holds the version, the type and
+	// the size of the body on 6 bytes.
+	infoVersion = 2
+	infoType    = 1
+
+	// maxInfoSize bounds the size of the info responses.
+	maxInfoSize = 64 * 1024 * 1024
+)
+
+// infoClient sends info commands to Aerospike nodes.
+type infoClient struct {
+	timeout time.Duration
+}
+
+func newInfoClient(timeout time.Duration) *infoClient {
+	return &infoClient{timeout: timeout}
+}
+
+// info sends the commands to the node at endpoint and returns the responses
+// keyed by command.
+func (c *infoClient) info(ctx context.Context, endpoint string, commands ...string) (map[string]string, error) {
+	d := net.Dialer{Timeout: c.timeout}
+	conn, err := d.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.Write(encodeInfoRequest(commands)); err != nil {
+		return nil, fmt.Errorf("failed to send info request to %s: %w", endpoint, err)
+	}
+	resp, err := readInfoResponse(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read info response from %s: %w", endpoint, err)
+	}
+	return resp, nil
+}
+
+// encodeInfoRequest encodes the commands, one per line, in an info message.
+func encodeInfoRequest(commands []string) []byte {
+	body := strings.Join(commands, "\n") + "\n"
+	buf := make([]byte, 8+len(body))
+	binary.BigEndian.PutUint64(buf, infoVersion<<56|infoType<<48|uint64(len(body)))
+	copy(buf[8:], body)
+	return buf
+}
+
+// readInfoResponse reads an info message and parses its body.
+func readInfoResponse(r io.Reader) (map[string]string, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	h := binary.BigEndian.Uint64(header[:])
+	if version, typ := h>>56, h>>48&0xff; version != infoVersion || typ != infoType {
+		return nil, fmt.Errorf("unexpected message version %d and type %d", version, typ)
+	}
+	size := h & (1<<48 - 1)
+	if size > maxInfoSize {
+		return nil, fmt.Errorf("info response of %d bytes exceeds the limit of %d bytes", size, maxInfoSize)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	return parseInfoResponse(string(body)), nil
+}
+
+// parseInfoResponse parses the body of an info response, made of a
+// <command>\t<value> line per command.
+func parseInfoResponse(body string) map[string]string {
+	resp := make(map[string]string)
+	for _, line := range strings.Split(body, "\n") {
+		if line == "" {
+			continue
+		}
+		i := strings.IndexByte(line, '\t')
+		if i < 0 {
+			resp[line] = ""
+			continue
+		}
+		resp[line[:i]] = line[i+1:]
+	}
+	return resp
+}
+
+// parseStats parses statistics in the <name>=<value>;<name>=<value> format.
+func parseStats(s string) map[string]string {
+	stats := make(map[string]string)
+	for _, stat := range strings.Split(s, ";") {
+		i := strings.IndexByte(stat, '=')
+		if i <= 0 {
+			continue
+		}
+		stats[stat[:i]] = stat[i+1:]
+	}
+	return stats
+}
+
+// parseList parses a list in the <item>;<item> format.
+func parseList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ";") {
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
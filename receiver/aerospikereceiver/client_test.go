@@ -0,0 +1,110 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospikereceiver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestNode starts a fake Aerospike node answering the info commands with
+// responses and returns its endpoint.
+func newTestNode(t *testing.T, responses map[string]string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveInfo(conn, responses)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveInfo(conn net.Conn, responses map[string]string) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return
+	}
+	body := make([]byte, binary.BigEndian.Uint64(header[:])&(1<<48-1))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return
+	}
+
+	var resp strings.Builder
+	for _, command := range strings.Split(strings.TrimSuffix(string(body), "\n"), "\n") {
+		if value, ok := responses[command]; ok {
+			resp.WriteString(command + "\t" + value + "\n")
+		}
+	}
+	conn.Write(encodeInfoRequest([]string{strings.TrimSuffix(resp.String(), "\n")}))
+}
+
+func TestInfo(t *testing.T) {
+	endpoint := newTestNode(t, map[string]string{
+		"node":       "BB9020011AC4202",
+		"namespaces": "test;bar",
+	})
+
+	resp, err := newInfoClient(5*time.Second).info(context.Background(), endpoint, "node", "namespaces", "unknown")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"node": "BB9020011AC4202", "namespaces": "test;bar"}, resp)
+}
+
+func TestInfoError(t *testing.T) {
+	_, err := newInfoClient(time.Second).info(context.Background(), "127.0.0.1:1", "node")
+	assert.Error(t, err)
+}
+
+func TestEncodeInfoRequest(t *testing.T) {
+	assert.Equal(t, []byte("\x02\x01\x00\x00\x00\x00\x00\x10node\nstatistics\n"), encodeInfoRequest([]string{"node", "statistics"}))
+}
+
+func TestReadInfoResponse(t *testing.T) {
+	resp, err := readInfoResponse(bytes.NewReader([]byte("\x02\x01\x00\x00\x00\x00\x00\x12node\tA1\nservices\t\n")))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"node": "A1", "services": ""}, resp)
+
+	_, err = readInfoResponse(bytes.NewReader([]byte("\x02\x03\x00\x00\x00\x00\x00\x00")))
+	assert.Error(t, err)
+
+	_, err = readInfoResponse(bytes.NewReader([]byte("\x02\x01\x00\x00\x00\x00\x00\x12node")))
+	assert.Error(t, err)
+}
+
+func TestParseStats(t *testing.T) {
+	assert.Equal(t,
+		map[string]string{"cluster_size": "3", "uptime": "1200", "paxos_principal": "BB9"},
+		parseStats("cluster_size=3;uptime=1200;paxos_principal=BB9;invalid;=1"))
+	assert.Equal(t, []string{"test", "bar"}, parseList("test;bar;"))
+	assert.Nil(t, parseList(""))
+}
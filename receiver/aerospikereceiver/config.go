@@ -0,0 +1,58 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospikereceiver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the aerospike receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Endpoint is the host:port of the info service of an Aerospike node.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// CollectClusterMetrics enables the collection of the statistics of the
+	// peers of the node, discovered with the services info command.
+	CollectClusterMetrics bool `mapstructure:"collect_cluster_metrics"`
+
+	// CollectionInterval is the interval at which metrics should be collected.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+
+	// Timeout is the timeout of the info requests to a node.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+func (c *Config) validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	if _, _, err := net.SplitHostPort(c.Endpoint); err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", c.Endpoint, err)
+	}
+	if c.CollectionInterval <= 0 {
+		return errors.New("collection_interval must be positive")
+	}
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospikereceiver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+const (
+	nodeLabel      = "aerospike.node.name"
+	namespaceLabel = "aerospike.namespace"
+	typeLabel      = "type"
+	operationLabel = "operation"
+	componentLabel = "component"
+	resultLabel    = "result"
+	thresholdLabel = "threshold"
+)
+
+// nodeInfo holds the statistics of a node.
+type nodeInfo struct {
+	name  string
+	stats map[string]string
+	// namespaces holds the statistics of the namespaces, keyed by name.
+	namespaces map[string]map[string]string
+	// latencies is the response of the latencies: command.
+	latencies string
+}
+
+// statSpec is a statistic reported by a metric, identified by the values of
+// the labels of the metric distinguishing its statistics, if any.
+type statSpec struct {
+	name       string
+	attrValues []string
+}
+
+// metricSpec describes a metric and the statistics it is built from.
+type metricSpec struct {
+	name        string
+	description string
+	unit        string
+	metricType  metricspb.MetricDescriptor_Type
+	// attrKeys are the keys of the labels distinguishing the statistics of
+	// the metric, empty if the metric is built from a single statistic.
+	attrKeys []string
+	stats    []statSpec
+}
+
+var nodeMetricSpecs = []metricSpec{
+	{
+		name:        "aerospike.node.connection.open",
+		description: "Current number of open connections to the node.",
+		unit:        "{connections}",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		attrKeys:    []string{typeLabel},
+		stats: []statSpec{
+			{name: "client_connections", attrValues: []string{"client"}},
+			{name: "fabric_connections", attrValues: []string{"fabric"}},
+			{name: "heartbeat_connections", attrValues: []string{"heartbeat"}},
+		},
+	},
+	{
+		name:        "aerospike.node.connection.count",
+		description: "Total number of client connections opened and closed.",
+		unit:        "{connections}",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		attrKeys:    []string{operationLabel},
+		stats: []statSpec{
+			{name: "client_connections_opened", attrValues: []string{"open"}},
+			{name: "client_connections_closed", attrValues: []string{"close"}},
+		},
+	},
+	{
+		name:        "aerospike.node.memory.free",
+		description: "Percentage of the memory of the node that is free.",
+		unit:        "%",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		stats:       []statSpec{{name: "system_free_mem_pct"}},
+	},
+	{
+		name:        "aerospike.node.cluster.size",
+		description: "Number of nodes in the cluster, as seen by the node.",
+		unit:        "{nodes}",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		stats:       []statSpec{{name: "cluster_size"}},
+	},
+	{
+		name:        "aerospike.node.uptime",
+		description: "The amount of time the node has been running.",
+		unit:        "s",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		stats:       []statSpec{{name: "uptime"}},
+	},
+}
+
+var namespaceMetricSpecs = []metricSpec{
+	{
+		name:        "aerospike.namespace.memory.usage",
+		description: "Memory used by the namespace.",
+		unit:        "By",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		attrKeys:    []string{componentLabel},
+		stats: []statSpec{
+			{name: "memory_used_data_bytes", attrValues: []string{"data"}},
+			{name: "memory_used_index_bytes", attrValues: []string{"index"}},
+			{name: "memory_used_sindex_bytes", attrValues: []string{"secondary_index"}},
+			{name: "memory_used_set_index_bytes", attrValues: []string{"set_index"}},
+		},
+	},
+	{
+		name:        "aerospike.namespace.memory.free",
+		description: "Percentage of the memory of the namespace that is free.",
+		unit:        "%",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		stats:       []statSpec{{name: "memory_free_pct"}},
+	},
+	{
+		name:        "aerospike.namespace.disk.available",
+		description: "Minimum percentage of contiguous free space of the devices of the namespace.",
+		unit:        "%",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		stats:       []statSpec{{name: "device_available_pct"}},
+	},
+	{
+		name:        "aerospike.namespace.objects",
+		description: "Number of records in the namespace, excluding the replicas.",
+		unit:        "{records}",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		stats:       []statSpec{{name: "master_objects"}},
+	},
+	{
+		name:        "aerospike.namespace.transaction.count",
+		description: "Total number of client transactions of the namespace.",
+		unit:        "{transactions}",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		attrKeys:    []string{typeLabel, resultLabel},
+		stats: []statSpec{
+			{name: "client_read_success", attrValues: []string{"read", "success"}},
+			{name: "client_read_not_found", attrValues: []string{"read", "not_found"}},
+			{name: "client_read_error", attrValues: []string{"read", "error"}},
+			{name: "client_read_timeout", attrValues: []string{"read", "timeout"}},
+			{name: "client_write_success", attrValues: []string{"write", "success"}},
+			{name: "client_write_error", attrValues: []string{"write", "error"}},
+			{name: "client_write_timeout", attrValues: []string{"write", "timeout"}},
+			{name: "client_delete_success", attrValues: []string{"delete", "success"}},
+			{name: "client_delete_not_found", attrValues: []string{"delete", "not_found"}},
+			{name: "client_delete_error", attrValues: []string{"delete", "error"}},
+			{name: "client_delete_timeout", attrValues: []string{"delete", "timeout"}},
+			{name: "client_udf_complete", attrValues: []string{"udf", "success"}},
+			{name: "client_udf_error", attrValues: []string{"udf", "error"}},
+			{name: "client_udf_timeout", attrValues: []string{"udf", "timeout"}},
+		},
+	},
+	{
+		name:        "aerospike.namespace.scan.count",
+		description: "Total number of scans of the namespace.",
+		unit:        "{scans}",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		attrKeys:    []string{typeLabel, resultLabel},
+		stats: []statSpec{
+			{name: "scan_basic_complete", attrValues: []string{"basic", "complete"}},
+			{name: "scan_basic_error", attrValues: []string{"basic", "error"}},
+			{name: "scan_basic_abort", attrValues: []string{"basic", "abort"}},
+			{name: "scan_aggr_complete", attrValues: []string{"aggregation", "complete"}},
+			{name: "scan_aggr_error", attrValues: []string{"aggregation", "error"}},
+			{name: "scan_aggr_abort", attrValues: []string{"aggregation", "abort"}},
+			{name: "scan_udf_bg_complete", attrValues: []string{"udf_background", "complete"}},
+			{name: "scan_udf_bg_error", attrValues: []string{"udf_background", "error"}},
+			{name: "scan_udf_bg_abort", attrValues: []string{"udf_background", "abort"}},
+		},
+	},
+}
+
+// buildMetrics converts the statistics of a node to metrics. Cumulative
+// metrics start at startTime.
+func buildMetrics(node *nodeInfo, startTime, ts *timestamp.Timestamp) []*metricspb.Metric {
+	var metrics []*metricspb.Metric
+	for i := range nodeMetricSpecs {
+		spec := &nodeMetricSpecs[i]
+		if m := spec.toMetric([]string{nodeLabel}, []statsSource{{[]string{node.name}, node.stats}}, startTime, ts); m != nil {
+			metrics = append(metrics, m)
+		}
+	}
+
+	names := make([]string, 0, len(node.namespaces))
+	for name := range node.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sources := make([]statsSource, 0, len(names))
+	for _, name := range names {
+		sources = append(sources, statsSource{[]string{node.name, name}, node.namespaces[name]})
+	}
+	for i := range namespaceMetricSpecs {
+		spec := &namespaceMetricSpecs[i]
+		if m := spec.toMetric([]string{nodeLabel, namespaceLabel}, sources, startTime, ts); m != nil {
+			metrics = append(metrics, m)
+		}
+	}
+
+	return append(metrics, latencyMetrics(node.name, node.latencies, ts)...)
+}
+
+// statsSource holds statistics and the values of the labels identifying
+// them.
+type statsSource struct {
+	labelValues []string
+	stats       map[string]string
+}
+
+func (spec *metricSpec) toMetric(keys []string, sources []statsSource, startTime, ts *timestamp.Timestamp) *metricspb.Metric {
+	var timeseries []*metricspb.TimeSeries
+	for _, source := range sources {
+		for _, s := range spec.stats {
+			value, err := strconv.ParseInt(source.stats[s.name], 10, 64)
+			if err != nil {
+				continue
+			}
+			series := &metricspb.TimeSeries{
+				LabelValues: labelValues(append(append([]string{}, source.labelValues...), s.attrValues...)),
+				Points: []*metricspb.Point{{
+					Timestamp: ts,
+					Value:     &metricspb.Point_Int64Value{Int64Value: value},
+				}},
+			}
+			if spec.metricType == metricspb.MetricDescriptor_CUMULATIVE_INT64 {
+				series.StartTimestamp = startTime
+			}
+			timeseries = append(timeseries, series)
+		}
+	}
+	if len(timeseries) == 0 {
+		return nil
+	}
+
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        spec.name,
+			Description: spec.description,
+			Unit:        spec.unit,
+			Type:        spec.metricType,
+			LabelKeys:   labelKeys(append(append([]string{}, keys...), spec.attrKeys...)),
+		},
+		Timeseries: timeseries,
+	}
+}
+
+// latencyMetrics converts the response of the latencies: command to the
+// rate of the operations of the namespaces, and the percentage of these
+// operations exceeding each threshold.
+//
+// The response holds a histogram per namespace and operation, e.g.
+// {test}-read:msec,1.5,2.00,0.50,0.00 for 1.5 reads per second of the test
+// namespace, 2% of them exceeding 1ms, 0.5% exceeding 8ms and none 64ms.
+// Every threshold is 8 times the previous one, starting at 1 unit.
+func latencyMetrics(node, latencies string, ts *timestamp.Timestamp) []*metricspb.Metric {
+	var rates, exceeded []*metricspb.TimeSeries
+	for _, histogram := range parseList(latencies) {
+		namespace, operation, unit, values, ok := parseLatencyHistogram(histogram)
+		if !ok {
+			continue
+		}
+		rates = append(rates, &metricspb.TimeSeries{
+			LabelValues: labelValues([]string{node, namespace, operation}),
+			Points:      []*metricspb.Point{doublePoint(values[0], ts)},
+		})
+		for i, v := range values[1:] {
+			threshold := fmt.Sprintf("%d%s", 1<<(3*i), unit)
+			exceeded = append(exceeded, &metricspb.TimeSeries{
+				LabelValues: labelValues([]string{node, namespace, operation, threshold}),
+				Points:      []*metricspb.Point{doublePoint(v, ts)},
+			})
+		}
+	}
+
+	var metrics []*metricspb.Metric
+	if len(rates) > 0 {
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: &metricspb.MetricDescriptor{
+				Name:        "aerospike.namespace.latency.rate",
+				Description: "Rate of the operations of the namespace.",
+				Unit:        "{operations}/s",
+				Type:        metricspb.MetricDescriptor_GAUGE_DOUBLE,
+				LabelKeys:   labelKeys([]string{nodeLabel, namespaceLabel, operationLabel}),
+			},
+			Timeseries: rates,
+		})
+	}
+	if len(exceeded) > 0 {
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: &metricspb.MetricDescriptor{
+				Name:        "aerospike.namespace.latency.exceeded",
+				Description: "Percentage of the operations of the namespace whose latency exceeds the threshold.",
+				Unit:        "%",
+				Type:        metricspb.MetricDescriptor_GAUGE_DOUBLE,
+				LabelKeys:   labelKeys([]string{nodeLabel, namespaceLabel, operationLabel, thresholdLabel}),
+			},
+			Timeseries: exceeded,
+		})
+	}
+	return metrics
+}
+
+// parseLatencyHistogram parses a histogram of the latencies: command. The
+// histograms that are not of a namespace, or that have no data, are
+// skipped.
+func parseLatencyHistogram(histogram string) (namespace, operation, unit string, values []float64, ok bool) {
+	i := strings.IndexByte(histogram, ':')
+	if i < 0 || !strings.HasPrefix(histogram, "{") {
+		return "", "", "", nil, false
+	}
+	name, data := histogram[1:i], strings.Split(histogram[i+1:], ",")
+	j := strings.Index(name, "}-")
+	if j <= 0 || j+2 == len(name) || len(data) < 2 {
+		return "", "", "", nil, false
+	}
+	namespace, operation = name[:j], name[j+2:]
+
+	switch data[0] {
+	case "msec":
+		unit = "ms"
+	case "usec":
+		unit = "us"
+	default:
+		return "", "", "", nil, false
+	}
+	values = make([]float64, 0, len(data)-1)
+	for _, d := range data[1:] {
+		v, err := strconv.ParseFloat(d, 64)
+		if err != nil {
+			return "", "", "", nil, false
+		}
+		values = append(values, v)
+	}
+	return namespace, operation, unit, values, true
+}
+
+func labelKeys(keys []string) []*metricspb.LabelKey {
+	labelKeys := make([]*metricspb.LabelKey, 0, len(keys))
+	for _, key := range keys {
+		labelKeys = append(labelKeys, &metricspb.LabelKey{Key: key})
+	}
+	return labelKeys
+}
+
+func labelValues(values []string) []*metricspb.LabelValue {
+	labelValues := make([]*metricspb.LabelValue, 0, len(values))
+	for _, v := range values {
+		labelValues = append(labelValues, &metricspb.LabelValue{Value: v, HasValue: true})
+	}
+	return labelValues
+}
+
+func doublePoint(value float64, ts *timestamp.Timestamp) *metricspb.Point {
+	return &metricspb.Point{
+		Timestamp: ts,
+		Value:     &metricspb.Point_DoubleValue{DoubleValue: value},
+	}
+}
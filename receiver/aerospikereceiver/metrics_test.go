@@ -0,0 +1,100 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospikereceiver
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMetrics(t *testing.T) {
+	startTime := ptypes.TimestampNow()
+	ts := ptypes.TimestampNow()
+	node := &nodeInfo{
+		name: "BB9020011AC4202",
+		stats: map[string]string{
+			"client_connections":    "10",
+			"heartbeat_connections": "2",
+			"cluster_size":          "3",
+			"uptime":                "invalid",
+		},
+		namespaces: map[string]map[string]string{
+			"test": {"memory_used_data_bytes": "1024", "client_read_success": "7"},
+			"bar":  {"memory_used_data_bytes": "2048"},
+		},
+		latencies: "batch-index:;{test}-read:msec,1.5,2.00,0.50,0.00;{test}-write:msec,0.0,0.00,0.00,0.00;{bar}-udf:",
+	}
+
+	metrics := buildMetrics(node, startTime, ts)
+	byName := make(map[string]*metricspb.Metric)
+	for _, m := range metrics {
+		byName[m.MetricDescriptor.Name] = m
+	}
+	assert.Len(t, byName, 6)
+
+	open := byName["aerospike.node.connection.open"]
+	require.NotNil(t, open)
+	require.Len(t, open.Timeseries, 2)
+	assert.Equal(t, []*metricspb.LabelKey{{Key: nodeLabel}, {Key: typeLabel}}, open.MetricDescriptor.LabelKeys)
+	assert.Equal(t, "BB9020011AC4202", open.Timeseries[0].LabelValues[0].Value)
+	assert.Equal(t, "client", open.Timeseries[0].LabelValues[1].Value)
+	assert.Equal(t, int64(10), open.Timeseries[0].Points[0].GetInt64Value())
+	assert.Nil(t, open.Timeseries[0].StartTimestamp)
+
+	assert.NotNil(t, byName["aerospike.node.cluster.size"])
+	assert.Nil(t, byName["aerospike.node.uptime"])
+
+	memory := byName["aerospike.namespace.memory.usage"]
+	require.NotNil(t, memory)
+	require.Len(t, memory.Timeseries, 2)
+	assert.Equal(t, "bar", memory.Timeseries[0].LabelValues[1].Value)
+	assert.Equal(t, "data", memory.Timeseries[0].LabelValues[2].Value)
+	assert.Equal(t, int64(2048), memory.Timeseries[0].Points[0].GetInt64Value())
+
+	transactions := byName["aerospike.namespace.transaction.count"]
+	require.NotNil(t, transactions)
+	require.Len(t, transactions.Timeseries, 1)
+	assert.Equal(t, startTime, transactions.Timeseries[0].StartTimestamp)
+	assert.Len(t, transactions.MetricDescriptor.LabelKeys, 4)
+
+	rate := byName["aerospike.namespace.latency.rate"]
+	require.NotNil(t, rate)
+	require.Len(t, rate.Timeseries, 2)
+	assert.Equal(t, 1.5, rate.Timeseries[0].Points[0].GetDoubleValue())
+
+	exceeded := byName["aerospike.namespace.latency.exceeded"]
+	require.NotNil(t, exceeded)
+	require.Len(t, exceeded.Timeseries, 6)
+	assert.Equal(t, "8ms", exceeded.Timeseries[1].LabelValues[3].Value)
+	assert.Equal(t, 0.5, exceeded.Timeseries[1].Points[0].GetDoubleValue())
+}
+
+func TestParseLatencyHistogram(t *testing.T) {
+	namespace, operation, unit, values, ok := parseLatencyHistogram("{test}-batch-sub-read:usec,10.0,5.00")
+	require.True(t, ok)
+	assert.Equal(t, "test", namespace)
+	assert.Equal(t, "batch-sub-read", operation)
+	assert.Equal(t, "us", unit)
+	assert.Equal(t, []float64{10, 5}, values)
+
+	for _, histogram := range []string{"batch-index:", "{test}-read:", "{test}-read:sec,1.0", "{test}-read:msec,x", "{test}:msec,1.0"} {
+		_, _, _, _, ok := parseLatencyHistogram(histogram)
+		assert.False(t, ok, histogram)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospikereceiver
+
+import (
+	"context"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "tcp"
+
+const (
+	nodeCommand       = "node"
+	statisticsCommand = "statistics"
+	namespacesCommand = "namespaces"
+	latenciesCommand  = "latencies:"
+	servicesCommand   = "services"
+	namespacePrefix   = "namespace/"
+)
+
+var _ component.MetricsReceiver = (*aerospikeReceiver)(nil)
+
+// aerospikeReceiver implements the component.MetricsReceiver for Aerospike.
+type aerospikeReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	client       *infoClient
+	startTime    *timestamp.Timestamp
+	cancel       context.CancelFunc
+}
+
+// newAerospikeReceiver creates the aerospike receiver with the given
+// parameters.
+func newAerospikeReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	r := &aerospikeReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		client:       newInfoClient(config.Timeout),
+	}
+	return r, nil
+}
+
+// Start begins polling the Aerospike nodes.
+func (ar *aerospikeReceiver) Start(ctx context.Context, host component.Host) error {
+	ar.startTime = ptypes.TimestampNow()
+	ctx, ar.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, ar.config.Name()))
+	go func() {
+		ticker := time.NewTicker(ar.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := ar.collect(ctx); err != nil {
+					ar.logger.Warn("Failed to collect aerospike metrics", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the aerospike receiver.
+func (ar *aerospikeReceiver) Shutdown(context.Context) error {
+	if ar.cancel != nil {
+		ar.cancel()
+	}
+	return nil
+}
+
+// collect sends the metrics of the configured node and, if enabled, of its
+// peers. The peers that cannot be queried are skipped.
+func (ar *aerospikeReceiver) collect(ctx context.Context) error {
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+
+	node, peers, err := ar.nodeInfo(ctx, ar.config.Endpoint)
+	if err != nil {
+		obsreport.EndMetricsReceiveOp(ctx, typeStr, 0, 0, err)
+		return err
+	}
+	ts := ptypes.TimestampNow()
+	metrics := buildMetrics(node, ar.startTime, ts)
+
+	if ar.config.CollectClusterMetrics {
+		for _, peer := range peers {
+			peerNode, _, err := ar.nodeInfo(ctx, peer)
+			if err != nil {
+				ar.logger.Warn("Failed to collect aerospike metrics of peer", zap.String("peer", peer), zap.Error(err))
+				continue
+			}
+			metrics = append(metrics, buildMetrics(peerNode, ar.startTime, ts)...)
+		}
+	}
+
+	return ar.send(ctx, metrics)
+}
+
+func (ar *aerospikeReceiver) send(ctx context.Context, metrics []*metricspb.Metric) error {
+	if len(metrics) == 0 {
+		obsreport.EndMetricsReceiveOp(ctx, typeStr, 0, 0, nil)
+		return nil
+	}
+	md := consumerdata.MetricsData{Metrics: metrics}
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(md)
+	err := ar.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md}))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+	return err
+}
+
+// nodeInfo returns the statistics of the node at endpoint, and the
+// endpoints of its peers.
+func (ar *aerospikeReceiver) nodeInfo(ctx context.Context, endpoint string) (*nodeInfo, []string, error) {
+	resp, err := ar.client.info(ctx, endpoint, nodeCommand, statisticsCommand, namespacesCommand, latenciesCommand, servicesCommand)
+	if err != nil {
+		return nil, nil, err
+	}
+	node := &nodeInfo{
+		name:       resp[nodeCommand],
+		stats:      parseStats(resp[statisticsCommand]),
+		namespaces: make(map[string]map[string]string),
+		latencies:  resp[latenciesCommand],
+	}
+
+	namespaces := parseList(resp[namespacesCommand])
+	if len(namespaces) > 0 {
+		commands := make([]string, 0, len(namespaces))
+		for _, ns := range namespaces {
+			commands = append(commands, namespacePrefix+ns)
+		}
+		nsResp, err := ar.client.info(ctx, endpoint, commands...)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, ns := range namespaces {
+			node.namespaces[ns] = parseStats(nsResp[namespacePrefix+ns])
+		}
+	}
+	return node, parseList(resp[servicesCommand]), nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospikereceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestReceiver(t *testing.T) {
+	peer := newTestNode(t, map[string]string{
+		"node":       "B2",
+		"statistics": "cluster_size=2",
+	})
+	endpoint := newTestNode(t, map[string]string{
+		"node":           "A1",
+		"statistics":     "cluster_size=2;client_connections=4",
+		"namespaces":     "test",
+		"namespace/test": "master_objects=12",
+		"latencies:":     "{test}-read:msec,3.0,1.00,0.00,0.00",
+		"services":       peer + ";127.0.0.1:1",
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.CollectClusterMetrics = true
+	cfg.CollectionInterval = 100 * time.Millisecond
+	cfg.Timeout = time.Second
+
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newAerospikeReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+
+	ctx := context.Background()
+	require.NoError(t, metricsReceiver.Start(ctx, componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		return sink.MetricsCount() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, metricsReceiver.Shutdown(ctx))
+
+	mds := pdatautil.MetricsToMetricsData(sink.AllMetrics()[0])
+	require.Len(t, mds, 1)
+	nodes := make(map[string]bool)
+	names := make(map[string]bool)
+	for _, m := range mds[0].Metrics {
+		names[m.MetricDescriptor.Name] = true
+		for _, ts := range m.Timeseries {
+			nodes[ts.LabelValues[0].Value] = true
+		}
+	}
+	assert.Equal(t, map[string]bool{"A1": true, "B2": true}, nodes)
+	assert.True(t, names["aerospike.node.connection.open"])
+	assert.True(t, names["aerospike.namespace.objects"])
+	assert.True(t, names["aerospike.namespace.latency.rate"])
+}
+
+func TestCollectError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "127.0.0.1:1"
+	cfg.Timeout = time.Second
+
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newAerospikeReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	assert.Error(t, metricsReceiver.(*aerospikeReceiver).collect(context.Background()))
+	assert.Equal(t, 0, sink.MetricsCount())
+}
@@ -0,0 +1,42 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for aws container insight receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// CollectionInterval is the interval at which metrics should be collected.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+
+	// ContainerOrchestrator is the type of container orchestration service, either eks or ecs.
+	ContainerOrchestrator string `mapstructure:"container_orchestrator"`
+
+	// CAdvisorEndpoint is the URL of the cAdvisor REST API running on the node.
+	CAdvisorEndpoint string `mapstructure:"cadvisor_endpoint"`
+
+	// ClusterName is the name of the EKS or ECS cluster the node belongs to.
+	ClusterName string `mapstructure:"cluster_name"`
+
+	// NodeName is the name of the node, defaults to the HOST_NAME environment
+	// variable or the hostname.
+	NodeName string `mapstructure:"node_name"`
+}
@@ -0,0 +1,67 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestValidConfig(t *testing.T) {
+	err := configcheck.ValidateConfig(createDefaultConfig())
+	require.NoError(t, err)
+}
+
+func TestCreateMetricsReceiver(t *testing.T) {
+	metricsReceiver, err := createMetricsReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		createDefaultConfig(),
+		exportertest.NewNopMetricsExporter(),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+}
+
+func TestCreateMetricsReceiverWithNilConsumer(t *testing.T) {
+	metricsReceiver, err := createMetricsReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		createDefaultConfig(),
+		nil,
+	)
+	require.Nil(t, metricsReceiver)
+	require.Equal(t, err, componenterror.ErrNilNextConsumer)
+}
+
+func TestCreateMetricsReceiverWithInvalidOrchestrator(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ContainerOrchestrator = "nomad"
+	metricsReceiver, err := createMetricsReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		cfg,
+		exportertest.NewNopMetricsExporter(),
+	)
+	require.Nil(t, metricsReceiver)
+	require.Error(t, err)
+}
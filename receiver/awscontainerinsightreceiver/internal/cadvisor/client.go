@@ -0,0 +1,90 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cadvisor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	machinePath       = "/api/v1.3/machine"
+	subcontainersPath = "/api/v1.3/subcontainers/"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// Client retrieves the node capacity and the container stats from cAdvisor.
+type Client interface {
+	MachineInfo(ctx context.Context) (*MachineInfo, error)
+	SubcontainersInfo(ctx context.Context) ([]ContainerInfo, error)
+}
+
+type client struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the cAdvisor REST API served at endpoint.
+func NewClient(endpoint string) Client {
+	return &client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+func (c *client) MachineInfo(ctx context.Context) (*MachineInfo, error) {
+	info := &MachineInfo{}
+	if err := c.get(ctx, machinePath, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (c *client) SubcontainersInfo(ctx context.Context) ([]ContainerInfo, error) {
+	var infos []ContainerInfo
+	if err := c.get(ctx, subcontainersPath, &infos); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+func (c *client) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cadvisor request %s failed with status %d: %s", path, resp.StatusCode, body)
+	}
+
+	return json.Unmarshal(body, v)
+}
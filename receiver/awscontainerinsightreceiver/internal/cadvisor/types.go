@@ -0,0 +1,113 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cadvisor
+
+import "time"
+
+// The types below mirror the subset of the cAdvisor v1.3 REST API used by
+// the receiver, see https://github.com/google/cadvisor/blob/master/info/v1/container.go
+
+// MachineInfo holds the capacity of the node.
+type MachineInfo struct {
+	NumCores       int    `json:"num_cores"`
+	MemoryCapacity uint64 `json:"memory_capacity"`
+}
+
+// ContainerInfo holds the spec and the recent stats of a container.
+type ContainerInfo struct {
+	// Name is the absolute cgroup name of the container, "/" for the root container.
+	Name      string            `json:"name"`
+	Aliases   []string          `json:"aliases,omitempty"`
+	Namespace string            `json:"namespace,omitempty"`
+	Spec      ContainerSpec     `json:"spec,omitempty"`
+	Stats     []*ContainerStats `json:"stats,omitempty"`
+}
+
+// ContainerSpec holds the configuration of a container.
+type ContainerSpec struct {
+	Labels    map[string]string `json:"labels,omitempty"`
+	HasCPU    bool              `json:"has_cpu"`
+	CPU       CPUSpec           `json:"cpu,omitempty"`
+	HasMemory bool              `json:"has_memory"`
+	Memory    MemorySpec        `json:"memory,omitempty"`
+}
+
+// CPUSpec holds the cpu limits of a container.
+type CPUSpec struct {
+	Limit  uint64 `json:"limit"`
+	Quota  uint64 `json:"quota,omitempty"`
+	Period uint64 `json:"period,omitempty"`
+}
+
+// MemorySpec holds the memory limits of a container.
+type MemorySpec struct {
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+// ContainerStats is a sample of the container resource usage.
+type ContainerStats struct {
+	Timestamp  time.Time    `json:"timestamp"`
+	CPU        CPUStats     `json:"cpu,omitempty"`
+	Memory     MemoryStats  `json:"memory,omitempty"`
+	Network    NetworkStats `json:"network,omitempty"`
+	Filesystem []FsStats    `json:"filesystem,omitempty"`
+}
+
+// CPUStats holds the cumulative cpu usage in nanoseconds.
+type CPUStats struct {
+	Usage CPUUsage `json:"usage"`
+}
+
+// CPUUsage holds the cumulative cpu usage in nanoseconds.
+type CPUUsage struct {
+	Total  uint64 `json:"total"`
+	User   uint64 `json:"user"`
+	System uint64 `json:"system"`
+}
+
+// MemoryStats holds the memory usage in bytes.
+type MemoryStats struct {
+	Usage      uint64 `json:"usage"`
+	Cache      uint64 `json:"cache"`
+	RSS        uint64 `json:"rss"`
+	WorkingSet uint64 `json:"working_set"`
+}
+
+// NetworkStats holds the cumulative network usage of every interface.
+type NetworkStats struct {
+	Interfaces []InterfaceStats `json:"interfaces,omitempty"`
+}
+
+// InterfaceStats holds the cumulative network usage of an interface.
+type InterfaceStats struct {
+	Name      string `json:"name"`
+	RxBytes   uint64 `json:"rx_bytes"`
+	RxPackets uint64 `json:"rx_packets"`
+	RxErrors  uint64 `json:"rx_errors"`
+	RxDropped uint64 `json:"rx_dropped"`
+	TxBytes   uint64 `json:"tx_bytes"`
+	TxPackets uint64 `json:"tx_packets"`
+	TxErrors  uint64 `json:"tx_errors"`
+	TxDropped uint64 `json:"tx_dropped"`
+}
+
+// FsStats holds the usage of a filesystem in bytes.
+type FsStats struct {
+	Device    string `json:"device,omitempty"`
+	Type      string `json:"type"`
+	Limit     uint64 `json:"capacity"`
+	Usage     uint64 `json:"usage"`
+	Available uint64 `json:"available"`
+}
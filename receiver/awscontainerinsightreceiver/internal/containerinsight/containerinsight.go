@@ -0,0 +1,137 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package containerinsight converts cAdvisor stats to Container Insights
+// metrics.
+package containerinsight
+
+import (
+	"strings"
+	"time"
+)
+
+// Container orchestration services supported by the receiver.
+const (
+	EKS = "eks"
+	ECS = "ecs"
+)
+
+// Label keys of the Container Insights metrics.
+const (
+	ClusterNameKey          = "ClusterName"
+	NodeNameKey             = "NodeName"
+	NamespaceKey            = "Namespace"
+	PodNameKey              = "PodName"
+	ContainerNameKey        = "ContainerName"
+	TaskDefinitionFamilyKey = "TaskDefinitionFamily"
+	TaskARNKey              = "TaskARN"
+	MetricTypeKey           = "Type"
+)
+
+// Values of the MetricTypeKey label, they also prefix the metric names.
+const (
+	TypeNode      = "Node"
+	TypeInstance  = "Instance"
+	TypePod       = "Pod"
+	TypeTask      = "Task"
+	TypeContainer = "Container"
+)
+
+// Metric name suffixes, the full name is the lower cased type, an
+// underscore and the suffix, e.g. node_cpu_usage_total.
+const (
+	CPUUsageTotal         = "cpu_usage_total"
+	CPUUsageUser          = "cpu_usage_user"
+	CPUUsageSystem        = "cpu_usage_system"
+	CPULimit              = "cpu_limit"
+	CPUUtilization        = "cpu_utilization"
+	MemUsage              = "memory_usage"
+	MemCache              = "memory_cache"
+	MemRss                = "memory_rss"
+	MemWorkingset         = "memory_working_set"
+	MemLimit              = "memory_limit"
+	MemUtilization        = "memory_utilization"
+	NetRxBytes            = "network_rx_bytes"
+	NetTxBytes            = "network_tx_bytes"
+	NetTotalBytes         = "network_total_bytes"
+	NetRxErrors           = "network_rx_errors"
+	NetTxErrors           = "network_tx_errors"
+	NetRxDropped          = "network_rx_dropped"
+	NetTxDropped          = "network_tx_dropped"
+	FSUsage               = "filesystem_usage"
+	FSCapacity            = "filesystem_capacity"
+	FSAvailable           = "filesystem_available"
+	FSUtilization         = "filesystem_utilization"
+	RunningContainerCount = "number_of_running_containers"
+)
+
+// Units of the metrics.
+const (
+	UnitMillicore   = "Millicore"
+	UnitBytes       = "Bytes"
+	UnitBytesPerSec = "Bytes/Second"
+	UnitCountPerSec = "Count/Second"
+	UnitPercent     = "Percent"
+	UnitCount       = "Count"
+)
+
+var unitsBySuffix = map[string]string{
+	CPUUsageTotal:         UnitMillicore,
+	CPUUsageUser:          UnitMillicore,
+	CPUUsageSystem:        UnitMillicore,
+	CPULimit:              UnitMillicore,
+	CPUUtilization:        UnitPercent,
+	MemUsage:              UnitBytes,
+	MemCache:              UnitBytes,
+	MemRss:                UnitBytes,
+	MemWorkingset:         UnitBytes,
+	MemLimit:              UnitBytes,
+	MemUtilization:        UnitPercent,
+	NetRxBytes:            UnitBytesPerSec,
+	NetTxBytes:            UnitBytesPerSec,
+	NetTotalBytes:         UnitBytesPerSec,
+	NetRxErrors:           UnitCountPerSec,
+	NetTxErrors:           UnitCountPerSec,
+	NetRxDropped:          UnitCountPerSec,
+	NetTxDropped:          UnitCountPerSec,
+	FSUsage:               UnitBytes,
+	FSCapacity:            UnitBytes,
+	FSAvailable:           UnitBytes,
+	FSUtilization:         UnitPercent,
+	RunningContainerCount: UnitCount,
+}
+
+// Metric is a set of measurements sharing the same labels.
+type Metric struct {
+	// Labels identify the node, pod, task or container measured.
+	Labels map[string]string
+	// Fields map the full metric names to their values.
+	Fields map[string]float64
+	// Timestamp is the time of the last sample the fields were computed from.
+	Timestamp time.Time
+}
+
+// MetricName returns the name of the metric for the given type and suffix.
+func MetricName(metricType, suffix string) string {
+	return strings.ToLower(metricType) + "_" + suffix
+}
+
+// MetricUnit returns the unit of the metric with the given name.
+func MetricUnit(name string) string {
+	idx := strings.IndexByte(name, '_')
+	if idx < 0 {
+		return ""
+	}
+	return unitsBySuffix[name[idx+1:]]
+}
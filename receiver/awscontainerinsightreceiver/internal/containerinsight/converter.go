@@ -0,0 +1,427 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerinsight
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/cadvisor"
+)
+
+// Labels set by the container runtimes on the cAdvisor container specs.
+const (
+	k8sPodNameLabel       = "io.kubernetes.pod.name"
+	k8sNamespaceLabel     = "io.kubernetes.pod.namespace"
+	k8sContainerNameLabel = "io.kubernetes.container.name"
+	// k8sInfraContainerName is the name of the container holding the
+	// network namespace of a pod.
+	k8sInfraContainerName = "POD"
+
+	ecsContainerNameLabel = "com.amazonaws.ecs.container-name"
+	ecsTaskARNLabel       = "com.amazonaws.ecs.task-arn"
+	ecsTaskFamilyLabel    = "com.amazonaws.ecs.task-definition-family"
+
+	rootContainerName = "/"
+)
+
+// virtualInterfacePrefixes are the prefixes of the host interfaces created
+// for the containers, they are excluded from the node network usage to not
+// count the container traffic twice.
+var virtualInterfacePrefixes = []string{"lo", "veth", "docker", "cali", "eni", "cni", "flannel"}
+
+// NodeInfo identifies the node the stats are collected on.
+type NodeInfo struct {
+	ClusterName  string
+	NodeName     string
+	Orchestrator string
+}
+
+// Converter builds the Container Insights metrics from cAdvisor stats.
+type Converter struct {
+	info NodeInfo
+}
+
+// NewConverter creates a converter for the metrics of the given node.
+func NewConverter(info NodeInfo) *Converter {
+	return &Converter{info: info}
+}
+
+// group aggregates the containers of a pod or task.
+type group struct {
+	labels     map[string]string
+	cpu        float64
+	cpuUser    float64
+	cpuSystem  float64
+	hasCPU     bool
+	memory     cadvisor.MemoryStats
+	network    map[string]float64
+	containers int
+}
+
+// Convert returns the node, pod or task and container metrics.
+func (c *Converter) Convert(machine *cadvisor.MachineInfo, containers []cadvisor.ContainerInfo) []*Metric {
+	var metrics []*Metric
+
+	nodeType := TypeNode
+	groupType := TypePod
+	if c.info.Orchestrator == ECS {
+		nodeType = TypeInstance
+		groupType = TypeTask
+	}
+
+	cpuCapacity := float64(machine.NumCores) * 1000
+	memCapacity := float64(machine.MemoryCapacity)
+
+	groups := map[string]*group{}
+	var groupKeys []string
+	runningContainers := 0
+
+	for i := range containers {
+		info := &containers[i]
+		if len(info.Stats) == 0 {
+			continue
+		}
+
+		if info.Name == rootContainerName {
+			metrics = append(metrics, c.nodeMetric(nodeType, info, cpuCapacity, memCapacity))
+			continue
+		}
+
+		groupKey, groupLabels, containerLabels, infra := c.identify(info)
+		if groupKey == "" {
+			// not a pod or task container, e.g. a system cgroup.
+			continue
+		}
+
+		g := groups[groupKey]
+		if g == nil {
+			g = &group{labels: groupLabels}
+			groups[groupKey] = g
+			groupKeys = append(groupKeys, groupKey)
+		}
+
+		last := info.Stats[len(info.Stats)-1]
+		if network, ok := networkRates(info, nil); ok && (infra || g.network == nil) {
+			g.network = network
+		}
+		if infra {
+			continue
+		}
+
+		runningContainers++
+		g.containers++
+		m := c.newMetric(TypeContainer, containerLabels, info)
+		if cpu, ok := cpuRates(info); ok {
+			m.add(TypeContainer, CPUUsageTotal, cpu.total)
+			m.add(TypeContainer, CPUUsageUser, cpu.user)
+			m.add(TypeContainer, CPUUsageSystem, cpu.system)
+			if cpuCapacity > 0 {
+				m.add(TypeContainer, CPUUtilization, cpu.total/cpuCapacity*100)
+			}
+			g.cpu += cpu.total
+			g.cpuUser += cpu.user
+			g.cpuSystem += cpu.system
+			g.hasCPU = true
+		}
+		if limit := cpuLimit(&info.Spec); limit > 0 {
+			m.add(TypeContainer, CPULimit, limit)
+		}
+		addMemory(m, TypeContainer, &last.Memory, memCapacity)
+		if limit := info.Spec.Memory.Limit; info.Spec.HasMemory && limit > 0 && float64(limit) < memCapacity {
+			m.add(TypeContainer, MemLimit, float64(limit))
+		}
+		metrics = append(metrics, m)
+
+		g.memory.Usage += last.Memory.Usage
+		g.memory.Cache += last.Memory.Cache
+		g.memory.RSS += last.Memory.RSS
+		g.memory.WorkingSet += last.Memory.WorkingSet
+	}
+
+	sort.Strings(groupKeys)
+	for _, key := range groupKeys {
+		g := groups[key]
+		m := &Metric{Labels: c.labels(groupType, g.labels), Fields: map[string]float64{}}
+		if g.hasCPU {
+			m.add(groupType, CPUUsageTotal, g.cpu)
+			m.add(groupType, CPUUsageUser, g.cpuUser)
+			m.add(groupType, CPUUsageSystem, g.cpuSystem)
+			if cpuCapacity > 0 {
+				m.add(groupType, CPUUtilization, g.cpu/cpuCapacity*100)
+			}
+		}
+		if g.containers > 0 {
+			addMemory(m, groupType, &g.memory, memCapacity)
+		}
+		for suffix, v := range g.network {
+			m.add(groupType, suffix, v)
+		}
+		m.add(groupType, RunningContainerCount, float64(g.containers))
+		metrics = append(metrics, m)
+	}
+
+	// the running container count of the node is only known once every
+	// container has been seen.
+	for _, m := range metrics {
+		if m.Labels[MetricTypeKey] == nodeType {
+			m.add(nodeType, RunningContainerCount, float64(runningContainers))
+		}
+	}
+
+	// the pod and task metrics are aggregated from several containers, stamp
+	// them with the most recent sample.
+	ts := latestTimestamp(metrics)
+	for _, m := range metrics {
+		if m.Timestamp.IsZero() {
+			m.Timestamp = ts
+		}
+	}
+
+	return metrics
+}
+
+func (c *Converter) nodeMetric(nodeType string, info *cadvisor.ContainerInfo, cpuCapacity, memCapacity float64) *Metric {
+	m := c.newMetric(nodeType, nil, info)
+	last := info.Stats[len(info.Stats)-1]
+
+	if cpu, ok := cpuRates(info); ok {
+		m.add(nodeType, CPUUsageTotal, cpu.total)
+		m.add(nodeType, CPUUsageUser, cpu.user)
+		m.add(nodeType, CPUUsageSystem, cpu.system)
+		if cpuCapacity > 0 {
+			m.add(nodeType, CPUUtilization, cpu.total/cpuCapacity*100)
+		}
+	}
+	m.add(nodeType, CPULimit, cpuCapacity)
+
+	addMemory(m, nodeType, &last.Memory, memCapacity)
+	m.add(nodeType, MemLimit, memCapacity)
+
+	if network, ok := networkRates(info, isPhysicalInterface); ok {
+		for suffix, v := range network {
+			m.add(nodeType, suffix, v)
+		}
+	}
+
+	var capacity, usage, available uint64
+	for _, fs := range last.Filesystem {
+		capacity += fs.Limit
+		usage += fs.Usage
+		available += fs.Available
+	}
+	if capacity > 0 {
+		m.add(nodeType, FSCapacity, float64(capacity))
+		m.add(nodeType, FSUsage, float64(usage))
+		m.add(nodeType, FSAvailable, float64(available))
+		m.add(nodeType, FSUtilization, float64(usage)/float64(capacity)*100)
+	}
+
+	return m
+}
+
+// identify returns the key and the labels of the pod or task of the
+// container, the labels of the container and whether it is the pod
+// infrastructure container.
+func (c *Converter) identify(info *cadvisor.ContainerInfo) (string, map[string]string, map[string]string, bool) {
+	specLabels := info.Spec.Labels
+	if c.info.Orchestrator == ECS {
+		taskARN := specLabels[ecsTaskARNLabel]
+		containerName := specLabels[ecsContainerNameLabel]
+		if taskARN == "" || containerName == "" {
+			return "", nil, nil, false
+		}
+		groupLabels := map[string]string{TaskARNKey: taskARN}
+		if family := specLabels[ecsTaskFamilyLabel]; family != "" {
+			groupLabels[TaskDefinitionFamilyKey] = family
+		}
+		return taskARN, groupLabels, withLabel(groupLabels, ContainerNameKey, containerName), false
+	}
+
+	podName := specLabels[k8sPodNameLabel]
+	namespace := specLabels[k8sNamespaceLabel]
+	containerName := specLabels[k8sContainerNameLabel]
+	if podName == "" || namespace == "" {
+		return "", nil, nil, false
+	}
+	groupLabels := map[string]string{NamespaceKey: namespace, PodNameKey: podName}
+	infra := containerName == "" || containerName == k8sInfraContainerName
+	return namespace + "/" + podName, groupLabels, withLabel(groupLabels, ContainerNameKey, containerName), infra
+}
+
+func (c *Converter) labels(metricType string, extra map[string]string) map[string]string {
+	labels := make(map[string]string, len(extra)+3)
+	for k, v := range extra {
+		labels[k] = v
+	}
+	labels[MetricTypeKey] = metricType
+	if c.info.ClusterName != "" {
+		labels[ClusterNameKey] = c.info.ClusterName
+	}
+	if c.info.NodeName != "" {
+		labels[NodeNameKey] = c.info.NodeName
+	}
+	return labels
+}
+
+func (c *Converter) newMetric(metricType string, extra map[string]string, info *cadvisor.ContainerInfo) *Metric {
+	return &Metric{
+		Labels:    c.labels(metricType, extra),
+		Fields:    map[string]float64{},
+		Timestamp: info.Stats[len(info.Stats)-1].Timestamp,
+	}
+}
+
+func (m *Metric) add(metricType, suffix string, value float64) {
+	m.Fields[MetricName(metricType, suffix)] = value
+}
+
+func addMemory(m *Metric, metricType string, mem *cadvisor.MemoryStats, memCapacity float64) {
+	m.add(metricType, MemUsage, float64(mem.Usage))
+	m.add(metricType, MemCache, float64(mem.Cache))
+	m.add(metricType, MemRss, float64(mem.RSS))
+	m.add(metricType, MemWorkingset, float64(mem.WorkingSet))
+	if memCapacity > 0 {
+		m.add(metricType, MemUtilization, float64(mem.WorkingSet)/memCapacity*100)
+	}
+}
+
+type cpuUsage struct {
+	total, user, system float64
+}
+
+// cpuRates returns the cpu usage in millicores between the last two samples.
+func cpuRates(info *cadvisor.ContainerInfo) (cpuUsage, bool) {
+	prev, last, seconds, ok := lastTwo(info)
+	if !ok {
+		return cpuUsage{}, false
+	}
+	// the usage is in nanoseconds, 1e6 nanoseconds per second is a millicore.
+	rate := func(cur, old uint64) float64 {
+		if cur < old {
+			return 0
+		}
+		return float64(cur-old) / seconds / 1e6
+	}
+	return cpuUsage{
+		total:  rate(last.CPU.Usage.Total, prev.CPU.Usage.Total),
+		user:   rate(last.CPU.Usage.User, prev.CPU.Usage.User),
+		system: rate(last.CPU.Usage.System, prev.CPU.Usage.System),
+	}, true
+}
+
+// networkRates returns the per second network usage of the interfaces
+// accepted by filter between the last two samples.
+func networkRates(info *cadvisor.ContainerInfo, filter func(string) bool) (map[string]float64, bool) {
+	prev, last, seconds, ok := lastTwo(info)
+	if !ok || len(last.Network.Interfaces) == 0 {
+		return nil, false
+	}
+
+	previous := map[string]cadvisor.InterfaceStats{}
+	for _, iface := range prev.Network.Interfaces {
+		previous[iface.Name] = iface
+	}
+
+	var sums [6]float64
+	found := false
+	for _, cur := range last.Network.Interfaces {
+		if filter != nil && !filter(cur.Name) {
+			continue
+		}
+		old, ok := previous[cur.Name]
+		if !ok {
+			continue
+		}
+		found = true
+		for i, pair := range [][2]uint64{
+			{cur.RxBytes, old.RxBytes},
+			{cur.TxBytes, old.TxBytes},
+			{cur.RxErrors, old.RxErrors},
+			{cur.TxErrors, old.TxErrors},
+			{cur.RxDropped, old.RxDropped},
+			{cur.TxDropped, old.TxDropped},
+		} {
+			if pair[0] >= pair[1] {
+				sums[i] += float64(pair[0]-pair[1]) / seconds
+			}
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	return map[string]float64{
+		NetRxBytes:    sums[0],
+		NetTxBytes:    sums[1],
+		NetTotalBytes: sums[0] + sums[1],
+		NetRxErrors:   sums[2],
+		NetTxErrors:   sums[3],
+		NetRxDropped:  sums[4],
+		NetTxDropped:  sums[5],
+	}, true
+}
+
+func lastTwo(info *cadvisor.ContainerInfo) (*cadvisor.ContainerStats, *cadvisor.ContainerStats, float64, bool) {
+	if len(info.Stats) < 2 {
+		return nil, nil, 0, false
+	}
+	prev := info.Stats[len(info.Stats)-2]
+	last := info.Stats[len(info.Stats)-1]
+	seconds := last.Timestamp.Sub(prev.Timestamp).Seconds()
+	if seconds <= 0 {
+		return nil, nil, 0, false
+	}
+	return prev, last, seconds, true
+}
+
+// cpuLimit returns the cpu limit of the container in millicores, zero when
+// the container is not limited.
+func cpuLimit(spec *cadvisor.ContainerSpec) float64 {
+	if !spec.HasCPU || spec.CPU.Quota == 0 || spec.CPU.Period == 0 {
+		return 0
+	}
+	return float64(spec.CPU.Quota) / float64(spec.CPU.Period) * 1000
+}
+
+func isPhysicalInterface(name string) bool {
+	for _, prefix := range virtualInterfacePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func withLabel(labels map[string]string, key, value string) map[string]string {
+	res := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		res[k] = v
+	}
+	if value != "" {
+		res[key] = value
+	}
+	return res
+}
+
+func latestTimestamp(metrics []*Metric) (ts time.Time) {
+	for _, m := range metrics {
+		if m.Timestamp.After(ts) {
+			ts = m.Timestamp
+		}
+	}
+	return ts
+}
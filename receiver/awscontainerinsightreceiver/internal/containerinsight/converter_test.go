@@ -0,0 +1,217 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerinsight
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/cadvisor"
+)
+
+var (
+	t0 = time.Date(2020, 8, 20, 10, 0, 0, 0, time.UTC)
+	t1 = t0.Add(10 * time.Second)
+)
+
+func stats(ts time.Time, cpu uint64, mem uint64, ifaces ...cadvisor.InterfaceStats) *cadvisor.ContainerStats {
+	return &cadvisor.ContainerStats{
+		Timestamp: ts,
+		CPU:       cadvisor.CPUStats{Usage: cadvisor.CPUUsage{Total: cpu, User: cpu / 2, System: cpu / 4}},
+		Memory:    cadvisor.MemoryStats{Usage: mem, Cache: mem / 4, RSS: mem / 2, WorkingSet: mem / 2},
+		Network:   cadvisor.NetworkStats{Interfaces: ifaces},
+	}
+}
+
+func iface(name string, rx, tx uint64) cadvisor.InterfaceStats {
+	return cadvisor.InterfaceStats{Name: name, RxBytes: rx, TxBytes: tx}
+}
+
+func findMetric(t *testing.T, metrics []*Metric, labels map[string]string) *Metric {
+	for _, m := range metrics {
+		match := true
+		for k, v := range labels {
+			if m.Labels[k] != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			return m
+		}
+	}
+	require.Failf(t, "metric not found", "labels: %v", labels)
+	return nil
+}
+
+func TestConvertEKS(t *testing.T) {
+	machine := &cadvisor.MachineInfo{NumCores: 2, MemoryCapacity: 8000}
+
+	root := cadvisor.ContainerInfo{
+		Name: "/",
+		Stats: []*cadvisor.ContainerStats{
+			stats(t0, 0, 4000, iface("eth0", 0, 0), iface("veth1", 0, 0)),
+			stats(t1, 10e9, 4000, iface("eth0", 1000, 500), iface("veth1", 9999, 9999)),
+		},
+	}
+	root.Stats[1].Filesystem = []cadvisor.FsStats{{Device: "/dev/xvda1", Limit: 100, Usage: 25, Available: 75}}
+
+	podLabels := map[string]string{
+		k8sPodNameLabel:   "coredns-1",
+		k8sNamespaceLabel: "kube-system",
+	}
+	infra := cadvisor.ContainerInfo{
+		Name: "/kubepods/pod1/infra",
+		Spec: cadvisor.ContainerSpec{Labels: withLabel(podLabels, k8sContainerNameLabel, k8sInfraContainerName)},
+		Stats: []*cadvisor.ContainerStats{
+			stats(t0, 0, 0, iface("eth0", 0, 0)),
+			stats(t1, 1e9, 0, iface("eth0", 200, 100)),
+		},
+	}
+	coredns := cadvisor.ContainerInfo{
+		Name: "/kubepods/pod1/coredns",
+		Spec: cadvisor.ContainerSpec{
+			Labels: withLabel(podLabels, k8sContainerNameLabel, "coredns"),
+			HasCPU: true,
+			CPU:    cadvisor.CPUSpec{Quota: 50000, Period: 100000},
+		},
+		Stats: []*cadvisor.ContainerStats{
+			stats(t0, 0, 1000),
+			stats(t1, 2e9, 1000),
+		},
+	}
+	system := cadvisor.ContainerInfo{
+		Name:  "/system.slice/kubelet.service",
+		Stats: []*cadvisor.ContainerStats{stats(t0, 0, 100), stats(t1, 1e9, 100)},
+	}
+
+	c := NewConverter(NodeInfo{ClusterName: "cluster", NodeName: "node-1", Orchestrator: EKS})
+	metrics := c.Convert(machine, []cadvisor.ContainerInfo{root, infra, coredns, system})
+	require.Len(t, metrics, 3)
+
+	node := findMetric(t, metrics, map[string]string{MetricTypeKey: TypeNode})
+	assert.Equal(t, map[string]string{MetricTypeKey: TypeNode, ClusterNameKey: "cluster", NodeNameKey: "node-1"}, node.Labels)
+	assert.Equal(t, t1, node.Timestamp)
+	assert.Equal(t, 1000.0, node.Fields["node_cpu_usage_total"])
+	assert.Equal(t, 2000.0, node.Fields["node_cpu_limit"])
+	assert.Equal(t, 50.0, node.Fields["node_cpu_utilization"])
+	assert.Equal(t, 2000.0, node.Fields["node_memory_working_set"])
+	assert.Equal(t, 25.0, node.Fields["node_memory_utilization"])
+	assert.Equal(t, 100.0, node.Fields["node_network_rx_bytes"])
+	assert.Equal(t, 50.0, node.Fields["node_network_tx_bytes"])
+	assert.Equal(t, 150.0, node.Fields["node_network_total_bytes"])
+	assert.Equal(t, 25.0, node.Fields["node_filesystem_utilization"])
+	assert.Equal(t, 1.0, node.Fields["node_number_of_running_containers"])
+
+	container := findMetric(t, metrics, map[string]string{MetricTypeKey: TypeContainer})
+	assert.Equal(t, map[string]string{
+		MetricTypeKey:    TypeContainer,
+		ClusterNameKey:   "cluster",
+		NodeNameKey:      "node-1",
+		NamespaceKey:     "kube-system",
+		PodNameKey:       "coredns-1",
+		ContainerNameKey: "coredns",
+	}, container.Labels)
+	assert.Equal(t, 200.0, container.Fields["container_cpu_usage_total"])
+	assert.Equal(t, 500.0, container.Fields["container_cpu_limit"])
+	assert.Equal(t, 10.0, container.Fields["container_cpu_utilization"])
+	assert.Equal(t, 1000.0, container.Fields["container_memory_usage"])
+
+	pod := findMetric(t, metrics, map[string]string{MetricTypeKey: TypePod})
+	assert.Equal(t, map[string]string{
+		MetricTypeKey:  TypePod,
+		ClusterNameKey: "cluster",
+		NodeNameKey:    "node-1",
+		NamespaceKey:   "kube-system",
+		PodNameKey:     "coredns-1",
+	}, pod.Labels)
+	assert.Equal(t, t1, pod.Timestamp)
+	assert.Equal(t, 200.0, pod.Fields["pod_cpu_usage_total"])
+	assert.Equal(t, 1000.0, pod.Fields["pod_memory_usage"])
+	assert.Equal(t, 20.0, pod.Fields["pod_network_rx_bytes"])
+	assert.Equal(t, 10.0, pod.Fields["pod_network_tx_bytes"])
+	assert.Equal(t, 1.0, pod.Fields["pod_number_of_running_containers"])
+}
+
+func TestConvertECS(t *testing.T) {
+	machine := &cadvisor.MachineInfo{NumCores: 1, MemoryCapacity: 1000}
+
+	taskLabels := map[string]string{
+		ecsTaskARNLabel:    "arn:aws:ecs:us-west-2:123456789012:task/abc",
+		ecsTaskFamilyLabel: "web",
+	}
+	containers := []cadvisor.ContainerInfo{
+		{
+			Name:  "/",
+			Stats: []*cadvisor.ContainerStats{stats(t0, 0, 500), stats(t1, 5e9, 500)},
+		},
+		{
+			Name:  "/docker/a",
+			Spec:  cadvisor.ContainerSpec{Labels: withLabel(taskLabels, ecsContainerNameLabel, "app")},
+			Stats: []*cadvisor.ContainerStats{stats(t0, 0, 100), stats(t1, 1e9, 100)},
+		},
+		{
+			Name:  "/docker/b",
+			Spec:  cadvisor.ContainerSpec{Labels: withLabel(taskLabels, ecsContainerNameLabel, "sidecar")},
+			Stats: []*cadvisor.ContainerStats{stats(t0, 0, 300), stats(t1, 3e9, 300)},
+		},
+	}
+
+	c := NewConverter(NodeInfo{ClusterName: "cluster", Orchestrator: ECS})
+	metrics := c.Convert(machine, containers)
+	require.Len(t, metrics, 4)
+
+	instance := findMetric(t, metrics, map[string]string{MetricTypeKey: TypeInstance})
+	assert.Equal(t, 500.0, instance.Fields["instance_cpu_usage_total"])
+	assert.Equal(t, 2.0, instance.Fields["instance_number_of_running_containers"])
+	_, hasNode := instance.Labels[NodeNameKey]
+	assert.False(t, hasNode)
+
+	sidecar := findMetric(t, metrics, map[string]string{ContainerNameKey: "sidecar"})
+	assert.Equal(t, TypeContainer, sidecar.Labels[MetricTypeKey])
+	assert.Equal(t, "web", sidecar.Labels[TaskDefinitionFamilyKey])
+	assert.Equal(t, 300.0, sidecar.Fields["container_cpu_usage_total"])
+
+	task := findMetric(t, metrics, map[string]string{MetricTypeKey: TypeTask})
+	assert.Equal(t, "arn:aws:ecs:us-west-2:123456789012:task/abc", task.Labels[TaskARNKey])
+	assert.Equal(t, 400.0, task.Fields["task_cpu_usage_total"])
+	assert.Equal(t, 40.0, task.Fields["task_cpu_utilization"])
+	assert.Equal(t, 400.0, task.Fields["task_memory_usage"])
+	assert.Equal(t, 2.0, task.Fields["task_number_of_running_containers"])
+}
+
+func TestConvertSingleSample(t *testing.T) {
+	machine := &cadvisor.MachineInfo{NumCores: 1, MemoryCapacity: 1000}
+	containers := []cadvisor.ContainerInfo{
+		{Name: "/", Stats: []*cadvisor.ContainerStats{stats(t0, 1e9, 500)}},
+		{Name: "/empty"},
+	}
+
+	metrics := NewConverter(NodeInfo{Orchestrator: EKS}).Convert(machine, containers)
+	require.Len(t, metrics, 1)
+	_, hasCPU := metrics[0].Fields["node_cpu_usage_total"]
+	assert.False(t, hasCPU)
+	assert.Equal(t, 500.0, metrics[0].Fields["node_memory_usage"])
+}
+
+func TestMetricUnit(t *testing.T) {
+	assert.Equal(t, UnitMillicore, MetricUnit(MetricName(TypePod, CPUUsageTotal)))
+	assert.Equal(t, UnitBytesPerSec, MetricUnit(MetricName(TypeNode, NetRxBytes)))
+	assert.Equal(t, UnitPercent, MetricUnit(MetricName(TypeContainer, MemUtilization)))
+	assert.Equal(t, "", MetricUnit("unknown"))
+}
@@ -0,0 +1,74 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"sort"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/containerinsight"
+)
+
+// resourceType is the type of the resource of the emitted metrics.
+const resourceType = "aws.containerinsight"
+
+// toMetricsData converts the Container Insights metrics to gauges, the
+// labels of each metric become the labels of its resource so that the EMF
+// exporter can use them as dimensions.
+func toMetricsData(metrics []*containerinsight.Metric) []consumerdata.MetricsData {
+	mds := make([]consumerdata.MetricsData, 0, len(metrics))
+	for _, m := range metrics {
+		timestamp, err := ptypes.TimestampProto(m.Timestamp)
+		if err != nil {
+			timestamp = nil
+		}
+
+		names := make([]string, 0, len(m.Fields))
+		for name := range m.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		ms := make([]*metricspb.Metric, 0, len(names))
+		for _, name := range names {
+			ms = append(ms, &metricspb.Metric{
+				MetricDescriptor: &metricspb.MetricDescriptor{
+					Name: name,
+					Unit: containerinsight.MetricUnit(name),
+					Type: metricspb.MetricDescriptor_GAUGE_DOUBLE,
+				},
+				Timeseries: []*metricspb.TimeSeries{{
+					Points: []*metricspb.Point{{
+						Timestamp: timestamp,
+						Value:     &metricspb.Point_DoubleValue{DoubleValue: m.Fields[name]},
+					}},
+				}},
+			})
+		}
+
+		mds = append(mds, consumerdata.MetricsData{
+			Resource: &resourcepb.Resource{
+				Type:   resourceType,
+				Labels: m.Labels,
+			},
+			Metrics: ms,
+		})
+	}
+	return mds
+}
@@ -0,0 +1,145 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/cadvisor"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/containerinsight"
+)
+
+// hostNameEnv is the environment variable usually set from the node name
+// with the downward API when running as a daemonset.
+const hostNameEnv = "HOST_NAME"
+
+var _ component.MetricsReceiver = (*awsContainerInsightReceiver)(nil)
+
+// awsContainerInsightReceiver implements the component.MetricsReceiver for aws container insight.
+type awsContainerInsightReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	client       cadvisor.Client
+	converter    *containerinsight.Converter
+	cancel       context.CancelFunc
+}
+
+// newAWSContainerInsightReceiver creates the aws container insight receiver with the given parameters.
+func newAWSContainerInsightReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if config.ContainerOrchestrator != containerinsight.EKS && config.ContainerOrchestrator != containerinsight.ECS {
+		return nil, fmt.Errorf("unsupported container_orchestrator %q, must be %q or %q",
+			config.ContainerOrchestrator, containerinsight.EKS, containerinsight.ECS)
+	}
+
+	nodeName, err := nodeName(config)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &awsContainerInsightReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		client:       cadvisor.NewClient(config.CAdvisorEndpoint),
+		converter: containerinsight.NewConverter(containerinsight.NodeInfo{
+			ClusterName:  config.ClusterName,
+			NodeName:     nodeName,
+			Orchestrator: config.ContainerOrchestrator,
+		}),
+	}
+	return r, nil
+}
+
+// Start begins collecting metrics from cAdvisor.
+func (acir *awsContainerInsightReceiver) Start(ctx context.Context, host component.Host) error {
+	ctx, acir.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, "http", acir.config.Name()))
+	go func() {
+		ticker := time.NewTicker(acir.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := acir.collectData(ctx); err != nil {
+					acir.logger.Warn("Failed to collect container insight metrics", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the awscontainerinsight receiver.
+func (acir *awsContainerInsightReceiver) Shutdown(context.Context) error {
+	acir.cancel()
+	return nil
+}
+
+// collectData collects the cAdvisor stats and sends them as Container Insights metrics.
+func (acir *awsContainerInsightReceiver) collectData(ctx context.Context) error {
+	machine, err := acir.client.MachineInfo(ctx)
+	if err != nil {
+		return err
+	}
+	containers, err := acir.client.SubcontainersInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	mds := toMetricsData(acir.converter.Convert(machine, containers))
+	if len(mds) == 0 {
+		return nil
+	}
+
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, "http")
+	numTimeSeries, numPoints := 0, 0
+	for _, md := range mds {
+		ts, points := obsreport.CountMetricPoints(md)
+		numTimeSeries += ts
+		numPoints += points
+	}
+	err = acir.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData(mds))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+	return err
+}
+
+func nodeName(config *Config) (string, error) {
+	if config.NodeName != "" {
+		return config.NodeName, nil
+	}
+	if name := os.Getenv(hostNameEnv); name != "" {
+		return name, nil
+	}
+	return os.Hostname()
+}
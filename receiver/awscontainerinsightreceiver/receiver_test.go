@@ -0,0 +1,154 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+const (
+	machineResponse       = `{"num_cores": 2, "memory_capacity": 8000}`
+	subcontainersResponse = `[
+  {
+    "name": "/",
+    "stats": [
+      {"timestamp": "2020-08-20T10:00:00Z", "cpu": {"usage": {"total": 0}}, "memory": {"usage": 4000, "working_set": 2000}},
+      {"timestamp": "2020-08-20T10:00:10Z", "cpu": {"usage": {"total": 10000000000}}, "memory": {"usage": 4000, "working_set": 2000}}
+    ]
+  },
+  {
+    "name": "/kubepods/pod1/coredns",
+    "spec": {"labels": {
+      "io.kubernetes.pod.name": "coredns-1",
+      "io.kubernetes.pod.namespace": "kube-system",
+      "io.kubernetes.container.name": "coredns"
+    }},
+    "stats": [
+      {"timestamp": "2020-08-20T10:00:00Z", "cpu": {"usage": {"total": 0}}, "memory": {"usage": 1000}},
+      {"timestamp": "2020-08-20T10:00:10Z", "cpu": {"usage": {"total": 2000000000}}, "memory": {"usage": 1000}}
+    ]
+  }
+]`
+)
+
+func newCAdvisorServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1.3/machine":
+			w.Write([]byte(machineResponse))
+		case "/api/v1.3/subcontainers/":
+			w.Write([]byte(subcontainersResponse))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	metricsReceiver, err := newAWSContainerInsightReceiver(
+		zap.NewNop(),
+		cfg,
+		exportertest.NewNopMetricsExporter(),
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	ctx := context.Background()
+
+	err = r.Start(ctx, componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	err = r.Shutdown(ctx)
+	require.NoError(t, err)
+}
+
+func TestCollectData(t *testing.T) {
+	server := newCAdvisorServer(t)
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CAdvisorEndpoint = server.URL
+	cfg.ClusterName = "cluster"
+	cfg.NodeName = "node-1"
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newAWSContainerInsightReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	require.NoError(t, r.collectData(context.Background()))
+
+	metrics := sink.AllMetrics()
+	require.Len(t, metrics, 1)
+	mds := pdatautil.MetricsToMetricsData(metrics[0])
+	// node, container and pod
+	require.Len(t, mds, 3)
+
+	node := mds[0]
+	assert.Equal(t, resourceType, node.Resource.Type)
+	assert.Equal(t, map[string]string{"Type": "Node", "ClusterName": "cluster", "NodeName": "node-1"}, node.Resource.Labels)
+
+	values := map[string]float64{}
+	for _, m := range node.Metrics {
+		values[m.MetricDescriptor.Name] = m.Timeseries[0].Points[0].GetDoubleValue()
+	}
+	assert.Equal(t, 1000.0, values["node_cpu_usage_total"])
+	assert.Equal(t, 50.0, values["node_cpu_utilization"])
+	assert.Equal(t, 25.0, values["node_memory_utilization"])
+	assert.Equal(t, 1.0, values["node_number_of_running_containers"])
+}
+
+func TestCollectDataEndpointError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CAdvisorEndpoint = server.URL
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newAWSContainerInsightReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	require.Error(t, r.collectData(context.Background()))
+	assert.Len(t, sink.AllMetrics(), 0)
+}
+
+func TestNodeName(t *testing.T) {
+	name, err := nodeName(&Config{NodeName: "configured"})
+	require.NoError(t, err)
+	assert.Equal(t, "configured", name)
+
+	os.Setenv(hostNameEnv, "from-env")
+	defer os.Unsetenv(hostNameEnv)
+	name, err = nodeName(&Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", name)
+}
@@ -18,6 +18,8 @@ import (
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/proxy"
 )
 
 const (
@@ -66,3 +68,18 @@ type proxyServer struct {
 	// metadata check.
 	LocalMode *bool `mapstructure:"local_mode"`
 }
+
+// proxyConfig converts the configuration of the proxy server to the one
+// of the proxy package.
+func (p *proxyServer) proxyConfig() *proxy.Config {
+	return &proxy.Config{
+		Endpoint:     p.Endpoint,
+		ProxyAddress: p.ProxyAddress,
+		Insecure:     p.TLSSetting.Insecure,
+		ServerName:   p.TLSSetting.ServerName,
+		Region:       p.Region,
+		RoleARN:      p.RoleARN,
+		AWSEndpoint:  p.AWSEndpoint,
+		LocalMode:    p.LocalMode != nil && *p.LocalMode,
+	}
+}
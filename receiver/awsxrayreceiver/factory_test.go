@@ -16,10 +16,12 @@ package awsxrayreceiver
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -56,9 +58,12 @@ func TestCreateDefaultConfig(t *testing.T) {
 }
 
 func TestCreateTraceReceiver(t *testing.T) {
-	// TODO: Create proper tests after CreateTraceReceiver is implemented.
+	// the proxy server needs a region to forward requests to.
+	os.Setenv("AWS_REGION", "us-west-2")
+	defer os.Unsetenv("AWS_REGION")
+
 	factory := NewFactory()
-	_, err := factory.CreateTraceReceiver(
+	rcvr, err := factory.CreateTraceReceiver(
 		context.Background(),
 		component.ReceiverCreateParams{
 			Logger: zap.NewNop(),
@@ -67,6 +72,8 @@ func TestCreateTraceReceiver(t *testing.T) {
 		&mockTraceConsumer{},
 	)
 	assert.Nil(t, err, "trace receiver can be created")
+	assert.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, rcvr.Shutdown(context.Background()))
 }
 
 func TestCreateMetricsReceiver(t *testing.T) {
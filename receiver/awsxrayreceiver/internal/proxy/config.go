@@ -0,0 +1,47 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+// Config is the configuration for the local TCP proxy server.
+type Config struct {
+	// Endpoint is the TCP address and port on which the proxy listens.
+	Endpoint string
+
+	// ProxyAddress defines the proxy address that the local TCP server
+	// forwards HTTP requests to AWS X-Ray backend through.
+	ProxyAddress string
+
+	// Insecure disables the verification of the X-Ray backend certificate.
+	Insecure bool
+
+	// ServerName overrides the server name used to verify the certificate
+	// of the X-Ray backend.
+	ServerName string
+
+	// Region is the AWS region the local TCP server forwards requests to.
+	Region string
+
+	// RoleARN is the IAM role used by the local TCP server when
+	// communicating with the AWS X-Ray service.
+	RoleARN string
+
+	// AWSEndpoint is the X-Ray service endpoint which the local
+	// TCP server forwards requests to.
+	AWSEndpoint string
+
+	// LocalMode determines whether the EC2 instance metadata endpoint
+	// will be called or not.
+	LocalMode bool
+}
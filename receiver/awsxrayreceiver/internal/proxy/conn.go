@@ -0,0 +1,143 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"go.uber.org/zap"
+)
+
+const (
+	awsRegionEnvVar  = "AWS_REGION"
+	httpsProxyEnvVar = "HTTPS_PROXY"
+
+	idleConnTimeout                = 30 * time.Second
+	remoteProxyMaxIdleConnsPerHost = 2
+)
+
+var errNoRegion = errors.New("could not fetch region from config file, environment variables or EC2 metadata")
+
+// getRegion returns the configured region, falling back to the
+// AWS_REGION environment variable and, outside of local mode, the EC2
+// instance metadata.
+func getRegion(cfg *Config, logger *zap.Logger, metadataRegion func() (string, error)) (string, error) {
+	if cfg.Region != "" {
+		logger.Debug("Fetched region from config file", zap.String("region", cfg.Region))
+		return cfg.Region, nil
+	}
+
+	if region := os.Getenv(awsRegionEnvVar); region != "" {
+		logger.Debug("Fetched region from environment variables", zap.String("region", region))
+		return region, nil
+	}
+
+	if !cfg.LocalMode {
+		region, err := metadataRegion()
+		if err == nil && region != "" {
+			logger.Debug("Fetched region from EC2 metadata", zap.String("region", region))
+			return region, nil
+		}
+		logger.Warn("Unable to fetch region from EC2 metadata", zap.Error(err))
+	}
+
+	return "", errNoRegion
+}
+
+func ec2MetadataRegion() (string, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return "", err
+	}
+	return ec2metadata.New(sess).Region()
+}
+
+// getCredentials returns the credentials to sign the requests with,
+// assuming roleARN through STS when it is set.
+func getCredentials(roleARN, region string) (*credentials.Credentials, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, err
+	}
+	if roleARN == "" {
+		return sess.Config.Credentials, nil
+	}
+	return stscreds.NewCredentials(sess, roleARN), nil
+}
+
+// getServiceEndpoint returns the configured X-Ray endpoint or the
+// default one of the region.
+func getServiceEndpoint(awsEndpoint, region string) (*url.URL, error) {
+	if awsEndpoint == "" {
+		resolved, err := endpoints.DefaultResolver().EndpointFor(service, region)
+		if err != nil {
+			return nil, err
+		}
+		awsEndpoint = resolved.URL
+	}
+
+	endpoint, err := url.Parse(awsEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aws_endpoint %q: %w", awsEndpoint, err)
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid aws_endpoint %q: scheme and host are required", awsEndpoint)
+	}
+	return endpoint, nil
+}
+
+// newTransport returns the transport used to forward requests to X-Ray.
+func newTransport(cfg *Config) (*http.Transport, error) {
+	proxyAddr := cfg.ProxyAddress
+	if proxyAddr == "" {
+		proxyAddr = os.Getenv(httpsProxyEnvVar)
+	}
+
+	var proxyURL *url.URL
+	if proxyAddr != "" {
+		var err error
+		if proxyURL, err = url.Parse(proxyAddr); err != nil {
+			return nil, fmt.Errorf("invalid proxy_address %q: %w", proxyAddr, err)
+		}
+	}
+
+	return &http.Transport{
+		MaxIdleConnsPerHost: remoteProxyMaxIdleConnsPerHost,
+		IdleConnTimeout:     idleConnTimeout,
+		Proxy:               http.ProxyURL(proxyURL),
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: cfg.Insecure,
+			ServerName:         cfg.ServerName,
+		},
+
+		// If not disabled the transport will add a gzip encoding header
+		// to requests with no `accept-encoding` header value. The header
+		// is added after we sign the request which invalidates the
+		// signature.
+		DisableCompression: true,
+	}, nil
+}
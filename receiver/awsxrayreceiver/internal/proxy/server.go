@@ -0,0 +1,128 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	// service is the name of the X-Ray service used to sign requests.
+	service = "xray"
+
+	connHeader = "Connection"
+)
+
+// Server represents the local TCP proxy server relaying the sampling
+// requests of the X-Ray SDKs to the X-Ray backend.
+type Server interface {
+	ListenAndServe() error
+	Close() error
+}
+
+type server struct {
+	listener net.Listener
+	server   *http.Server
+}
+
+// NewServer creates a proxy server listening on cfg.Endpoint.
+func NewServer(cfg *Config, logger *zap.Logger) (Server, error) {
+	return newServer(cfg, logger, ec2MetadataRegion)
+}
+
+func newServer(cfg *Config, logger *zap.Logger, metadataRegion func() (string, error)) (Server, error) {
+	region, err := getRegion(cfg, logger, metadataRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := getCredentials(cfg.RoleARN, region)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := getServiceEndpoint(cfg.AWSEndpoint, region)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := newTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := v4.NewSigner(creds)
+
+	listener, err := net.Listen("tcp", cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Starting X-Ray proxy server",
+		zap.String("address", listener.Addr().String()),
+		zap.String("endpoint", endpoint.String()),
+		zap.String("region", region))
+
+	reverseProxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			// The SDKs send small JSON documents, buffer them to
+			// compute the payload hash of the signature.
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				logger.Error("Unable to read request body", zap.Error(err))
+				body = nil
+			}
+
+			req.URL.Scheme = endpoint.Scheme
+			req.URL.Host = endpoint.Host
+			req.Host = endpoint.Host
+
+			// The connection header may be stripped by the proxy
+			// after signing, which would invalidate the signature.
+			req.Header.Del(connHeader)
+
+			_, err = signer.Sign(req, bytes.NewReader(body), service, region, time.Now())
+			if err != nil {
+				logger.Error("Unable to sign request", zap.Error(err))
+			}
+		},
+		Transport: transport,
+		ErrorLog:  zap.NewStdLog(logger),
+	}
+
+	return &server{
+		listener: listener,
+		server: &http.Server{
+			Handler: reverseProxy,
+		},
+	}, nil
+}
+
+// ListenAndServe serves the requests until Close is called.
+func (s *server) ListenAndServe() error {
+	return s.server.Serve(s.listener)
+}
+
+// Close stops the server.
+func (s *server) Close() error {
+	return s.server.Close()
+}
@@ -0,0 +1,129 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+)
+
+func noMetadataRegion() (string, error) {
+	return "", errors.New("no EC2 metadata")
+}
+
+func TestGetRegion(t *testing.T) {
+	os.Unsetenv(awsRegionEnvVar)
+	logger := zap.NewNop()
+
+	region, err := getRegion(&Config{Region: "us-west-1"}, logger, noMetadataRegion)
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west-1", region)
+
+	region, err = getRegion(&Config{}, logger, func() (string, error) { return "eu-west-1", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west-1", region)
+
+	_, err = getRegion(&Config{LocalMode: true}, logger, func() (string, error) { return "eu-west-1", nil })
+	assert.Equal(t, errNoRegion, err)
+
+	_, err = getRegion(&Config{}, logger, noMetadataRegion)
+	assert.Equal(t, errNoRegion, err)
+
+	os.Setenv(awsRegionEnvVar, "ap-south-1")
+	defer os.Unsetenv(awsRegionEnvVar)
+	region, err = getRegion(&Config{LocalMode: true}, logger, noMetadataRegion)
+	assert.NoError(t, err)
+	assert.Equal(t, "ap-south-1", region)
+}
+
+func TestGetServiceEndpoint(t *testing.T) {
+	endpoint, err := getServiceEndpoint("", "us-west-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://xray.us-west-2.amazonaws.com", endpoint.String())
+
+	endpoint, err = getServiceEndpoint("https://xray.example.com", "us-west-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "xray.example.com", endpoint.Host)
+
+	_, err = getServiceEndpoint("xray.example.com", "us-west-2")
+	assert.Error(t, err)
+}
+
+func TestProxyForwardsSignedRequests(t *testing.T) {
+	os.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	defer os.Unsetenv("AWS_ACCESS_KEY_ID")
+	defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+
+	type received struct {
+		path          string
+		authorization string
+		body          string
+	}
+	receivedCh := make(chan received, 1)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		receivedCh <- received{
+			path:          r.URL.Path,
+			authorization: r.Header.Get("Authorization"),
+			body:          string(body),
+		}
+		w.Write([]byte(`{"SamplingRuleRecords":[]}`))
+	}))
+	defer backend.Close()
+
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := newServer(&Config{
+		Endpoint:    addr,
+		Region:      "us-west-2",
+		AWSEndpoint: backend.URL,
+		LocalMode:   true,
+	}, zap.NewNop(), noMetadataRegion)
+	require.NoError(t, err)
+
+	go srv.ListenAndServe()
+	defer srv.Close()
+
+	resp, err := http.Post("http://"+addr+"/GetSamplingRules", "application/json", strings.NewReader(`{"NextToken":null}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"SamplingRuleRecords":[]}`, string(body))
+
+	got := <-receivedCh
+	assert.Equal(t, "/GetSamplingRules", got.path)
+	assert.Equal(t, `{"NextToken":null}`, got.body)
+	assert.True(t, strings.HasPrefix(got.authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+	assert.Contains(t, got.authorization, "/us-west-2/xray/aws4_request")
+}
+
+func TestNewServerNoRegion(t *testing.T) {
+	os.Unsetenv(awsRegionEnvVar)
+	_, err := newServer(&Config{Endpoint: "localhost:0", LocalMode: true}, zap.NewNop(), noMetadataRegion)
+	assert.Equal(t, errNoRegion, err)
+}
@@ -0,0 +1,58 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// addAnnotations stores the annotations as span attributes. Annotation
+// values can only be strings, numbers or booleans.
+func addAnnotations(annos map[string]interface{}, attrs pdata.AttributeMap) {
+	for k, v := range annos {
+		switch val := v.(type) {
+		case string:
+			attrs.UpsertString(k, val)
+		case bool:
+			attrs.UpsertBool(k, val)
+		case float64:
+			// encoding/json decodes every number as float64
+			if val == math.Trunc(val) && math.Abs(val) < 1<<53 {
+				attrs.UpsertInt(k, int64(val))
+			} else {
+				attrs.UpsertDouble(k, val)
+			}
+		default:
+			attrs.UpsertString(k, fmt.Sprintf("%v", val))
+		}
+	}
+}
+
+// addMetadata stores the metadata of each namespace as a JSON encoded
+// span attribute.
+func addMetadata(meta map[string]map[string]interface{}, attrs pdata.AttributeMap) error {
+	for ns, metaVal := range meta {
+		val, err := json.Marshal(metaVal)
+		if err != nil {
+			return err
+		}
+		attrs.UpsertString(AWSXRayMetadataAttributePrefix+ns, string(val))
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+// X-Ray specific attributes, the AWS ones share their names with the ones
+// read by the AWS X-Ray exporter so that segments can be round-tripped.
+const (
+	AWSOperationAttribute = "aws.operation"
+	AWSAccountAttribute   = "aws.account_id"
+	AWSRegionAttribute    = "aws.region"
+	AWSRequestIDAttribute = "aws.request_id"
+	AWSQueueURLAttribute  = "aws.queue_url"
+	AWSTableNameAttribute = "aws.table_name"
+	AWSRetriesAttribute   = "aws.retries"
+
+	AWSXRayInProgressAttribute          = "aws.xray.inprogress"
+	AWSXRayOriginAttribute              = "aws.xray.origin"
+	AWSXRayResourceARNAttribute         = "aws.xray.resource_arn"
+	AWSXRayNamespaceAttribute           = "aws.xray.namespace"
+	AWSXRayTypeAttribute                = "aws.xray.type"
+	AWSXRayPrecursorIDsAttribute        = "aws.xray.precursor_ids"
+	AWSXRayXForwardedForAttribute       = "aws.xray.x_forwarded_for"
+	AWSXRayTracedAttribute              = "aws.xray.traced"
+	AWSXRayAutoInstrumentationAttribute = "aws.xray.auto_instrumentation"
+	AWSXRayExceptionIDAttribute         = "aws.xray.exception_id"
+
+	// AWSXRayMetadataAttributePrefix prefixes the attributes holding the
+	// JSON encoded metadata of each namespace.
+	AWSXRayMetadataAttributePrefix = "aws.xray.metadata."
+
+	// EnduserIDAttribute holds the user that originated the segment.
+	EnduserIDAttribute = "enduser.id"
+
+	// HTTPResponseContentLengthAttribute holds the content length of the response.
+	HTTPResponseContentLengthAttribute = "http.response_content_length"
+
+	initAttrCapacity = 15
+)
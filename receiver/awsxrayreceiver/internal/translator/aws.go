@@ -0,0 +1,40 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/tracesegment"
+)
+
+func addAWS(aws *tracesegment.AWSData, attrs pdata.AttributeMap) {
+	if aws == nil {
+		return
+	}
+
+	addString(aws.AccountID, AWSAccountAttribute, attrs)
+	addString(aws.Operation, AWSOperationAttribute, attrs)
+	addString(aws.RemoteRegion, AWSRegionAttribute, attrs)
+	addString(aws.RequestID, AWSRequestIDAttribute, attrs)
+	addString(aws.QueueURL, AWSQueueURLAttribute, attrs)
+	addString(aws.TableName, AWSTableNameAttribute, attrs)
+	if aws.TableName == nil && len(aws.TableNames) > 0 {
+		attrs.UpsertString(AWSTableNameAttribute, strings.Join(aws.TableNames, ","))
+	}
+	addInt64(aws.Retries, AWSRetriesAttribute, attrs)
+}
@@ -0,0 +1,115 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"strconv"
+	"strings"
+
+	otlptrace "github.com/open-telemetry/opentelemetry-proto/gen/go/trace/v1"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/tracesegment"
+)
+
+// addCause sets the span status from the fault, error and throttle flags
+// and records the exceptions of the cause as span events.
+func addCause(seg *tracesegment.Segment, span pdata.Span) {
+	status := span.Status()
+	status.InitEmpty()
+
+	code := otlptrace.Status_Ok
+	switch {
+	case isSet(seg.Fault):
+		code = otlptrace.Status_InternalError
+	case isSet(seg.Throttle):
+		code = otlptrace.Status_ResourceExhausted
+	case isSet(seg.Error):
+		code = otlptrace.Status_UnknownError
+		if seg.HTTP != nil && seg.HTTP.Response != nil && seg.HTTP.Response.Status != nil {
+			code = statusFromHTTPCode(*seg.HTTP.Response.Status)
+		}
+	}
+	status.SetCode(pdata.StatusCode(code))
+
+	if seg.Cause == nil {
+		return
+	}
+
+	// The exception is recorded in another segment, only keep a
+	// reference to it.
+	if seg.Cause.ExceptionID != nil {
+		span.Attributes().UpsertString(AWSXRayExceptionIDAttribute, *seg.Cause.ExceptionID)
+		return
+	}
+
+	if len(seg.Cause.Exceptions) == 0 {
+		return
+	}
+
+	timestamp := span.EndTime()
+	if timestamp == 0 {
+		timestamp = span.StartTime()
+	}
+
+	events := span.Events()
+	events.Resize(len(seg.Cause.Exceptions))
+	for i, exception := range seg.Cause.Exceptions {
+		event := events.At(i)
+		event.InitEmpty()
+		event.SetName(conventions.AttributeExceptionEventName)
+		event.SetTimestamp(timestamp)
+
+		attrs := event.Attributes()
+		attrs.InitEmptyWithCapacity(3)
+		addString(exception.Type, conventions.AttributeExceptionType, attrs)
+		addString(exception.Message, conventions.AttributeExceptionMessage, attrs)
+		if len(exception.Stack) > 0 {
+			attrs.UpsertString(conventions.AttributeExceptionStacktrace, convertStackFrames(exception.Stack))
+		}
+	}
+
+	if msg := seg.Cause.Exceptions[0].Message; msg != nil {
+		status.SetMessage(*msg)
+	}
+}
+
+// convertStackFrames renders the stack frames as label(path:line), one per line.
+func convertStackFrames(stack []tracesegment.StackFrame) string {
+	var b strings.Builder
+	for i, frame := range stack {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		if frame.Label != nil {
+			b.WriteString(*frame.Label)
+		}
+		b.WriteByte('(')
+		if frame.Path != nil {
+			b.WriteString(*frame.Path)
+		}
+		if frame.Line != nil {
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(*frame.Line))
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+func isSet(b *bool) bool {
+	return b != nil && *b
+}
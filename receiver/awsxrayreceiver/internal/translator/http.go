@@ -0,0 +1,67 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	otlptrace "github.com/open-telemetry/opentelemetry-proto/gen/go/trace/v1"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/tracesegment"
+)
+
+func addHTTP(seg *tracesegment.Segment, span pdata.Span) {
+	if seg.HTTP == nil {
+		return
+	}
+	attrs := span.Attributes()
+
+	if req := seg.HTTP.Request; req != nil {
+		addString(req.Method, conventions.AttributeHTTPMethod, attrs)
+		addString(req.URL, conventions.AttributeHTTPURL, attrs)
+		addString(req.UserAgent, conventions.AttributeHTTPUserAgent, attrs)
+		addString(req.ClientIP, conventions.AttributeHTTPClientIP, attrs)
+		addBool(req.XForwardedFor, AWSXRayXForwardedForAttribute, attrs)
+		addBool(req.Traced, AWSXRayTracedAttribute, attrs)
+	}
+
+	if resp := seg.HTTP.Response; resp != nil {
+		addInt64(resp.Status, conventions.AttributeHTTPStatusCode, attrs)
+		addInt64(resp.ContentLength, HTTPResponseContentLengthAttribute, attrs)
+	}
+}
+
+// statusFromHTTPCode maps the client errors reported by the X-Ray SDKs to
+// the corresponding OT status codes.
+func statusFromHTTPCode(code int64) otlptrace.Status_StatusCode {
+	switch code {
+	case 400:
+		return otlptrace.Status_InvalidArgument
+	case 401:
+		return otlptrace.Status_Unauthenticated
+	case 403:
+		return otlptrace.Status_PermissionDenied
+	case 404:
+		return otlptrace.Status_NotFound
+	case 409:
+		return otlptrace.Status_AlreadyExists
+	case 429:
+		return otlptrace.Status_ResourceExhausted
+	case 499:
+		return otlptrace.Status_Cancelled
+	default:
+		return otlptrace.Status_UnknownError
+	}
+}
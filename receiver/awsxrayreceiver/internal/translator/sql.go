@@ -0,0 +1,63 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/tracesegment"
+)
+
+func addSQL(sql *tracesegment.SQLData, attrs pdata.AttributeMap) {
+	if sql == nil {
+		return
+	}
+
+	// The X-Ray url has the form protocol://host[:port]/database, the
+	// connection string is used as is when the url is missing.
+	if sql.URL != nil {
+		url := *sql.URL
+		if idx := lastSlash(url); idx >= 0 {
+			attrs.UpsertString(conventions.AttributeDBConnectionString, url[:idx])
+			attrs.UpsertString(conventions.AttributeDBName, url[idx+1:])
+		} else {
+			attrs.UpsertString(conventions.AttributeDBConnectionString, url)
+		}
+	} else {
+		addString(sql.ConnectionString, conventions.AttributeDBConnectionString, attrs)
+	}
+	addString(sql.DatabaseType, conventions.AttributeDBSystem, attrs)
+	addString(sql.SanitizedQuery, conventions.AttributeDBStatement, attrs)
+	addString(sql.User, conventions.AttributeDBUser, attrs)
+}
+
+// lastSlash returns the index of the slash separating the database from
+// the rest of the url, ignoring the ones of the scheme.
+func lastSlash(url string) int {
+	start := 0
+	for i := 0; i+2 < len(url); i++ {
+		if url[i] == ':' && url[i+1] == '/' && url[i+2] == '/' {
+			start = i + 3
+			break
+		}
+	}
+	for i := len(url) - 1; i >= start; i-- {
+		if url[i] == '/' {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,288 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/tracesegment"
+)
+
+const (
+	// traceIDLength is the length of an X-Ray trace ID, e.g.
+	// 1-5759e988-bd862e3fe1be46a994272793
+	traceIDLength = 35
+	// spanIDLength is the length of an X-Ray segment ID in hex characters.
+	spanIDLength = 16
+
+	// X-Ray subsegment namespaces of downstream calls.
+	namespaceAWS    = "aws"
+	namespaceRemote = "remote"
+)
+
+var (
+	errInvalidTraceID = errors.New("invalid X-Ray trace ID")
+	errInvalidSpanID  = errors.New("invalid X-Ray segment ID")
+)
+
+// ToTraces converts X-Ray segment (and its subsegments) to an OT ResourceSpans.
+// It returns the number of spans produced, which is the number of segments
+// and subsegments found in the document.
+func ToTraces(rawSeg []byte) (*pdata.Traces, int, error) {
+	var seg tracesegment.Segment
+	if err := json.Unmarshal(rawSeg, &seg); err != nil {
+		// return 1 as total segment (&span) count
+		// because we can't parse the body the UDP packet.
+		return nil, 1, err
+	}
+	count := totalSegmentsCount(seg)
+
+	if err := seg.Validate(); err != nil {
+		return nil, count, err
+	}
+
+	traceData := pdata.NewTraces()
+	rspanSlice := traceData.ResourceSpans()
+	rspanSlice.Resize(1)
+	rspan := rspanSlice.At(0)
+	rspan.InitEmpty()
+	rspan.Resource().InitEmpty()
+	populateResource(&seg, rspan.Resource())
+
+	ilss := rspan.InstrumentationLibrarySpans()
+	ilss.Resize(1)
+	ils := ilss.At(0)
+	ils.InitEmpty()
+	spans := ils.Spans()
+	spans.Resize(count)
+
+	traceID, err := convertTraceID(*seg.TraceID)
+	if err != nil {
+		return nil, count, err
+	}
+
+	// the root segment may be an independent subsegment and
+	// carry its own parent ID.
+	var parentID []byte
+	if seg.ParentID != nil {
+		if parentID, err = convertSpanID(*seg.ParentID); err != nil {
+			return nil, count, err
+		}
+	}
+
+	idx := 0
+	if err = segToSpans(&seg, traceID, parentID, spans, &idx); err != nil {
+		return nil, count, err
+	}
+
+	return &traceData, count, nil
+}
+
+func segToSpans(seg *tracesegment.Segment, traceID, parentID []byte, spans pdata.SpanSlice, idx *int) error {
+	span := spans.At(*idx)
+	*idx++
+	if err := populateSpan(seg, traceID, parentID, span); err != nil {
+		return err
+	}
+
+	for i := range seg.Subsegments {
+		sub := &seg.Subsegments[i]
+		if sub.Name == nil || sub.ID == nil || sub.StartTime == nil {
+			return fmt.Errorf("invalid subsegment of segment %s", *seg.ID)
+		}
+		if err := segToSpans(sub, traceID, span.SpanID(), spans, idx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func populateSpan(seg *tracesegment.Segment, traceID, parentID []byte, span pdata.Span) error {
+	span.InitEmpty()
+
+	spanID, err := convertSpanID(*seg.ID)
+	if err != nil {
+		return err
+	}
+
+	span.SetTraceID(pdata.TraceID(traceID))
+	span.SetSpanID(pdata.SpanID(spanID))
+	if parentID != nil {
+		span.SetParentSpanID(pdata.SpanID(parentID))
+	}
+	span.SetName(*seg.Name)
+	span.SetKind(spanKind(seg, parentID))
+	span.SetStartTime(secondsToUnixNano(*seg.StartTime))
+	if seg.EndTime != nil {
+		span.SetEndTime(secondsToUnixNano(*seg.EndTime))
+	}
+
+	attrs := span.Attributes()
+	attrs.InitEmptyWithCapacity(initAttrCapacity)
+
+	if seg.InProgress != nil {
+		attrs.UpsertBool(AWSXRayInProgressAttribute, *seg.InProgress)
+	}
+	addString(seg.Origin, AWSXRayOriginAttribute, attrs)
+	addString(seg.User, EnduserIDAttribute, attrs)
+	addString(seg.ResourceARN, AWSXRayResourceARNAttribute, attrs)
+	addString(seg.Namespace, AWSXRayNamespaceAttribute, attrs)
+	addString(seg.Type, AWSXRayTypeAttribute, attrs)
+	if len(seg.PrecursorIDs) > 0 {
+		attrs.UpsertString(AWSXRayPrecursorIDsAttribute, strings.Join(seg.PrecursorIDs, ","))
+	}
+
+	addHTTP(seg, span)
+	addAWS(seg.AWS, attrs)
+	addSQL(seg.SQL, attrs)
+	addAnnotations(seg.Annotations, attrs)
+	if err = addMetadata(seg.Metadata, attrs); err != nil {
+		return err
+	}
+	addCause(seg, span)
+
+	return nil
+}
+
+// spanKind infers the kind of the span. Segments describe the work done by
+// a service so they are servers, subsegments recording a call to a remote
+// or AWS service are clients, the others describe internal work.
+func spanKind(seg *tracesegment.Segment, parentID []byte) pdata.SpanKind {
+	if seg.Namespace != nil {
+		switch *seg.Namespace {
+		case namespaceAWS, namespaceRemote:
+			return pdata.SpanKindCLIENT
+		}
+	}
+	if parentID == nil {
+		return pdata.SpanKindSERVER
+	}
+	return pdata.SpanKindINTERNAL
+}
+
+func populateResource(seg *tracesegment.Segment, rs pdata.Resource) {
+	attrs := rs.Attributes()
+	attrs.InitEmptyWithCapacity(initAttrCapacity)
+
+	attrs.UpsertString(conventions.AttributeCloudProvider, "aws")
+	attrs.UpsertString(conventions.AttributeServiceName, *seg.Name)
+
+	if seg.Service != nil {
+		addString(seg.Service.Version, conventions.AttributeServiceVersion, attrs)
+	}
+
+	if seg.AWS == nil {
+		return
+	}
+	addString(seg.AWS.AccountID, conventions.AttributeCloudAccount, attrs)
+
+	if ec2 := seg.AWS.EC2; ec2 != nil {
+		addString(ec2.InstanceID, conventions.AttributeHostID, attrs)
+		addString(ec2.AvailabilityZone, conventions.AttributeCloudZone, attrs)
+		addString(ec2.InstanceSize, conventions.AttributeHostType, attrs)
+		addString(ec2.AmiID, conventions.AttributeHostImageID, attrs)
+	}
+
+	if ecs := seg.AWS.ECS; ecs != nil {
+		addString(ecs.ContainerName, conventions.AttributeContainerName, attrs)
+	}
+
+	if bs := seg.AWS.Beanstalk; bs != nil {
+		addString(bs.Environment, conventions.AttributeServiceNamespace, attrs)
+		addString(bs.VersionLabel, conventions.AttributeServiceVersion, attrs)
+		if bs.DeploymentID != nil {
+			attrs.UpsertString(conventions.AttributeServiceInstance, fmt.Sprintf("%d", *bs.DeploymentID))
+		}
+	}
+
+	if xray := seg.AWS.XRay; xray != nil {
+		addString(xray.SDK, conventions.AttributeTelemetrySDKName, attrs)
+		addString(xray.SDKVersion, conventions.AttributeTelemetrySDKVersion, attrs)
+		if xray.AutoInstrumentation != nil {
+			attrs.UpsertBool(AWSXRayAutoInstrumentationAttribute, *xray.AutoInstrumentation)
+		}
+	}
+}
+
+// convertTraceID converts an X-Ray trace ID of the form
+// 1-5759e988-bd862e3fe1be46a994272793 to the 16 bytes OT trace ID made of
+// the epoch and the unique identifier.
+func convertTraceID(xrayTraceID string) ([]byte, error) {
+	if len(xrayTraceID) != traceIDLength {
+		return nil, errInvalidTraceID
+	}
+	parts := strings.Split(xrayTraceID, "-")
+	if len(parts) != 3 {
+		return nil, errInvalidTraceID
+	}
+	traceID, err := hex.DecodeString(parts[1] + parts[2])
+	if err != nil || len(traceID) != 16 {
+		return nil, errInvalidTraceID
+	}
+	return traceID, nil
+}
+
+func convertSpanID(xraySegmentID string) ([]byte, error) {
+	if len(xraySegmentID) != spanIDLength {
+		return nil, errInvalidSpanID
+	}
+	spanID, err := hex.DecodeString(xraySegmentID)
+	if err != nil {
+		return nil, errInvalidSpanID
+	}
+	return spanID, nil
+}
+
+// secondsToUnixNano converts the X-Ray epoch seconds with fractional
+// part to nanoseconds. X-Ray SDKs record microseconds, rounding to them
+// avoids the float64 precision loss in the nanoseconds.
+func secondsToUnixNano(seconds float64) pdata.TimestampUnixNano {
+	return pdata.TimestampUnixNano(int64(math.Round(seconds*1e6)) * 1e3)
+}
+
+func totalSegmentsCount(seg tracesegment.Segment) int {
+	subsegmentCount := 0
+	for _, s := range seg.Subsegments {
+		subsegmentCount += totalSegmentsCount(s)
+	}
+
+	return 1 + subsegmentCount
+}
+
+func addString(val *string, key string, attrs pdata.AttributeMap) {
+	if val != nil {
+		attrs.UpsertString(key, *val)
+	}
+}
+
+func addInt64(val *int64, key string, attrs pdata.AttributeMap) {
+	if val != nil {
+		attrs.UpsertInt(key, *val)
+	}
+}
+
+func addBool(val *bool, key string, attrs pdata.AttributeMap) {
+	if val != nil {
+		attrs.UpsertBool(key, *val)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	otlptrace "github.com/open-telemetry/opentelemetry-proto/gen/go/trace/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestToTracesServerSample(t *testing.T) {
+	content, err := ioutil.ReadFile(path.Join("testdata", "serverSample.txt"))
+	require.NoError(t, err)
+
+	traces, count, err := ToTraces(content)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	require.Equal(t, 4, traces.SpanCount())
+
+	rs := traces.ResourceSpans().At(0)
+	assertAttrs(t, rs.Resource().Attributes(), map[string]interface{}{
+		conventions.AttributeCloudProvider:       "aws",
+		conventions.AttributeServiceName:         "SampleServer",
+		conventions.AttributeServiceVersion:      "1.2.3",
+		conventions.AttributeCloudAccount:        "123456789012",
+		conventions.AttributeHostID:              "i-00f7c0bcb26da2a99",
+		conventions.AttributeCloudZone:           "us-west-2c",
+		conventions.AttributeHostType:            "m5.xlarge",
+		conventions.AttributeHostImageID:         "ami-0123456789abcdef0",
+		conventions.AttributeTelemetrySDKName:    "X-Ray for Go",
+		conventions.AttributeTelemetrySDKVersion: "1.0.0",
+	})
+
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	traceID := pdata.TraceID([]byte{0x5f, 0x18, 0x72, 0x53, 0x6a, 0x10, 0x66, 0x96, 0xd5, 0x6b, 0x1f, 0x4e, 0xf9, 0xeb, 0xa2, 0xed})
+
+	root := spans.At(0)
+	assert.Equal(t, traceID, root.TraceID())
+	assert.Equal(t, pdata.SpanID([]byte{0x5c, 0xc4, 0xa4, 0x47, 0xf5, 0xd4, 0xd6, 0x96}), root.SpanID())
+	assert.Nil(t, []byte(root.ParentSpanID()))
+	assert.Equal(t, "SampleServer", root.Name())
+	assert.Equal(t, pdata.SpanKindSERVER, root.Kind())
+	assert.Equal(t, pdata.TimestampUnixNano(1595437651680097000), root.StartTime())
+	assert.Equal(t, pdata.StatusCode(otlptrace.Status_InternalError), root.Status().Code())
+	assert.Equal(t, "Something went wrong", root.Status().Message())
+	assertAttrs(t, root.Attributes(), map[string]interface{}{
+		AWSXRayOriginAttribute:                     "AWS::EC2::Instance",
+		EnduserIDAttribute:                         "xraysample",
+		conventions.AttributeHTTPMethod:            "GET",
+		conventions.AttributeHTTPURL:               "http://localhost:8000/",
+		conventions.AttributeHTTPUserAgent:         "curl/7.54.0",
+		conventions.AttributeHTTPClientIP:          "127.0.0.1",
+		AWSXRayXForwardedForAttribute:              true,
+		conventions.AttributeHTTPStatusCode:        int64(500),
+		HTTPResponseContentLengthAttribute:         int64(12),
+		AWSAccountAttribute:                        "123456789012",
+		"int":                                      int64(1),
+		"float":                                    1.5,
+		"bool":                                     true,
+		"string":                                   "value",
+		AWSXRayMetadataAttributePrefix + "default": `{"key":"value"}`,
+	})
+
+	require.Equal(t, 1, root.Events().Len())
+	event := root.Events().At(0)
+	assert.Equal(t, conventions.AttributeExceptionEventName, event.Name())
+	assert.Equal(t, root.EndTime(), event.Timestamp())
+	assertAttrs(t, event.Attributes(), map[string]interface{}{
+		conventions.AttributeExceptionType:       "errors.errorString",
+		conventions.AttributeExceptionMessage:    "Something went wrong",
+		conventions.AttributeExceptionStacktrace: "main.handler(main.go:42)",
+	})
+
+	dynamo := spans.At(1)
+	assert.Equal(t, traceID, dynamo.TraceID())
+	assert.Equal(t, root.SpanID(), dynamo.ParentSpanID())
+	assert.Equal(t, pdata.SpanKindCLIENT, dynamo.Kind())
+	assert.Equal(t, pdata.StatusCode(otlptrace.Status_NotFound), dynamo.Status().Code())
+	assertAttrs(t, dynamo.Attributes(), map[string]interface{}{
+		AWSXRayNamespaceAttribute:           "aws",
+		conventions.AttributeHTTPStatusCode: int64(404),
+		AWSOperationAttribute:               "GetItem",
+		AWSRegionAttribute:                  "us-west-2",
+		AWSRequestIDAttribute:               "3AIENM5J4ELQ3SPODHKBIRVIC3VV4KQNSO5AEMVJF66Q9ASUAAJG",
+		AWSTableNameAttribute:               "xray_sample_table",
+		AWSRetriesAttribute:                 int64(1),
+	})
+
+	marshal := spans.At(2)
+	assert.Equal(t, "marshal", marshal.Name())
+	assert.Equal(t, dynamo.SpanID(), marshal.ParentSpanID())
+	assert.Equal(t, pdata.SpanKindINTERNAL, marshal.Kind())
+	assert.Equal(t, pdata.StatusCode(otlptrace.Status_Ok), marshal.Status().Code())
+
+	postgres := spans.At(3)
+	assert.Equal(t, root.SpanID(), postgres.ParentSpanID())
+	assert.Equal(t, pdata.SpanKindCLIENT, postgres.Kind())
+	assertAttrs(t, postgres.Attributes(), map[string]interface{}{
+		AWSXRayNamespaceAttribute:               "remote",
+		conventions.AttributeDBConnectionString: "postgresql://localhost:5432",
+		conventions.AttributeDBName:             "customers",
+		conventions.AttributeDBSystem:           "postgresql",
+		conventions.AttributeDBStatement:        "SELECT * FROM customers WHERE id = ?",
+		conventions.AttributeDBUser:             "dbuser",
+	})
+}
+
+func TestToTracesIndependentSubsegment(t *testing.T) {
+	content := []byte(`{"type":"subsegment","trace_id":"1-5f187253-6a106696d56b1f4ef9eba2ed",` +
+		`"parent_id":"5cc4a447f5d4d696","id":"0239497ae7a2bd0c","name":"S3",` +
+		`"start_time":1595437651.68011,"in_progress":true,"cause":"3e9e11e3ab3fba60"}`)
+
+	traces, count, err := ToTraces(content)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	span := traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.SpanID([]byte{0x5c, 0xc4, 0xa4, 0x47, 0xf5, 0xd4, 0xd6, 0x96}), span.ParentSpanID())
+	assert.Equal(t, pdata.SpanKindINTERNAL, span.Kind())
+	assert.Equal(t, pdata.TimestampUnixNano(0), span.EndTime())
+	assertAttrs(t, span.Attributes(), map[string]interface{}{
+		AWSXRayTypeAttribute:        "subsegment",
+		AWSXRayInProgressAttribute:  true,
+		AWSXRayExceptionIDAttribute: "3e9e11e3ab3fba60",
+	})
+}
+
+func TestToTracesInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantCount int
+		wantErr   string
+	}{
+		{
+			name:      "not json",
+			content:   "Body",
+			wantCount: 1,
+			wantErr:   "invalid character 'B' looking for beginning of value",
+		},
+		{
+			name:      "missing trace id",
+			content:   `{"id":"5cc4a447f5d4d696","name":"s","start_time":1,"subsegments":[{"id":"0239497ae7a2bd0c"}]}`,
+			wantCount: 2,
+			wantErr:   `segment "trace_id" can not be nil`,
+		},
+		{
+			name:      "invalid trace id",
+			content:   `{"trace_id":"1-5f187253","id":"5cc4a447f5d4d696","name":"s","start_time":1}`,
+			wantCount: 1,
+			wantErr:   errInvalidTraceID.Error(),
+		},
+		{
+			name:      "invalid span id",
+			content:   `{"trace_id":"1-5f187253-6a106696d56b1f4ef9eba2ed","id":"5cc4","name":"s","start_time":1}`,
+			wantCount: 1,
+			wantErr:   errInvalidSpanID.Error(),
+		},
+		{
+			name: "invalid subsegment",
+			content: `{"trace_id":"1-5f187253-6a106696d56b1f4ef9eba2ed","id":"5cc4a447f5d4d696","name":"s",` +
+				`"start_time":1,"subsegments":[{"id":"0239497ae7a2bd0c"}]}`,
+			wantCount: 2,
+			wantErr:   "invalid subsegment of segment 5cc4a447f5d4d696",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traces, count, err := ToTraces([]byte(tt.content))
+			assert.Nil(t, traces)
+			assert.Equal(t, tt.wantCount, count)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func assertAttrs(t *testing.T, attrs pdata.AttributeMap, want map[string]interface{}) {
+	assert.Equal(t, len(want), attrs.Len())
+	for k, v := range want {
+		got, ok := attrs.Get(k)
+		if !assert.True(t, ok, "missing attribute %s", k) {
+			continue
+		}
+		switch val := v.(type) {
+		case string:
+			assert.Equal(t, val, got.StringVal(), k)
+		case int64:
+			assert.Equal(t, val, got.IntVal(), k)
+		case float64:
+			assert.Equal(t, val, got.DoubleVal(), k)
+		case bool:
+			assert.Equal(t, val, got.BoolVal(), k)
+		}
+	}
+}
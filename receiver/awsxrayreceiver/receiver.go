@@ -1,4 +1,4 @@
-// Copyright The OpenTelemetry Authors
+// Copyright 2020, OpenTelemetry Authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,15 +16,18 @@ package awsxrayreceiver
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/consumer"
-	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/proxy"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/translator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/udppoller"
 )
 
@@ -32,6 +35,9 @@ const (
 	// number of goroutines polling the UDP socket.
 	// https://github.com/aws/aws-xray-daemon/blob/master/pkg/cfg/cfg.go#L184
 	maxPollerCount = 2
+
+	// segmentFormat is the format reported to obsreport.
+	segmentFormat = "awsxray"
 )
 
 // xrayReceiver implements the component.TraceReceiver interface for converting
@@ -39,6 +45,7 @@ const (
 type xrayReceiver struct {
 	instanceName string
 	poller       udppoller.Poller
+	server       proxy.Server
 	logger       *zap.Logger
 	consumer     consumer.TraceConsumer
 	longLivedCtx context.Context
@@ -69,9 +76,19 @@ func newReceiver(config *Config,
 	logger.Info("Listening on endpoint for X-Ray segments",
 		zap.String(udppoller.Transport, config.Endpoint))
 
+	var srv proxy.Server
+	if config.ProxyServer != nil {
+		srv, err = proxy.NewServer(config.ProxyServer.proxyConfig(), logger)
+		if err != nil {
+			poller.Close()
+			return nil, err
+		}
+	}
+
 	return &xrayReceiver{
 		instanceName: config.Name(),
 		poller:       poller,
+		server:       srv,
 		logger:       logger,
 		consumer:     consumer,
 	}, nil
@@ -84,6 +101,13 @@ func (x *xrayReceiver) Start(ctx context.Context, host component.Host) error {
 		x.longLivedCtx = obsreport.ReceiverContext(ctx, x.instanceName, udppoller.Transport, "")
 		x.poller.Start(x.longLivedCtx)
 		go x.start()
+		if x.server != nil {
+			go func() {
+				if errSrv := x.server.ListenAndServe(); errSrv != nil && !errors.Is(errSrv, http.ErrServerClosed) {
+					host.ReportFatalError(errSrv)
+				}
+			}()
+		}
 		err = nil
 	})
 	return err
@@ -93,17 +117,30 @@ func (x *xrayReceiver) Shutdown(_ context.Context) error {
 	var err = componenterror.ErrAlreadyStopped
 	x.stopOnce.Do(func() {
 		err = x.poller.Close()
+		if x.server != nil {
+			if errSrv := x.server.Close(); errSrv != nil && err == nil {
+				err = errSrv
+			}
+		}
 	})
 	return err
 }
 
 func (x *xrayReceiver) start() {
 	incomingSegments := x.poller.SegmentsChan()
-	for range incomingSegments {
-		// TODO: Transform an incoming segment to consumer.ConsumeTraceData. For now
-		// we are just dropping the ingested X-Ray segments.
-		// TODO: use the context generated by obsreport.StartMetricsReceiveOp
-		x.consumer.ConsumeTraces(context.Background(), pdata.NewTraces())
-		// TODO: emit metrics here to indicate segment received
+	for seg := range incomingSegments {
+		ctx := obsreport.StartTraceDataReceiveOp(seg.SegmentCtx, x.instanceName, udppoller.Transport)
+		traces, totalSpansCount, err := translator.ToTraces(seg.Payload)
+		if err != nil {
+			x.logger.Warn("X-Ray segment to OT traces conversion failed", zap.Error(err))
+			obsreport.EndTraceDataReceiveOp(ctx, segmentFormat, totalSpansCount, err)
+			continue
+		}
+
+		err = x.consumer.ConsumeTraces(ctx, *traces)
+		if err != nil {
+			x.logger.Warn("Trace consumer errored out", zap.Error(err))
+		}
+		obsreport.EndTraceDataReceiveOp(ctx, segmentFormat, totalSpansCount, err)
 	}
 }
@@ -18,9 +18,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"path"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
@@ -140,15 +144,14 @@ func TestCantStopAnInstanceTwice(t *testing.T) {
 	assert.True(t, errors.Is(err, componenterror.ErrAlreadyStopped), "should not stop receiver instance twice")
 }
 
-// TODO: Update this test to assert on the format of traces
-// once the transformation from X-Ray segments -> OTLP is done.
 func TestSegmentsPassedToConsumer(t *testing.T) {
 	addr, rcvr, _ := createAndOptionallyStartReceiver(t, true)
 	defer rcvr.Shutdown(context.Background())
 
-	// valid header with invalid body (for now this is ok because we haven't
-	// implemented the X-Ray segment -> OT format conversion)
-	err := writePacket(t, addr, `{"format": "json", "version": 1}`+"\nBody")
+	content, err := ioutil.ReadFile(path.Join("internal", "translator", "testdata", "serverSample.txt"))
+	assert.NoError(t, err, "can not read raw segment")
+
+	err = writePacket(t, addr, `{"format": "json", "version": 1}`+"\n"+string(content))
 	assert.NoError(t, err, "can not write packet in the happy case")
 
 	sink := rcvr.(*xrayReceiver).consumer.(*exportertest.SinkTraceExporter)
@@ -157,6 +160,84 @@ func TestSegmentsPassedToConsumer(t *testing.T) {
 		got := sink.AllTraces()
 		return len(got) == 1
 	}, "consumer should eventually get the X-Ray span")
+
+	got := sink.AllTraces()[0]
+	assert.Equal(t, 4, got.SpanCount())
+}
+
+func TestTranslatorErrorsOut(t *testing.T) {
+	addr, rcvr, recordedLogs := createAndOptionallyStartReceiver(t, true)
+	defer rcvr.Shutdown(context.Background())
+
+	err := writePacket(t, addr, `{"format": "json", "version": 1}`+"\nBody")
+	assert.NoError(t, err, "can not write packet in the happy case")
+
+	testutil.WaitFor(t, func() bool {
+		logs := recordedLogs.All()
+		return len(logs) > 0 && strings.Contains(logs[len(logs)-1].Message,
+			"X-Ray segment to OT traces conversion failed")
+	}, "poller should log warning because consumer errored out")
+
+	sink := rcvr.(*xrayReceiver).consumer.(*exportertest.SinkTraceExporter)
+	assert.Equal(t, 0, len(sink.AllTraces()))
+}
+
+func TestProxyServerStarted(t *testing.T) {
+	udpAddr, err := findAvailableAddress()
+	assert.NoError(t, err, "there should be address available")
+	tcpAddr := testutil.GetAvailableLocalAddress(t)
+
+	rcvr, err := newReceiver(
+		&Config{
+			NetAddr: confignet.NetAddr{
+				Endpoint:  udpAddr,
+				Transport: udppoller.Transport,
+			},
+			ProxyServer: &proxyServer{
+				TCPAddr: confignet.TCPAddr{
+					Endpoint: tcpAddr,
+				},
+				Region:    "us-west-2",
+				LocalMode: aws.Bool(true),
+			},
+		},
+		new(exportertest.SinkTraceExporter),
+		zap.NewNop(),
+	)
+	assert.NoError(t, err, "receiver should be created")
+
+	err = rcvr.Start(context.Background(), componenttest.NewNopHost())
+	assert.NoError(t, err, "receiver should be started")
+
+	conn, err := net.Dial("tcp", tcpAddr)
+	assert.NoError(t, err, "proxy server should be listening")
+	conn.Close()
+
+	assert.NoError(t, rcvr.Shutdown(context.Background()))
+}
+
+func TestProxyServerCreationFailed(t *testing.T) {
+	udpAddr, err := findAvailableAddress()
+	assert.NoError(t, err, "there should be address available")
+
+	_, err = newReceiver(
+		&Config{
+			NetAddr: confignet.NetAddr{
+				Endpoint:  udpAddr,
+				Transport: udppoller.Transport,
+			},
+			ProxyServer: &proxyServer{
+				TCPAddr: confignet.TCPAddr{
+					Endpoint: "invalid",
+				},
+				Region:    "us-west-2",
+				LocalMode: aws.Bool(true),
+			},
+		},
+		new(exportertest.SinkTraceExporter),
+		zap.NewNop(),
+	)
+	assert.Error(t, err, "receiver should not be created with an invalid proxy endpoint")
 }
 
 func createAndOptionallyStartReceiver(t *testing.T, start bool) (string, component.TraceReceiver, *observer.ObservedLogs) {
@@ -0,0 +1,179 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigipreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	loginPath = "/mgmt/shared/authn/login"
+	// tokenHeader is the header holding the authentication token.
+	tokenHeader = "X-F5-Auth-Token"
+	// loginProvider is the provider of the local users of the Big-IP.
+	loginProvider = "tmos"
+)
+
+// statsEntries are the entries of a stats response of the iControl REST API.
+type statsEntries struct {
+	Entries map[string]statsEntry `json:"entries"`
+}
+
+// statsEntry is a statistic, with a numeric value or a description, or the
+// nested statistics of an object.
+type statsEntry struct {
+	Value       *int64        `json:"value"`
+	Description string        `json:"description"`
+	NestedStats *statsEntries `json:"nestedStats"`
+}
+
+// objects returns the statistics of the objects of a collection, keyed by
+// name. Recent versions nest the statistics of every object one level
+// deeper, under the self link of the object.
+func (e *statsEntries) objects() []map[string]statsEntry {
+	var objects []map[string]statsEntry
+	for _, entry := range e.Entries {
+		stats := entry.NestedStats
+		for stats != nil {
+			inner, ok := onlyNestedStats(stats)
+			if !ok {
+				break
+			}
+			stats = inner
+		}
+		if stats != nil {
+			objects = append(objects, stats.Entries)
+		}
+	}
+	return objects
+}
+
+// onlyNestedStats returns the nested statistics of the single entry of
+// stats, if any.
+func onlyNestedStats(stats *statsEntries) (*statsEntries, bool) {
+	if len(stats.Entries) != 1 {
+		return nil, false
+	}
+	for _, entry := range stats.Entries {
+		return entry.NestedStats, entry.NestedStats != nil
+	}
+	return nil, false
+}
+
+// bigipClient retrieves statistics from the iControl REST API, logging in
+// to get a token when needed.
+type bigipClient struct {
+	endpoint string
+	username string
+	password string
+	client   *http.Client
+	token    string
+}
+
+func newBigIPClient(endpoint, username, password string, client *http.Client) *bigipClient {
+	return &bigipClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		username: username,
+		password: password,
+		client:   client,
+	}
+}
+
+// stats returns the statistics at path, logging in again if the token
+// expired.
+func (c *bigipClient) stats(ctx context.Context, path string) (*statsEntries, error) {
+	if c.token == "" {
+		if err := c.login(ctx); err != nil {
+			return nil, err
+		}
+	}
+	var entries statsEntries
+	status, err := c.get(ctx, path, &entries)
+	if status == http.StatusUnauthorized {
+		if err := c.login(ctx); err != nil {
+			return nil, err
+		}
+		_, err = c.get(ctx, path, &entries)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entries, nil
+}
+
+// login exchanges the credentials for a token.
+func (c *bigipClient) login(ctx context.Context) error {
+	body, err := json.Marshal(map[string]string{
+		"username":          c.username,
+		"password":          c.password,
+		"loginProviderName": loginProvider,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+loginPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var resp struct {
+		Token struct {
+			Token string `json:"token"`
+		} `json:"token"`
+	}
+	if _, err := c.do(req, &resp); err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+	if resp.Token.Token == "" {
+		return fmt.Errorf("failed to log in: no token in the response of %s", loginPath)
+	}
+	c.token = resp.Token.Token
+	return nil
+}
+
+func (c *bigipClient) get(ctx context.Context, path string, v interface{}) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+path, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set(tokenHeader, c.token)
+	return c.do(req, v)
+}
+
+// do sends the request and decodes the response in v, returning the status
+// code of the response.
+func (c *bigipClient) do(req *http.Request, v interface{}) (int, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, req.URL.Path)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode the response of %s: %w", req.URL.Path, err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigipreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBigIP serves the stats of the testdata directory to the clients
+// logged in with the otel/secret credentials.
+type fakeBigIP struct {
+	mu     sync.Mutex
+	token  string
+	logins int
+}
+
+func (f *fakeBigIP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == loginPath {
+		var creds map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil || creds["username"] != "otel" || creds["password"] != "secret" || creds["loginProviderName"] != "tmos" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.logins++
+		f.token = "token" + strconv.Itoa(f.logins)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"token": map[string]string{"token": f.token}})
+		return
+	}
+
+	if r.Header.Get(tokenHeader) == "" || r.Header.Get(tokenHeader) != f.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	files := map[string]string{
+		"/mgmt/tm/ltm/virtual/stats": "virtual_stats.json",
+		"/mgmt/tm/ltm/pool/stats":    "pool_stats.json",
+		"/mgmt/tm/ltm/node/stats":    "node_stats.json",
+	}
+	file, ok := files[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	data, err := ioutil.ReadFile(path.Join("testdata", file))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(data)
+}
+
+// expire invalidates the current token.
+func (f *fakeBigIP) expire() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.token = "expired"
+}
+
+func TestClientStats(t *testing.T) {
+	fake := &fakeBigIP{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	client := newBigIPClient(srv.URL+"/", "otel", "secret", srv.Client())
+	stats, err := client.stats(context.Background(), "/mgmt/tm/ltm/virtual/stats")
+	require.NoError(t, err)
+	objects := stats.objects()
+	require.Len(t, objects, 1)
+	assert.Equal(t, "/Common/web", objects[0]["tmName"].Description)
+	assert.Equal(t, int64(3), *objects[0]["clientside.curConns"].Value)
+
+	// The client logs in again when the token expires.
+	fake.expire()
+	stats, err = client.stats(context.Background(), "/mgmt/tm/ltm/node/stats")
+	require.NoError(t, err)
+	assert.Len(t, stats.objects(), 2)
+	assert.Equal(t, 2, fake.logins)
+
+	_, err = client.stats(context.Background(), "/mgmt/tm/ltm/unknown/stats")
+	assert.Error(t, err)
+}
+
+func TestClientLoginError(t *testing.T) {
+	srv := httptest.NewServer(&fakeBigIP{})
+	defer srv.Close()
+
+	client := newBigIPClient(srv.URL, "otel", "wrong", srv.Client())
+	_, err := client.stats(context.Background(), "/mgmt/tm/ltm/virtual/stats")
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigipreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config defines configuration for the bigip receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Endpoint is the URL of the iControl REST API, e.g. https://bigip.local.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Username and Password are the credentials exchanged for an
+	// authentication token.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+
+	// TLS configures the connection to the API.
+	TLS configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// CollectionInterval is the interval at which metrics should be collected.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+
+	// Timeout is the timeout of the requests to the API.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+func (c *Config) validate() error {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", c.Endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("endpoint %q must be an http or https URL", c.Endpoint)
+	}
+	if c.Username == "" || c.Password == "" {
+		return errors.New("username and password must be specified")
+	}
+	if c.CollectionInterval <= 0 {
+		return errors.New("collection_interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigipreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r1 := cfg.Receivers["bigip"]
+	assert.Equal(t, r1, factory.CreateDefaultConfig())
+
+	r2 := cfg.Receivers["bigip/customname"].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "bigip/customname",
+			},
+			Endpoint: "https://bigip.local:8443",
+			Username: "otel",
+			Password: "secret",
+			TLS: configtls.TLSClientSetting{
+				InsecureSkipVerify: true,
+			},
+			CollectionInterval: 30 * time.Second,
+			Timeout:            5 * time.Second,
+		})
+	assert.NoError(t, r2.validate())
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Username = "otel"
+		cfg.Password = "secret"
+		return cfg
+	}
+	assert.NoError(t, valid().validate())
+
+	cfg := valid()
+	cfg.Endpoint = "bigip.local"
+	assert.Error(t, cfg.validate())
+
+	cfg = valid()
+	cfg.Password = ""
+	assert.Error(t, cfg.validate())
+
+	cfg = valid()
+	cfg.CollectionInterval = 0
+	assert.Error(t, cfg.validate())
+}
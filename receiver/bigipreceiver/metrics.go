@@ -0,0 +1,349 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigipreceiver
+
+import (
+	"sort"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+const (
+	directionLabel = "direction"
+	statusLabel    = "status"
+)
+
+// labelSpec is a label of the metrics of an object, whose value is the
+// description of a statistic of the object.
+type labelSpec struct {
+	key  string
+	stat string
+}
+
+// statSpec is a statistic reported by a metric, identified by the value of
+// the label of the metric distinguishing its statistics, if any.
+type statSpec struct {
+	name      string
+	attrValue string
+}
+
+// metricSpec describes a metric and the statistics it is built from. The
+// metrics built from a state have a timeseries per state, whose value is 1
+// for the current state and 0 for the others.
+type metricSpec struct {
+	name        string
+	description string
+	unit        string
+	metricType  metricspb.MetricDescriptor_Type
+	// attrKey is the key of the label distinguishing the statistics of the
+	// metric, empty if the metric is built from a single statistic.
+	attrKey string
+	stats   []statSpec
+	// divisor converts the value of the statistics to the unit.
+	divisor int64
+	// states are the possible descriptions of the state statistic.
+	states []string
+}
+
+// objectSpec describes a kind of objects, where its statistics are
+// retrieved from, and its metrics.
+type objectSpec struct {
+	path    string
+	labels  []labelSpec
+	metrics []metricSpec
+}
+
+var availabilityStates = []string{"available", "offline", "unknown"}
+
+var enabledStates = []string{"enabled", "disabled"}
+
+var objectSpecs = []objectSpec{
+	{
+		path: "/mgmt/tm/ltm/virtual/stats",
+		labels: []labelSpec{
+			{key: "bigip.virtual_server.name", stat: "tmName"},
+			{key: "bigip.virtual_server.destination", stat: "destination"},
+		},
+		metrics: []metricSpec{
+			{
+				name:        "bigip.virtual_server.data.transmitted",
+				description: "Amount of data transmitted to and from the virtual server.",
+				unit:        "By",
+				metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+				attrKey:     directionLabel,
+				stats: []statSpec{
+					{name: "clientside.bitsIn", attrValue: "received"},
+					{name: "clientside.bitsOut", attrValue: "sent"},
+				},
+				divisor: 8,
+			},
+			{
+				name:        "bigip.virtual_server.connection.count",
+				description: "Current number of connections to the virtual server.",
+				unit:        "{connections}",
+				metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+				stats:       []statSpec{{name: "clientside.curConns"}},
+			},
+			{
+				name:        "bigip.virtual_server.request.count",
+				description: "Total number of requests to the virtual server.",
+				unit:        "{requests}",
+				metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+				stats:       []statSpec{{name: "totRequests"}},
+			},
+			{
+				name:        "bigip.virtual_server.availability",
+				description: "Availability of the virtual server.",
+				unit:        "1",
+				metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+				attrKey:     statusLabel,
+				stats:       []statSpec{{name: "status.availabilityState"}},
+				states:      availabilityStates,
+			},
+			{
+				name:        "bigip.virtual_server.enabled",
+				description: "Enabled state of the virtual server.",
+				unit:        "1",
+				metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+				attrKey:     statusLabel,
+				stats:       []statSpec{{name: "status.enabledState"}},
+				states:      enabledStates,
+			},
+		},
+	},
+	{
+		path: "/mgmt/tm/ltm/pool/stats",
+		labels: []labelSpec{
+			{key: "bigip.pool.name", stat: "tmName"},
+		},
+		metrics: []metricSpec{
+			{
+				name:        "bigip.pool.data.transmitted",
+				description: "Amount of data transmitted to and from the pool.",
+				unit:        "By",
+				metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+				attrKey:     directionLabel,
+				stats: []statSpec{
+					{name: "serverside.bitsIn", attrValue: "received"},
+					{name: "serverside.bitsOut", attrValue: "sent"},
+				},
+				divisor: 8,
+			},
+			{
+				name:        "bigip.pool.connection.count",
+				description: "Current number of connections to the pool.",
+				unit:        "{connections}",
+				metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+				stats:       []statSpec{{name: "serverside.curConns"}},
+			},
+			{
+				name:        "bigip.pool.request.count",
+				description: "Total number of requests to the pool.",
+				unit:        "{requests}",
+				metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+				stats:       []statSpec{{name: "totRequests"}},
+			},
+			{
+				name:        "bigip.pool.member.count",
+				description: "Current number of active members of the pool.",
+				unit:        "{members}",
+				metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+				stats:       []statSpec{{name: "activeMemberCnt"}},
+			},
+			{
+				name:        "bigip.pool.availability",
+				description: "Availability of the pool.",
+				unit:        "1",
+				metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+				attrKey:     statusLabel,
+				stats:       []statSpec{{name: "status.availabilityState"}},
+				states:      availabilityStates,
+			},
+		},
+	},
+	{
+		path: "/mgmt/tm/ltm/node/stats",
+		labels: []labelSpec{
+			{key: "bigip.node.name", stat: "tmName"},
+			{key: "bigip.node.ip_address", stat: "addr"},
+		},
+		metrics: []metricSpec{
+			{
+				name:        "bigip.node.data.transmitted",
+				description: "Amount of data transmitted to and from the node.",
+				unit:        "By",
+				metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+				attrKey:     directionLabel,
+				stats: []statSpec{
+					{name: "serverside.bitsIn", attrValue: "received"},
+					{name: "serverside.bitsOut", attrValue: "sent"},
+				},
+				divisor: 8,
+			},
+			{
+				name:        "bigip.node.connection.count",
+				description: "Current number of connections to the node.",
+				unit:        "{connections}",
+				metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+				stats:       []statSpec{{name: "serverside.curConns"}},
+			},
+			{
+				name:        "bigip.node.session.count",
+				description: "Current number of sessions of the node.",
+				unit:        "{sessions}",
+				metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+				stats:       []statSpec{{name: "curSessions"}},
+			},
+			{
+				name:        "bigip.node.request.count",
+				description: "Total number of requests to the node.",
+				unit:        "{requests}",
+				metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+				stats:       []statSpec{{name: "totRequests"}},
+			},
+			{
+				name:        "bigip.node.availability",
+				description: "Availability of the node.",
+				unit:        "1",
+				metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+				attrKey:     statusLabel,
+				stats:       []statSpec{{name: "status.availabilityState"}},
+				states:      availabilityStates,
+			},
+			{
+				name:        "bigip.node.enabled",
+				description: "Enabled state of the node.",
+				unit:        "1",
+				metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+				attrKey:     statusLabel,
+				stats:       []statSpec{{name: "status.enabledState"}},
+				states:      enabledStates,
+			},
+		},
+	},
+}
+
+// buildMetrics converts the statistics of the objects of a kind to metrics.
+// Cumulative metrics start at startTime.
+func (spec *objectSpec) buildMetrics(objects []map[string]statsEntry, startTime, ts *timestamp.Timestamp) []*metricspb.Metric {
+	// Sort the objects by labels to keep the order of the timeseries stable.
+	labelValues := make([][]string, len(objects))
+	for i, stats := range objects {
+		for _, l := range spec.labels {
+			labelValues[i] = append(labelValues[i], stats[l.stat].Description)
+		}
+	}
+	order := make([]int, len(objects))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return lessStrings(labelValues[order[i]], labelValues[order[j]])
+	})
+
+	metrics := make([]*metricspb.Metric, 0, len(spec.metrics))
+	for i := range spec.metrics {
+		m := &spec.metrics[i]
+		var timeseries []*metricspb.TimeSeries
+		for _, o := range order {
+			timeseries = append(timeseries, m.timeseries(objects[o], labelValues[o], startTime, ts)...)
+		}
+		if len(timeseries) == 0 {
+			continue
+		}
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: &metricspb.MetricDescriptor{
+				Name:        m.name,
+				Description: m.description,
+				Unit:        m.unit,
+				Type:        m.metricType,
+				LabelKeys:   spec.labelKeys(m.attrKey),
+			},
+			Timeseries: timeseries,
+		})
+	}
+	return metrics
+}
+
+func (spec *objectSpec) labelKeys(attrKey string) []*metricspb.LabelKey {
+	labelKeys := make([]*metricspb.LabelKey, 0, len(spec.labels)+1)
+	for _, l := range spec.labels {
+		labelKeys = append(labelKeys, &metricspb.LabelKey{Key: l.key})
+	}
+	if attrKey != "" {
+		labelKeys = append(labelKeys, &metricspb.LabelKey{Key: attrKey})
+	}
+	return labelKeys
+}
+
+// timeseries returns the timeseries of the metric for an object.
+func (m *metricSpec) timeseries(stats map[string]statsEntry, objectLabels []string, startTime, ts *timestamp.Timestamp) []*metricspb.TimeSeries {
+	var timeseries []*metricspb.TimeSeries
+	for _, s := range m.stats {
+		stat, ok := stats[s.name]
+		if !ok {
+			continue
+		}
+		if m.states != nil {
+			for _, state := range m.states {
+				var value int64
+				if stat.Description == state {
+					value = 1
+				}
+				timeseries = append(timeseries, m.series(objectLabels, state, value, startTime, ts))
+			}
+			continue
+		}
+		if stat.Value == nil {
+			continue
+		}
+		value := *stat.Value
+		if m.divisor != 0 {
+			value /= m.divisor
+		}
+		timeseries = append(timeseries, m.series(objectLabels, s.attrValue, value, startTime, ts))
+	}
+	return timeseries
+}
+
+func (m *metricSpec) series(objectLabels []string, attrValue string, value int64, startTime, ts *timestamp.Timestamp) *metricspb.TimeSeries {
+	series := &metricspb.TimeSeries{
+		LabelValues: make([]*metricspb.LabelValue, 0, len(objectLabels)+1),
+		Points: []*metricspb.Point{{
+			Timestamp: ts,
+			Value:     &metricspb.Point_Int64Value{Int64Value: value},
+		}},
+	}
+	for _, lv := range objectLabels {
+		series.LabelValues = append(series.LabelValues, &metricspb.LabelValue{Value: lv, HasValue: true})
+	}
+	if m.attrKey != "" {
+		series.LabelValues = append(series.LabelValues, &metricspb.LabelValue{Value: attrValue, HasValue: true})
+	}
+	if m.metricType == metricspb.MetricDescriptor_CUMULATIVE_INT64 {
+		series.StartTimestamp = startTime
+	}
+	return series
+}
+
+func lessStrings(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
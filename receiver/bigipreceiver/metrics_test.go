@@ -0,0 +1,92 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigipreceiver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadObjects(t *testing.T, file string) []map[string]statsEntry {
+	data, err := ioutil.ReadFile(path.Join("testdata", file))
+	require.NoError(t, err)
+	var stats statsEntries
+	require.NoError(t, json.Unmarshal(data, &stats))
+	return stats.objects()
+}
+
+func TestBuildVirtualServerMetrics(t *testing.T) {
+	startTime := ptypes.TimestampNow()
+	ts := ptypes.TimestampNow()
+	metrics := objectSpecs[0].buildMetrics(loadObjects(t, "virtual_stats.json"), startTime, ts)
+	require.Len(t, metrics, 5)
+
+	data := metrics[0]
+	assert.Equal(t, "bigip.virtual_server.data.transmitted", data.MetricDescriptor.Name)
+	assert.Equal(t, []*metricspb.LabelKey{
+		{Key: "bigip.virtual_server.name"},
+		{Key: "bigip.virtual_server.destination"},
+		{Key: directionLabel},
+	}, data.MetricDescriptor.LabelKeys)
+	require.Len(t, data.Timeseries, 2)
+	assert.Equal(t, startTime, data.Timeseries[0].StartTimestamp)
+	assert.Equal(t, []*metricspb.LabelValue{
+		{Value: "/Common/web", HasValue: true},
+		{Value: "10.0.0.10:443", HasValue: true},
+		{Value: "received", HasValue: true},
+	}, data.Timeseries[0].LabelValues)
+	assert.Equal(t, int64(1000), data.Timeseries[0].Points[0].GetInt64Value())
+	assert.Equal(t, int64(2000), data.Timeseries[1].Points[0].GetInt64Value())
+
+	availability := metrics[3]
+	assert.Equal(t, "bigip.virtual_server.availability", availability.MetricDescriptor.Name)
+	require.Len(t, availability.Timeseries, 3)
+	values := make(map[string]int64)
+	for _, series := range availability.Timeseries {
+		values[series.LabelValues[2].Value] = series.Points[0].GetInt64Value()
+	}
+	assert.Equal(t, map[string]int64{"available": 1, "offline": 0, "unknown": 0}, values)
+}
+
+func TestBuildNodeMetrics(t *testing.T) {
+	ts := ptypes.TimestampNow()
+	metrics := objectSpecs[2].buildMetrics(loadObjects(t, "node_stats.json"), ts, ts)
+
+	names := make([]string, 0, len(metrics))
+	for _, m := range metrics {
+		names = append(names, m.MetricDescriptor.Name)
+	}
+	// The nodes have no traffic statistics.
+	assert.Equal(t, []string{
+		"bigip.node.connection.count",
+		"bigip.node.session.count",
+		"bigip.node.availability",
+		"bigip.node.enabled",
+	}, names)
+
+	sessions := metrics[1]
+	require.Len(t, sessions.Timeseries, 2)
+	assert.Equal(t, "/Common/web1", sessions.Timeseries[0].LabelValues[0].Value)
+	assert.Equal(t, "10.0.1.1", sessions.Timeseries[0].LabelValues[1].Value)
+	assert.Equal(t, int64(5), sessions.Timeseries[0].Points[0].GetInt64Value())
+	assert.Equal(t, "/Common/web2", sessions.Timeseries[1].LabelValues[0].Value)
+}
@@ -0,0 +1,139 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigipreceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "http"
+
+var _ component.MetricsReceiver = (*bigipReceiver)(nil)
+
+// bigipReceiver implements the component.MetricsReceiver for F5 Big-IP.
+type bigipReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	client       *bigipClient
+	startTime    *timestamp.Timestamp
+	cancel       context.CancelFunc
+}
+
+// newBigIPReceiver creates the bigip receiver with the given parameters.
+func newBigIPReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := config.TLS.LoadTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the TLS configuration: %w", err)
+	}
+
+	httpClient := &http.Client{
+		Timeout:   config.Timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	r := &bigipReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		client:       newBigIPClient(config.Endpoint, config.Username, config.Password, httpClient),
+	}
+	return r, nil
+}
+
+// Start begins polling the iControl REST API.
+func (br *bigipReceiver) Start(ctx context.Context, host component.Host) error {
+	br.startTime = ptypes.TimestampNow()
+	ctx, br.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, br.config.Name()))
+	go func() {
+		ticker := time.NewTicker(br.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := br.collect(ctx); err != nil {
+					br.logger.Warn("Failed to collect bigip metrics", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the bigip receiver.
+func (br *bigipReceiver) Shutdown(context.Context) error {
+	if br.cancel != nil {
+		br.cancel()
+	}
+	return nil
+}
+
+// collect sends the metrics of the virtual servers, pools and nodes. The
+// metrics of the kinds of objects whose statistics were retrieved are sent
+// even if others failed.
+func (br *bigipReceiver) collect(ctx context.Context) error {
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+
+	var metrics []*metricspb.Metric
+	var errs []error
+	ts := ptypes.TimestampNow()
+	for i := range objectSpecs {
+		spec := &objectSpecs[i]
+		stats, err := br.client.stats(ctx, spec.path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		metrics = append(metrics, spec.buildMetrics(stats.objects(), br.startTime, ts)...)
+	}
+	err := componenterror.CombineErrors(errs)
+	if len(metrics) == 0 {
+		obsreport.EndMetricsReceiveOp(ctx, typeStr, 0, 0, err)
+		return err
+	}
+
+	md := consumerdata.MetricsData{Metrics: metrics}
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(md)
+	if consumeErr := br.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md})); consumeErr != nil {
+		err = consumeErr
+	}
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+	return err
+}
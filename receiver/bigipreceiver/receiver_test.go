@@ -0,0 +1,78 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigipreceiver
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestReceiver(t *testing.T) {
+	srv := httptest.NewTLSServer(&fakeBigIP{})
+	defer srv.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = srv.URL
+	cfg.Username = "otel"
+	cfg.Password = "secret"
+	cfg.TLS.InsecureSkipVerify = true
+	cfg.CollectionInterval = 100 * time.Millisecond
+
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newBigIPReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+
+	ctx := context.Background()
+	require.NoError(t, metricsReceiver.Start(ctx, componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		return sink.MetricsCount() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, metricsReceiver.Shutdown(ctx))
+
+	mds := pdatautil.MetricsToMetricsData(sink.AllMetrics()[0])
+	require.Len(t, mds, 1)
+	names := make(map[string]bool)
+	for _, m := range mds[0].Metrics {
+		names[m.MetricDescriptor.Name] = true
+	}
+	assert.True(t, names["bigip.virtual_server.request.count"])
+	assert.True(t, names["bigip.pool.member.count"])
+	assert.True(t, names["bigip.node.session.count"])
+}
+
+func TestCollectError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "http://127.0.0.1:1"
+	cfg.Username = "otel"
+	cfg.Password = "secret"
+
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newBigIPReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	assert.Error(t, metricsReceiver.(*bigipReceiver).collect(context.Background()))
+	assert.Equal(t, 0, sink.MetricsCount())
+}
@@ -0,0 +1,154 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"fmt"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+)
+
+// MessageParser is implemented by parsers of protocols in which each message
+// carries a batch of metrics, instead of a text line per metric. Transports
+// that support it frame the messages according to the protocol.
+type MessageParser interface {
+	Parser
+
+	// ParseMessage decodes a message into metrics. The returned errors are
+	// for the datapoints that could not be converted, or a single error if
+	// the message itself could not be decoded.
+	ParseMessage(message []byte) ([]*metricspb.Metric, []error)
+}
+
+// PickleConfig holds the configuration for the pickle parser.
+type PickleConfig struct{}
+
+var _ (ParserConfig) = (*PickleConfig)(nil)
+
+// BuildParser creates a new Parser instance that receives Carbon data using
+// the pickle protocol.
+func (p *PickleConfig) BuildParser() (Parser, error) {
+	pathParser := &PlaintextPathParser{}
+	lineParser, err := NewParser(pathParser)
+	if err != nil {
+		return nil, err
+	}
+	return &PickleParser{
+		Parser:     lineParser,
+		pathParser: pathParser,
+	}, nil
+}
+
+// PickleParser decodes messages of https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol.
+// Each message is a pickled list of datapoints in the following format:
+//
+// 	[(<metric_path>, (<metric_timestamp>, <metric_value>)), ...]
+//
+// The <metric_path> is parsed the same way as the plaintext parser does. The
+// embedded Parser handles plaintext lines, if a transport does not support
+// messages.
+type PickleParser struct {
+	Parser
+	pathParser PathParser
+}
+
+var _ (MessageParser) = (*PickleParser)(nil)
+
+// ParseMessage decodes a pickled list of datapoints, see MessageParser.
+func (pp *PickleParser) ParseMessage(message []byte) ([]*metricspb.Metric, []error) {
+	obj, err := unpickle(message)
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid carbon pickle message: %v", err)}
+	}
+	list, ok := obj.(*pickleList)
+	if !ok {
+		return nil, []error{fmt.Errorf("invalid carbon pickle message: expected a list, got %T", obj)}
+	}
+
+	var metrics []*metricspb.Metric
+	var errs []error
+	for _, item := range list.items {
+		metric, err := pp.parseDatapoint(item)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, errs
+}
+
+func (pp *PickleParser) parseDatapoint(item interface{}) (*metricspb.Metric, error) {
+	datapoint, ok := item.([]interface{})
+	if !ok || len(datapoint) != 2 {
+		return nil, fmt.Errorf("invalid carbon pickle datapoint %v", item)
+	}
+	path, ok := datapoint[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid carbon pickle datapoint path %v", datapoint[0])
+	}
+	tsValue, ok := datapoint[1].([]interface{})
+	if !ok || len(tsValue) != 2 {
+		return nil, fmt.Errorf("invalid carbon pickle datapoint [%s]: %v", path, datapoint[1])
+	}
+
+	parsedPath := ParsedPath{}
+	if err := pp.pathParser.ParsePath(path, &parsedPath); err != nil {
+		return nil, fmt.Errorf("invalid carbon metric [%s]: %v", path, err)
+	}
+
+	var unixTime int64
+	switch ts := tsValue[0].(type) {
+	case int64:
+		unixTime = ts
+	case float64:
+		unixTime = int64(ts)
+	default:
+		return nil, fmt.Errorf("invalid carbon metric time [%s]: %v", path, tsValue[0])
+	}
+
+	var metricType metricspb.MetricDescriptor_Type
+	point := metricspb.Point{
+		Timestamp: convertUnixSec(unixTime),
+	}
+	switch value := tsValue[1].(type) {
+	case int64:
+		metricType = metricspb.MetricDescriptor_GAUGE_INT64
+		if parsedPath.MetricType == CumulativeMetricType {
+			metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
+		}
+		point.Value = &metricspb.Point_Int64Value{Int64Value: value}
+	case float64:
+		metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
+		if parsedPath.MetricType == CumulativeMetricType {
+			metricType = metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
+		}
+		point.Value = &metricspb.Point_DoubleValue{DoubleValue: value}
+	default:
+		return nil, fmt.Errorf("invalid carbon metric value [%s]: %v", path, tsValue[1])
+	}
+
+	metric := buildMetricForSinglePoint(
+		parsedPath.MetricName,
+		metricType,
+		parsedPath.LabelKeys,
+		parsedPath.LabelValues,
+		&point)
+	return metric, nil
+}
+
+func pickleDefaultConfig() ParserConfig {
+	return &PickleConfig{}
+}
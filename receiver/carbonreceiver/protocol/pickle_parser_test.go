@@ -0,0 +1,177 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The messages below were generated with Python's pickle.dumps on
+// [("test.metric", (1582230020, 1.5)), ("test.int;k=v", (1582230020.5, 3))]
+// using the respective protocol version.
+const (
+	pickleProtocol0 = "(lp0\x0a(Vtest.metric\x0ap1\x0a(I1582230020\x0aF1.5\x0atp2\x0atp3\x0aa(Vtest.int;k=v\x0ap4\x0a(F1582230020.5\x0aI3\x0atp5\x0atp6\x0aa."
+	pickleProtocol2 = "\x80\x02]q\x00(X\x0b\x00\x00\x00test.metricq\x01J\x04\xeaN^G?\xf8\x00\x00\x00\x00\x00\x00\x86q\x02\x86q\x03X\x0c\x00\x00\x00test.int;k=vq\x04GA\xd7\x93\xba\x81 \x00\x00K\x03\x86q\x05\x86q\x06e."
+	pickleProtocol4 = "\x80\x04\x95C\x00\x00\x00\x00\x00\x00\x00]\x94(\x8c\x0btest.metric\x94J\x04\xeaN^G?\xf8\x00\x00\x00\x00\x00\x00\x86\x94\x86\x94\x8c\x0ctest.int;k=v\x94GA\xd7\x93\xba\x81 \x00\x00K\x03\x86\x94\x86\x94e."
+)
+
+func TestPickleParser_ParseMessage(t *testing.T) {
+	p, err := (&PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+	mp, ok := p.(MessageParser)
+	require.True(t, ok)
+
+	want := []*metricspb.Metric{
+		buildMetricForSinglePoint(
+			"test.metric",
+			metricspb.MetricDescriptor_GAUGE_DOUBLE,
+			nil,
+			nil,
+			&metricspb.Point{
+				Timestamp: convertUnixSec(1582230020),
+				Value:     &metricspb.Point_DoubleValue{DoubleValue: 1.5},
+			}),
+		buildMetricForSinglePoint(
+			"test.int",
+			metricspb.MetricDescriptor_GAUGE_INT64,
+			[]*metricspb.LabelKey{{Key: "k"}},
+			[]*metricspb.LabelValue{{Value: "v", HasValue: true}},
+			&metricspb.Point{
+				Timestamp: convertUnixSec(1582230020),
+				Value:     &metricspb.Point_Int64Value{Int64Value: 3},
+			}),
+	}
+
+	for name, message := range map[string]string{
+		"protocol_0": pickleProtocol0,
+		"protocol_2": pickleProtocol2,
+		"protocol_4": pickleProtocol4,
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, errs := mp.ParseMessage([]byte(message))
+			assert.Nil(t, errs)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestPickleParser_ParseMessageErrors(t *testing.T) {
+	p, err := (&PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+	mp := p.(MessageParser)
+
+	tests := []struct {
+		name        string
+		message     string
+		wantMetrics int
+		wantErrs    int
+	}{
+		{
+			name:     "truncated",
+			message:  pickleProtocol2[:20],
+			wantErrs: 1,
+		},
+		{
+			// pickle.dumps(("not", "list"), protocol=2)
+			name:     "not_a_list",
+			message:  "\x80\x02X\x03\x00\x00\x00notq\x00X\x04\x00\x00\x00listq\x01\x86q\x02.",
+			wantErrs: 1,
+		},
+		{
+			// A pickled call to os.system.
+			name:     "unsupported_opcode",
+			message:  "cos\nsystem\n(S'echo'\ntR.",
+			wantErrs: 1,
+		},
+		{
+			// pickle.dumps([("bad", (1, "x")), "y", ("ok", (1, 2))], protocol=2)
+			name:        "invalid_datapoints",
+			message:     "\x80\x02]q\x00(X\x03\x00\x00\x00badq\x01K\x01X\x01\x00\x00\x00xq\x02\x86q\x03\x86q\x04X\x01\x00\x00\x00yq\x05X\x02\x00\x00\x00okq\x06K\x01K\x02\x86q\x07\x86q\x08e.",
+			wantMetrics: 1,
+			wantErrs:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics, errs := mp.ParseMessage([]byte(tt.message))
+			assert.Len(t, metrics, tt.wantMetrics)
+			assert.Len(t, errs, tt.wantErrs)
+		})
+	}
+}
+
+func TestPickleParser_Parse(t *testing.T) {
+	p, err := (&PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+
+	got, err := p.Parse("test.metric 1 1582230020")
+	require.NoError(t, err)
+	assert.Equal(t, "test.metric", got.MetricDescriptor.Name)
+}
+
+func Test_unpickle(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			// pickle.dumps([("neg", (1, -2**40))], protocol=2)
+			name: "long1",
+			data: "\x80\x02]q\x00X\x03\x00\x00\x00negq\x01K\x01\x8a\x06\x00\x00\x00\x00\x00\xff\x86q\x02\x86q\x03a.",
+			want: &pickleList{items: []interface{}{
+				[]interface{}{"neg", []interface{}{int64(1), int64(-1 << 40)}},
+			}},
+		},
+		{
+			// pickle.dumps([None, True, False, "s"], protocol=0)
+			name: "protocol_0_misc",
+			data: "(lp0\nNaI01\naI00\naVs\np1\na.",
+			want: &pickleList{items: []interface{}{nil, true, false, "s"}},
+		},
+		{
+			name: "memo_get",
+			data: "\x80\x02]q\x00(X\x01\x00\x00\x00aq\x01h\x01e.",
+			want: &pickleList{items: []interface{}{"a", "a"}},
+		},
+		{
+			name:    "missing_memo",
+			data:    "\x80\x02]q\x00h\x01a.",
+			wantErr: true,
+		},
+		{
+			name:    "missing_stop",
+			data:    "\x80\x02]q\x00",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			data:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := unpickle([]byte(tt.data))
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
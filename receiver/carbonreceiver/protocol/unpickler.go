@@ -0,0 +1,457 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// This file implements a minimal decoder of the Python pickle format, limited
+// to the opcodes needed to decode the lists of tuples of strings and numbers
+// sent with Graphite's pickle protocol. Opcodes that instantiate arbitrary
+// objects (GLOBAL, REDUCE, BUILD, etc) are not supported on purpose.
+
+const (
+	opMark           = '('
+	opStop           = '.'
+	opPop            = '0'
+	opNone           = 'N'
+	opInt            = 'I'
+	opBinInt         = 'J'
+	opBinInt1        = 'K'
+	opBinInt2        = 'M'
+	opLong           = 'L'
+	opFloat          = 'F'
+	opBinFloat       = 'G'
+	opString         = 'S'
+	opBinString      = 'T'
+	opShortBinString = 'U'
+	opUnicode        = 'V'
+	opBinUnicode     = 'X'
+	opAppend         = 'a'
+	opAppends        = 'e'
+	opList           = 'l'
+	opEmptyList      = ']'
+	opTuple          = 't'
+	opEmptyTuple     = ')'
+	opPut            = 'p'
+	opBinPut         = 'q'
+	opLongBinPut     = 'r'
+	opGet            = 'g'
+	opBinGet         = 'h'
+	opLongBinGet     = 'j'
+
+	// Protocol 2.
+	opProto    = '\x80'
+	opTuple1   = '\x85'
+	opTuple2   = '\x86'
+	opTuple3   = '\x87'
+	opNewTrue  = '\x88'
+	opNewFalse = '\x89'
+	opLong1    = '\x8a'
+	opLong4    = '\x8b'
+
+	// Protocol 3.
+	opBinBytes      = 'B'
+	opShortBinBytes = 'C'
+
+	// Protocol 4.
+	opShortBinUnicode = '\x8c'
+	opBinUnicode8     = '\x8d'
+	opBinBytes8       = '\x8e'
+	opMemoize         = '\x94'
+	opFrame           = '\x95'
+)
+
+var errUnexpectedEnd = errors.New("unexpected end of pickle data")
+
+// pickleMark is pushed to the stack by the MARK opcode.
+type pickleMark struct{}
+
+// pickleList is a mutable list, a pointer is used so appends are visible to
+// all references to it, e.g. the ones kept on the memo.
+type pickleList struct {
+	items []interface{}
+}
+
+type unpickler struct {
+	data  []byte
+	pos   int
+	stack []interface{}
+	memo  map[int]interface{}
+}
+
+// unpickle decodes the pickled object in data. Lists are returned as
+// *pickleList, tuples as []interface{}, strings and bytes as string, integers
+// as int64, floats as float64 and booleans as bool.
+func unpickle(data []byte) (interface{}, error) {
+	u := &unpickler{
+		data: data,
+		memo: make(map[int]interface{}),
+	}
+	for {
+		op, err := u.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if op == opStop {
+			return u.pop()
+		}
+		if err = u.execute(op); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (u *unpickler) execute(op byte) error {
+	switch op {
+	case opProto:
+		_, err := u.readN(1)
+		return err
+	case opFrame:
+		_, err := u.readN(8)
+		return err
+	case opMark:
+		u.push(pickleMark{})
+	case opPop:
+		_, err := u.pop()
+		return err
+	case opNone:
+		u.push(nil)
+	case opNewTrue:
+		u.push(true)
+	case opNewFalse:
+		u.push(false)
+	case opInt:
+		return u.loadInt()
+	case opBinInt:
+		b, err := u.readN(4)
+		if err != nil {
+			return err
+		}
+		u.push(int64(int32(binary.LittleEndian.Uint32(b))))
+	case opBinInt1:
+		b, err := u.readByte()
+		if err != nil {
+			return err
+		}
+		u.push(int64(b))
+	case opBinInt2:
+		b, err := u.readN(2)
+		if err != nil {
+			return err
+		}
+		u.push(int64(binary.LittleEndian.Uint16(b)))
+	case opLong:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		i, err := strconv.ParseInt(strings.TrimSuffix(line, "L"), 10, 64)
+		if err != nil {
+			return err
+		}
+		u.push(i)
+	case opLong1:
+		n, err := u.readByte()
+		if err != nil {
+			return err
+		}
+		return u.loadLong(int(n))
+	case opLong4:
+		b, err := u.readN(4)
+		if err != nil {
+			return err
+		}
+		return u.loadLong(int(int32(binary.LittleEndian.Uint32(b))))
+	case opFloat:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		f, err := strconv.ParseFloat(line, 64)
+		if err != nil {
+			return err
+		}
+		u.push(f)
+	case opBinFloat:
+		b, err := u.readN(8)
+		if err != nil {
+			return err
+		}
+		u.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+	case opString:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		if len(line) < 2 || line[0] != line[len(line)-1] || (line[0] != '\'' && line[0] != '"') {
+			return fmt.Errorf("invalid pickle string %q", line)
+		}
+		u.push(line[1 : len(line)-1])
+	case opUnicode:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		u.push(line)
+	case opShortBinString, opShortBinBytes, opShortBinUnicode:
+		n, err := u.readByte()
+		if err != nil {
+			return err
+		}
+		return u.loadString(uint64(n))
+	case opBinString, opBinBytes, opBinUnicode:
+		b, err := u.readN(4)
+		if err != nil {
+			return err
+		}
+		return u.loadString(uint64(binary.LittleEndian.Uint32(b)))
+	case opBinUnicode8, opBinBytes8:
+		b, err := u.readN(8)
+		if err != nil {
+			return err
+		}
+		return u.loadString(binary.LittleEndian.Uint64(b))
+	case opEmptyList:
+		u.push(&pickleList{})
+	case opList:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		u.push(&pickleList{items: items})
+	case opAppend:
+		v, err := u.pop()
+		if err != nil {
+			return err
+		}
+		return u.appendToList(v)
+	case opAppends:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		return u.appendToList(items...)
+	case opEmptyTuple:
+		u.push([]interface{}{})
+	case opTuple:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		u.push(items)
+	case opTuple1, opTuple2, opTuple3:
+		n := int(op-opTuple1) + 1
+		if len(u.stack) < n {
+			return errUnexpectedEnd
+		}
+		items := make([]interface{}, n)
+		copy(items, u.stack[len(u.stack)-n:])
+		u.stack = u.stack[:len(u.stack)-n]
+		u.push(items)
+	case opPut:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		idx, err := strconv.Atoi(line)
+		if err != nil {
+			return err
+		}
+		return u.put(idx)
+	case opBinPut:
+		b, err := u.readByte()
+		if err != nil {
+			return err
+		}
+		return u.put(int(b))
+	case opLongBinPut:
+		b, err := u.readN(4)
+		if err != nil {
+			return err
+		}
+		return u.put(int(binary.LittleEndian.Uint32(b)))
+	case opMemoize:
+		return u.put(len(u.memo))
+	case opGet:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		idx, err := strconv.Atoi(line)
+		if err != nil {
+			return err
+		}
+		return u.get(idx)
+	case opBinGet:
+		b, err := u.readByte()
+		if err != nil {
+			return err
+		}
+		return u.get(int(b))
+	case opLongBinGet:
+		b, err := u.readN(4)
+		if err != nil {
+			return err
+		}
+		return u.get(int(binary.LittleEndian.Uint32(b)))
+	default:
+		return fmt.Errorf("unsupported pickle opcode 0x%02x", op)
+	}
+	return nil
+}
+
+func (u *unpickler) readByte() (byte, error) {
+	if u.pos >= len(u.data) {
+		return 0, errUnexpectedEnd
+	}
+	b := u.data[u.pos]
+	u.pos++
+	return b, nil
+}
+
+func (u *unpickler) readN(n uint64) ([]byte, error) {
+	if n > uint64(len(u.data)-u.pos) {
+		return nil, errUnexpectedEnd
+	}
+	b := u.data[u.pos : u.pos+int(n)]
+	u.pos += int(n)
+	return b, nil
+}
+
+func (u *unpickler) readLine() (string, error) {
+	idx := bytes.IndexByte(u.data[u.pos:], '\n')
+	if idx < 0 {
+		return "", errUnexpectedEnd
+	}
+	line := string(u.data[u.pos : u.pos+idx])
+	u.pos += idx + 1
+	return line, nil
+}
+
+func (u *unpickler) push(v interface{}) {
+	u.stack = append(u.stack, v)
+}
+
+func (u *unpickler) pop() (interface{}, error) {
+	if len(u.stack) == 0 {
+		return nil, errors.New("pickle stack underflow")
+	}
+	v := u.stack[len(u.stack)-1]
+	u.stack = u.stack[:len(u.stack)-1]
+	return v, nil
+}
+
+// popMark pops all items up to the last mark, returning them in the order
+// they were pushed.
+func (u *unpickler) popMark() ([]interface{}, error) {
+	for i := len(u.stack) - 1; i >= 0; i-- {
+		if _, ok := u.stack[i].(pickleMark); ok {
+			items := make([]interface{}, len(u.stack)-i-1)
+			copy(items, u.stack[i+1:])
+			u.stack = u.stack[:i]
+			return items, nil
+		}
+	}
+	return nil, errors.New("pickle mark not found")
+}
+
+func (u *unpickler) appendToList(items ...interface{}) error {
+	if len(u.stack) == 0 {
+		return errors.New("pickle stack underflow")
+	}
+	list, ok := u.stack[len(u.stack)-1].(*pickleList)
+	if !ok {
+		return fmt.Errorf("cannot append to pickle %T", u.stack[len(u.stack)-1])
+	}
+	list.items = append(list.items, items...)
+	return nil
+}
+
+func (u *unpickler) loadInt() error {
+	line, err := u.readLine()
+	if err != nil {
+		return err
+	}
+	// Protocol 0 encodes booleans as the "01" and "00" ints.
+	switch line {
+	case "01":
+		u.push(true)
+		return nil
+	case "00":
+		u.push(false)
+		return nil
+	}
+	i, err := strconv.ParseInt(line, 10, 64)
+	if err != nil {
+		return err
+	}
+	u.push(i)
+	return nil
+}
+
+// loadLong decodes a little-endian two's complement integer of n bytes.
+func (u *unpickler) loadLong(n int) error {
+	if n < 0 || n > 8 {
+		return fmt.Errorf("unsupported pickle long of %d bytes", n)
+	}
+	b, err := u.readN(uint64(n))
+	if err != nil {
+		return err
+	}
+	var v uint64
+	for i := n - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	if n > 0 && n < 8 && b[n-1]&0x80 != 0 {
+		// Sign extend negative values.
+		v |= math.MaxUint64 << (8 * uint(n))
+	}
+	u.push(int64(v))
+	return nil
+}
+
+func (u *unpickler) loadString(n uint64) error {
+	b, err := u.readN(n)
+	if err != nil {
+		return err
+	}
+	u.push(string(b))
+	return nil
+}
+
+func (u *unpickler) put(idx int) error {
+	if len(u.stack) == 0 {
+		return errors.New("pickle stack underflow")
+	}
+	u.memo[idx] = u.stack[len(u.stack)-1]
+	return nil
+}
+
+func (u *unpickler) get(idx int) error {
+	v, ok := u.memo[idx]
+	if !ok {
+		return fmt.Errorf("pickle memo key %d not found", idx)
+	}
+	u.push(v)
+	return nil
+}
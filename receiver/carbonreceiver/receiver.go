@@ -81,6 +81,11 @@ func New(
 		return nil, err
 	}
 
+	// Messages of parsers like "pickle" are framed for stream transports.
+	if _, ok := parser.(protocol.MessageParser); ok && strings.ToLower(config.Transport) == "udp" {
+		return nil, fmt.Errorf("parser %q for receiver %q requires the tcp transport", config.Parser.Type, config.Name())
+	}
+
 	// This should be the last one built, or if any other error is raised after
 	// it, the server should be closed.
 	server, err := buildTransportServer(config, logger)
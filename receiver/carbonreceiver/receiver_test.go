@@ -133,6 +133,45 @@ func Test_carbonreceiver_New(t *testing.T) {
 				nextConsumer: new(exportertest.SinkMetricsExporterOld),
 			},
 		},
+		{
+			name: "pickle_parser",
+			args: args{
+				config: Config{
+					ReceiverSettings: configmodels.ReceiverSettings{
+						NameVal: "pickle_parser_rcv",
+					},
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:2004",
+						Transport: "tcp",
+					},
+					Parser: &protocol.Config{
+						Type:   "pickle",
+						Config: &protocol.PickleConfig{},
+					},
+				},
+				nextConsumer: new(exportertest.SinkMetricsExporterOld),
+			},
+		},
+		{
+			name: "pickle_parser_udp",
+			args: args{
+				config: Config{
+					ReceiverSettings: configmodels.ReceiverSettings{
+						NameVal: "pickle_parser_udp_rcv",
+					},
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:2004",
+						Transport: "udp",
+					},
+					Parser: &protocol.Config{
+						Type:   "pickle",
+						Config: &protocol.PickleConfig{},
+					},
+				},
+				nextConsumer: new(exportertest.SinkMetricsExporterOld),
+			},
+			wantErr: errors.New("parser \"pickle\" for receiver \"pickle_parser_udp_rcv\" requires the tcp transport"),
+		},
 		{
 			name: "negative_tcp_idle_timeout",
 			args: args{
@@ -16,6 +16,7 @@ package transport
 
 import (
 	"context"
+	"encoding/binary"
 	"net"
 	"runtime"
 	"strconv"
@@ -109,6 +110,49 @@ func Test_Server_ListenAndServe(t *testing.T) {
 	}
 }
 
+func Test_TCPServer_MessageParser(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	svr, err := NewTCPServer(addr, 1*time.Second)
+	require.NoError(t, err)
+
+	mc := &mockMetricsConsumer{}
+	p, err := (&protocol.PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+	mr := NewMockReporter(2)
+
+	wgListenAndServe := sync.WaitGroup{}
+	wgListenAndServe.Add(1)
+	go func() {
+		defer wgListenAndServe.Done()
+		assert.Error(t, svr.ListenAndServe(p, mc, mr))
+	}()
+
+	runtime.Gosched()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	// pickle.dumps([("test.metric", (1582230020, 1.5))], protocol=2)
+	message := []byte("\x80\x02]q\x00X\x0b\x00\x00\x00test.metricq\x01J\x04\xeaN^G?\xf8\x00\x00\x00\x00\x00\x00\x86q\x02\x86q\x03a.")
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(len(message)))
+	for i := 0; i < 2; i++ {
+		_, err = conn.Write(append(header, message...))
+		require.NoError(t, err)
+	}
+
+	mr.WaitAllOnMetricsProcessedCalls()
+	assert.NoError(t, conn.Close())
+	assert.NoError(t, svr.Close())
+	wgListenAndServe.Wait()
+
+	require.Equal(t, 2, len(mc.md))
+	for _, md := range mc.md {
+		require.Equal(t, 1, len(md.Metrics))
+		assert.Equal(t, "test.metric", md.Metrics[0].GetMetricDescriptor().GetName())
+	}
+}
+
 type mockMetricsConsumer struct {
 	sync.Mutex
 	md []consumerdata.MetricsData
@@ -17,6 +17,7 @@ package transport
 import (
 	"bufio"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"net"
@@ -35,6 +36,11 @@ import (
 const (
 	// TCPIdleTimeoutDefault is the default timeout for idle TCP connections.
 	TCPIdleTimeoutDefault = 30 * time.Second
+
+	// maxMessageSize is the maximum size of a message handled by a
+	// protocol.MessageParser, messages are rejected above it (same limit
+	// used by Graphite's carbon).
+	maxMessageSize = 1 << 20
 )
 
 type tcpServer struct {
@@ -144,6 +150,11 @@ func (t *tcpServer) handleConnection(
 	conn net.Conn,
 ) {
 	defer conn.Close()
+	if mp, ok := p.(protocol.MessageParser); ok {
+		t.handleMessages(mp, nextConsumer, conn)
+		return
+	}
+
 	var span *trace.Span
 	reader := bufio.NewReader(conn)
 	for {
@@ -222,3 +233,70 @@ func (t *tcpServer) handleConnection(
 		}
 	}
 }
+
+// handleMessages handles a connection of a protocol in which each message is
+// prefixed by its length as a 4 bytes big-endian unsigned integer, e.g.
+// Graphite's pickle protocol.
+func (t *tcpServer) handleMessages(
+	p protocol.MessageParser,
+	nextConsumer consumer.MetricsConsumerOld,
+	conn net.Conn,
+) {
+	reader := bufio.NewReader(conn)
+	header := make([]byte, 4)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(t.idleTimeout)); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - conn.SetDeadLine error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		// Either the connection was closed, by client or server, or an idle
+		// timeout happened: nothing else to do.
+		if _, err := io.ReadFull(reader, header); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		size := binary.BigEndian.Uint32(header)
+		if size > maxMessageSize {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - message of %d bytes exceeds the maximum of %d bytes",
+				t.ln.Addr(),
+				size,
+				maxMessageSize)
+			return
+		}
+
+		message := make([]byte, size)
+		if _, err := io.ReadFull(reader, message); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		ctx := t.reporter.OnDataReceived(context.Background())
+		metrics, errs := p.ParseMessage(message)
+		for _, err := range errs {
+			t.reporter.OnTranslationError(ctx, err)
+		}
+
+		md := consumerdata.MetricsData{
+			Metrics: metrics,
+		}
+		err := nextConsumer.ConsumeMetricsData(ctx, md)
+		t.reporter.OnMetricsProcessed(ctx, len(metrics)+len(errs), len(errs), err)
+		if err != nil {
+			// See handleConnection: close the connection to report the error
+			// back to the client.
+			return
+		}
+	}
+}
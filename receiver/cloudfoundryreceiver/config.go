@@ -0,0 +1,76 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundryreceiver
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// RLPGatewayConfig defines the connection to the Reverse Log Proxy gateway.
+type RLPGatewayConfig struct {
+	// Endpoint is the URL of the RLP gateway, usually https://log-stream.<system domain>.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// ShardID identifies the receiver to the gateway, receivers sharing the
+	// same shard ID split the envelopes between them.
+	ShardID string `mapstructure:"shard_id"`
+
+	// InsecureSkipVerify disables the verification of the gateway certificate.
+	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+}
+
+// UAAConfig defines the credentials used to get a token from the UAA server.
+type UAAConfig struct {
+	// Endpoint is the URL of the UAA server, usually https://uaa.<system domain>.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Username is the ID of the UAA client, it needs the logs.admin authority.
+	Username string `mapstructure:"username"`
+
+	// Password is the secret of the UAA client.
+	Password string `mapstructure:"password"`
+
+	// InsecureSkipVerify disables the verification of the UAA certificate.
+	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+}
+
+// Config defines configuration for the Cloud Foundry receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	RLPGateway RLPGatewayConfig `mapstructure:"rlp_gateway"`
+	UAA        UAAConfig        `mapstructure:"uaa"`
+}
+
+func (c *Config) validate() error {
+	if c.RLPGateway.Endpoint == "" {
+		return errors.New("rlp_gateway.endpoint must be specified")
+	}
+	if c.RLPGateway.ShardID == "" {
+		return errors.New("rlp_gateway.shard_id must be specified")
+	}
+	if c.UAA.Endpoint == "" {
+		return errors.New("uaa.endpoint must be specified")
+	}
+	if c.UAA.Username == "" {
+		return errors.New("uaa.username must be specified")
+	}
+	if c.UAA.Password == "" {
+		return errors.New("uaa.password must be specified")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundryreceiver
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r1 := cfg.Receivers["cloudfoundry"]
+	assert.Equal(t, r1, factory.CreateDefaultConfig())
+
+	r2 := cfg.Receivers["cloudfoundry/custom"].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "cloudfoundry/custom",
+			},
+			RLPGateway: RLPGatewayConfig{
+				Endpoint:           "https://log-stream.sys.example.com",
+				ShardID:            "otel-test",
+				InsecureSkipVerify: true,
+			},
+			UAA: UAAConfig{
+				Endpoint:           "https://uaa.sys.example.com",
+				Username:           "otel",
+				Password:           "secret",
+				InsecureSkipVerify: true,
+			},
+		})
+	assert.NoError(t, r2.validate())
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.validate(), "uaa.username must be specified")
+
+	cfg.UAA.Username = "otel"
+	assert.EqualError(t, cfg.validate(), "uaa.password must be specified")
+
+	cfg.UAA.Password = "secret"
+	assert.NoError(t, cfg.validate())
+
+	cfg.RLPGateway.ShardID = ""
+	assert.EqualError(t, cfg.validate(), "rlp_gateway.shard_id must be specified")
+}
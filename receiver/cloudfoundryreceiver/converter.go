@@ -0,0 +1,136 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundryreceiver
+
+import (
+	"sort"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+const (
+	// labelPrefix prefixes the envelope tags, e.g. org.cloudfoundry.app_name.
+	labelPrefix = "org.cloudfoundry."
+
+	sourceIDLabel   = labelPrefix + "source_id"
+	instanceIDLabel = labelPrefix + "instance_id"
+
+	// originTag is the tag holding the name of the component emitting the
+	// envelope, it prefixes the metric names.
+	originTag = "origin"
+)
+
+// convertEnvelopes converts the counter and gauge envelopes to metrics, the
+// other envelopes are ignored. The app, org and space of the envelopes
+// emitted for an application are part of their tags.
+func convertEnvelopes(envelopes []*envelope) []*metricspb.Metric {
+	var metrics []*metricspb.Metric
+	for _, env := range envelopes {
+		switch {
+		case env.Counter != nil:
+			metrics = append(metrics, convertCounter(env))
+		case env.Gauge != nil:
+			metrics = append(metrics, convertGauge(env)...)
+		}
+	}
+	return metrics
+}
+
+func convertCounter(env *envelope) *metricspb.Metric {
+	keys, values := labels(env)
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      metricName(env, env.Counter.Name),
+			Type:      metricspb.MetricDescriptor_CUMULATIVE_INT64,
+			LabelKeys: keys,
+		},
+		Timeseries: []*metricspb.TimeSeries{{
+			LabelValues: values,
+			Points: []*metricspb.Point{{
+				Timestamp: toTimestamp(int64(env.Timestamp)),
+				Value:     &metricspb.Point_Int64Value{Int64Value: int64(env.Counter.Total)},
+			}},
+		}},
+	}
+}
+
+func convertGauge(env *envelope) []*metricspb.Metric {
+	names := make([]string, 0, len(env.Gauge.Metrics))
+	for name := range env.Gauge.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys, values := labels(env)
+	metrics := make([]*metricspb.Metric, 0, len(names))
+	for _, name := range names {
+		value := env.Gauge.Metrics[name]
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: &metricspb.MetricDescriptor{
+				Name:      metricName(env, name),
+				Unit:      value.Unit,
+				Type:      metricspb.MetricDescriptor_GAUGE_DOUBLE,
+				LabelKeys: keys,
+			},
+			Timeseries: []*metricspb.TimeSeries{{
+				LabelValues: values,
+				Points: []*metricspb.Point{{
+					Timestamp: toTimestamp(int64(env.Timestamp)),
+					Value:     &metricspb.Point_DoubleValue{DoubleValue: value.Value},
+				}},
+			}},
+		})
+	}
+	return metrics
+}
+
+func metricName(env *envelope, name string) string {
+	if origin := env.Tags[originTag]; origin != "" {
+		return origin + "." + name
+	}
+	return name
+}
+
+func labels(env *envelope) ([]*metricspb.LabelKey, []*metricspb.LabelValue) {
+	tags := make([]string, 0, len(env.Tags))
+	for tag := range env.Tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	keys := make([]*metricspb.LabelKey, 0, len(tags)+2)
+	values := make([]*metricspb.LabelValue, 0, len(tags)+2)
+	add := func(key, value string) {
+		keys = append(keys, &metricspb.LabelKey{Key: key})
+		values = append(values, &metricspb.LabelValue{Value: value, HasValue: true})
+	}
+
+	add(sourceIDLabel, env.SourceID)
+	if env.InstanceID != "" {
+		add(instanceIDLabel, env.InstanceID)
+	}
+	for _, tag := range tags {
+		add(labelPrefix+tag, env.Tags[tag])
+	}
+	return keys, values
+}
+
+func toTimestamp(nanos int64) *timestamp.Timestamp {
+	return &timestamp.Timestamp{
+		Seconds: nanos / 1e9,
+		Nanos:   int32(nanos % 1e9),
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundryreceiver
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertEnvelopes(t *testing.T) {
+	envelopes, err := decodeBatch([]byte(sampleBatch))
+	require.NoError(t, err)
+	// log envelopes are ignored
+	envelopes = append(envelopes, &envelope{SourceID: "app-guid"})
+
+	metrics := convertEnvelopes(envelopes)
+	require.Len(t, metrics, 3)
+
+	cpu := metrics[0]
+	assert.Equal(t, "rep.cpu", cpu.MetricDescriptor.Name)
+	assert.Equal(t, "percentage", cpu.MetricDescriptor.Unit)
+	assert.Equal(t, metricspb.MetricDescriptor_GAUGE_DOUBLE, cpu.MetricDescriptor.Type)
+	assert.Equal(t, []*metricspb.LabelKey{
+		{Key: "org.cloudfoundry.source_id"},
+		{Key: "org.cloudfoundry.instance_id"},
+		{Key: "org.cloudfoundry.app_name"},
+		{Key: "org.cloudfoundry.origin"},
+	}, cpu.MetricDescriptor.LabelKeys)
+	assert.Equal(t, []*metricspb.LabelValue{
+		{Value: "app-guid", HasValue: true},
+		{Value: "1", HasValue: true},
+		{Value: "web", HasValue: true},
+		{Value: "rep", HasValue: true},
+	}, cpu.Timeseries[0].LabelValues)
+	point := cpu.Timeseries[0].Points[0]
+	assert.Equal(t, int64(1581447006), point.Timestamp.Seconds)
+	assert.Equal(t, int32(352020890), point.Timestamp.Nanos)
+	assert.Equal(t, 0.5, point.GetDoubleValue())
+
+	memory := metrics[1]
+	assert.Equal(t, "rep.memory", memory.MetricDescriptor.Name)
+	assert.Equal(t, 1024.0, memory.Timeseries[0].Points[0].GetDoubleValue())
+
+	requests := metrics[2]
+	assert.Equal(t, "gorouter.total_requests", requests.MetricDescriptor.Name)
+	assert.Equal(t, metricspb.MetricDescriptor_CUMULATIVE_INT64, requests.MetricDescriptor.Type)
+	assert.Len(t, requests.MetricDescriptor.LabelKeys, 2)
+	assert.Equal(t, int64(100), requests.Timeseries[0].Points[0].GetInt64Value())
+}
@@ -0,0 +1,145 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundryreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// The types below mirror the JSON encoding of the loggregator v2 envelopes
+// sent by the RLP gateway, see
+// https://github.com/cloudfoundry/loggregator-api/blob/master/v2/envelope.proto
+
+type envelopeBatch struct {
+	Batch []*envelope `json:"batch"`
+}
+
+type envelope struct {
+	Timestamp  jsonInt64         `json:"timestamp"`
+	SourceID   string            `json:"source_id"`
+	InstanceID string            `json:"instance_id"`
+	Tags       map[string]string `json:"tags"`
+	Counter    *counter          `json:"counter"`
+	Gauge      *gauge            `json:"gauge"`
+}
+
+type counter struct {
+	Name  string     `json:"name"`
+	Delta jsonUint64 `json:"delta"`
+	Total jsonUint64 `json:"total"`
+}
+
+type gauge struct {
+	Metrics map[string]gaugeValue `json:"metrics"`
+}
+
+type gaugeValue struct {
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+}
+
+// jsonInt64 and jsonUint64 decode the 64 bits integers which the proto3 JSON
+// mapping encodes as strings.
+type jsonInt64 int64
+
+func (i *jsonInt64) UnmarshalJSON(data []byte) error {
+	v, err := strconv.ParseInt(string(bytes.Trim(data, `"`)), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = jsonInt64(v)
+	return nil
+}
+
+type jsonUint64 uint64
+
+func (i *jsonUint64) UnmarshalJSON(data []byte) error {
+	v, err := strconv.ParseUint(string(bytes.Trim(data, `"`)), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = jsonUint64(v)
+	return nil
+}
+
+// Events sent by the RLP gateway besides the envelope batches.
+const (
+	heartbeatEvent = "heartbeat"
+	closingEvent   = "closing"
+)
+
+// eventStream reads the server-sent events of the RLP gateway.
+type eventStream struct {
+	reader *bufio.Reader
+}
+
+type event struct {
+	name string
+	data []byte
+}
+
+func newEventStream(r io.Reader) *eventStream {
+	return &eventStream{reader: bufio.NewReader(r)}
+}
+
+// next returns the next event, io.EOF once the stream is over.
+func (s *eventStream) next() (*event, error) {
+	ev := &event{}
+	hasData := false
+	for {
+		line, err := s.reader.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF && (hasData || ev.name != "") {
+				return ev, nil
+			}
+			return nil, err
+		}
+		line = bytes.TrimRight(line, "\r\n")
+
+		if len(line) == 0 {
+			if hasData || ev.name != "" {
+				return ev, nil
+			}
+			continue
+		}
+
+		field, value := line, []byte(nil)
+		if idx := bytes.IndexByte(line, ':'); idx >= 0 {
+			field, value = line[:idx], bytes.TrimPrefix(line[idx+1:], []byte(" "))
+		}
+		switch string(field) {
+		case "event":
+			ev.name = string(value)
+		case "data":
+			if hasData {
+				ev.data = append(ev.data, '\n')
+			}
+			ev.data = append(ev.data, value...)
+			hasData = true
+		}
+	}
+}
+
+func decodeBatch(data []byte) ([]*envelope, error) {
+	var batch envelopeBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, err
+	}
+	return batch.Batch, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundryreceiver
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleBatch = `{"batch":[` +
+	`{"timestamp":"1581447006352020890","source_id":"app-guid","instance_id":"1","tags":{"app_name":"web","origin":"rep"},` +
+	`"gauge":{"metrics":{"cpu":{"unit":"percentage","value":0.5},"memory":{"unit":"bytes","value":1024}}}},` +
+	`{"timestamp":"1581447006352020890","source_id":"gorouter","tags":{"origin":"gorouter"},` +
+	`"counter":{"name":"total_requests","delta":"2","total":"100"}}]}`
+
+func TestEventStream(t *testing.T) {
+	stream := newEventStream(strings.NewReader(
+		"event: heartbeat\ndata: 1581447006\n\n" +
+			"data: " + sampleBatch + "\r\n\r\n" +
+			": comment\n\n" +
+			"data: a\ndata: b\n\n" +
+			"event: closing\ndata: closing\n"))
+
+	ev, err := stream.next()
+	require.NoError(t, err)
+	assert.Equal(t, heartbeatEvent, ev.name)
+
+	ev, err = stream.next()
+	require.NoError(t, err)
+	assert.Equal(t, "", ev.name)
+	assert.Equal(t, sampleBatch, string(ev.data))
+
+	ev, err = stream.next()
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb", string(ev.data))
+
+	ev, err = stream.next()
+	require.NoError(t, err)
+	assert.Equal(t, closingEvent, ev.name)
+
+	_, err = stream.next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecodeBatch(t *testing.T) {
+	envelopes, err := decodeBatch([]byte(sampleBatch))
+	require.NoError(t, err)
+	require.Len(t, envelopes, 2)
+
+	gauge := envelopes[0]
+	assert.EqualValues(t, 1581447006352020890, gauge.Timestamp)
+	assert.Equal(t, "app-guid", gauge.SourceID)
+	assert.Equal(t, "1", gauge.InstanceID)
+	assert.Nil(t, gauge.Counter)
+	require.NotNil(t, gauge.Gauge)
+	assert.Equal(t, gaugeValue{Unit: "percentage", Value: 0.5}, gauge.Gauge.Metrics["cpu"])
+
+	counter := envelopes[1]
+	require.NotNil(t, counter.Counter)
+	assert.Equal(t, "total_requests", counter.Counter.Name)
+	assert.EqualValues(t, 2, counter.Counter.Delta)
+	assert.EqualValues(t, 100, counter.Counter.Total)
+
+	_, err = decodeBatch([]byte(`{"batch":[{"timestamp":"abc"}]}`))
+	assert.Error(t, err)
+}
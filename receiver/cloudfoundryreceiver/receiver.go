@@ -0,0 +1,196 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundryreceiver
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const (
+	transport = "http"
+
+	rlpReadPath = "/v2/read"
+
+	uaaTimeout = 10 * time.Second
+
+	// reconnectInterval is the delay before reconnecting to the gateway once
+	// a stream failed or was closed.
+	reconnectInterval = 5 * time.Second
+)
+
+var _ component.MetricsReceiver = (*cloudFoundryReceiver)(nil)
+
+// cloudFoundryReceiver implements the component.MetricsReceiver for Cloud Foundry.
+type cloudFoundryReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	uaa          *uaaClient
+	httpClient   *http.Client
+	cancel       context.CancelFunc
+	done         chan struct{}
+}
+
+// newCloudFoundryReceiver creates the Cloud Foundry receiver with the given parameters.
+func newCloudFoundryReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	uaaHTTPClient := newHTTPClient(config.UAA.InsecureSkipVerify)
+	uaaHTTPClient.Timeout = uaaTimeout
+
+	r := &cloudFoundryReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		uaa:          newUAAClient(&config.UAA, uaaHTTPClient),
+		// the stream is long lived, it must not have a timeout.
+		httpClient: newHTTPClient(config.RLPGateway.InsecureSkipVerify),
+		done:       make(chan struct{}),
+	}
+	return r, nil
+}
+
+// Start connects to the RLP gateway and streams the envelopes until shutdown.
+func (cfr *cloudFoundryReceiver) Start(ctx context.Context, host component.Host) error {
+	ctx, cfr.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, cfr.config.Name()))
+	go func() {
+		defer close(cfr.done)
+		for {
+			err := cfr.streamEnvelopes(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				cfr.logger.Warn("Cloud Foundry RLP gateway stream failed", zap.Error(err))
+			}
+
+			select {
+			case <-time.After(reconnectInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the cloudfoundry receiver.
+func (cfr *cloudFoundryReceiver) Shutdown(context.Context) error {
+	if cfr.cancel == nil {
+		return nil
+	}
+	cfr.cancel()
+	<-cfr.done
+	return nil
+}
+
+// streamEnvelopes reads the gauge and counter envelopes from the gateway
+// until the stream ends.
+func (cfr *cloudFoundryReceiver) streamEnvelopes(ctx context.Context) error {
+	token, err := cfr.uaa.token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get uaa token: %w", err)
+	}
+
+	query := url.Values{"shard_id": {cfr.config.RLPGateway.ShardID}}
+	// the envelope types are selected by the presence of the parameters.
+	rawQuery := query.Encode() + "&counter&gauge"
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(cfr.config.RLPGateway.Endpoint, "/")+rlpReadPath+"?"+rawQuery, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", token)
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := cfr.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("rlp gateway request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	stream := newEventStream(resp.Body)
+	for {
+		ev, err := stream.next()
+		if err != nil {
+			return err
+		}
+
+		switch ev.name {
+		case heartbeatEvent:
+			continue
+		case closingEvent:
+			return nil
+		}
+
+		envelopes, err := decodeBatch(ev.data)
+		if err != nil {
+			cfr.logger.Debug("Failed to decode envelope batch", zap.Error(err))
+			continue
+		}
+		cfr.consumeEnvelopes(ctx, envelopes)
+	}
+}
+
+func (cfr *cloudFoundryReceiver) consumeEnvelopes(ctx context.Context, envelopes []*envelope) {
+	metrics := convertEnvelopes(envelopes)
+	if len(metrics) == 0 {
+		return
+	}
+
+	md := consumerdata.MetricsData{Metrics: metrics}
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(md)
+	err := cfr.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md}))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+}
+
+func newHTTPClient(insecureSkipVerify bool) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: insecureSkipVerify,
+			},
+		},
+	}
+}
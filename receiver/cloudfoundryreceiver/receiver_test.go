@@ -0,0 +1,111 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundryreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func newRLPGatewayServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, rlpReadPath, r.URL.Path)
+		assert.Equal(t, "opentelemetry", r.URL.Query().Get("shard_id"))
+		_, hasCounter := r.URL.Query()["counter"]
+		assert.True(t, hasCounter)
+		if r.Header.Get("Authorization") != "bearer abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("event: heartbeat\ndata: 1581447006\n\n"))
+		w.Write([]byte("data: " + sampleBatch + "\n\n"))
+		w.Write([]byte("event: closing\ndata: closing\n\n"))
+	}))
+}
+
+func testConfig(uaaEndpoint, rlpEndpoint string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.UAA.Endpoint = uaaEndpoint
+	cfg.UAA.Username = "otel"
+	cfg.UAA.Password = "secret"
+	cfg.RLPGateway.Endpoint = rlpEndpoint
+	return cfg
+}
+
+func TestStreamEnvelopes(t *testing.T) {
+	uaa := newUAAServer(t)
+	defer uaa.Close()
+	rlp := newRLPGatewayServer(t)
+	defer rlp.Close()
+
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newCloudFoundryReceiver(zap.NewNop(), testConfig(uaa.URL, rlp.URL), sink)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*cloudFoundryReceiver)
+	require.NoError(t, r.streamEnvelopes(context.Background()))
+
+	metrics := sink.AllMetrics()
+	require.Len(t, metrics, 1)
+	mds := pdatautil.MetricsToMetricsData(metrics[0])
+	require.Len(t, mds, 1)
+	assert.Len(t, mds[0].Metrics, 3)
+}
+
+func TestStreamEnvelopesUnauthorized(t *testing.T) {
+	uaa := newUAAServer(t)
+	defer uaa.Close()
+	rlp := newRLPGatewayServer(t)
+	defer rlp.Close()
+
+	cfg := testConfig(uaa.URL, rlp.URL)
+	cfg.UAA.Password = "wrong"
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newCloudFoundryReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*cloudFoundryReceiver)
+	require.Error(t, r.streamEnvelopes(context.Background()))
+	assert.Len(t, sink.AllMetrics(), 0)
+}
+
+func TestReceiverStartShutdown(t *testing.T) {
+	uaa := newUAAServer(t)
+	defer uaa.Close()
+	rlp := newRLPGatewayServer(t)
+	defer rlp.Close()
+
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newCloudFoundryReceiver(zap.NewNop(), testConfig(uaa.URL, rlp.URL), sink)
+	require.NoError(t, err)
+
+	require.NoError(t, metricsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, metricsReceiver.Shutdown(context.Background()))
+}
@@ -0,0 +1,93 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundryreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const uaaTokenPath = "/oauth/token"
+
+// uaaClient gets the tokens authorizing the receiver to read from the RLP
+// gateway using the client credentials grant.
+type uaaClient struct {
+	endpoint   string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+type uaaTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+func newUAAClient(cfg *UAAConfig, httpClient *http.Client) *uaaClient {
+	return &uaaClient{
+		endpoint:   strings.TrimSuffix(cfg.Endpoint, "/"),
+		username:   cfg.Username,
+		password:   cfg.Password,
+		httpClient: httpClient,
+	}
+}
+
+// token returns the value of the Authorization header, e.g. "bearer <token>".
+func (c *uaaClient) token(ctx context.Context) (string, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.username},
+		"response_type": {"token"},
+	}
+	req, err := http.NewRequest(http.MethodPost, c.endpoint+uaaTokenPath, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("uaa token request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var token uaaTokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("failed to decode uaa token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("uaa response has no access token")
+	}
+	tokenType := token.TokenType
+	if tokenType == "" {
+		tokenType = "bearer"
+	}
+	return tokenType + " " + token.AccessToken, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundryreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUAAServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, uaaTokenPath, r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "otel" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"unauthorized"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"abc","token_type":"bearer","expires_in":43199}`))
+	}))
+}
+
+func TestUAAToken(t *testing.T) {
+	server := newUAAServer(t)
+	defer server.Close()
+
+	client := newUAAClient(&UAAConfig{Endpoint: server.URL + "/", Username: "otel", Password: "secret"}, server.Client())
+	token, err := client.token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "bearer abc", token)
+}
+
+func TestUAATokenUnauthorized(t *testing.T) {
+	server := newUAAServer(t)
+	defer server.Close()
+
+	client := newUAAClient(&UAAConfig{Endpoint: server.URL, Username: "otel", Password: "wrong"}, server.Client())
+	_, err := client.token(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
@@ -0,0 +1,472 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec, used by the collectd protocol to check encrypted data
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// This file implements the decoding of collectd's binary network protocol, see
+// https://collectd.org/wiki/index.php/Binary_protocol.
+
+const (
+	securityLevelNone    = "none"
+	securityLevelSign    = "sign"
+	securityLevelEncrypt = "encrypt"
+)
+
+// trust is the level of security of the data being parsed, data is only
+// accepted if its trust is at least the one of the configured security level.
+type trust int
+
+const (
+	trustNone trust = iota
+	trustSigned
+	trustEncrypted
+)
+
+const (
+	partHost           = 0x0000
+	partTime           = 0x0001
+	partPlugin         = 0x0002
+	partPluginInstance = 0x0003
+	partType           = 0x0004
+	partTypeInstance   = 0x0005
+	partValues         = 0x0006
+	partInterval       = 0x0007
+	partTimeHR         = 0x0008
+	partIntervalHR     = 0x0009
+	partMessage        = 0x0100
+	partSeverity       = 0x0101
+	partSignature      = 0x0200
+	partEncryption     = 0x0210
+
+	partHeaderLen = 4
+)
+
+// Data source types of the values part.
+const (
+	dsTypeCounter  = 0
+	dsTypeGauge    = 1
+	dsTypeDerive   = 2
+	dsTypeAbsolute = 3
+)
+
+var dsTypeNames = map[byte]string{
+	dsTypeCounter:  collectDMetricCounter,
+	dsTypeGauge:    collectDMetricGauge,
+	dsTypeDerive:   collectDMetricDerive,
+	dsTypeAbsolute: collectDMetricAbsolute,
+}
+
+var severities = map[int64]string{
+	1: "FAILURE",
+	2: "WARNING",
+	4: "OKAY",
+}
+
+var errPartTooShort = errors.New("part shorter than its header")
+
+// binaryParser converts packets of the binary protocol into records.
+type binaryParser struct {
+	minTrust  trust
+	passwords map[string]string
+	typesDB   map[string][]string
+}
+
+func newBinaryParser(securityLevel string, authFile string, typesDBFiles []string) (*binaryParser, error) {
+	p := &binaryParser{}
+	switch strings.ToLower(securityLevel) {
+	case "", securityLevelNone:
+		p.minTrust = trustNone
+	case securityLevelSign:
+		p.minTrust = trustSigned
+	case securityLevelEncrypt:
+		p.minTrust = trustEncrypted
+	default:
+		return nil, fmt.Errorf("invalid security_level %q, must be one of %q, %q or %q",
+			securityLevel, securityLevelNone, securityLevelSign, securityLevelEncrypt)
+	}
+
+	if authFile != "" {
+		var err error
+		if p.passwords, err = readAuthFile(authFile); err != nil {
+			return nil, err
+		}
+	} else if p.minTrust > trustNone {
+		return nil, fmt.Errorf("auth_file is required with security_level %q", securityLevel)
+	}
+
+	p.typesDB = make(map[string][]string)
+	for _, file := range typesDBFiles {
+		if err := readTypesDB(file, p.typesDB); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// packetState holds the values of the parts that apply to the following
+// values and notification parts of a packet.
+type packetState struct {
+	host           string
+	time           float64
+	interval       float64
+	plugin         string
+	pluginInstance string
+	typeS          string
+	typeInstance   string
+	severity       string
+}
+
+// parse decodes a packet into records. Values that do not meet the configured
+// security level are dropped.
+func (p *binaryParser) parse(packet []byte) ([]collectDRecord, error) {
+	return p.parseParts(packet, trustNone, nil)
+}
+
+func (p *binaryParser) parseParts(buf []byte, t trust, records []collectDRecord) ([]collectDRecord, error) {
+	state := packetState{}
+	for len(buf) > 0 {
+		if len(buf) < partHeaderLen {
+			return records, errPartTooShort
+		}
+		typ := binary.BigEndian.Uint16(buf[0:2])
+		partLen := int(binary.BigEndian.Uint16(buf[2:4]))
+		if partLen < partHeaderLen || partLen > len(buf) {
+			return records, fmt.Errorf("invalid length %d of part 0x%04x", partLen, typ)
+		}
+		part := buf[partHeaderLen:partLen]
+		rest := buf[partLen:]
+
+		var err error
+		switch typ {
+		case partHost:
+			state.host, err = parseString(part)
+		case partPlugin:
+			state.plugin, err = parseString(part)
+		case partPluginInstance:
+			state.pluginInstance, err = parseString(part)
+		case partType:
+			state.typeS, err = parseString(part)
+		case partTypeInstance:
+			state.typeInstance, err = parseString(part)
+		case partTime, partInterval, partTimeHR, partIntervalHR:
+			var v uint64
+			if v, err = parseUint64(part); err != nil {
+				break
+			}
+			seconds := float64(v)
+			if typ == partTimeHR || typ == partIntervalHR {
+				// High resolution times are in units of 2^-30 seconds.
+				seconds /= 1 << 30
+			}
+			if typ == partTime || typ == partTimeHR {
+				state.time = seconds
+			} else {
+				state.interval = seconds
+			}
+		case partSeverity:
+			var v uint64
+			if v, err = parseUint64(part); err == nil {
+				state.severity = severities[int64(v)]
+			}
+		case partMessage:
+			var message string
+			if message, err = parseString(part); err == nil && t >= p.minTrust {
+				records = append(records, state.event(message))
+			}
+		case partValues:
+			var record collectDRecord
+			if record, err = p.parseValues(part, &state); err == nil && t >= p.minTrust {
+				records = append(records, record)
+			}
+		case partSignature:
+			// The signature covers the rest of the packet.
+			var signedTrust trust
+			if signedTrust, err = p.verifySignature(part, rest); err != nil {
+				return records, err
+			}
+			return p.parseParts(rest, maxTrust(t, signedTrust), records)
+		case partEncryption:
+			var plaintext []byte
+			if plaintext, err = p.decrypt(part); err != nil {
+				return records, err
+			}
+			if records, err = p.parseParts(plaintext, trustEncrypted, records); err != nil {
+				return records, err
+			}
+		default:
+			// Unknown parts are ignored, as collectd does.
+		}
+		if err != nil {
+			return records, fmt.Errorf("invalid part 0x%04x: %v", typ, err)
+		}
+
+		buf = rest
+	}
+	return records, nil
+}
+
+func (p *binaryParser) parseValues(part []byte, state *packetState) (collectDRecord, error) {
+	if len(part) < 2 {
+		return collectDRecord{}, errPartTooShort
+	}
+	num := int(binary.BigEndian.Uint16(part[0:2]))
+	if len(part) != 2+num*9 {
+		return collectDRecord{}, fmt.Errorf("invalid length for %d values", num)
+	}
+	types := part[2 : 2+num]
+	data := part[2+num:]
+
+	dsNames := p.typesDB[state.typeS]
+	if len(dsNames) != num {
+		dsNames = defaultDsNames(num)
+	}
+
+	record := state.record()
+	for i := 0; i < num; i++ {
+		raw := data[i*8 : (i+1)*8]
+		var value string
+		switch types[i] {
+		case dsTypeCounter, dsTypeAbsolute:
+			value = strconv.FormatUint(binary.BigEndian.Uint64(raw), 10)
+		case dsTypeDerive:
+			value = strconv.FormatInt(int64(binary.BigEndian.Uint64(raw)), 10)
+		case dsTypeGauge:
+			// Gauges are sent in the x86 byte order.
+			f := math.Float64frombits(binary.LittleEndian.Uint64(raw))
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				// Unknown values, they are skipped by the conversion to metrics.
+				record.Values = append(record.Values, nil)
+				record.Dsnames = append(record.Dsnames, stringPtr(dsNames[i]))
+				record.Dstypes = append(record.Dstypes, stringPtr(collectDMetricGauge))
+				continue
+			}
+			value = strconv.FormatFloat(f, 'g', -1, 64)
+		default:
+			return collectDRecord{}, fmt.Errorf("unknown data source type %d", types[i])
+		}
+		number := json.Number(value)
+		record.Values = append(record.Values, &number)
+		record.Dsnames = append(record.Dsnames, stringPtr(dsNames[i]))
+		record.Dstypes = append(record.Dstypes, stringPtr(dsTypeNames[types[i]]))
+	}
+	return record, nil
+}
+
+// verifySignature checks the HMAC-SHA256 signature of the signed data, which is
+// the username followed by the rest of the packet.
+func (p *binaryParser) verifySignature(part []byte, signed []byte) (trust, error) {
+	if len(part) < sha256.Size {
+		return trustNone, errPartTooShort
+	}
+	signature := part[:sha256.Size]
+	username := part[sha256.Size:]
+
+	password, ok := p.passwords[string(username)]
+	if !ok {
+		if p.minTrust > trustNone {
+			return trustNone, fmt.Errorf("unknown user %q for signed data", username)
+		}
+		// Signed data is accepted without verification when security isn't required.
+		return trustNone, nil
+	}
+
+	mac := hmac.New(sha256.New, []byte(password))
+	mac.Write(username)
+	mac.Write(signed)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return trustNone, fmt.Errorf("invalid signature for user %q", username)
+	}
+	return trustSigned, nil
+}
+
+// decrypt decrypts the AES-256 OFB encrypted data of an encryption part and
+// verifies its SHA-1 checksum.
+func (p *binaryParser) decrypt(part []byte) ([]byte, error) {
+	if len(part) < 2 {
+		return nil, errPartTooShort
+	}
+	usernameLen := int(binary.BigEndian.Uint16(part[0:2]))
+	if len(part) < 2+usernameLen+aes.BlockSize+sha1.Size {
+		return nil, errPartTooShort
+	}
+	username := string(part[2 : 2+usernameLen])
+	iv := part[2+usernameLen : 2+usernameLen+aes.BlockSize]
+	encrypted := part[2+usernameLen+aes.BlockSize:]
+
+	password, ok := p.passwords[username]
+	if !ok {
+		return nil, fmt.Errorf("unknown user %q for encrypted data", username)
+	}
+
+	key := sha256.Sum256([]byte(password))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	decrypted := make([]byte, len(encrypted))
+	cipher.NewOFB(block, iv).XORKeyStream(decrypted, encrypted)
+
+	checksum := decrypted[:sha1.Size]
+	plaintext := decrypted[sha1.Size:]
+	// #nosec, the checksum is defined by the collectd protocol
+	if sum := sha1.Sum(plaintext); !hmac.Equal(checksum, sum[:]) {
+		return nil, fmt.Errorf("invalid checksum of encrypted data for user %q", username)
+	}
+	return plaintext, nil
+}
+
+func (s *packetState) record() collectDRecord {
+	record := collectDRecord{
+		Host:           stringPtr(s.host),
+		Plugin:         stringPtr(s.plugin),
+		PluginInstance: stringPtr(s.pluginInstance),
+		TypeS:          stringPtr(s.typeS),
+		TypeInstance:   stringPtr(s.typeInstance),
+	}
+	if s.time != 0 {
+		t := s.time
+		record.Time = &t
+	}
+	if s.interval != 0 {
+		interval := s.interval
+		record.Interval = &interval
+	}
+	return record
+}
+
+func (s *packetState) event(message string) collectDRecord {
+	record := s.record()
+	record.Message = &message
+	severity := s.severity
+	record.Severity = &severity
+	if record.Time == nil {
+		// Events are identified by the presence of time, severity and message.
+		t := 0.0
+		record.Time = &t
+	}
+	return record
+}
+
+func parseString(part []byte) (string, error) {
+	if len(part) == 0 || part[len(part)-1] != 0 {
+		return "", errors.New("string is not null terminated")
+	}
+	return string(part[:len(part)-1]), nil
+}
+
+func parseUint64(part []byte) (uint64, error) {
+	if len(part) != 8 {
+		return 0, fmt.Errorf("invalid length %d for a numeric part", len(part))
+	}
+	return binary.BigEndian.Uint64(part), nil
+}
+
+func maxTrust(a, b trust) trust {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// defaultDsNames names the values of types that are not in the types.db: a
+// single value is named "value", as for most collectd types, and multiple
+// values are named after their index.
+func defaultDsNames(num int) []string {
+	if num == 1 {
+		return []string{"value"}
+	}
+	names := make([]string, num)
+	for i := range names {
+		names[i] = strconv.Itoa(i)
+	}
+	return names
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// readAuthFile reads a collectd auth file, in which each line has the
+// "username: password" format.
+func readAuthFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open auth_file: %v", err)
+	}
+	defer f.Close()
+
+	passwords := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.IndexByte(line, ':')
+		if idx < 1 {
+			return nil, fmt.Errorf("invalid auth_file line %q", line)
+		}
+		passwords[strings.TrimSpace(line[:idx])] = strings.TrimSpace(line[idx+1:])
+	}
+	return passwords, scanner.Err()
+}
+
+// readTypesDB reads the data source names from a collectd types.db file, in
+// which each line has the "type ds_name:DS_TYPE:min:max[, ...]" format.
+func readTypesDB(path string, types map[string][]string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read typesdb: %v", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("invalid typesdb line %q", line)
+		}
+		var dsNames []string
+		for _, ds := range strings.Split(strings.Join(fields[1:], ""), ",") {
+			if ds == "" {
+				continue
+			}
+			dsNames = append(dsNames, strings.SplitN(ds, ":", 2)[0])
+		}
+		types[fields[0]] = dsNames
+	}
+	return scanner.Err()
+}
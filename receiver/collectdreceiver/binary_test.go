@@ -0,0 +1,340 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packetBuilder builds packets of the collectd binary protocol for tests.
+type packetBuilder struct {
+	bytes.Buffer
+}
+
+func (b *packetBuilder) part(partType uint16, data []byte) *packetBuilder {
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint16(header[0:2], partType)
+	binary.BigEndian.PutUint16(header[2:4], uint16(len(data)+4))
+	b.Write(header)
+	b.Write(data)
+	return b
+}
+
+func (b *packetBuilder) str(partType uint16, s string) *packetBuilder {
+	return b.part(partType, append([]byte(s), 0))
+}
+
+func (b *packetBuilder) num(partType uint16, v uint64) *packetBuilder {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, v)
+	return b.part(partType, data)
+}
+
+type testValue struct {
+	dsType byte
+	value  interface{}
+}
+
+func (b *packetBuilder) values(values ...testValue) *packetBuilder {
+	data := make([]byte, 2+len(values)*9)
+	binary.BigEndian.PutUint16(data[0:2], uint16(len(values)))
+	for i, v := range values {
+		data[2+i] = v.dsType
+		raw := data[2+len(values)+i*8 : 2+len(values)+(i+1)*8]
+		switch val := v.value.(type) {
+		case uint64:
+			binary.BigEndian.PutUint64(raw, val)
+		case int64:
+			binary.BigEndian.PutUint64(raw, uint64(val))
+		case float64:
+			binary.LittleEndian.PutUint64(raw, math.Float64bits(val))
+		}
+	}
+	return b.part(partValues, data)
+}
+
+func (b *packetBuilder) sign(username, password string) []byte {
+	mac := hmac.New(sha256.New, []byte(password))
+	mac.Write([]byte(username))
+	mac.Write(b.Bytes())
+
+	signed := &packetBuilder{}
+	signed.part(partSignature, append(mac.Sum(nil), username...))
+	signed.Write(b.Bytes())
+	return signed.Bytes()
+}
+
+func (b *packetBuilder) encrypt(t *testing.T, username, password string) []byte {
+	checksum := sha1.Sum(b.Bytes()) // #nosec
+	plaintext := append(checksum[:], b.Bytes()...)
+
+	key := sha256.Sum256([]byte(password))
+	block, err := aes.NewCipher(key[:])
+	require.NoError(t, err)
+	iv := bytes.Repeat([]byte{7}, aes.BlockSize)
+	encrypted := make([]byte, len(plaintext))
+	cipher.NewOFB(block, iv).XORKeyStream(encrypted, plaintext)
+
+	data := make([]byte, 2)
+	binary.BigEndian.PutUint16(data, uint16(len(username)))
+	data = append(data, username...)
+	data = append(data, iv...)
+	data = append(data, encrypted...)
+
+	encryptedPacket := &packetBuilder{}
+	encryptedPacket.part(partEncryption, data)
+	return encryptedPacket.Bytes()
+}
+
+func loadPacket() *packetBuilder {
+	b := &packetBuilder{}
+	b.str(partHost, "host0").
+		num(partTimeHR, 1582230020<<30).
+		num(partIntervalHR, 10<<30).
+		str(partPlugin, "load").
+		str(partType, "load").
+		values(
+			testValue{dsTypeGauge, 0.5},
+			testValue{dsTypeGauge, 0.25},
+			testValue{dsTypeGauge, 0.125},
+		)
+	return b
+}
+
+func jsonNumber(s string) *json.Number {
+	n := json.Number(s)
+	return &n
+}
+
+func TestBinaryParser_Parse(t *testing.T) {
+	p, err := newBinaryParser("", "", []string{path.Join("testdata", "types.db")})
+	require.NoError(t, err)
+
+	b := loadPacket()
+	b.str(partPlugin, "interface").
+		str(partPluginInstance, "eth0").
+		str(partType, "if_octets").
+		num(partTime, 1582230030).
+		values(
+			testValue{dsTypeDerive, int64(-1)},
+			testValue{dsTypeDerive, int64(2)},
+		).
+		str(partType, "unknown").
+		str(partTypeInstance, "inst").
+		values(
+			testValue{dsTypeCounter, uint64(3)},
+			testValue{dsTypeAbsolute, uint64(4)},
+		).
+		num(partSeverity, 2).
+		str(partMessage, "something happened")
+
+	records, err := p.parse(b.Bytes())
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	load := records[0]
+	assert.Equal(t, "host0", *load.Host)
+	assert.Equal(t, "load", *load.Plugin)
+	assert.Equal(t, "", *load.PluginInstance)
+	assert.Equal(t, "load", *load.TypeS)
+	assert.Equal(t, 1582230020.0, *load.Time)
+	assert.Equal(t, 10.0, *load.Interval)
+	assert.Equal(t, []*string{stringPtr("shortterm"), stringPtr("midterm"), stringPtr("longterm")}, load.Dsnames)
+	assert.Equal(t, []*string{stringPtr("gauge"), stringPtr("gauge"), stringPtr("gauge")}, load.Dstypes)
+	assert.Equal(t, []*json.Number{jsonNumber("0.5"), jsonNumber("0.25"), jsonNumber("0.125")}, load.Values)
+
+	ifOctets := records[1]
+	assert.Equal(t, "interface", *ifOctets.Plugin)
+	assert.Equal(t, "eth0", *ifOctets.PluginInstance)
+	assert.Equal(t, 1582230030.0, *ifOctets.Time)
+	assert.Equal(t, []*string{stringPtr("rx"), stringPtr("tx")}, ifOctets.Dsnames)
+	assert.Equal(t, []*string{stringPtr("derive"), stringPtr("derive")}, ifOctets.Dstypes)
+	assert.Equal(t, []*json.Number{jsonNumber("-1"), jsonNumber("2")}, ifOctets.Values)
+
+	unknown := records[2]
+	assert.Equal(t, "inst", *unknown.TypeInstance)
+	assert.Equal(t, []*string{stringPtr("0"), stringPtr("1")}, unknown.Dsnames)
+	assert.Equal(t, []*string{stringPtr("counter"), stringPtr("absolute")}, unknown.Dstypes)
+	assert.Equal(t, []*json.Number{jsonNumber("3"), jsonNumber("4")}, unknown.Values)
+
+	event := records[3]
+	assert.True(t, event.isEvent())
+	assert.Equal(t, "WARNING", *event.Severity)
+	assert.Equal(t, "something happened", *event.Message)
+
+	metrics, err := load.appendToMetrics(nil, nil)
+	require.NoError(t, err)
+	require.Len(t, metrics, 3)
+	assert.Equal(t, "load.shortterm", metrics[0].MetricDescriptor.Name)
+}
+
+func TestBinaryParser_ParseInvalid(t *testing.T) {
+	p, err := newBinaryParser("", "", nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		packet []byte
+	}{
+		{
+			name:   "short_header",
+			packet: []byte{0, 0, 0},
+		},
+		{
+			name:   "invalid_length",
+			packet: []byte{0, 0, 0, 200, 'h', 0},
+		},
+		{
+			name:   "not_null_terminated",
+			packet: (&packetBuilder{}).part(partHost, []byte("host")).Bytes(),
+		},
+		{
+			name:   "invalid_numeric",
+			packet: (&packetBuilder{}).part(partTime, []byte{1, 2}).Bytes(),
+		},
+		{
+			name:   "invalid_values_length",
+			packet: (&packetBuilder{}).part(partValues, []byte{0, 2, 1}).Bytes(),
+		},
+		{
+			name:   "unknown_ds_type",
+			packet: (&packetBuilder{}).values(testValue{9, uint64(1)}).Bytes(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.parse(tt.packet)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBinaryParser_Security(t *testing.T) {
+	authFile := path.Join("testdata", "auth_file")
+	tests := []struct {
+		name          string
+		securityLevel string
+		packet        func(t *testing.T) []byte
+		wantRecords   int
+		wantErr       bool
+	}{
+		{
+			name:          "none_plain",
+			securityLevel: securityLevelNone,
+			packet:        func(*testing.T) []byte { return loadPacket().Bytes() },
+			wantRecords:   1,
+		},
+		{
+			name:          "none_signed_unknown_user",
+			securityLevel: securityLevelNone,
+			packet:        func(*testing.T) []byte { return loadPacket().sign("eve", "x") },
+			wantRecords:   1,
+		},
+		{
+			name:          "sign_plain",
+			securityLevel: securityLevelSign,
+			packet:        func(*testing.T) []byte { return loadPacket().Bytes() },
+			wantRecords:   0,
+		},
+		{
+			name:          "sign_signed",
+			securityLevel: securityLevelSign,
+			packet:        func(*testing.T) []byte { return loadPacket().sign("alice", "secret") },
+			wantRecords:   1,
+		},
+		{
+			name:          "sign_bad_signature",
+			securityLevel: securityLevelSign,
+			packet:        func(*testing.T) []byte { return loadPacket().sign("alice", "wrong") },
+			wantErr:       true,
+		},
+		{
+			name:          "sign_unknown_user",
+			securityLevel: securityLevelSign,
+			packet:        func(*testing.T) []byte { return loadPacket().sign("eve", "x") },
+			wantErr:       true,
+		},
+		{
+			name:          "sign_encrypted",
+			securityLevel: securityLevelSign,
+			packet:        func(t *testing.T) []byte { return loadPacket().encrypt(t, "bob", "hunter2") },
+			wantRecords:   1,
+		},
+		{
+			name:          "encrypt_signed",
+			securityLevel: securityLevelEncrypt,
+			packet:        func(*testing.T) []byte { return loadPacket().sign("alice", "secret") },
+			wantRecords:   0,
+		},
+		{
+			name:          "encrypt_encrypted",
+			securityLevel: securityLevelEncrypt,
+			packet:        func(t *testing.T) []byte { return loadPacket().encrypt(t, "bob", "hunter2") },
+			wantRecords:   1,
+		},
+		{
+			name:          "encrypt_wrong_password",
+			securityLevel: securityLevelEncrypt,
+			packet:        func(t *testing.T) []byte { return loadPacket().encrypt(t, "bob", "wrong") },
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newBinaryParser(tt.securityLevel, authFile, nil)
+			require.NoError(t, err)
+
+			records, err := p.parse(tt.packet(t))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, records, tt.wantRecords)
+		})
+	}
+}
+
+func TestNewBinaryParser(t *testing.T) {
+	_, err := newBinaryParser("invalid", "", nil)
+	assert.Error(t, err)
+
+	_, err = newBinaryParser(securityLevelSign, "", nil)
+	assert.Error(t, err)
+
+	_, err = newBinaryParser(securityLevelNone, path.Join("testdata", "nonexistent"), nil)
+	assert.Error(t, err)
+
+	_, err = newBinaryParser(securityLevelNone, "", []string{path.Join("testdata", "nonexistent")})
+	assert.Error(t, err)
+
+	p, err := newBinaryParser(securityLevelEncrypt, path.Join("testdata", "auth_file"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, trustEncrypted, p.minTrust)
+	assert.Equal(t, map[string]string{"alice": "secret", "bob": "hunter2"}, p.passwords)
+}
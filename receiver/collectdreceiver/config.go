@@ -29,4 +29,14 @@ type Config struct {
 	Timeout          time.Duration `mapstructure:"timeout"`
 	AttributesPrefix string        `mapstructure:"attributes_prefix"`
 	Encoding         string        `mapstructure:"encoding"`
+
+	// SecurityLevel is the minimum security level of the data accepted with the
+	// "binary" encoding: "none", "sign" or "encrypt".
+	SecurityLevel string `mapstructure:"security_level"`
+	// AuthFile is the path to the file with the "username: password" entries used
+	// to verify signed data and decrypt encrypted data with the "binary" encoding.
+	AuthFile string `mapstructure:"auth_file"`
+	// TypesDB are the paths of the collectd types.db files used to name the
+	// values received with the "binary" encoding.
+	TypesDB []string `mapstructure:"typesdb"`
 }
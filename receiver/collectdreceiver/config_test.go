@@ -40,7 +40,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 2)
+	assert.Equal(t, len(cfg.Receivers), 3)
 
 	r0 := cfg.Receivers["collectd"]
 	assert.Equal(t, r0, factory.CreateDefaultConfig())
@@ -58,5 +58,23 @@ func TestLoadConfig(t *testing.T) {
 			Timeout:          time.Second * 50,
 			AttributesPrefix: "dap_",
 			Encoding:         "command",
+			SecurityLevel:    "none",
+		})
+
+	r2 := cfg.Receivers["collectd/binary"].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: configmodels.Type(typeStr),
+				NameVal: "collectd/binary",
+			},
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "localhost:25826",
+			},
+			Timeout:       defaultTimeout,
+			Encoding:      "binary",
+			SecurityLevel: "sign",
+			AuthFile:      "/etc/collectd/auth_file",
+			TypesDB:       []string{"/usr/share/collectd/types.db"},
 		})
 }
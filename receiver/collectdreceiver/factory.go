@@ -35,6 +35,8 @@ const (
 	defaultBindEndpoint   = "localhost:8081"
 	defaultTimeout        = time.Duration(time.Second * 30)
 	defaultEncodingFormat = "json"
+	binaryEncodingFormat  = "binary"
+	defaultSecurityLevel  = securityLevelNone
 )
 
 // Factory is the factory for collectd receiver.
@@ -63,8 +65,9 @@ func (f *Factory) CreateDefaultConfig() configmodels.Receiver {
 		TCPAddr: confignet.TCPAddr{
 			Endpoint: defaultBindEndpoint,
 		},
-		Timeout:  defaultTimeout,
-		Encoding: defaultEncodingFormat,
+		Timeout:       defaultTimeout,
+		Encoding:      defaultEncodingFormat,
+		SecurityLevel: defaultSecurityLevel,
 	}
 }
 
@@ -87,13 +90,18 @@ func (f *Factory) CreateMetricsReceiver(
 ) (component.MetricsReceiver, error) {
 	c := cfg.(*Config)
 	c.Encoding = strings.ToLower(c.Encoding)
-	// CollectD receiver only supports JSON encoding. We expose a config option
-	// to make it explicit and obvious to the users.
-	if c.Encoding != defaultEncodingFormat {
-		return nil, fmt.Errorf(
-			"CollectD only support JSON encoding format. %s is not supported",
-			c.Encoding,
-		)
+	switch c.Encoding {
+	case defaultEncodingFormat:
+		return New(logger, c.Endpoint, c.Timeout, c.AttributesPrefix, nextConsumer)
+	case binaryEncodingFormat:
+		parser, err := newBinaryParser(c.SecurityLevel, c.AuthFile, c.TypesDB)
+		if err != nil {
+			return nil, err
+		}
+		return newNetworkReceiver(logger, c.Endpoint, parser, nextConsumer)
 	}
-	return New(logger, c.Endpoint, c.Timeout, c.AttributesPrefix, nextConsumer)
+	return nil, fmt.Errorf(
+		"CollectD only support JSON and binary encoding formats. %s is not supported",
+		c.Encoding,
+	)
 }
@@ -16,6 +16,7 @@ package collectdreceiver
 
 import (
 	"context"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -55,3 +56,29 @@ func TestCreateReceiver(t *testing.T) {
 	assert.Equal(t, err, configerror.ErrDataTypeIsNotSupported)
 	assert.Nil(t, mReceiver)
 }
+
+func TestCreateBinaryReceiver(t *testing.T) {
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Encoding = "BINARY"
+	cfg.TypesDB = []string{path.Join("testdata", "types.db")}
+
+	mReceiver, err := factory.CreateMetricsReceiver(context.Background(), zap.NewNop(), cfg, &mockMetricsConsumer{})
+	assert.NoError(t, err)
+	assert.IsType(t, &networkReceiver{}, mReceiver)
+
+	cfg.SecurityLevel = "encrypt"
+	mReceiver, err = factory.CreateMetricsReceiver(context.Background(), zap.NewNop(), cfg, &mockMetricsConsumer{})
+	assert.Error(t, err)
+	assert.Nil(t, mReceiver)
+}
+
+func TestCreateReceiverInvalidEncoding(t *testing.T) {
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Encoding = "command"
+
+	mReceiver, err := factory.CreateMetricsReceiver(context.Background(), zap.NewNop(), cfg, &mockMetricsConsumer{})
+	assert.Error(t, err)
+	assert.Nil(t, mReceiver)
+}
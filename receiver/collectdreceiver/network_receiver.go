@@ -0,0 +1,146 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.uber.org/zap"
+)
+
+// maxPacketSize is the maximum size of an UDP packet body.
+const maxPacketSize = 65535
+
+var _ component.MetricsReceiver = (*networkReceiver)(nil)
+
+// networkReceiver implements the component.MetricsReceiver for collectd's
+// binary network protocol, as sent by the network plugin over UDP.
+type networkReceiver struct {
+	sync.Mutex
+	logger       *zap.Logger
+	addr         string
+	parser       *binaryParser
+	nextConsumer consumer.MetricsConsumerOld
+
+	conn net.PacketConn
+	wg   sync.WaitGroup
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+func newNetworkReceiver(
+	logger *zap.Logger,
+	addr string,
+	parser *binaryParser,
+	nextConsumer consumer.MetricsConsumerOld) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, errNilNextConsumer
+	}
+
+	return &networkReceiver{
+		logger:       logger,
+		addr:         addr,
+		parser:       parser,
+		nextConsumer: nextConsumer,
+	}, nil
+}
+
+// Start starts listening for collectd packets on the UDP address.
+func (nr *networkReceiver) Start(_ context.Context, _ component.Host) error {
+	nr.Lock()
+	defer nr.Unlock()
+
+	err := errAlreadyStarted
+	nr.startOnce.Do(func() {
+		nr.conn, err = net.ListenPacket("udp", nr.addr)
+		if err != nil {
+			return
+		}
+		nr.wg.Add(1)
+		go func() {
+			defer nr.wg.Done()
+			nr.readPackets()
+		}()
+	})
+
+	return err
+}
+
+// Shutdown stops the receiver.
+func (nr *networkReceiver) Shutdown(context.Context) error {
+	nr.Lock()
+	defer nr.Unlock()
+
+	var err = errAlreadyStopped
+	nr.stopOnce.Do(func() {
+		err = nil
+		if nr.conn != nil {
+			err = nr.conn.Close()
+			nr.wg.Wait()
+		}
+	})
+	return err
+}
+
+func (nr *networkReceiver) readPackets() {
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := nr.conn.ReadFrom(buf)
+		if n > 0 {
+			nr.handlePacket(buf[:n])
+		}
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				continue
+			}
+			nr.logger.Debug("collectd receiver stopped reading packets", zap.Error(err))
+			return
+		}
+	}
+}
+
+func (nr *networkReceiver) handlePacket(packet []byte) {
+	recordRequestReceived()
+
+	records, err := nr.parser.parse(packet)
+	if err != nil {
+		recordRequestErrors()
+		nr.logger.Debug("unable to decode collectd packet", zap.Error(err))
+		return
+	}
+
+	md := consumerdata.MetricsData{}
+	for _, record := range records {
+		md.Metrics, err = record.appendToMetrics(md.Metrics, nil)
+		if err != nil {
+			recordRequestErrors()
+			nr.logger.Debug("unable to process metrics", zap.Error(err))
+			return
+		}
+	}
+	if len(md.Metrics) == 0 {
+		return
+	}
+
+	if err = nr.nextConsumer.ConsumeMetricsData(context.Background(), md); err != nil {
+		nr.logger.Error("unable to process metrics", zap.Error(err))
+	}
+}
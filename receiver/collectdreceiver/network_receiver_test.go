@@ -0,0 +1,69 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestNetworkReceiver(t *testing.T) {
+	parser, err := newBinaryParser(securityLevelNone, "", nil)
+	require.NoError(t, err)
+
+	sink := new(exportertest.SinkMetricsExporterOld)
+	r, err := newNetworkReceiver(zap.NewNop(), "localhost:0", parser, sink)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+	assert.Equal(t, errAlreadyStarted, r.Start(ctx, componenttest.NewNopHost()))
+
+	conn, err := net.Dial("udp", r.(*networkReceiver).conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// An invalid packet is dropped without stopping the receiver.
+	_, err = conn.Write([]byte{0, 0, 0})
+	require.NoError(t, err)
+	_, err = conn.Write(loadPacket().Bytes())
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	metrics := sink.AllMetrics()[0].Metrics
+	require.Len(t, metrics, 3)
+	assert.Equal(t, "load.shortterm", metrics[0].MetricDescriptor.Name)
+	assert.Equal(t, 0.5, metrics[0].Timeseries[0].Points[0].GetDoubleValue())
+
+	require.NoError(t, r.Shutdown(ctx))
+	assert.Equal(t, errAlreadyStopped, r.Shutdown(ctx))
+}
+
+func TestNetworkReceiverNilConsumer(t *testing.T) {
+	r, err := newNetworkReceiver(zap.NewNop(), "localhost:0", &binaryParser{}, nil)
+	assert.Equal(t, errNilNextConsumer, err)
+	assert.Nil(t, r)
+}
@@ -0,0 +1,63 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvarreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// vars are the variables published by expvar, keyed by name.
+type vars map[string]json.RawMessage
+
+// expvarClient retrieves the variables from the expvar endpoint.
+type expvarClient struct {
+	url    string
+	client *http.Client
+}
+
+func newExpvarClient(endpoint string, timeout time.Duration) *expvarClient {
+	return &expvarClient{
+		url:    endpoint,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *expvarClient) vars(ctx context.Context) (vars, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, c.url)
+	}
+
+	var v vars
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return nil, fmt.Errorf("failed to decode the variables from %s: %w", c.url, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvarreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientVars(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/debug/vars", r.URL.Path)
+		_, _ = w.Write([]byte(`{"cmdline": ["service"], "requests": 42}`))
+	}))
+	defer srv.Close()
+
+	v, err := newExpvarClient(srv.URL+"/debug/vars", time.Second).vars(context.Background())
+	require.NoError(t, err)
+	require.Len(t, v, 2)
+	assert.Equal(t, "42", string(v["requests"]))
+}
+
+func TestClientVarsErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/invalid" {
+			_, _ = w.Write([]byte(`not json`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	_, err := newExpvarClient(srv.URL+"/debug/vars", time.Second).vars(context.Background())
+	assert.EqualError(t, err, "unexpected status code 404 from "+srv.URL+"/debug/vars")
+
+	_, err = newExpvarClient(srv.URL+"/invalid", time.Second).vars(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvarreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the expvar receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Endpoint is the URL of the expvar endpoint of the Go service, e.g.
+	// http://localhost:8000/debug/vars.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// CollectionInterval is the interval at which metrics should be collected.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+
+	// Timeout is the timeout of the requests to the expvar endpoint.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MetricPrefix is prepended to the names of the metrics of the
+	// user-defined variables.
+	MetricPrefix string `mapstructure:"metric_prefix"`
+}
+
+func (c *Config) validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", c.Endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("endpoint %q must be an http or https URL", c.Endpoint)
+	}
+	if c.CollectionInterval <= 0 {
+		return errors.New("collection_interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvarreceiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sort"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+const (
+	// memstatsVar is the variable holding the runtime.MemStats of the service.
+	memstatsVar = "memstats"
+	// cmdlineVar is the variable holding the command line of the service.
+	cmdlineVar = "cmdline"
+
+	memstatsMetricPrefix = "process.runtime.memstats."
+
+	// keyLabel is the label holding the key of the entries of map variables.
+	keyLabel = "key"
+)
+
+// memStatsMetric describes a metric built from a field of runtime.MemStats.
+type memStatsMetric struct {
+	name        string
+	description string
+	unit        string
+	cumulative  bool
+	value       func(ms *runtime.MemStats) uint64
+}
+
+var memStatsMetrics = []memStatsMetric{
+	{"alloc", "Bytes of allocated heap objects.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.Alloc }},
+	{"total_alloc", "Cumulative bytes allocated for heap objects.", "By", true, func(ms *runtime.MemStats) uint64 { return ms.TotalAlloc }},
+	{"sys", "Total bytes of memory obtained from the OS.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.Sys }},
+	{"lookups", "Number of pointer lookups performed by the runtime.", "1", true, func(ms *runtime.MemStats) uint64 { return ms.Lookups }},
+	{"mallocs", "Cumulative count of heap objects allocated.", "1", true, func(ms *runtime.MemStats) uint64 { return ms.Mallocs }},
+	{"frees", "Cumulative count of heap objects freed.", "1", true, func(ms *runtime.MemStats) uint64 { return ms.Frees }},
+	{"heap_alloc", "Bytes of allocated heap objects.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.HeapAlloc }},
+	{"heap_sys", "Bytes of heap memory obtained from the OS.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.HeapSys }},
+	{"heap_idle", "Bytes in idle (unused) spans.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.HeapIdle }},
+	{"heap_inuse", "Bytes in in-use spans.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.HeapInuse }},
+	{"heap_released", "Bytes of physical memory returned to the OS.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.HeapReleased }},
+	{"heap_objects", "Number of allocated heap objects.", "1", false, func(ms *runtime.MemStats) uint64 { return ms.HeapObjects }},
+	{"stack_inuse", "Bytes in stack spans.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.StackInuse }},
+	{"stack_sys", "Bytes of stack memory obtained from the OS.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.StackSys }},
+	{"mspan_inuse", "Bytes of allocated mspan structures.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.MSpanInuse }},
+	{"mspan_sys", "Bytes of memory obtained from the OS for mspan structures.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.MSpanSys }},
+	{"mcache_inuse", "Bytes of allocated mcache structures.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.MCacheInuse }},
+	{"mcache_sys", "Bytes of memory obtained from the OS for mcache structures.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.MCacheSys }},
+	{"buck_hash_sys", "Bytes of memory in profiling bucket hash tables.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.BuckHashSys }},
+	{"gc_sys", "Bytes of memory in garbage collection metadata.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.GCSys }},
+	{"other_sys", "Bytes of memory in miscellaneous off-heap runtime allocations.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.OtherSys }},
+	{"next_gc", "Target heap size of the next GC cycle.", "By", false, func(ms *runtime.MemStats) uint64 { return ms.NextGC }},
+	{"pause_total", "Cumulative time spent in GC stop-the-world pauses.", "ns", true, func(ms *runtime.MemStats) uint64 { return ms.PauseTotalNs }},
+	{"last_pause", "Duration of the last GC stop-the-world pause.", "ns", false, lastPause},
+	{"num_gc", "Number of completed GC cycles.", "1", true, func(ms *runtime.MemStats) uint64 { return uint64(ms.NumGC) }},
+	{"num_forced_gc", "Number of GC cycles forced by the application calling the GC function.", "1", true, func(ms *runtime.MemStats) uint64 { return uint64(ms.NumForcedGC) }},
+}
+
+// lastPause returns the duration of the most recent GC pause, stored in the
+// circular buffer PauseNs at index (NumGC+255)%256.
+func lastPause(ms *runtime.MemStats) uint64 {
+	if ms.NumGC == 0 {
+		return 0
+	}
+	return ms.PauseNs[(ms.NumGC+255)%256]
+}
+
+// buildMetrics converts the variables to metrics: the memory statistics, if
+// published, and every numeric variable or map of numeric variables, whose
+// metric name is prefixed by metricPrefix. Cumulative metrics start at
+// startTime.
+func buildMetrics(v vars, metricPrefix string, startTime, ts *timestamp.Timestamp) ([]*metricspb.Metric, error) {
+	var metrics []*metricspb.Metric
+	if raw, ok := v[memstatsVar]; ok {
+		var ms runtime.MemStats
+		if err := json.Unmarshal(raw, &ms); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", memstatsVar, err)
+		}
+		metrics = append(metrics, memStatsMetricsData(&ms, startTime, ts)...)
+	}
+
+	names := make([]string, 0, len(v))
+	for name := range v {
+		if name != memstatsVar && name != cmdlineVar {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if m := varMetric(metricPrefix+name, v[name], ts); m != nil {
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics, nil
+}
+
+func memStatsMetricsData(ms *runtime.MemStats, startTime, ts *timestamp.Timestamp) []*metricspb.Metric {
+	metrics := make([]*metricspb.Metric, 0, len(memStatsMetrics)+1)
+	for _, msm := range memStatsMetrics {
+		metricType := metricspb.MetricDescriptor_GAUGE_INT64
+		var start *timestamp.Timestamp
+		if msm.cumulative {
+			metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
+			start = startTime
+		}
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: &metricspb.MetricDescriptor{
+				Name:        memstatsMetricPrefix + msm.name,
+				Description: msm.description,
+				Unit:        msm.unit,
+				Type:        metricType,
+			},
+			Timeseries: []*metricspb.TimeSeries{{
+				StartTimestamp: start,
+				Points: []*metricspb.Point{{
+					Timestamp: ts,
+					Value:     &metricspb.Point_Int64Value{Int64Value: int64(msm.value(ms))},
+				}},
+			}},
+		})
+	}
+	metrics = append(metrics, &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        memstatsMetricPrefix + "gc_cpu_fraction",
+			Description: "Fraction of the available CPU time used by the GC since the program started.",
+			Unit:        "1",
+			Type:        metricspb.MetricDescriptor_GAUGE_DOUBLE,
+		},
+		Timeseries: []*metricspb.TimeSeries{{
+			Points: []*metricspb.Point{{
+				Timestamp: ts,
+				Value:     &metricspb.Point_DoubleValue{DoubleValue: ms.GCCPUFraction},
+			}},
+		}},
+	})
+	return metrics
+}
+
+// varMetric converts a numeric variable to a gauge, or a map of numeric
+// variables, e.g. an expvar.Map, to a gauge with a timeseries per key. Other
+// variables are ignored.
+func varMetric(name string, raw json.RawMessage, ts *timestamp.Timestamp) *metricspb.Metric {
+	descriptor := &metricspb.MetricDescriptor{
+		Name: name,
+		Type: metricspb.MetricDescriptor_GAUGE_DOUBLE,
+	}
+
+	if value, ok := numericValue(raw); ok {
+		return &metricspb.Metric{
+			MetricDescriptor: descriptor,
+			Timeseries:       []*metricspb.TimeSeries{doubleSeries(value, ts)},
+		}
+	}
+
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var timeseries []*metricspb.TimeSeries
+	for _, key := range keys {
+		value, ok := numericValue(entries[key])
+		if !ok {
+			continue
+		}
+		series := doubleSeries(value, ts)
+		series.LabelValues = []*metricspb.LabelValue{{Value: key, HasValue: true}}
+		timeseries = append(timeseries, series)
+	}
+	if len(timeseries) == 0 {
+		return nil
+	}
+	descriptor.LabelKeys = []*metricspb.LabelKey{{Key: keyLabel}}
+	return &metricspb.Metric{
+		MetricDescriptor: descriptor,
+		Timeseries:       timeseries,
+	}
+}
+
+// numericValue returns the value of a variable if it is a number.
+func numericValue(raw json.RawMessage) (float64, bool) {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return 0, false
+	}
+	value, ok := v.(float64)
+	return value, ok
+}
+
+func doubleSeries(value float64, ts *timestamp.Timestamp) *metricspb.TimeSeries {
+	return &metricspb.TimeSeries{
+		Points: []*metricspb.Point{{
+			Timestamp: ts,
+			Value:     &metricspb.Point_DoubleValue{DoubleValue: value},
+		}},
+	}
+}
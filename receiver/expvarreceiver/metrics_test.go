@@ -0,0 +1,101 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvarreceiver
+
+import (
+	"encoding/json"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMetrics(t *testing.T) {
+	var v vars
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"cmdline": ["service", "-flag"],
+		"memstats": {"Alloc": 1024, "TotalAlloc": 4096, "NumGC": 2, "PauseNs": [100, 200], "GCCPUFraction": 0.01},
+		"requests": 42,
+		"version": "1.0.0",
+		"uptime": null,
+		"responses": {"200": 40, "500": 2, "last": "ok"}
+	}`), &v))
+
+	startTime := ptypes.TimestampNow()
+	ts := ptypes.TimestampNow()
+	metrics, err := buildMetrics(v, "myservice.", startTime, ts)
+	require.NoError(t, err)
+	require.Len(t, metrics, len(memStatsMetrics)+3)
+
+	byName := make(map[string]*metricspb.Metric, len(metrics))
+	for _, m := range metrics {
+		byName[m.MetricDescriptor.Name] = m
+	}
+
+	alloc := byName["process.runtime.memstats.alloc"]
+	require.NotNil(t, alloc)
+	assert.Equal(t, metricspb.MetricDescriptor_GAUGE_INT64, alloc.MetricDescriptor.Type)
+	assert.Nil(t, alloc.Timeseries[0].StartTimestamp)
+	assert.Equal(t, int64(1024), alloc.Timeseries[0].Points[0].GetInt64Value())
+
+	totalAlloc := byName["process.runtime.memstats.total_alloc"]
+	require.NotNil(t, totalAlloc)
+	assert.Equal(t, metricspb.MetricDescriptor_CUMULATIVE_INT64, totalAlloc.MetricDescriptor.Type)
+	assert.Equal(t, startTime, totalAlloc.Timeseries[0].StartTimestamp)
+	assert.Equal(t, int64(4096), totalAlloc.Timeseries[0].Points[0].GetInt64Value())
+
+	lastPause := byName["process.runtime.memstats.last_pause"]
+	require.NotNil(t, lastPause)
+	assert.Equal(t, int64(200), lastPause.Timeseries[0].Points[0].GetInt64Value())
+
+	gcCPUFraction := byName["process.runtime.memstats.gc_cpu_fraction"]
+	require.NotNil(t, gcCPUFraction)
+	assert.Equal(t, 0.01, gcCPUFraction.Timeseries[0].Points[0].GetDoubleValue())
+
+	assert.Equal(t, &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name: "myservice.requests",
+			Type: metricspb.MetricDescriptor_GAUGE_DOUBLE,
+		},
+		Timeseries: []*metricspb.TimeSeries{doubleSeries(42, ts)},
+	}, byName["myservice.requests"])
+
+	responses := byName["myservice.responses"]
+	require.NotNil(t, responses)
+	assert.Equal(t, []*metricspb.LabelKey{{Key: keyLabel}}, responses.MetricDescriptor.LabelKeys)
+	require.Len(t, responses.Timeseries, 2)
+	assert.Equal(t, "200", responses.Timeseries[0].LabelValues[0].Value)
+	assert.Equal(t, 40.0, responses.Timeseries[0].Points[0].GetDoubleValue())
+	assert.Equal(t, "500", responses.Timeseries[1].LabelValues[0].Value)
+	assert.Equal(t, 2.0, responses.Timeseries[1].Points[0].GetDoubleValue())
+
+	assert.Nil(t, byName["myservice.cmdline"])
+	assert.Nil(t, byName["myservice.version"])
+	assert.Nil(t, byName["myservice.uptime"])
+}
+
+func TestBuildMetricsWithoutMemStats(t *testing.T) {
+	metrics, err := buildMetrics(vars{"requests": json.RawMessage(`1`)}, "", nil, ptypes.TimestampNow())
+	require.NoError(t, err)
+	require.Len(t, metrics, 1)
+	assert.Equal(t, "requests", metrics[0].MetricDescriptor.Name)
+}
+
+func TestBuildMetricsInvalidMemStats(t *testing.T) {
+	_, err := buildMetrics(vars{"memstats": json.RawMessage(`"invalid"`)}, "", nil, ptypes.TimestampNow())
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvarreceiver
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "http"
+
+var _ component.MetricsReceiver = (*expvarReceiver)(nil)
+
+// expvarReceiver implements the component.MetricsReceiver for expvar.
+type expvarReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	client       *expvarClient
+	startTime    *timestamp.Timestamp
+	cancel       context.CancelFunc
+}
+
+// newExpvarReceiver creates the expvar receiver with the given parameters.
+func newExpvarReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	r := &expvarReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		client:       newExpvarClient(config.Endpoint, config.Timeout),
+	}
+	return r, nil
+}
+
+// Start begins polling the expvar endpoint.
+func (er *expvarReceiver) Start(ctx context.Context, host component.Host) error {
+	er.startTime = ptypes.TimestampNow()
+	ctx, er.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, er.config.Name()))
+	go func() {
+		ticker := time.NewTicker(er.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := er.collect(ctx); err != nil {
+					er.logger.Warn("Failed to collect expvar metrics", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the expvar receiver.
+func (er *expvarReceiver) Shutdown(context.Context) error {
+	if er.cancel != nil {
+		er.cancel()
+	}
+	return nil
+}
+
+// collect sends the metrics built from the current variables.
+func (er *expvarReceiver) collect(ctx context.Context) error {
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+
+	v, err := er.client.vars(ctx)
+	if err != nil {
+		obsreport.EndMetricsReceiveOp(ctx, typeStr, 0, 0, err)
+		return err
+	}
+	metrics, err := buildMetrics(v, er.config.MetricPrefix, er.startTime, ptypes.TimestampNow())
+	if err != nil || len(metrics) == 0 {
+		obsreport.EndMetricsReceiveOp(ctx, typeStr, 0, 0, err)
+		return err
+	}
+
+	md := consumerdata.MetricsData{Metrics: metrics}
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(md)
+	err = er.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md}))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+	return err
+}
@@ -0,0 +1,103 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+)
+
+// Config defines configuration for the googlecloudpubsub receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Subscription is the subscription to pull the messages from, in the
+	// projects/<project>/subscriptions/<name> format.
+	Subscription string `mapstructure:"subscription"`
+
+	// Encoding is the encoding of the messages: otlp_proto_trace,
+	// otlp_proto_metric, otlp_proto_log, raw_text or raw_json. If empty, it
+	// is detected from the ce-type and content-type attributes of every
+	// message.
+	Encoding string `mapstructure:"encoding"`
+
+	// Endpoint overrides the Pub/Sub endpoint, e.g. with the address of an
+	// emulator.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Insecure disables TLS and authentication, e.g. for an emulator.
+	Insecure bool `mapstructure:"insecure"`
+
+	// FlowControl limits the messages being processed.
+	FlowControl FlowControlConfig `mapstructure:"flow_control"`
+}
+
+// FlowControlConfig defines the flow control of the subscription.
+type FlowControlConfig struct {
+	// MaxOutstandingMessages is the maximum number of messages being
+	// processed, unlimited if negative.
+	MaxOutstandingMessages int `mapstructure:"max_outstanding_messages"`
+
+	// MaxOutstandingBytes is the maximum size of the messages being
+	// processed, unlimited if negative.
+	MaxOutstandingBytes int `mapstructure:"max_outstanding_bytes"`
+
+	// MaxExtension is the maximum period for which the ack deadline of a
+	// message is extended while it is processed.
+	MaxExtension time.Duration `mapstructure:"max_extension"`
+}
+
+func (c *Config) validate() error {
+	if _, _, err := parseSubscription(c.Subscription); err != nil {
+		return err
+	}
+	if c.Encoding != "" {
+		if _, err := lookupEncoding(c.Encoding); err != nil {
+			return err
+		}
+	}
+	if c.FlowControl.MaxExtension <= 0 {
+		return errors.New("flow_control.max_extension must be positive")
+	}
+	return nil
+}
+
+// parseSubscription returns the project and the name of a subscription in
+// the projects/<project>/subscriptions/<name> format.
+func parseSubscription(subscription string) (project, name string, err error) {
+	parts := strings.Split(subscription, "/")
+	if len(parts) != 4 || parts[0] != "projects" || parts[1] == "" || parts[2] != "subscriptions" || parts[3] == "" {
+		return "", "", fmt.Errorf("subscription %q must be in the projects/<project>/subscriptions/<name> format", subscription)
+	}
+	return parts[1], parts[3], nil
+}
+
+// clientOptions returns the options of the Pub/Sub client.
+func (c *Config) clientOptions() []option.ClientOption {
+	var opts []option.ClientOption
+	if c.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(c.Endpoint))
+	}
+	if c.Insecure {
+		opts = append(opts, option.WithoutAuthentication(), option.WithGRPCDialOption(grpc.WithInsecure()))
+	}
+	return opts
+}
@@ -0,0 +1,94 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r1 := cfg.Receivers["googlecloudpubsub"]
+	assert.Equal(t, r1, factory.CreateDefaultConfig())
+
+	r2 := cfg.Receivers["googlecloudpubsub/customname"].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "googlecloudpubsub/customname",
+			},
+			Subscription: "projects/my-project/subscriptions/otlp",
+			Encoding:     "otlp_proto_trace",
+			Endpoint:     "localhost:8085",
+			Insecure:     true,
+			FlowControl: FlowControlConfig{
+				MaxOutstandingMessages: 100,
+				MaxOutstandingBytes:    1000000,
+				MaxExtension:           time.Minute,
+			},
+		})
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Error(t, cfg.validate())
+
+	cfg.Subscription = "otlp"
+	assert.Error(t, cfg.validate())
+
+	cfg.Subscription = "projects//subscriptions/otlp"
+	assert.Error(t, cfg.validate())
+
+	cfg.Subscription = "projects/my-project/subscriptions/otlp"
+	assert.NoError(t, cfg.validate())
+
+	cfg.Encoding = "otlp_json"
+	assert.Error(t, cfg.validate())
+
+	cfg.Encoding = "raw_json"
+	assert.NoError(t, cfg.validate())
+
+	cfg.FlowControl.MaxExtension = 0
+	assert.Error(t, cfg.validate())
+}
+
+func TestParseSubscription(t *testing.T) {
+	project, name, err := parseSubscription("projects/my-project/subscriptions/otlp")
+	require.NoError(t, err)
+	assert.Equal(t, "my-project", project)
+	assert.Equal(t, "otlp", name)
+}
@@ -0,0 +1,76 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver
+
+import (
+	"fmt"
+)
+
+// encoding is the encoding of the messages.
+type encoding int
+
+const (
+	otlpProtoTrace encoding = iota
+	otlpProtoMetric
+	otlpProtoLog
+	rawText
+	rawJSON
+)
+
+var encodings = map[string]encoding{
+	"otlp_proto_trace":  otlpProtoTrace,
+	"otlp_proto_metric": otlpProtoMetric,
+	"otlp_proto_log":    otlpProtoLog,
+	"raw_text":          rawText,
+	"raw_json":          rawJSON,
+}
+
+func lookupEncoding(name string) (encoding, error) {
+	e, ok := encodings[name]
+	if !ok {
+		return 0, fmt.Errorf("unsupported encoding %q", name)
+	}
+	return e, nil
+}
+
+const (
+	// ceTypeAttribute and contentTypeAttribute are the attributes of the
+	// Pub/Sub binding of CloudEvents describing the data of a message.
+	ceTypeAttribute      = "ce-type"
+	contentTypeAttribute = "content-type"
+
+	protobufContentType = "application/protobuf"
+	jsonContentType     = "application/json"
+)
+
+// otlpCETypes are the CloudEvents types of the OTLP messages.
+var otlpCETypes = map[string]encoding{
+	"org.opentelemetry.otlp.traces.v1":  otlpProtoTrace,
+	"org.opentelemetry.otlp.metrics.v1": otlpProtoMetric,
+	"org.opentelemetry.otlp.logs.v1":    otlpProtoLog,
+}
+
+// detectEncoding returns the encoding of a message from its attributes. The
+// messages that are not OTLP nor JSON are raw text.
+func detectEncoding(attributes map[string]string) encoding {
+	contentType := attributes[contentTypeAttribute]
+	if e, ok := otlpCETypes[attributes[ceTypeAttribute]]; ok && contentType == protobufContentType {
+		return e
+	}
+	if contentType == jsonContentType {
+		return rawJSON
+	}
+	return rawText
+}
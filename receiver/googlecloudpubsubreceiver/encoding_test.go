@@ -0,0 +1,68 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]string
+		want       encoding
+	}{
+		{
+			name:       "traces",
+			attributes: map[string]string{"ce-type": "org.opentelemetry.otlp.traces.v1", "content-type": "application/protobuf"},
+			want:       otlpProtoTrace,
+		},
+		{
+			name:       "metrics",
+			attributes: map[string]string{"ce-type": "org.opentelemetry.otlp.metrics.v1", "content-type": "application/protobuf"},
+			want:       otlpProtoMetric,
+		},
+		{
+			name:       "logs",
+			attributes: map[string]string{"ce-type": "org.opentelemetry.otlp.logs.v1", "content-type": "application/protobuf"},
+			want:       otlpProtoLog,
+		},
+		{
+			name:       "otlp json",
+			attributes: map[string]string{"ce-type": "org.opentelemetry.otlp.logs.v1", "content-type": "application/json"},
+			want:       rawJSON,
+		},
+		{
+			name: "no attributes",
+			want: rawText,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectEncoding(tt.attributes))
+		})
+	}
+}
+
+func TestLookupEncoding(t *testing.T) {
+	e, err := lookupEncoding("otlp_proto_metric")
+	assert.NoError(t, err)
+	assert.Equal(t, otlpProtoMetric, e)
+
+	_, err = lookupEncoding("otlp_json_metric")
+	assert.Error(t, err)
+}
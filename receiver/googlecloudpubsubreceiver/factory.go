@@ -0,0 +1,134 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+)
+
+const (
+	typeStr = "googlecloudpubsub"
+
+	defaultMaxOutstandingMessages = 1000
+	defaultMaxOutstandingBytes    = 1e9
+	defaultMaxExtension           = 10 * time.Minute
+)
+
+// receivers holds the receivers created per configuration so that the
+// pipelines using the same configuration share a single subscriber.
+var (
+	receiversLock sync.Mutex
+	receivers     = map[*Config]*pubsubReceiver{}
+)
+
+// NewFactory creates a factory for the googlecloudpubsub receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithTraces(createTraceReceiver),
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
+}
+
+func createDefaultConfig() configmodels.Receiver {
+	return &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		FlowControl: FlowControlConfig{
+			MaxOutstandingMessages: defaultMaxOutstandingMessages,
+			MaxOutstandingBytes:    defaultMaxOutstandingBytes,
+			MaxExtension:           defaultMaxExtension,
+		},
+	}
+}
+
+func createTraceReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.TraceConsumer,
+) (component.TraceReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	r, err := getOrCreateReceiver(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.tracesConsumer = nextConsumer
+	return r, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.MetricsConsumer,
+) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	r, err := getOrCreateReceiver(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.metricsConsumer = nextConsumer
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	r, err := getOrCreateReceiver(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.logsConsumer = nextConsumer
+	return r, nil
+}
+
+func getOrCreateReceiver(logger *zap.Logger, cfg *Config) (*pubsubReceiver, error) {
+	receiversLock.Lock()
+	defer receiversLock.Unlock()
+
+	r := receivers[cfg]
+	if r == nil {
+		var err error
+		r, err = newPubSubReceiver(logger, cfg)
+		if err != nil {
+			return nil, err
+		}
+		receivers[cfg] = r
+	}
+	return r, nil
+}
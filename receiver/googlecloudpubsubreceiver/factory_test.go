@@ -0,0 +1,73 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestValidConfig(t *testing.T) {
+	err := configcheck.ValidateConfig(createDefaultConfig())
+	require.NoError(t, err)
+}
+
+func TestCreateReceivers(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Subscription = "projects/my-project/subscriptions/otlp"
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	traceReceiver, err := createTraceReceiver(context.Background(), params, cfg, exportertest.NewNopTraceExporter())
+	require.NoError(t, err)
+	metricsReceiver, err := createMetricsReceiver(context.Background(), params, cfg, exportertest.NewNopMetricsExporter())
+	require.NoError(t, err)
+	logsReceiver, err := createLogsReceiver(context.Background(), params, cfg, &logsSink{})
+	require.NoError(t, err)
+
+	// The pipelines using the same configuration share the receiver.
+	assert.Same(t, traceReceiver, metricsReceiver)
+	assert.Same(t, traceReceiver, logsReceiver)
+	require.NoError(t, traceReceiver.Shutdown(context.Background()))
+}
+
+func TestCreateReceiverWithInvalidConfig(t *testing.T) {
+	receiver, err := createLogsReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		createDefaultConfig(),
+		&logsSink{},
+	)
+	require.Nil(t, receiver)
+	require.Error(t, err)
+}
+
+func TestCreateReceiverWithNilConsumer(t *testing.T) {
+	receiver, err := createTraceReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		createDefaultConfig(),
+		nil,
+	)
+	require.Nil(t, receiver)
+	require.Equal(t, err, componenterror.ErrNilNextConsumer)
+}
@@ -0,0 +1,15 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver
+
+go 1.14
+
+require (
+	cloud.google.com/go/pubsub v1.6.1
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/collector v0.8.1-0.20200815205113-8e5c6065eb0e
+	go.uber.org/zap v1.15.0
+	google.golang.org/api v0.30.0
+	google.golang.org/grpc v1.31.0
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/common => ../../internal/common
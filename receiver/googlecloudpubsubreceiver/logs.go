@@ -0,0 +1,59 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver
+
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// rawLogs converts the data of a raw message to logs, with the attributes of
+// the message and the attributes parsed from its data, if any.
+func rawLogs(data []byte, attributes map[string]string, parsed map[string]interface{}, publishTime time.Time) pdata.Logs {
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rl := rls.At(0)
+	rl.InitEmpty()
+	ills := rl.InstrumentationLibraryLogs()
+	ills.Resize(1)
+	ill := ills.At(0)
+	ill.InitEmpty()
+	lrs := ill.Logs()
+	lrs.Resize(1)
+	lr := lrs.At(0)
+
+	lr.SetTimestamp(pdata.TimestampUnixNano(uint64(publishTime.UnixNano())))
+	lr.Body().SetStringVal(strings.TrimRight(string(data), "\r\n"))
+
+	lrAttrs := lr.Attributes()
+	lrAttrs.InitEmptyWithCapacity(len(attributes) + len(parsed))
+	for key, value := range attributes {
+		lrAttrs.UpsertString(key, value)
+	}
+	for key, value := range parsed {
+		switch v := value.(type) {
+		case string:
+			lrAttrs.UpsertString(key, v)
+		case float64:
+			lrAttrs.UpsertDouble(key, v)
+		case bool:
+			lrAttrs.UpsertBool(key, v)
+		}
+	}
+	return ld
+}
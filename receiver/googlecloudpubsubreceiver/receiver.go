@@ -0,0 +1,202 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+const transport = "pubsub"
+
+// pubsubReceiver pulls the messages of a subscription and sends them to the
+// consumer of their signal.
+type pubsubReceiver struct {
+	logger *zap.Logger
+	config *Config
+
+	tracesConsumer  consumer.TraceConsumer
+	metricsConsumer consumer.MetricsConsumer
+	logsConsumer    consumer.LogsConsumer
+
+	// encoding is the configured encoding, detected per message if nil.
+	encoding   *encoding
+	jsonParser *logparser.Parser
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	client    *pubsub.Client
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+var _ component.TraceReceiver = (*pubsubReceiver)(nil)
+var _ component.MetricsReceiver = (*pubsubReceiver)(nil)
+var _ component.LogsReceiver = (*pubsubReceiver)(nil)
+
+func newPubSubReceiver(logger *zap.Logger, config *Config) (*pubsubReceiver, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	jsonParser, err := logparser.New(logparser.Config{Parser: logparser.ParserJSON})
+	if err != nil {
+		return nil, err
+	}
+	r := &pubsubReceiver{
+		logger:     logger,
+		config:     config,
+		jsonParser: jsonParser,
+	}
+	if config.Encoding != "" {
+		e, _ := lookupEncoding(config.Encoding)
+		r.encoding = &e
+	}
+	return r, nil
+}
+
+// Start creates the Pub/Sub client and starts pulling the messages. The
+// receiver is started once even if it is shared by several pipelines.
+func (r *pubsubReceiver) Start(_ context.Context, _ component.Host) error {
+	var err error
+	r.startOnce.Do(func() {
+		err = r.start()
+	})
+	return err
+}
+
+func (r *pubsubReceiver) start() error {
+	project, name, _ := parseSubscription(r.config.Subscription)
+	ctx := obsreport.ReceiverContext(context.Background(), typeStr, transport, r.config.Name())
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	client, err := pubsub.NewClient(ctx, project, r.config.clientOptions()...)
+	if err != nil {
+		r.cancel()
+		return fmt.Errorf("failed to create the Pub/Sub client: %w", err)
+	}
+	r.client = client
+
+	sub := client.Subscription(name)
+	sub.ReceiveSettings.MaxOutstandingMessages = r.config.FlowControl.MaxOutstandingMessages
+	sub.ReceiveSettings.MaxOutstandingBytes = r.config.FlowControl.MaxOutstandingBytes
+	sub.ReceiveSettings.MaxExtension = r.config.FlowControl.MaxExtension
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		err := sub.Receive(ctx, func(ctx context.Context, m *pubsub.Message) {
+			if err := r.handleMessage(ctx, m.Data, m.Attributes, m.PublishTime); err != nil {
+				r.logger.Warn("Failed to consume message, it will be redelivered", zap.String("id", m.ID), zap.Error(err))
+				m.Nack()
+				return
+			}
+			m.Ack()
+		})
+		if err != nil {
+			r.logger.Error("Failed to receive messages", zap.String("subscription", r.config.Subscription), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops pulling the messages and closes the Pub/Sub client.
+func (r *pubsubReceiver) Shutdown(context.Context) error {
+	var err error
+	r.stopOnce.Do(func() {
+		receiversLock.Lock()
+		delete(receivers, r.config)
+		receiversLock.Unlock()
+
+		if r.cancel == nil {
+			return
+		}
+		r.cancel()
+		r.wg.Wait()
+		err = r.client.Close()
+	})
+	return err
+}
+
+// handleMessage decodes a message and sends it to the consumer of its
+// signal. The messages that cannot be decoded, or whose signal has no
+// consumer, are dropped. The error of the consumer is returned so that the
+// message is redelivered.
+func (r *pubsubReceiver) handleMessage(ctx context.Context, data []byte, attributes map[string]string, publishTime time.Time) error {
+	var e encoding
+	if r.encoding != nil {
+		e = *r.encoding
+	} else {
+		e = detectEncoding(attributes)
+	}
+
+	switch e {
+	case otlpProtoTrace:
+		if r.tracesConsumer == nil {
+			r.logger.Debug("Dropping traces, the receiver is not in a traces pipeline")
+			return nil
+		}
+		td := pdata.NewTraces()
+		if err := td.FromOtlpProtoBytes(data); err != nil {
+			r.logger.Warn("Failed to decode traces", zap.Error(err))
+			return nil
+		}
+		return r.tracesConsumer.ConsumeTraces(ctx, td)
+	case otlpProtoMetric:
+		if r.metricsConsumer == nil {
+			r.logger.Debug("Dropping metrics, the receiver is not in a metrics pipeline")
+			return nil
+		}
+		md := pdata.NewMetrics()
+		if err := md.FromOtlpProtoBytes(data); err != nil {
+			r.logger.Warn("Failed to decode metrics", zap.Error(err))
+			return nil
+		}
+		return r.metricsConsumer.ConsumeMetrics(ctx, md)
+	}
+
+	if r.logsConsumer == nil {
+		r.logger.Debug("Dropping logs, the receiver is not in a logs pipeline")
+		return nil
+	}
+	var ld pdata.Logs
+	switch e {
+	case otlpProtoLog:
+		ld = pdata.NewLogs()
+		if err := ld.FromOtlpProtoBytes(data); err != nil {
+			r.logger.Warn("Failed to decode logs", zap.Error(err))
+			return nil
+		}
+	case rawJSON:
+		parsed, err := r.jsonParser.Parse(string(data))
+		if err != nil {
+			r.logger.Debug("Failed to parse log", zap.Error(err))
+		}
+		ld = rawLogs(data, attributes, parsed, publishTime)
+	default:
+		ld = rawLogs(data, attributes, nil, publishTime)
+	}
+	return r.logsConsumer.ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,162 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlecloudpubsubreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+type logsSink struct {
+	mu   sync.Mutex
+	logs []pdata.Logs
+	err  error
+}
+
+var _ consumer.LogsConsumer = (*logsSink)(nil)
+
+func (s *logsSink) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.logs = append(s.logs, ld)
+	return nil
+}
+
+// bodies returns the bodies of the received logs.
+func (s *logsSink) bodies() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var bodies []string
+	for _, ld := range s.logs {
+		lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+		bodies = append(bodies, lr.Body().StringVal())
+	}
+	return bodies
+}
+
+func newTestReceiver(t *testing.T, encoding string) *pubsubReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Subscription = "projects/my-project/subscriptions/otlp"
+	cfg.Encoding = encoding
+	r, err := newPubSubReceiver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	return r
+}
+
+func TestHandleRawMessages(t *testing.T) {
+	r := newTestReceiver(t, "")
+	sink := &logsSink{}
+	r.logsConsumer = sink
+
+	publishTime := time.Unix(1600000000, 0)
+	require.NoError(t, r.handleMessage(context.Background(), []byte("plain text\n"), map[string]string{"source": "app"}, publishTime))
+	require.NoError(t, r.handleMessage(context.Background(), []byte(`{"level": "info", "status": 200}`), map[string]string{"content-type": "application/json"}, publishTime))
+	require.Equal(t, []string{"plain text", `{"level": "info", "status": 200}`}, sink.bodies())
+
+	lr := sink.logs[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampUnixNano(publishTime.UnixNano()), lr.Timestamp())
+	v, ok := lr.Attributes().Get("source")
+	require.True(t, ok)
+	assert.Equal(t, "app", v.StringVal())
+
+	attrs := sink.logs[1].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()
+	v, ok = attrs.Get("level")
+	require.True(t, ok)
+	assert.Equal(t, "info", v.StringVal())
+	v, ok = attrs.Get("status")
+	require.True(t, ok)
+	assert.Equal(t, 200.0, v.DoubleVal())
+}
+
+func TestHandleOTLPMessages(t *testing.T) {
+	r := newTestReceiver(t, "")
+	traces := &exportertest.SinkTraceExporter{}
+	r.tracesConsumer = traces
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	data, err := td.ToOtlpProtoBytes()
+	require.NoError(t, err)
+
+	attributes := map[string]string{"ce-type": "org.opentelemetry.otlp.traces.v1", "content-type": "application/protobuf"}
+	require.NoError(t, r.handleMessage(context.Background(), data, attributes, time.Now()))
+	assert.Len(t, traces.AllTraces(), 1)
+
+	// Undecodable messages are dropped.
+	require.NoError(t, r.handleMessage(context.Background(), []byte("not otlp"), attributes, time.Now()))
+	assert.Len(t, traces.AllTraces(), 1)
+
+	// Messages of a signal without consumer are dropped.
+	attributes["ce-type"] = "org.opentelemetry.otlp.metrics.v1"
+	require.NoError(t, r.handleMessage(context.Background(), data, attributes, time.Now()))
+}
+
+func TestHandleMessageConsumerError(t *testing.T) {
+	r := newTestReceiver(t, "raw_text")
+	r.logsConsumer = &logsSink{err: errors.New("consumer error")}
+	assert.Error(t, r.handleMessage(context.Background(), []byte("log"), nil, time.Now()))
+}
+
+func TestReceiver(t *testing.T) {
+	srv := pstest.NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Subscription = "projects/my-project/subscriptions/logs"
+	cfg.Endpoint = srv.Addr
+	cfg.Insecure = true
+
+	client, err := pubsub.NewClient(ctx, "my-project", cfg.clientOptions()...)
+	require.NoError(t, err)
+	defer client.Close()
+	topic, err := client.CreateTopic(ctx, "logs")
+	require.NoError(t, err)
+	defer topic.Stop()
+	_, err = client.CreateSubscription(ctx, "logs", pubsub.SubscriptionConfig{Topic: topic})
+	require.NoError(t, err)
+
+	sink := &logsSink{}
+	r, err := newPubSubReceiver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	r.logsConsumer = sink
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+
+	_, err = topic.Publish(ctx, &pubsub.Message{Data: []byte("hello")}).Get(ctx)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(sink.bodies()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"hello"}, sink.bodies())
+
+	require.NoError(t, r.Shutdown(ctx))
+}
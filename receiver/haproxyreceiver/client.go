@@ -0,0 +1,99 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const showStatCommand = "show stat\n"
+
+// client retrieves HAProxy stats in CSV format.
+type client interface {
+	stats(ctx context.Context) (io.ReadCloser, error)
+}
+
+// newClient returns an HTTP client if endpoint is a URL, otherwise a client
+// for the stats unix socket at the endpoint path.
+func newClient(endpoint string, timeout time.Duration) client {
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return &httpClient{
+			url:    endpoint,
+			client: &http.Client{Timeout: timeout},
+		}
+	}
+	return &socketClient{
+		path:    endpoint,
+		timeout: timeout,
+	}
+}
+
+// httpClient retrieves stats from the stats page of HAProxy.
+type httpClient struct {
+	url    string
+	client *http.Client
+}
+
+var _ client = (*httpClient)(nil)
+
+func (c *httpClient) stats(ctx context.Context) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, c.url)
+	}
+	return resp.Body, nil
+}
+
+// socketClient retrieves stats from the HAProxy stats unix socket.
+type socketClient struct {
+	path    string
+	timeout time.Duration
+}
+
+var _ client = (*socketClient)(nil)
+
+func (c *socketClient) stats(ctx context.Context) (io.ReadCloser, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "unix", c.path)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// HAProxy closes the connection after answering a single command.
+	if _, err := conn.Write([]byte(showStatCommand)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
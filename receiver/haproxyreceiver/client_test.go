@@ -0,0 +1,98 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readTestStats(t *testing.T) []byte {
+	stats, err := ioutil.ReadFile(path.Join("testdata", "stats.csv"))
+	require.NoError(t, err)
+	return stats
+}
+
+func TestNewClient(t *testing.T) {
+	require.IsType(t, &httpClient{}, newClient("http://localhost:8404/stats;csv", time.Second))
+	require.IsType(t, &httpClient{}, newClient("https://localhost:8404/stats;csv", time.Second))
+	require.IsType(t, &socketClient{}, newClient("/var/run/haproxy.sock", time.Second))
+}
+
+func TestHTTPClient(t *testing.T) {
+	stats := readTestStats(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(stats)
+	}))
+	defer srv.Close()
+
+	body, err := newClient(srv.URL+"/stats;csv", time.Second).stats(context.Background())
+	require.NoError(t, err)
+	defer body.Close()
+	got, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, stats, got)
+}
+
+func TestHTTPClientBadStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	_, err := newClient(srv.URL, time.Second).stats(context.Background())
+	require.Error(t, err)
+}
+
+func TestSocketClient(t *testing.T) {
+	stats := readTestStats(t)
+	dir, err := ioutil.TempDir("", "haproxy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socketPath := path.Join(dir, "haproxy.sock")
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err == nil && line == showStatCommand {
+			_, _ = conn.Write(stats)
+		}
+	}()
+
+	body, err := newClient(socketPath, time.Second).stats(context.Background())
+	require.NoError(t, err)
+	defer body.Close()
+	got, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, stats, got)
+}
@@ -0,0 +1,35 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for haproxy receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Endpoint is either the URL of the HAProxy stats page in CSV format
+	// (e.g. http://localhost:8404/stats;csv) or the path to the HAProxy
+	// stats unix socket (e.g. /var/run/haproxy.sock).
+	Endpoint string `mapstructure:"endpoint"`
+	// CollectionInterval is the interval at which stats are collected.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+	// Timeout is the timeout for retrieving stats.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
@@ -0,0 +1,239 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+const (
+	proxyNameField   = "pxname"
+	serviceNameField = "svname"
+	typeField        = "type"
+	statusField      = "status"
+
+	proxyLabel   = "proxy"
+	serverLabel  = "server"
+	typeLabel    = "type"
+	statusMetric = "haproxy/up"
+)
+
+// proxyTypes maps the value of the type column to the proxy type.
+var proxyTypes = map[string]string{
+	"0": "frontend",
+	"1": "backend",
+	"2": "server",
+	"3": "listener",
+}
+
+var labelKeys = []*metricspb.LabelKey{
+	{Key: proxyLabel, Description: "Name of the frontend or backend"},
+	{Key: serverLabel, Description: "Name of the server, FRONTEND or BACKEND"},
+	{Key: typeLabel, Description: "One of frontend, backend, server or listener"},
+}
+
+// haproxyMetric describes how to convert a CSV stats column into a metric.
+type haproxyMetric struct {
+	field  string
+	name   string
+	units  string
+	desc   string
+	mdType metricspb.MetricDescriptor_Type
+}
+
+// haproxyMetrics are the stats columns converted to metrics. Columns that are
+// empty for a row, e.g. queue metrics of a frontend, are skipped for that row.
+var haproxyMetrics = []haproxyMetric{
+	{field: "scur", name: "haproxy/sessions/current", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Current number of sessions"},
+	{field: "smax", name: "haproxy/sessions/max", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Maximum number of concurrent sessions"},
+	{field: "slim", name: "haproxy/sessions/limit", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Configured session limit"},
+	{field: "stot", name: "haproxy/sessions/total", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Cumulative number of sessions"},
+	{field: "rate", name: "haproxy/sessions/rate", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Number of sessions per second over last elapsed second"},
+	{field: "qcur", name: "haproxy/queue/current", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Current number of queued requests"},
+	{field: "qmax", name: "haproxy/queue/max", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Maximum number of queued requests"},
+	{field: "qtime", name: "haproxy/queue/time", units: "ms", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Average queue time over the last 1024 requests"},
+	{field: "bin", name: "haproxy/bytes/in", units: "By", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Number of bytes received"},
+	{field: "bout", name: "haproxy/bytes/out", units: "By", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Number of bytes sent"},
+	{field: "dreq", name: "haproxy/requests/denied", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Requests denied because of security concerns"},
+	{field: "dresp", name: "haproxy/responses/denied", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Responses denied because of security concerns"},
+	{field: "ereq", name: "haproxy/requests/errors", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Number of request errors"},
+	{field: "econ", name: "haproxy/connections/errors", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Number of requests that encountered an error trying to connect to a server"},
+	{field: "eresp", name: "haproxy/responses/errors", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Number of response errors"},
+	{field: "wretr", name: "haproxy/connections/retries", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Number of times a connection to a server was retried"},
+	{field: "wredis", name: "haproxy/requests/redispatched", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Number of times a request was redispatched to another server"},
+}
+
+// readStats reads the rows of the CSV stats output. Each row is a map of column
+// name to value. The header line is prefixed with "# ".
+func readStats(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	// The output has a trailing comma on each line.
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty stats response")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(header) == 0 || !strings.HasPrefix(header[0], "# ") {
+		return nil, fmt.Errorf("unexpected stats header %q", strings.Join(header, ","))
+	}
+	header[0] = strings.TrimPrefix(header[0], "# ")
+
+	var rows []map[string]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := make(map[string]string, len(header))
+		for i, value := range record {
+			if i < len(header) && header[i] != "" {
+				row[header[i]] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// buildMetricsData converts the stats rows into metrics with a time series per
+// row. Returns the metrics and parsing errors, to be treated as warnings, if
+// there were any.
+func buildMetricsData(rows []map[string]string, now time.Time) (*consumerdata.MetricsData, []error) {
+	var warnings []error
+	ts, _ := ptypes.TimestampProto(now)
+
+	md := &consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{Type: typeStr},
+	}
+
+	for _, m := range haproxyMetrics {
+		metric := &metricspb.Metric{
+			MetricDescriptor: &metricspb.MetricDescriptor{
+				Name:        m.name,
+				Description: m.desc,
+				Unit:        m.units,
+				Type:        m.mdType,
+				LabelKeys:   labelKeys,
+			},
+		}
+		for _, row := range rows {
+			strVal := row[m.field]
+			if strVal == "" {
+				continue
+			}
+			i, err := strconv.ParseInt(strVal, 10, 64)
+			if err != nil {
+				warnings = append(warnings, fmt.Errorf("failed parsing %s of %s/%s: %v", m.field, row[proxyNameField], row[serviceNameField], err))
+				continue
+			}
+			metric.Timeseries = append(metric.Timeseries, &metricspb.TimeSeries{
+				LabelValues: labelValues(row),
+				Points: []*metricspb.Point{{
+					Timestamp: ts,
+					Value:     &metricspb.Point_Int64Value{Int64Value: i},
+				}},
+			})
+		}
+		if len(metric.Timeseries) > 0 {
+			md.Metrics = append(md.Metrics, metric)
+		}
+	}
+
+	if status := buildStatusMetric(rows, ts); status != nil {
+		md.Metrics = append(md.Metrics, status)
+	}
+
+	return md, warnings
+}
+
+// buildStatusMetric reports whether each backend and server is up (1) or not (0).
+// Frontends and servers without health checks are skipped.
+func buildStatusMetric(rows []map[string]string, ts *timestamp.Timestamp) *metricspb.Metric {
+	metric := &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        statusMetric,
+			Description: "Whether the backend or server is up (1) or down (0)",
+			Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+			LabelKeys:   labelKeys,
+		},
+	}
+	for _, row := range rows {
+		var up int64
+		status := row[statusField]
+		switch {
+		case strings.HasPrefix(status, "UP"):
+			up = 1
+		case strings.HasPrefix(status, "DOWN"), status == "MAINT", status == "NOLB":
+			up = 0
+		default:
+			continue
+		}
+		metric.Timeseries = append(metric.Timeseries, &metricspb.TimeSeries{
+			LabelValues: labelValues(row),
+			Points: []*metricspb.Point{{
+				Timestamp: ts,
+				Value:     &metricspb.Point_Int64Value{Int64Value: up},
+			}},
+		})
+	}
+	if len(metric.Timeseries) == 0 {
+		return nil
+	}
+	return metric
+}
+
+func labelValues(row map[string]string) []*metricspb.LabelValue {
+	return []*metricspb.LabelValue{
+		{Value: row[proxyNameField], HasValue: true},
+		{Value: row[serviceNameField], HasValue: true},
+		{Value: proxyTypes[row[typeField]], HasValue: true},
+	}
+}
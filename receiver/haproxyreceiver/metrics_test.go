@@ -0,0 +1,93 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadStats(t *testing.T) {
+	rows, err := readStats(bytes.NewReader(readTestStats(t)))
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	require.Equal(t, "http-in", rows[0]["pxname"])
+	require.Equal(t, "FRONTEND", rows[0]["svname"])
+	require.Equal(t, "3", rows[0]["scur"])
+	require.Equal(t, "web-2", rows[2]["svname"])
+	require.Equal(t, "DOWN", rows[2]["status"])
+}
+
+func TestReadStatsInvalid(t *testing.T) {
+	_, err := readStats(strings.NewReader(""))
+	require.Error(t, err)
+	_, err = readStats(strings.NewReader("Unknown command.\n"))
+	require.Error(t, err)
+}
+
+func findMetric(t *testing.T, metrics []*metricspb.Metric, name string) *metricspb.Metric {
+	for _, m := range metrics {
+		if m.MetricDescriptor.Name == name {
+			return m
+		}
+	}
+	require.Failf(t, "metric not found", "metric %q not found", name)
+	return nil
+}
+
+func TestBuildMetricsData(t *testing.T) {
+	rows, err := readStats(bytes.NewReader(readTestStats(t)))
+	require.NoError(t, err)
+
+	md, warnings := buildMetricsData(rows, time.Now())
+	require.Nil(t, warnings)
+	require.Equal(t, typeStr, md.Resource.Type)
+
+	sessions := findMetric(t, md.Metrics, "haproxy/sessions/current")
+	require.Len(t, sessions.Timeseries, 4)
+	require.Equal(t, []*metricspb.LabelValue{
+		{Value: "http-in", HasValue: true},
+		{Value: "FRONTEND", HasValue: true},
+		{Value: "frontend", HasValue: true},
+	}, sessions.Timeseries[0].LabelValues)
+	require.Equal(t, int64(3), sessions.Timeseries[0].Points[0].GetInt64Value())
+
+	// Frontends have no queue.
+	queue := findMetric(t, md.Metrics, "haproxy/queue/current")
+	require.Len(t, queue.Timeseries, 3)
+
+	connErrors := findMetric(t, md.Metrics, "haproxy/connections/errors")
+	require.Len(t, connErrors.Timeseries, 3)
+	require.Equal(t, "web-2", connErrors.Timeseries[1].LabelValues[1].Value)
+	require.Equal(t, int64(3), connErrors.Timeseries[1].Points[0].GetInt64Value())
+
+	up := findMetric(t, md.Metrics, "haproxy/up")
+	require.Len(t, up.Timeseries, 3)
+	require.Equal(t, int64(1), up.Timeseries[0].Points[0].GetInt64Value())
+	require.Equal(t, int64(0), up.Timeseries[1].Points[0].GetInt64Value())
+	require.Equal(t, "backend", up.Timeseries[2].LabelValues[2].Value)
+}
+
+func TestBuildMetricsDataInvalidValue(t *testing.T) {
+	rows := []map[string]string{{"pxname": "app", "svname": "web-1", "type": "2", "scur": "x"}}
+	md, warnings := buildMetricsData(rows, time.Now())
+	require.Len(t, warnings, 1)
+	require.Empty(t, md.Metrics)
+}
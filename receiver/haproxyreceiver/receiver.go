@@ -0,0 +1,114 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "http"
+
+var _ component.MetricsReceiver = (*haproxyReceiver)(nil)
+
+// haproxyReceiver implements the component.MetricsReceiver for HAProxy.
+type haproxyReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	client       client
+	cancel       context.CancelFunc
+}
+
+// newHAProxyReceiver creates the HAProxy receiver with the given parameters.
+func newHAProxyReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	r := &haproxyReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		client:       newClient(config.Endpoint, config.Timeout),
+	}
+	return r, nil
+}
+
+// Start begins collecting HAProxy stats at the configured interval.
+func (hr *haproxyReceiver) Start(ctx context.Context, host component.Host) error {
+	ctx, hr.cancel = context.WithCancel(obsreport.ReceiverContext(context.Background(), typeStr, transport, hr.config.Name()))
+	go func() {
+		ticker := time.NewTicker(hr.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				hr.scrape(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HAProxy receiver.
+func (hr *haproxyReceiver) Shutdown(context.Context) error {
+	hr.cancel()
+	return nil
+}
+
+// scrape retrieves the stats and sends the resulting metrics to the next consumer.
+func (hr *haproxyReceiver) scrape(ctx context.Context) {
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+
+	rows, err := hr.readStats(ctx)
+	if err != nil {
+		hr.logger.Error("failed to collect stats from HAProxy", zap.String("endpoint", hr.config.Endpoint), zap.Error(err))
+		obsreport.EndMetricsReceiveOp(ctx, typeStr, 0, 0, err)
+		return
+	}
+
+	md, warnings := buildMetricsData(rows, time.Now())
+	if warnings != nil {
+		hr.logger.Warn("errors parsing HAProxy stats", zap.Errors("parsing errors", warnings))
+	}
+
+	err = hr.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{*md}))
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(*md)
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+}
+
+func (hr *haproxyReceiver) readStats(ctx context.Context) ([]map[string]string, error) {
+	body, err := hr.client.stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return readStats(body)
+}
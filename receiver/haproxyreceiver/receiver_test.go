@@ -0,0 +1,66 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestReceiver(t *testing.T) {
+	stats := readTestStats(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(stats)
+	}))
+	defer srv.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = srv.URL + "/stats;csv"
+	cfg.CollectionInterval = 100 * time.Millisecond
+
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newHAProxyReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+
+	ctx := context.Background()
+	require.NoError(t, metricsReceiver.Start(ctx, componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		return sink.MetricsCount() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, metricsReceiver.Shutdown(ctx))
+}
+
+func TestScrapeError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "/nonexistent/haproxy.sock"
+
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newHAProxyReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	metricsReceiver.(*haproxyReceiver).scrape(context.Background())
+	require.Equal(t, 0, sink.MetricsCount())
+}
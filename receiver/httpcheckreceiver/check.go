@@ -0,0 +1,57 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// checkResult is the outcome of a check of a target.
+type checkResult struct {
+	target     Target
+	statusCode int
+	duration   time.Duration
+	err        error
+}
+
+// check performs a request against the target. The duration includes
+// reading the response body.
+func check(ctx context.Context, client *http.Client, target Target) checkResult {
+	result := checkResult{target: target}
+
+	req, err := http.NewRequestWithContext(ctx, target.method(), target.Endpoint, nil)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.duration = time.Since(start)
+		result.err = err
+		return result
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	result.duration = time.Since(start)
+	result.statusCode = resp.StatusCode
+	return result
+}
@@ -0,0 +1,47 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	client := &http.Client{Timeout: time.Second}
+
+	r := check(context.Background(), client, Target{Endpoint: srv.URL})
+	assert.NoError(t, r.err)
+	assert.Equal(t, http.StatusServiceUnavailable, r.statusCode)
+
+	r = check(context.Background(), client, Target{Endpoint: srv.URL, Method: http.MethodHead})
+	assert.NoError(t, r.err)
+	assert.Equal(t, http.StatusOK, r.statusCode)
+
+	r = check(context.Background(), client, Target{Endpoint: "http://127.0.0.1:1"})
+	assert.Error(t, r.err)
+	assert.Equal(t, 0, r.statusCode)
+}
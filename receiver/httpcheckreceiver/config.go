@@ -0,0 +1,75 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the httpcheck receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// CollectionInterval is the interval at which the targets are checked.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+
+	// Timeout is the timeout of the requests to the targets.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Targets is the list of endpoints to check.
+	Targets []Target `mapstructure:"targets"`
+}
+
+// Target is an endpoint to check.
+type Target struct {
+	// Endpoint is the URL of the target, e.g. http://localhost:8080/health.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Method is the HTTP method of the requests, GET by default.
+	Method string `mapstructure:"method"`
+}
+
+func (c *Config) validate() error {
+	if c.CollectionInterval <= 0 {
+		return errors.New("collection_interval must be positive")
+	}
+	if len(c.Targets) == 0 {
+		return errors.New("at least one target must be specified")
+	}
+	for i, target := range c.Targets {
+		u, err := url.Parse(target.Endpoint)
+		if err != nil {
+			return fmt.Errorf("targets[%d]: invalid endpoint %q: %w", i, target.Endpoint, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("targets[%d]: endpoint %q must be an http or https URL", i, target.Endpoint)
+		}
+	}
+	return nil
+}
+
+// method returns the HTTP method of the requests to the target.
+func (t *Target) method() string {
+	if t.Method == "" {
+		return http.MethodGet
+	}
+	return t.Method
+}
@@ -0,0 +1,134 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver
+
+import (
+	"strconv"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	statusClassLabel  = "http.status_class"
+	errorMessageLabel = "error.message"
+)
+
+// statusClasses are the classes of the HTTP status codes.
+var statusClasses = []string{"1xx", "2xx", "3xx", "4xx", "5xx"}
+
+// buildMetrics converts the results of the checks to metrics.
+func buildMetrics(results []checkResult, ts *timestamp.Timestamp) []*metricspb.Metric {
+	available := &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        "httpcheck.available",
+			Description: "1 if the target answered with a 2xx or 3xx status code, 0 otherwise.",
+			Unit:        "1",
+			Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+			LabelKeys:   labelKeys(conventions.AttributeHTTPURL, conventions.AttributeHTTPMethod),
+		},
+	}
+	status := &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        "httpcheck.status",
+			Description: "1 for the class of the status code the target answered with, 0 for the other classes.",
+			Unit:        "1",
+			Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+			LabelKeys:   labelKeys(conventions.AttributeHTTPURL, conventions.AttributeHTTPMethod, conventions.AttributeHTTPStatusCode, statusClassLabel),
+		},
+	}
+	duration := &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        "httpcheck.duration",
+			Description: "Duration of the request to the target.",
+			Unit:        "ms",
+			Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+			LabelKeys:   labelKeys(conventions.AttributeHTTPURL, conventions.AttributeHTTPMethod),
+		},
+	}
+	errorsMetric := &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        "httpcheck.error",
+			Description: "1 if the request to the target failed, with the error.",
+			Unit:        "1",
+			Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+			LabelKeys:   labelKeys(conventions.AttributeHTTPURL, conventions.AttributeHTTPMethod, errorMessageLabel),
+		},
+	}
+
+	for _, r := range results {
+		url, method := r.target.Endpoint, r.target.method()
+		duration.Timeseries = append(duration.Timeseries, int64Series(int64(r.duration/time.Millisecond), ts, url, method))
+
+		if r.err != nil {
+			available.Timeseries = append(available.Timeseries, int64Series(0, ts, url, method))
+			errorsMetric.Timeseries = append(errorsMetric.Timeseries, int64Series(1, ts, url, method, r.err.Error()))
+			continue
+		}
+
+		class := statusClass(r.statusCode)
+		isAvailable := int64(0)
+		if class == "2xx" || class == "3xx" {
+			isAvailable = 1
+		}
+		available.Timeseries = append(available.Timeseries, int64Series(isAvailable, ts, url, method))
+
+		code := strconv.Itoa(r.statusCode)
+		for _, c := range statusClasses {
+			value := int64(0)
+			if c == class {
+				value = 1
+			}
+			status.Timeseries = append(status.Timeseries, int64Series(value, ts, url, method, code, c))
+		}
+	}
+
+	metrics := make([]*metricspb.Metric, 0, 4)
+	for _, m := range []*metricspb.Metric{available, status, duration, errorsMetric} {
+		if len(m.Timeseries) > 0 {
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics
+}
+
+// statusClass returns the class of a status code, e.g. 2xx for 204.
+func statusClass(statusCode int) string {
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+func labelKeys(keys ...string) []*metricspb.LabelKey {
+	labelKeys := make([]*metricspb.LabelKey, 0, len(keys))
+	for _, key := range keys {
+		labelKeys = append(labelKeys, &metricspb.LabelKey{Key: key})
+	}
+	return labelKeys
+}
+
+func int64Series(value int64, ts *timestamp.Timestamp, labelValues ...string) *metricspb.TimeSeries {
+	series := &metricspb.TimeSeries{
+		LabelValues: make([]*metricspb.LabelValue, 0, len(labelValues)),
+		Points: []*metricspb.Point{{
+			Timestamp: ts,
+			Value:     &metricspb.Point_Int64Value{Int64Value: value},
+		}},
+	}
+	for _, lv := range labelValues {
+		series.LabelValues = append(series.LabelValues, &metricspb.LabelValue{Value: lv, HasValue: true})
+	}
+	return series
+}
@@ -0,0 +1,95 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMetrics(t *testing.T) {
+	ts := ptypes.TimestampNow()
+	metrics := buildMetrics([]checkResult{
+		{
+			target:     Target{Endpoint: "http://localhost:8080/health"},
+			statusCode: 204,
+			duration:   15 * time.Millisecond,
+		},
+		{
+			target:     Target{Endpoint: "http://localhost:8081/health", Method: "HEAD"},
+			statusCode: 503,
+			duration:   5 * time.Millisecond,
+		},
+	}, ts)
+	require.Len(t, metrics, 3)
+
+	assert.Equal(t, "httpcheck.available", metrics[0].MetricDescriptor.Name)
+	assert.Equal(t, []*metricspb.TimeSeries{
+		int64Series(1, ts, "http://localhost:8080/health", "GET"),
+		int64Series(0, ts, "http://localhost:8081/health", "HEAD"),
+	}, metrics[0].Timeseries)
+
+	assert.Equal(t, "httpcheck.status", metrics[1].MetricDescriptor.Name)
+	assert.Equal(t, []*metricspb.TimeSeries{
+		int64Series(0, ts, "http://localhost:8080/health", "GET", "204", "1xx"),
+		int64Series(1, ts, "http://localhost:8080/health", "GET", "204", "2xx"),
+		int64Series(0, ts, "http://localhost:8080/health", "GET", "204", "3xx"),
+		int64Series(0, ts, "http://localhost:8080/health", "GET", "204", "4xx"),
+		int64Series(0, ts, "http://localhost:8080/health", "GET", "204", "5xx"),
+		int64Series(0, ts, "http://localhost:8081/health", "HEAD", "503", "1xx"),
+		int64Series(0, ts, "http://localhost:8081/health", "HEAD", "503", "2xx"),
+		int64Series(0, ts, "http://localhost:8081/health", "HEAD", "503", "3xx"),
+		int64Series(0, ts, "http://localhost:8081/health", "HEAD", "503", "4xx"),
+		int64Series(1, ts, "http://localhost:8081/health", "HEAD", "503", "5xx"),
+	}, metrics[1].Timeseries)
+
+	assert.Equal(t, "httpcheck.duration", metrics[2].MetricDescriptor.Name)
+	assert.Equal(t, []*metricspb.TimeSeries{
+		int64Series(15, ts, "http://localhost:8080/health", "GET"),
+		int64Series(5, ts, "http://localhost:8081/health", "HEAD"),
+	}, metrics[2].Timeseries)
+}
+
+func TestBuildMetricsWithError(t *testing.T) {
+	ts := ptypes.TimestampNow()
+	metrics := buildMetrics([]checkResult{
+		{
+			target:   Target{Endpoint: "http://localhost:8080/health"},
+			duration: 2 * time.Millisecond,
+			err:      errors.New("connection refused"),
+		},
+	}, ts)
+	require.Len(t, metrics, 3)
+
+	assert.Equal(t, "httpcheck.available", metrics[0].MetricDescriptor.Name)
+	assert.Equal(t, []*metricspb.TimeSeries{int64Series(0, ts, "http://localhost:8080/health", "GET")}, metrics[0].Timeseries)
+	assert.Equal(t, "httpcheck.duration", metrics[1].MetricDescriptor.Name)
+	assert.Equal(t, "httpcheck.error", metrics[2].MetricDescriptor.Name)
+	assert.Equal(t, []*metricspb.TimeSeries{
+		int64Series(1, ts, "http://localhost:8080/health", "GET", "connection refused"),
+	}, metrics[2].Timeseries)
+}
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, "2xx", statusClass(200))
+	assert.Equal(t, "3xx", statusClass(301))
+	assert.Equal(t, "5xx", statusClass(599))
+}
@@ -0,0 +1,123 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "http"
+
+var _ component.MetricsReceiver = (*httpCheckReceiver)(nil)
+
+// httpCheckReceiver implements the component.MetricsReceiver for HTTP checks.
+type httpCheckReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	client       *http.Client
+	cancel       context.CancelFunc
+}
+
+// newHTTPCheckReceiver creates the httpcheck receiver with the given
+// parameters.
+func newHTTPCheckReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	r := &httpCheckReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		client:       &http.Client{Timeout: config.Timeout},
+	}
+	return r, nil
+}
+
+// Start begins checking the targets.
+func (hr *httpCheckReceiver) Start(ctx context.Context, host component.Host) error {
+	ctx, hr.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, hr.config.Name()))
+	go func() {
+		ticker := time.NewTicker(hr.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := hr.checkTargets(ctx); err != nil {
+					hr.logger.Warn("Failed to send httpcheck metrics", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the httpcheck receiver.
+func (hr *httpCheckReceiver) Shutdown(context.Context) error {
+	if hr.cancel != nil {
+		hr.cancel()
+	}
+	return nil
+}
+
+// checkTargets checks all the targets concurrently and sends the results.
+func (hr *httpCheckReceiver) checkTargets(ctx context.Context) error {
+	results := make([]checkResult, len(hr.config.Targets))
+	var wg sync.WaitGroup
+	for i, target := range hr.config.Targets {
+		wg.Add(1)
+		go func(i int, target Target) {
+			defer wg.Done()
+			results[i] = check(ctx, hr.client, target)
+		}(i, target)
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		if r.err != nil {
+			hr.logger.Debug("HTTP check failed", zap.String("endpoint", r.target.Endpoint), zap.Error(r.err))
+		}
+	}
+
+	md := consumerdata.MetricsData{Metrics: buildMetrics(results, ptypes.TimestampNow())}
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(md)
+
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+	err := hr.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md}))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+	return err
+}
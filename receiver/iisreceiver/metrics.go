@@ -0,0 +1,343 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iisreceiver
+
+import (
+	"strings"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/pdh"
+)
+
+const (
+	siteLabel       = "iis.site"
+	appPoolLabel    = "iis.application_pool"
+	processPIDLabel = "process.pid"
+	requestLabel    = "request"
+	directionLabel  = "direction"
+
+	totalInstance = "_Total"
+)
+
+// objectSpec describes a performance object of IIS and how its instances are
+// mapped to labels.
+type objectSpec struct {
+	name      string
+	labelKeys []string
+	// labelValues returns the label values of an instance, or false if the
+	// instance must not be reported.
+	labelValues func(instance string) ([]string, bool)
+}
+
+var (
+	webService = objectSpec{
+		name:        "Web Service",
+		labelKeys:   []string{siteLabel},
+		labelValues: namedInstance,
+	}
+	requestQueues = objectSpec{
+		name:        "HTTP Service Request Queues",
+		labelKeys:   []string{appPoolLabel},
+		labelValues: namedInstance,
+	}
+	appPools = objectSpec{
+		name:        "APP_POOL_WAS",
+		labelKeys:   []string{appPoolLabel},
+		labelValues: namedInstance,
+	}
+	workerProcesses = objectSpec{
+		name:        "W3SVC_W3WP",
+		labelKeys:   []string{appPoolLabel, processPIDLabel},
+		labelValues: workerProcessInstance,
+	}
+)
+
+// namedInstance maps an instance to a label holding its name, skipping the
+// aggregated _Total instance.
+func namedInstance(instance string) ([]string, bool) {
+	if instance == "" || instance == totalInstance {
+		return nil, false
+	}
+	return []string{instance}, true
+}
+
+// workerProcessInstance maps an instance of a worker process, named
+// <pid>_<application pool>, to its application pool and pid labels.
+func workerProcessInstance(instance string) ([]string, bool) {
+	i := strings.IndexByte(instance, '_')
+	if i <= 0 || i == len(instance)-1 {
+		return nil, false
+	}
+	return []string{instance[i+1:], instance[:i]}, true
+}
+
+// counterSpec is a counter reported by a metric, identified by the value of
+// the label of the metric distinguishing its counters, if any.
+type counterSpec struct {
+	name      string
+	attrValue string
+}
+
+// metricSpec describes a metric and the counters it is built from.
+type metricSpec struct {
+	name        string
+	description string
+	unit        string
+	metricType  metricspb.MetricDescriptor_Type
+	object      objectSpec
+	// attrKey is the key of the label distinguishing the counters of the
+	// metric, empty if the metric is built from a single counter.
+	attrKey  string
+	counters []counterSpec
+}
+
+var metricSpecs = []metricSpec{
+	{
+		name:        "iis.request.count",
+		description: "Total number of requests of a given type.",
+		unit:        "{requests}",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		object:      webService,
+		attrKey:     requestLabel,
+		counters: []counterSpec{
+			{name: "Total Delete Requests", attrValue: "delete"},
+			{name: "Total Get Requests", attrValue: "get"},
+			{name: "Total Head Requests", attrValue: "head"},
+			{name: "Total Options Requests", attrValue: "options"},
+			{name: "Total Post Requests", attrValue: "post"},
+			{name: "Total Put Requests", attrValue: "put"},
+			{name: "Total Trace Requests", attrValue: "trace"},
+		},
+	},
+	{
+		name:        "iis.connection.active",
+		description: "Number of active connections.",
+		unit:        "{connections}",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		object:      webService,
+		counters:    []counterSpec{{name: "Current Connections"}},
+	},
+	{
+		name:        "iis.connection.attempt.count",
+		description: "Total number of attempts to connect to the server.",
+		unit:        "{attempts}",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		object:      webService,
+		counters:    []counterSpec{{name: "Total Connection Attempts (all instances)"}},
+	},
+	{
+		name:        "iis.connection.anonymous",
+		description: "Total number of connections established anonymously.",
+		unit:        "{connections}",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		object:      webService,
+		counters:    []counterSpec{{name: "Total Anonymous Users"}},
+	},
+	{
+		name:        "iis.network.io",
+		description: "Total amount of bytes sent and received.",
+		unit:        "By",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		object:      webService,
+		attrKey:     directionLabel,
+		counters: []counterSpec{
+			{name: "Total Bytes Received", attrValue: "received"},
+			{name: "Total Bytes Sent", attrValue: "sent"},
+		},
+	},
+	{
+		name:        "iis.network.file.count",
+		description: "Total number of files sent and received.",
+		unit:        "{files}",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		object:      webService,
+		attrKey:     directionLabel,
+		counters: []counterSpec{
+			{name: "Total Files Received", attrValue: "received"},
+			{name: "Total Files Sent", attrValue: "sent"},
+		},
+	},
+	{
+		name:        "iis.network.blocked",
+		description: "Total amount of bytes blocked due to bandwidth throttling.",
+		unit:        "By",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		object:      webService,
+		counters:    []counterSpec{{name: "Total blocked bandwidth bytes"}},
+	},
+	{
+		name:        "iis.uptime",
+		description: "The amount of time the site has been running.",
+		unit:        "s",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		object:      webService,
+		counters:    []counterSpec{{name: "Service Uptime"}},
+	},
+	{
+		name:        "iis.request.queue.count",
+		description: "Current number of requests in the queue of the application pool.",
+		unit:        "{requests}",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		object:      requestQueues,
+		counters:    []counterSpec{{name: "CurrentQueueSize"}},
+	},
+	{
+		name:        "iis.request.queue.age.max",
+		description: "Age of the oldest request in the queue of the application pool.",
+		unit:        "ms",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		object:      requestQueues,
+		counters:    []counterSpec{{name: "MaxQueueItemAge"}},
+	},
+	{
+		name:        "iis.request.rejected",
+		description: "Total number of requests rejected by the queue of the application pool.",
+		unit:        "{requests}",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		object:      requestQueues,
+		counters:    []counterSpec{{name: "RejectedRequests"}},
+	},
+	{
+		name:        "iis.application_pool.worker_process.count",
+		description: "Current number of worker processes of the application pool.",
+		unit:        "{processes}",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		object:      appPools,
+		counters:    []counterSpec{{name: "Current Worker Processes"}},
+	},
+	{
+		name:        "iis.application_pool.worker_process.failure.count",
+		description: "Total number of failures of the worker processes of the application pool.",
+		unit:        "{failures}",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		object:      appPools,
+		counters:    []counterSpec{{name: "Total Worker Process Failures"}},
+	},
+	{
+		name:        "iis.application_pool.uptime",
+		description: "The amount of time the application pool has been running.",
+		unit:        "s",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		object:      appPools,
+		counters:    []counterSpec{{name: "Current Application Pool Uptime"}},
+	},
+	{
+		name:        "iis.worker_process.request.active",
+		description: "Current number of requests being processed by the worker process.",
+		unit:        "{requests}",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		object:      workerProcesses,
+		counters:    []counterSpec{{name: "Active Requests"}},
+	},
+	{
+		name:        "iis.worker_process.request.count",
+		description: "Total number of requests served by the worker process.",
+		unit:        "{requests}",
+		metricType:  metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		object:      workerProcesses,
+		counters:    []counterSpec{{name: "Total HTTP Requests Served"}},
+	},
+	{
+		name:        "iis.worker_process.thread.active",
+		description: "Current number of threads of the worker process processing requests.",
+		unit:        "{threads}",
+		metricType:  metricspb.MetricDescriptor_GAUGE_INT64,
+		object:      workerProcesses,
+		counters:    []counterSpec{{name: "Active Threads Count"}},
+	},
+}
+
+// counterPath returns the PDH path of a counter of all the instances of an
+// object, e.g. `\Web Service(*)\Current Connections`.
+func counterPath(object, counter string) string {
+	return `\` + object + `(*)\` + counter
+}
+
+// counterPaths returns the paths of all the counters of the metrics.
+func counterPaths() []string {
+	var paths []string
+	for _, spec := range metricSpecs {
+		for _, c := range spec.counters {
+			paths = append(paths, counterPath(spec.object.name, c.name))
+		}
+	}
+	return paths
+}
+
+// buildMetrics converts the values of the counters, keyed by path, to
+// metrics. Cumulative metrics start at startTime.
+func buildMetrics(values map[string][]pdh.CounterValue, startTime, ts *timestamp.Timestamp) []*metricspb.Metric {
+	metrics := make([]*metricspb.Metric, 0, len(metricSpecs))
+	for _, spec := range metricSpecs {
+		if m := spec.toMetric(values, startTime, ts); m != nil {
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics
+}
+
+func (spec *metricSpec) toMetric(values map[string][]pdh.CounterValue, startTime, ts *timestamp.Timestamp) *metricspb.Metric {
+	var timeseries []*metricspb.TimeSeries
+	for _, c := range spec.counters {
+		for _, v := range values[counterPath(spec.object.name, c.name)] {
+			labelValues, ok := spec.object.labelValues(v.InstanceName)
+			if !ok {
+				continue
+			}
+			if spec.attrKey != "" {
+				labelValues = append(labelValues, c.attrValue)
+			}
+
+			series := &metricspb.TimeSeries{
+				LabelValues: make([]*metricspb.LabelValue, 0, len(labelValues)),
+				Points: []*metricspb.Point{{
+					Timestamp: ts,
+					Value:     &metricspb.Point_Int64Value{Int64Value: int64(v.Value)},
+				}},
+			}
+			for _, lv := range labelValues {
+				series.LabelValues = append(series.LabelValues, &metricspb.LabelValue{Value: lv, HasValue: true})
+			}
+			if spec.metricType == metricspb.MetricDescriptor_CUMULATIVE_INT64 {
+				series.StartTimestamp = startTime
+			}
+			timeseries = append(timeseries, series)
+		}
+	}
+	if len(timeseries) == 0 {
+		return nil
+	}
+
+	labelKeys := make([]*metricspb.LabelKey, 0, len(spec.object.labelKeys)+1)
+	for _, key := range spec.object.labelKeys {
+		labelKeys = append(labelKeys, &metricspb.LabelKey{Key: key})
+	}
+	if spec.attrKey != "" {
+		labelKeys = append(labelKeys, &metricspb.LabelKey{Key: spec.attrKey})
+	}
+
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        spec.name,
+			Description: spec.description,
+			Unit:        spec.unit,
+			Type:        spec.metricType,
+			LabelKeys:   labelKeys,
+		},
+		Timeseries: timeseries,
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iisreceiver
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/pdh"
+)
+
+func TestCounterPaths(t *testing.T) {
+	paths := counterPaths()
+	assert.Contains(t, paths, `\Web Service(*)\Total Get Requests`)
+	assert.Contains(t, paths, `\HTTP Service Request Queues(*)\CurrentQueueSize`)
+	assert.Contains(t, paths, `\APP_POOL_WAS(*)\Current Worker Processes`)
+	assert.Contains(t, paths, `\W3SVC_W3WP(*)\Active Requests`)
+}
+
+func TestWorkerProcessInstance(t *testing.T) {
+	labelValues, ok := workerProcessInstance("1234_DefaultAppPool")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"DefaultAppPool", "1234"}, labelValues)
+
+	labelValues, ok = workerProcessInstance("1234_My_App_Pool")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"My_App_Pool", "1234"}, labelValues)
+
+	_, ok = workerProcessInstance("_Total")
+	assert.False(t, ok)
+
+	_, ok = workerProcessInstance("1234_")
+	assert.False(t, ok)
+}
+
+func TestBuildMetrics(t *testing.T) {
+	startTime := ptypes.TimestampNow()
+	ts := ptypes.TimestampNow()
+
+	metrics := buildMetrics(map[string][]pdh.CounterValue{
+		`\Web Service(*)\Total Get Requests`: {
+			{InstanceName: "_Total", Value: 15},
+			{InstanceName: "Default Web Site", Value: 10},
+			{InstanceName: "api", Value: 5},
+		},
+		`\Web Service(*)\Total Post Requests`: {
+			{InstanceName: "_Total", Value: 3},
+			{InstanceName: "api", Value: 3},
+		},
+		`\HTTP Service Request Queues(*)\CurrentQueueSize`: {
+			{InstanceName: "DefaultAppPool", Value: 2},
+		},
+		`\W3SVC_W3WP(*)\Active Requests`: {
+			{InstanceName: "_Total", Value: 1},
+			{InstanceName: "4321_DefaultAppPool", Value: 1},
+		},
+	}, startTime, ts)
+	require.Len(t, metrics, 3)
+
+	assert.Equal(t, &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        "iis.request.count",
+			Description: "Total number of requests of a given type.",
+			Unit:        "{requests}",
+			Type:        metricspb.MetricDescriptor_CUMULATIVE_INT64,
+			LabelKeys:   []*metricspb.LabelKey{{Key: siteLabel}, {Key: requestLabel}},
+		},
+		Timeseries: []*metricspb.TimeSeries{
+			cumulativeSeries(startTime, ts, 10, "Default Web Site", "get"),
+			cumulativeSeries(startTime, ts, 5, "api", "get"),
+			cumulativeSeries(startTime, ts, 3, "api", "post"),
+		},
+	}, metrics[0])
+
+	assert.Equal(t, "iis.request.queue.count", metrics[1].MetricDescriptor.Name)
+	assert.Equal(t, []*metricspb.LabelKey{{Key: appPoolLabel}}, metrics[1].MetricDescriptor.LabelKeys)
+	require.Len(t, metrics[1].Timeseries, 1)
+	assert.Nil(t, metrics[1].Timeseries[0].StartTimestamp)
+	assert.Equal(t, "DefaultAppPool", metrics[1].Timeseries[0].LabelValues[0].Value)
+
+	assert.Equal(t, "iis.worker_process.request.active", metrics[2].MetricDescriptor.Name)
+	assert.Equal(t, []*metricspb.LabelKey{{Key: appPoolLabel}, {Key: processPIDLabel}}, metrics[2].MetricDescriptor.LabelKeys)
+	require.Len(t, metrics[2].Timeseries, 1)
+	assert.Equal(t, []*metricspb.LabelValue{
+		{Value: "DefaultAppPool", HasValue: true},
+		{Value: "4321", HasValue: true},
+	}, metrics[2].Timeseries[0].LabelValues)
+}
+
+func cumulativeSeries(startTime, ts *timestamp.Timestamp, value int64, labelValues ...string) *metricspb.TimeSeries {
+	series := &metricspb.TimeSeries{
+		StartTimestamp: startTime,
+		Points: []*metricspb.Point{{
+			Timestamp: ts,
+			Value:     &metricspb.Point_Int64Value{Int64Value: value},
+		}},
+	}
+	for _, lv := range labelValues {
+		series.LabelValues = append(series.LabelValues, &metricspb.LabelValue{Value: lv, HasValue: true})
+	}
+	return series
+}
@@ -0,0 +1,160 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build windows
+
+package iisreceiver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/pdh"
+)
+
+const transport = "pdh"
+
+var _ component.MetricsReceiver = (*iisReceiver)(nil)
+
+// iisReceiver implements the component.MetricsReceiver for IIS.
+type iisReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	query        *pdh.Query
+	counters     map[string]*pdh.Counter
+	startTime    *timestamp.Timestamp
+	cancel       context.CancelFunc
+	// done tracks the collection goroutine, the query is only closed once it
+	// has returned.
+	done sync.WaitGroup
+}
+
+// newIISReceiver creates the IIS receiver with the given parameters.
+func newIISReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	r := &iisReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+	}
+	return r, nil
+}
+
+// Start opens the PDH query of the IIS counters and begins collecting them.
+func (ir *iisReceiver) Start(ctx context.Context, host component.Host) error {
+	query, err := pdh.OpenQuery()
+	if err != nil {
+		return err
+	}
+	ir.query = query
+
+	ir.counters = make(map[string]*pdh.Counter)
+	for _, path := range counterPaths() {
+		counter, err := query.AddCounter(path)
+		if err != nil {
+			ir.logger.Warn("Failed to add IIS performance counter", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		ir.counters[path] = counter
+	}
+
+	if err := query.Collect(); err != nil {
+		ir.logger.Warn("Failed to collect IIS performance counters", zap.Error(err))
+	}
+	ir.startTime = ptypes.TimestampNow()
+
+	ctx, ir.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, ir.config.Name()))
+	ir.done.Add(1)
+	go func() {
+		defer ir.done.Done()
+		ticker := time.NewTicker(ir.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := ir.collect(ctx); err != nil {
+					ir.logger.Warn("Failed to collect IIS performance counters", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the IIS receiver and closes the PDH query once the
+// collection in progress, if any, is over.
+func (ir *iisReceiver) Shutdown(context.Context) error {
+	if ir.cancel != nil {
+		ir.cancel()
+	}
+	ir.done.Wait()
+	if ir.query != nil {
+		return ir.query.Close()
+	}
+	return nil
+}
+
+// collect sends the IIS metrics built from the current values of the counters.
+func (ir *iisReceiver) collect(ctx context.Context) error {
+	if err := ir.query.Collect(); err != nil {
+		return err
+	}
+
+	values := make(map[string][]pdh.CounterValue, len(ir.counters))
+	for path, counter := range ir.counters {
+		v, err := counter.Values()
+		if err != nil {
+			ir.logger.Debug("Failed to read IIS performance counter", zap.Error(err))
+			continue
+		}
+		values[path] = v
+	}
+
+	metrics := buildMetrics(values, ir.startTime, ptypes.TimestampNow())
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	md := consumerdata.MetricsData{Metrics: metrics}
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(md)
+
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+	err := ir.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md}))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+	return err
+}
@@ -0,0 +1,77 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jmxreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the JMX receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+	// JARPath is the path to the JMX Metric Gatherer jar.
+	JARPath string `mapstructure:"jar_path"`
+	// Endpoint is the JMX service URL or the host:port of the JMX server to connect to.
+	Endpoint string `mapstructure:"endpoint"`
+	// TargetSystem is the name of a built-in target system script of the JMX Metric Gatherer
+	// (e.g. jvm, kafka, cassandra). Mutually exclusive with GroovyScript.
+	TargetSystem string `mapstructure:"target_system"`
+	// GroovyScript is the path to a custom groovy script run by the JMX Metric Gatherer.
+	// Mutually exclusive with TargetSystem.
+	GroovyScript string `mapstructure:"groovy_script"`
+	// CollectionInterval is the interval at which the JMX Metric Gatherer collects metrics.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+	// Username is the username for JMX authentication.
+	Username string `mapstructure:"username"`
+	// Password is the password for JMX authentication.
+	Password string `mapstructure:"password"`
+	// OTLPExporterConfig is the configuration of the local OTLP endpoint the
+	// JMX Metric Gatherer sends metrics to.
+	OTLPExporterConfig otlpExporterConfig `mapstructure:"otlp"`
+	// Properties are additional system properties passed to the JMX Metric Gatherer.
+	Properties map[string]string `mapstructure:"properties"`
+}
+
+type otlpExporterConfig struct {
+	// Endpoint is the host:port the internal OTLP receiver listens on. A random
+	// port is used if the port is 0.
+	Endpoint string `mapstructure:"endpoint"`
+	// Timeout is the timeout of the JMX Metric Gatherer's OTLP exporter.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+func (c *Config) validate() error {
+	var missingFields []string
+	if c.Endpoint == "" {
+		missingFields = append(missingFields, "`endpoint`")
+	}
+	if c.TargetSystem == "" && c.GroovyScript == "" {
+		missingFields = append(missingFields, "`target_system` or `groovy_script`")
+	}
+	if missingFields != nil {
+		return fmt.Errorf("%v missing required field(s): %v", c.Name(), missingFields)
+	}
+	if c.TargetSystem != "" && c.GroovyScript != "" {
+		return fmt.Errorf("%v only one of `target_system` and `groovy_script` can be set", c.Name())
+	}
+	if c.CollectionInterval < 0 {
+		return errors.New("`collection_interval` must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jmxreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 3)
+
+	r0 := cfg.Receivers["jmx"].(*Config)
+	assert.Equal(t, factory.CreateDefaultConfig(), r0)
+	assert.Error(t, r0.validate())
+
+	r1 := cfg.Receivers["jmx/all"].(*Config)
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: configmodels.Type(typeStr),
+				NameVal: "jmx/all",
+			},
+			JARPath:            "/opt/jmx-metrics.jar",
+			Endpoint:           "myhost:12345",
+			TargetSystem:       "jvm",
+			CollectionInterval: 15 * time.Second,
+			Username:           "myusername",
+			Password:           "mypassword",
+			OTLPExporterConfig: otlpExporterConfig{
+				Endpoint: "localhost:55690",
+				Timeout:  10 * time.Second,
+			},
+			Properties: map[string]string{
+				"otel.resource.attributes": "service.name=myservice",
+			},
+		}, r1)
+	assert.NoError(t, r1.validate())
+
+	r2 := cfg.Receivers["jmx/groovy"].(*Config)
+	assert.Equal(t, "service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi", r2.Endpoint)
+	assert.Equal(t, "/opt/scripts/custom.groovy", r2.GroovyScript)
+	assert.NoError(t, r2.validate())
+}
+
+func TestValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.validate(), "jmx missing required field(s): [`endpoint` `target_system` or `groovy_script`]")
+
+	cfg.Endpoint = "myhost:12345"
+	cfg.TargetSystem = "jvm"
+	cfg.GroovyScript = "/opt/scripts/custom.groovy"
+	assert.EqualError(t, cfg.validate(), "jmx only one of `target_system` and `groovy_script` can be set")
+
+	cfg.GroovyScript = ""
+	assert.NoError(t, cfg.validate())
+}
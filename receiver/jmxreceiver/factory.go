@@ -0,0 +1,75 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jmxreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+const (
+	typeStr = "jmx"
+
+	defaultJARPath            = "/opt/opentelemetry-java-contrib-jmx-metrics.jar"
+	defaultCollectionInterval = 10 * time.Second
+	defaultOTLPEndpoint       = "localhost:0"
+	defaultOTLPTimeout        = 5 * time.Second
+)
+
+// NewFactory creates a factory for the JMX receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithMetrics(createMetricsReceiver))
+}
+
+func createDefaultConfig() configmodels.Receiver {
+	return &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		JARPath:            defaultJARPath,
+		CollectionInterval: defaultCollectionInterval,
+		OTLPExporterConfig: otlpExporterConfig{
+			Endpoint: defaultOTLPEndpoint,
+			Timeout:  defaultOTLPTimeout,
+		},
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.MetricsConsumer,
+) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	jmxConfig := cfg.(*Config)
+	if err := jmxConfig.validate(); err != nil {
+		return nil, err
+	}
+	return newJMXMetricReceiver(params, jmxConfig, nextConsumer), nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jmxreceiver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusexecreceiver/subprocessmanager"
+)
+
+// restartDelay is the time to wait before restarting the JMX Metric Gatherer after it exited.
+const restartDelay = 5 * time.Second
+
+var _ component.MetricsReceiver = (*jmxMetricReceiver)(nil)
+
+type jmxMetricReceiver struct {
+	params   component.ReceiverCreateParams
+	config   *Config
+	consumer consumer.MetricsConsumer
+
+	otlpReceiver component.MetricsReceiver
+	subprocess   *subprocessmanager.SubprocessConfig
+	configFile   string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newJMXMetricReceiver(
+	params component.ReceiverCreateParams,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer,
+) *jmxMetricReceiver {
+	return &jmxMetricReceiver{
+		params:   params,
+		config:   config,
+		consumer: nextConsumer,
+	}
+}
+
+// Start starts the internal OTLP receiver and the JMX Metric Gatherer subprocess.
+func (jmx *jmxMetricReceiver) Start(ctx context.Context, host component.Host) error {
+	otlpEndpoint, err := resolveOTLPEndpoint(jmx.config.OTLPExporterConfig.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	jmx.otlpReceiver, err = jmx.buildOTLPReceiver(ctx, otlpEndpoint)
+	if err != nil {
+		return err
+	}
+	if err = jmx.otlpReceiver.Start(ctx, host); err != nil {
+		return fmt.Errorf("failed starting internal OTLP receiver: %w", err)
+	}
+
+	jmx.configFile, err = writeConfigFile(buildProperties(jmx.config, otlpEndpoint))
+	if err != nil {
+		_ = jmx.otlpReceiver.Shutdown(ctx)
+		return err
+	}
+
+	jmx.subprocess = &subprocessmanager.SubprocessConfig{
+		Command: shellquote.Join("java", "-jar", jmx.config.JARPath, "-config", jmx.configFile),
+	}
+
+	var runCtx context.Context
+	runCtx, jmx.cancel = context.WithCancel(context.Background())
+	jmx.done = make(chan struct{})
+	go jmx.runSubprocess(runCtx)
+
+	return nil
+}
+
+// Shutdown stops the JMX Metric Gatherer subprocess and the internal OTLP receiver.
+func (jmx *jmxMetricReceiver) Shutdown(ctx context.Context) error {
+	if jmx.cancel != nil {
+		jmx.cancel()
+		<-jmx.done
+	}
+	if jmx.configFile != "" {
+		os.Remove(jmx.configFile)
+	}
+	if jmx.otlpReceiver != nil {
+		return jmx.otlpReceiver.Shutdown(ctx)
+	}
+	return nil
+}
+
+// runSubprocess runs the JMX Metric Gatherer until ctx is cancelled, restarting
+// it whenever it exits.
+func (jmx *jmxMetricReceiver) runSubprocess(ctx context.Context) {
+	defer close(jmx.done)
+	for {
+		elapsed, err := jmx.subprocess.Run(ctx, jmx.params.Logger)
+		if ctx.Err() != nil {
+			return
+		}
+		jmx.params.Logger.Error("JMX Metric Gatherer exited, restarting",
+			zap.Duration("elapsed", elapsed),
+			zap.Duration("delay", restartDelay),
+			zap.Error(err))
+
+		select {
+		case <-time.After(restartDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (jmx *jmxMetricReceiver) buildOTLPReceiver(ctx context.Context, endpoint string) (component.MetricsReceiver, error) {
+	factory := otlpreceiver.NewFactory()
+	config := factory.CreateDefaultConfig().(*otlpreceiver.Config)
+	config.SetName(jmx.config.Name())
+	config.GRPC.NetAddr.Endpoint = endpoint
+	config.HTTP = nil
+
+	return factory.CreateMetricsReceiver(ctx, jmx.params, config, jmx.consumer)
+}
+
+// resolveOTLPEndpoint replaces a 0 port in endpoint by a currently available port.
+func resolveOTLPEndpoint(endpoint string) (string, error) {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid otlp endpoint %q: %w", endpoint, err)
+	}
+	if port != "0" {
+		return endpoint, nil
+	}
+
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed finding an available port for %q: %w", endpoint, err)
+	}
+	defer listener.Close()
+	return net.JoinHostPort(host, strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)), nil
+}
+
+// serviceURL returns the JMX service URL for endpoint, which may already be a
+// service URL or a host:port.
+func serviceURL(endpoint string) string {
+	if strings.HasPrefix(endpoint, "service:jmx:") {
+		return endpoint
+	}
+	return fmt.Sprintf("service:jmx:rmi:///jndi/rmi://%s/jmxrmi", endpoint)
+}
+
+// buildProperties returns the JMX Metric Gatherer configuration properties.
+func buildProperties(cfg *Config, otlpEndpoint string) map[string]string {
+	props := map[string]string{
+		"otel.jmx.service.url":           serviceURL(cfg.Endpoint),
+		"otel.jmx.interval.milliseconds": strconv.FormatInt(cfg.CollectionInterval.Milliseconds(), 10),
+		"otel.exporter":                  "otlp",
+		"otel.otlp.endpoint":             otlpEndpoint,
+		"otel.otlp.metric.timeout":       strconv.FormatInt(cfg.OTLPExporterConfig.Timeout.Milliseconds(), 10),
+	}
+	if cfg.TargetSystem != "" {
+		props["otel.jmx.target.system"] = cfg.TargetSystem
+	}
+	if cfg.GroovyScript != "" {
+		props["otel.jmx.groovy.script"] = cfg.GroovyScript
+	}
+	if cfg.Username != "" {
+		props["otel.jmx.username"] = cfg.Username
+	}
+	if cfg.Password != "" {
+		props["otel.jmx.password"] = cfg.Password
+	}
+	for k, v := range cfg.Properties {
+		props[k] = v
+	}
+	return props
+}
+
+// writeConfigFile writes props to a temporary properties file so that
+// credentials don't show up in the subprocess command line.
+func writeConfigFile(props map[string]string) (string, error) {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "%s = %s\n", k, escapePropertyValue(props[k]))
+	}
+
+	f, err := ioutil.TempFile("", "jmx-config-*.properties")
+	if err != nil {
+		return "", fmt.Errorf("failed creating JMX Metric Gatherer config file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err = f.WriteString(sb.String()); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed writing JMX Metric Gatherer config file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// escapePropertyValue escapes backslashes, which are escape characters in Java
+// properties files.
+func escapePropertyValue(value string) string {
+	return strings.ReplaceAll(value, `\`, `\\`)
+}
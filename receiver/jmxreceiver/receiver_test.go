@@ -0,0 +1,109 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jmxreceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestServiceURL(t *testing.T) {
+	require.Equal(t, "service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi", serviceURL("myhost:12345"))
+	require.Equal(t, "service:jmx:rmi:///jndi/rmi://other:1/jmxrmi", serviceURL("service:jmx:rmi:///jndi/rmi://other:1/jmxrmi"))
+}
+
+func TestBuildProperties(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "myhost:12345"
+	cfg.TargetSystem = "kafka"
+	cfg.Username = "myusername"
+	cfg.Password = "mypassword"
+	cfg.Properties = map[string]string{"otel.resource.attributes": "service.name=kafka"}
+
+	require.Equal(t, map[string]string{
+		"otel.jmx.service.url":           "service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi",
+		"otel.jmx.interval.milliseconds": "10000",
+		"otel.jmx.target.system":         "kafka",
+		"otel.jmx.username":              "myusername",
+		"otel.jmx.password":              "mypassword",
+		"otel.exporter":                  "otlp",
+		"otel.otlp.endpoint":             "localhost:55690",
+		"otel.otlp.metric.timeout":       "5000",
+		"otel.resource.attributes":       "service.name=kafka",
+	}, buildProperties(cfg, "localhost:55690"))
+}
+
+func TestWriteConfigFile(t *testing.T) {
+	file, err := writeConfigFile(map[string]string{
+		"otel.jmx.target.system": "jvm",
+		"otel.jmx.groovy.script": `C:\scripts\custom.groovy`,
+	})
+	require.NoError(t, err)
+	defer os.Remove(file)
+
+	content, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "otel.jmx.groovy.script = C:\\\\scripts\\\\custom.groovy\notel.jmx.target.system = jvm\n", string(content))
+}
+
+func TestResolveOTLPEndpoint(t *testing.T) {
+	endpoint, err := resolveOTLPEndpoint("localhost:55690")
+	require.NoError(t, err)
+	require.Equal(t, "localhost:55690", endpoint)
+
+	endpoint, err = resolveOTLPEndpoint("localhost:0")
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(endpoint)
+	require.NoError(t, err)
+	require.Equal(t, "localhost", host)
+	require.NotEqual(t, "0", port)
+
+	_, err = resolveOTLPEndpoint("localhost")
+	require.Error(t, err)
+}
+
+func TestReceiverStartShutdown(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.JARPath = "/nonexistent/jmx-metrics.jar"
+	cfg.Endpoint = "localhost:12345"
+	cfg.TargetSystem = "jvm"
+
+	receiver := newJMXMetricReceiver(
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		cfg,
+		exportertest.NewNopMetricsExporter(),
+	)
+
+	ctx := context.Background()
+	require.NoError(t, receiver.Start(ctx, componenttest.NewNopHost()))
+	configFile := receiver.configFile
+	require.FileExists(t, configFile)
+
+	// The subprocess fails to start, give the receiver some time to attempt a restart.
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, receiver.Shutdown(ctx))
+	require.NoFileExists(t, configFile)
+}
@@ -0,0 +1,135 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntpreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"time"
+)
+
+// The tracking request and reply of version 6 of the command and monitoring
+// protocol of chronyd, see candm.h in the chrony sources.
+const (
+	chronyProtocolVersion = 6
+	chronyPktTypeRequest  = 1
+	chronyPktTypeReply    = 2
+	chronyReqTracking     = 33
+	chronyRpyTracking     = 5
+	chronyStatusSuccess   = 0
+
+	// chronyTrackingLen is the length of the tracking reply. The request is
+	// padded to the same length, as required by chronyd.
+	chronyTrackingLen = 104
+
+	// Offsets of the fields of the tracking reply, after the 28 bytes
+	// header, the reference ID and the 20 bytes IP address of the
+	// reference.
+	chronyStratumOffset        = 52
+	chronyLeapStatusOffset     = 54
+	chronyCorrectionOffset     = 68
+	chronyFrequencyOffset      = 80
+	chronyRootDelayOffset      = 92
+	chronyRootDispersionOffset = 96
+)
+
+// chronyClient queries the tracking report of chronyd.
+type chronyClient struct {
+	endpoint string
+	timeout  time.Duration
+}
+
+func (c *chronyClient) stats(ctx context.Context) (*clockStats, error) {
+	d := net.Dialer{Timeout: c.timeout}
+	conn, err := d.DialContext(ctx, "udp", c.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+
+	sequence := rand.Uint32()
+	if _, err := conn.Write(encodeChronyTrackingRequest(sequence)); err != nil {
+		return nil, fmt.Errorf("failed to send tracking request to %s: %w", c.endpoint, err)
+	}
+	reply := make([]byte, 1024)
+	n, err := conn.Read(reply)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tracking reply from %s: %w", c.endpoint, err)
+	}
+	return decodeChronyTracking(reply[:n], sequence)
+}
+
+func encodeChronyTrackingRequest(sequence uint32) []byte {
+	req := make([]byte, chronyTrackingLen)
+	req[0] = chronyProtocolVersion
+	req[1] = chronyPktTypeRequest
+	binary.BigEndian.PutUint16(req[4:], chronyReqTracking)
+	binary.BigEndian.PutUint32(req[8:], sequence)
+	return req
+}
+
+func decodeChronyTracking(reply []byte, sequence uint32) (*clockStats, error) {
+	if len(reply) < chronyTrackingLen {
+		return nil, fmt.Errorf("tracking reply of %d bytes is too short", len(reply))
+	}
+	if reply[0] != chronyProtocolVersion || reply[1] != chronyPktTypeReply {
+		return nil, fmt.Errorf("unexpected reply version %d and type %d", reply[0], reply[1])
+	}
+	if binary.BigEndian.Uint32(reply[16:]) != sequence {
+		return nil, errors.New("unexpected reply sequence")
+	}
+	if status := binary.BigEndian.Uint16(reply[8:]); status != chronyStatusSuccess {
+		return nil, fmt.Errorf("tracking request failed with status %d", status)
+	}
+	if code := binary.BigEndian.Uint16(reply[6:]); code != chronyRpyTracking {
+		return nil, fmt.Errorf("unexpected reply code %d", code)
+	}
+
+	return &clockStats{
+		offset:         chronyFloat(reply[chronyCorrectionOffset:]),
+		frequency:      chronyFloat(reply[chronyFrequencyOffset:]),
+		stratum:        int64(binary.BigEndian.Uint16(reply[chronyStratumOffset:])),
+		rootDelay:      chronyFloat(reply[chronyRootDelayOffset:]),
+		rootDispersion: chronyFloat(reply[chronyRootDispersionOffset:]),
+		leapStatus:     int64(binary.BigEndian.Uint16(reply[chronyLeapStatusOffset:])),
+	}, nil
+}
+
+// chronyFloat decodes the floating-point format of chronyd: a 7 bits signed
+// exponent followed by a 25 bits signed coefficient.
+func chronyFloat(b []byte) float64 {
+	const expBits, coefBits = 7, 25
+	x := binary.BigEndian.Uint32(b)
+
+	exp := int32(x >> coefBits)
+	if exp >= 1<<(expBits-1) {
+		exp -= 1 << expBits
+	}
+	exp -= coefBits
+
+	coef := int32(x % (1 << coefBits))
+	if coef >= 1<<(coefBits-1) {
+		coef -= 1 << coefBits
+	}
+	return float64(coef) * math.Pow(2, float64(exp))
+}
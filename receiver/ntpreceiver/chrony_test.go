@@ -0,0 +1,117 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntpreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeChronyFloat encodes coef*2^(exp-25) in the floating-point format of
+// chronyd.
+func encodeChronyFloat(coef, exp int32) uint32 {
+	return uint32(exp&0x7f)<<25 | uint32(coef)&(1<<25-1)
+}
+
+func TestChronyFloat(t *testing.T) {
+	tests := []struct {
+		coef, exp int32
+		want      float64
+	}{
+		{coef: 1 << 23, exp: 1, want: 0.5},
+		{coef: -(1 << 23), exp: 1, want: -0.5},
+		{coef: 3, exp: 25, want: 3},
+		{coef: 1 << 23, exp: -9, want: math.Pow(2, -11)},
+		{coef: 0, exp: 0, want: 0},
+	}
+	for _, tt := range tests {
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, encodeChronyFloat(tt.coef, tt.exp))
+		assert.Equal(t, tt.want, chronyFloat(b))
+	}
+}
+
+// newTestChrony starts a fake chronyd answering tracking requests and
+// returns its endpoint.
+func newTestChrony(t *testing.T) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		req := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(req)
+			if err != nil {
+				return
+			}
+			if n != chronyTrackingLen || req[0] != chronyProtocolVersion || binary.BigEndian.Uint16(req[4:]) != chronyReqTracking {
+				continue
+			}
+			_, _ = conn.WriteTo(chronyTrackingReply(binary.BigEndian.Uint32(req[8:])), addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func chronyTrackingReply(sequence uint32) []byte {
+	reply := make([]byte, chronyTrackingLen)
+	reply[0] = chronyProtocolVersion
+	reply[1] = chronyPktTypeReply
+	binary.BigEndian.PutUint16(reply[4:], chronyReqTracking)
+	binary.BigEndian.PutUint16(reply[6:], chronyRpyTracking)
+	binary.BigEndian.PutUint32(reply[16:], sequence)
+	binary.BigEndian.PutUint16(reply[chronyStratumOffset:], 3)
+	binary.BigEndian.PutUint16(reply[chronyLeapStatusOffset:], 0)
+	binary.BigEndian.PutUint32(reply[chronyCorrectionOffset:], encodeChronyFloat(-(1<<23), -9))
+	binary.BigEndian.PutUint32(reply[chronyFrequencyOffset:], encodeChronyFloat(12, 25))
+	binary.BigEndian.PutUint32(reply[chronyRootDelayOffset:], encodeChronyFloat(1<<23, -3))
+	binary.BigEndian.PutUint32(reply[chronyRootDispersionOffset:], encodeChronyFloat(1<<23, -5))
+	return reply
+}
+
+func TestChronyStats(t *testing.T) {
+	client := &chronyClient{endpoint: newTestChrony(t), timeout: 5 * time.Second}
+	stats, err := client.stats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &clockStats{
+		offset:         -math.Pow(2, -11),
+		frequency:      12,
+		stratum:        3,
+		rootDelay:      math.Pow(2, -5),
+		rootDispersion: math.Pow(2, -7),
+		leapStatus:     0,
+	}, stats)
+}
+
+func TestDecodeChronyTrackingErrors(t *testing.T) {
+	_, err := decodeChronyTracking(make([]byte, 28), 1)
+	assert.Error(t, err)
+
+	reply := chronyTrackingReply(1)
+	_, err = decodeChronyTracking(reply, 2)
+	assert.Error(t, err)
+
+	binary.BigEndian.PutUint16(reply[8:], 2)
+	_, err = decodeChronyTracking(reply, 1)
+	assert.EqualError(t, err, "tracking request failed with status 2")
+}
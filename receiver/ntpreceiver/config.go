@@ -0,0 +1,82 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntpreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	// DaemonChrony queries chronyd with its command and monitoring protocol.
+	DaemonChrony = "chrony"
+	// DaemonNTPD queries ntpd with the NTP control messages.
+	DaemonNTPD = "ntpd"
+)
+
+// defaultEndpoints are the default endpoints of the daemons.
+var defaultEndpoints = map[string]string{
+	DaemonChrony: "localhost:323",
+	DaemonNTPD:   "localhost:123",
+}
+
+// Config defines configuration for the ntp receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Daemon is the NTP daemon to query, chrony or ntpd.
+	Daemon string `mapstructure:"daemon"`
+
+	// Endpoint is the host:port of the daemon, localhost:323 for chrony
+	// and localhost:123 for ntpd by default.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// CollectionInterval is the interval at which metrics should be collected.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+
+	// Timeout is the timeout of the queries to the daemon.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+func (c *Config) validate() error {
+	if _, ok := defaultEndpoints[c.Daemon]; !ok {
+		return fmt.Errorf("daemon must be %s or %s, got %q", DaemonChrony, DaemonNTPD, c.Daemon)
+	}
+	if c.Endpoint != "" {
+		if _, _, err := net.SplitHostPort(c.Endpoint); err != nil {
+			return fmt.Errorf("invalid endpoint %q: %w", c.Endpoint, err)
+		}
+	}
+	if c.CollectionInterval <= 0 {
+		return errors.New("collection_interval must be positive")
+	}
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
+
+// endpoint returns the configured endpoint or the default endpoint of the
+// daemon.
+func (c *Config) endpoint() string {
+	if c.Endpoint != "" {
+		return c.Endpoint
+	}
+	return defaultEndpoints[c.Daemon]
+}
@@ -0,0 +1,87 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntpreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r1 := cfg.Receivers["ntp"]
+	assert.Equal(t, r1, factory.CreateDefaultConfig())
+
+	r2 := cfg.Receivers["ntp/customname"].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "ntp/customname",
+			},
+			Daemon:             DaemonNTPD,
+			Endpoint:           "127.0.0.1:1123",
+			CollectionInterval: 30 * time.Second,
+			Timeout:            2 * time.Second,
+		})
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.validate())
+	assert.Equal(t, "localhost:323", cfg.endpoint())
+
+	cfg.Daemon = DaemonNTPD
+	assert.NoError(t, cfg.validate())
+	assert.Equal(t, "localhost:123", cfg.endpoint())
+
+	cfg.Daemon = "openntpd"
+	assert.Error(t, cfg.validate())
+
+	cfg.Daemon = DaemonChrony
+	cfg.Endpoint = "localhost"
+	assert.Error(t, cfg.validate())
+
+	cfg.Endpoint = "127.0.0.1:1323"
+	assert.NoError(t, cfg.validate())
+	assert.Equal(t, "127.0.0.1:1323", cfg.endpoint())
+
+	cfg.CollectionInterval = 0
+	assert.Error(t, cfg.validate())
+
+	cfg.CollectionInterval = time.Minute
+	cfg.Timeout = 0
+	assert.Error(t, cfg.validate())
+}
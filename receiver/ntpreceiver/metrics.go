@@ -0,0 +1,86 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntpreceiver
+
+import (
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// clockStats are the statistics of the clock of the host, as estimated by
+// the NTP daemon.
+type clockStats struct {
+	// offset is the estimated offset of the NTP time from the system clock,
+	// positive if the system clock is slow, in seconds.
+	offset float64
+	// frequency is the rate at which the system clock would be wrong without
+	// correction, positive if it is slow, in ppm.
+	frequency float64
+	stratum   int64
+	// rootDelay and rootDispersion are the total delay and dispersion to
+	// the root of the NTP hierarchy, in seconds.
+	rootDelay      float64
+	rootDispersion float64
+	// leapStatus is the leap indicator: 0 if normal, 1 or 2 if a leap
+	// second will be inserted or deleted, 3 if the clock is not
+	// synchronized.
+	leapStatus int64
+}
+
+// buildMetrics converts the clock statistics to metrics.
+func buildMetrics(stats *clockStats, ts *timestamp.Timestamp) []*metricspb.Metric {
+	return []*metricspb.Metric{
+		doubleGauge("ntp.clock.offset", "Estimated offset of the NTP time from the system clock, positive if the system clock is slow.", "s", stats.offset, ts),
+		doubleGauge("ntp.clock.frequency", "Rate at which the system clock would be wrong without correction, positive if it is slow.", "ppm", stats.frequency, ts),
+		intGauge("ntp.stratum", "Number of hops to the reference clock.", "1", stats.stratum, ts),
+		doubleGauge("ntp.root.delay", "Total network delay to the reference clock.", "s", stats.rootDelay, ts),
+		doubleGauge("ntp.root.dispersion", "Total dispersion accumulated up to the reference clock.", "s", stats.rootDispersion, ts),
+		intGauge("ntp.leap_status", "Leap indicator: 0 if normal, 1 or 2 if a leap second will be inserted or deleted, 3 if not synchronized.", "1", stats.leapStatus, ts),
+	}
+}
+
+func doubleGauge(name, description, unit string, value float64, ts *timestamp.Timestamp) *metricspb.Metric {
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        name,
+			Description: description,
+			Unit:        unit,
+			Type:        metricspb.MetricDescriptor_GAUGE_DOUBLE,
+		},
+		Timeseries: []*metricspb.TimeSeries{{
+			Points: []*metricspb.Point{{
+				Timestamp: ts,
+				Value:     &metricspb.Point_DoubleValue{DoubleValue: value},
+			}},
+		}},
+	}
+}
+
+func intGauge(name, description, unit string, value int64, ts *timestamp.Timestamp) *metricspb.Metric {
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        name,
+			Description: description,
+			Unit:        unit,
+			Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+		},
+		Timeseries: []*metricspb.TimeSeries{{
+			Points: []*metricspb.Point{{
+				Timestamp: ts,
+				Value:     &metricspb.Point_Int64Value{Int64Value: value},
+			}},
+		}},
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntpreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The read variables control message of NTP, see RFC 1305 appendix B.
+const (
+	ntpVersion         = 2
+	ntpModeControl     = 6
+	ntpOpReadVariables = 2
+
+	ntpResponseBit = 0x80
+	ntpErrorBit    = 0x40
+	ntpMoreBit     = 0x20
+	ntpOpMask      = 0x1f
+
+	ntpControlHeaderLen = 12
+	// ntpMaxFragments bounds the number of fragments of a response.
+	ntpMaxFragments = 32
+)
+
+// ntpdClient queries the system variables of ntpd.
+type ntpdClient struct {
+	endpoint string
+	timeout  time.Duration
+}
+
+func (c *ntpdClient) stats(ctx context.Context) (*clockStats, error) {
+	d := net.Dialer{Timeout: c.timeout}
+	conn, err := d.DialContext(ctx, "udp", c.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+
+	sequence := uint16(rand.Uint32())
+	if _, err := conn.Write(encodeNTPReadVariables(sequence)); err != nil {
+		return nil, fmt.Errorf("failed to send control message to %s: %w", c.endpoint, err)
+	}
+	data, err := readNTPResponse(conn, sequence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read control response from %s: %w", c.endpoint, err)
+	}
+	return ntpdStats(parseNTPVariables(data))
+}
+
+func encodeNTPReadVariables(sequence uint16) []byte {
+	req := make([]byte, ntpControlHeaderLen)
+	req[0] = ntpVersion<<3 | ntpModeControl
+	req[1] = ntpOpReadVariables
+	binary.BigEndian.PutUint16(req[2:], sequence)
+	return req
+}
+
+// readNTPResponse reads the fragments of the response to the request with
+// sequence, and returns its data.
+func readNTPResponse(conn net.Conn, sequence uint16) (string, error) {
+	var data []byte
+	buf := make([]byte, 1024)
+	for i := 0; i < ntpMaxFragments; i++ {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return "", err
+		}
+		fragment := buf[:n]
+		if len(fragment) < ntpControlHeaderLen {
+			return "", fmt.Errorf("control response of %d bytes is too short", len(fragment))
+		}
+		if fragment[0]&0x7 != ntpModeControl || fragment[1]&ntpOpMask != ntpOpReadVariables || fragment[1]&ntpResponseBit == 0 ||
+			binary.BigEndian.Uint16(fragment[2:]) != sequence {
+			// Not a response to the request.
+			continue
+		}
+		if fragment[1]&ntpErrorBit != 0 {
+			return "", fmt.Errorf("control request failed with status %d", binary.BigEndian.Uint16(fragment[4:])>>8)
+		}
+
+		offset := int(binary.BigEndian.Uint16(fragment[8:]))
+		count := int(binary.BigEndian.Uint16(fragment[10:]))
+		if offset != len(data) {
+			return "", errors.New("control response fragments are missing or out of order")
+		}
+		if ntpControlHeaderLen+count > len(fragment) {
+			return "", errors.New("control response fragment is truncated")
+		}
+		data = append(data, fragment[ntpControlHeaderLen:ntpControlHeaderLen+count]...)
+		if fragment[1]&ntpMoreBit == 0 {
+			return string(data), nil
+		}
+	}
+	return "", errors.New("control response has too many fragments")
+}
+
+// parseNTPVariables parses variables in the name=value, name="value" format.
+func parseNTPVariables(data string) map[string]string {
+	vars := make(map[string]string)
+	for len(data) > 0 {
+		var item string
+		item, data = nextNTPVariable(data)
+		item = strings.TrimSpace(item)
+		i := strings.IndexByte(item, '=')
+		if i <= 0 {
+			continue
+		}
+		vars[item[:i]] = strings.Trim(item[i+1:], `"`)
+	}
+	return vars
+}
+
+// nextNTPVariable splits data at the first comma outside of quotes.
+func nextNTPVariable(data string) (string, string) {
+	quoted := false
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				return data[:i], data[i+1:]
+			}
+		}
+	}
+	return data, ""
+}
+
+// ntpdStats converts the system variables of ntpd to clock statistics. The
+// offset, root delay and root dispersion of ntpd are in milliseconds.
+func ntpdStats(vars map[string]string) (*clockStats, error) {
+	var stats clockStats
+	var err error
+	parseFloat := func(name string, scale float64) float64 {
+		v, parseErr := strconv.ParseFloat(vars[name], 64)
+		if parseErr != nil && err == nil {
+			err = fmt.Errorf("invalid %s variable %q", name, vars[name])
+		}
+		return v * scale
+	}
+	stats.offset = parseFloat("offset", 1e-3)
+	stats.frequency = parseFloat("frequency", 1)
+	stats.rootDelay = parseFloat("rootdelay", 1e-3)
+	stats.rootDispersion = parseFloat("rootdisp", 1e-3)
+	stats.stratum = int64(parseFloat("stratum", 1))
+	if err != nil {
+		return nil, err
+	}
+
+	// ntpq shows the leap indicator as 2 bits, e.g. 11, ntpd reports it as
+	// an integer.
+	leap := vars["leap"]
+	base := 10
+	if len(leap) == 2 {
+		base = 2
+	}
+	if stats.leapStatus, err = strconv.ParseInt(leap, base, 64); err != nil {
+		return nil, fmt.Errorf("invalid leap variable %q", leap)
+	}
+	return &stats, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntpreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNTPVariables = `version="ntpd 4.2.8p15@1.3728-o", processor="x86_64", system="Linux/5.4.0", leap=0, stratum=2, precision=-24, rootdelay=12.500, rootdisp=30.250, refid=192.0.2.1, reftime=e3b8c3f2.1a2b3c4d  Sat, Jan 16 2021 10:00:00.102, clock=e3b8c4a1.5e6f7a8b  Sat, Jan 16 2021 10:02:57.368, peer=12345, tc=10, mintc=3, offset=-0.750, frequency=-15.125, sys_jitter=0.123, clk_jitter=0.045, clk_wander=0.002`
+
+// newTestNTPD starts a fake ntpd answering read variables requests with
+// testNTPVariables, split in two fragments, and returns its endpoint.
+func newTestNTPD(t *testing.T) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		req := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(req)
+			if err != nil {
+				return
+			}
+			if n < ntpControlHeaderLen || req[0]&0x7 != ntpModeControl || req[1] != ntpOpReadVariables {
+				continue
+			}
+			sequence := binary.BigEndian.Uint16(req[2:])
+			half := len(testNTPVariables) / 2
+			_, _ = conn.WriteTo(ntpFragment(sequence, 0, testNTPVariables[:half], true), addr)
+			_, _ = conn.WriteTo(ntpFragment(sequence, half, testNTPVariables[half:], false), addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func ntpFragment(sequence uint16, offset int, data string, more bool) []byte {
+	fragment := make([]byte, ntpControlHeaderLen+len(data))
+	fragment[0] = ntpVersion<<3 | ntpModeControl
+	fragment[1] = ntpResponseBit | ntpOpReadVariables
+	if more {
+		fragment[1] |= ntpMoreBit
+	}
+	binary.BigEndian.PutUint16(fragment[2:], sequence)
+	binary.BigEndian.PutUint16(fragment[8:], uint16(offset))
+	binary.BigEndian.PutUint16(fragment[10:], uint16(len(data)))
+	copy(fragment[ntpControlHeaderLen:], data)
+	return fragment
+}
+
+func TestNTPDStats(t *testing.T) {
+	client := &ntpdClient{endpoint: newTestNTPD(t), timeout: 5 * time.Second}
+	stats, err := client.stats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &clockStats{
+		offset:         -0.00075,
+		frequency:      -15.125,
+		stratum:        2,
+		rootDelay:      0.0125,
+		rootDispersion: 0.03025,
+		leapStatus:     0,
+	}, stats)
+}
+
+func TestParseNTPVariables(t *testing.T) {
+	vars := parseNTPVariables("version=\"ntpd 4.2.8, built\",\r\nleap=11, stratum=16")
+	assert.Equal(t, map[string]string{"version": "ntpd 4.2.8, built", "leap": "11", "stratum": "16"}, vars)
+}
+
+func TestNTPDStatsErrors(t *testing.T) {
+	vars := map[string]string{
+		"offset":    "0.5",
+		"frequency": "1",
+		"rootdelay": "1",
+		"rootdisp":  "1",
+		"stratum":   "16",
+		"leap":      "11",
+	}
+	stats, err := ntpdStats(vars)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stats.leapStatus)
+
+	vars["leap"] = "x"
+	_, err = ntpdStats(vars)
+	assert.Error(t, err)
+
+	delete(vars, "offset")
+	_, err = ntpdStats(vars)
+	assert.EqualError(t, err, `invalid offset variable ""`)
+}
@@ -0,0 +1,121 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntpreceiver
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "udp"
+
+// clockSource returns the clock statistics estimated by an NTP daemon.
+type clockSource interface {
+	stats(ctx context.Context) (*clockStats, error)
+}
+
+var _ component.MetricsReceiver = (*ntpReceiver)(nil)
+
+// ntpReceiver implements the component.MetricsReceiver for NTP daemons.
+type ntpReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	source       clockSource
+	cancel       context.CancelFunc
+}
+
+// newNTPReceiver creates the ntp receiver with the given parameters.
+func newNTPReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	var source clockSource
+	switch config.Daemon {
+	case DaemonChrony:
+		source = &chronyClient{endpoint: config.endpoint(), timeout: config.Timeout}
+	case DaemonNTPD:
+		source = &ntpdClient{endpoint: config.endpoint(), timeout: config.Timeout}
+	}
+	r := &ntpReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		source:       source,
+	}
+	return r, nil
+}
+
+// Start begins querying the NTP daemon.
+func (nr *ntpReceiver) Start(ctx context.Context, host component.Host) error {
+	ctx, nr.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, nr.config.Name()))
+	go func() {
+		ticker := time.NewTicker(nr.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := nr.collect(ctx); err != nil {
+					nr.logger.Warn("Failed to collect ntp metrics", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the ntp receiver.
+func (nr *ntpReceiver) Shutdown(context.Context) error {
+	if nr.cancel != nil {
+		nr.cancel()
+	}
+	return nil
+}
+
+// collect sends the metrics built from the current clock statistics.
+func (nr *ntpReceiver) collect(ctx context.Context) error {
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+
+	stats, err := nr.source.stats(ctx)
+	if err != nil {
+		obsreport.EndMetricsReceiveOp(ctx, typeStr, 0, 0, err)
+		return err
+	}
+
+	md := consumerdata.MetricsData{Metrics: buildMetrics(stats, ptypes.TimestampNow())}
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(md)
+	err = nr.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md}))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+	return err
+}
@@ -0,0 +1,84 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntpreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestReceiver(t *testing.T) {
+	for _, daemon := range []string{DaemonChrony, DaemonNTPD} {
+		t.Run(daemon, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Daemon = daemon
+			if daemon == DaemonChrony {
+				cfg.Endpoint = newTestChrony(t)
+			} else {
+				cfg.Endpoint = newTestNTPD(t)
+			}
+			cfg.CollectionInterval = 100 * time.Millisecond
+
+			sink := new(exportertest.SinkMetricsExporter)
+			metricsReceiver, err := newNTPReceiver(zap.NewNop(), cfg, sink)
+			require.NoError(t, err)
+			require.NotNil(t, metricsReceiver)
+
+			ctx := context.Background()
+			require.NoError(t, metricsReceiver.Start(ctx, componenttest.NewNopHost()))
+
+			require.Eventually(t, func() bool {
+				return sink.MetricsCount() > 0
+			}, 5*time.Second, 10*time.Millisecond)
+			require.NoError(t, metricsReceiver.Shutdown(ctx))
+
+			mds := pdatautil.MetricsToMetricsData(sink.AllMetrics()[0])
+			require.Len(t, mds, 1)
+			names := make([]string, 0, len(mds[0].Metrics))
+			for _, m := range mds[0].Metrics {
+				names = append(names, m.MetricDescriptor.Name)
+			}
+			assert.Equal(t, []string{
+				"ntp.clock.offset",
+				"ntp.clock.frequency",
+				"ntp.stratum",
+				"ntp.root.delay",
+				"ntp.root.dispersion",
+				"ntp.leap_status",
+			}, names)
+		})
+	}
+}
+
+func TestCollectError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "127.0.0.1:1"
+	cfg.Timeout = 100 * time.Millisecond
+
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newNTPReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	assert.Error(t, metricsReceiver.(*ntpReceiver).collect(context.Background()))
+	assert.Equal(t, 0, sink.MetricsCount())
+}
@@ -0,0 +1,137 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmanreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// container is an entry of the libpod container list.
+type container struct {
+	ID    string   `json:"Id"`
+	Names []string `json:"Names"`
+	Image string   `json:"Image"`
+	State string   `json:"State"`
+}
+
+// containerStats is the resource usage of a container as reported by the
+// libpod stats endpoint, the cpu times are in nanoseconds and the sizes in
+// bytes.
+type containerStats struct {
+	ContainerID   string  `json:"ContainerID"`
+	Name          string  `json:"Name"`
+	CPU           float64 `json:"CPU"`
+	CPUNano       uint64  `json:"CPUNano"`
+	CPUSystemNano uint64  `json:"CPUSystemNano"`
+	MemUsage      uint64  `json:"MemUsage"`
+	MemLimit      uint64  `json:"MemLimit"`
+	MemPerc       float64 `json:"MemPerc"`
+	NetInput      uint64  `json:"NetInput"`
+	NetOutput     uint64  `json:"NetOutput"`
+	BlockInput    uint64  `json:"BlockInput"`
+	BlockOutput   uint64  `json:"BlockOutput"`
+	PIDs          uint64  `json:"PIDs"`
+}
+
+type containerStatsReport struct {
+	Stats []containerStats `json:"Stats"`
+}
+
+// podmanClient queries the libpod REST API of Podman.
+type podmanClient struct {
+	baseURL    string
+	apiVersion string
+	httpClient *http.Client
+}
+
+func newPodmanClient(cfg *Config) (*podmanClient, error) {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{}
+	baseURL := "http://" + u.Host
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+		// the host is ignored when dialing the socket.
+		baseURL = "http://podman"
+	case "tcp":
+	default:
+		return nil, fmt.Errorf("unsupported endpoint scheme %q", u.Scheme)
+	}
+
+	return &podmanClient{
+		baseURL:    baseURL,
+		apiVersion: cfg.APIVersion,
+		httpClient: &http.Client{Transport: transport, Timeout: cfg.Timeout},
+	}, nil
+}
+
+// containers returns the running containers.
+func (c *podmanClient) containers(ctx context.Context) ([]container, error) {
+	var containers []container
+	if err := c.get(ctx, "/containers/json", nil, &containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+// stats returns a single sample of the resource usage of the running
+// containers.
+func (c *podmanClient) stats(ctx context.Context) ([]containerStats, error) {
+	var report containerStatsReport
+	if err := c.get(ctx, "/containers/stats", url.Values{"stream": {"false"}}, &report); err != nil {
+		return nil, err
+	}
+	return report.Stats, nil
+}
+
+func (c *podmanClient) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	u := c.baseURL + "/v" + c.apiVersion + "/libpod" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("podman request %s failed with status %d: %s", path, resp.StatusCode, body)
+	}
+	return json.Unmarshal(body, v)
+}
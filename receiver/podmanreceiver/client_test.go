@@ -0,0 +1,135 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmanreceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	containersResponse = `[{"Id":"abc123","Names":["web"],"Image":"docker.io/library/nginx:1.19","State":"running"}]`
+	statsResponse      = `{"Error":null,"Stats":[{"ContainerID":"abc123","Name":"web","CPU":2.5,"CPUNano":1500000000,` +
+		`"CPUSystemNano":500000000,"MemUsage":1048576,"MemLimit":4194304,"MemPerc":25,"NetInput":100,"NetOutput":200,` +
+		`"BlockInput":300,"BlockOutput":400,"PIDs":3}]}`
+)
+
+func podmanHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3.3.1/libpod/containers/json":
+			w.Write([]byte(containersResponse))
+		case "/v3.3.1/libpod/containers/stats":
+			assert.Equal(t, "false", r.URL.Query().Get("stream"))
+			w.Write([]byte(statsResponse))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"cause":"not found"}`))
+		}
+	})
+}
+
+// newUnixServer serves handler on a unix socket and returns its endpoint.
+func newUnixServer(t *testing.T, handler http.Handler) (string, func()) {
+	dir, err := ioutil.TempDir("", "podman")
+	require.NoError(t, err)
+	socket := filepath.Join(dir, "podman.sock")
+
+	l, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = l
+	server.Start()
+
+	return "unix://" + socket, func() {
+		server.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func testClientConfig(endpoint string) *Config {
+	return &Config{
+		Endpoint:           endpoint,
+		CollectionInterval: time.Second,
+		Timeout:            time.Second,
+		APIVersion:         "3.3.1",
+	}
+}
+
+func TestClientUnixSocket(t *testing.T) {
+	endpoint, closeServer := newUnixServer(t, podmanHandler(t))
+	defer closeServer()
+
+	client, err := newPodmanClient(testClientConfig(endpoint))
+	require.NoError(t, err)
+
+	containers, err := client.containers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []container{{
+		ID:    "abc123",
+		Names: []string{"web"},
+		Image: "docker.io/library/nginx:1.19",
+		State: "running",
+	}}, containers)
+
+	stats, err := client.stats(context.Background())
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "abc123", stats[0].ContainerID)
+	assert.Equal(t, uint64(1500000000), stats[0].CPUNano)
+	assert.Equal(t, uint64(4194304), stats[0].MemLimit)
+	assert.Equal(t, uint64(3), stats[0].PIDs)
+}
+
+func TestClientTCP(t *testing.T) {
+	server := httptest.NewServer(podmanHandler(t))
+	defer server.Close()
+
+	client, err := newPodmanClient(testClientConfig("tcp://" + server.Listener.Addr().String()))
+	require.NoError(t, err)
+
+	containers, err := client.containers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, containers, 1)
+}
+
+func TestClientErrorStatus(t *testing.T) {
+	server := httptest.NewServer(podmanHandler(t))
+	defer server.Close()
+
+	cfg := testClientConfig("tcp://" + server.Listener.Addr().String())
+	cfg.APIVersion = "1.0.0"
+	client, err := newPodmanClient(cfg)
+	require.NoError(t, err)
+
+	_, err = client.stats(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
+
+func TestNewClientInvalidScheme(t *testing.T) {
+	_, err := newPodmanClient(testClientConfig("http://localhost:8080"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmanreceiver
+
+import (
+	"strings"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	containerResourceType = "container"
+
+	// containerRuntimeLabel identifies the runtime of the container.
+	containerRuntimeLabel = "container.runtime"
+	containerRuntime      = "podman"
+)
+
+// containerMetricsData converts the stats of a container to metrics of the
+// container resource.
+func containerMetricsData(c *container, stats *containerStats, ts *timestamp.Timestamp) consumerdata.MetricsData {
+	return consumerdata.MetricsData{
+		Resource: containerResource(c, stats),
+		Metrics: []*metricspb.Metric{
+			cumulativeInt("container.cpu.usage.total", "ns", stats.CPUNano, ts),
+			cumulativeInt("container.cpu.usage.system", "ns", stats.CPUSystemNano, ts),
+			gaugeDouble("container.cpu.percent", "1", stats.CPU, ts),
+			gaugeInt("container.memory.usage.total", "By", stats.MemUsage, ts),
+			gaugeInt("container.memory.usage.limit", "By", stats.MemLimit, ts),
+			gaugeDouble("container.memory.percent", "1", stats.MemPerc, ts),
+			cumulativeInt("container.network.io.usage.rx_bytes", "By", stats.NetInput, ts),
+			cumulativeInt("container.network.io.usage.tx_bytes", "By", stats.NetOutput, ts),
+			cumulativeInt("container.blockio.io_service_bytes_recursive.read", "By", stats.BlockInput, ts),
+			cumulativeInt("container.blockio.io_service_bytes_recursive.write", "By", stats.BlockOutput, ts),
+			gaugeInt("container.pids.count", "1", stats.PIDs, ts),
+		},
+	}
+}
+
+func containerResource(c *container, stats *containerStats) *resourcepb.Resource {
+	labels := map[string]string{
+		conventions.AttributeContainerID: stats.ContainerID,
+		containerRuntimeLabel:            containerRuntime,
+	}
+
+	name := stats.Name
+	if c != nil && len(c.Names) > 0 {
+		name = c.Names[0]
+	}
+	if name != "" {
+		labels[conventions.AttributeContainerName] = name
+	}
+
+	if c != nil && c.Image != "" {
+		image, tag := splitImage(c.Image)
+		labels[conventions.AttributeContainerImage] = image
+		if tag != "" {
+			labels[conventions.AttributeContainerTag] = tag
+		}
+	}
+
+	return &resourcepb.Resource{
+		Type:   containerResourceType,
+		Labels: labels,
+	}
+}
+
+// splitImage splits a reference like docker.io/library/nginx:1.19 in the
+// image name and its tag, the port of the registry is not a tag.
+func splitImage(image string) (string, string) {
+	idx := strings.LastIndexByte(image, ':')
+	if idx < 0 || strings.ContainsRune(image[idx:], '/') {
+		return image, ""
+	}
+	return image[:idx], image[idx+1:]
+}
+
+func gaugeInt(name, unit string, value uint64, ts *timestamp.Timestamp) *metricspb.Metric {
+	return newMetric(name, unit, metricspb.MetricDescriptor_GAUGE_INT64,
+		&metricspb.Point{Timestamp: ts, Value: &metricspb.Point_Int64Value{Int64Value: int64(value)}})
+}
+
+func cumulativeInt(name, unit string, value uint64, ts *timestamp.Timestamp) *metricspb.Metric {
+	return newMetric(name, unit, metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		&metricspb.Point{Timestamp: ts, Value: &metricspb.Point_Int64Value{Int64Value: int64(value)}})
+}
+
+func gaugeDouble(name, unit string, value float64, ts *timestamp.Timestamp) *metricspb.Metric {
+	return newMetric(name, unit, metricspb.MetricDescriptor_GAUGE_DOUBLE,
+		&metricspb.Point{Timestamp: ts, Value: &metricspb.Point_DoubleValue{DoubleValue: value}})
+}
+
+func newMetric(name, unit string, metricType metricspb.MetricDescriptor_Type, point *metricspb.Point) *metricspb.Metric {
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name: name,
+			Unit: unit,
+			Type: metricType,
+		},
+		Timeseries: []*metricspb.TimeSeries{{
+			Points: []*metricspb.Point{point},
+		}},
+	}
+}
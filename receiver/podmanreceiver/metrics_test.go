@@ -0,0 +1,81 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmanreceiver
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerMetricsData(t *testing.T) {
+	c := &container{ID: "abc123", Names: []string{"web"}, Image: "localhost:5000/nginx:1.19"}
+	stats := &containerStats{ContainerID: "abc123", Name: "web", CPU: 2.5, CPUNano: 1500, MemUsage: 10, MemLimit: 40, MemPerc: 25, PIDs: 3}
+	ts := ptypes.TimestampNow()
+
+	md := containerMetricsData(c, stats, ts)
+	assert.Equal(t, containerResourceType, md.Resource.Type)
+	assert.Equal(t, map[string]string{
+		"container.id":         "abc123",
+		"container.name":       "web",
+		"container.image.name": "localhost:5000/nginx",
+		"container.image.tag":  "1.19",
+		"container.runtime":    "podman",
+	}, md.Resource.Labels)
+
+	values := map[string]interface{}{}
+	for _, m := range md.Metrics {
+		require.Len(t, m.Timeseries, 1)
+		point := m.Timeseries[0].Points[0]
+		assert.Equal(t, ts, point.Timestamp)
+		if m.MetricDescriptor.Unit == "By" || m.MetricDescriptor.Unit == "ns" {
+			values[m.MetricDescriptor.Name] = point.GetInt64Value()
+		}
+	}
+	assert.Equal(t, int64(1500), values["container.cpu.usage.total"])
+	assert.Equal(t, int64(10), values["container.memory.usage.total"])
+	assert.Equal(t, int64(40), values["container.memory.usage.limit"])
+}
+
+func TestContainerResourceWithoutListEntry(t *testing.T) {
+	resource := containerResource(nil, &containerStats{ContainerID: "abc123", Name: "web"})
+	assert.Equal(t, map[string]string{
+		"container.id":      "abc123",
+		"container.name":    "web",
+		"container.runtime": "podman",
+	}, resource.Labels)
+}
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image string
+		name  string
+		tag   string
+	}{
+		{"nginx", "nginx", ""},
+		{"docker.io/library/nginx:1.19", "docker.io/library/nginx", "1.19"},
+		{"localhost:5000/nginx", "localhost:5000/nginx", ""},
+		{"localhost:5000/nginx:latest", "localhost:5000/nginx", "latest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			name, tag := splitImage(tt.image)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.tag, tag)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmanreceiver
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "http"
+
+var _ component.MetricsReceiver = (*podmanReceiver)(nil)
+
+// podmanReceiver implements the component.MetricsReceiver for Podman.
+type podmanReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	client       *podmanClient
+	cancel       context.CancelFunc
+}
+
+// newPodmanReceiver creates the Podman receiver with the given parameters.
+func newPodmanReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newPodmanClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &podmanReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		client:       client,
+	}
+	return r, nil
+}
+
+// Start begins collecting the container stats from the Podman API.
+func (pr *podmanReceiver) Start(ctx context.Context, host component.Host) error {
+	ctx, pr.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, pr.config.Name()))
+	go func() {
+		ticker := time.NewTicker(pr.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := pr.collectStats(ctx); err != nil {
+					pr.logger.Warn("Failed to collect podman container stats", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the podman receiver.
+func (pr *podmanReceiver) Shutdown(context.Context) error {
+	if pr.cancel != nil {
+		pr.cancel()
+	}
+	return nil
+}
+
+// collectStats sends the resource usage of the running containers.
+func (pr *podmanReceiver) collectStats(ctx context.Context) error {
+	containers, err := pr.client.containers(ctx)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+	stats, err := pr.client.stats(ctx)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*container, len(containers))
+	for i := range containers {
+		byID[containers[i].ID] = &containers[i]
+	}
+
+	ts := ptypes.TimestampNow()
+	mds := make([]consumerdata.MetricsData, 0, len(stats))
+	numTimeSeries, numPoints := 0, 0
+	for i := range stats {
+		md := containerMetricsData(byID[stats[i].ContainerID], &stats[i], ts)
+		series, points := obsreport.CountMetricPoints(md)
+		numTimeSeries += series
+		numPoints += points
+		mds = append(mds, md)
+	}
+	if len(mds) == 0 {
+		return nil
+	}
+
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+	err = pr.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData(mds))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+	return err
+}
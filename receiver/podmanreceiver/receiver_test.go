@@ -0,0 +1,89 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmanreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	metricsReceiver, err := newPodmanReceiver(
+		zap.NewNop(),
+		cfg,
+		exportertest.NewNopMetricsExporter(),
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+
+	r := metricsReceiver.(*podmanReceiver)
+	ctx := context.Background()
+
+	err = r.Start(ctx, componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	err = r.Shutdown(ctx)
+	require.NoError(t, err)
+}
+
+func TestCollectStats(t *testing.T) {
+	endpoint, closeServer := newUnixServer(t, podmanHandler(t))
+	defer closeServer()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newPodmanReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*podmanReceiver)
+	require.NoError(t, r.collectStats(context.Background()))
+
+	metrics := sink.AllMetrics()
+	require.Len(t, metrics, 1)
+	mds := pdatautil.MetricsToMetricsData(metrics[0])
+	require.Len(t, mds, 1)
+	assert.Equal(t, "abc123", mds[0].Resource.Labels["container.id"])
+	assert.Equal(t, "docker.io/library/nginx", mds[0].Resource.Labels["container.image.name"])
+	assert.Len(t, mds[0].Metrics, 11)
+}
+
+func TestCollectStatsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "tcp://" + server.Listener.Addr().String()
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newPodmanReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*podmanReceiver)
+	require.Error(t, r.collectStats(context.Background()))
+	assert.Len(t, sink.AllMetrics(), 0)
+}
@@ -0,0 +1,76 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	otelconfig "go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// discoveryConfigAnnotation is the pod annotation containing a map of receiver
+// names (ie <receiver type>/<id>) to the config used to create them.
+const discoveryConfigAnnotation = "io.opentelemetry.discovery/config"
+
+// receiverConfigsFromAnnotations parses the receivers embedded in the discovery
+// annotation. Returns nil if the annotation is not set. Receiver types not in
+// allowed are rejected.
+func receiverConfigsFromAnnotations(annotations map[string]string, allowed []configmodels.Type) ([]receiverConfig, error) {
+	snippet, ok := annotations[discoveryConfigAnnotation]
+	if !ok || strings.TrimSpace(snippet) == "" {
+		return nil, nil
+	}
+
+	v := otelconfig.NewViper()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(snippet)); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %q: %v", discoveryConfigAnnotation, err)
+	}
+
+	var names []string
+	for name := range v.AllSettings() {
+		names = append(names, name)
+	}
+	// Sort so receivers are always started in the same order.
+	sort.Strings(names)
+
+	var receivers []receiverConfig
+	for _, name := range names {
+		template, err := newReceiverTemplate(name, viperSub(v, name).AllSettings())
+		if err != nil {
+			return nil, err
+		}
+		if !isReceiverAllowed(template.typeStr, allowed) {
+			return nil, fmt.Errorf("receiver %q is not in allowed_receivers", name)
+		}
+		receivers = append(receivers, template.receiverConfig)
+	}
+
+	return receivers, nil
+}
+
+// isReceiverAllowed returns whether typeStr may be created from annotations.
+// No receiver type is allowed if allowed is empty.
+func isReceiverAllowed(typeStr configmodels.Type, allowed []configmodels.Type) bool {
+	for _, a := range allowed {
+		if a == typeStr {
+			return true
+		}
+	}
+	return false
+}
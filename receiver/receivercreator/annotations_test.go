@@ -0,0 +1,99 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+func TestReceiverConfigsFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		allowed     []configmodels.Type
+		want        []receiverConfig
+		wantErr     bool
+	}{
+		{
+			name:        "no annotation",
+			annotations: map[string]string{"scrape": "true"},
+			want:        nil,
+		},
+		{
+			name: "multiple receivers",
+			annotations: map[string]string{
+				discoveryConfigAnnotation: "redis/1:\n  password: secret\nprometheus_simple:\n  metrics_path: /stats\n",
+			},
+			allowed: []configmodels.Type{"redis", "prometheus_simple"},
+			want: []receiverConfig{
+				{fullName: "prometheus_simple", typeStr: "prometheus_simple", config: userConfigMap{"metrics_path": "/stats"}},
+				{fullName: "redis/1", typeStr: "redis", config: userConfigMap{"password": "secret"}},
+			},
+		},
+		{
+			name: "allowed receiver",
+			annotations: map[string]string{
+				discoveryConfigAnnotation: "redis:\n  password: secret\n",
+			},
+			allowed: []configmodels.Type{"redis"},
+			want: []receiverConfig{
+				{fullName: "redis", typeStr: "redis", config: userConfigMap{"password": "secret"}},
+			},
+		},
+		{
+			name: "disallowed receiver",
+			annotations: map[string]string{
+				discoveryConfigAnnotation: "prometheus_exec:\n  exec: rm -rf /\n",
+			},
+			allowed: []configmodels.Type{"redis"},
+			wantErr: true,
+		},
+		{
+			name: "empty allowed receivers",
+			annotations: map[string]string{
+				discoveryConfigAnnotation: "prometheus_exec:\n  exec: rm -rf /\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid yaml",
+			annotations: map[string]string{
+				discoveryConfigAnnotation: "redis: [",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := receiverConfigsFromAnnotations(tt.annotations, tt.allowed)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsReceiverAllowed(t *testing.T) {
+	assert.False(t, isReceiverAllowed("prometheus_exec", nil))
+	assert.False(t, isReceiverAllowed("prometheus_exec", []configmodels.Type{"redis"}))
+	assert.True(t, isReceiverAllowed("redis", []configmodels.Type{"redis"}))
+}
@@ -15,6 +15,7 @@
 package receivercreator
 
 import (
+	"errors"
 	"reflect"
 
 	"github.com/spf13/cast"
@@ -79,6 +80,28 @@ type Config struct {
 	receiverTemplates             map[string]receiverTemplate
 	// WatchObservers are the extensions to listen to endpoints from.
 	WatchObservers []configmodels.Type `mapstructure:"watch_observers"`
+	// Annotations configures creating receivers from config embedded in the
+	// annotations of discovered pods.
+	Annotations AnnotationsConfig `mapstructure:"annotations"`
+}
+
+// AnnotationsConfig defines configuration for annotation-driven receiver creation.
+type AnnotationsConfig struct {
+	// Enabled turns on creating receivers from the io.opentelemetry.discovery/config
+	// annotation of discovered pods.
+	Enabled bool `mapstructure:"enabled"`
+	// AllowedReceivers lists the receiver types that can be created from annotations.
+	// It is required when Enabled is set, as receivers such as prometheus_exec
+	// run commands on the collector host.
+	AllowedReceivers []configmodels.Type `mapstructure:"allowed_receivers"`
+}
+
+// validate checks that the receivers created from annotations are restricted.
+func (cfg *AnnotationsConfig) validate() error {
+	if cfg.Enabled && len(cfg.AllowedReceivers) == 0 {
+		return errors.New("annotations.allowed_receivers must not be empty when annotations are enabled")
+	}
+	return nil
 }
 
 // Copied from the Viper but changed to use the same delimiter.
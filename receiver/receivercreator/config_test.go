@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	otelconfig "go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
 )
@@ -86,4 +87,17 @@ func TestLoadConfig(t *testing.T) {
 		endpointConfigKey: "localhost:12345",
 	}, r1.receiverTemplates["examplereceiver/1"].config)
 	assert.Equal(t, []configmodels.Type{"mock_observer"}, r1.WatchObservers)
+	assert.Equal(t, AnnotationsConfig{
+		Enabled:          true,
+		AllowedReceivers: []configmodels.Type{"examplereceiver"},
+	}, r1.Annotations)
+}
+
+func TestAnnotationsRequireAllowedReceivers(t *testing.T) {
+	v := otelconfig.NewViper()
+	v.Set("annotations", map[string]interface{}{"enabled": true})
+
+	cfg := createDefaultConfig().(*Config)
+	err := customUnmarshaler(v, cfg)
+	assert.EqualError(t, err, "annotations.allowed_receivers must not be empty when annotations are enabled")
 }
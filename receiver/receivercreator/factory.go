@@ -17,12 +17,14 @@ package receivercreator
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
 )
 
 // This file implements factory for receiver_creator. A receiver_creator can create other receivers at runtime.
@@ -31,13 +33,23 @@ const (
 	typeStr = "receiver_creator"
 )
 
+// receivers holds the receiver_creator instances created per configuration, so
+// that the pipelines of different types using the same configuration share a
+// single instance, as required by the receivers builder.
+var (
+	receiversLock sync.Mutex
+	receivers     = map[*Config]*receiverCreator{}
+)
+
 // NewFactory creates a factory for receiver creator.
 func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
 		receiverhelper.WithCustomUnmarshaler(customUnmarshaler),
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithTraces(createTraceReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
 }
 
 func createDefaultConfig() configmodels.Receiver {
@@ -56,7 +68,54 @@ func createMetricsReceiver(
 	cfg configmodels.Receiver,
 	consumer consumer.MetricsConsumer,
 ) (component.MetricsReceiver, error) {
-	return newReceiverCreator(params.Logger, consumer, cfg.(*Config))
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+	r := getOrCreateReceiverCreator(params.Logger, cfg.(*Config))
+	r.nextMetricsConsumer = consumer
+	return r, nil
+}
+
+func createTraceReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.TraceConsumer,
+) (component.TraceReceiver, error) {
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+	r := getOrCreateReceiverCreator(params.Logger, cfg.(*Config))
+	r.nextTracesConsumer = consumer
+	return r, nil
+}
+
+func createLogsReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+	r := getOrCreateReceiverCreator(params.Logger, cfg.(*Config))
+	r.nextLogsConsumer = consumer
+	return r, nil
+}
+
+// getOrCreateReceiverCreator returns the receiver_creator of the configuration,
+// creating it on first use.
+func getOrCreateReceiverCreator(logger *zap.Logger, cfg *Config) *receiverCreator {
+	receiversLock.Lock()
+	defer receiversLock.Unlock()
+
+	r := receivers[cfg]
+	if r == nil {
+		r = newReceiverCreator(logger, cfg)
+		receivers[cfg] = r
+	}
+	return r
 }
 
 func customUnmarshaler(sourceViperSection *viper.Viper, intoCfg interface{}) error {
@@ -69,6 +128,9 @@ func customUnmarshaler(sourceViperSection *viper.Viper, intoCfg interface{}) err
 	if err := sourceViperSection.Unmarshal(&c); err != nil {
 		return err
 	}
+	if err := c.Annotations.validate(); err != nil {
+		return err
+	}
 
 	receiversCfg := viperSub(sourceViperSection, receiversConfigKey)
 
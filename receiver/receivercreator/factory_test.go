@@ -19,8 +19,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.uber.org/zap"
 )
 
@@ -37,8 +38,38 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 
-	mReceiver, err := factory.CreateTraceReceiver(context.Background(), params, cfg, nil)
-	assert.Error(t, err)
-	assert.Equal(t, err, configerror.ErrDataTypeIsNotSupported)
-	assert.Nil(t, mReceiver)
+	tReceiver, err = factory.CreateMetricsReceiver(context.Background(), params, cfg, nil)
+	assert.Equal(t, errNilNextConsumer, err)
+	assert.Nil(t, tReceiver)
+
+	traceReceiver, err := factory.CreateTraceReceiver(context.Background(), params, cfg, &exportertest.SinkTraceExporter{})
+	assert.NoError(t, err, "receiver creation failed")
+	assert.NotNil(t, traceReceiver, "receiver creation failed")
+
+	logsReceiver, err := factory.(component.LogsReceiverFactory).CreateLogsReceiver(context.Background(), params, cfg, &exportertest.SinkLogsExporter{})
+	assert.NoError(t, err, "receiver creation failed")
+	assert.NotNil(t, logsReceiver, "receiver creation failed")
+}
+
+func TestCreateReceiverSharedAcrossSignals(t *testing.T) {
+	factory := NewFactory()
+	cfg := createDefaultConfig()
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	metricsConsumer := &mockMetricsConsumer{}
+	mReceiver, err := factory.CreateMetricsReceiver(context.Background(), params, cfg, metricsConsumer)
+	require.NoError(t, err)
+	traceConsumer := &exportertest.SinkTraceExporter{}
+	tReceiver, err := factory.CreateTraceReceiver(context.Background(), params, cfg, traceConsumer)
+	require.NoError(t, err)
+
+	assert.Same(t, mReceiver, tReceiver)
+	r := mReceiver.(*receiverCreator)
+	assert.Equal(t, metricsConsumer, r.nextMetricsConsumer)
+	assert.Equal(t, traceConsumer, r.nextTracesConsumer)
+
+	// A different configuration gets its own instance.
+	other, err := factory.CreateMetricsReceiver(context.Background(), params, createDefaultConfig(), metricsConsumer)
+	require.NoError(t, err)
+	assert.NotSame(t, mReceiver, other)
 }
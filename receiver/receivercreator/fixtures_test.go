@@ -35,6 +35,17 @@ var podEndpoint = observer.Endpoint{
 	Details: pod,
 }
 
+var annotatedPodEndpoint = observer.Endpoint{
+	ID:     "pod-2",
+	Target: "localhost",
+	Details: observer.Pod{
+		Name: "pod-2",
+		Annotations: map[string]string{
+			discoveryConfigAnnotation: "name/1:\n  endpoint: \"`endpoint`:6379\"\n",
+		},
+	},
+}
+
 var portEndpoint = observer.Endpoint{
 	ID:     "port-1",
 	Target: "localhost:1234",
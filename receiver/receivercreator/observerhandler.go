@@ -19,6 +19,7 @@ import (
 	"sync"
 
 	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configerror"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
@@ -32,6 +33,8 @@ type observerHandler struct {
 	logger *zap.Logger
 	// receiverTemplates maps receiver template full name to a receiverTemplate value.
 	receiverTemplates map[string]receiverTemplate
+	// annotations configures creating receivers from pod annotations.
+	annotations AnnotationsConfig
 	// receiversByEndpointID is a map of endpoint IDs to a receiver instance.
 	receiversByEndpointID receiverMap
 	// runner starts and stops receiver instances.
@@ -80,46 +83,81 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 				continue
 			}
 
-			obs.logger.Info("starting receiver",
-				zap.String("name", template.fullName),
-				zap.String("type", string(template.typeStr)),
-				zap.String("endpoint", e.Target),
-				zap.String("endpoint_id", string(e.ID)))
+			obs.startReceiver(template.receiverConfig, env, e)
+		}
 
-			resolvedConfig, err := expandMap(template.config, env)
-			if err != nil {
-				obs.logger.Error("unable to resolve template config", zap.String("receiver", template.fullName), zap.Error(err))
-				continue
-			}
+		if obs.annotations.Enabled {
+			obs.startAnnotatedReceivers(env, e)
+		}
+	}
+}
 
-			discoveredConfig := userConfigMap{}
+// startAnnotatedReceivers starts the receivers embedded in the discovery annotation
+// of a pod endpoint.
+func (obs *observerHandler) startAnnotatedReceivers(env observer.EndpointEnv, e observer.Endpoint) {
+	pod, ok := e.Details.(observer.Pod)
+	if !ok {
+		return
+	}
 
-			// If user didn't set endpoint set to default value.
-			if _, ok := resolvedConfig[endpointConfigKey]; !ok {
-				discoveredConfig[endpointConfigKey] = e.Target
-			}
+	receivers, err := receiverConfigsFromAnnotations(pod.Annotations, obs.annotations.AllowedReceivers)
+	if err != nil {
+		obs.logger.Error("unable to load receivers from annotations", zap.String("endpoint_id", string(e.ID)), zap.Error(err))
+		return
+	}
 
-			resolvedDiscoveredConfig, err := expandMap(discoveredConfig, env)
+	for _, receiver := range receivers {
+		obs.startReceiver(receiver, env, e)
+	}
+}
 
-			if err != nil {
-				obs.logger.Error("unable to resolve discovered config", zap.String("receiver", template.fullName), zap.Error(err))
-				continue
-			}
+// startReceiver resolves the template config against env and starts the receiver for endpoint e.
+func (obs *observerHandler) startReceiver(template receiverConfig, env observer.EndpointEnv, e observer.Endpoint) {
+	obs.logger.Info("starting receiver",
+		zap.String("name", template.fullName),
+		zap.String("type", string(template.typeStr)),
+		zap.String("endpoint", e.Target),
+		zap.String("endpoint_id", string(e.ID)))
+
+	resolvedConfig, err := expandMap(template.config, env)
+	if err != nil {
+		obs.logger.Error("unable to resolve template config", zap.String("receiver", template.fullName), zap.Error(err))
+		return
+	}
 
-			rcvr, err := obs.runner.start(receiverConfig{
-				fullName: template.fullName,
-				typeStr:  template.typeStr,
-				config:   resolvedConfig,
-			}, resolvedDiscoveredConfig)
+	discoveredConfig := userConfigMap{}
 
-			if err != nil {
-				obs.logger.Error("failed to start receiver", zap.String("receiver", template.fullName))
-				continue
-			}
+	// If user didn't set endpoint set to default value. The runner leaves it out
+	// for receivers without an endpoint setting.
+	if _, ok := resolvedConfig[endpointConfigKey]; !ok {
+		discoveredConfig[endpointConfigKey] = e.Target
+	}
 
-			obs.receiversByEndpointID.Put(e.ID, rcvr)
-		}
+	resolvedDiscoveredConfig, err := expandMap(discoveredConfig, env)
+
+	if err != nil {
+		obs.logger.Error("unable to resolve discovered config", zap.String("receiver", template.fullName), zap.Error(err))
+		return
+	}
+
+	rcvr, err := obs.runner.start(receiverConfig{
+		fullName: template.fullName,
+		typeStr:  template.typeStr,
+		config:   resolvedConfig,
+	}, resolvedDiscoveredConfig)
+
+	if err == configerror.ErrDataTypeIsNotSupported {
+		// The receiver does not support the signal of this receiver_creator instance,
+		// it is expected to be started by the instance in another pipeline.
+		obs.logger.Debug("receiver does not support data type", zap.String("receiver", template.fullName))
+		return
 	}
+	if err != nil {
+		obs.logger.Error("failed to start receiver", zap.String("receiver", template.fullName), zap.Error(err))
+		return
+	}
+
+	obs.receiversByEndpointID.Put(e.ID, rcvr)
 }
 
 // OnRemove responds to endpoint removal notifications.
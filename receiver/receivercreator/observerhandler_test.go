@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.uber.org/zap"
 
@@ -66,6 +67,26 @@ func TestOnAdd(t *testing.T) {
 	assert.Equal(t, 1, handler.receiversByEndpointID.Size())
 }
 
+func TestOnAddUnsupportedDataType(t *testing.T) {
+	runner := &mockRunner{}
+	rcvrCfg := receiverConfig{typeStr: configmodels.Type("name"), config: userConfigMap{"foo": "bar"}, fullName: "name/1"}
+	handler := &observerHandler{
+		logger: zap.NewNop(),
+		receiverTemplates: map[string]receiverTemplate{
+			"name/1": {rcvrCfg, "", newRuleOrPanic(`type.port`)},
+		},
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+	}
+
+	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}).Return((*componenttest.ExampleReceiverProducer)(nil), configerror.ErrDataTypeIsNotSupported)
+
+	handler.OnAdd([]observer.Endpoint{portEndpoint})
+
+	runner.AssertExpectations(t)
+	assert.Equal(t, 0, handler.receiversByEndpointID.Size())
+}
+
 func TestOnRemove(t *testing.T) {
 	runner := &mockRunner{}
 	rcvr := &componenttest.ExampleReceiverProducer{}
@@ -136,3 +157,44 @@ func TestDynamicConfig(t *testing.T) {
 
 	runner.AssertExpectations(t)
 }
+
+func TestOnAddAnnotations(t *testing.T) {
+	runner := &mockRunner{}
+	handler := &observerHandler{
+		logger:                zap.NewNop(),
+		receiverTemplates:     map[string]receiverTemplate{},
+		annotations:           AnnotationsConfig{Enabled: true, AllowedReceivers: []configmodels.Type{"name"}},
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+	}
+
+	runner.On("start", receiverConfig{
+		fullName: "name/1",
+		typeStr:  "name",
+		config:   userConfigMap{endpointConfigKey: "localhost:6379"},
+	}, userConfigMap{}).Return(&componenttest.ExampleReceiverProducer{}, nil)
+
+	handler.OnAdd([]observer.Endpoint{
+		annotatedPodEndpoint,
+		portEndpoint,
+	})
+
+	runner.AssertExpectations(t)
+	assert.Equal(t, 1, handler.receiversByEndpointID.Size())
+	assert.Len(t, handler.receiversByEndpointID.Get("pod-2"), 1)
+}
+
+func TestOnAddAnnotationsDisabled(t *testing.T) {
+	runner := &mockRunner{}
+	handler := &observerHandler{
+		logger:                zap.NewNop(),
+		receiverTemplates:     map[string]receiverTemplate{},
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+	}
+
+	handler.OnAdd([]observer.Endpoint{annotatedPodEndpoint})
+
+	runner.AssertExpectations(t)
+	assert.Equal(t, 0, handler.receiversByEndpointID.Size())
+}
@@ -31,28 +31,31 @@ var (
 	errNilNextConsumer = errors.New("nil nextConsumer")
 )
 
-var _ component.MetricsReceiver = (*receiverCreator)(nil)
+var (
+	_ component.MetricsReceiver = (*receiverCreator)(nil)
+	_ component.TraceReceiver   = (*receiverCreator)(nil)
+	_ component.LogsReceiver    = (*receiverCreator)(nil)
+)
 
-// receiverCreator implements consumer.MetricsConsumer.
+// receiverCreator implements component.MetricsReceiver, component.TraceReceiver
+// and component.LogsReceiver. A single instance is created per configuration, with
+// the next consumers of the pipelines it is used in. Receivers started at runtime are
+// created for each of those signals they support.
 type receiverCreator struct {
-	nextConsumer    consumer.MetricsConsumer
-	logger          *zap.Logger
-	cfg             *Config
-	observerHandler observerHandler
+	nextMetricsConsumer consumer.MetricsConsumer
+	nextTracesConsumer  consumer.TraceConsumer
+	nextLogsConsumer    consumer.LogsConsumer
+	logger              *zap.Logger
+	cfg                 *Config
+	observerHandler     observerHandler
 }
 
 // newReceiverCreator creates the receiver_creator with the given parameters.
-func newReceiverCreator(logger *zap.Logger, nextConsumer consumer.MetricsConsumer, cfg *Config) (component.MetricsReceiver, error) {
-	if nextConsumer == nil {
-		return nil, errNilNextConsumer
-	}
-
-	r := &receiverCreator{
-		logger:       logger,
-		nextConsumer: nextConsumer,
-		cfg:          cfg,
+func newReceiverCreator(logger *zap.Logger, cfg *Config) *receiverCreator {
+	return &receiverCreator{
+		logger: logger,
+		cfg:    cfg,
 	}
-	return r, nil
 }
 
 // loggingHost provides a safer version of host that logs errors instead of exiting the process.
@@ -73,11 +76,14 @@ func (rc *receiverCreator) Start(ctx context.Context, host component.Host) error
 	rc.observerHandler = observerHandler{
 		logger:                rc.logger,
 		receiverTemplates:     rc.cfg.receiverTemplates,
+		annotations:           rc.cfg.Annotations,
 		receiversByEndpointID: receiverMap{},
 		runner: &receiverRunner{
-			logger:       rc.logger,
-			nextConsumer: rc.nextConsumer,
-			idNamespace:  rc.cfg.Name(),
+			logger:              rc.logger,
+			nextMetricsConsumer: rc.nextMetricsConsumer,
+			nextTracesConsumer:  rc.nextTracesConsumer,
+			nextLogsConsumer:    rc.nextLogsConsumer,
+			idNamespace:         rc.cfg.Name(),
 			// TODO: not really sure what context should be used here for starting subreceivers
 			// as don't think it makes sense to use Start context as the lifetimes are different.
 			ctx:  context.Background(),
@@ -17,9 +17,13 @@ package receivercreator
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/zap"
@@ -35,11 +39,14 @@ type runner interface {
 
 // receiverRunner handles starting/stopping of a concrete subreceiver instance.
 type receiverRunner struct {
-	logger       *zap.Logger
-	nextConsumer consumer.MetricsConsumer
-	idNamespace  string
-	ctx          context.Context
-	host         component.Host
+	logger *zap.Logger
+	// Only the next consumers of the signals receiver_creator is used for are set.
+	nextMetricsConsumer consumer.MetricsConsumer
+	nextTracesConsumer  consumer.TraceConsumer
+	nextLogsConsumer    consumer.LogsConsumer
+	idNamespace         string
+	ctx                 context.Context
+	host                component.Host
 }
 
 var _ runner = (*receiverRunner)(nil)
@@ -89,6 +96,20 @@ func (run *receiverRunner) loadRuntimeReceiverConfig(
 		return nil, fmt.Errorf("failed to merge template config from config file: %v", err)
 	}
 
+	// The discovered endpoint is left out for receivers without an endpoint setting as
+	// loading their config would fail on the unknown key, it still names the receiver.
+	discoveredEndpoint := ""
+	if endpoint, ok := discoveredConfig[endpointConfigKey]; ok && !configHasKey(factory.CreateDefaultConfig(), endpointConfigKey) {
+		discoveredEndpoint = fmt.Sprint(endpoint)
+		withoutEndpoint := userConfigMap{}
+		for k, v := range discoveredConfig {
+			if k != endpointConfigKey {
+				withoutEndpoint[k] = v
+			}
+		}
+		discoveredConfig = withoutEndpoint
+	}
+
 	// Merge in discoveredConfig containing values discovered at runtime.
 	if err := mergedConfig.MergeConfigMap(discoveredConfig); err != nil {
 		return nil, fmt.Errorf("failed to merge template config from discovered runtime values: %v", err)
@@ -100,12 +121,132 @@ func (run *receiverRunner) loadRuntimeReceiverConfig(
 	}
 	// Sets dynamically created receiver to something like receiver_creator/1/redis{endpoint="localhost:6380"}.
 	// TODO: Need to make sure this is unique (just endpoint is probably not totally sufficient).
-	receiverConfig.SetName(fmt.Sprintf("%s/%s{endpoint=%q}", run.idNamespace, receiver.fullName, mergedConfig.GetString(endpointConfigKey)))
+	endpoint := mergedConfig.GetString(endpointConfigKey)
+	if endpoint == "" {
+		endpoint = discoveredEndpoint
+	}
+	receiverConfig.SetName(fmt.Sprintf("%s/%s{endpoint=%q}", run.idNamespace, receiver.fullName, endpoint))
 	return receiverConfig, nil
 }
 
-// createRuntimeReceiver creates a receiver that is discovered at runtime.
-func (run *receiverRunner) createRuntimeReceiver(factory component.ReceiverFactory, cfg configmodels.Receiver) (component.MetricsReceiver, error) {
+// configHasKey returns whether the config struct cfg has a setting named key,
+// including the settings of the structs squashed into it.
+func configHasKey(cfg interface{}, key string) bool {
+	t := reflect.TypeOf(cfg)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tagParts := strings.Split(field.Tag.Get("mapstructure"), ",")
+		squash := false
+		for _, option := range tagParts[1:] {
+			squash = squash || option == "squash"
+		}
+		if squash {
+			if configHasKey(reflect.Zero(field.Type).Interface(), key) {
+				return true
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			// Unexported fields are not loaded from the config.
+			continue
+		}
+		name := tagParts[0]
+		if name == "" {
+			name = field.Name
+		}
+		// Keys are matched case-insensitively when loading the config.
+		if strings.EqualFold(name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// createRuntimeReceiver creates a receiver that is discovered at runtime. The receiver is
+// created for each signal with a configured next consumer that the factory supports.
+// Returns configerror.ErrDataTypeIsNotSupported if the factory supports none of them.
+func (run *receiverRunner) createRuntimeReceiver(factory component.ReceiverFactory, cfg configmodels.Receiver) (component.Receiver, error) {
 	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
-	return factory.CreateMetricsReceiver(context.Background(), params, cfg, run.nextConsumer)
+	if run.nextMetricsConsumer == nil && run.nextTracesConsumer == nil && run.nextLogsConsumer == nil {
+		return nil, errNilNextConsumer
+	}
+
+	var rcvrs multiReceiver
+	add := func(rcvr component.Receiver, err error) error {
+		if err == configerror.ErrDataTypeIsNotSupported {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// A factory may return the same receiver for several signals.
+		for _, r := range rcvrs {
+			if r == rcvr {
+				return nil
+			}
+		}
+		rcvrs = append(rcvrs, rcvr)
+		return nil
+	}
+
+	if run.nextMetricsConsumer != nil {
+		if err := add(factory.CreateMetricsReceiver(context.Background(), params, cfg, run.nextMetricsConsumer)); err != nil {
+			return nil, err
+		}
+	}
+	if run.nextTracesConsumer != nil {
+		if err := add(factory.CreateTraceReceiver(context.Background(), params, cfg, run.nextTracesConsumer)); err != nil {
+			return nil, err
+		}
+	}
+	if run.nextLogsConsumer != nil {
+		if logsFactory, ok := factory.(component.LogsReceiverFactory); ok {
+			if err := add(logsFactory.CreateLogsReceiver(context.Background(), params, cfg, run.nextLogsConsumer)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch len(rcvrs) {
+	case 0:
+		return nil, configerror.ErrDataTypeIsNotSupported
+	case 1:
+		return rcvrs[0], nil
+	}
+	return rcvrs, nil
+}
+
+// multiReceiver is a receiver created for several signals, made of the receivers
+// created for each of them.
+type multiReceiver []component.Receiver
+
+var _ component.Receiver = (multiReceiver)(nil)
+
+// Start starts the receivers, shutting down the started ones if one fails.
+func (mr multiReceiver) Start(ctx context.Context, host component.Host) error {
+	for i, r := range mr {
+		if err := r.Start(ctx, host); err != nil {
+			_ = mr[:i].Shutdown(ctx)
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown shuts down the receivers.
+func (mr multiReceiver) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, r := range mr {
+		if err := r.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
 }
@@ -20,11 +20,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/zap"
 )
 
 func Test_loadAndCreateRuntimeReceiver(t *testing.T) {
-	run := &receiverRunner{logger: zap.NewNop(), nextConsumer: &mockMetricsConsumer{}, idNamespace: "receiver_creator/1"}
+	run := &receiverRunner{logger: zap.NewNop(), nextMetricsConsumer: &mockMetricsConsumer{}, idNamespace: "receiver_creator/1"}
 	exampleFactory := &componenttest.ExampleReceiverFactory{}
 	template, err := newReceiverTemplate("examplereceiver/1", nil)
 	require.NoError(t, err)
@@ -45,6 +48,85 @@ func Test_loadAndCreateRuntimeReceiver(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, recvr)
 		exampleReceiver := recvr.(*componenttest.ExampleReceiverProducer)
-		assert.Equal(t, run.nextConsumer, exampleReceiver.MetricsConsumer)
+		assert.Equal(t, run.nextMetricsConsumer, exampleReceiver.MetricsConsumer)
 	})
 }
+
+func Test_createRuntimeTraceReceiver(t *testing.T) {
+	traceConsumer := &exportertest.SinkTraceExporter{}
+	run := &receiverRunner{logger: zap.NewNop(), nextTracesConsumer: traceConsumer, idNamespace: "receiver_creator/1"}
+	exampleFactory := &componenttest.ExampleReceiverFactory{}
+	template, err := newReceiverTemplate("examplereceiver/1", nil)
+	require.NoError(t, err)
+
+	loadedConfig, err := run.loadRuntimeReceiverConfig(exampleFactory, template.receiverConfig, userConfigMap{
+		endpointConfigKey: "localhost:12345",
+	})
+	require.NoError(t, err)
+
+	recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig)
+	require.NoError(t, err)
+	exampleReceiver := recvr.(*componenttest.ExampleReceiverProducer)
+	assert.Equal(t, traceConsumer, exampleReceiver.TraceConsumer)
+}
+
+func Test_createRuntimeReceiverForSeveralSignals(t *testing.T) {
+	metricsConsumer := &mockMetricsConsumer{}
+	traceConsumer := &exportertest.SinkTraceExporter{}
+	run := &receiverRunner{
+		logger:              zap.NewNop(),
+		nextMetricsConsumer: metricsConsumer,
+		nextTracesConsumer:  traceConsumer,
+		idNamespace:         "receiver_creator/1",
+	}
+	exampleFactory := &componenttest.ExampleReceiverFactory{}
+	template, err := newReceiverTemplate("examplereceiver/1", nil)
+	require.NoError(t, err)
+
+	loadedConfig, err := run.loadRuntimeReceiverConfig(exampleFactory, template.receiverConfig, userConfigMap{
+		endpointConfigKey: "localhost:12345",
+	})
+	require.NoError(t, err)
+
+	// The example factory returns the same receiver for all the signals.
+	recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig)
+	require.NoError(t, err)
+	exampleReceiver := recvr.(*componenttest.ExampleReceiverProducer)
+	assert.Equal(t, metricsConsumer, exampleReceiver.MetricsConsumer)
+	assert.Equal(t, traceConsumer, exampleReceiver.TraceConsumer)
+}
+
+// noEndpointConfig is the config of a receiver without an endpoint setting.
+type noEndpointConfig struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+	Path                          string `mapstructure:"path"`
+}
+
+func Test_loadRuntimeReceiverConfigWithoutEndpoint(t *testing.T) {
+	run := &receiverRunner{logger: zap.NewNop(), idNamespace: "receiver_creator/1"}
+	factory := receiverhelper.NewFactory("noendpoint", func() configmodels.Receiver {
+		return &noEndpointConfig{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: "noendpoint",
+				NameVal: "noendpoint",
+			},
+		}
+	})
+	template, err := newReceiverTemplate("noendpoint/1", userConfigMap{"path": "/var/log/app.log"})
+	require.NoError(t, err)
+
+	loadedConfig, err := run.loadRuntimeReceiverConfig(factory, template.receiverConfig, userConfigMap{
+		endpointConfigKey: "localhost:12345",
+	})
+	require.NoError(t, err)
+	cfg := loadedConfig.(*noEndpointConfig)
+	assert.Equal(t, "/var/log/app.log", cfg.Path)
+	assert.Equal(t, "receiver_creator/1/noendpoint/1{endpoint=\"localhost:12345\"}", cfg.Name())
+}
+
+func Test_configHasKey(t *testing.T) {
+	assert.True(t, configHasKey(&componenttest.ExampleReceiver{}, endpointConfigKey))
+	assert.False(t, configHasKey(&noEndpointConfig{}, endpointConfigKey))
+	assert.True(t, configHasKey(&noEndpointConfig{}, "path"))
+	assert.False(t, configHasKey("endpoint", endpointConfigKey))
+}
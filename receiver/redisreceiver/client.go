@@ -22,6 +22,8 @@ import (
 type client interface {
 	// retrieves a string of key/value pairs of redis metadata
 	retrieveInfo() (string, error)
+	// retrieves a string of key/value pairs of redis cluster metadata
+	retrieveClusterInfo() (string, error)
 	// line delimiter
 	// redis lines are delimited by \r\n, files (for testing) by \n
 	delimiter() string
@@ -41,6 +43,14 @@ func newRedisClient(options *redis.Options) client {
 	}
 }
 
+// Creates a new real Redis client connected to the master discovered through
+// Redis Sentinel.
+func newRedisFailoverClient(options *redis.FailoverOptions) client {
+	return &redisClient{
+		client: redis.NewFailoverClient(options),
+	}
+}
+
 // Redis strings are CRLF delimited.
 func (c *redisClient) delimiter() string {
 	return "\r\n"
@@ -50,3 +60,8 @@ func (c *redisClient) delimiter() string {
 func (c *redisClient) retrieveInfo() (string, error) {
 	return c.client.Info().Result()
 }
+
+// Retrieve Redis CLUSTER INFO. Fails if cluster mode is not enabled.
+func (c *redisClient) retrieveClusterInfo() (string, error) {
+	return c.client.ClusterInfo().Result()
+}
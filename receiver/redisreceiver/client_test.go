@@ -39,6 +39,10 @@ func (fakeClient) retrieveInfo() (string, error) {
 	return readFile("info")
 }
 
+func (fakeClient) retrieveClusterInfo() (string, error) {
+	return readFile("cluster_info")
+}
+
 func readFile(fname string) (string, error) {
 	file, err := ioutil.ReadFile(path.Join("testdata", fname+".txt"))
 	if err != nil {
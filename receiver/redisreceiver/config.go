@@ -15,6 +15,7 @@
 package redisreceiver
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -36,4 +37,32 @@ type config struct {
 	// Optional password. Must match the password specified in the
 	// requirepass server configuration option.
 	Password string `mapstructure:"password"`
+
+	// Optional Sentinel configuration. If set, the current master is discovered
+	// through Redis Sentinel and Endpoint is ignored.
+	Sentinel *sentinelConfig `mapstructure:"sentinel"`
+}
+
+type sentinelConfig struct {
+	// The name of the master monitored by the sentinels.
+	MasterName string `mapstructure:"master_name"`
+	// The host:port addresses of the sentinels.
+	Endpoints []string `mapstructure:"endpoints"`
+	// Optional password used to authenticate with the sentinels.
+	Password string `mapstructure:"password"`
+}
+
+// validate checks that a sentinel configuration, if present, names the master
+// and at least one sentinel to query.
+func (c *config) validate() error {
+	if c.Sentinel == nil {
+		return nil
+	}
+	if c.Sentinel.MasterName == "" {
+		return errors.New("sentinel.master_name must be specified")
+	}
+	if len(c.Sentinel.Endpoints) == 0 {
+		return errors.New("sentinel.endpoints must contain at least one endpoint")
+	}
+	return nil
 }
@@ -0,0 +1,70 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestCreateMetricsReceiverSentinelValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		sentinel *sentinelConfig
+		wantErr  bool
+	}{
+		{name: "no sentinel"},
+		{
+			name:     "valid sentinel",
+			sentinel: &sentinelConfig{MasterName: "mymaster", Endpoints: []string{"localhost:26379"}},
+		},
+		{
+			name:     "missing master name",
+			sentinel: &sentinelConfig{Endpoints: []string{"localhost:26379"}},
+			wantErr:  true,
+		},
+		{
+			name:     "missing endpoints",
+			sentinel: &sentinelConfig{MasterName: "mymaster"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*config)
+			cfg.Endpoint = "localhost:6379"
+			cfg.Sentinel = tt.sentinel
+			r, err := createMetricsReceiver(
+				context.Background(),
+				component.ReceiverCreateParams{Logger: zap.NewNop()},
+				cfg,
+				exportertest.NewNopMetricsExporter(),
+			)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, r)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, r)
+		})
+	}
+}
@@ -77,6 +77,70 @@ func (i info) buildKeyspaceProtoMetrics(t *timeBundle) (
 	return protoMetrics, warnings
 }
 
+// Builds proto metrics from the Replication section of Redis INFO. On a master
+// there are metrics for each connected replica, on a replica there are metrics
+// for the link to its master. Returns proto metrics and parsing errors, to be
+// treated as warnings, if there were any.
+func (i info) buildReplicationProtoMetrics(t *timeBundle) (
+	protoMetrics []*metricspb.Metric,
+	warnings []error,
+) {
+	switch i["role"] {
+	case "master":
+		masterOffset, err := strconv.ParseInt(i["master_repl_offset"], 10, 64)
+		if err != nil {
+			return nil, []error{fmt.Errorf("invalid master_repl_offset: %v", err)}
+		}
+		numReplicas, err := strconv.Atoi(i["connected_slaves"])
+		if err != nil {
+			return nil, []error{fmt.Errorf("invalid connected_slaves: %v", err)}
+		}
+		for n := 0; n < numReplicas; n++ {
+			str, ok := i["slave"+strconv.Itoa(n)]
+			if !ok {
+				break
+			}
+			r, parsingError := parseReplicaString(str)
+			if parsingError != nil {
+				warnings = append(warnings, parsingError)
+				continue
+			}
+			protoMetrics = append(protoMetrics, buildReplicaMetrics(r, masterOffset, t)...)
+		}
+	case "slave":
+		protoMetrics = append(protoMetrics, buildMasterLinkUpMetric(i["master_link_status"] == "up", t))
+		// Only reported while the link to the master is up.
+		if str, ok := i["master_last_io_seconds_ago"]; ok {
+			m, parsingError := masterLastIO().parseMetric(str, t)
+			if parsingError != nil {
+				warnings = append(warnings, parsingError)
+			} else {
+				protoMetrics = append(protoMetrics, m)
+			}
+		}
+	}
+	return protoMetrics, warnings
+}
+
+// Returns true if the Redis server has cluster mode enabled.
+func (i info) isClusterEnabled() bool {
+	return i["cluster_enabled"] == "1"
+}
+
+// Builds proto metrics from Redis CLUSTER INFO: slot coverage, number of nodes
+// and the cluster state. Returns proto metrics and parsing errors, to be treated
+// as warnings, if there were any.
+func (i info) buildClusterProtoMetrics(metrics []*redisMetric, t *timeBundle) (
+	protoMetrics []*metricspb.Metric,
+	warnings []error,
+) {
+	protoMetrics, warnings = i.buildFixedProtoMetrics(metrics, t)
+	if state, ok := i["cluster_state"]; ok {
+		protoMetrics = append(protoMetrics, buildClusterStateMetric(state == "ok", t))
+	}
+	return protoMetrics, warnings
+}
+
 func (i info) getUptimeInSeconds() (int, error) {
 	const uptimeKey = "uptime_in_seconds"
 	uptimeStr, ok := i[uptimeKey]
@@ -16,6 +16,7 @@ package redisreceiver
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -27,3 +28,66 @@ func TestGetUptime(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, 104946, uptime)
 }
+
+func TestBuildReplicationProtoMetricsMaster(t *testing.T) {
+	inf := info{
+		"role":               "master",
+		"connected_slaves":   "2",
+		"master_repl_offset": "1500",
+		"slave0":             "ip=10.0.0.2,port=6379,state=online,offset=1500,lag=0",
+		"slave1":             "ip=10.0.0.3,port=6379,state=online,offset=1000,lag=2",
+	}
+	metrics, warnings := inf.buildReplicationProtoMetrics(newTimeBundle(time.Now(), 100))
+	require.Nil(t, warnings)
+	require.Len(t, metrics, 4)
+	require.Equal(t, "redis/replication/replica_lag", metrics[2].MetricDescriptor.Name)
+	require.Equal(t, "10.0.0.3:6379", metrics[2].Timeseries[0].LabelValues[0].Value)
+	require.Equal(t, int64(2), metrics[2].Timeseries[0].Points[0].GetInt64Value())
+	require.Equal(t, "redis/replication/replica_offset_lag", metrics[3].MetricDescriptor.Name)
+	require.Equal(t, int64(500), metrics[3].Timeseries[0].Points[0].GetInt64Value())
+}
+
+func TestBuildReplicationProtoMetricsReplica(t *testing.T) {
+	inf := info{
+		"role":                       "slave",
+		"master_link_status":         "up",
+		"master_last_io_seconds_ago": "3",
+	}
+	metrics, warnings := inf.buildReplicationProtoMetrics(newTimeBundle(time.Now(), 100))
+	require.Nil(t, warnings)
+	require.Len(t, metrics, 2)
+	require.Equal(t, "redis/replication/master_link_up", metrics[0].MetricDescriptor.Name)
+	require.Equal(t, int64(1), metrics[0].Timeseries[0].Points[0].GetInt64Value())
+	require.Equal(t, "redis/replication/master_last_io", metrics[1].MetricDescriptor.Name)
+	require.Equal(t, int64(3), metrics[1].Timeseries[0].Points[0].GetInt64Value())
+
+	inf = info{
+		"role":               "slave",
+		"master_link_status": "down",
+	}
+	metrics, warnings = inf.buildReplicationProtoMetrics(newTimeBundle(time.Now(), 100))
+	require.Nil(t, warnings)
+	require.Len(t, metrics, 1)
+	require.Equal(t, int64(0), metrics[0].Timeseries[0].Points[0].GetInt64Value())
+}
+
+func TestBuildClusterProtoMetrics(t *testing.T) {
+	svc := newRedisSvc(newFakeClient())
+	inf, err := svc.info()
+	require.Nil(t, err)
+	require.False(t, inf.isClusterEnabled())
+
+	clusterInf, err := svc.clusterInfo()
+	require.Nil(t, err)
+	metrics, warnings := clusterInf.buildClusterProtoMetrics(getClusterRedisMetrics(), newTimeBundle(time.Now(), 100))
+	require.Nil(t, warnings)
+	require.Len(t, metrics, len(getClusterRedisMetrics())+1)
+	names := map[string]bool{}
+	for _, m := range metrics {
+		require.False(t, names[m.MetricDescriptor.Name], "duplicate metric name %q", m.MetricDescriptor.Name)
+		names[m.MetricDescriptor.Name] = true
+	}
+	stateMetric := metrics[len(metrics)-1]
+	require.Equal(t, "redis/cluster/state_ok", stateMetric.MetricDescriptor.Name)
+	require.Equal(t, int64(1), stateMetric.Timeseries[0].Points[0].GetInt64Value())
+}
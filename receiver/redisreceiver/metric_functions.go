@@ -70,6 +70,20 @@ func getDefaultRedisMetrics() []*redisMetric {
 	}
 }
 
+// Called once at startup. Returns the metrics we want to extract from Redis
+// CLUSTER INFO when cluster mode is enabled.
+func getClusterRedisMetrics() []*redisMetric {
+	return []*redisMetric{
+		clusterSlotsAssigned(),
+		clusterSlotsOK(),
+		clusterSlotsPFail(),
+		clusterSlotsFail(),
+
+		clusterKnownNodes(),
+		clusterSize(),
+	}
+}
+
 func uptimeInSeconds() *redisMetric {
 	return &redisMetric{
 		key:    "uptime_in_seconds",
@@ -336,3 +350,67 @@ func masterReplOffset() *redisMetric {
 		desc:   "The server's current replication offset",
 	}
 }
+
+func masterLastIO() *redisMetric {
+	return &redisMetric{
+		key:    "master_last_io_seconds_ago",
+		name:   "redis/replication/master_last_io",
+		units:  "s",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Number of seconds since the last interaction with the master",
+	}
+}
+
+func clusterSlotsAssigned() *redisMetric {
+	return &redisMetric{
+		key:    "cluster_slots_assigned",
+		name:   "redis/cluster/slots_assigned",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Number of hash slots associated to some node",
+	}
+}
+
+func clusterSlotsOK() *redisMetric {
+	return &redisMetric{
+		key:    "cluster_slots_ok",
+		name:   "redis/cluster/slots_ok",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Number of hash slots mapping to a node not in FAIL or PFAIL state",
+	}
+}
+
+func clusterSlotsPFail() *redisMetric {
+	return &redisMetric{
+		key:    "cluster_slots_pfail",
+		name:   "redis/cluster/slots_pfail",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Number of hash slots mapping to a node in PFAIL state",
+	}
+}
+
+func clusterSlotsFail() *redisMetric {
+	return &redisMetric{
+		key:    "cluster_slots_fail",
+		name:   "redis/cluster/slots_fail",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Number of hash slots mapping to a node in FAIL state",
+	}
+}
+
+func clusterKnownNodes() *redisMetric {
+	return &redisMetric{
+		key:    "cluster_known_nodes",
+		name:   "redis/cluster/known_nodes",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Total number of known nodes in the cluster, including nodes in handshake state",
+	}
+}
+
+func clusterSize() *redisMetric {
+	return &redisMetric{
+		key:    "cluster_size",
+		name:   "redis/cluster/size",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Number of master nodes serving at least one hash slot",
+	}
+}
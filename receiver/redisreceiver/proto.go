@@ -73,6 +73,64 @@ func buildKeyspaceTTLMetric(k *keyspace, t *timeBundle) *metricspb.Metric {
 	return newProtoMetric(m, pt, t)
 }
 
+func buildReplicaMetrics(r *replica, masterOffset int64, t *timeBundle) []*metricspb.Metric {
+	return []*metricspb.Metric{
+		buildReplicaLagMetric(r, t),
+		buildReplicaOffsetLagMetric(r, masterOffset, t),
+	}
+}
+
+func buildReplicaLagMetric(r *replica, t *timeBundle) *metricspb.Metric {
+	m := &redisMetric{
+		name:   "redis/replication/replica_lag",
+		units:  "s",
+		labels: map[string]string{"replica": r.addr},
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Seconds since the last acknowledgement from the replica",
+	}
+	pt := &metricspb.Point{Value: &metricspb.Point_Int64Value{Int64Value: r.lag}}
+	return newProtoMetric(m, pt, t)
+}
+
+func buildReplicaOffsetLagMetric(r *replica, masterOffset int64, t *timeBundle) *metricspb.Metric {
+	m := &redisMetric{
+		name:   "redis/replication/replica_offset_lag",
+		units:  "By",
+		labels: map[string]string{"replica": r.addr},
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Number of bytes of the replication stream the replica has not yet processed",
+	}
+	pt := &metricspb.Point{Value: &metricspb.Point_Int64Value{Int64Value: masterOffset - r.offset}}
+	return newProtoMetric(m, pt, t)
+}
+
+func buildMasterLinkUpMetric(up bool, t *timeBundle) *metricspb.Metric {
+	m := &redisMetric{
+		name:   "redis/replication/master_link_up",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Whether the link to the master is up (1) or down (0)",
+	}
+	pt := &metricspb.Point{Value: &metricspb.Point_Int64Value{Int64Value: boolToInt64(up)}}
+	return newProtoMetric(m, pt, t)
+}
+
+func buildClusterStateMetric(ok bool, t *timeBundle) *metricspb.Metric {
+	m := &redisMetric{
+		name:   "redis/cluster/state_ok",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Whether the cluster state is ok (1) or fail (0)",
+	}
+	pt := &metricspb.Point{Value: &metricspb.Point_Int64Value{Int64Value: boolToInt64(ok)}}
+	return newProtoMetric(m, pt, t)
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // Create new protobuf Metric.
 // Arguments:
 //   * redisMetric -- the fixed metadata to build the protobuf metric
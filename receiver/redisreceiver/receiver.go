@@ -46,10 +46,20 @@ func newRedisReceiver(
 
 // Set up and kick off the interval runner.
 func (r *redisReceiver) Start(ctx context.Context, host component.Host) error {
-	c := newRedisClient(&redis.Options{
-		Addr:     r.config.Endpoint,
-		Password: r.config.Password,
-	})
+	var c client
+	if r.config.Sentinel != nil {
+		c = newRedisFailoverClient(&redis.FailoverOptions{
+			MasterName:       r.config.Sentinel.MasterName,
+			SentinelAddrs:    r.config.Sentinel.Endpoints,
+			SentinelPassword: r.config.Sentinel.Password,
+			Password:         r.config.Password,
+		})
+	} else {
+		c = newRedisClient(&redis.Options{
+			Addr:     r.config.Endpoint,
+			Password: r.config.Password,
+		})
+	}
 	redisRunnable := newRedisRunnable(ctx, c, r.config.ServiceName, r.consumer, r.logger)
 	r.intervalRunner = interval.NewRunner(r.config.CollectionInterval, redisRunnable)
 
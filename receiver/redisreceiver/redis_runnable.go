@@ -36,6 +36,7 @@ type redisRunnable struct {
 	metricsConsumer consumer.MetricsConsumer
 	redisSvc        *redisSvc
 	redisMetrics    []*redisMetric
+	clusterMetrics  []*redisMetric
 	logger          *zap.Logger
 	timeBundle      *timeBundle
 	serviceName     string
@@ -61,6 +62,7 @@ func newRedisRunnable(
 // later extract data from Redis.
 func (r *redisRunnable) Setup() error {
 	r.redisMetrics = getDefaultRedisMetrics()
+	r.clusterMetrics = getClusterRedisMetrics()
 	return nil
 }
 
@@ -68,7 +70,8 @@ func (r *redisRunnable) Setup() error {
 // the next consumer. First builds 'fixed' metrics (non-keyspace metrics)
 // defined at startup time. Then builds 'keyspace' metrics if there are any
 // keyspace lines returned by Redis. There should be one keyspace line per
-// active Redis database, of which there can be 16.
+// active Redis database, of which there can be 16. Finally builds replication
+// metrics and, if cluster mode is enabled, cluster metrics.
 func (r *redisRunnable) Run() error {
 	const dataFormat = "redis"
 	const transport = "http" // todo verify this
@@ -109,6 +112,31 @@ func (r *redisRunnable) Run() error {
 		)
 	}
 
+	replicationMetrics, warnings := inf.buildReplicationProtoMetrics(r.timeBundle)
+	metrics = append(metrics, replicationMetrics...)
+	if warnings != nil {
+		r.logger.Warn(
+			"errors parsing replication string",
+			zap.Errors("parsing errors", warnings),
+		)
+	}
+
+	if inf.isClusterEnabled() {
+		clusterInf, err := r.redisSvc.clusterInfo()
+		if err != nil {
+			r.logger.Warn("failed retrieving redis cluster info", zap.Error(err))
+		} else {
+			clusterMetrics, warnings := clusterInf.buildClusterProtoMetrics(r.clusterMetrics, r.timeBundle)
+			metrics = append(metrics, clusterMetrics...)
+			if warnings != nil {
+				r.logger.Warn(
+					"errors parsing cluster info string",
+					zap.Errors("parsing errors", warnings),
+				)
+			}
+		}
+	}
+
 	md := newMetricsData(metrics, r.serviceName)
 
 	err = r.metricsConsumer.ConsumeMetrics(r.ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{*md}))
@@ -39,6 +39,20 @@ func (p *redisSvc) info() (info, error) {
 	if err != nil {
 		return nil, err
 	}
+	return p.parse(str), nil
+}
+
+// Calls the Redis CLUSTER INFO command on the client and returns an `info` map.
+func (p *redisSvc) clusterInfo() (info, error) {
+	str, err := p.client.retrieveClusterInfo()
+	if err != nil {
+		return nil, err
+	}
+	return p.parse(str), nil
+}
+
+// Parses the colon separated key/value lines returned by INFO and CLUSTER INFO.
+func (p *redisSvc) parse(str string) info {
 	lines := strings.Split(str, p.delimiter)
 	attrs := make(map[string]string)
 	for _, line := range lines {
@@ -50,5 +64,5 @@ func (p *redisSvc) info() (info, error) {
 			attrs[pair[0]] = pair[1]
 		}
 	}
-	return attrs, nil
+	return attrs
 }
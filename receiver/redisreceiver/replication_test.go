@@ -0,0 +1,39 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReplica(t *testing.T) {
+	r, err := parseReplicaString("ip=10.0.0.2,port=6380,state=online,offset=1234,lag=1")
+	require.Nil(t, err)
+	require.Equal(t, "10.0.0.2:6380", r.addr)
+	require.Equal(t, "online", r.state)
+	require.Equal(t, int64(1234), r.offset)
+	require.Equal(t, int64(1), r.lag)
+}
+
+func TestParseReplicaErrors(t *testing.T) {
+	_, err := parseReplicaString("ip=10.0.0.2,state=online")
+	require.Error(t, err)
+	_, err = parseReplicaString("ip=10.0.0.2,port=6380,offset")
+	require.Error(t, err)
+	_, err = parseReplicaString("ip=10.0.0.2,port=6380,lag=x")
+	require.Error(t, err)
+}
@@ -6,6 +6,7 @@ require (
 	github.com/Azure/go-autorest/autorest/adal v0.9.0 // indirect
 	github.com/gorilla/mux v1.7.4
 	github.com/jaegertracing/jaeger v1.18.2-0.20200707061226-97d2319ff2be
+	github.com/klauspost/compress v1.10.10
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-proto v0.4.0
 	github.com/signalfx/sapm-proto v0.5.3
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -25,6 +26,7 @@ import (
 	"sync"
 
 	"github.com/gorilla/mux"
+	"github.com/klauspost/compress/zstd"
 	splunksapm "github.com/signalfx/sapm-proto/gen"
 	"github.com/signalfx/sapm-proto/sapmprotocol"
 	"go.opentelemetry.io/collector/component"
@@ -37,12 +39,23 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/splunk"
 )
 
+// zstdEncodingHeaderValue is the Content-Encoding value for zstd compressed payloads.
+const zstdEncodingHeaderValue = "zstd"
+
+var (
+	errBadMethod      = errors.New("request method must be POST")
+	errBadContentType = errors.New("request content type must be " + sapmprotocol.ContentTypeHeaderValue)
+)
+
 var gzipWriterPool = &sync.Pool{
 	New: func() interface{} {
 		return gzip.NewWriter(ioutil.Discard)
 	},
 }
 
+// zstdDecoder is shared by all requests. DecodeAll is safe for concurrent use.
+var zstdDecoder, _ = zstd.NewReader(nil)
+
 // sapmReceiver receives spans in the Splunk SAPM format over HTTP
 type sapmReceiver struct {
 	// mu protects the fields of this type
@@ -62,9 +75,40 @@ type sapmReceiver struct {
 	defaultResponse []byte
 }
 
+// parseTraceV2Request parses a SAPM request. Identity and gzip encoded bodies are handled by
+// sapmprotocol, zstd encoded bodies are decoded here.
+func parseTraceV2Request(req *http.Request) (*splunksapm.PostSpansRequest, error) {
+	if req.Header.Get(sapmprotocol.ContentEncodingHeaderName) != zstdEncodingHeaderValue {
+		return sapmprotocol.ParseTraceV2Request(req)
+	}
+
+	if req.Method != http.MethodPost {
+		return nil, errBadMethod
+	}
+	if req.Header.Get(sapmprotocol.ContentTypeHeaderName) != sapmprotocol.ContentTypeHeaderValue {
+		return nil, errBadContentType
+	}
+
+	compressed, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	reqBytes, err := zstdDecoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode zstd request body: %w", err)
+	}
+
+	sapm := &splunksapm.PostSpansRequest{}
+	if err = sapm.Unmarshal(reqBytes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sapm request: %w", err)
+	}
+	return sapm, nil
+}
+
 // handleRequest parses an http request containing sapm and passes the trace data to the next consumer
 func (sr *sapmReceiver) handleRequest(ctx context.Context, req *http.Request) error {
-	sapm, err := sapmprotocol.ParseTraceV2Request(req)
+	sapm, err := parseTraceV2Request(req)
 	// errors processing the request should return http.StatusBadRequest
 	if err != nil {
 		return err
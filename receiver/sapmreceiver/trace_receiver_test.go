@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/jaegertracing/jaeger/model"
+	"github.com/klauspost/compress/zstd"
 	otlptrace "github.com/open-telemetry/opentelemetry-proto/gen/go/trace/v1"
 	splunksapm "github.com/signalfx/sapm-proto/gen"
 	"github.com/signalfx/sapm-proto/sapmprotocol"
@@ -141,14 +142,15 @@ func grpcFixture(t1 time.Time, d1, d2 time.Duration) *model.Batch {
 }
 
 // sendSapm acts as a client for sending sapm to the receiver.  This could be replaced with a sapm exporter in the future.
-func sendSapm(endpoint string, sapm *splunksapm.PostSpansRequest, zipped bool, tlsEnabled bool, token string) (*http.Response, error) {
+func sendSapm(endpoint string, sapm *splunksapm.PostSpansRequest, encoding string, tlsEnabled bool, token string) (*http.Response, error) {
 	// marshal the sapm
 	reqBytes, err := sapm.Marshal()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal sapm %v", err.Error())
 	}
 
-	if zipped {
+	switch encoding {
+	case sapmprotocol.GZipEncodingHeaderValue:
 		// create a gzip writer
 		var buff bytes.Buffer
 		writer := gzip.NewWriter(&buff)
@@ -167,6 +169,10 @@ func sendSapm(endpoint string, sapm *splunksapm.PostSpansRequest, zipped bool, t
 
 		// save the gzipped bytes as the request bytes
 		reqBytes = buff.Bytes()
+	case zstdEncodingHeaderValue:
+		encoder, _ := zstd.NewWriter(nil)
+		reqBytes = encoder.EncodeAll(reqBytes, nil)
+		encoder.Close()
 	}
 
 	// build the request
@@ -177,10 +183,13 @@ func sendSapm(endpoint string, sapm *splunksapm.PostSpansRequest, zipped bool, t
 	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBytes))
 	req.Header.Set(sapmprotocol.ContentTypeHeaderName, sapmprotocol.ContentTypeHeaderValue)
 
-	// set headers for gzip
-	if zipped {
+	// set headers for compressed payloads
+	switch encoding {
+	case sapmprotocol.GZipEncodingHeaderValue:
 		req.Header.Set(sapmprotocol.ContentEncodingHeaderName, sapmprotocol.GZipEncodingHeaderValue)
 		req.Header.Set(sapmprotocol.AcceptEncodingHeaderName, sapmprotocol.GZipEncodingHeaderValue)
+	case zstdEncodingHeaderValue:
+		req.Header.Set(sapmprotocol.ContentEncodingHeaderName, zstdEncodingHeaderValue)
 	}
 
 	if token != "" {
@@ -238,10 +247,10 @@ func TestReception(t *testing.T) {
 	tlsAddress := testutil.GetAvailableLocalAddress(t)
 
 	type args struct {
-		config *Config
-		sapm   *splunksapm.PostSpansRequest
-		zipped bool
-		useTLS bool
+		config   *Config
+		sapm     *splunksapm.PostSpansRequest
+		encoding string
+		useTLS   bool
 	}
 	tests := []struct {
 		name string
@@ -257,9 +266,9 @@ func TestReception(t *testing.T) {
 						Endpoint: defaultEndpoint,
 					},
 				},
-				sapm:   &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now, time.Minute*10, time.Second*2)}},
-				zipped: false,
-				useTLS: false,
+				sapm:     &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now, time.Minute*10, time.Second*2)}},
+				encoding: "",
+				useTLS:   false,
 			},
 			want: expectedTraceData(now, nowPlus10min, nowPlus10min2sec),
 		},
@@ -271,9 +280,23 @@ func TestReception(t *testing.T) {
 						Endpoint: defaultEndpoint,
 					},
 				},
-				sapm:   &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now, time.Minute*10, time.Second*2)}},
-				zipped: true,
-				useTLS: false,
+				sapm:     &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now, time.Minute*10, time.Second*2)}},
+				encoding: sapmprotocol.GZipEncodingHeaderValue,
+				useTLS:   false,
+			},
+			want: expectedTraceData(now, nowPlus10min, nowPlus10min2sec),
+		},
+		{
+			name: "receive zstd compressed sapm",
+			args: args{
+				config: &Config{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: defaultEndpoint,
+					},
+				},
+				sapm:     &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now, time.Minute*10, time.Second*2)}},
+				encoding: zstdEncodingHeaderValue,
+				useTLS:   false,
 			},
 			want: expectedTraceData(now, nowPlus10min, nowPlus10min2sec),
 		},
@@ -291,9 +314,9 @@ func TestReception(t *testing.T) {
 						},
 					},
 				},
-				sapm:   &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now, time.Minute*10, time.Second*2)}},
-				zipped: false,
-				useTLS: true,
+				sapm:     &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now, time.Minute*10, time.Second*2)}},
+				encoding: "",
+				useTLS:   true,
 			},
 			want: expectedTraceData(now, nowPlus10min, nowPlus10min2sec),
 		},
@@ -307,7 +330,7 @@ func TestReception(t *testing.T) {
 
 			t.Log("Sending Sapm Request")
 			var resp *http.Response
-			resp, err := sendSapm(tt.args.config.Endpoint, tt.args.sapm, tt.args.zipped, tt.args.useTLS, "")
+			resp, err := sendSapm(tt.args.config.Endpoint, tt.args.sapm, tt.args.encoding, tt.args.useTLS, "")
 			require.NoErrorf(t, err, "should not have failed when sending sapm %v", err)
 			assert.Equal(t, 200, resp.StatusCode)
 			t.Log("SAPM Request Received")
@@ -350,44 +373,79 @@ func TestAccessTokenPassthrough(t *testing.T) {
 			token:                  "MyAccessToken",
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			config := &Config{
-				HTTPServerSettings: confighttp.HTTPServerSettings{
-					Endpoint: defaultEndpoint,
-				},
-				AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
-					AccessTokenPassthrough: tt.accessTokenPassthrough,
-				},
-			}
+	encodings := []string{sapmprotocol.GZipEncodingHeaderValue, zstdEncodingHeaderValue}
+	for _, encoding := range encodings {
+		for _, tt := range tests {
+			t.Run(encoding+" "+tt.name, func(t *testing.T) {
+				config := &Config{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: defaultEndpoint,
+					},
+					AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
+						AccessTokenPassthrough: tt.accessTokenPassthrough,
+					},
+				}
 
-			sapm := &splunksapm.PostSpansRequest{
-				Batches: []*model.Batch{grpcFixture(time.Now().UTC(), time.Minute*10, time.Second*2)},
-			}
+				sapm := &splunksapm.PostSpansRequest{
+					Batches: []*model.Batch{grpcFixture(time.Now().UTC(), time.Minute*10, time.Second*2)},
+				}
 
-			sink := new(exportertest.SinkTraceExporter)
-			sr := setupReceiver(t, config, sink)
-			defer sr.Shutdown(context.Background())
+				sink := new(exportertest.SinkTraceExporter)
+				sr := setupReceiver(t, config, sink)
+				defer sr.Shutdown(context.Background())
+
+				var resp *http.Response
+				resp, err := sendSapm(config.Endpoint, sapm, encoding, false, tt.token)
+				require.NoErrorf(t, err, "should not have failed when sending sapm %v", err)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				got := sink.AllTraces()
+				assert.Equal(t, 1, len(got))
+
+				received := got[0].ResourceSpans()
+				for i := 0; i < received.Len(); i++ {
+					rspan := received.At(i)
+					attrs := rspan.Resource().Attributes()
+					amap, contains := attrs.Get("com.splunk.signalfx.access_token")
+					if tt.accessTokenPassthrough && tt.token != "" {
+						assert.Equal(t, tt.token, amap.StringVal())
+					} else {
+						assert.False(t, contains)
+					}
+				}
+			})
+		}
+	}
+}
 
-			var resp *http.Response
-			resp, err := sendSapm(config.Endpoint, sapm, true, false, tt.token)
-			require.NoErrorf(t, err, "should not have failed when sending sapm %v", err)
-			assert.Equal(t, 200, resp.StatusCode)
+func TestParseTraceV2RequestZstd(t *testing.T) {
+	sapm := &splunksapm.PostSpansRequest{
+		Batches: []*model.Batch{grpcFixture(time.Now().UTC(), time.Minute*10, time.Second*2)},
+	}
+	reqBytes, err := sapm.Marshal()
+	require.NoError(t, err)
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressed := encoder.EncodeAll(reqBytes, nil)
+	encoder.Close()
+
+	newRequest := func(method, contentType string, body []byte) *http.Request {
+		req, _ := http.NewRequest(method, "http://localhost"+sapmprotocol.TraceEndpointV2, bytes.NewReader(body))
+		req.Header.Set(sapmprotocol.ContentTypeHeaderName, contentType)
+		req.Header.Set(sapmprotocol.ContentEncodingHeaderName, zstdEncodingHeaderValue)
+		return req
+	}
 
-			got := sink.AllTraces()
-			assert.Equal(t, 1, len(got))
+	got, err := parseTraceV2Request(newRequest(http.MethodPost, sapmprotocol.ContentTypeHeaderValue, compressed))
+	require.NoError(t, err)
+	assert.Equal(t, sapm, got)
 
-			received := got[0].ResourceSpans()
-			for i := 0; i < received.Len(); i++ {
-				rspan := received.At(i)
-				attrs := rspan.Resource().Attributes()
-				amap, contains := attrs.Get("com.splunk.signalfx.access_token")
-				if tt.accessTokenPassthrough && tt.token != "" {
-					assert.Equal(t, tt.token, amap.StringVal())
-				} else {
-					assert.False(t, contains)
-				}
-			}
-		})
-	}
+	_, err = parseTraceV2Request(newRequest(http.MethodGet, sapmprotocol.ContentTypeHeaderValue, compressed))
+	assert.Equal(t, errBadMethod, err)
+
+	_, err = parseTraceV2Request(newRequest(http.MethodPost, "application/json", compressed))
+	assert.Equal(t, errBadContentType, err)
+
+	_, err = parseTraceV2Request(newRequest(http.MethodPost, sapmprotocol.ContentTypeHeaderValue, reqBytes))
+	assert.Error(t, err)
 }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configerror"
@@ -43,6 +44,15 @@ type Factory struct {
 }
 
 var _ component.ReceiverFactoryOld = (*Factory)(nil)
+var _ component.LogsReceiverFactory = (*Factory)(nil)
+
+// receivers holds the receivers created per configuration so that the
+// metrics and logs pipelines using the same configuration share a single
+// HTTP server.
+var (
+	receiversLock sync.Mutex
+	receivers     = map[*Config]*sfxReceiver{}
+)
 
 // Type gets the type of the Receiver config created by this factory.
 func (f *Factory) Type() configmodels.Type {
@@ -112,6 +122,41 @@ func (f *Factory) CreateMetricsReceiver(
 	consumer consumer.MetricsConsumerOld,
 ) (component.MetricsReceiver, error) {
 
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+
+	r, err := getOrCreateReceiver(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.RegisterMetricsConsumer(consumer)
+
+	return r, nil
+}
+
+// CreateLogsReceiver creates a logs receiver based on provided config.
+func (f *Factory) CreateLogsReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+
+	r, err := getOrCreateReceiver(params.Logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.RegisterLogsConsumer(consumer)
+
+	return r, nil
+}
+
+func getOrCreateReceiver(logger *zap.Logger, cfg configmodels.Receiver) (*sfxReceiver, error) {
 	rCfg := cfg.(*Config)
 
 	err := rCfg.validate()
@@ -119,5 +164,17 @@ func (f *Factory) CreateMetricsReceiver(
 		return nil, err
 	}
 
-	return New(logger, *rCfg, consumer)
+	receiversLock.Lock()
+	defer receiversLock.Unlock()
+
+	r := receivers[rCfg]
+	if r == nil {
+		r, err = newReceiver(logger, *rCfg)
+		if err != nil {
+			return nil, err
+		}
+		receivers[rCfg] = r
+	}
+
+	return r, nil
 }
@@ -34,6 +34,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
@@ -52,6 +53,7 @@ const (
 	responseErrReadBody        = "Failed to read message body"
 	responseErrUnmarshalBody   = "Failed to unmarshal message body"
 	responseErrNextConsumer    = "Internal Server Error"
+	responseErrNoConsumer      = "Data type not supported by this receiver"
 
 	// Centralizing some HTTP and related string constants.
 	protobufContentType       = "application/x-protobuf"
@@ -72,21 +74,26 @@ var (
 	errReadBodyRespBody      = initJSONResponse(responseErrReadBody)
 	errUnmarshalBodyRespBody = initJSONResponse(responseErrUnmarshalBody)
 	errNextConsumerRespBody  = initJSONResponse(responseErrNextConsumer)
+	errNoConsumerRespBody    = initJSONResponse(responseErrNoConsumer)
 )
 
-// sfxReceiver implements the component.MetricsReceiver for SignalFx metric protocol.
+// sfxReceiver implements the component.MetricsReceiver and component.LogsReceiver
+// for SignalFx metric and event protocols. A single instance serves both data
+// types so that the metrics and logs pipelines can share the same endpoint.
 type sfxReceiver struct {
 	sync.Mutex
-	logger       *zap.Logger
-	config       *Config
-	nextConsumer consumer.MetricsConsumerOld
-	server       *http.Server
+	logger          *zap.Logger
+	config          *Config
+	metricsConsumer consumer.MetricsConsumerOld
+	logsConsumer    consumer.LogsConsumer
+	server          *http.Server
 
 	startOnce sync.Once
 	stopOnce  sync.Once
 }
 
 var _ component.MetricsReceiver = (*sfxReceiver)(nil)
+var _ component.LogsReceiver = (*sfxReceiver)(nil)
 
 // New creates the SignalFx receiver with the given configuration.
 func New(
@@ -99,19 +106,44 @@ func New(
 		return nil, errNilNextConsumer
 	}
 
+	r, err := newReceiver(logger, config)
+	if err != nil {
+		return nil, err
+	}
+	r.RegisterMetricsConsumer(nextConsumer)
+
+	return r, nil
+}
+
+// newReceiver creates a SignalFx receiver without any consumer, consumers
+// must be registered before the receiver is started.
+func newReceiver(logger *zap.Logger, config Config) (*sfxReceiver, error) {
 	if config.Endpoint == "" {
 		return nil, errEmptyEndpoint
 	}
 
 	r := &sfxReceiver{
-		logger:       logger,
-		config:       &config,
-		nextConsumer: nextConsumer,
+		logger: logger,
+		config: &config,
 	}
 
 	return r, nil
 }
 
+// RegisterMetricsConsumer sets the consumer of data points received on /v2/datapoint.
+func (r *sfxReceiver) RegisterMetricsConsumer(mc consumer.MetricsConsumerOld) {
+	r.Lock()
+	defer r.Unlock()
+	r.metricsConsumer = mc
+}
+
+// RegisterLogsConsumer sets the consumer of events received on /v2/event.
+func (r *sfxReceiver) RegisterLogsConsumer(lc consumer.LogsConsumer) {
+	r.Lock()
+	defer r.Unlock()
+	r.logsConsumer = lc
+}
+
 // StartMetricsReception tells the receiver to start its processing.
 // By convention the consumer of the received data is set when the receiver
 // instance is created.
@@ -132,7 +164,8 @@ func (r *sfxReceiver) Start(_ context.Context, host component.Host) error {
 		}
 
 		mx := mux.NewRouter()
-		mx.HandleFunc("/v2/datapoint", r.handleReq)
+		mx.HandleFunc("/v2/datapoint", r.handleDatapointReq)
+		mx.HandleFunc("/v2/event", r.handleEventReq)
 
 		r.server = r.config.HTTPServerSettings.ToServer(mx)
 
@@ -164,28 +197,23 @@ func (r *sfxReceiver) Shutdown(context.Context) error {
 	return err
 }
 
-func (r *sfxReceiver) handleReq(resp http.ResponseWriter, req *http.Request) {
-	transport := "http"
-	if r.config.TLSSetting != nil {
-		transport = "https"
-	}
-	ctx := obsreport.ReceiverContext(req.Context(), r.config.Name(), transport, r.config.Name())
-	ctx = obsreport.StartMetricsReceiveOp(ctx, r.config.Name(), transport)
-
+// readBody validates the request headers and returns its decompressed body. If
+// the request is not valid the failure is written to resp and ok is false.
+func (r *sfxReceiver) readBody(ctx context.Context, resp http.ResponseWriter, req *http.Request) ([]byte, bool) {
 	if req.Method != http.MethodPost {
 		r.failRequest(ctx, resp, http.StatusBadRequest, invalidMethodRespBody, nil)
-		return
+		return nil, false
 	}
 
 	if req.Header.Get(httpContentTypeHeader) != protobufContentType {
 		r.failRequest(ctx, resp, http.StatusUnsupportedMediaType, invalidContentRespBody, nil)
-		return
+		return nil, false
 	}
 
 	encoding := req.Header.Get(httpContentEncodingHeader)
 	if encoding != "" && encoding != gzipEncoding {
 		r.failRequest(ctx, resp, http.StatusUnsupportedMediaType, invalidEncodingRespBody, nil)
-		return
+		return nil, false
 	}
 
 	bodyReader := req.Body
@@ -194,18 +222,43 @@ func (r *sfxReceiver) handleReq(resp http.ResponseWriter, req *http.Request) {
 		bodyReader, err = gzip.NewReader(bodyReader)
 		if err != nil {
 			r.failRequest(ctx, resp, http.StatusBadRequest, errGzipReaderRespBody, err)
-			return
+			return nil, false
 		}
 	}
 
 	body, err := ioutil.ReadAll(bodyReader)
 	if err != nil {
 		r.failRequest(ctx, resp, http.StatusBadRequest, errReadBodyRespBody, err)
+		return nil, false
+	}
+
+	return body, true
+}
+
+func (r *sfxReceiver) transport() string {
+	if r.config.TLSSetting != nil {
+		return "https"
+	}
+	return "http"
+}
+
+func (r *sfxReceiver) handleDatapointReq(resp http.ResponseWriter, req *http.Request) {
+	transport := r.transport()
+	ctx := obsreport.ReceiverContext(req.Context(), r.config.Name(), transport, r.config.Name())
+	ctx = obsreport.StartMetricsReceiveOp(ctx, r.config.Name(), transport)
+
+	if r.metricsConsumer == nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errNoConsumerRespBody, nil)
+		return
+	}
+
+	body, ok := r.readBody(ctx, resp, req)
+	if !ok {
 		return
 	}
 
 	msg := &sfxpb.DataPointUploadMessage{}
-	if err = msg.Unmarshal(body); err != nil {
+	if err := msg.Unmarshal(body); err != nil {
 		r.failRequest(ctx, resp, http.StatusBadRequest, errUnmarshalBodyRespBody, err)
 		return
 	}
@@ -230,7 +283,7 @@ func (r *sfxReceiver) handleReq(resp http.ResponseWriter, req *http.Request) {
 		}
 	}
 
-	err = r.nextConsumer.ConsumeMetricsData(ctx, *md)
+	err := r.metricsConsumer.ConsumeMetricsData(ctx, *md)
 	obsreport.EndMetricsReceiveOp(
 		ctx,
 		typeStr,
@@ -246,6 +299,67 @@ func (r *sfxReceiver) handleReq(resp http.ResponseWriter, req *http.Request) {
 	resp.Write(okRespBody)
 }
 
+func (r *sfxReceiver) handleEventReq(resp http.ResponseWriter, req *http.Request) {
+	transport := r.transport()
+	ctx := obsreport.ReceiverContext(req.Context(), r.config.Name(), transport, r.config.Name())
+	ctx = obsreport.StartLogsReceiveOp(ctx, r.config.Name(), transport)
+
+	r.Lock()
+	logsConsumer := r.logsConsumer
+	r.Unlock()
+
+	if logsConsumer == nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errNoConsumerRespBody, nil)
+		return
+	}
+
+	body, ok := r.readBody(ctx, resp, req)
+	if !ok {
+		return
+	}
+
+	msg := &sfxpb.EventUploadMessage{}
+	if err := msg.Unmarshal(body); err != nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errUnmarshalBodyRespBody, err)
+		return
+	}
+
+	if len(msg.Events) == 0 {
+		obsreport.EndLogsReceiveOp(ctx, typeStr, 0, nil)
+		resp.Write(okRespBody)
+		return
+	}
+
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rl := rls.At(0)
+	rl.InitEmpty()
+	ills := rl.InstrumentationLibraryLogs()
+	ills.Resize(1)
+	ill := ills.At(0)
+	ill.InitEmpty()
+
+	signalFxV2EventsToLogRecords(msg.Events, ill.Logs())
+
+	if r.config.AccessTokenPassthrough {
+		if accessToken := req.Header.Get(splunk.SFxAccessTokenHeader); accessToken != "" {
+			rl.Resource().InitEmpty()
+			rl.Resource().Attributes().InsertString(splunk.SFxAccessTokenLabel, accessToken)
+		}
+	}
+
+	err := logsConsumer.ConsumeLogs(ctx, ld)
+	obsreport.EndLogsReceiveOp(ctx, typeStr, len(msg.Events), err)
+	if err != nil {
+		r.failRequest(ctx, resp, http.StatusInternalServerError, errNextConsumerRespBody, err)
+		return
+	}
+
+	resp.WriteHeader(http.StatusAccepted)
+	resp.Write(okRespBody)
+}
+
 func (r *sfxReceiver) failRequest(
 	ctx context.Context,
 	resp http.ResponseWriter,
@@ -43,6 +43,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/pdatautil"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/testutil"
@@ -167,7 +168,7 @@ func Test_signalfxeceiver_EndToEnd(t *testing.T) {
 	assert.Equal(t, componenterror.ErrAlreadyStopped, r.Shutdown(context.Background()))
 }
 
-func Test_sfxReceiver_handleReq(t *testing.T) {
+func Test_sfxReceiver_handleDatapointReq(t *testing.T) {
 	config := (&Factory{}).CreateDefaultConfig().(*Config)
 	config.Endpoint = "localhost:0" // Actually not creating the endpoint
 
@@ -311,7 +312,7 @@ func Test_sfxReceiver_handleReq(t *testing.T) {
 
 			r := rcv.(*sfxReceiver)
 			w := httptest.NewRecorder()
-			r.handleReq(w, tt.req)
+			r.handleDatapointReq(w, tt.req)
 
 			resp := w.Result()
 			respBytes, err := ioutil.ReadAll(resp.Body)
@@ -475,7 +476,7 @@ func Test_sfxReceiver_AccessTokenPassthrough(t *testing.T) {
 
 			r := rcv.(*sfxReceiver)
 			w := httptest.NewRecorder()
-			r.handleReq(w, req)
+			r.handleDatapointReq(w, req)
 
 			resp := w.Result()
 			respBytes, err := ioutil.ReadAll(resp.Body)
@@ -504,6 +505,174 @@ func Test_sfxReceiver_AccessTokenPassthrough(t *testing.T) {
 	}
 }
 
+type logsSink struct {
+	logs []pdata.Logs
+	err  error
+}
+
+var _ consumer.LogsConsumer = (*logsSink)(nil)
+
+func (s *logsSink) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.logs = append(s.logs, ld)
+	return nil
+}
+
+func Test_sfxReceiver_handleEventReq(t *testing.T) {
+	config := (&Factory{}).CreateDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	config.AccessTokenPassthrough = true
+
+	currentTime := time.Now().Unix() * 1e3
+	sFxMsg := buildSFxEventMsg(currentTime, 3)
+	msgBytes, err := sFxMsg.Marshal()
+	require.NoError(t, err)
+
+	newReq := func() *http.Request {
+		req := httptest.NewRequest("POST", "http://localhost/v2/event", bytes.NewReader(msgBytes))
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		req.Header.Set("x-sf-token", "myToken")
+		return req
+	}
+
+	tests := []struct {
+		name       string
+		consumer   *logsSink
+		req        *http.Request
+		wantStatus int
+		wantBody   string
+		wantLogs   int
+	}{
+		{
+			name:       "no_logs_consumer",
+			req:        newReq(),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   responseErrNoConsumer,
+		},
+		{
+			name:       "incorrect_method",
+			consumer:   &logsSink{},
+			req:        httptest.NewRequest("PUT", "http://localhost/v2/event", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   responseInvalidMethod,
+		},
+		{
+			name:     "bad_data_in_body",
+			consumer: &logsSink{},
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost/v2/event", bytes.NewReader([]byte{1, 2, 3, 4}))
+				req.Header.Set("Content-Type", "application/x-protobuf")
+				return req
+			}(),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   responseErrUnmarshalBody,
+		},
+		{
+			name:       "next_consumer_error",
+			consumer:   &logsSink{err: errors.New("boom")},
+			req:        newReq(),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   responseErrNextConsumer,
+		},
+		{
+			name:       "msg_accepted",
+			consumer:   &logsSink{},
+			req:        newReq(),
+			wantStatus: http.StatusAccepted,
+			wantBody:   responseOK,
+			wantLogs:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newReceiver(zap.NewNop(), *config)
+			require.NoError(t, err)
+			if tt.consumer != nil {
+				r.RegisterLogsConsumer(tt.consumer)
+			}
+
+			w := httptest.NewRecorder()
+			r.handleEventReq(w, tt.req)
+
+			resp := w.Result()
+			respBytes, err := ioutil.ReadAll(resp.Body)
+			assert.NoError(t, err)
+
+			var bodyStr string
+			assert.NoError(t, json.Unmarshal(respBytes, &bodyStr))
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantBody, bodyStr)
+
+			if tt.wantLogs == 0 {
+				return
+			}
+			require.Len(t, tt.consumer.logs, tt.wantLogs)
+			rl := tt.consumer.logs[0].ResourceLogs().At(0)
+			token, ok := rl.Resource().Attributes().Get("com.splunk.signalfx.access_token")
+			require.True(t, ok)
+			assert.Equal(t, "myToken", token.StringVal())
+			assert.Equal(t, 1, rl.InstrumentationLibraryLogs().At(0).Logs().Len())
+		})
+	}
+}
+
+func Test_sfxReceiver_metricsAndLogsShareReceiver(t *testing.T) {
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	metricsSink := new(exportertest.SinkMetricsExporterOld)
+	mr, err := factory.CreateMetricsReceiver(context.Background(), zap.NewNop(), cfg, metricsSink)
+	require.NoError(t, err)
+	logsSink := &logsSink{}
+	lr, err := factory.CreateLogsReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, logsSink)
+	require.NoError(t, err)
+	require.Same(t, mr, lr)
+
+	require.NoError(t, mr.Start(context.Background(), componenttest.NewNopHost()))
+	defer mr.Shutdown(context.Background())
+	require.Equal(t, componenterror.ErrAlreadyStarted, lr.Start(context.Background(), componenttest.NewNopHost()))
+
+	post := func(path string, msg interface{ Marshal() ([]byte, error) }) {
+		body, err := msg.Marshal()
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "http://"+cfg.Endpoint+path, bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	}
+
+	currentTime := time.Now().Unix() * 1e3
+	post("/v2/datapoint", buildSFxMsg(currentTime, 13, 3))
+	post("/v2/event", buildSFxEventMsg(currentTime, 3))
+
+	assert.Equal(t, 1, len(metricsSink.AllMetrics()))
+	assert.Equal(t, 1, len(logsSink.logs))
+}
+
+func buildSFxEventMsg(time int64, dimensions uint) *sfxpb.EventUploadMessage {
+	return &sfxpb.EventUploadMessage{
+		Events: []*sfxpb.Event{
+			{
+				EventType:  "single",
+				Timestamp:  time,
+				Category:   sfxCategoryPtr(sfxpb.EventCategory_USER_DEFINED),
+				Dimensions: buildNDimensions(dimensions),
+			},
+		},
+	}
+}
+
+func sfxCategoryPtr(c sfxpb.EventCategory) *sfxpb.EventCategory {
+	return &c
+}
+
 func buildSFxMsg(time int64, value int64, dimensions uint) *sfxpb.DataPointUploadMessage {
 	return &sfxpb.DataPointUploadMessage{
 		Datapoints: []*sfxpb.DataPoint{
@@ -0,0 +1,79 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxreceiver
+
+import (
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/splunk"
+)
+
+// signalFxV2EventsToLogRecords converts SignalFx event proto data points to
+// log records appended to lrs. The event type becomes the record name and
+// the SFxEventType attribute, the dimensions and properties become attributes.
+func signalFxV2EventsToLogRecords(events []*sfxpb.Event, lrs pdata.LogSlice) {
+	start := lrs.Len()
+	lrs.Resize(start + len(events))
+
+	i := start
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+
+		lr := lrs.At(i)
+		i++
+
+		lr.SetName(event.GetEventType())
+		// SignalFx timestamps are in milliseconds.
+		lr.SetTimestamp(pdata.TimestampUnixNano(event.GetTimestamp() * 1e6))
+
+		attrs := lr.Attributes()
+		attrs.InitEmptyWithCapacity(len(event.Dimensions) + len(event.Properties) + 2)
+		attrs.InsertString(splunk.SFxEventType, event.GetEventType())
+
+		for _, dim := range event.Dimensions {
+			if dim == nil {
+				continue
+			}
+			attrs.InsertString(dim.Key, dim.Value)
+		}
+
+		if event.Category != nil {
+			attrs.InsertInt(splunk.SFxEventCategoryKey, int64(*event.Category))
+		}
+
+		for _, prop := range event.Properties {
+			if prop == nil || prop.Value == nil {
+				continue
+			}
+			key := splunk.SFxEventPropertyPrefix + prop.Key
+			switch v := prop.Value; {
+			case v.StrValue != nil:
+				attrs.InsertString(key, *v.StrValue)
+			case v.IntValue != nil:
+				attrs.InsertInt(key, *v.IntValue)
+			case v.DoubleValue != nil:
+				attrs.InsertDouble(key, *v.DoubleValue)
+			case v.BoolValue != nil:
+				attrs.InsertBool(key, *v.BoolValue)
+			}
+		}
+	}
+
+	// Drop the slots reserved for nil events.
+	lrs.Resize(i)
+}
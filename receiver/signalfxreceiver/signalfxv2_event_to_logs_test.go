@@ -0,0 +1,88 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxreceiver
+
+import (
+	"testing"
+	"time"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestSignalFxV2EventsToLogRecords(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	msec := now.UnixNano() / int64(time.Millisecond)
+
+	events := []*sfxpb.Event{
+		{
+			EventType: "shutdown",
+			Timestamp: msec,
+			Category:  sfxCategoryPtr(sfxpb.EventCategory_USER_DEFINED),
+			Dimensions: []*sfxpb.Dimension{
+				{Key: "host", Value: "localhost"},
+				nil,
+			},
+			Properties: []*sfxpb.Property{
+				{Key: "str", Value: &sfxpb.PropertyValue{StrValue: strPtr("s")}},
+				{Key: "int", Value: &sfxpb.PropertyValue{IntValue: int64Ptr(13)}},
+				{Key: "double", Value: &sfxpb.PropertyValue{DoubleValue: float64Ptr(1.5)}},
+				{Key: "bool", Value: &sfxpb.PropertyValue{BoolValue: boolPtr(true)}},
+				{Key: "nil"},
+			},
+		},
+		nil,
+		{
+			EventType: "no_category",
+			Timestamp: msec,
+		},
+	}
+
+	lrs := pdata.NewLogSlice()
+	signalFxV2EventsToLogRecords(events, lrs)
+	require.Equal(t, 2, lrs.Len())
+
+	lr := lrs.At(0)
+	assert.Equal(t, "shutdown", lr.Name())
+	assert.Equal(t, pdata.TimestampUnixNano(now.UnixNano()), lr.Timestamp())
+
+	attrs := lr.Attributes()
+	assert.Equal(t, 7, attrs.Len())
+	v, _ := attrs.Get("com.splunk.signalfx.event_type")
+	assert.Equal(t, "shutdown", v.StringVal())
+	v, _ = attrs.Get("host")
+	assert.Equal(t, "localhost", v.StringVal())
+	v, _ = attrs.Get("com.splunk.signalfx.event_category")
+	assert.Equal(t, int64(sfxpb.EventCategory_USER_DEFINED), v.IntVal())
+	v, _ = attrs.Get("com.splunk.signalfx.event_properties.str")
+	assert.Equal(t, "s", v.StringVal())
+	v, _ = attrs.Get("com.splunk.signalfx.event_properties.int")
+	assert.Equal(t, int64(13), v.IntVal())
+	v, _ = attrs.Get("com.splunk.signalfx.event_properties.double")
+	assert.Equal(t, 1.5, v.DoubleVal())
+	v, _ = attrs.Get("com.splunk.signalfx.event_properties.bool")
+	assert.True(t, v.BoolVal())
+
+	lr = lrs.At(1)
+	assert.Equal(t, "no_category", lr.Name())
+	_, ok := lr.Attributes().Get("com.splunk.signalfx.event_category")
+	assert.False(t, ok)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,139 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Value types of the metrics.
+const (
+	valueTypeInt    = "int"
+	valueTypeDouble = "double"
+)
+
+// Data types of the metrics.
+const (
+	dataTypeGauge = "gauge"
+	dataTypeSum   = "sum"
+)
+
+// Config defines configuration for the SQL query receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Driver is the name of the database/sql driver, e.g. postgres or mysql.
+	Driver string `mapstructure:"driver"`
+
+	// DataSource is the driver specific connection string.
+	DataSource string `mapstructure:"datasource"`
+
+	// CollectionInterval is the interval at which the queries are run.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+
+	// Queries are the queries to run and the metrics built from their results.
+	Queries []Query `mapstructure:"queries"`
+}
+
+// Query is a SQL query whose rows are converted to metrics.
+type Query struct {
+	// SQL is the query to run.
+	SQL string `mapstructure:"sql"`
+
+	// Metrics are the metrics built from every row of the result.
+	Metrics []MetricCfg `mapstructure:"metrics"`
+}
+
+// MetricCfg maps the columns of a row to a metric.
+type MetricCfg struct {
+	// MetricName is the name of the metric.
+	MetricName string `mapstructure:"metric_name"`
+
+	// ValueColumn is the column holding the value of the metric.
+	ValueColumn string `mapstructure:"value_column"`
+
+	// AttributeColumns are the columns whose values become the labels of the
+	// metric, the labels are named after the columns.
+	AttributeColumns []string `mapstructure:"attribute_columns"`
+
+	// DataType is either gauge, the default, or sum.
+	DataType string `mapstructure:"data_type"`
+
+	// ValueType is either int, the default, or double.
+	ValueType string `mapstructure:"value_type"`
+
+	// IsMonotonic marks a sum as cumulative, non monotonic sums are reported
+	// as gauges.
+	IsMonotonic bool `mapstructure:"is_monotonic"`
+
+	// Unit is the unit of the metric.
+	Unit string `mapstructure:"unit"`
+
+	// Description is the description of the metric.
+	Description string `mapstructure:"description"`
+}
+
+func (c *Config) validate() error {
+	if c.Driver == "" {
+		return errors.New("driver must be specified")
+	}
+	if c.DataSource == "" {
+		return errors.New("datasource must be specified")
+	}
+	if c.CollectionInterval <= 0 {
+		return errors.New("collection_interval must be positive")
+	}
+	if len(c.Queries) == 0 {
+		return errors.New("at least one query must be specified")
+	}
+	for i, q := range c.Queries {
+		if q.SQL == "" {
+			return fmt.Errorf("queries[%d]: sql must be specified", i)
+		}
+		if len(q.Metrics) == 0 {
+			return fmt.Errorf("queries[%d]: at least one metric must be specified", i)
+		}
+		for j, m := range q.Metrics {
+			if err := m.validate(); err != nil {
+				return fmt.Errorf("queries[%d].metrics[%d]: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MetricCfg) validate() error {
+	if m.MetricName == "" {
+		return errors.New("metric_name must be specified")
+	}
+	if m.ValueColumn == "" {
+		return errors.New("value_column must be specified")
+	}
+	switch m.DataType {
+	case "", dataTypeGauge, dataTypeSum:
+	default:
+		return fmt.Errorf("unsupported data_type %q, must be %q or %q", m.DataType, dataTypeGauge, dataTypeSum)
+	}
+	switch m.ValueType {
+	case "", valueTypeInt, valueTypeDouble:
+	default:
+		return fmt.Errorf("unsupported value_type %q, must be %q or %q", m.ValueType, valueTypeInt, valueTypeDouble)
+	}
+	return nil
+}
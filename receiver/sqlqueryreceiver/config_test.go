@@ -0,0 +1,106 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r1 := cfg.Receivers["sqlquery"]
+	assert.Equal(t, r1, factory.CreateDefaultConfig())
+
+	r2 := cfg.Receivers["sqlquery/movies"].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "sqlquery/movies",
+			},
+			Driver:             "postgres",
+			DataSource:         "host=localhost port=5432 user=otel password=otel dbname=movies sslmode=disable",
+			CollectionInterval: 60 * time.Second,
+			Queries: []Query{
+				{
+					SQL: "SELECT count(*) AS count, genre FROM movie GROUP BY genre",
+					Metrics: []MetricCfg{{
+						MetricName:       "movie.genre.count",
+						ValueColumn:      "count",
+						AttributeColumns: []string{"genre"},
+						Unit:             "1",
+					}},
+				},
+				{
+					SQL: "SELECT sum(duration) AS total FROM movie",
+					Metrics: []MetricCfg{{
+						MetricName:  "movie.duration.total",
+						ValueColumn: "total",
+						DataType:    "sum",
+						IsMonotonic: true,
+						ValueType:   "double",
+						Description: "Total duration of the movies.",
+					}},
+				},
+			},
+		})
+	assert.NoError(t, r2.validate())
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{"no driver", func(c *Config) { c.Driver = "" }, "driver must be specified"},
+		{"no datasource", func(c *Config) { c.DataSource = "" }, "datasource must be specified"},
+		{"no queries", func(c *Config) { c.Queries = nil }, "at least one query must be specified"},
+		{"no sql", func(c *Config) { c.Queries[0].SQL = "" }, "queries[0]: sql must be specified"},
+		{"no metrics", func(c *Config) { c.Queries[1].Metrics = nil }, "queries[1]: at least one metric must be specified"},
+		{"no value column", func(c *Config) { c.Queries[1].Metrics[1].ValueColumn = "" }, "queries[1].metrics[1]: value_column must be specified"},
+		{"bad data type", func(c *Config) { c.Queries[0].Metrics[0].DataType = "histogram" }, `queries[0].metrics[0]: unsupported data_type "histogram", must be "gauge" or "sum"`},
+		{"bad value type", func(c *Config) { c.Queries[0].Metrics[0].ValueType = "string" }, `queries[0].metrics[0]: unsupported value_type "string", must be "int" or "double"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			require.NoError(t, cfg.validate())
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryRows(t *testing.T) {
+	db, err := sql.Open(fakeDriverName, "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows, err := queryRows(context.Background(), db, "SELECT count(*) AS count, genre FROM movie GROUP BY genre")
+	require.NoError(t, err)
+	assert.Equal(t, []row{
+		{"count": "3", "genre": "sci-fi"},
+		{"count": "2", "genre": "drama"},
+		{"count": "1"},
+	}, rows)
+
+	rows, err = queryRows(context.Background(), db, "SELECT sum(duration) AS total, avg(rating) AS rating FROM movie")
+	require.NoError(t, err)
+	assert.Equal(t, []row{{"total": "720", "rating": "7.5"}}, rows)
+
+	_, err = queryRows(context.Background(), db, "SELECT * FROM unknown")
+	assert.Error(t, err)
+}
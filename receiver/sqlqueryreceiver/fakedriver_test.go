@@ -0,0 +1,122 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+)
+
+// fakeDriverName is the name of a database/sql driver answering the queries
+// of fakeResults, the data source is ignored.
+const fakeDriverName = "sqlqueryfake"
+
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+var fakeResults = map[string]fakeResult{
+	"SELECT count(*) AS count, genre FROM movie GROUP BY genre": {
+		columns: []string{"count", "genre"},
+		rows: [][]driver.Value{
+			{int64(3), "sci-fi"},
+			{int64(2), "drama"},
+			{int64(1), nil},
+		},
+	},
+	"SELECT sum(duration) AS total, avg(rating) AS rating FROM movie": {
+		columns: []string{"total", "rating"},
+		rows: [][]driver.Value{
+			{int64(720), float64(7.5)},
+		},
+	},
+	"SELECT 'abc' AS value": {
+		columns: []string{"value"},
+		rows:    [][]driver.Value{{[]byte("abc")}},
+	},
+}
+
+func init() {
+	sql.Register(fakeDriverName, fakeDriver{})
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	result, ok := fakeResults[query]
+	if !ok {
+		return nil, fmt.Errorf("unknown query %q", query)
+	}
+	return &fakeStmt{result: result}, nil
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions are not supported")
+}
+
+type fakeStmt struct {
+	result fakeResult
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return 0
+}
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("exec is not supported")
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{result: s.result}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	next   int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.result.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
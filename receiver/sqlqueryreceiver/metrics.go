@@ -0,0 +1,110 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"fmt"
+	"strconv"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// buildMetrics converts the rows of a query to a metric per configured
+// metric with a time series per row. The rows missing a column or holding a
+// value that cannot be parsed are skipped and reported as errors.
+func buildMetrics(q *Query, rows []row, ts *timestamp.Timestamp) ([]*metricspb.Metric, []error) {
+	var errs []error
+	metrics := make([]*metricspb.Metric, 0, len(q.Metrics))
+	for i := range q.Metrics {
+		cfg := &q.Metrics[i]
+		metric := newMetric(cfg)
+		for _, r := range rows {
+			series, err := newTimeSeries(cfg, r, ts)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("metric %s: %w", cfg.MetricName, err))
+				continue
+			}
+			metric.Timeseries = append(metric.Timeseries, series)
+		}
+		if len(metric.Timeseries) > 0 {
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics, errs
+}
+
+func newMetric(cfg *MetricCfg) *metricspb.Metric {
+	keys := make([]*metricspb.LabelKey, 0, len(cfg.AttributeColumns))
+	for _, column := range cfg.AttributeColumns {
+		keys = append(keys, &metricspb.LabelKey{Key: column})
+	}
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        cfg.MetricName,
+			Description: cfg.Description,
+			Unit:        cfg.Unit,
+			Type:        metricType(cfg),
+			LabelKeys:   keys,
+		},
+	}
+}
+
+func metricType(cfg *MetricCfg) metricspb.MetricDescriptor_Type {
+	cumulative := cfg.DataType == dataTypeSum && cfg.IsMonotonic
+	switch {
+	case cfg.ValueType == valueTypeDouble && cumulative:
+		return metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
+	case cfg.ValueType == valueTypeDouble:
+		return metricspb.MetricDescriptor_GAUGE_DOUBLE
+	case cumulative:
+		return metricspb.MetricDescriptor_CUMULATIVE_INT64
+	default:
+		return metricspb.MetricDescriptor_GAUGE_INT64
+	}
+}
+
+func newTimeSeries(cfg *MetricCfg, r row, ts *timestamp.Timestamp) (*metricspb.TimeSeries, error) {
+	raw, ok := r[cfg.ValueColumn]
+	if !ok {
+		return nil, fmt.Errorf("value column %q is missing or null", cfg.ValueColumn)
+	}
+
+	point := &metricspb.Point{Timestamp: ts}
+	if cfg.ValueType == valueTypeDouble {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value column %q: %w", cfg.ValueColumn, err)
+		}
+		point.Value = &metricspb.Point_DoubleValue{DoubleValue: v}
+	} else {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value column %q: %w", cfg.ValueColumn, err)
+		}
+		point.Value = &metricspb.Point_Int64Value{Int64Value: v}
+	}
+
+	values := make([]*metricspb.LabelValue, 0, len(cfg.AttributeColumns))
+	for _, column := range cfg.AttributeColumns {
+		v, ok := r[column]
+		values = append(values, &metricspb.LabelValue{Value: v, HasValue: ok})
+	}
+
+	return &metricspb.TimeSeries{
+		LabelValues: values,
+		Points:      []*metricspb.Point{point},
+	}, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMetrics(t *testing.T) {
+	q := &Query{
+		Metrics: []MetricCfg{
+			{
+				MetricName:       "movie.genre.count",
+				ValueColumn:      "count",
+				AttributeColumns: []string{"genre"},
+				Unit:             "1",
+			},
+			{
+				MetricName:  "movie.genre.count.total",
+				ValueColumn: "count",
+				DataType:    dataTypeSum,
+				IsMonotonic: true,
+				ValueType:   valueTypeDouble,
+			},
+		},
+	}
+	rows := []row{
+		{"count": "3", "genre": "sci-fi"},
+		{"count": "1"},
+	}
+	ts := ptypes.TimestampNow()
+
+	metrics, errs := buildMetrics(q, rows, ts)
+	assert.Empty(t, errs)
+	require.Len(t, metrics, 2)
+
+	count := metrics[0]
+	assert.Equal(t, &metricspb.MetricDescriptor{
+		Name:      "movie.genre.count",
+		Unit:      "1",
+		Type:      metricspb.MetricDescriptor_GAUGE_INT64,
+		LabelKeys: []*metricspb.LabelKey{{Key: "genre"}},
+	}, count.MetricDescriptor)
+	require.Len(t, count.Timeseries, 2)
+	assert.Equal(t, []*metricspb.LabelValue{{Value: "sci-fi", HasValue: true}}, count.Timeseries[0].LabelValues)
+	assert.Equal(t, int64(3), count.Timeseries[0].Points[0].GetInt64Value())
+	assert.Equal(t, ts, count.Timeseries[0].Points[0].Timestamp)
+	assert.Equal(t, []*metricspb.LabelValue{{HasValue: false}}, count.Timeseries[1].LabelValues)
+
+	total := metrics[1]
+	assert.Equal(t, metricspb.MetricDescriptor_CUMULATIVE_DOUBLE, total.MetricDescriptor.Type)
+	assert.Equal(t, 3.0, total.Timeseries[0].Points[0].GetDoubleValue())
+}
+
+func TestBuildMetricsInvalidRows(t *testing.T) {
+	q := &Query{
+		Metrics: []MetricCfg{{MetricName: "value", ValueColumn: "value"}},
+	}
+	rows := []row{
+		{"value": "abc"},
+		{"other": "1"},
+		{"value": "2"},
+	}
+
+	metrics, errs := buildMetrics(q, rows, ptypes.TimestampNow())
+	assert.Len(t, errs, 2)
+	require.Len(t, metrics, 1)
+	require.Len(t, metrics[0].Timeseries, 1)
+	assert.Equal(t, int64(2), metrics[0].Timeseries[0].Points[0].GetInt64Value())
+
+	metrics, errs = buildMetrics(q, rows[:1], ptypes.TimestampNow())
+	assert.Len(t, errs, 1)
+	assert.Empty(t, metrics)
+}
+
+func TestMetricType(t *testing.T) {
+	tests := []struct {
+		cfg      MetricCfg
+		expected metricspb.MetricDescriptor_Type
+	}{
+		{MetricCfg{}, metricspb.MetricDescriptor_GAUGE_INT64},
+		{MetricCfg{ValueType: valueTypeDouble}, metricspb.MetricDescriptor_GAUGE_DOUBLE},
+		{MetricCfg{DataType: dataTypeSum, IsMonotonic: true}, metricspb.MetricDescriptor_CUMULATIVE_INT64},
+		{MetricCfg{DataType: dataTypeSum, IsMonotonic: true, ValueType: valueTypeDouble}, metricspb.MetricDescriptor_CUMULATIVE_DOUBLE},
+		{MetricCfg{DataType: dataTypeSum}, metricspb.MetricDescriptor_GAUGE_INT64},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, metricType(&tt.cfg))
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "sql"
+
+var _ component.MetricsReceiver = (*sqlQueryReceiver)(nil)
+
+// sqlQueryReceiver implements the component.MetricsReceiver for SQL queries.
+type sqlQueryReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	db           *sql.DB
+	cancel       context.CancelFunc
+}
+
+// newSQLQueryReceiver creates the SQL query receiver with the given parameters.
+func newSQLQueryReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	r := &sqlQueryReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+	}
+	return r, nil
+}
+
+// Start opens the database and begins running the queries.
+func (sqr *sqlQueryReceiver) Start(ctx context.Context, host component.Host) error {
+	// sql.Open only validates its arguments, the connections are established
+	// by the queries so that an unavailable database does not fail the start.
+	db, err := sql.Open(sqr.config.Driver, sqr.config.DataSource)
+	if err != nil {
+		return err
+	}
+	sqr.db = db
+
+	ctx, sqr.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, sqr.config.Name()))
+	go func() {
+		ticker := time.NewTicker(sqr.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				sqr.collect(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the sqlquery receiver and closes the database.
+func (sqr *sqlQueryReceiver) Shutdown(context.Context) error {
+	if sqr.cancel != nil {
+		sqr.cancel()
+	}
+	if sqr.db != nil {
+		return sqr.db.Close()
+	}
+	return nil
+}
+
+// collect runs every query and sends the metrics built from their results.
+func (sqr *sqlQueryReceiver) collect(ctx context.Context) {
+	ts := ptypes.TimestampNow()
+	md := consumerdata.MetricsData{}
+	for i := range sqr.config.Queries {
+		q := &sqr.config.Queries[i]
+		rows, err := queryRows(ctx, sqr.db, q.SQL)
+		if err != nil {
+			sqr.logger.Warn("Failed to run query", zap.String("sql", q.SQL), zap.Error(err))
+			continue
+		}
+
+		metrics, errs := buildMetrics(q, rows, ts)
+		for _, err := range errs {
+			sqr.logger.Debug("Failed to convert row", zap.String("sql", q.SQL), zap.Error(err))
+		}
+		md.Metrics = append(md.Metrics, metrics...)
+	}
+	if len(md.Metrics) == 0 {
+		return
+	}
+
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(md)
+	err := sqr.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md}))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+}
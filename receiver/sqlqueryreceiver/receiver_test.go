@@ -0,0 +1,91 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func testConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Driver = fakeDriverName
+	cfg.DataSource = "movies"
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.Queries = []Query{
+		{
+			SQL: "SELECT count(*) AS count, genre FROM movie GROUP BY genre",
+			Metrics: []MetricCfg{{
+				MetricName:       "movie.genre.count",
+				ValueColumn:      "count",
+				AttributeColumns: []string{"genre"},
+			}},
+		},
+		{
+			SQL: "SELECT sum(duration) AS total, avg(rating) AS rating FROM movie",
+			Metrics: []MetricCfg{
+				{MetricName: "movie.duration.total", ValueColumn: "total", DataType: dataTypeSum, IsMonotonic: true, Unit: "min"},
+				{MetricName: "movie.rating.avg", ValueColumn: "rating", ValueType: valueTypeDouble},
+			},
+		},
+		{
+			SQL:     "SELECT * FROM unknown",
+			Metrics: []MetricCfg{{MetricName: "unknown", ValueColumn: "value"}},
+		},
+	}
+	return cfg
+}
+
+func TestReceiver(t *testing.T) {
+	sink := new(exportertest.SinkMetricsExporter)
+	metricsReceiver, err := newSQLQueryReceiver(zap.NewNop(), testConfig(), sink)
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+
+	ctx := context.Background()
+	require.NoError(t, metricsReceiver.Start(ctx, componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, metricsReceiver.Shutdown(ctx))
+
+	mds := pdatautil.MetricsToMetricsData(sink.AllMetrics()[0])
+	require.Len(t, mds, 1)
+	metrics := mds[0].Metrics
+	require.Len(t, metrics, 3)
+	assert.Equal(t, "movie.genre.count", metrics[0].MetricDescriptor.Name)
+	assert.Len(t, metrics[0].Timeseries, 3)
+	assert.Equal(t, "movie.duration.total", metrics[1].MetricDescriptor.Name)
+	assert.Equal(t, int64(720), metrics[1].Timeseries[0].Points[0].GetInt64Value())
+	assert.Equal(t, "movie.rating.avg", metrics[2].MetricDescriptor.Name)
+	assert.Equal(t, 7.5, metrics[2].Timeseries[0].Points[0].GetDoubleValue())
+}
+
+func TestReceiverUnknownDriver(t *testing.T) {
+	cfg := testConfig()
+	cfg.Driver = "unknown"
+	metricsReceiver, err := newSQLQueryReceiver(zap.NewNop(), cfg, exportertest.NewNopMetricsExporter())
+	require.NoError(t, err)
+	assert.Error(t, metricsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, metricsReceiver.Shutdown(context.Background()))
+}
@@ -0,0 +1,54 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcplogreceiver
+
+import (
+	"errors"
+	"net"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+// Config defines configuration for the tcplog receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Endpoint is the address to listen on, e.g. 0.0.0.0:54525.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// TLS configures the TLS server, the connections are not encrypted if
+	// it is not set.
+	TLS *configtls.TLSServerSetting `mapstructure:"tls"`
+
+	// MaxLogSize is the maximum size in bytes of a log line, longer lines
+	// close the connection.
+	MaxLogSize int `mapstructure:"max_log_size"`
+
+	// Config configures how the lines are decoded and parsed.
+	logparser.Config `mapstructure:",squash"`
+}
+
+func (c *Config) validate() error {
+	if _, _, err := net.SplitHostPort(c.Endpoint); err != nil {
+		return err
+	}
+	if c.MaxLogSize <= 0 {
+		return errors.New("max_log_size must be positive")
+	}
+	return c.Config.Validate()
+}
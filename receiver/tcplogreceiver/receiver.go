@@ -0,0 +1,183 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcplogreceiver
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+const transport = "tcp"
+
+var _ component.LogsReceiver = (*tcpLogReceiver)(nil)
+
+// tcpLogReceiver implements the component.LogsReceiver for logs received on
+// a TCP socket.
+type tcpLogReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.LogsConsumer
+	config       *Config
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+// newTCPLogReceiver creates the tcplog receiver with the given parameters.
+func newTCPLogReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.LogsConsumer) (component.LogsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	r := &tcpLogReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		conns:        make(map[net.Conn]struct{}),
+	}
+	return r, nil
+}
+
+// Start listens on the endpoint and begins accepting connections.
+func (tr *tcpLogReceiver) Start(ctx context.Context, host component.Host) error {
+	listener, err := net.Listen("tcp", tr.config.Endpoint)
+	if err != nil {
+		return err
+	}
+	if tr.config.TLS != nil {
+		tlsCfg, err := tr.config.TLS.LoadTLSConfig()
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		listener = tls.NewListener(listener, tlsCfg)
+	}
+	tr.listener = listener
+
+	ctx = obsreport.ReceiverContext(context.Background(), typeStr, transport, tr.config.Name())
+	tr.wg.Add(1)
+	go tr.accept(ctx)
+	return nil
+}
+
+// Shutdown stops accepting connections and closes the open ones.
+func (tr *tcpLogReceiver) Shutdown(context.Context) error {
+	if tr.listener == nil {
+		return nil
+	}
+	err := tr.listener.Close()
+
+	tr.mu.Lock()
+	tr.closed = true
+	for conn := range tr.conns {
+		conn.Close()
+	}
+	tr.mu.Unlock()
+
+	tr.wg.Wait()
+	return err
+}
+
+func (tr *tcpLogReceiver) accept(ctx context.Context) {
+	defer tr.wg.Done()
+	for {
+		conn, err := tr.listener.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				tr.logger.Warn("Failed to accept connection", zap.Error(err))
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return
+		}
+
+		tr.mu.Lock()
+		if tr.closed {
+			tr.mu.Unlock()
+			conn.Close()
+			return
+		}
+		tr.conns[conn] = struct{}{}
+		tr.wg.Add(1)
+		tr.mu.Unlock()
+
+		go tr.handleConn(ctx, conn)
+	}
+}
+
+// handleConn reads the log lines of a connection until it is closed.
+func (tr *tcpLogReceiver) handleConn(ctx context.Context, conn net.Conn) {
+	defer func() {
+		conn.Close()
+		tr.mu.Lock()
+		delete(tr.conns, conn)
+		tr.mu.Unlock()
+		tr.wg.Done()
+	}()
+
+	// A parser is created per connection since decoders are not safe for
+	// concurrent use.
+	parser, err := logparser.New(tr.config.Config)
+	if err != nil {
+		tr.logger.Error("Failed to create the log parser", zap.Error(err))
+		return
+	}
+	peerIP := ""
+	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+		peerIP = addr.IP.String()
+	}
+
+	scanner := parser.NewScanner(conn, tr.config.MaxLogSize)
+	for scanner.Scan() {
+		line, err := parser.Decode(scanner.Bytes())
+		if err != nil {
+			tr.logger.Debug("Failed to decode log", zap.Error(err))
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		entry, err := parser.Process(line)
+		if err != nil {
+			tr.logger.Debug("Failed to parse log", zap.Error(err))
+		}
+		if err := tr.nextConsumer.ConsumeLogs(ctx, toLogs(entry, peerIP, time.Now())); err != nil {
+			tr.logger.Warn("Failed to send logs", zap.Error(err))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		tr.logger.Debug("Closing connection", zap.String("peer", conn.RemoteAddr().String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcplogreceiver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+type logsSink struct {
+	mu   sync.Mutex
+	logs []pdata.Logs
+}
+
+var _ consumer.LogsConsumer = (*logsSink)(nil)
+
+func (s *logsSink) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logs = append(s.logs, ld)
+	return nil
+}
+
+// bodies returns the bodies of the received logs.
+func (s *logsSink) bodies() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var bodies []string
+	for _, ld := range s.logs {
+		lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+		bodies = append(bodies, lr.Body().StringVal())
+	}
+	return bodies
+}
+
+func TestReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Config = logparser.Config{Parser: logparser.ParserJSON}
+
+	sink := &logsSink{}
+	r, err := newTCPLogReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, err := net.Dial("tcp", cfg.Endpoint)
+	require.NoError(t, err)
+	_, err = fmt.Fprint(conn, "{\"level\": \"info\"}\r\n\nnot json\n")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, func() bool {
+		return len(sink.bodies()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{`{"level": "info"}`, "not json"}, sink.bodies())
+
+	attrs := sink.logs[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()
+	v, ok := attrs.Get("level")
+	require.True(t, ok)
+	assert.Equal(t, "info", v.StringVal())
+
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestReceiverTLS(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.TLS = &configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: "./testdata/testcert.crt",
+			KeyFile:  "./testdata/testkey.key",
+		},
+	}
+
+	sink := &logsSink{}
+	r, err := newTCPLogReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer r.Shutdown(context.Background())
+
+	cert, err := ioutil.ReadFile("./testdata/testcert.crt")
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(cert))
+
+	conn, err := tls.Dial("tcp", cfg.Endpoint, &tls.Config{RootCAs: pool})
+	require.NoError(t, err)
+	_, err = fmt.Fprint(conn, "secure\n")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, func() bool {
+		return len(sink.bodies()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"secure"}, sink.bodies())
+}
+
+func TestShutdownClosesConnections(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	r, err := newTCPLogReceiver(zap.NewNop(), cfg, &logsSink{})
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, err := net.Dial("tcp", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = fmt.Fprint(conn, "open\n")
+	require.NoError(t, err)
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package udplogreceiver
+
+import (
+	"net"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+// Config defines configuration for the udplog receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Endpoint is the address to listen on, e.g. 0.0.0.0:54526.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Config configures how the lines are decoded and parsed. A datagram
+	// may hold several lines.
+	logparser.Config `mapstructure:",squash"`
+}
+
+func (c *Config) validate() error {
+	if _, _, err := net.SplitHostPort(c.Endpoint); err != nil {
+		return err
+	}
+	return c.Config.Validate()
+}
@@ -0,0 +1,68 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package udplogreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+// toLogs converts an entry parsed from a log line received from the peer
+// address to logs. The logs are timestamped with the time they were received
+// at if no timestamp was parsed.
+func toLogs(entry *logparser.Entry, peerIP string, receivedAt time.Time) pdata.Logs {
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rl := rls.At(0)
+	rl.InitEmpty()
+	ills := rl.InstrumentationLibraryLogs()
+	ills.Resize(1)
+	ill := ills.At(0)
+	ill.InitEmpty()
+	lrs := ill.Logs()
+	lrs.Resize(1)
+	lr := lrs.At(0)
+
+	ts := entry.Timestamp
+	if ts.IsZero() {
+		ts = receivedAt
+	}
+	lr.SetTimestamp(pdata.TimestampUnixNano(uint64(ts.UnixNano())))
+	if entry.Severity != logparser.SeverityUndefined {
+		lr.SetSeverityNumber(pdata.SeverityNumber(entry.Severity))
+		lr.SetSeverityText(entry.SeverityText)
+	}
+	lr.Body().SetStringVal(entry.Body)
+
+	lrAttrs := lr.Attributes()
+	lrAttrs.InitEmptyWithCapacity(len(entry.Attributes) + 1)
+	lrAttrs.InsertString(conventions.AttributeNetPeerIP, peerIP)
+	for key, value := range entry.Attributes {
+		switch v := value.(type) {
+		case string:
+			lrAttrs.InsertString(key, v)
+		case float64:
+			lrAttrs.InsertDouble(key, v)
+		case bool:
+			lrAttrs.InsertBool(key, v)
+		}
+	}
+	return ld
+}
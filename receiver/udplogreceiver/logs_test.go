@@ -0,0 +1,74 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package udplogreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+func TestToLogs(t *testing.T) {
+	receivedAt := time.Unix(1600000000, 0)
+	ld := toLogs(&logparser.Entry{
+		Body: "ERROR disk full",
+		Attributes: map[string]interface{}{
+			"level":  "ERROR",
+			"status": 500.0,
+			"ok":     false,
+		},
+	}, "10.0.0.1", receivedAt)
+
+	require.Equal(t, 1, ld.ResourceLogs().Len())
+	lrs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 1, lrs.Len())
+	lr := lrs.At(0)
+
+	assert.Equal(t, pdata.TimestampUnixNano(1600000000*1e9), lr.Timestamp())
+	assert.Equal(t, "ERROR disk full", lr.Body().StringVal())
+	assert.Equal(t, 4, lr.Attributes().Len())
+
+	v, ok := lr.Attributes().Get("net.peer.ip")
+	require.True(t, ok)
+	assert.Equal(t, "10.0.0.1", v.StringVal())
+	v, ok = lr.Attributes().Get("level")
+	require.True(t, ok)
+	assert.Equal(t, "ERROR", v.StringVal())
+	v, ok = lr.Attributes().Get("status")
+	require.True(t, ok)
+	assert.Equal(t, 500.0, v.DoubleVal())
+	v, ok = lr.Attributes().Get("ok")
+	require.True(t, ok)
+	assert.False(t, v.BoolVal())
+}
+
+func TestToLogsParsedTimestampAndSeverity(t *testing.T) {
+	ld := toLogs(&logparser.Entry{
+		Body:         "ERROR disk full",
+		Timestamp:    time.Unix(1500000000, 0),
+		Severity:     logparser.SeverityError,
+		SeverityText: "ERROR",
+	}, "10.0.0.1", time.Unix(1600000000, 0))
+
+	lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampUnixNano(1500000000*1e9), lr.Timestamp())
+	assert.Equal(t, pdata.SeverityNumber(logparser.SeverityError), lr.SeverityNumber())
+	assert.Equal(t, "ERROR", lr.SeverityText())
+}
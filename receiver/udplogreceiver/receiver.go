@@ -0,0 +1,139 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package udplogreceiver
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+const (
+	transport = "udp"
+
+	// maxDatagramSize is the maximum size of a UDP datagram.
+	maxDatagramSize = 64 * 1024
+)
+
+var _ component.LogsReceiver = (*udpLogReceiver)(nil)
+
+// udpLogReceiver implements the component.LogsReceiver for logs received on
+// a UDP socket.
+type udpLogReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.LogsConsumer
+	config       *Config
+	parser       *logparser.Parser
+
+	conn net.PacketConn
+	wg   sync.WaitGroup
+}
+
+// newUDPLogReceiver creates the udplog receiver with the given parameters.
+func newUDPLogReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.LogsConsumer) (component.LogsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	parser, err := logparser.New(config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &udpLogReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		parser:       parser,
+	}
+	return r, nil
+}
+
+// Start listens on the endpoint and begins reading datagrams.
+func (ur *udpLogReceiver) Start(ctx context.Context, host component.Host) error {
+	conn, err := net.ListenPacket("udp", ur.config.Endpoint)
+	if err != nil {
+		return err
+	}
+	ur.conn = conn
+
+	ctx = obsreport.ReceiverContext(context.Background(), typeStr, transport, ur.config.Name())
+	ur.wg.Add(1)
+	go ur.read(ctx)
+	return nil
+}
+
+// Shutdown closes the socket.
+func (ur *udpLogReceiver) Shutdown(context.Context) error {
+	if ur.conn == nil {
+		return nil
+	}
+	err := ur.conn.Close()
+	ur.wg.Wait()
+	return err
+}
+
+// read reads the datagrams until the socket is closed. The datagrams are
+// handled sequentially, which lets the parser be reused.
+func (ur *udpLogReceiver) read(ctx context.Context) {
+	defer ur.wg.Done()
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := ur.conn.ReadFrom(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				ur.logger.Debug("Failed to read datagram", zap.Error(err))
+				continue
+			}
+			return
+		}
+
+		peerIP := ""
+		if udpAddr, ok := addr.(*net.UDPAddr); ok {
+			peerIP = udpAddr.IP.String()
+		}
+		for _, l := range ur.parser.Lines(buf[:n]) {
+			line, err := ur.parser.Decode(l)
+			if err != nil {
+				ur.logger.Debug("Failed to decode log", zap.Error(err))
+				continue
+			}
+			if line == "" {
+				continue
+			}
+			entry, err := ur.parser.Process(line)
+			if err != nil {
+				ur.logger.Debug("Failed to parse log", zap.Error(err))
+			}
+			if err := ur.nextConsumer.ConsumeLogs(ctx, toLogs(entry, peerIP, time.Now())); err != nil {
+				ur.logger.Warn("Failed to send logs", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package udplogreceiver
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/logparser"
+)
+
+type logsSink struct {
+	mu   sync.Mutex
+	logs []pdata.Logs
+}
+
+var _ consumer.LogsConsumer = (*logsSink)(nil)
+
+func (s *logsSink) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logs = append(s.logs, ld)
+	return nil
+}
+
+// bodies returns the bodies of the received logs.
+func (s *logsSink) bodies() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var bodies []string
+	for _, ld := range s.logs {
+		lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+		bodies = append(bodies, lr.Body().StringVal())
+	}
+	return bodies
+}
+
+func TestReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Config = logparser.Config{
+		Parser: logparser.ParserRegex,
+		Regex:  `^<(?P<priority>\d+)>(?P<message>.*)$`,
+	}
+
+	sink := &logsSink{}
+	r, err := newUDPLogReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer r.Shutdown(context.Background())
+
+	conn, err := net.Dial("udp", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("<13>first\n<14>second"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(sink.bodies()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"<13>first", "<14>second"}, sink.bodies())
+
+	attrs := sink.logs[1].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()
+	v, ok := attrs.Get("priority")
+	require.True(t, ok)
+	assert.Equal(t, "14", v.StringVal())
+	v, ok = attrs.Get("message")
+	require.True(t, ok)
+	assert.Equal(t, "second", v.StringVal())
+}
@@ -0,0 +1,135 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver
+
+import (
+	"context"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/performance"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// realtimeInterval is the sampling interval in seconds of the real-time
+// statistics of hosts and virtual machines.
+const realtimeInterval = 20
+
+// vcenterClient retrieves the inventory and the performance statistics of
+// a vCenter server.
+type vcenterClient struct {
+	cfg    *Config
+	client *govmomi.Client
+	views  *view.Manager
+	perf   *performance.Manager
+}
+
+func newVCenterClient(cfg *Config) *vcenterClient {
+	return &vcenterClient{cfg: cfg}
+}
+
+// connect logs in the vCenter server, the session is kept alive until
+// disconnect.
+func (c *vcenterClient) connect(ctx context.Context) error {
+	if c.client != nil {
+		if active, err := c.client.SessionManager.SessionIsActive(ctx); err == nil && active {
+			return nil
+		}
+		// the session expired, log in again.
+		c.client.Logout(ctx)
+		c.client = nil
+	}
+
+	u, err := c.cfg.sdkURL()
+	if err != nil {
+		return err
+	}
+	client, err := govmomi.NewClient(ctx, u, c.cfg.InsecureSkipVerify)
+	if err != nil {
+		return err
+	}
+	c.client = client
+	c.views = view.NewManager(client.Client)
+	c.perf = performance.NewManager(client.Client)
+	return nil
+}
+
+func (c *vcenterClient) disconnect(ctx context.Context) error {
+	if c.client == nil {
+		return nil
+	}
+	err := c.client.Logout(ctx)
+	c.client = nil
+	return err
+}
+
+// retrieve gets the properties of every managed object of the kind, e.g.
+// HostSystem, in the inventory into dst, a pointer to a slice of the matching
+// mo type.
+func (c *vcenterClient) retrieve(ctx context.Context, kind string, props []string, dst interface{}) error {
+	v, err := c.views.CreateContainerView(ctx, c.client.ServiceContent.RootFolder, []string{kind}, true)
+	if err != nil {
+		return err
+	}
+	defer v.Destroy(ctx)
+
+	return v.Retrieve(ctx, []string{kind}, props, dst)
+}
+
+func (c *vcenterClient) clusters(ctx context.Context) ([]mo.ClusterComputeResource, error) {
+	var clusters []mo.ClusterComputeResource
+	err := c.retrieve(ctx, "ClusterComputeResource", []string{"name", "summary"}, &clusters)
+	return clusters, err
+}
+
+func (c *vcenterClient) hosts(ctx context.Context) ([]mo.HostSystem, error) {
+	var hosts []mo.HostSystem
+	err := c.retrieve(ctx, "HostSystem", []string{"name", "parent", "summary"}, &hosts)
+	return hosts, err
+}
+
+func (c *vcenterClient) vms(ctx context.Context) ([]mo.VirtualMachine, error) {
+	var vms []mo.VirtualMachine
+	err := c.retrieve(ctx, "VirtualMachine", []string{"name", "summary"}, &vms)
+	return vms, err
+}
+
+func (c *vcenterClient) datastores(ctx context.Context) ([]mo.Datastore, error) {
+	var datastores []mo.Datastore
+	err := c.retrieve(ctx, "Datastore", []string{"name", "summary"}, &datastores)
+	return datastores, err
+}
+
+// perfMetrics returns the latest real-time sample of the aggregated counters
+// of the entities, the counters are named group.name.rollup, e.g.
+// net.bytesRx.average.
+func (c *vcenterClient) perfMetrics(ctx context.Context, counters []string, entities []types.ManagedObjectReference) ([]performance.EntityMetric, error) {
+	if len(counters) == 0 || len(entities) == 0 {
+		return nil, nil
+	}
+	spec := types.PerfQuerySpec{
+		MaxSample:  1,
+		IntervalId: realtimeInterval,
+		// the empty instance selects the value aggregated over the instances,
+		// e.g. the network interfaces.
+		MetricId: []types.PerfMetricId{{Instance: ""}},
+	}
+	sample, err := c.perf.SampleByName(ctx, spec, counters, entities)
+	if err != nil {
+		return nil, err
+	}
+	return c.perf.ToMetricSeries(ctx, sample)
+}
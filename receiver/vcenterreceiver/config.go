@@ -0,0 +1,94 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Collection levels, they match the statistics levels of vCenter: level 1
+// only collects the basic usage metrics while level 4 collects every metric
+// supported by the receiver.
+const (
+	minCollectionLevel = 1
+	maxCollectionLevel = 4
+)
+
+// Config defines configuration for the vCenter receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// Endpoint is the URL of the vCenter server, e.g. https://vcenter.example.com.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Username is the user used to log in the vCenter server.
+	Username string `mapstructure:"username"`
+
+	// Password is the password of the user.
+	Password string `mapstructure:"password"`
+
+	// InsecureSkipVerify disables the verification of the server certificate.
+	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+
+	// CollectionInterval is the interval at which metrics should be collected.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+
+	// CollectionLevel selects the metrics collected, from 1 to 4.
+	CollectionLevel int `mapstructure:"collection_level"`
+}
+
+func (c *Config) validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", c.Endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("endpoint %q must be an http or https URL", c.Endpoint)
+	}
+	if c.Username == "" {
+		return errors.New("username must be specified")
+	}
+	if c.Password == "" {
+		return errors.New("password must be specified")
+	}
+	if c.CollectionInterval <= 0 {
+		return errors.New("collection_interval must be positive")
+	}
+	if c.CollectionLevel < minCollectionLevel || c.CollectionLevel > maxCollectionLevel {
+		return fmt.Errorf("collection_level must be between %d and %d", minCollectionLevel, maxCollectionLevel)
+	}
+	return nil
+}
+
+// sdkURL returns the URL of the vSphere API with the credentials.
+func (c *Config) sdkURL() (*url.URL, error) {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/sdk"
+	}
+	u.User = url.UserPassword(c.Username, c.Password)
+	return u, nil
+}
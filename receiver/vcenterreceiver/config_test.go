@@ -0,0 +1,108 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r1 := cfg.Receivers["vcenter"]
+	assert.Equal(t, r1, factory.CreateDefaultConfig())
+
+	r2 := cfg.Receivers["vcenter/custom"].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "vcenter/custom",
+			},
+			Endpoint:           "https://vcenter.example.com",
+			Username:           "otel@vsphere.local",
+			Password:           "secret",
+			InsecureSkipVerify: true,
+			CollectionInterval: 5 * time.Minute,
+			CollectionLevel:    3,
+		})
+	assert.NoError(t, r2.validate())
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Endpoint = "https://vcenter.example.com"
+		cfg.Username = "otel"
+		cfg.Password = "secret"
+		return cfg
+	}
+	require.NoError(t, valid().validate())
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"no endpoint", func(c *Config) { c.Endpoint = "" }},
+		{"bad scheme", func(c *Config) { c.Endpoint = "tcp://vcenter.example.com" }},
+		{"no username", func(c *Config) { c.Username = "" }},
+		{"no password", func(c *Config) { c.Password = "" }},
+		{"no interval", func(c *Config) { c.CollectionInterval = 0 }},
+		{"level too low", func(c *Config) { c.CollectionLevel = 0 }},
+		{"level too high", func(c *Config) { c.CollectionLevel = 5 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			assert.Error(t, cfg.validate())
+		})
+	}
+}
+
+func TestSDKURL(t *testing.T) {
+	cfg := &Config{Endpoint: "https://vcenter.example.com", Username: "otel", Password: "s3cr/t"}
+	u, err := cfg.sdkURL()
+	require.NoError(t, err)
+	assert.Equal(t, "/sdk", u.Path)
+	assert.Equal(t, "otel", u.User.Username())
+	password, _ := u.User.Password()
+	assert.Equal(t, "s3cr/t", password)
+
+	cfg.Endpoint = "https://vcenter.example.com/custom/sdk"
+	u, err = cfg.sdkURL()
+	require.NoError(t, err)
+	assert.Equal(t, "/custom/sdk", u.Path)
+}
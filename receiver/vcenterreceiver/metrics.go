@@ -0,0 +1,259 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver
+
+import (
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/vmware/govmomi/performance"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+// Resource labels identifying the measured entities.
+const (
+	clusterNameLabel   = "vcenter.cluster.name"
+	hostNameLabel      = "vcenter.host.name"
+	vmNameLabel        = "vcenter.vm.name"
+	vmIDLabel          = "vcenter.vm.id"
+	datastoreNameLabel = "vcenter.datastore.name"
+)
+
+// Resource types of the measured entities.
+const (
+	clusterResourceType   = "vcenter.cluster"
+	hostResourceType      = "vcenter.host"
+	vmResourceType        = "vcenter.vm"
+	datastoreResourceType = "vcenter.datastore"
+)
+
+// Metric labels.
+const (
+	directionLabel = "direction"
+	diskStateLabel = "disk_state"
+)
+
+// perfCounter is a vCenter performance counter reported as a time series of
+// a metric.
+type perfCounter struct {
+	// name is the full name of the counter, group.name.rollup.
+	name string
+	// direction is the value of the direction label, empty when the metric
+	// has no direction.
+	direction string
+}
+
+// perfMetric is a metric built from performance counters.
+type perfMetric struct {
+	name     string
+	unit     string
+	level    int
+	counters []perfCounter
+}
+
+// The real-time performance metrics of the hosts and virtual machines, the
+// level is the vCenter statistics level of the counters.
+var (
+	hostPerfMetrics = []perfMetric{
+		{name: "vcenter.host.disk.latency.max", unit: "ms", level: 1, counters: []perfCounter{
+			{name: "disk.maxTotalLatency.latest"},
+		}},
+		{name: "vcenter.host.network.throughput", unit: "KiBy/s", level: 2, counters: []perfCounter{
+			{name: "net.bytesRx.average", direction: "received"},
+			{name: "net.bytesTx.average", direction: "transmitted"},
+		}},
+		{name: "vcenter.host.disk.throughput", unit: "KiBy/s", level: 2, counters: []perfCounter{
+			{name: "disk.read.average", direction: "read"},
+			{name: "disk.write.average", direction: "write"},
+		}},
+		{name: "vcenter.host.network.packet.errors", unit: "{errors}", level: 2, counters: []perfCounter{
+			{name: "net.errorsRx.summation", direction: "received"},
+			{name: "net.errorsTx.summation", direction: "transmitted"},
+		}},
+		{name: "vcenter.host.disk.latency.avg", unit: "ms", level: 3, counters: []perfCounter{
+			{name: "disk.totalReadLatency.average", direction: "read"},
+			{name: "disk.totalWriteLatency.average", direction: "write"},
+		}},
+		{name: "vcenter.host.network.packet.dropped", unit: "{packets}", level: 3, counters: []perfCounter{
+			{name: "net.droppedRx.summation", direction: "received"},
+			{name: "net.droppedTx.summation", direction: "transmitted"},
+		}},
+	}
+
+	vmPerfMetrics = []perfMetric{
+		{name: "vcenter.vm.cpu.ready", unit: "ms", level: 1, counters: []perfCounter{
+			{name: "cpu.ready.summation"},
+		}},
+		{name: "vcenter.vm.memory.ballooned", unit: "KiBy", level: 1, counters: []perfCounter{
+			{name: "mem.vmmemctl.average"},
+		}},
+		{name: "vcenter.vm.network.throughput", unit: "KiBy/s", level: 2, counters: []perfCounter{
+			{name: "net.bytesRx.average", direction: "received"},
+			{name: "net.bytesTx.average", direction: "transmitted"},
+		}},
+		{name: "vcenter.vm.disk.throughput", unit: "KiBy/s", level: 2, counters: []perfCounter{
+			{name: "disk.read.average", direction: "read"},
+			{name: "disk.write.average", direction: "write"},
+		}},
+		{name: "vcenter.vm.memory.swapped", unit: "KiBy", level: 2, counters: []perfCounter{
+			{name: "mem.swapped.average"},
+		}},
+		{name: "vcenter.vm.disk.latency.max", unit: "ms", level: 3, counters: []perfCounter{
+			{name: "disk.maxTotalLatency.latest"},
+		}},
+		{name: "vcenter.vm.cpu.costop", unit: "ms", level: 4, counters: []perfCounter{
+			{name: "cpu.costop.summation"},
+		}},
+	}
+)
+
+// enabledPerfMetrics returns the metrics collected at the collection level
+// and the names of their counters.
+func enabledPerfMetrics(metrics []perfMetric, level int) ([]perfMetric, []string) {
+	var enabled []perfMetric
+	var counters []string
+	for _, m := range metrics {
+		if m.level > level {
+			continue
+		}
+		enabled = append(enabled, m)
+		for _, c := range m.counters {
+			counters = append(counters, c.name)
+		}
+	}
+	return enabled, counters
+}
+
+// perfMetricsData converts the samples of an entity to the enabled metrics.
+// The value aggregated over the instances of a counter is used when
+// reported, otherwise the values of the instances are summed.
+func perfMetricsData(metrics []perfMetric, sample *performance.EntityMetric) []*metricspb.Metric {
+	if len(sample.SampleInfo) == 0 {
+		return nil
+	}
+	ts := toTimestamp(sample.SampleInfo[len(sample.SampleInfo)-1].Timestamp.UnixNano())
+
+	type value struct {
+		aggregate, sum       int64
+		hasAggregate, hasSum bool
+	}
+	values := map[string]*value{}
+	for _, series := range sample.Value {
+		if len(series.Value) == 0 {
+			continue
+		}
+		v := values[series.Name]
+		if v == nil {
+			v = &value{}
+			values[series.Name] = v
+		}
+		last := series.Value[len(series.Value)-1]
+		if series.Instance == "" {
+			v.aggregate, v.hasAggregate = last, true
+		} else {
+			v.sum += last
+			v.hasSum = true
+		}
+	}
+
+	var res []*metricspb.Metric
+	for _, m := range metrics {
+		metric := newMetric(m.name, m.unit, metricspb.MetricDescriptor_GAUGE_INT64)
+		if m.counters[0].direction != "" {
+			metric.MetricDescriptor.LabelKeys = []*metricspb.LabelKey{{Key: directionLabel}}
+		}
+		for _, c := range m.counters {
+			v := values[c.name]
+			if v == nil {
+				continue
+			}
+			point := v.aggregate
+			if !v.hasAggregate {
+				point = v.sum
+			}
+			var labels []*metricspb.LabelValue
+			if c.direction != "" {
+				labels = []*metricspb.LabelValue{{Value: c.direction, HasValue: true}}
+			}
+			metric.Timeseries = append(metric.Timeseries, int64Series(labels, point, ts))
+		}
+		if len(metric.Timeseries) > 0 {
+			res = append(res, metric)
+		}
+	}
+	return res
+}
+
+func newMetricsData(resourceType string, labels map[string]string) *consumerdata.MetricsData {
+	return &consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{
+			Type:   resourceType,
+			Labels: labels,
+		},
+	}
+}
+
+func newMetric(name, unit string, metricType metricspb.MetricDescriptor_Type, labelKeys ...string) *metricspb.Metric {
+	keys := make([]*metricspb.LabelKey, 0, len(labelKeys))
+	for _, k := range labelKeys {
+		keys = append(keys, &metricspb.LabelKey{Key: k})
+	}
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      name,
+			Unit:      unit,
+			Type:      metricType,
+			LabelKeys: keys,
+		},
+	}
+}
+
+func gaugeInt(name, unit string, value int64, ts *timestamp.Timestamp) *metricspb.Metric {
+	m := newMetric(name, unit, metricspb.MetricDescriptor_GAUGE_INT64)
+	m.Timeseries = []*metricspb.TimeSeries{int64Series(nil, value, ts)}
+	return m
+}
+
+func gaugeDouble(name, unit string, value float64, ts *timestamp.Timestamp) *metricspb.Metric {
+	m := newMetric(name, unit, metricspb.MetricDescriptor_GAUGE_DOUBLE)
+	m.Timeseries = []*metricspb.TimeSeries{{
+		Points: []*metricspb.Point{{
+			Timestamp: ts,
+			Value:     &metricspb.Point_DoubleValue{DoubleValue: value},
+		}},
+	}}
+	return m
+}
+
+func int64Series(labels []*metricspb.LabelValue, value int64, ts *timestamp.Timestamp) *metricspb.TimeSeries {
+	return &metricspb.TimeSeries{
+		LabelValues: labels,
+		Points: []*metricspb.Point{{
+			Timestamp: ts,
+			Value:     &metricspb.Point_Int64Value{Int64Value: value},
+		}},
+	}
+}
+
+func labelValue(v string) []*metricspb.LabelValue {
+	return []*metricspb.LabelValue{{Value: v, HasValue: true}}
+}
+
+func toTimestamp(nanos int64) *timestamp.Timestamp {
+	return &timestamp.Timestamp{
+		Seconds: nanos / 1e9,
+		Nanos:   int32(nanos % 1e9),
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver
+
+import (
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/performance"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestEnabledPerfMetrics(t *testing.T) {
+	enabled, counters := enabledPerfMetrics(hostPerfMetrics, 1)
+	require.Len(t, enabled, 1)
+	assert.Equal(t, "vcenter.host.disk.latency.max", enabled[0].name)
+	assert.Equal(t, []string{"disk.maxTotalLatency.latest"}, counters)
+
+	enabled, counters = enabledPerfMetrics(hostPerfMetrics, 2)
+	assert.Len(t, enabled, 4)
+	assert.Len(t, counters, 7)
+
+	enabled, _ = enabledPerfMetrics(vmPerfMetrics, maxCollectionLevel)
+	assert.Len(t, enabled, len(vmPerfMetrics))
+}
+
+func TestPerfMetricsData(t *testing.T) {
+	now := time.Unix(1597000000, 0)
+	sample := &performance.EntityMetric{
+		Entity:     types.ManagedObjectReference{Type: "HostSystem", Value: "host-1"},
+		SampleInfo: []types.PerfSampleInfo{{Timestamp: now.Add(-20 * time.Second)}, {Timestamp: now}},
+		Value: []performance.MetricSeries{
+			// the aggregated value is used over the instances
+			{Name: "net.bytesRx.average", Instance: "", Value: []int64{1, 100}},
+			{Name: "net.bytesRx.average", Instance: "vmnic0", Value: []int64{1, 60}},
+			{Name: "net.bytesRx.average", Instance: "vmnic1", Value: []int64{1, 40}},
+			// without aggregated value the instances are summed
+			{Name: "net.bytesTx.average", Instance: "vmnic0", Value: []int64{1, 30}},
+			{Name: "net.bytesTx.average", Instance: "vmnic1", Value: []int64{1, 20}},
+			{Name: "disk.maxTotalLatency.latest", Instance: "", Value: []int64{5}},
+		},
+	}
+
+	enabled, _ := enabledPerfMetrics(hostPerfMetrics, 2)
+	metrics := perfMetricsData(enabled, sample)
+	require.Len(t, metrics, 2)
+
+	latency := metrics[0]
+	assert.Equal(t, "vcenter.host.disk.latency.max", latency.MetricDescriptor.Name)
+	assert.Empty(t, latency.MetricDescriptor.LabelKeys)
+	require.Len(t, latency.Timeseries, 1)
+	assert.Equal(t, int64(5), latency.Timeseries[0].Points[0].GetInt64Value())
+	assert.Equal(t, now.Unix(), latency.Timeseries[0].Points[0].Timestamp.Seconds)
+
+	network := metrics[1]
+	assert.Equal(t, "vcenter.host.network.throughput", network.MetricDescriptor.Name)
+	assert.Equal(t, "KiBy/s", network.MetricDescriptor.Unit)
+	assert.Equal(t, []*metricspb.LabelKey{{Key: directionLabel}}, network.MetricDescriptor.LabelKeys)
+	require.Len(t, network.Timeseries, 2)
+	assert.Equal(t, labelValue("received"), network.Timeseries[0].LabelValues)
+	assert.Equal(t, int64(100), network.Timeseries[0].Points[0].GetInt64Value())
+	assert.Equal(t, labelValue("transmitted"), network.Timeseries[1].LabelValues)
+	assert.Equal(t, int64(50), network.Timeseries[1].Points[0].GetInt64Value())
+}
+
+func TestPerfMetricsDataWithoutSamples(t *testing.T) {
+	assert.Empty(t, perfMetricsData(hostPerfMetrics, &performance.EntityMetric{}))
+}
+
+func TestHostSummaryMetrics(t *testing.T) {
+	summary := &types.HostListSummary{
+		Hardware: &types.HostHardwareSummary{CpuMhz: 2000, NumCpuCores: 4, MemorySize: 8 * bytesPerMiB * 1024},
+		QuickStats: types.HostListSummaryQuickStats{
+			OverallCpuUsage:    2000,
+			OverallMemoryUsage: 2048,
+		},
+	}
+
+	metrics := hostSummaryMetrics(summary, toTimestamp(time.Now().UnixNano()))
+	values := map[string]float64{}
+	for _, m := range metrics {
+		p := m.Timeseries[0].Points[0]
+		values[m.MetricDescriptor.Name] = p.GetDoubleValue() + float64(p.GetInt64Value())
+	}
+	assert.Equal(t, map[string]float64{
+		"vcenter.host.cpu.usage":          2000,
+		"vcenter.host.cpu.utilization":    25,
+		"vcenter.host.memory.usage":       2048,
+		"vcenter.host.memory.utilization": 25,
+	}, values)
+}
+
+func TestDatastoreMetrics(t *testing.T) {
+	summary := &types.DatastoreSummary{Capacity: 1000, FreeSpace: 250}
+
+	metrics := datastoreMetrics(summary, toTimestamp(time.Now().UnixNano()))
+	require.Len(t, metrics, 2)
+	usage := metrics[0]
+	assert.Equal(t, []*metricspb.LabelKey{{Key: diskStateLabel}}, usage.MetricDescriptor.LabelKeys)
+	assert.Equal(t, int64(750), usage.Timeseries[0].Points[0].GetInt64Value())
+	assert.Equal(t, int64(250), usage.Timeseries[1].Points[0].GetInt64Value())
+	assert.Equal(t, 75.0, metrics[1].Timeseries[0].Points[0].GetDoubleValue())
+}
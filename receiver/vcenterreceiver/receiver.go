@@ -0,0 +1,117 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "https"
+
+var _ component.MetricsReceiver = (*vcenterReceiver)(nil)
+
+// vcenterReceiver implements the component.MetricsReceiver for vCenter.
+type vcenterReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	scraper      *scraper
+	cancel       context.CancelFunc
+	done         chan struct{}
+}
+
+// newVCenterReceiver creates the vCenter receiver with the given parameters.
+func newVCenterReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	r := &vcenterReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		scraper:      newScraper(config),
+		done:         make(chan struct{}),
+	}
+	return r, nil
+}
+
+// Start begins collecting metrics from vCenter.
+func (vr *vcenterReceiver) Start(ctx context.Context, host component.Host) error {
+	ctx, vr.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, vr.config.Name()))
+	go func() {
+		defer close(vr.done)
+		ticker := time.NewTicker(vr.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				vr.collect(ctx)
+			case <-ctx.Done():
+				if err := vr.scraper.close(context.Background()); err != nil {
+					vr.logger.Debug("Failed to log out of vCenter", zap.Error(err))
+				}
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the vcenter receiver and logs out of vCenter.
+func (vr *vcenterReceiver) Shutdown(context.Context) error {
+	if vr.cancel == nil {
+		return nil
+	}
+	vr.cancel()
+	<-vr.done
+	return nil
+}
+
+// collect scrapes vCenter and sends the metrics collected, even partially.
+func (vr *vcenterReceiver) collect(ctx context.Context) {
+	mds, err := vr.scraper.scrape(ctx)
+	if err != nil {
+		vr.logger.Warn("Failed to collect vCenter metrics", zap.Error(err))
+	}
+	if len(mds) == 0 {
+		return
+	}
+
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+	numTimeSeries, numPoints := 0, 0
+	for _, md := range mds {
+		series, points := obsreport.CountMetricPoints(md)
+		numTimeSeries += series
+		numPoints += points
+	}
+	err = vr.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData(mds))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+}
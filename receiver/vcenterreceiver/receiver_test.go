@@ -0,0 +1,42 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestReceiver(t *testing.T) {
+	withSimulator(t, func(cfg *Config) {
+		cfg.CollectionInterval = 10 * time.Millisecond
+		sink := new(exportertest.SinkMetricsExporter)
+		metricsReceiver, err := newVCenterReceiver(zap.NewNop(), cfg, sink)
+		require.NoError(t, err)
+
+		ctx := context.Background()
+		require.NoError(t, metricsReceiver.Start(ctx, componenttest.NewNopHost()))
+		require.Eventually(t, func() bool {
+			return len(sink.AllMetrics()) > 0
+		}, 10*time.Second, 10*time.Millisecond)
+		require.NoError(t, metricsReceiver.Shutdown(ctx))
+	})
+}
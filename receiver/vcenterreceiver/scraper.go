@@ -0,0 +1,294 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+const bytesPerMiB = 1024 * 1024
+
+// scraper collects the metrics of the vCenter inventory.
+type scraper struct {
+	client *vcenterClient
+	level  int
+}
+
+// inventory maps the managed object references to the names of the clusters
+// and hosts, used to label the entities they contain.
+type inventory struct {
+	clusterNames map[string]string
+	hostNames    map[string]string
+	hostClusters map[string]string
+}
+
+func newScraper(cfg *Config) *scraper {
+	return &scraper{
+		client: newVCenterClient(cfg),
+		level:  cfg.CollectionLevel,
+	}
+}
+
+// scrape returns a MetricsData per entity, the errors of a kind of entity do
+// not prevent the collection of the others.
+func (s *scraper) scrape(ctx context.Context) ([]consumerdata.MetricsData, error) {
+	if err := s.client.connect(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to vCenter: %w", err)
+	}
+
+	inv := &inventory{
+		clusterNames: map[string]string{},
+		hostNames:    map[string]string{},
+		hostClusters: map[string]string{},
+	}
+	var mds []*consumerdata.MetricsData
+	var errs []error
+
+	clusters, err := s.scrapeClusters(ctx, inv)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect clusters: %w", err))
+	}
+	mds = append(mds, clusters...)
+
+	hosts, err := s.scrapeHosts(ctx, inv)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect hosts: %w", err))
+	}
+	mds = append(mds, hosts...)
+
+	vms, err := s.scrapeVMs(ctx, inv)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect virtual machines: %w", err))
+	}
+	mds = append(mds, vms...)
+
+	datastores, err := s.scrapeDatastores(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect datastores: %w", err))
+	}
+	mds = append(mds, datastores...)
+
+	res := make([]consumerdata.MetricsData, 0, len(mds))
+	for _, md := range mds {
+		if len(md.Metrics) > 0 {
+			res = append(res, *md)
+		}
+	}
+	return res, componenterror.CombineErrors(errs)
+}
+
+func (s *scraper) close(ctx context.Context) error {
+	return s.client.disconnect(ctx)
+}
+
+func (s *scraper) scrapeClusters(ctx context.Context, inv *inventory) ([]*consumerdata.MetricsData, error) {
+	clusters, err := s.client.clusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := toTimestamp(time.Now().UnixNano())
+	mds := make([]*consumerdata.MetricsData, 0, len(clusters))
+	for i := range clusters {
+		c := &clusters[i]
+		inv.clusterNames[c.Reference().Value] = c.Name
+
+		md := newMetricsData(clusterResourceType, map[string]string{clusterNameLabel: c.Name})
+		if c.Summary != nil {
+			if summary := c.Summary.GetComputeResourceSummary(); summary != nil {
+				md.Metrics = append(md.Metrics,
+					gaugeInt("vcenter.cluster.cpu.limit", "MHz", int64(summary.TotalCpu), ts),
+					gaugeInt("vcenter.cluster.cpu.effective", "MHz", int64(summary.EffectiveCpu), ts),
+					gaugeInt("vcenter.cluster.memory.limit", "By", summary.TotalMemory, ts),
+					gaugeInt("vcenter.cluster.memory.effective", "By", summary.EffectiveMemory*bytesPerMiB, ts),
+					gaugeInt("vcenter.cluster.host.count", "{hosts}", int64(summary.NumHosts), ts),
+					gaugeInt("vcenter.cluster.host.effective", "{hosts}", int64(summary.NumEffectiveHosts), ts),
+				)
+			}
+		}
+		mds = append(mds, md)
+	}
+	return mds, nil
+}
+
+func (s *scraper) scrapeHosts(ctx context.Context, inv *inventory) ([]*consumerdata.MetricsData, error) {
+	hosts, err := s.client.hosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := toTimestamp(time.Now().UnixNano())
+	mds := make(map[string]*consumerdata.MetricsData, len(hosts))
+	res := make([]*consumerdata.MetricsData, 0, len(hosts))
+	refs := make([]types.ManagedObjectReference, 0, len(hosts))
+	for i := range hosts {
+		h := &hosts[i]
+		ref := h.Reference()
+		inv.hostNames[ref.Value] = h.Name
+
+		labels := map[string]string{hostNameLabel: h.Name}
+		if h.Parent != nil {
+			if cluster, ok := inv.clusterNames[h.Parent.Value]; ok {
+				labels[clusterNameLabel] = cluster
+				inv.hostClusters[ref.Value] = cluster
+			}
+		}
+
+		md := newMetricsData(hostResourceType, labels)
+		md.Metrics = hostSummaryMetrics(&h.Summary, ts)
+		mds[ref.Value] = md
+		res = append(res, md)
+		refs = append(refs, ref)
+	}
+
+	return res, s.addPerfMetrics(ctx, hostPerfMetrics, refs, mds)
+}
+
+func hostSummaryMetrics(summary *types.HostListSummary, ts *timestamp.Timestamp) []*metricspb.Metric {
+	stats := summary.QuickStats
+	metrics := []*metricspb.Metric{
+		gaugeInt("vcenter.host.cpu.usage", "MHz", int64(stats.OverallCpuUsage), ts),
+		gaugeInt("vcenter.host.memory.usage", "MiBy", int64(stats.OverallMemoryUsage), ts),
+	}
+	if hw := summary.Hardware; hw != nil {
+		if capacity := float64(hw.CpuMhz) * float64(hw.NumCpuCores); capacity > 0 {
+			metrics = append(metrics, gaugeDouble("vcenter.host.cpu.utilization", "%",
+				float64(stats.OverallCpuUsage)/capacity*100, ts))
+		}
+		if hw.MemorySize > 0 {
+			metrics = append(metrics, gaugeDouble("vcenter.host.memory.utilization", "%",
+				float64(stats.OverallMemoryUsage)*bytesPerMiB/float64(hw.MemorySize)*100, ts))
+		}
+	}
+	return metrics
+}
+
+func (s *scraper) scrapeVMs(ctx context.Context, inv *inventory) ([]*consumerdata.MetricsData, error) {
+	vms, err := s.client.vms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := toTimestamp(time.Now().UnixNano())
+	mds := make(map[string]*consumerdata.MetricsData, len(vms))
+	res := make([]*consumerdata.MetricsData, 0, len(vms))
+	// only the running virtual machines have real-time statistics.
+	var refs []types.ManagedObjectReference
+	for i := range vms {
+		vm := &vms[i]
+		ref := vm.Reference()
+		summary := &vm.Summary
+
+		labels := map[string]string{vmNameLabel: vm.Name}
+		if id := summary.Config.InstanceUuid; id != "" {
+			labels[vmIDLabel] = id
+		}
+		if host := summary.Runtime.Host; host != nil {
+			if name, ok := inv.hostNames[host.Value]; ok {
+				labels[hostNameLabel] = name
+			}
+			if cluster, ok := inv.hostClusters[host.Value]; ok {
+				labels[clusterNameLabel] = cluster
+			}
+		}
+
+		md := newMetricsData(vmResourceType, labels)
+		md.Metrics = vmSummaryMetrics(summary, ts)
+		mds[ref.Value] = md
+		res = append(res, md)
+		if summary.Runtime.PowerState == types.VirtualMachinePowerStatePoweredOn {
+			refs = append(refs, ref)
+		}
+	}
+
+	return res, s.addPerfMetrics(ctx, vmPerfMetrics, refs, mds)
+}
+
+func vmSummaryMetrics(summary *types.VirtualMachineSummary, ts *timestamp.Timestamp) []*metricspb.Metric {
+	stats := summary.QuickStats
+	metrics := []*metricspb.Metric{
+		gaugeInt("vcenter.vm.cpu.usage", "MHz", int64(stats.OverallCpuUsage), ts),
+		gaugeInt("vcenter.vm.memory.usage", "MiBy", int64(stats.GuestMemoryUsage), ts),
+	}
+	if summary.Config.MemorySizeMB > 0 {
+		metrics = append(metrics, gaugeDouble("vcenter.vm.memory.utilization", "%",
+			float64(stats.GuestMemoryUsage)/float64(summary.Config.MemorySizeMB)*100, ts))
+	}
+	if storage := summary.Storage; storage != nil {
+		metrics = append(metrics, gaugeInt("vcenter.vm.disk.usage", "By", storage.Committed, ts))
+	}
+	return metrics
+}
+
+func (s *scraper) scrapeDatastores(ctx context.Context) ([]*consumerdata.MetricsData, error) {
+	datastores, err := s.client.datastores(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := toTimestamp(time.Now().UnixNano())
+	mds := make([]*consumerdata.MetricsData, 0, len(datastores))
+	for i := range datastores {
+		ds := &datastores[i]
+		md := newMetricsData(datastoreResourceType, map[string]string{datastoreNameLabel: ds.Name})
+		md.Metrics = datastoreMetrics(&ds.Summary, ts)
+		mds = append(mds, md)
+	}
+	return mds, nil
+}
+
+func datastoreMetrics(summary *types.DatastoreSummary, ts *timestamp.Timestamp) []*metricspb.Metric {
+	used := summary.Capacity - summary.FreeSpace
+	usage := newMetric("vcenter.datastore.disk.usage", "By", metricspb.MetricDescriptor_GAUGE_INT64, diskStateLabel)
+	usage.Timeseries = []*metricspb.TimeSeries{
+		int64Series(labelValue("used"), used, ts),
+		int64Series(labelValue("available"), summary.FreeSpace, ts),
+	}
+	metrics := []*metricspb.Metric{usage}
+	if summary.Capacity > 0 {
+		metrics = append(metrics, gaugeDouble("vcenter.datastore.disk.utilization", "%",
+			float64(used)/float64(summary.Capacity)*100, ts))
+	}
+	return metrics
+}
+
+// addPerfMetrics adds the performance metrics enabled at the collection level
+// to the MetricsData of the entities.
+func (s *scraper) addPerfMetrics(
+	ctx context.Context,
+	metrics []perfMetric,
+	refs []types.ManagedObjectReference,
+	mds map[string]*consumerdata.MetricsData,
+) error {
+	enabled, counters := enabledPerfMetrics(metrics, s.level)
+	samples, err := s.client.perfMetrics(ctx, counters, refs)
+	if err != nil {
+		return fmt.Errorf("failed to query performance counters: %w", err)
+	}
+	for i := range samples {
+		if md, ok := mds[samples[i].Entity.Value]; ok {
+			md.Metrics = append(md.Metrics, perfMetricsData(enabled, &samples[i])...)
+		}
+	}
+	return nil
+}
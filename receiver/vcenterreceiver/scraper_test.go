@@ -0,0 +1,100 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/simulator"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+// withSimulator runs f with the configuration of a simulated vCenter server
+// with clusters, hosts, virtual machines and datastores.
+func withSimulator(t *testing.T, f func(cfg *Config)) {
+	model := simulator.VPX()
+	require.NoError(t, model.Create())
+	defer model.Remove()
+
+	server := model.Service.NewServer()
+	defer server.Close()
+
+	u := *server.URL
+	password, _ := u.User.Password()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Username = u.User.Username()
+	cfg.Password = password
+	cfg.InsecureSkipVerify = true
+	u.User = nil
+	cfg.Endpoint = u.String()
+
+	f(cfg)
+}
+
+func resourcesByType(mds []consumerdata.MetricsData) map[string]int {
+	res := map[string]int{}
+	for _, md := range mds {
+		res[md.Resource.Type]++
+	}
+	return res
+}
+
+func TestScrape(t *testing.T) {
+	withSimulator(t, func(cfg *Config) {
+		s := newScraper(cfg)
+		defer s.close(context.Background())
+
+		mds, err := s.scrape(context.Background())
+		require.NoError(t, err)
+
+		counts := resourcesByType(mds)
+		assert.Greater(t, counts[clusterResourceType], 0)
+		assert.Greater(t, counts[hostResourceType], 0)
+		assert.Greater(t, counts[vmResourceType], 0)
+		assert.Greater(t, counts[datastoreResourceType], 0)
+
+		for _, md := range mds {
+			switch md.Resource.Type {
+			case clusterResourceType:
+				assert.NotEmpty(t, md.Resource.Labels[clusterNameLabel])
+			case hostResourceType:
+				assert.NotEmpty(t, md.Resource.Labels[hostNameLabel])
+			case vmResourceType:
+				assert.NotEmpty(t, md.Resource.Labels[vmNameLabel])
+				assert.NotEmpty(t, md.Resource.Labels[hostNameLabel])
+			case datastoreResourceType:
+				assert.NotEmpty(t, md.Resource.Labels[datastoreNameLabel])
+			}
+		}
+
+		// the session is reused
+		_, err = s.scrape(context.Background())
+		require.NoError(t, err)
+	})
+}
+
+func TestScrapeConnectionError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "https://127.0.0.1:1/sdk"
+	cfg.Username = "user"
+	cfg.Password = "pass"
+	s := newScraper(cfg)
+
+	_, err := s.scrape(context.Background())
+	require.Error(t, err)
+}
@@ -16,6 +16,7 @@ package wavefrontreceiver
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -44,6 +45,10 @@ var escapedCharReplacer = strings.NewReplacer(
 	`\n`, "\n", // Repaces escaped new-line.
 )
 
+// Delta counter names are prefixed either by the increment (U+2206) or the
+// Greek capital delta (U+0394) character.
+var deltaPrefixes = []string{"\u2206", "\u0394"}
+
 // BuildParser creates a new Parser instance that receives Wavefront metric data.
 func (wp *WavefrontParser) BuildParser() (protocol.Parser, error) {
 	return wp, nil
@@ -57,14 +62,22 @@ func (wp *WavefrontParser) BuildParser() (protocol.Parser, error) {
 //
 // 	"<metricName> <metricValue> [<timestamp>] source=<source> [pointTags]"
 //
+// Metric names prefixed with "∆" or "Δ" are delta counters, see
+// https://docs.wavefront.com/delta_counters.html. Lines starting with "!M",
+// "!H" or "!D" are histograms, see parseHistogram.
+//
 // Detailed description of each element is available on the link above.
 func (wp *WavefrontParser) Parse(line string) (*metricspb.Metric, error) {
+	if strings.HasPrefix(line, "!") {
+		return wp.parseHistogram(line)
+	}
+
 	parts := strings.SplitN(line, " ", 3)
 	if len(parts) < 3 {
 		return nil, fmt.Errorf("invalid wavefront metric [%s]", line)
 	}
 
-	metricName := unDoubleQuote(parts[0])
+	metricName, isDelta := trimDeltaPrefix(unDoubleQuote(parts[0]))
 	if metricName == "" {
 		return nil, fmt.Errorf("empty name for wavefront metric [%s]", line)
 	}
@@ -75,6 +88,9 @@ func (wp *WavefrontParser) Parse(line string) (*metricspb.Metric, error) {
 	var point metricspb.Point
 	if intVal, err := strconv.ParseInt(valueStr, 10, 64); err == nil {
 		metricType = metricspb.MetricDescriptor_GAUGE_INT64
+		if isDelta {
+			metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
+		}
 		point.Value = &metricspb.Point_Int64Value{Int64Value: intVal}
 	} else {
 		dblVal, err := strconv.ParseFloat(valueStr, 64)
@@ -82,10 +98,194 @@ func (wp *WavefrontParser) Parse(line string) (*metricspb.Metric, error) {
 			return nil, fmt.Errorf("invalid wavefront metric value [%s]: %v", line, err)
 		}
 		metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
+		if isDelta {
+			metricType = metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
+		}
 		point.Value = &metricspb.Point_DoubleValue{DoubleValue: dblVal}
 	}
 
+	ts, tags, err := parseTimestamp(rest)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"invalid timestamp for wavefront metric [%s]", line)
+	}
+	point.Timestamp = ts
+
+	metricName, labelKeys, labelValues, err := wp.labelsFromTags(metricName, tags)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wavefront metric [%s]: %v", line, err)
+	}
+
+	timeseries := &metricspb.TimeSeries{
+		LabelValues: labelValues,
+		Points:      []*metricspb.Point{&point},
+	}
+	if isDelta {
+		// A delta counter only accounts for the increments since it was last
+		// reported: start the series at the point itself so it isn't mistaken
+		// for a cumulative total since process start.
+		timeseries.StartTimestamp = &timestamp.Timestamp{Seconds: ts.Seconds}
+	}
+
+	metric := &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      metricName,
+			Type:      metricType,
+			LabelKeys: labelKeys,
+		},
+		Timeseries: []*metricspb.TimeSeries{timeseries},
+	}
+	return metric, nil
+}
+
+// parseHistogram parses a Wavefront histogram distribution, see
+// https://docs.wavefront.com/proxies_histograms.html#sending-histogram-distributions,
+// in the following format:
+//
+// 	"{!M | !H | !D} [<timestamp>] {#<count> <centroid>}+ <metricName> source=<source> [pointTags]"
+//
+// The centroids are converted to a distribution with a bucket per centroid.
+// The granularity (minute, hour or day) isn't represented in the result.
+func (wp *WavefrontParser) parseHistogram(line string) (*metricspb.Metric, error) {
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid wavefront histogram [%s]", line)
+	}
+	switch parts[0] {
+	case "!M", "!H", "!D":
+	default:
+		return nil, fmt.Errorf("invalid wavefront histogram granularity [%s]", line)
+	}
+	rest := parts[1]
+
+	var ts *timestamp.Timestamp
+	if strings.HasPrefix(rest, "#") {
+		ts = &timestamp.Timestamp{Seconds: time.Now().Unix()}
+	} else {
+		parts = strings.SplitN(rest, " ", 2)
+		unixTime, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || len(parts) < 2 {
+			return nil, fmt.Errorf("invalid timestamp for wavefront histogram [%s]", line)
+		}
+		ts = &timestamp.Timestamp{Seconds: unixTime}
+		rest = parts[1]
+	}
+
+	var centroids []centroid
+	for strings.HasPrefix(rest, "#") {
+		parts = strings.SplitN(rest, " ", 3)
+		if len(parts) < 3 {
+			return nil, fmt.Errorf("invalid wavefront histogram [%s]", line)
+		}
+		count, err := strconv.ParseInt(parts[0][1:], 10, 64)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("invalid centroid count for wavefront histogram [%s]", line)
+		}
+		value, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid centroid value for wavefront histogram [%s]: %v", line, err)
+		}
+		centroids = append(centroids, centroid{value: value, count: count})
+		rest = parts[2]
+	}
+	if len(centroids) == 0 {
+		return nil, fmt.Errorf("no centroids for wavefront histogram [%s]", line)
+	}
+
 	parts = strings.SplitN(rest, " ", 2)
+	metricName := unDoubleQuote(parts[0])
+	if metricName == "" {
+		return nil, fmt.Errorf("empty name for wavefront histogram [%s]", line)
+	}
+	var tags string
+	if len(parts) == 2 {
+		tags = parts[1]
+	}
+
+	metricName, labelKeys, labelValues, err := wp.labelsFromTags(metricName, tags)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wavefront histogram [%s]: %v", line, err)
+	}
+
+	metric := &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      metricName,
+			Type:      metricspb.MetricDescriptor_GAUGE_DISTRIBUTION,
+			LabelKeys: labelKeys,
+		},
+		Timeseries: []*metricspb.TimeSeries{
+			{
+				LabelValues: labelValues,
+				Points: []*metricspb.Point{
+					{
+						Timestamp: ts,
+						Value: &metricspb.Point_DistributionValue{
+							DistributionValue: buildDistribution(centroids),
+						},
+					},
+				},
+			},
+		},
+	}
+	return metric, nil
+}
+
+type centroid struct {
+	value float64
+	count int64
+}
+
+// buildDistribution converts the centroids of a histogram to a distribution
+// using the centroid values as bucket bounds, so that each centroid falls in
+// the bucket starting at its value.
+func buildDistribution(centroids []centroid) *metricspb.DistributionValue {
+	counts := make(map[float64]int64, len(centroids))
+	var count int64
+	var sum float64
+	for _, c := range centroids {
+		counts[c.value] += c.count
+		count += c.count
+		sum += float64(c.count) * c.value
+	}
+
+	bounds := make([]float64, 0, len(counts))
+	for value := range counts {
+		bounds = append(bounds, value)
+	}
+	sort.Float64s(bounds)
+
+	// The first bucket, below the lowest centroid, is always empty.
+	buckets := make([]*metricspb.DistributionValue_Bucket, len(bounds)+1)
+	buckets[0] = &metricspb.DistributionValue_Bucket{}
+	var sumOfSquaredDeviation float64
+	for i, bound := range bounds {
+		buckets[i+1] = &metricspb.DistributionValue_Bucket{Count: counts[bound]}
+		if count > 0 {
+			deviation := bound - sum/float64(count)
+			sumOfSquaredDeviation += float64(counts[bound]) * deviation * deviation
+		}
+	}
+
+	return &metricspb.DistributionValue{
+		Count:                 count,
+		Sum:                   sum,
+		SumOfSquaredDeviation: sumOfSquaredDeviation,
+		BucketOptions: &metricspb.DistributionValue_BucketOptions{
+			Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+				Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{
+					Bounds: bounds,
+				},
+			},
+		},
+		Buckets: buckets,
+	}
+}
+
+// parseTimestamp parses the optional timestamp at the start of rest and
+// returns it along with the remaining tags. The current time is used if the
+// timestamp was omitted.
+func parseTimestamp(rest string) (*timestamp.Timestamp, string, error) {
+	parts := strings.SplitN(rest, " ", 2)
 	timestampStr := parts[0]
 	var tags string
 	if len(parts) == 2 {
@@ -97,15 +297,21 @@ func (wp *WavefrontParser) Parse(line string) (*metricspb.Metric, error) {
 	} else {
 		// Timestamp can be omitted so it is only correct if the string was a tag.
 		if strings.IndexByte(timestampStr, '=') == -1 {
-			return nil, fmt.Errorf(
-				"invalid timestamp for wavefront metric [%s]", line)
+			return nil, "", err
 		}
 		// Assume timestamp was omitted, get current time and adjust index.
 		ts.Seconds = time.Now().Unix()
 		tags = rest
 	}
-	point.Timestamp = &ts
+	return &ts, tags, nil
+}
 
+// labelsFromTags converts the tags to labels, extracting the CollectD tags from
+// the metric name if configured to do so.
+func (wp *WavefrontParser) labelsFromTags(
+	metricName string,
+	tags string,
+) (string, []*metricspb.LabelKey, []*metricspb.LabelValue, error) {
 	var labelKeys []*metricspb.LabelKey
 	var labelValues []*metricspb.LabelValue
 	if tags != "" {
@@ -114,28 +320,25 @@ func (wp *WavefrontParser) Parse(line string) (*metricspb.Metric, error) {
 		var err error
 		labelKeys, labelValues, err = buildLabels(tags)
 		if err != nil {
-			return nil, fmt.Errorf("invalid wavefront metric [%s]: %v", line, err)
+			return "", nil, nil, err
 		}
 	}
 
 	if wp.ExtractCollectdTags {
 		metricName, labelKeys, labelValues = wp.injectCollectDLabels(metricName, labelKeys, labelValues)
 	}
+	return metricName, labelKeys, labelValues, nil
+}
 
-	metric := &metricspb.Metric{
-		MetricDescriptor: &metricspb.MetricDescriptor{
-			Name:      metricName,
-			Type:      metricType,
-			LabelKeys: labelKeys,
-		},
-		Timeseries: []*metricspb.TimeSeries{
-			{
-				LabelValues: labelValues,
-				Points:      []*metricspb.Point{&point},
-			},
-		},
+// trimDeltaPrefix removes the delta counter prefix from metricName, returning
+// whether it was present.
+func trimDeltaPrefix(metricName string) (string, bool) {
+	for _, prefix := range deltaPrefixes {
+		if strings.HasPrefix(metricName, prefix) {
+			return strings.TrimPrefix(metricName, prefix), true
+		}
 	}
-	return metric, nil
+	return metricName, false
 }
 
 func (wp *WavefrontParser) injectCollectDLabels(
@@ -335,3 +335,160 @@ func buildMetric(
 		},
 	}
 }
+
+func Test_wavefrontParser_ParseDeltaCounter(t *testing.T) {
+	tests := []struct {
+		line string
+		want *metricspb.Metric
+	}{
+		{
+			line: "∆tst.delta.int 3 1582230020 source=tst",
+			want: buildMetric(
+				metricspb.MetricDescriptor_CUMULATIVE_INT64,
+				"tst.delta.int",
+				[]string{"source"},
+				[]string{"tst"},
+				&metricspb.Point{
+					Timestamp: &timestamp.Timestamp{Seconds: 1582230020},
+					Value:     &metricspb.Point_Int64Value{Int64Value: 3},
+				},
+			),
+		},
+		{
+			line: "\"Δtst.delta.dbl\" 1.5 1582230020 source=tst",
+			want: buildMetric(
+				metricspb.MetricDescriptor_CUMULATIVE_DOUBLE,
+				"tst.delta.dbl",
+				[]string{"source"},
+				[]string{"tst"},
+				&metricspb.Point{
+					Timestamp: &timestamp.Timestamp{Seconds: 1582230020},
+					Value:     &metricspb.Point_DoubleValue{DoubleValue: 1.5},
+				},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			tt.want.Timeseries[0].StartTimestamp = &timestamp.Timestamp{Seconds: 1582230020}
+			p := WavefrontParser{}
+			got, err := p.Parse(tt.line)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_wavefrontParser_ParseHistogram(t *testing.T) {
+	tests := []struct {
+		line             string
+		missingTimestamp bool
+		want             *metricspb.Metric
+		wantErr          bool
+	}{
+		{
+			line: "!M 1582230020 #2 30 #1 10.5 #1 30 request.latency source=tst k0=v0",
+			want: buildMetric(
+				metricspb.MetricDescriptor_GAUGE_DISTRIBUTION,
+				"request.latency",
+				[]string{"source", "k0"},
+				[]string{"tst", "v0"},
+				&metricspb.Point{
+					Timestamp: &timestamp.Timestamp{Seconds: 1582230020},
+					Value: &metricspb.Point_DistributionValue{
+						DistributionValue: &metricspb.DistributionValue{
+							Count:                 4,
+							Sum:                   100.5,
+							SumOfSquaredDeviation: 285.1875,
+							BucketOptions: &metricspb.DistributionValue_BucketOptions{
+								Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+									Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{
+										Bounds: []float64{10.5, 30},
+									},
+								},
+							},
+							Buckets: []*metricspb.DistributionValue_Bucket{
+								{Count: 0},
+								{Count: 1},
+								{Count: 3},
+							},
+						},
+					},
+				},
+			),
+		},
+		{
+			line:             "!H #1 5 request.latency",
+			missingTimestamp: true,
+			want: buildMetric(
+				metricspb.MetricDescriptor_GAUGE_DISTRIBUTION,
+				"request.latency",
+				nil,
+				nil,
+				&metricspb.Point{
+					Value: &metricspb.Point_DistributionValue{
+						DistributionValue: &metricspb.DistributionValue{
+							Count: 1,
+							Sum:   5,
+							BucketOptions: &metricspb.DistributionValue_BucketOptions{
+								Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+									Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{
+										Bounds: []float64{5},
+									},
+								},
+							},
+							Buckets: []*metricspb.DistributionValue_Bucket{
+								{Count: 0},
+								{Count: 1},
+							},
+						},
+					},
+				},
+			),
+		},
+		{
+			line:    "!X 1582230020 #1 5 request.latency source=tst",
+			wantErr: true,
+		},
+		{
+			line:    "!D 1582230020 request.latency source=tst",
+			wantErr: true,
+		},
+		{
+			line:    "!D xyz #1 5 request.latency source=tst",
+			wantErr: true,
+		},
+		{
+			line:    "!D 1582230020 #x 5 request.latency source=tst",
+			wantErr: true,
+		},
+		{
+			line:    "!D 1582230020 #1 xyz request.latency source=tst",
+			wantErr: true,
+		},
+		{
+			line:    "!D 1582230020 #1 5",
+			wantErr: true,
+		},
+		{
+			line:    "!D 1582230020 #1 5 request.latency source",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			p := WavefrontParser{}
+			got, err := p.Parse(tt.line)
+			if tt.missingTimestamp {
+				unixNow := time.Now().Unix()
+				ts := got.Timeseries[0].Points[0].Timestamp
+				assert.LessOrEqual(t, math.Abs(float64(ts.GetSeconds()-unixNow)), 2.0)
+				tt.want.Timeseries[0].Points[0].Timestamp = ts
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
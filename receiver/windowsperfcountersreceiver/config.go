@@ -0,0 +1,72 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package windowsperfcountersreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the windowsperfcounters receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// CollectionInterval is the interval at which metrics should be collected.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+
+	// PerfCounters is the list of performance counters to collect.
+	PerfCounters []PerfCounterConfig `mapstructure:"perfcounters"`
+}
+
+// PerfCounterConfig defines the counters to collect for a performance object.
+type PerfCounterConfig struct {
+	// Object is the name of the performance object, e.g. "Memory".
+	Object string `mapstructure:"object"`
+
+	// Instances is the list of instances of the object to collect, e.g.
+	// "_Total". The wildcard "*" collects all the instances. Leave empty
+	// for objects without instances.
+	Instances []string `mapstructure:"instances"`
+
+	// Counters is the list of counters of the object to collect, e.g.
+	// "Committed Bytes".
+	Counters []string `mapstructure:"counters"`
+}
+
+func (c *Config) validate() error {
+	if c.CollectionInterval <= 0 {
+		return errors.New("collection_interval must be positive")
+	}
+	if len(c.PerfCounters) == 0 {
+		return errors.New("at least one perfcounter must be specified")
+	}
+	for i, pc := range c.PerfCounters {
+		if pc.Object == "" {
+			return fmt.Errorf("perfcounters[%d]: object must be specified", i)
+		}
+		if len(pc.Counters) == 0 {
+			return fmt.Errorf("perfcounters[%d]: at least one counter must be specified for object %q", i, pc.Object)
+		}
+		for _, instance := range pc.Instances {
+			if instance == "" {
+				return fmt.Errorf("perfcounters[%d]: empty instance for object %q", i, pc.Object)
+			}
+		}
+	}
+	return nil
+}
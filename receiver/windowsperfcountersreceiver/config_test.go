@@ -0,0 +1,88 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package windowsperfcountersreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r1 := cfg.Receivers["windowsperfcounters"]
+	assert.Equal(t, r1, factory.CreateDefaultConfig())
+
+	r2 := cfg.Receivers["windowsperfcounters/customname"].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "windowsperfcounters/customname",
+			},
+			CollectionInterval: 30 * time.Second,
+			PerfCounters: []PerfCounterConfig{
+				{
+					Object:   "Memory",
+					Counters: []string{"Committed Bytes"},
+				},
+				{
+					Object:    "Processor",
+					Instances: []string{"*"},
+					Counters:  []string{"% Processor Time", "% Idle Time"},
+				},
+			},
+		})
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.validate(), "at least one perfcounter must be specified")
+
+	cfg.PerfCounters = []PerfCounterConfig{{Object: "Memory", Counters: []string{"Committed Bytes"}}}
+	assert.NoError(t, cfg.validate())
+
+	cfg.PerfCounters = []PerfCounterConfig{{Counters: []string{"Committed Bytes"}}}
+	assert.EqualError(t, cfg.validate(), "perfcounters[0]: object must be specified")
+
+	cfg.PerfCounters = []PerfCounterConfig{{Object: "Memory"}}
+	assert.EqualError(t, cfg.validate(), `perfcounters[0]: at least one counter must be specified for object "Memory"`)
+
+	cfg.PerfCounters = []PerfCounterConfig{{Object: "Processor", Instances: []string{""}, Counters: []string{"% Processor Time"}}}
+	assert.EqualError(t, cfg.validate(), `perfcounters[0]: empty instance for object "Processor"`)
+
+	cfg.PerfCounters = []PerfCounterConfig{{Object: "Memory", Counters: []string{"Committed Bytes"}}}
+	cfg.CollectionInterval = 0
+	assert.EqualError(t, cfg.validate(), "collection_interval must be positive")
+}
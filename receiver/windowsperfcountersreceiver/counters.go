@@ -0,0 +1,101 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package windowsperfcountersreceiver
+
+import (
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/pdh"
+)
+
+// instanceLabel is the label identifying the instance of a counter.
+const instanceLabel = "instance"
+
+// counterMetric describes the metric reported for a counter of an object,
+// which is collected through one counter path per configured instance.
+type counterMetric struct {
+	name         string
+	hasInstances bool
+	paths        []string
+}
+
+// counterMetrics returns the metrics described by the configuration.
+func counterMetrics(perfCounters []PerfCounterConfig) []counterMetric {
+	var metrics []counterMetric
+	for _, pc := range perfCounters {
+		for _, counter := range pc.Counters {
+			m := counterMetric{
+				name:         metricName(pc.Object, counter),
+				hasInstances: len(pc.Instances) > 0,
+			}
+			if m.hasInstances {
+				for _, instance := range pc.Instances {
+					m.paths = append(m.paths, counterPath(pc.Object, instance, counter))
+				}
+			} else {
+				m.paths = []string{counterPath(pc.Object, "", counter)}
+			}
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics
+}
+
+// counterPath returns the PDH path of a counter, e.g.
+// `\Processor(_Total)\% Processor Time`.
+func counterPath(object, instance, counter string) string {
+	if instance == "" {
+		return `\` + object + `\` + counter
+	}
+	return `\` + object + `(` + instance + `)\` + counter
+}
+
+// metricName returns the name of the metric of a counter, e.g.
+// `\Processor\% Processor Time`.
+func metricName(object, counter string) string {
+	return `\` + object + `\` + counter
+}
+
+// toMetric converts the values of a counter to a gauge with one timeseries
+// per instance.
+func (m *counterMetric) toMetric(values []pdh.CounterValue, ts *timestamp.Timestamp) *metricspb.Metric {
+	descriptor := &metricspb.MetricDescriptor{
+		Name: m.name,
+		Type: metricspb.MetricDescriptor_GAUGE_DOUBLE,
+	}
+	if m.hasInstances {
+		descriptor.LabelKeys = []*metricspb.LabelKey{{Key: instanceLabel}}
+	}
+
+	timeseries := make([]*metricspb.TimeSeries, 0, len(values))
+	for _, v := range values {
+		series := &metricspb.TimeSeries{
+			Points: []*metricspb.Point{{
+				Timestamp: ts,
+				Value:     &metricspb.Point_DoubleValue{DoubleValue: v.Value},
+			}},
+		}
+		if m.hasInstances {
+			series.LabelValues = []*metricspb.LabelValue{{Value: v.InstanceName, HasValue: true}}
+		}
+		timeseries = append(timeseries, series)
+	}
+
+	return &metricspb.Metric{
+		MetricDescriptor: descriptor,
+		Timeseries:       timeseries,
+	}
+}
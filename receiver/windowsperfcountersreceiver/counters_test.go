@@ -0,0 +1,92 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package windowsperfcountersreceiver
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/pdh"
+)
+
+func TestCounterMetrics(t *testing.T) {
+	metrics := counterMetrics([]PerfCounterConfig{
+		{
+			Object:   "Memory",
+			Counters: []string{"Committed Bytes"},
+		},
+		{
+			Object:    "Processor",
+			Instances: []string{"0", "_Total"},
+			Counters:  []string{"% Processor Time", "% Idle Time"},
+		},
+	})
+
+	assert.Equal(t, []counterMetric{
+		{
+			name:  `\Memory\Committed Bytes`,
+			paths: []string{`\Memory\Committed Bytes`},
+		},
+		{
+			name:         `\Processor\% Processor Time`,
+			hasInstances: true,
+			paths:        []string{`\Processor(0)\% Processor Time`, `\Processor(_Total)\% Processor Time`},
+		},
+		{
+			name:         `\Processor\% Idle Time`,
+			hasInstances: true,
+			paths:        []string{`\Processor(0)\% Idle Time`, `\Processor(_Total)\% Idle Time`},
+		},
+	}, metrics)
+}
+
+func TestToMetric(t *testing.T) {
+	ts := ptypes.TimestampNow()
+
+	m := counterMetric{name: `\Memory\Committed Bytes`}
+	metric := m.toMetric([]pdh.CounterValue{{Value: 1024}}, ts)
+	assert.Equal(t, &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name: `\Memory\Committed Bytes`,
+			Type: metricspb.MetricDescriptor_GAUGE_DOUBLE,
+		},
+		Timeseries: []*metricspb.TimeSeries{{
+			Points: []*metricspb.Point{{Timestamp: ts, Value: &metricspb.Point_DoubleValue{DoubleValue: 1024}}},
+		}},
+	}, metric)
+
+	m = counterMetric{name: `\Processor\% Processor Time`, hasInstances: true}
+	metric = m.toMetric([]pdh.CounterValue{{InstanceName: "0", Value: 12.5}, {InstanceName: "_Total", Value: 25}}, ts)
+	assert.Equal(t, &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:      `\Processor\% Processor Time`,
+			Type:      metricspb.MetricDescriptor_GAUGE_DOUBLE,
+			LabelKeys: []*metricspb.LabelKey{{Key: instanceLabel}},
+		},
+		Timeseries: []*metricspb.TimeSeries{
+			{
+				LabelValues: []*metricspb.LabelValue{{Value: "0", HasValue: true}},
+				Points:      []*metricspb.Point{{Timestamp: ts, Value: &metricspb.Point_DoubleValue{DoubleValue: 12.5}}},
+			},
+			{
+				LabelValues: []*metricspb.LabelValue{{Value: "_Total", HasValue: true}},
+				Points:      []*metricspb.Point{{Timestamp: ts, Value: &metricspb.Point_DoubleValue{DoubleValue: 25}}},
+			},
+		},
+	}, metric)
+}
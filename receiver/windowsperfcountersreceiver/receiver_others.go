@@ -0,0 +1,36 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package windowsperfcountersreceiver
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.uber.org/zap"
+)
+
+// errNotSupported is returned when the receiver is created on a platform
+// other than Windows.
+var errNotSupported = errors.New("the windowsperfcounters receiver is only supported on Windows")
+
+func newWindowsPerfCountersReceiver(
+	*zap.Logger,
+	*Config,
+	consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	return nil, errNotSupported
+}
@@ -0,0 +1,179 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build windows
+
+package windowsperfcountersreceiver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/golang/protobuf/ptypes"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/pdh"
+)
+
+const transport = "pdh"
+
+var _ component.MetricsReceiver = (*windowsPerfCountersReceiver)(nil)
+
+// windowsPerfCountersReceiver implements the component.MetricsReceiver for
+// Windows performance counters.
+type windowsPerfCountersReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	query        *pdh.Query
+	metrics      []collectedMetric
+	cancel       context.CancelFunc
+	// done tracks the collection goroutine, the query is only closed once it
+	// has returned.
+	done sync.WaitGroup
+}
+
+// collectedMetric is a metric with the counters added to the query for it.
+type collectedMetric struct {
+	counterMetric
+	counters []*pdh.Counter
+}
+
+// newWindowsPerfCountersReceiver creates the windowsperfcounters receiver
+// with the given parameters.
+func newWindowsPerfCountersReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	r := &windowsPerfCountersReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+	}
+	return r, nil
+}
+
+// Start opens the PDH query and begins collecting the configured counters.
+func (wr *windowsPerfCountersReceiver) Start(ctx context.Context, host component.Host) error {
+	query, err := pdh.OpenQuery()
+	if err != nil {
+		return err
+	}
+	wr.query = query
+
+	for _, m := range counterMetrics(wr.config.PerfCounters) {
+		cm := collectedMetric{counterMetric: m}
+		for _, path := range m.paths {
+			counter, err := query.AddCounter(path)
+			if err != nil {
+				wr.logger.Warn("Failed to add performance counter", zap.String("path", path), zap.Error(err))
+				continue
+			}
+			cm.counters = append(cm.counters, counter)
+		}
+		if len(cm.counters) > 0 {
+			wr.metrics = append(wr.metrics, cm)
+		}
+	}
+
+	// Rate counters need two samples to be computed, so the first one is
+	// taken right away.
+	if err := query.Collect(); err != nil {
+		wr.logger.Warn("Failed to collect performance counters", zap.Error(err))
+	}
+
+	ctx, wr.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, wr.config.Name()))
+	wr.done.Add(1)
+	go func() {
+		defer wr.done.Done()
+		ticker := time.NewTicker(wr.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := wr.collect(ctx); err != nil {
+					wr.logger.Warn("Failed to collect performance counters", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the windowsperfcounters receiver and closes the PDH query
+// once the collection in progress, if any, is over.
+func (wr *windowsPerfCountersReceiver) Shutdown(context.Context) error {
+	if wr.cancel != nil {
+		wr.cancel()
+	}
+	wr.done.Wait()
+	if wr.query != nil {
+		return wr.query.Close()
+	}
+	return nil
+}
+
+// collect sends the current values of the counters.
+func (wr *windowsPerfCountersReceiver) collect(ctx context.Context) error {
+	if err := wr.query.Collect(); err != nil {
+		return err
+	}
+
+	ts := ptypes.TimestampNow()
+	metrics := make([]*metricspb.Metric, 0, len(wr.metrics))
+	for i := range wr.metrics {
+		m := &wr.metrics[i]
+		var values []pdh.CounterValue
+		for _, counter := range m.counters {
+			v, err := counter.Values()
+			if err != nil {
+				wr.logger.Debug("Failed to read performance counter", zap.Error(err))
+				continue
+			}
+			values = append(values, v...)
+		}
+		if len(values) > 0 {
+			metrics = append(metrics, m.toMetric(values, ts))
+		}
+	}
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	md := consumerdata.MetricsData{Metrics: metrics}
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(md)
+
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+	err := wr.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md}))
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+	return err
+}
@@ -0,0 +1,123 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build windows
+
+package windowsperfcountersreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func testConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 100 * time.Millisecond
+	cfg.PerfCounters = []PerfCounterConfig{
+		{
+			Object:   "Memory",
+			Counters: []string{"Committed Bytes"},
+		},
+		{
+			Object:    "Processor",
+			Instances: []string{"*"},
+			Counters:  []string{"% Processor Time"},
+		},
+	}
+	return cfg
+}
+
+func TestCreateMetricsReceiver(t *testing.T) {
+	metricsReceiver, err := createMetricsReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		testConfig(),
+		exportertest.NewNopMetricsExporter(),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+}
+
+func TestCreateMetricsReceiverWithNilConsumer(t *testing.T) {
+	metricsReceiver, err := createMetricsReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		testConfig(),
+		nil,
+	)
+	require.Nil(t, metricsReceiver)
+	require.Equal(t, err, componenterror.ErrNilNextConsumer)
+}
+
+func TestCreateMetricsReceiverWithInvalidConfig(t *testing.T) {
+	metricsReceiver, err := createMetricsReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		createDefaultConfig(),
+		exportertest.NewNopMetricsExporter(),
+	)
+	require.Nil(t, metricsReceiver)
+	require.Error(t, err)
+}
+
+func TestReceiverCollect(t *testing.T) {
+	sink := &exportertest.SinkMetricsExporter{}
+	r, err := newWindowsPerfCountersReceiver(zap.NewNop(), testConfig(), sink)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer r.Shutdown(context.Background())
+
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	mds := pdatautil.MetricsToMetricsData(sink.AllMetrics()[0])
+	require.Len(t, mds, 1)
+	names := make(map[string]int)
+	for _, m := range mds[0].Metrics {
+		names[m.MetricDescriptor.Name] = len(m.Timeseries)
+	}
+	assert.Equal(t, 1, names[`\Memory\Committed Bytes`])
+	assert.GreaterOrEqual(t, names[`\Processor\% Processor Time`], 2)
+}
+
+func TestReceiverShutdownWhileCollecting(t *testing.T) {
+	cfg := testConfig()
+	cfg.CollectionInterval = time.Millisecond
+	sink := &exportertest.SinkMetricsExporter{}
+	r, err := newWindowsPerfCountersReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) > 0
+	}, 5*time.Second, time.Millisecond)
+
+	// The query is closed after the collection goroutine returns.
+	require.NoError(t, r.Shutdown(context.Background()))
+	count := len(sink.AllMetrics())
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, count, len(sink.AllMetrics()))
+}
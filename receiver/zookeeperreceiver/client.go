@@ -0,0 +1,87 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zookeeperreceiver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const mntrCommand = "mntr"
+
+// client issues four letter word commands to ZooKeeper.
+type client interface {
+	// mntr sends the mntr command and returns the key/value pairs of the response.
+	mntr(ctx context.Context) (map[string]string, error)
+}
+
+// tcpClient sends commands over a new TCP connection to the ZooKeeper client port.
+type tcpClient struct {
+	endpoint string
+	timeout  time.Duration
+}
+
+var _ client = (*tcpClient)(nil)
+
+func (c *tcpClient) mntr(ctx context.Context) (map[string]string, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write([]byte(mntrCommand)); err != nil {
+		return nil, err
+	}
+
+	return parseMntr(bufio.NewScanner(conn))
+}
+
+// parseMntr parses the tab separated key/value lines of a mntr response, e.g.
+// "zk_avg_latency\t0". ZooKeeper closes the connection once the full response
+// is written.
+func parseMntr(scanner *bufio.Scanner) (map[string]string, error) {
+	stats := map[string]string{}
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		// ZooKeeper answers disabled commands with a plain text message.
+		if strings.Contains(line, "is not executed because it is not in the whitelist") {
+			return nil, fmt.Errorf("mntr command is not whitelisted, add it to 4lw.commands.whitelist")
+		}
+		parts := strings.SplitN(line, "\t", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		stats[parts[0]] = parts[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("empty response to %s command", mntrCommand)
+	}
+	return stats, nil
+}
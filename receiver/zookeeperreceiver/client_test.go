@@ -0,0 +1,86 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zookeeperreceiver
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeZookeeper listens on a random port and answers each connection with
+// the contents of the given testdata file once the mntr command is received.
+func startFakeZookeeper(t *testing.T, fname string) net.Listener {
+	response, err := ioutil.ReadFile(path.Join("testdata", fname))
+	require.NoError(t, err)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			buf := make([]byte, len(mntrCommand))
+			if _, err := conn.Read(buf); err == nil && string(buf) == mntrCommand {
+				_, _ = conn.Write(response)
+			}
+			conn.Close()
+		}
+	}()
+	return l
+}
+
+func TestTCPClientMntr(t *testing.T) {
+	l := startFakeZookeeper(t, "mntr.txt")
+	defer l.Close()
+
+	c := &tcpClient{endpoint: l.Addr().String(), timeout: 5 * time.Second}
+	stats, err := c.mntr(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "leader", stats["zk_server_state"])
+	require.Equal(t, "0.5", stats["zk_avg_latency"])
+	require.Equal(t, "5", stats["zk_znode_count"])
+}
+
+func TestTCPClientConnectionRefused(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := l.Addr().String()
+	l.Close()
+
+	c := &tcpClient{endpoint: endpoint, timeout: time.Second}
+	_, err = c.mntr(context.Background())
+	require.Error(t, err)
+}
+
+func TestParseMntrNotWhitelisted(t *testing.T) {
+	_, err := parseMntr(bufio.NewScanner(strings.NewReader("mntr is not executed because it is not in the whitelist.\n")))
+	require.Error(t, err)
+}
+
+func TestParseMntrEmpty(t *testing.T) {
+	_, err := parseMntr(bufio.NewScanner(strings.NewReader("")))
+	require.Error(t, err)
+}
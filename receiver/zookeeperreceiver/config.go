@@ -0,0 +1,34 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zookeeperreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// Config defines configuration for zookeeper receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+	// Endpoint is the host:port of the ZooKeeper client port.
+	confignet.TCPAddr `mapstructure:",squash"`
+
+	// CollectionInterval is the interval at which the mntr command is issued.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+	// Timeout is the timeout for connecting to ZooKeeper and reading the mntr response.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
@@ -0,0 +1,143 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zookeeperreceiver
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"github.com/golang/protobuf/ptypes"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+)
+
+const (
+	serverStateKey = "zk_server_state"
+	versionKey     = "zk_version"
+
+	serverStateLabel = "server.state"
+	versionLabel     = "zk.version"
+)
+
+// zkMetric describes how to convert a mntr key into a metric.
+type zkMetric struct {
+	key    string
+	name   string
+	units  string
+	desc   string
+	mdType metricspb.MetricDescriptor_Type
+}
+
+// zkMetrics are the mntr keys converted to metrics. Keys missing from the response,
+// such as the follower metrics that are only reported by the leader, are skipped.
+var zkMetrics = []zkMetric{
+	{key: "zk_avg_latency", name: "zookeeper/latency/avg", units: "ms", mdType: metricspb.MetricDescriptor_GAUGE_DOUBLE,
+		desc: "Average time in milliseconds for requests to be processed"},
+	{key: "zk_max_latency", name: "zookeeper/latency/max", units: "ms", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Maximum time in milliseconds for requests to be processed"},
+	{key: "zk_min_latency", name: "zookeeper/latency/min", units: "ms", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Minimum time in milliseconds for requests to be processed"},
+	{key: "zk_packets_received", name: "zookeeper/packets/received", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Number of ZooKeeper packets received by the server"},
+	{key: "zk_packets_sent", name: "zookeeper/packets/sent", mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc: "Number of ZooKeeper packets sent by the server"},
+	{key: "zk_num_alive_connections", name: "zookeeper/connections/alive", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Number of active clients connected to the server"},
+	{key: "zk_outstanding_requests", name: "zookeeper/requests/outstanding", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Number of currently executing requests"},
+	{key: "zk_znode_count", name: "zookeeper/znodes", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Number of znodes"},
+	{key: "zk_watch_count", name: "zookeeper/watches", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Number of watches placed on znodes"},
+	{key: "zk_ephemerals_count", name: "zookeeper/ephemeral_nodes", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Number of ephemeral znodes"},
+	{key: "zk_approximate_data_size", name: "zookeeper/data_size", units: "By", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Approximate size of the data stored by the server"},
+	{key: "zk_open_file_descriptor_count", name: "zookeeper/file_descriptors/open", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Number of open file descriptors"},
+	{key: "zk_max_file_descriptor_count", name: "zookeeper/file_descriptors/limit", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Maximum number of file descriptors"},
+	{key: "zk_followers", name: "zookeeper/followers", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Number of followers, only reported by the leader"},
+	{key: "zk_synced_followers", name: "zookeeper/followers/synced", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Number of followers in sync with the leader, only reported by the leader"},
+	{key: "zk_pending_syncs", name: "zookeeper/followers/pending_syncs", mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc: "Number of pending syncs from followers, only reported by the leader"},
+}
+
+// buildMetricsData converts a mntr response into metrics. Returns the metrics and
+// parsing errors, to be treated as warnings, if there were any.
+func buildMetricsData(stats map[string]string, now time.Time) (*consumerdata.MetricsData, []error) {
+	var warnings []error
+	ts, _ := ptypes.TimestampProto(now)
+
+	labels := map[string]string{}
+	if state, ok := stats[serverStateKey]; ok {
+		labels[serverStateLabel] = state
+	}
+	if version, ok := stats[versionKey]; ok {
+		labels[versionLabel] = version
+	}
+
+	md := &consumerdata.MetricsData{
+		Resource: &resourcepb.Resource{
+			Type:   typeStr,
+			Labels: labels,
+		},
+	}
+
+	for _, m := range zkMetrics {
+		strVal, ok := stats[m.key]
+		if !ok {
+			continue
+		}
+		pt, err := parsePoint(m.mdType, strVal)
+		if err != nil {
+			warnings = append(warnings, fmt.Errorf("failed parsing %s: %v", m.key, err))
+			continue
+		}
+		pt.Timestamp = ts
+		md.Metrics = append(md.Metrics, &metricspb.Metric{
+			MetricDescriptor: &metricspb.MetricDescriptor{
+				Name:        m.name,
+				Description: m.desc,
+				Unit:        m.units,
+				Type:        m.mdType,
+			},
+			Timeseries: []*metricspb.TimeSeries{{
+				Points: []*metricspb.Point{pt},
+			}},
+		})
+	}
+
+	return md, warnings
+}
+
+func parsePoint(mdType metricspb.MetricDescriptor_Type, strVal string) (*metricspb.Point, error) {
+	if mdType == metricspb.MetricDescriptor_GAUGE_DOUBLE {
+		f, err := strconv.ParseFloat(strVal, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &metricspb.Point{Value: &metricspb.Point_DoubleValue{DoubleValue: f}}, nil
+	}
+	i, err := strconv.ParseInt(strVal, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &metricspb.Point{Value: &metricspb.Point_Int64Value{Int64Value: i}}, nil
+}
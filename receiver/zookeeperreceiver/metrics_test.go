@@ -0,0 +1,45 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zookeeperreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMetricsData(t *testing.T) {
+	stats := map[string]string{
+		"zk_version":          "3.6.1",
+		"zk_server_state":     "follower",
+		"zk_avg_latency":      "1.5",
+		"zk_znode_count":      "42",
+		"zk_watch_count":      "not-a-number",
+		"zk_unknown_key":      "1",
+		"zk_packets_received": "100",
+	}
+	md, warnings := buildMetricsData(stats, time.Now())
+	require.Len(t, warnings, 1)
+	require.Equal(t, map[string]string{"server.state": "follower", "zk.version": "3.6.1"}, md.Resource.Labels)
+	require.Len(t, md.Metrics, 3)
+
+	require.Equal(t, "zookeeper/latency/avg", md.Metrics[0].MetricDescriptor.Name)
+	require.Equal(t, 1.5, md.Metrics[0].Timeseries[0].Points[0].GetDoubleValue())
+	require.Equal(t, "zookeeper/packets/received", md.Metrics[1].MetricDescriptor.Name)
+	require.Equal(t, int64(100), md.Metrics[1].Timeseries[0].Points[0].GetInt64Value())
+	require.Equal(t, "zookeeper/znodes", md.Metrics[2].MetricDescriptor.Name)
+	require.Equal(t, int64(42), md.Metrics[2].Timeseries[0].Points[0].GetInt64Value())
+}
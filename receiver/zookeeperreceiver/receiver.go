@@ -0,0 +1,108 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zookeeperreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "tcp"
+
+var _ component.MetricsReceiver = (*zookeeperReceiver)(nil)
+
+// zookeeperReceiver implements the component.MetricsReceiver for ZooKeeper.
+type zookeeperReceiver struct {
+	logger       *zap.Logger
+	nextConsumer consumer.MetricsConsumer
+	config       *Config
+	client       client
+	cancel       context.CancelFunc
+}
+
+// newZookeeperReceiver creates the ZooKeeper receiver with the given parameters.
+func newZookeeperReceiver(
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	r := &zookeeperReceiver{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		config:       config,
+		client: &tcpClient{
+			endpoint: config.Endpoint,
+			timeout:  config.Timeout,
+		},
+	}
+	return r, nil
+}
+
+// Start begins issuing the mntr command at the configured interval.
+func (zr *zookeeperReceiver) Start(ctx context.Context, host component.Host) error {
+	ctx, zr.cancel = context.WithCancel(obsreport.ReceiverContext(context.Background(), typeStr, transport, zr.config.Name()))
+	go func() {
+		ticker := time.NewTicker(zr.config.CollectionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				zr.scrape(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the ZooKeeper receiver.
+func (zr *zookeeperReceiver) Shutdown(context.Context) error {
+	zr.cancel()
+	return nil
+}
+
+// scrape issues the mntr command and sends the resulting metrics to the next consumer.
+func (zr *zookeeperReceiver) scrape(ctx context.Context) {
+	ctx = obsreport.StartMetricsReceiveOp(ctx, typeStr, transport)
+
+	stats, err := zr.client.mntr(ctx)
+	if err != nil {
+		zr.logger.Error("failed to collect metrics from ZooKeeper", zap.String("endpoint", zr.config.Endpoint), zap.Error(err))
+		obsreport.EndMetricsReceiveOp(ctx, typeStr, 0, 0, err)
+		return
+	}
+
+	md, warnings := buildMetricsData(stats, time.Now())
+	if warnings != nil {
+		zr.logger.Warn("errors parsing mntr response", zap.Errors("parsing errors", warnings))
+	}
+
+	err = zr.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{*md}))
+	numTimeSeries, numPoints := obsreport.CountMetricPoints(*md)
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints, numTimeSeries, err)
+}